@@ -16,6 +16,8 @@ import (
 	progress "gopkg.in/cheggaaa/pb.v1"
 	"gopkg.in/src-d/hercules.v10"
 	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/leaves"
 )
 
 // combineCmd represents the combine command
@@ -41,6 +43,18 @@ var combineCmd = &cobra.Command{
 		if err != nil {
 			panic(err)
 		}
+		highPrecision, err := cmd.Flags().GetBool("burndown-high-precision")
+		if err != nil {
+			panic(err)
+		}
+		normalizeIdentities, err := cmd.Flags().GetBool("normalize-identities")
+		if err != nil {
+			panic(err)
+		}
+		facts := map[string]interface{}{
+			leaves.ConfigBurndownHighPrecision:       highPrecision,
+			identity.ConfigIdentityDetectorNormalize: normalizeIdentities,
+		}
 		var repos []string
 		allErrors := map[string][]string{}
 		mergedResults := map[string]interface{}{}
@@ -59,7 +73,8 @@ var combineCmd = &cobra.Command{
 			bar.Increment()
 			anotherResults, anotherMetadata, errs := loadMessage(fileName, &repos)
 			if anotherMetadata != nil {
-				mergeErrs := mergeResults(mergedResults, mergedMetadata, anotherResults, anotherMetadata, only)
+				mergeErrs := mergeResults(
+					mergedResults, mergedMetadata, anotherResults, anotherMetadata, only, facts)
 				for _, err := range mergeErrs {
 					errs = append(errs, err.Error())
 				}
@@ -76,7 +91,7 @@ var combineCmd = &cobra.Command{
 		}
 		mergedMessage := pb.AnalysisResults{
 			Header: &pb.Metadata{
-				Version:    int32(hercules.BinaryVersion),
+				Version:    hercules.SchemaVersion,
 				Hash:       hercules.BinaryGitHash,
 				Repository: strings.Join(repos, " & "),
 			},
@@ -117,14 +132,19 @@ func loadMessage(fileName string, repos *[]string) (
 		errs = append(errs, "Cannot read "+fileName+": "+err.Error())
 		return nil, nil, errs
 	}
+	buffer, err = hercules.DecompressResults(buffer)
+	if err != nil {
+		errs = append(errs, "Cannot decompress "+fileName+": "+err.Error())
+		return nil, nil, errs
+	}
 	message := pb.AnalysisResults{}
 	err = proto.Unmarshal(buffer, &message)
 	if err != nil {
 		errs = append(errs, "Cannot parse "+fileName+": "+err.Error())
 		return nil, nil, errs
 	}
-	if message.Header == nil {
-		errs = append(errs, "Cannot parse "+fileName+": corrupted header")
+	if err = hercules.CheckCompatibility(message.Header); err != nil {
+		errs = append(errs, "Cannot parse "+fileName+": "+err.Error())
 		return nil, nil, errs
 	}
 	*repos = append(*repos, message.Header.Repository)
@@ -172,11 +192,13 @@ func printErrors(allErrors map[string][]string) {
 	}
 }
 
+// mergeResults merges anotherResults into mergedResults. The items which merge the results
+// are configured with the facts beforehand.
 func mergeResults(mergedResults map[string]interface{},
 	mergedCommons *hercules.CommonAnalysisResult,
 	anotherResults map[string]interface{},
 	anotherCommons *hercules.CommonAnalysisResult,
-	only string) []error {
+	only string, facts map[string]interface{}) []error {
 	var errors []error
 	for key, val := range anotherResults {
 		if only != "" && key != only {
@@ -188,6 +210,10 @@ func mergeResults(mergedResults map[string]interface{},
 			continue
 		}
 		item := hercules.Registry.Summon(key)[0].(hercules.ResultMergeablePipelineItem)
+		if err := item.Configure(facts); err != nil {
+			errors = append(errors, fmt.Errorf("could not configure %s: %v", item.Name(), err))
+			continue
+		}
 		mergedResult = item.MergeResults(mergedResult, val, mergedCommons, anotherCommons)
 		if err, isErr := mergedResult.(error); isErr {
 			errors = append(errors, fmt.Errorf("could not merge %s: %v", item.Name(), err))
@@ -216,4 +242,9 @@ func init() {
 	combineCmd.SetUsageFunc(combineCmd.UsageFunc())
 	combineCmd.Flags().String("only", "", "Consider only the specified analysis. "+
 		"Empty means all available. Choices: "+getOptionsString()+".")
+	combineCmd.Flags().Bool("burndown-high-precision", false, "Merge the burndown matrices "+
+		"in float64 and round instead of truncating to reduce the drift when combining many files.")
+	combineCmd.Flags().Bool("normalize-identities", false, "Match the developers after "+
+		"removing \"+suffix\"-es from the emails and collapsing the whitespace in the names. "+
+		"Set it if the analyses were run with --normalize-identities.")
 }
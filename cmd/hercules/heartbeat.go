@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/src-d/hercules.v10"
+)
+
+// heartbeat periodically prints single status lines with the progress, the elapsed time and
+// the ETA. It replaces the progress bar when stderr is not a terminal, e.g. in CI, so that
+// the long runs do not look hung and the logs stay free of the escape sequences.
+type heartbeat struct {
+	writer   io.Writer
+	interval time.Duration
+	now      func() time.Time
+
+	started   bool
+	start     time.Time
+	startStep int
+	last      time.Time
+}
+
+func newHeartbeat(writer io.Writer, interval time.Duration) *heartbeat {
+	return &heartbeat{writer: writer, interval: interval, now: time.Now}
+}
+
+// Update is the Pipeline.OnProgress callback. The first call starts the clock so that
+// the preparations before the analysis do not distort the ETA.
+func (hb *heartbeat) Update(step, steps int, action string) {
+	now := hb.now()
+	if !hb.started {
+		hb.started = true
+		hb.start = now
+		hb.startStep = step
+		hb.last = now
+		return
+	}
+	elapsed := now.Sub(hb.start)
+	if action == hercules.MessageFinalize {
+		fmt.Fprintf(hb.writer, "finalizing, elapsed %s\n", elapsed.Round(time.Second))
+		return
+	}
+	if now.Sub(hb.last) < hb.interval {
+		return
+	}
+	hb.last = now
+	eta := "unknown"
+	if done := step - hb.startStep; done > 0 && steps >= step {
+		eta = (elapsed * time.Duration(steps-step) / time.Duration(done)).Round(time.Second).String()
+	}
+	fmt.Fprintf(hb.writer, "processed %d/%d, elapsed %s, ETA %s\n",
+		step, steps, elapsed.Round(time.Second), eta)
+}
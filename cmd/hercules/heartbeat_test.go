@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v10"
+)
+
+func TestHeartbeat(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	hb := newHeartbeat(buffer, 30*time.Second)
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	hb.now = func() time.Time { return now }
+	hb.Update(0, 100, "commit")
+	assert.Empty(t, buffer.String())
+	now = now.Add(10 * time.Second)
+	hb.Update(5, 100, "commit")
+	assert.Empty(t, buffer.String())
+	now = now.Add(30 * time.Second)
+	hb.Update(20, 100, "commit")
+	assert.Equal(t, "processed 20/100, elapsed 40s, ETA 2m40s\n", buffer.String())
+	buffer.Reset()
+	now = now.Add(10 * time.Second)
+	hb.Update(25, 100, "commit")
+	assert.Empty(t, buffer.String())
+	now = now.Add(50 * time.Minute)
+	hb.Update(100, 100, hercules.MessageFinalize)
+	assert.Equal(t, "finalizing, elapsed 50m50s\n", buffer.String())
+}
+
+func TestHeartbeatUnknownETA(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	hb := newHeartbeat(buffer, time.Second)
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	hb.now = func() time.Time { return now }
+	hb.Update(3, 10, "commit")
+	now = now.Add(time.Minute)
+	hb.Update(3, 10, "fork")
+	assert.Equal(t, "processed 3/10, elapsed 1m0s, ETA unknown\n", buffer.String())
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v10"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// jobFile is the YAML file of --job. It lists the repositories to analyse together with
+// the analyses and the facts, so that the scheduled runs do not depend on the shell scripts.
+//
+//	analyses: [burndown, devs]
+//	facts:
+//	  granularity: 30
+//	  tick-size: 7d
+//	output: results
+//	pb: true
+//	repositories:
+//	  - uri: https://github.com/src-d/hercules
+//	  - uri: https://github.com/src-d/go-git
+//	    name: go-git-couples
+//	    analyses: [couples]
+//	    facts: {Pipeline.TimeSource: author}
+type jobFile struct {
+	// Analyses are the flags of the analyses which run on every repository, e.g. "burndown".
+	Analyses []string `yaml:"analyses"`
+	// Facts are shared by all the repositories. The keys are either the names or the flags of
+	// the configuration options, see --list-analyses. The other keys are passed as is.
+	Facts map[string]interface{} `yaml:"facts"`
+	// Output is the directory to write the results to, relative to the job file.
+	Output string `yaml:"output"`
+	// PB selects the Protocol Buffers output format instead of YAML.
+	PB bool `yaml:"pb"`
+	// Repositories are analysed one by one in the listed order.
+	Repositories []jobRepository `yaml:"repositories"`
+}
+
+// jobRepository is a single repository of jobFile.
+type jobRepository struct {
+	// URI is the same as the positional argument of hercules: a remote URL or a local path.
+	URI string `yaml:"uri"`
+	// Name is the name of the results file without the extension. The default is the last
+	// component of URI.
+	Name string `yaml:"name"`
+	// Analyses replace jobFile.Analyses if not empty.
+	Analyses []string `yaml:"analyses"`
+	// Facts override jobFile.Facts.
+	Facts map[string]interface{} `yaml:"facts"`
+
+	// outputPath is where the results are written.
+	outputPath string
+	// deployed are the names of the deployed leaves.
+	deployed []string
+	// facts are jobFile.Facts and Facts converted to the types of the configuration options.
+	facts map[string]interface{}
+}
+
+// loadJob reads and validates the job file. The analyses, the facts and the output paths of
+// the repositories are resolved here so that the mistakes are reported before the first run.
+func loadJob(path string) (*jobFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	job := &jobFile{}
+	if err = goyaml.UnmarshalStrict(data, job); err != nil {
+		return nil, err
+	}
+	if len(job.Repositories) == 0 {
+		return nil, fmt.Errorf("no repositories")
+	}
+	options := jobConfigurationOptions()
+	leaves := map[string]string{}
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		leaves[leaf.Flag()] = leaf.Name()
+	}
+	sharedFacts, err := convertJobFacts(job.Facts, options)
+	if err != nil {
+		return nil, err
+	}
+	extension := ".yaml"
+	if job.PB {
+		extension = ".pb"
+	}
+	outputDir := filepath.Join(filepath.Dir(path), job.Output)
+	outputPaths := map[string]string{}
+	for i := range job.Repositories {
+		repo := &job.Repositories[i]
+		if repo.URI == "" {
+			return nil, fmt.Errorf("repository #%d: uri is empty", i+1)
+		}
+		analyses := repo.Analyses
+		if len(analyses) == 0 {
+			analyses = job.Analyses
+		}
+		if len(analyses) == 0 {
+			return nil, fmt.Errorf("%s: no analyses", repo.URI)
+		}
+		for _, flag := range analyses {
+			name, exists := leaves[flag]
+			if !exists {
+				return nil, fmt.Errorf("%s: unknown analysis %s", repo.URI, flag)
+			}
+			repo.deployed = append(repo.deployed, name)
+		}
+		repoFacts, err := convertJobFacts(repo.Facts, options)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", repo.URI, err)
+		}
+		repo.facts = map[string]interface{}{}
+		for key, val := range sharedFacts {
+			repo.facts[key] = val
+		}
+		for key, val := range repoFacts {
+			repo.facts[key] = val
+		}
+		name := repo.Name
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(strings.TrimRight(repo.URI, "/")), ".git")
+		}
+		repo.outputPath = filepath.Join(outputDir, name+extension)
+		if other, exists := outputPaths[repo.outputPath]; exists {
+			return nil, fmt.Errorf("%s and %s write to the same %s, set the names",
+				other, repo.URI, repo.outputPath)
+		}
+		outputPaths[repo.outputPath] = repo.URI
+	}
+	return job, nil
+}
+
+// jobConfigurationOptions indexes the configuration options of all the registered items
+// by name and by flag.
+func jobConfigurationOptions() map[string]hercules.ConfigurationOption {
+	var items []hercules.PipelineItem
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		items = append(items, leaf)
+	}
+	items = append(items, hercules.Registry.GetPlumbingItems()...)
+	options := map[string]hercules.ConfigurationOption{}
+	for _, item := range items {
+		for _, opt := range item.ListConfigurationOptions() {
+			options[opt.Name] = opt
+			options[opt.Flag] = opt
+		}
+	}
+	return options
+}
+
+// convertJobFacts converts the facts parsed from YAML to the types which the items expect
+// in Configure(), the same as the command line flags produce. The unknown keys are kept as is.
+func convertJobFacts(facts map[string]interface{}, options map[string]hercules.ConfigurationOption) (
+	map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	for key, val := range facts {
+		opt, exists := options[key]
+		if !exists {
+			result[key] = val
+			continue
+		}
+		converted, err := convertJobFact(opt, val)
+		if err != nil {
+			return nil, fmt.Errorf("fact %s: %v", key, err)
+		}
+		result[opt.Name] = converted
+	}
+	return result, nil
+}
+
+func convertJobFact(opt hercules.ConfigurationOption, val interface{}) (interface{}, error) {
+	switch opt.Type {
+	case hercules.BoolConfigurationOption:
+		if converted, ok := val.(bool); ok {
+			return converted, nil
+		}
+	case hercules.IntConfigurationOption:
+		if converted, ok := val.(int); ok {
+			return converted, nil
+		}
+	case hercules.StringConfigurationOption, hercules.PathConfigurationOption:
+		switch val.(type) {
+		case string, int, float64:
+			return fmt.Sprint(val), nil
+		}
+	case hercules.FloatConfigurationOption:
+		switch converted := val.(type) {
+		case float64:
+			return float32(converted), nil
+		case int:
+			return float32(converted), nil
+		}
+	case hercules.StringsConfigurationOption:
+		switch converted := val.(type) {
+		case string:
+			return strings.Split(converted, ","), nil
+		case []interface{}:
+			strs := make([]string, len(converted))
+			for i, elem := range converted {
+				str, ok := elem.(string)
+				if !ok {
+					return nil, fmt.Errorf("expected a list of strings, got %v", val)
+				}
+				strs[i] = str
+			}
+			return strs, nil
+		}
+	}
+	typeName := opt.Type.String()
+	if typeName == "" {
+		typeName = "bool"
+	}
+	return nil, fmt.Errorf("expected %s, got %v", typeName, val)
+}
+
+// runJob analyses each repository of the job and writes the results to its own file.
+// The failed repositories are reported with reportError() and skipped. It returns the number
+// of failures.
+func runJob(job *jobFile, sshIdentity string, httpToken string, options analysisOptions,
+	errorsJSON bool) int {
+	failures := 0
+	if len(job.Repositories) > 1 {
+		// the results are expected to be combined, so they must share the options
+		options.NoRepoConfig = true
+	}
+	for _, repo := range job.Repositories {
+		stage, err := runJobRepository(repo, job.PB, sshIdentity, httpToken, options)
+		if !options.DisableStatus {
+			fmt.Fprint(os.Stderr, "\033[2K\r")
+		}
+		if err != nil {
+			reportError(os.Stderr, stage, fmt.Errorf("%s: %v", repo.URI, err), errorsJSON)
+			failures++
+		}
+	}
+	return failures
+}
+
+// runJobRepository analyses a single repository of the job. The returned stage tells where
+// the error happened.
+func runJobRepository(repo jobRepository, protobuf bool, sshIdentity string, httpToken string,
+	options analysisOptions) (string, error) {
+	var repository *git.Repository
+	err := catchPanic(func() error {
+		var err error
+		repository, err = loadRepository(repo.URI, "", options.DisableStatus, sshIdentity, httpToken)
+		return err
+	})
+	if err != nil {
+		return errorStageClone, err
+	}
+	facts := map[string]interface{}{}
+	for key, val := range cmdlineFacts {
+		facts[key] = val
+	}
+	explicit := map[string]bool{}
+	for key := range options.ExplicitFacts {
+		explicit[key] = true
+	}
+	for key, val := range repo.facts {
+		facts[key] = val
+		explicit[key] = true
+	}
+	options.Analyses = repo.deployed
+	options.ExplicitFacts = explicit
+	var deployed []hercules.LeafPipelineItem
+	var results map[hercules.LeafPipelineItem]interface{}
+	err = catchPanic(func() error {
+		var err error
+		deployed, results, err = runPipeline(repository, facts, options)
+		return err
+	})
+	if err != nil {
+		return errorStageRun, err
+	}
+	defer closeResults(results)
+	err = catchPanic(func() error {
+		return writeJobResults(repo.outputPath, protobuf, repo.URI, deployed, results)
+	})
+	if err != nil {
+		return errorStageSerialize, err
+	}
+	return "", nil
+}
+
+// writeJobResults writes the results of a single repository to `path` in the same format as
+// the regular output. The missing directories are created.
+func writeJobResults(path string, protobuf bool, uri string,
+	deployed []hercules.LeafPipelineItem, results map[hercules.LeafPipelineItem]interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	sink := newStdoutSink(uri, protobuf, deployed, results[nil].(*hercules.CommonAnalysisResult), writer)
+	err = consumeResults([]hercules.ResultSink{sink}, deployed, results)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/hercules.v10"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func writeJobFile(t *testing.T, dir string, text string) string {
+	path := filepath.Join(dir, "job.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(text), 0644))
+	return path
+}
+
+func TestLoadJob(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "hercules-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempdir)
+	job, err := loadJob(writeJobFile(t, tempdir, `
+analyses: [burndown, devs]
+facts:
+  granularity: 10
+  tick-size: 7d
+  Pipeline.TimeSource: author
+output: results
+pb: true
+repositories:
+  - uri: https://github.com/src-d/hercules
+  - uri: git@github.com:src-d/go-git.git
+    name: go-git-couples
+    analyses: [couples]
+    facts: {granularity: 20, burndown-files: true}
+`))
+	require.NoError(t, err)
+	require.Len(t, job.Repositories, 2)
+	repo := job.Repositories[0]
+	assert.Equal(t, filepath.Join(tempdir, "results", "hercules.pb"), repo.outputPath)
+	assert.Equal(t, []string{"Burndown", "Devs"}, repo.deployed)
+	assert.Equal(t, map[string]interface{}{
+		"Burndown.Granularity":     10,
+		"TicksSinceStart.TickSize": "7d",
+		hercules.ConfigTimeSource:  "author",
+	}, repo.facts)
+	repo = job.Repositories[1]
+	assert.Equal(t, filepath.Join(tempdir, "results", "go-git-couples.pb"), repo.outputPath)
+	assert.Equal(t, []string{"Couples"}, repo.deployed)
+	assert.Equal(t, 20, repo.facts["Burndown.Granularity"])
+	assert.Equal(t, true, repo.facts["Burndown.TrackFiles"])
+	assert.Equal(t, "7d", repo.facts["TicksSinceStart.TickSize"])
+
+	for text, msg := range map[string]string{
+		"analyses: [burndown]":                      "no repositories",
+		"repositories: [{}]":                        "repository #1: uri is empty",
+		"unknown: 1":                                "field unknown not found",
+		"repositories: [{uri: a}]":                  "a: no analyses",
+		"repositories: [{uri: a, analyses: [xxx]}]": "a: unknown analysis xxx",
+		"analyses: [devs]\nfacts: {granularity: abc}\nrepositories: [{uri: a}]":  "fact granularity: expected int, got abc",
+		"analyses: [devs]\nrepositories: [{uri: a, facts: {burndown-files: 1}}]": "a: fact burndown-files: expected bool, got 1",
+		"analyses: [devs]\nrepositories: [{uri: x/a}, {uri: y/a.git}]": "x/a and y/a.git write to the same " + filepath.Join(tempdir, "a.yaml") +
+			", set the names",
+	} {
+		_, err = loadJob(writeJobFile(t, tempdir, text))
+		if assert.Error(t, err, text) {
+			assert.Contains(t, err.Error(), msg)
+		}
+	}
+	_, err = loadJob(filepath.Join(tempdir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestConvertJobFact(t *testing.T) {
+	for _, c := range []struct {
+		Type     hercules.ConfigurationOptionType
+		Value    interface{}
+		Expected interface{}
+	}{
+		{hercules.BoolConfigurationOption, true, true},
+		{hercules.IntConfigurationOption, 7, 7},
+		{hercules.StringConfigurationOption, "7d", "7d"},
+		{hercules.StringConfigurationOption, 24, "24"},
+		{hercules.PathConfigurationOption, "/tmp", "/tmp"},
+		{hercules.FloatConfigurationOption, 0.5, float32(0.5)},
+		{hercules.FloatConfigurationOption, 1, float32(1)},
+		{hercules.StringsConfigurationOption, "a,b", []string{"a", "b"}},
+		{hercules.StringsConfigurationOption, []interface{}{"a", "b"}, []string{"a", "b"}},
+	} {
+		val, err := convertJobFact(hercules.ConfigurationOption{Type: c.Type}, c.Value)
+		assert.NoError(t, err)
+		assert.Equal(t, c.Expected, val)
+	}
+	_, err := convertJobFact(hercules.ConfigurationOption{
+		Type: hercules.StringsConfigurationOption}, []interface{}{1})
+	assert.EqualError(t, err, "expected a list of strings, got [1]")
+	_, err = convertJobFact(hercules.ConfigurationOption{
+		Type: hercules.FloatConfigurationOption}, "x")
+	assert.EqualError(t, err, "expected float, got x")
+}
+
+// writeBareRepository creates the bare repository with the commits at `path`.
+func writeBareRepository(t *testing.T, path string, commits []test.FakeCommit) {
+	memRepo, hashes, err := test.NewMemoryRepository(commits)
+	require.NoError(t, err)
+	bare, err := git.PlainInit(path, true)
+	require.NoError(t, err)
+	objects, err := memRepo.Storer.IterEncodedObjects(plumbing.AnyObject)
+	require.NoError(t, err)
+	require.NoError(t, objects.ForEach(func(obj plumbing.EncodedObject) error {
+		_, err := bare.Storer.SetEncodedObject(obj)
+		return err
+	}))
+	require.NoError(t, bare.Storer.SetReference(
+		plumbing.NewHashReference(plumbing.Master, hashes[len(hashes)-1])))
+}
+
+func TestRunJob(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	tempdir, err := ioutil.TempDir("", "hercules-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempdir)
+	barePath := filepath.Join(tempdir, "repo.git")
+	writeBareRepository(t, barePath, []test.FakeCommit{
+		// ignored because the job has several repositories
+		{Author: "one", When: when, Files: map[string]string{
+			"main.go": "a\n", repoConfigFileName: "granularity: many\n"}},
+		{Author: "two", When: when.Add(time.Hour), Files: map[string]string{"main.go": "a\nb\n"}},
+	})
+
+	job, err := loadJob(writeJobFile(t, tempdir, `
+analyses: [burndown]
+facts: {tick-size: 1h, burndown-total-lines: true}
+output: results
+repositories:
+  - uri: `+barePath+`
+  - uri: /does/not/exist
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 1, runJob(job, "", "", analysisOptions{DisableStatus: true}, true))
+	output, err := ioutil.ReadFile(filepath.Join(tempdir, "results", "repo.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "repository: "+barePath)
+	assert.Contains(t, string(output), "  total_lines: [2, 3]\n")
+	assert.Contains(t, string(output), "tick_size: 3600\n")
+	_, err = os.Stat(filepath.Join(tempdir, "results", "exist.yaml"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// repoConfigFileName is the name of the file in the root of the HEAD tree which sets
+// the default options of the analysed repository, so that the team shares them:
+//
+//	granularity: 30
+//	sampling: 15
+//	tick-size: 7d
+//	track-files: true
+//	track-people: true
+//	excludes: ["vendor/**", "*.pb.go"]
+//
+// The command line flags take precedence. --no-repo-config disables reading the file, e.g.
+// when the analysed repository is not trusted, and so do several repositories, which must
+// share the options.
+const repoConfigFileName = ".hercules.yml"
+
+// repoConfigKeys maps the supported keys of repoConfigFileName to the flags of the
+// corresponding configuration options.
+var repoConfigKeys = map[string]string{
+	"granularity":  "granularity",
+	"sampling":     "sampling",
+	"tick-size":    "tick-size",
+	"track-files":  "burndown-files",
+	"track-people": "burndown-people",
+	"excludes":     "exclude",
+}
+
+// loadRepoConfig reads repoConfigFileName from the HEAD tree and converts it to the facts.
+// It returns nil if the repository does not have HEAD or the file. The unknown keys are
+// ignored with a warning.
+func loadRepoConfig(repository *git.Repository) (map[string]interface{}, error) {
+	head, err := repository.Head()
+	if err != nil {
+		return nil, nil
+	}
+	commit, err := repository.CommitObject(head.Hash())
+	if err != nil {
+		return nil, nil
+	}
+	file, err := commit.File(repoConfigFileName)
+	if err == object.ErrFileNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	contents, err := file.Contents()
+	if err != nil {
+		return nil, err
+	}
+	return parseRepoConfig([]byte(contents))
+}
+
+// parseRepoConfig converts the contents of repoConfigFileName to the facts.
+func parseRepoConfig(data []byte) (map[string]interface{}, error) {
+	config := map[string]interface{}{}
+	if err := goyaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%s: %v", repoConfigFileName, err)
+	}
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	options := jobConfigurationOptions()
+	facts := map[string]interface{}{}
+	for _, key := range keys {
+		flag, exists := repoConfigKeys[key]
+		if !exists {
+			log.Printf("warning: ignored the unknown key %s in %s\n", key, repoConfigFileName)
+			continue
+		}
+		opt := options[flag]
+		val, err := convertJobFact(opt, config[key])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %v", repoConfigFileName, key, err)
+		}
+		facts[opt.Name] = val
+	}
+	return facts, nil
+}
+
+// explicitFacts returns the names of the configuration options which were set on the command line.
+func explicitFacts(flags *pflag.FlagSet) map[string]bool {
+	options := jobConfigurationOptions()
+	explicit := map[string]bool{}
+	flags.Visit(func(flag *pflag.Flag) {
+		if opt, exists := options[flag.Name]; exists {
+			explicit[opt.Name] = true
+		}
+	})
+	return explicit
+}
+
+// applyRepoConfig loads repoConfigFileName from the repository and writes the facts which
+// are not in `explicit` to `facts`. Each overridden option is logged.
+func applyRepoConfig(repository *git.Repository, facts map[string]interface{},
+	explicit map[string]bool) error {
+	repoFacts, err := loadRepoConfig(repository)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(repoConfigKeys))
+	for key := range repoConfigKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	options := jobConfigurationOptions()
+	for _, key := range keys {
+		flag := repoConfigKeys[key]
+		name := options[flag].Name
+		val, exists := repoFacts[name]
+		if !exists || explicit[name] {
+			continue
+		}
+		facts[name] = val
+		log.Printf("%s overrides --%s: %v\n", repoConfigFileName, flag, val)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/hercules.v10"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+	"gopkg.in/src-d/hercules.v10/leaves"
+)
+
+func TestParseRepoConfig(t *testing.T) {
+	facts, err := parseRepoConfig([]byte(`
+granularity: 15
+sampling: 5
+tick-size: 7d
+track-files: true
+track-people: false
+excludes: ["vendor/**", "*.pb.go"]
+colors: dark
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		leaves.ConfigBurndownGranularity:       15,
+		leaves.ConfigBurndownSampling:          5,
+		plumbing.ConfigTicksSinceStartTickSize: "7d",
+		leaves.ConfigBurndownTrackFiles:        true,
+		leaves.ConfigBurndownTrackPeople:       false,
+		plumbing.ConfigTreeDiffExcludeGlobs:    []string{"vendor/**", "*.pb.go"},
+	}, facts)
+	_, err = parseRepoConfig([]byte("granularity: many\n"))
+	assert.Error(t, err)
+	_, err = parseRepoConfig([]byte("- granularity\n"))
+	assert.Error(t, err)
+}
+
+func TestApplyRepoConfig(t *testing.T) {
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: time.Unix(1500000000, 0), Files: map[string]string{
+			"a.go":             "a\n",
+			repoConfigFileName: "granularity: 15\nsampling: 5\n",
+		}},
+	})
+	require.NoError(t, err)
+	facts := map[string]interface{}{
+		leaves.ConfigBurndownGranularity: 30,
+		leaves.ConfigBurndownSampling:    30,
+	}
+	buffer := &bytes.Buffer{}
+	log.SetOutput(buffer)
+	defer log.SetOutput(os.Stderr)
+	require.NoError(t, applyRepoConfig(repository, facts, map[string]bool{
+		leaves.ConfigBurndownSampling: true,
+	}))
+	assert.Equal(t, map[string]interface{}{
+		leaves.ConfigBurndownGranularity: 15,
+		leaves.ConfigBurndownSampling:    30,
+	}, facts)
+	assert.Contains(t, buffer.String(), repoConfigFileName+" overrides --granularity: 15")
+	assert.NotContains(t, buffer.String(), "--sampling")
+
+	repository, _, err = test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: time.Unix(1500000000, 0), Files: map[string]string{"a.go": "a\n"}},
+	})
+	require.NoError(t, err)
+	facts = map[string]interface{}{}
+	require.NoError(t, applyRepoConfig(repository, facts, nil))
+	assert.Empty(t, facts)
+}
+
+func TestExplicitFacts(t *testing.T) {
+	flags := rootCmd.Flags()
+	defer func() {
+		assert.NoError(t, flags.Set("granularity", "30"))
+		flags.Lookup("granularity").Changed = false
+	}()
+	assert.NotContains(t, explicitFacts(flags), leaves.ConfigBurndownGranularity)
+	assert.NoError(t, flags.Set("granularity", "10"))
+	assert.True(t, explicitFacts(flags)[leaves.ConfigBurndownGranularity])
+	assert.False(t, explicitFacts(flags)[hercules.ConfigPipelineSkipMerges])
+}
+
+func TestRunPipelineNoRepoConfig(t *testing.T) {
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: time.Unix(1500000000, 0), Files: map[string]string{
+			"a.go":             "a\n",
+			repoConfigFileName: "granularity: many\n",
+		}},
+	})
+	require.NoError(t, err)
+	_, _, err = runPipeline(repository, map[string]interface{}{},
+		analysisOptions{DisableStatus: true, Analyses: []string{"Burndown"}})
+	assert.Error(t, err)
+	deployed, _, err := runPipeline(repository, map[string]interface{}{},
+		analysisOptions{DisableStatus: true, Analyses: []string{"Burndown"}, NoRepoConfig: true})
+	assert.NoError(t, err)
+	assert.Len(t, deployed, 1)
+}
+
+func TestRunPipelinesIgnoreRepoConfig(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "hercules-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempdir)
+	var uris []string
+	for i, name := range []string{"one.git", "two.git"} {
+		uri := filepath.Join(tempdir, name)
+		writeBareRepository(t, uri, []test.FakeCommit{
+			{Author: "one", When: time.Unix(1500000000+int64(i)*3600, 0), Files: map[string]string{
+				"a.go":             "a\n",
+				repoConfigFileName: "granularity: many\n",
+			}},
+		})
+		uris = append(uris, uri)
+	}
+	options := analysisOptions{DisableStatus: true, Analyses: []string{"Burndown"}}
+	_, _, analysed := runPipelines(uris[:1], "", "", options)
+	assert.Len(t, analysed, 0)
+	deployed, _, analysed := runPipelines(uris, "", "", options)
+	assert.Len(t, deployed, 1)
+	assert.Equal(t, uris, analysed)
+}
+
+func TestMergeRepositoryResults(t *testing.T) {
+	devs := &leaves.DevsAnalysis{}
+	devsResult := func(tickSize time.Duration) interface{} {
+		message, err := proto.Marshal(&pb.DevsAnalysisResults{
+			Ticks: map[int32]*pb.TickDevs{0: {Devs: map[int32]*pb.DevTick{0: {
+				Commits: 1, Stats: &pb.LineStats{Added: 1}}}}},
+			DevIndex: []string{"one|one@srcd"},
+			TickSize: int64(tickSize),
+		})
+		require.NoError(t, err)
+		result, err := devs.Deserialize(message)
+		require.NoError(t, err)
+		return result
+	}
+	commons := func(commits int) *hercules.CommonAnalysisResult {
+		return &hercules.CommonAnalysisResult{
+			BeginTime: 1500000000, EndTime: 1500003600, CommitsNumber: commits,
+			RunTimePerItem: map[string]float64{}}
+	}
+	mergedResults := map[string]interface{}{}
+	mergedCommons := &hercules.CommonAnalysisResult{}
+	assert.Len(t, mergeRepositoryResults(mergedResults, mergedCommons, map[string]interface{}{
+		devs.Name(): devsResult(time.Hour)}, commons(1), nil), 0)
+	assert.Equal(t, 1, mergedCommons.CommitsNumber)
+	merged := mergedResults[devs.Name()]
+	assert.Len(t, mergeRepositoryResults(mergedResults, mergedCommons, map[string]interface{}{
+		devs.Name(): devsResult(24 * time.Hour)}, commons(2), nil), 1)
+	assert.Equal(t, 1, mergedCommons.CommitsNumber)
+	assert.Equal(t, merged, mergedResults[devs.Name()])
+	assert.Len(t, mergeRepositoryResults(mergedResults, mergedCommons, map[string]interface{}{
+		devs.Name(): devsResult(time.Hour)}, commons(4), nil), 0)
+	assert.Equal(t, 5, mergedCommons.CommitsNumber)
+	assert.NotEqual(t, merged, mergedResults[devs.Name()])
+}
@@ -1,20 +1,29 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
+	"net/url"
 	"os"
 	"path/filepath"
 	"plugin"
 	"regexp"
+	"runtime"
 	"runtime/pprof"
+	"sort"
 	"strings"
 	"text/template"
+	"time"
 	"unicode"
 
 	"github.com/Masterminds/sprig"
@@ -28,14 +37,20 @@ import (
 	"gopkg.in/src-d/go-billy.v4/memfs"
 	"gopkg.in/src-d/go-billy.v4/osfs"
 	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/cache"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	gitclient "gopkg.in/src-d/go-git.v4/plumbing/transport/client"
+	githttp "gopkg.in/src-d/go-git.v4/plumbing/transport/http"
 	"gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
 	"gopkg.in/src-d/go-git.v4/storage"
 	"gopkg.in/src-d/go-git.v4/storage/filesystem"
 	"gopkg.in/src-d/go-git.v4/storage/memory"
 	"gopkg.in/src-d/hercules.v10"
 	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+	"gopkg.in/src-d/hercules.v10/leaves"
 )
 
 // oneLineWriter splits the output data by lines and outputs one on top of another using '\r'.
@@ -67,11 +82,197 @@ func loadSSHIdentity(sshIdentity string) (*ssh.PublicKeys, error) {
 	return ssh.NewPublicKeysFromFile("git", actual, "")
 }
 
-func loadRepository(uri string, cachePath string, disableStatus bool, sshIdentity string) *git.Repository {
+// httpTokenEnv is the environment variable with the token to clone the HTTPS remotes.
+type httpTokenEnv struct {
+	name string
+	// host is the only host which receives the token. The empty string means any host.
+	host string
+}
+
+// httpTokenEnvs are the environment variables with the token to clone the HTTPS remotes,
+// in the order of precedence. They are consulted if --http-token is empty. The CI tokens
+// are sent only to their own hosts so that they never leak to the third party remotes.
+var httpTokenEnvs = []httpTokenEnv{
+	{"HERCULES_HTTP_TOKEN", ""},
+	{"GITHUB_TOKEN", "github.com"},
+	{"GITLAB_TOKEN", "gitlab.com"},
+	{"SYSTEM_ACCESSTOKEN", "dev.azure.com"}, // Azure Pipelines
+}
+
+// resolveHTTPToken returns the token if it is not empty, otherwise the value of the first
+// set variable in httpTokenEnvs which applies to the host of the remote `uri`.
+func resolveHTTPToken(token string, uri string) string {
+	if token != "" {
+		return token
+	}
+	host := ""
+	if parsed, err := url.Parse(uri); err == nil {
+		host = strings.ToLower(parsed.Hostname())
+	}
+	for _, env := range httpTokenEnvs {
+		if env.host != "" && env.host != host {
+			continue
+		}
+		if val := os.Getenv(env.name); val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
+// httpTokenEnvsHelp lists httpTokenEnvs for the --http-token help.
+func httpTokenEnvsHelp() string {
+	names := make([]string, len(httpTokenEnvs))
+	for i, env := range httpTokenEnvs {
+		names[i] = "$" + env.name
+		if env.host != "" {
+			names[i] += " (" + env.host + ")"
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// loadHTTPAuth converts the token to the HTTP basic authentication. "user:token" specifies
+// the user name explicitly, which Bitbucket app passwords require. Otherwise the user name is
+// "x-token-auth": GitHub, GitLab and Azure DevOps ignore it and Bitbucket accepts it for
+// the access tokens.
+func loadHTTPAuth(token string) *githttp.BasicAuth {
+	if colon := strings.IndexByte(token, ':'); colon >= 0 {
+		return &githttp.BasicAuth{Username: token[:colon], Password: token[colon+1:]}
+	}
+	return &githttp.BasicAuth{Username: "x-token-auth", Password: token}
+}
+
+// maskHTTPToken replaces the token in the text so that it never appears in the logs.
+func maskHTTPToken(text string, token string) string {
+	if token == "" {
+		return text
+	}
+	return strings.Replace(text, token, "***", -1)
+}
+
+// proxyEnvs are the environment variables with the proxy URL for each scheme of the remotes,
+// in the order of precedence. They are consulted if --proxy is empty.
+var proxyEnvs = map[string][]string{
+	"https": {"HTTPS_PROXY", "https_proxy"},
+	"http":  {"HTTP_PROXY", "http_proxy"},
+}
+
+// noProxyEnvs are the environment variables with the comma-separated hosts which must be
+// reached directly, in the order of precedence.
+var noProxyEnvs = []string{"NO_PROXY", "no_proxy"}
+
+// lookupEnvs returns the value of the first set environment variable.
+func lookupEnvs(envs []string) string {
+	for _, env := range envs {
+		if val := os.Getenv(env); val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
+// parseProxyURL parses the proxy address; the scheme defaults to "http".
+func parseProxyURL(proxy string) (*url.URL, error) {
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	parsed, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %s: %v", proxy, err)
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %s: the host is empty", proxy)
+	}
+	return parsed, nil
+}
+
+// matchNoProxy returns whether the host is listed in noProxy. "*" matches every host and
+// "example.com" and ".example.com" match example.com together with its subdomains.
+func matchNoProxy(host string, noProxy string) bool {
+	host = strings.ToLower(host)
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+		entry = strings.TrimPrefix(entry, ".")
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadHTTPProxy returns the proxy function of the HTTP transport to clone the remotes with.
+// The proxy is the explicit URL if it is not empty, otherwise taken from proxyEnvs by the
+// scheme of the remote. The hosts in $NO_PROXY are reached directly in both cases.
+// The returned function is nil if no proxy is configured.
+func loadHTTPProxy(proxy string) (func(*http.Request) (*url.URL, error), error) {
+	proxies := map[string]*url.URL{}
+	for scheme, envs := range proxyEnvs {
+		value := proxy
+		if value == "" {
+			value = lookupEnvs(envs)
+		}
+		if value == "" {
+			continue
+		}
+		parsed, err := parseProxyURL(value)
+		if err != nil {
+			return nil, err
+		}
+		proxies[scheme] = parsed
+	}
+	if len(proxies) == 0 {
+		return nil, nil
+	}
+	noProxy := lookupEnvs(noProxyEnvs)
+	return func(req *http.Request) (*url.URL, error) {
+		if matchNoProxy(req.URL.Hostname(), noProxy) {
+			return nil, nil
+		}
+		return proxies[req.URL.Scheme], nil
+	}, nil
+}
+
+// installHTTPProxy makes go-git clone the HTTP(S) remotes through the proxy resolved by
+// loadHTTPProxy. The default transport stays untouched if no proxy is configured.
+func installHTTPProxy(proxy string) error {
+	proxyFunc, err := loadHTTPProxy(proxy)
+	if err != nil || proxyFunc == nil {
+		return err
+	}
+	// the same settings as http.DefaultTransport
+	httpClient := &http.Client{Transport: &http.Transport{
+		Proxy: proxyFunc,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}}
+	for scheme := range proxyEnvs {
+		gitclient.InstallProtocol(scheme, githttp.NewClient(httpClient))
+	}
+	return nil
+}
+
+func loadRepository(uri string, cachePath string, disableStatus bool, sshIdentity string,
+	httpToken string) (*git.Repository, error) {
 	var repository *git.Repository
 	var backend storage.Storer
 	var err error
-	if strings.Contains(uri, "://") || regexp.MustCompile("^[A-Za-z]\\w*@[A-Za-z0-9][\\w.]*:").MatchString(uri) {
+	if isRemoteURI(uri) {
 		if cachePath != "" {
 			backend = filesystem.NewStorage(osfs.New(cachePath), cache.NewObjectLRUDefault())
 			_, err = os.Stat(cachePath)
@@ -95,6 +296,12 @@ func loadRepository(uri string, cachePath string, disableStatus bool, sshIdentit
 			}
 			cloneOptions.Auth = auth
 		}
+		if strings.HasPrefix(strings.ToLower(uri), "https://") {
+			httpToken = resolveHTTPToken(httpToken, uri)
+			if httpToken != "" {
+				cloneOptions.Auth = loadHTTPAuth(httpToken)
+			}
+		}
 
 		repository, err = git.Clone(backend, nil, cloneOptions)
 		if !disableStatus {
@@ -106,7 +313,7 @@ func loadRepository(uri string, cachePath string, disableStatus bool, sshIdentit
 		basePath := filepath.Base(uri)
 		fs, err2 := sivafs.NewFilesystem(localFs, basePath, tmpFs)
 		if err2 != nil {
-			log.Panicf("unable to create a siva filesystem from %s: %v", uri, err2)
+			return nil, fmt.Errorf("unable to create a siva filesystem from %s: %v", uri, err2)
 		}
 		sivaStorage := filesystem.NewStorage(fs, cache.NewObjectLRUDefault())
 		repository, err = git.Open(sivaStorage, tmpFs)
@@ -114,12 +321,113 @@ func loadRepository(uri string, cachePath string, disableStatus bool, sshIdentit
 		if uri[len(uri)-1] == os.PathSeparator {
 			uri = uri[:len(uri)-1]
 		}
-		repository, err = git.PlainOpen(uri)
+		if isBareRepository(uri) {
+			// DetectDotGit would climb to the parent directories looking for .git
+			repository, err = git.PlainOpen(uri)
+		} else if worktree, common := findLinkedWorktree(uri); worktree != "" {
+			repository, err = openLinkedWorktree(worktree, common)
+		} else {
+			repository, err = git.PlainOpenWithOptions(uri, &git.PlainOpenOptions{DetectDotGit: true})
+		}
+	}
+	if err != nil {
+		return nil, errors.New(
+			maskHTTPToken(fmt.Sprintf("failed to open %s: %v", uri, err), httpToken))
+	}
+	return repository, nil
+}
+
+// isBareRepository returns whether the directory is a Git repository without a working tree,
+// e.g. a clone made with `git clone --bare` or `git clone --mirror`.
+func isBareRepository(path string) bool {
+	for _, name := range []string{"HEAD", "objects", "refs"} {
+		if _, err := os.Stat(filepath.Join(path, name)); err != nil {
+			return false
+		}
+	}
+	_, err := os.Stat(filepath.Join(path, git.GitDirName))
+	return os.IsNotExist(err)
+}
+
+// findLinkedWorktree returns the root of the linked worktree ("git worktree add") which contains
+// `path` together with the common Git directory of the main repository. Unlike the regular
+// repositories, the .git of a linked worktree is a file which points to a directory with only
+// HEAD and the index, while the objects and the references stay in the main repository.
+// It returns empty strings if `path` does not belong to a linked worktree.
+func findLinkedWorktree(path string) (string, string) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", ""
+	}
+	for {
+		stat, err := os.Stat(filepath.Join(path, git.GitDirName))
+		if err == nil {
+			if stat.IsDir() {
+				return "", ""
+			}
+			break
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return "", ""
+		}
+		path = parent
+	}
+	data, err := ioutil.ReadFile(filepath.Join(path, git.GitDirName))
+	if err != nil {
+		return "", ""
+	}
+	const prefix = "gitdir: "
+	line := strings.TrimSpace(strings.SplitN(string(data), "\n", 2)[0])
+	if !strings.HasPrefix(line, prefix) {
+		return "", ""
+	}
+	gitDir := strings.TrimSpace(line[len(prefix):])
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+	// submodules have .git files, too, but no commondir
+	data, err = ioutil.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return "", ""
+	}
+	common := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(common) {
+		common = filepath.Join(gitDir, common)
+	}
+	return path, common
+}
+
+// worktreeStorage is the storage of the main repository with HEAD of the linked worktree.
+type worktreeStorage struct {
+	*filesystem.Storage
+	head *plumbing.Reference
+}
+
+// Reference returns HEAD of the linked worktree or the reference of the main repository.
+func (s worktreeStorage) Reference(name plumbing.ReferenceName) (*plumbing.Reference, error) {
+	if name == plumbing.HEAD {
+		return s.head, nil
 	}
+	return s.Storage.Reference(name)
+}
+
+// openLinkedWorktree opens the linked worktree at `path` with the objects and the references
+// of the main repository in `common`, see findLinkedWorktree().
+func openLinkedWorktree(path, common string) (*git.Repository, error) {
+	dot, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, err
+	}
+	head, err := dot.Storer.Reference(plumbing.HEAD)
 	if err != nil {
-		log.Panicf("failed to open %s: %v", uri, err)
+		return nil, fmt.Errorf("unable to read HEAD of the worktree %s: %v", path, err)
+	}
+	storage := worktreeStorage{
+		Storage: filesystem.NewStorage(osfs.New(common), cache.NewObjectLRUDefault()),
+		head:    head,
 	}
-	return repository
+	return git.Open(storage, osfs.New(path))
 }
 
 type arrayPluginFlags map[string]bool
@@ -170,9 +478,23 @@ var rootCmd = &cobra.Command{
 	Long: `Hercules is a flexible and fast Git repository analysis engine. The base command executes
 the commit processing pipeline which is automatically generated from the dependencies of one
 or several analysis targets. The list of the available targets is printed in --help. External
-targets can be added using the --plugin system.`,
-	Args: cobra.RangeArgs(1, 2),
+targets can be added using the --plugin system.
+
+Several repositories can be specified at once: each is analysed in a separate pipeline and
+the results are merged together, so all the repositories share the same --tick-size and
+the other options. The repositories which fail are reported and skipped. The second argument
+is the path to cache the cloned repository if the first is a remote URL and the second is not.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if list, err := cmd.Flags().GetBool("list-analyses"); err == nil && list {
+			return cobra.NoArgs(cmd, args)
+		}
+		if job, err := cmd.Flags().GetString("job"); err == nil && job != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
+		startTime := time.Now()
 		flags := cmd.Flags()
 		getBool := func(name string) bool {
 			value, err := flags.GetBool(name)
@@ -188,13 +510,96 @@ targets can be added using the --plugin system.`,
 			}
 			return value
 		}
+		if getBool("list-analyses") {
+			listAnalyses(hercules.Registry.GetLeaves(), os.Stdout)
+			return
+		}
+		errorsJSON := getBool("errors-json")
+		// fail reports the error of the stage and exits
+		var results map[hercules.LeafPipelineItem]interface{}
+		fail := func(stage string, err error) {
+			reportError(os.Stderr, stage, err, errorsJSON)
+			closeResults(results)
+			os.Exit(1)
+		}
+		// guard runs the stage and fails on its error or, with --errors-json, on its panic
+		guard := func(stage string, f func() error) {
+			var err error
+			if errorsJSON {
+				err = catchPanic(f)
+			} else {
+				err = f()
+			}
+			if err != nil {
+				fail(stage, err)
+			}
+		}
 		firstParent := getBool("first-parent")
 		commitsFile := getString("commits")
 		head := getBool("head")
+		singleCommit := getString("single-commit")
+		if singleCommit != "" && (head || commitsFile != "") {
+			fail(errorStageConfigure,
+				errors.New("--single-commit may not be used with --head or --commits"))
+		}
+		maxCommits, err := flags.GetInt("max-commits")
+		if err != nil {
+			panic(err)
+		}
+		if maxCommits < 0 {
+			fail(errorStageConfigure, fmt.Errorf("--max-commits may not be negative: %d", maxCommits))
+		}
+		threads, err := flags.GetInt("threads")
+		if err != nil {
+			panic(err)
+		}
+		if threads < 0 {
+			fail(errorStageConfigure, fmt.Errorf("--threads may not be negative: %d", threads))
+		}
+		setThreads(threads)
 		protobuf := getBool("pb")
+		compress := getBool("gzip")
+		if compress && !protobuf {
+			fail(errorStageConfigure, errors.New("--gzip requires --pb"))
+		}
+		ndjson := getBool("ndjson")
+		if ndjson && (protobuf || !getBool("stream-results")) {
+			fail(errorStageConfigure,
+				errors.New("--ndjson requires --stream-results and may not be used with --pb"))
+		}
+		if getBool("ndjson-progress") && !ndjson {
+			fail(errorStageConfigure, errors.New("--ndjson-progress requires --ndjson"))
+		}
 		profile := getBool("profile")
+		timing := getBool("timing")
+		logJSON := getBool("log-json")
 		disableStatus := getBool("quiet")
+		heartbeatInterval, err := flags.GetDuration("heartbeat")
+		if err != nil {
+			panic(err)
+		}
+		if heartbeatInterval < 0 {
+			fail(errorStageConfigure,
+				fmt.Errorf("--heartbeat may not be negative: %v", heartbeatInterval))
+		}
+		if terminal.IsTerminal(int(os.Stderr.Fd())) {
+			heartbeatInterval = 0
+		} else if heartbeatInterval > 0 {
+			// the heartbeat replaces the rest of the status updates
+			disableStatus = true
+		}
 		sshIdentity := getString("ssh-identity")
+		// the environment tokens are resolved for each remote in loadRepository()
+		httpToken := getString("http-token")
+		if err := installHTTPProxy(getString("proxy")); err != nil {
+			fail(errorStageConfigure, fmt.Errorf("failed to configure the HTTP proxy: %v", err))
+		}
+		outputPath := getString("output")
+		webhook := getString("webhook")
+		sqlitePath := getString("sqlite")
+		if logJSON {
+			cmdlineFacts[hercules.ConfigLogger] = hercules.NewJSONLogger(os.Stderr)
+		}
 
 		if profile {
 			go func() {
@@ -210,105 +615,736 @@ targets can be added using the --plugin system.`,
 			}
 			defer pprof.StopCPUProfile()
 		}
-		uri := args[0]
-		cachePath := ""
-		if len(args) == 2 {
-			cachePath = args[1]
+		options := analysisOptions{
+			CommitsFile:      commitsFile,
+			Head:             head,
+			SingleCommit:     singleCommit,
+			FirstParent:      firstParent,
+			MaxCommits:       maxCommits,
+			DisableStatus:    disableStatus,
+			Heartbeat:        heartbeatInterval,
+			WeightedProgress: getBool("weighted-progress"),
+			ExplicitFacts:    explicitFacts(flags),
+			NoRepoConfig:     getBool("no-repo-config"),
 		}
-		repository := loadRepository(uri, cachePath, disableStatus, sshIdentity)
-
-		// core logic
-		pipeline := hercules.NewPipeline(repository)
-		pipeline.SetFeaturesFromFlags()
-		var bar *progress.ProgressBar
-		if !disableStatus {
-			pipeline.OnProgress = func(commit, length int, action string) {
-				if bar == nil {
-					bar = progress.New(length)
-					bar.Callback = func(msg string) {
-						os.Stderr.WriteString("\033[2K\r" + msg)
+		if jobPath := getString("job"); jobPath != "" {
+			if outputPath != "" || webhook != "" || sqlitePath != "" || compress ||
+				getBool("stream-results") {
+				fail(errorStageConfigure, errors.New("--job may not be used with --output, "+
+					"--webhook, --sqlite, --gzip or --stream-results"))
+			}
+			if commitsFile != "" || singleCommit != "" {
+				fail(errorStageConfigure,
+					errors.New("--job may not be used with --commits or --single-commit"))
+			}
+			job, err := loadJob(jobPath)
+			if err != nil {
+				fail(errorStageConfigure, fmt.Errorf("failed to load the job %s: %v", jobPath, err))
+			}
+			failures := runJob(job, sshIdentity, httpToken, options, errorsJSON)
+			if failures > 0 {
+				if !errorsJSON {
+					log.Printf("failed to analyse %d of the %d repositories",
+						failures, len(job.Repositories))
+				}
+				os.Exit(1)
+			}
+			return
+		}
+		if getBool("validate") {
+			if outputPath != "" || webhook != "" || sqlitePath != "" || protobuf ||
+				getBool("stream-results") {
+				fail(errorStageConfigure, errors.New("--validate may not be used with --output, "+
+					"--webhook, --sqlite, --pb or --stream-results"))
+			}
+			uris, cachePath := parseRepositories(args)
+			if len(uris) > 1 {
+				fail(errorStageConfigure,
+					errors.New("--validate may not be used with several repositories"))
+			}
+			var repository *git.Repository
+			guard(errorStageClone, func() error {
+				var err error
+				repository, err = loadRepository(uris[0], cachePath, disableStatus, sshIdentity, httpToken)
+				return err
+			})
+			var violations []leaves.IntegrityViolation
+			guard(errorStageRun, func() error {
+				var err error
+				violations, err = runValidation(repository, cmdlineFacts, options)
+				return err
+			})
+			if !disableStatus {
+				fmt.Fprint(os.Stderr, "\033[2K\r")
+			}
+			printViolations(os.Stdout, uris[0], violations)
+			if len(violations) > 0 {
+				os.Exit(1)
+			}
+			return
+		}
+		// open the output file before the analysis to fail fast
+		var output io.Writer = os.Stdout
+		var outputFile *os.File
+		var outputBuffer *bufio.Writer
+		if outputPath != "" {
+			var err error
+			outputFile, err = os.Create(outputPath)
+			if err != nil {
+				fail(errorStageSerialize,
+					fmt.Errorf("failed to create the output file %s: %v", outputPath, err))
+			}
+			outputBuffer = bufio.NewWriter(outputFile)
+			output = outputBuffer
+		}
+		var gzipOutput *gzip.Writer
+		if compress {
+			gzipOutput = gzip.NewWriter(output)
+			output = gzipOutput
+		}
+		var sqlite *sqliteSink
+		if sqlitePath != "" {
+			var err error
+			sqlite, err = newSQLiteSink(sqlitePath)
+			if err != nil {
+				fail(errorStageSerialize,
+					fmt.Errorf("failed to create the SQLite database %s: %v", sqlitePath, err))
+			}
+		}
+		uris, cachePath := parseRepositories(args)
+		// the webhook and SQLite replace stdout but not the output file
+		writeOutput := (webhook == "" && sqlite == nil) || outputFile != nil
+		var stream *resultStream
+		if getBool("stream-results") {
+			if len(uris) > 1 {
+				fail(errorStageConfigure,
+					errors.New("--stream-results may not be used with several repositories"))
+			}
+			if !writeOutput {
+				fail(errorStageConfigure, errors.New(
+					"--stream-results requires writing the results to stdout or --output"))
+			}
+			flush := func() error {
+				if gzipOutput != nil {
+					if err := gzipOutput.Flush(); err != nil {
+						return err
+					}
+				}
+				if outputBuffer != nil {
+					return outputBuffer.Flush()
+				}
+				return nil
+			}
+			if ndjson {
+				stream = newNDJSONStream(output, flush)
+			} else {
+				stream = newResultStream(protobuf, output, flush)
+			}
+		}
+		if stream != nil {
+			options.OnResult = func(item hercules.LeafPipelineItem, result interface{}) {
+				if err := stream.WriteResult(item, result); err != nil {
+					fail(errorStageSerialize, fmt.Errorf("failed to write the results: %v", err))
+				}
+			}
+			if getBool("ndjson-progress") {
+				options.OnProgress = func(step, steps int, action string) {
+					if err := stream.WriteProgress(step, steps, action); err != nil {
+						fail(errorStageSerialize, fmt.Errorf("failed to write the progress: %v", err))
 					}
-					bar.NotPrint = true
-					bar.ShowPercent = false
-					bar.ShowSpeed = false
-					bar.SetMaxWidth(80).Start()
 				}
-				if action == hercules.MessageFinalize {
-					bar.Finish()
-					fmt.Fprint(os.Stderr, "\033[2K\rfinalizing...")
-				} else {
-					bar.Set(commit).Postfix(" [" + action + "] ")
+			}
+		}
+		var uri string
+		var deployed []hercules.LeafPipelineItem
+		if len(uris) == 1 {
+			uri = uris[0]
+			var repository *git.Repository
+			guard(errorStageClone, func() error {
+				var err error
+				repository, err = loadRepository(uri, cachePath, disableStatus, sshIdentity, httpToken)
+				return err
+			})
+			guard(errorStageRun, func() error {
+				var err error
+				deployed, results, err = runPipeline(repository, cmdlineFacts, options)
+				return err
+			})
+		} else {
+			if commitsFile != "" {
+				fail(errorStageConfigure,
+					errors.New("--commits may not be used with several repositories"))
+			}
+			if singleCommit != "" {
+				fail(errorStageConfigure,
+					errors.New("--single-commit may not be used with several repositories"))
+			}
+			for name, valPtr := range cmdlineDeployed {
+				if !*valPtr {
+					continue
+				}
+				item := hercules.Registry.Summon(name)[0]
+				if _, ok := item.(hercules.ResultMergeablePipelineItem); !ok {
+					fail(errorStageConfigure, fmt.Errorf("%s does not support merging the results of several repositories",
+						item.Name()))
 				}
 			}
+			var analysed []string
+			deployed, results, analysed = runPipelines(uris, sshIdentity, httpToken, options)
+			if len(analysed) == 0 {
+				fail(errorStageRun, fmt.Errorf("failed to analyse all the %d repositories", len(uris)))
+			}
+			uri = strings.Join(analysed, " & ")
+		}
+		if !disableStatus {
+			fmt.Fprint(os.Stderr, "\033[2K\r")
+			// if not a terminal, the user will not see the output, so show the status
+			if outputFile != nil || !terminal.IsTerminal(int(os.Stdout.Fd())) {
+				fmt.Fprint(os.Stderr, "writing...\r")
+			}
+		}
+		common := results[nil].(*hercules.CommonAnalysisResult)
+		var sinks []hercules.ResultSink
+		if stream != nil {
+			if err := stream.WriteHeader(uri, common); err != nil {
+				fail(errorStageSerialize, fmt.Errorf("failed to write the results: %v", err))
+			}
+		} else if writeOutput {
+			sinks = append(sinks, newStdoutSink(uri, protobuf, deployed, common, output))
+		}
+		if webhook != "" {
+			sinks = append(sinks, newWebhookSink(
+				webhook, getString("webhook-content-type"),
+				resolveWebhookToken(getString("webhook-token")), uri, deployed, common))
 		}
+		if sqlite != nil {
+			if err := sqlite.Start(uri, deployed, common); err != nil {
+				fail(errorStageSerialize,
+					fmt.Errorf("failed to write the results to %s: %v", sqlitePath, err))
+			}
+			sinks = append(sinks, sqlite)
+		}
+		sinks = append(sinks, hercules.Registry.GetSinks()...)
+		guard(errorStageSerialize, func() error {
+			if err := consumeResults(sinks, deployed, results); err != nil {
+				return fmt.Errorf("failed to write the results: %v", err)
+			}
+			return nil
+		})
+		if gzipOutput != nil {
+			if err := gzipOutput.Close(); err != nil {
+				fail(errorStageSerialize, fmt.Errorf("failed to write the results: %v", err))
+			}
+		}
+		if outputFile != nil {
+			err := outputBuffer.Flush()
+			if err == nil {
+				err = outputFile.Close()
+			} else {
+				outputFile.Close()
+			}
+			if err != nil {
+				fail(errorStageSerialize,
+					fmt.Errorf("failed to write the output file %s: %v", outputPath, err))
+			}
+		}
+		closeResults(results)
+		if timing {
+			printTiming(results[nil].(*hercules.CommonAnalysisResult), time.Since(startTime),
+				logJSON, os.Stderr)
+		}
+	},
+}
 
-		var commits []*object.Commit
-		var err error
-		if commitsFile == "" {
-			if !head {
-				fmt.Fprint(os.Stderr, "git log...\r")
-				commits, err = pipeline.Commits(firstParent)
+// listAnalyses prints the flag, the description and the configuration options of each analysis.
+// The analyses are sorted by flag and so are the options of each analysis.
+func listAnalyses(leaves []hercules.LeafPipelineItem, writer io.Writer) {
+	sort.Slice(leaves, func(i, j int) bool {
+		return leaves[i].Flag() < leaves[j].Flag()
+	})
+	for i, leaf := range leaves {
+		if i > 0 {
+			fmt.Fprintln(writer)
+		}
+		fmt.Fprintf(writer, "--%s [%s]\n", leaf.Flag(), leaf.Name())
+		fmt.Fprintf(writer, "    %s\n", leaf.Description())
+		options := leaf.ListConfigurationOptions()
+		sort.SliceStable(options, func(i, j int) bool {
+			return options[i].Flag < options[j].Flag
+		})
+		for _, opt := range options {
+			typeName := opt.Type.String()
+			if opt.Type == hercules.BoolConfigurationOption {
+				typeName = "bool"
+			}
+			fmt.Fprintf(writer, "    --%s %s [%s]", opt.Flag, typeName, opt.Name)
+			if def := opt.FormatDefault(); def != "" {
+				fmt.Fprintf(writer, " (default: %s)", def)
+			}
+			fmt.Fprintln(writer)
+			fmt.Fprintf(writer, "        %s\n", opt.Description)
+		}
+	}
+}
+
+// parseRepositories splits the command line arguments into the repositories to analyse and
+// the path to cache the cloned repository. The legacy form "<remote URL> <cache path>" is the only
+// one with the cache, otherwise all the arguments are repositories.
+func parseRepositories(args []string) ([]string, string) {
+	if len(args) == 2 && isRemoteURI(args[0]) && !isRemoteURI(args[1]) {
+		return args[:1], args[1]
+	}
+	return args, ""
+}
+
+// isRemoteURI returns true if the repository must be cloned.
+func isRemoteURI(uri string) bool {
+	return strings.Contains(uri, "://") ||
+		regexp.MustCompile("^[A-Za-z]\\w*@[A-Za-z0-9][\\w.]*:").MatchString(uri)
+}
+
+// analysisOptions are the command line options which apply to every analysed repository.
+type analysisOptions struct {
+	CommitsFile   string
+	Head          bool
+	SingleCommit  string
+	FirstParent   bool
+	MaxCommits    int
+	DisableStatus bool
+	// Heartbeat is the interval between the status lines which are printed instead of
+	// the progress bar. 0 disables them.
+	Heartbeat time.Duration
+	// WeightedProgress sets Pipeline.WeightedProgress for the progress bar and the heartbeat.
+	WeightedProgress bool
+	// Analyses are the names of the deployed leaves. nil means the analyses enabled
+	// on the command line.
+	Analyses []string
+	// OnResult is called with the result of each analysis as soon as it finalizes.
+	OnResult func(hercules.LeafPipelineItem, interface{})
+	// OnProgress is called with the number of complete steps, the total number of steps and
+	// the current action of the pipeline, in addition to the status updates on stderr.
+	OnProgress func(step, steps int, action string)
+	// ExplicitFacts are the names of the configuration options which were set explicitly,
+	// so that repoConfigFileName does not override them.
+	ExplicitFacts map[string]bool
+	// NoRepoConfig disables reading repoConfigFileName from the analysed repository.
+	NoRepoConfig bool
+}
+
+// runPipeline deploys the analyses requested on the command line, runs them over the repository
+// and returns the deployed items together with the results.
+func runPipeline(repository *git.Repository, facts map[string]interface{}, options analysisOptions) (
+	[]hercules.LeafPipelineItem, map[hercules.LeafPipelineItem]interface{}, error) {
+	if !options.NoRepoConfig {
+		if err := applyRepoConfig(repository, facts, options.ExplicitFacts); err != nil {
+			return nil, nil, err
+		}
+	}
+	pipeline := hercules.NewPipeline(repository)
+	pipeline.SetFeaturesFromFlags()
+	pipeline.OnResult = options.OnResult
+	var bar *progress.ProgressBar
+	if !options.DisableStatus {
+		pipeline.OnProgress = func(commit, length int, action string) {
+			if bar == nil {
+				bar = progress.New(length)
+				bar.Callback = func(msg string) {
+					os.Stderr.WriteString("\033[2K\r" + msg)
+				}
+				bar.NotPrint = true
+				bar.ShowPercent = false
+				bar.ShowSpeed = false
+				bar.SetMaxWidth(80).Start()
+			}
+			if action == hercules.MessageFinalize {
+				bar.Finish()
+				fmt.Fprint(os.Stderr, "\033[2K\rfinalizing...")
 			} else {
-				commits, err = pipeline.HeadCommit()
+				bar.Set(commit).Postfix(" [" + action + "] ")
+			}
+		}
+		pipeline.WeightedProgress = options.WeightedProgress
+	} else if options.Heartbeat > 0 {
+		pipeline.OnProgress = newHeartbeat(os.Stderr, options.Heartbeat).Update
+		pipeline.WeightedProgress = options.WeightedProgress
+	}
+	if options.OnProgress != nil {
+		if showStatus := pipeline.OnProgress; showStatus != nil {
+			pipeline.OnProgress = func(step, steps int, action string) {
+				showStatus(step, steps, action)
+				options.OnProgress(step, steps, action)
 			}
 		} else {
-			commits, err = hercules.LoadCommitsFromFile(commitsFile, repository)
+			pipeline.OnProgress = options.OnProgress
 		}
-		if err != nil {
-			log.Fatalf("failed to list the commits: %v", err)
+	}
+
+	var commits []*object.Commit
+	var err error
+	if options.SingleCommit != "" {
+		commits, err = pipeline.SingleCommit(options.SingleCommit)
+	} else if options.CommitsFile == "" {
+		if !options.Head {
+			if !options.DisableStatus {
+				fmt.Fprint(os.Stderr, "git log...\r")
+			}
+			commits, err = pipeline.Commits(options.FirstParent)
+		} else {
+			commits, err = pipeline.HeadCommit()
 		}
-		cmdlineFacts[hercules.ConfigPipelineCommits] = commits
-		dryRun, _ := cmdlineFacts[hercules.ConfigPipelineDryRun].(bool)
-		var deployed []hercules.LeafPipelineItem
+	} else {
+		commits, err = hercules.LoadCommitsFromFile(options.CommitsFile, repository)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list the commits: %v", err)
+	}
+	if options.MaxCommits > 0 {
+		commits = truncateCommits(commits, options.MaxCommits)
+	}
+	facts[hercules.ConfigPipelineCommits] = commits
+	dryRun, _ := facts[hercules.ConfigPipelineDryRun].(bool)
+	analyses := options.Analyses
+	if analyses == nil {
 		for name, valPtr := range cmdlineDeployed {
 			if *valPtr {
-				item := pipeline.DeployItem(hercules.Registry.Summon(name)[0])
-				if !dryRun {
-					deployed = append(deployed, item.(hercules.LeafPipelineItem))
-				}
+				analyses = append(analyses, name)
 			}
 		}
-		err = pipeline.Initialize(cmdlineFacts)
+	}
+	var deployed []hercules.LeafPipelineItem
+	for _, name := range analyses {
+		item := hercules.Registry.Summon(name)[0]
+		if dci, ok := item.(hercules.DependencyConfigurablePipelineItem); ok {
+			err = dci.ConfigureDependencies(facts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s failed to configure: %v", item.Name(), err)
+			}
+		}
+		item = pipeline.DeployItem(item)
+		if !dryRun {
+			deployed = append(deployed, item.(hercules.LeafPipelineItem))
+		}
+	}
+	err = pipeline.Initialize(facts)
+	if err != nil {
+		return nil, nil, err
+	}
+	results, err := pipeline.Run(nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to run the pipeline: %v", err)
+	}
+	return deployed, results, nil
+}
+
+// truncateCommits returns at most `maxCommits` most recent commits which form the first-parent
+// chain ending at the tip of `commits`, from the oldest to the newest. The tip is the last commit
+// which is not a parent of any other, that is, HEAD regardless of the order of `commits`.
+// The chain is connected, so the earliest retained commit's tree becomes the baseline for the analyses.
+func truncateCommits(commits []*object.Commit, maxCommits int) []*object.Commit {
+	if len(commits) <= maxCommits {
+		return commits
+	}
+	index := map[plumbing.Hash]*object.Commit{}
+	parents := map[plumbing.Hash]bool{}
+	for _, commit := range commits {
+		index[commit.Hash] = commit
+		for _, parent := range commit.ParentHashes {
+			parents[parent] = true
+		}
+	}
+	var tip *object.Commit
+	for _, commit := range commits {
+		if !parents[commit.Hash] {
+			tip = commit
+		}
+	}
+	chain := make([]*object.Commit, 0, maxCommits)
+	for commit := tip; commit != nil && len(chain) < maxCommits; {
+		chain = append(chain, commit)
+		if len(commit.ParentHashes) == 0 {
+			break
+		}
+		commit = index[commit.ParentHashes[0]]
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
+
+// setThreads limits the number of OS threads which execute Go code simultaneously to `threads`,
+// 0 means all the cores. The internal worker pools size themselves by runtime.GOMAXPROCS(0),
+// so they follow. Returns the effective number of threads.
+func setThreads(threads int) int {
+	if threads == 0 {
+		threads = runtime.NumCPU()
+	}
+	runtime.GOMAXPROCS(threads)
+	return threads
+}
+
+// runPipelines analyses each repository in a separate pipeline and merges the results with
+// ResultMergeablePipelineItem.MergeResults(). The failed repositories are reported to stderr
+// and skipped. The merged results are returned together with the deployed items of the first
+// successful pipeline and the list of the successfully analysed repositories.
+func runPipelines(uris []string, sshIdentity string, httpToken string, options analysisOptions) (
+	[]hercules.LeafPipelineItem, map[hercules.LeafPipelineItem]interface{}, []string) {
+	var deployed []hercules.LeafPipelineItem
+	var analysed []string
+	mergedResults := map[string]interface{}{}
+	mergedCommons := &hercules.CommonAnalysisResult{}
+	allErrors := map[string][]string{}
+	// only pass the options which affect merging, the rest are meant for the pipelines
+	mergeFacts := map[string]interface{}{}
+	if val, exists := cmdlineFacts[leaves.ConfigBurndownHighPrecision]; exists {
+		mergeFacts[leaves.ConfigBurndownHighPrecision] = val
+	}
+	if val, exists := cmdlineFacts[identity.ConfigIdentityDetectorNormalize]; exists {
+		mergeFacts[identity.ConfigIdentityDetectorNormalize] = val
+	}
+	if len(uris) > 1 {
+		// the merged results require the same tick size, granularity, etc.
+		options.NoRepoConfig = true
+	}
+	for _, uri := range uris {
+		items, results, err := analyseRepository(uri, sshIdentity, httpToken, options)
 		if err != nil {
-			log.Fatal(err)
+			allErrors[uri] = []string{err.Error()}
+			continue
+		}
+		itemResults := map[string]interface{}{}
+		for _, item := range items {
+			itemResults[item.Name()] = results[item]
+		}
+		errs := mergeRepositoryResults(mergedResults, mergedCommons, itemResults,
+			results[nil].(*hercules.CommonAnalysisResult), mergeFacts)
+		if len(errs) > 0 {
+			for _, err := range errs {
+				allErrors[uri] = append(allErrors[uri], err.Error())
+			}
+			continue
 		}
-		results, err := pipeline.Run(commits)
+		if deployed == nil {
+			deployed = items
+		}
+		analysed = append(analysed, uri)
+	}
+	if !options.DisableStatus {
+		fmt.Fprint(os.Stderr, "\033[2K\r")
+	}
+	printErrors(allErrors)
+	results := map[hercules.LeafPipelineItem]interface{}{nil: mergedCommons}
+	for _, item := range deployed {
+		results[item] = mergedResults[item.Name()]
+	}
+	return deployed, results, analysed
+}
+
+// mergeRepositoryResults merges the results of a single repository with mergeResults() unless
+// any of them fails to merge, in which case mergedResults and mergedCommons do not change
+// and the repository is skipped.
+func mergeRepositoryResults(mergedResults map[string]interface{},
+	mergedCommons *hercules.CommonAnalysisResult, results map[string]interface{},
+	commons *hercules.CommonAnalysisResult, facts map[string]interface{}) []error {
+	candidateResults := make(map[string]interface{}, len(mergedResults))
+	for key, val := range mergedResults {
+		candidateResults[key] = val
+	}
+	candidateCommons := mergedCommons.Copy()
+	if errs := mergeResults(
+		candidateResults, &candidateCommons, results, commons, "", facts); len(errs) > 0 {
+		return errs
+	}
+	for key, val := range candidateResults {
+		mergedResults[key] = val
+	}
+	*mergedCommons = candidateCommons
+	return nil
+}
+
+// analyseRepository loads the repository and runs a new pipeline over it. Unlike in the single
+// repository mode, the failures are returned instead of terminating the process.
+func analyseRepository(uri string, sshIdentity string, httpToken string,
+	options analysisOptions) (
+	deployed []hercules.LeafPipelineItem, results map[hercules.LeafPipelineItem]interface{},
+	err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	repository, err := loadRepository(uri, "", options.DisableStatus, sshIdentity, httpToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	// each pipeline writes its own facts, e.g. the identities
+	facts := map[string]interface{}{}
+	for key, val := range cmdlineFacts {
+		facts[key] = val
+	}
+	return runPipeline(repository, facts, options)
+}
+
+// printTiming writes the time elapsed by each pipeline item, the pipeline run time and
+// the total wall time in seconds. The items are sorted by the elapsed time in descending order.
+func printTiming(commonResult *hercules.CommonAnalysisResult, wallTime time.Duration,
+	asJSON bool, writer io.Writer) {
+	type itemTiming struct {
+		Name string  `json:"name"`
+		Time float64 `json:"time"`
+	}
+	items := make([]itemTiming, 0, len(commonResult.RunTimePerItem))
+	for name, seconds := range commonResult.RunTimePerItem {
+		items = append(items, itemTiming{Name: name, Time: seconds})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Time != items[j].Time {
+			return items[i].Time > items[j].Time
+		}
+		return items[i].Name < items[j].Name
+	})
+	if asJSON {
+		err := json.NewEncoder(writer).Encode(struct {
+			WallTime float64      `json:"wall_time"`
+			RunTime  float64      `json:"run_time"`
+			Items    []itemTiming `json:"items"`
+		}{wallTime.Seconds(), commonResult.RunTime.Seconds(), items})
 		if err != nil {
-			log.Fatalf("failed to run the pipeline: %v", err)
+			panic(err)
 		}
-		if !disableStatus {
-			fmt.Fprint(os.Stderr, "\033[2K\r")
-			// if not a terminal, the user will not see the output, so show the status
-			if !terminal.IsTerminal(int(os.Stdout.Fd())) {
-				fmt.Fprint(os.Stderr, "writing...\r")
+		return
+	}
+	fmt.Fprintln(writer, "timing:")
+	fmt.Fprintf(writer, "  wall_time: %.3f\n", wallTime.Seconds())
+	fmt.Fprintf(writer, "  run_time: %.3f\n", commonResult.RunTime.Seconds())
+	fmt.Fprintln(writer, "  items:")
+	for _, item := range items {
+		fmt.Fprintf(writer, "  - %s: %.3f\n", yaml.SafeString(item.Name), item.Time)
+	}
+}
+
+const (
+	// errorStageConfigure is the stage of --errors-json which validates the command line.
+	errorStageConfigure = "configure"
+	// errorStageClone is the stage of --errors-json which loads or clones the repository.
+	errorStageClone = "clone"
+	// errorStageRun is the stage of --errors-json which runs the analysis.
+	errorStageRun = "run"
+	// errorStageSerialize is the stage of --errors-json which writes the results.
+	errorStageSerialize = "serialize"
+)
+
+// reportError writes the error which happened at `stage` to `writer`: as a JSON object
+// {"error": ..., "stage": ...} on a single line if asJSON is true and as a log line otherwise.
+func reportError(writer io.Writer, stage string, err error, asJSON bool) {
+	if !asJSON {
+		log.New(writer, "", log.LstdFlags).Println(err)
+		return
+	}
+	report := struct {
+		Error string `json:"error"`
+		Stage string `json:"stage"`
+	}{err.Error(), stage}
+	if err := json.NewEncoder(writer).Encode(report); err != nil {
+		panic(err)
+	}
+}
+
+// catchPanic calls f and converts its panic to an error.
+func catchPanic(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return f()
+}
+
+// stdoutSink is the default ResultSink which writes YAML or Protocol Buffers, see printResults()
+// and protobufResults(). The results are written in Close() since both formats start with
+// the common header.
+type stdoutSink struct {
+	uri      string
+	protobuf bool
+	deployed []hercules.LeafPipelineItem
+	results  map[hercules.LeafPipelineItem]interface{}
+	writer   io.Writer
+}
+
+func newStdoutSink(uri string, protobuf bool, deployed []hercules.LeafPipelineItem,
+	common *hercules.CommonAnalysisResult, writer io.Writer) *stdoutSink {
+	return &stdoutSink{
+		uri:      uri,
+		protobuf: protobuf,
+		deployed: deployed,
+		// the header is written even if nothing was deployed
+		results: map[hercules.LeafPipelineItem]interface{}{nil: common},
+		writer:  writer,
+	}
+}
+
+// Consume remembers the result of the deployed item named `itemName`.
+func (sink *stdoutSink) Consume(
+	itemName string, result interface{}, common *hercules.CommonAnalysisResult) error {
+	for _, item := range sink.deployed {
+		if item.Name() == itemName {
+			sink.results[item] = result
+			return nil
+		}
+	}
+	return fmt.Errorf("%s was not deployed", itemName)
+}
+
+// Close writes the consumed results.
+func (sink *stdoutSink) Close() error {
+	if !sink.protobuf {
+		printResults(sink.uri, sink.deployed, sink.results, sink.writer)
+	} else {
+		protobufResults(sink.uri, sink.deployed, sink.results, sink.writer)
+	}
+	return nil
+}
+
+// consumeResults passes the result of each deployed item to each sink. The sinks which implement
+// io.Closer are closed afterwards.
+func consumeResults(sinks []hercules.ResultSink, deployed []hercules.LeafPipelineItem,
+	results map[hercules.LeafPipelineItem]interface{}) error {
+	common := results[nil].(*hercules.CommonAnalysisResult)
+	for _, sink := range sinks {
+		for _, item := range deployed {
+			if err := sink.Consume(item.Name(), results[item], common); err != nil {
+				return err
 			}
 		}
-		if !protobuf {
-			printResults(uri, deployed, results)
-		} else {
-			protobufResults(uri, deployed, results)
+		if closer, ok := sink.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				return err
+			}
 		}
-	},
+	}
+	return nil
+}
+
+// closeResults releases the resources held by the results, e.g. the temporary files. It must be
+// called after all the sinks have consumed the results.
+func closeResults(results map[hercules.LeafPipelineItem]interface{}) {
+	for item, result := range results {
+		if closer, ok := result.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Printf("failed to release the result of %s: %v", item.Name(), err)
+			}
+		}
+	}
 }
 
 func printResults(
 	uri string, deployed []hercules.LeafPipelineItem,
-	results map[hercules.LeafPipelineItem]interface{}) {
-	commonResult := results[nil].(*hercules.CommonAnalysisResult)
-
-	fmt.Println("hercules:")
-	fmt.Printf("  version: %d\n", hercules.BinaryVersion)
-	fmt.Println("  hash:", hercules.BinaryGitHash)
-	fmt.Println("  repository:", uri)
-	fmt.Println("  begin_unix_time:", commonResult.BeginTime)
-	fmt.Println("  end_unix_time:", commonResult.EndTime)
-	fmt.Println("  commits:", commonResult.CommitsNumber)
-	fmt.Println("  run_time:", commonResult.RunTime.Nanoseconds()/1e6)
-
+	results map[hercules.LeafPipelineItem]interface{}, writer io.Writer) {
+	printHeader(uri, results[nil].(*hercules.CommonAnalysisResult), writer)
 	for _, item := range deployed {
 		result := results[item]
-		fmt.Printf("%s:\n", item.Name())
-		if err := item.Serialize(result, false, os.Stdout); err != nil {
+		fmt.Fprintf(writer, "%s:\n", item.Name())
+		if err := item.Serialize(result, false, writer); err != nil {
 			panic(err)
 		}
 	}
@@ -316,17 +1352,9 @@ func printResults(
 
 func protobufResults(
 	uri string, deployed []hercules.LeafPipelineItem,
-	results map[hercules.LeafPipelineItem]interface{}) {
-
-	header := pb.Metadata{
-		Version:    2,
-		Hash:       hercules.BinaryGitHash,
-		Repository: uri,
-	}
-	results[nil].(*hercules.CommonAnalysisResult).FillMetadata(&header)
-
+	results map[hercules.LeafPipelineItem]interface{}, writer io.Writer) {
 	message := pb.AnalysisResults{
-		Header:   &header,
+		Header:   newMetadata(uri, results[nil].(*hercules.CommonAnalysisResult)),
 		Contents: map[string][]byte{},
 	}
 
@@ -343,7 +1371,48 @@ func protobufResults(
 	if err != nil {
 		panic(err)
 	}
-	os.Stdout.Write(serialized)
+	if _, err = writer.Write(serialized); err != nil {
+		panic(err)
+	}
+}
+
+// printHeader writes the common YAML header of the results.
+func printHeader(uri string, commonResult *hercules.CommonAnalysisResult, writer io.Writer) {
+	fmt.Fprintln(writer, "hercules:")
+	fmt.Fprintf(writer, "  version: %d\n", hercules.BinaryVersion)
+	fmt.Fprintln(writer, "  hash:", hercules.BinaryGitHash)
+	fmt.Fprintln(writer, "  repository:", uri)
+	fmt.Fprintln(writer, "  begin_unix_time:", commonResult.BeginTime)
+	fmt.Fprintln(writer, "  end_unix_time:", commonResult.EndTime)
+	fmt.Fprintln(writer, "  commits:", commonResult.CommitsNumber)
+	fmt.Fprintln(writer, "  skewed_commits:", commonResult.SkewedCommits)
+	if commonResult.FailedCommits > 0 {
+		fmt.Fprintln(writer, "  failed_commits:", commonResult.FailedCommits)
+	}
+	fmt.Fprintln(writer, "  run_time:", commonResult.RunTime.Nanoseconds()/1e6)
+	if len(commonResult.Config) > 0 {
+		keys := make([]string, 0, len(commonResult.Config))
+		for key := range commonResult.Config {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Fprintln(writer, "  config:")
+		for _, key := range keys {
+			fmt.Fprintf(writer, "    %s: %s\n",
+				yaml.SafeString(key), yaml.SafeString(commonResult.Config[key]))
+		}
+	}
+}
+
+// newMetadata creates the common Protocol Buffers header of the results.
+func newMetadata(uri string, commonResult *hercules.CommonAnalysisResult) *pb.Metadata {
+	header := &pb.Metadata{
+		Version:    hercules.SchemaVersion,
+		Hash:       hercules.BinaryGitHash,
+		Repository: uri,
+	}
+	commonResult.FillMetadata(header)
+	return header
 }
 
 // trimRightSpace removes the trailing whitespace characters.
@@ -487,25 +1556,90 @@ func init() {
 	rootFlags.String("commits", "", "Path to the text file with the "+
 		"commit history to follow instead of the default 'git log'. "+
 		"The format is the list of hashes, each hash on a "+
-		"separate line. The first hash is the root.")
+		"separate line. The first hash is the root. \"-\" reads the standard input. "+
+		"The packed binary format is detected automatically: \""+hercules.PackedCommitsMagic+
+		"\", the big-endian uint32 number of hashes and the raw 20-byte hashes.")
 	err := rootCmd.MarkFlagFilename("commits")
 	if err != nil {
 		panic(err)
 	}
 	hercules.PathifyFlagValue(rootFlags.Lookup("commits"))
+	rootFlags.String("job", "", "Path to the YAML file which lists the repositories together with "+
+		"their analyses, facts and output paths. Each repository is analysed separately and "+
+		"the results are written to a file per repository instead of stdout.")
+	rootFlags.Bool("no-repo-config", false, "Do not read the default options from "+
+		repoConfigFileName+" in the HEAD tree of the analysed repository, e.g. if it is not "+
+		"trusted.")
 	rootFlags.Bool("head", false, "Analyze only the latest commit.")
+	rootFlags.String("single-commit", "", "Analyze only the changes of the specified commit "+
+		"against its first parent, which is treated as the initial state.")
 	rootFlags.Bool("first-parent", false, "Follow only the first parent in the commit history - "+
 		"\"git log --first-parent\".")
+	rootFlags.Int("max-commits", 0, "Analyze only the specified number of the most recent commits "+
+		"along the first parents of HEAD; the earliest of them is treated as the initial state. "+
+		"0 means no limit.")
+	rootFlags.Int("threads", 0, "Number of threads to run the analysis on, including the "+
+		"internal worker pools such as the matrix merges and the blob line counting. "+
+		"0 means all the cores.")
+	rootFlags.Bool("validate", false, "Check the internal integrity of the line-based analyses "+
+		"(the burndown if none is enabled) and print the violations - commit, file, expected "+
+		"and actual size - instead of the results. The exit code is 1 if there are any.")
 	rootFlags.Bool("pb", false, "The output format will be Protocol Buffers instead of YAML.")
+	rootFlags.Bool("gzip", false, "Compress the Protocol Buffers output with gzip; requires --pb. "+
+		"Name the files *.pb.gz, \"hercules combine\" and labours decompress them automatically.")
+	rootFlags.Bool("stream-results", false, "Write the result of each analysis as soon as it "+
+		"finalizes instead of all the results in the end: a stream of YAML documents or of "+
+		"varint length-prefixed Protocol Buffers messages; the common header goes last.")
+	rootFlags.Bool("ndjson", false, "Stream the results as newline-delimited JSON events "+
+		"{\"type\", \"analysis\", \"payload\"} instead of YAML; requires --stream-results. "+
+		"The payload is the same as in the YAML output.")
+	rootFlags.Bool("ndjson-progress", false, "Also write the \"progress\" events with the "+
+		"pipeline steps to the --ndjson stream.")
+	rootFlags.StringP("output", "o", "", "Path to the file to write the results to "+
+		"instead of stdout.")
+	err = rootCmd.MarkFlagFilename("output")
+	if err != nil {
+		panic(err)
+	}
+	hercules.PathifyFlagValue(rootFlags.Lookup("output"))
 	rootFlags.Bool("quiet", !terminal.IsTerminal(int(os.Stdin.Fd())),
 		"Do not print status updates to stderr.")
+	rootFlags.Duration("heartbeat", 0, "If stderr is not a terminal, e.g. in CI, print a status "+
+		"line with the progress, the elapsed time and the ETA this often instead of the progress "+
+		"bar, even with --quiet. 0 disables.")
+	rootFlags.Bool("weighted-progress", false, "Weigh each commit by the number of the changed "+
+		"files in the progress bar and the heartbeat so that the ETA is steadier. The commits "+
+		"are diffed before the analysis, which takes an extra pass over the history.")
 	rootFlags.Bool("profile", false, "Collect the profile to hercules.pprof.")
+	rootFlags.Bool("log-json", false, "Write the log messages to stderr as JSON lines.")
+	rootFlags.Bool("errors-json", false, "Report the failures to stderr as JSON lines "+
+		"{\"error\": ..., \"stage\": \""+errorStageConfigure+"|"+errorStageClone+"|"+
+		errorStageRun+"|"+errorStageSerialize+"\"} instead of the log messages and the stack traces.")
+	rootFlags.Bool("timing", false, "Print the time elapsed by each pipeline item to stderr "+
+		"after the analysis; the format is JSON if --log-json is set and YAML otherwise.")
+	rootFlags.Bool("list-analyses", false, "Print the available analyses and their options, "+
+		"including the plugins, and exit.")
 	rootFlags.String("ssh-identity", "", "Path to SSH identity file (e.g., ~/.ssh/id_rsa) to clone from an SSH remote.")
 	err = rootCmd.MarkFlagFilename("ssh-identity")
 	if err != nil {
 		panic(err)
 	}
 	hercules.PathifyFlagValue(rootFlags.Lookup("ssh-identity"))
+	rootFlags.String("http-token", "", "Token to clone from an HTTPS remote, optionally "+
+		"prefixed with \"user:\". It is sent to any host. The default is taken from "+
+		httpTokenEnvsHelp()+"; the host in parentheses is the only one which receives that token.")
+	rootFlags.String("proxy", "", "URL of the proxy to clone the HTTP(S) remotes through. "+
+		"The default is taken from $HTTPS_PROXY and $HTTP_PROXY; the hosts in $NO_PROXY "+
+		"are reached directly.")
+	rootFlags.String("webhook", "", "URL to POST the results to in Protocol Buffers format "+
+		"after the analysis instead of writing them to stdout. The failures are reported to "+
+		"stderr and retried if transient.")
+	rootFlags.String("webhook-content-type", "application/x-protobuf",
+		"Content-Type header of the --webhook request.")
+	rootFlags.String("webhook-token", "", "Bearer token of the --webhook request. "+
+		"The default is taken from $"+webhookTokenEnv+".")
+	rootFlags.String("sqlite", "", "Path to the SQLite database to write the results to "+
+		"instead of stdout. The existing file is overwritten.")
 	cmdlineFacts, cmdlineDeployed = hercules.Registry.AddFlags(rootFlags)
 	rootCmd.SetUsageFunc(formatUsage)
 	rootCmd.AddCommand(versionCmd)
@@ -1,22 +1,42 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/src-d/go-billy.v4/osfs"
 	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/cache"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	gitclient "gopkg.in/src-d/go-git.v4/plumbing/transport/client"
 	"gopkg.in/src-d/go-git.v4/storage/filesystem"
+	"gopkg.in/src-d/hercules.v10"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+	"gopkg.in/src-d/hercules.v10/leaves"
 )
 
 func TestLoadRepository(t *testing.T) {
-	repo := loadRepository("https://github.com/src-d/hercules", "", true, "")
+	repo, err := loadRepository("https://github.com/src-d/hercules", "", true, "", "")
+	assert.NoError(t, err)
 	assert.NotNil(t, repo)
 	log.Println("TestLoadRepository: 1/3")
 
@@ -34,17 +54,633 @@ func TestLoadRepository(t *testing.T) {
 		assert.FailNow(t, "filesystem.NewStorage")
 	}
 
-	repo = loadRepository(tempdir, "", true, "")
+	repo, err = loadRepository(tempdir, "", true, "", "")
+	assert.NoError(t, err)
 	assert.NotNil(t, repo)
 	log.Println("TestLoadRepository: 2/3")
 
 	_, filename, _, _ := runtime.Caller(0)
 	sivafile := filepath.Join(filepath.Dir(filename), "test_data", "hercules.siva")
-	repo = loadRepository(sivafile, "", true, "")
+	repo, err = loadRepository(sivafile, "", true, "", "")
+	assert.NoError(t, err)
 	assert.NotNil(t, repo)
 	log.Println("TestLoadRepository: 3/3")
 
-	assert.Panics(t, func() { loadRepository("https://github.com/src-d/porn", "", true, "") })
-	assert.Panics(t, func() { loadRepository(filepath.Dir(filename), "", true, "") })
-	assert.Panics(t, func() { loadRepository("/xxx", "", true, "") })
+	_, err = loadRepository("https://github.com/src-d/porn", "", true, "", "")
+	assert.Error(t, err)
+	emptydir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(emptydir)
+	_, err = loadRepository(emptydir, "", true, "", "")
+	assert.Error(t, err)
+	_, err = loadRepository("/xxx", "", true, "", "")
+	assert.Error(t, err)
+}
+
+func TestLoadRepositoryBare(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	memRepo, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"main.go": "a\n"}},
+		{Author: "two", When: when.Add(time.Hour), Files: map[string]string{"main.go": "a\nb\n"}},
+	})
+	assert.NoError(t, err)
+	tempdir, err := ioutil.TempDir("", "hercules-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempdir)
+	barePath := filepath.Join(tempdir, "repo.git")
+	bare, err := git.PlainInit(barePath, true)
+	assert.NoError(t, err)
+	objects, err := memRepo.Storer.IterEncodedObjects(plumbing.AnyObject)
+	assert.NoError(t, err)
+	assert.NoError(t, objects.ForEach(func(obj plumbing.EncodedObject) error {
+		_, err := bare.Storer.SetEncodedObject(obj)
+		return err
+	}))
+	assert.NoError(t, bare.Storer.SetReference(
+		plumbing.NewHashReference(plumbing.Master, hashes[len(hashes)-1])))
+	assert.True(t, isBareRepository(barePath))
+	assert.False(t, isBareRepository(tempdir))
+
+	for _, uri := range []string{barePath, barePath + string(os.PathSeparator)} {
+		repository, err := loadRepository(uri, "", true, "", "")
+		assert.NoError(t, err)
+		_, err = repository.Worktree()
+		assert.Equal(t, git.ErrIsBareRepository, err)
+		deployed, results, err := runPipeline(repository, map[string]interface{}{},
+			analysisOptions{DisableStatus: true})
+		assert.NoError(t, err)
+		assert.Len(t, deployed, 0)
+		assert.Equal(t, 2, results[nil].(*hercules.CommonAnalysisResult).CommitsNumber)
+	}
+}
+
+func TestLoadRepositorySubdirectory(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "hercules-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempdir)
+	_, err = git.PlainInit(tempdir, false)
+	assert.NoError(t, err)
+	subdir := filepath.Join(tempdir, "sub")
+	assert.NoError(t, os.Mkdir(subdir, 0755))
+	assert.False(t, isBareRepository(tempdir))
+	repository, err := loadRepository(subdir, "", true, "", "")
+	assert.NoError(t, err)
+	_, err = repository.Worktree()
+	assert.NoError(t, err)
+}
+
+func TestLoadRepositoryLinkedWorktree(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	memRepo, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"main.go": "a\n"}},
+		{Author: "two", When: when.Add(time.Hour), Files: map[string]string{"main.go": "a\nb\n"}},
+	})
+	assert.NoError(t, err)
+	tempdir, err := ioutil.TempDir("", "hercules-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempdir)
+	mainPath := filepath.Join(tempdir, "main")
+	main, err := git.PlainInit(mainPath, false)
+	assert.NoError(t, err)
+	objects, err := memRepo.Storer.IterEncodedObjects(plumbing.AnyObject)
+	assert.NoError(t, err)
+	assert.NoError(t, objects.ForEach(func(obj plumbing.EncodedObject) error {
+		_, err := main.Storer.SetEncodedObject(obj)
+		return err
+	}))
+	assert.NoError(t, main.Storer.SetReference(
+		plumbing.NewHashReference(plumbing.Master, hashes[len(hashes)-1])))
+	// the layout of "git worktree add ../wt"
+	gitDir := filepath.Join(mainPath, ".git", "worktrees", "wt")
+	assert.NoError(t, os.MkdirAll(gitDir, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(gitDir, "commondir"), []byte("../..\n"), 0644))
+	wtPath := filepath.Join(tempdir, "wt")
+	assert.NoError(t, os.MkdirAll(filepath.Join(wtPath, "sub"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(wtPath, ".git"),
+		[]byte("gitdir: "+gitDir+"\n"), 0644))
+
+	worktree, common := findLinkedWorktree(filepath.Join(wtPath, "sub"))
+	assert.Equal(t, wtPath, worktree)
+	assert.Equal(t, filepath.Join(mainPath, ".git"), common)
+	worktree, common = findLinkedWorktree(mainPath)
+	assert.Empty(t, worktree)
+	assert.Empty(t, common)
+
+	for head, count := range map[string]int{
+		"ref: refs/heads/master\n": 2,
+		// detached
+		hashes[0].String() + "\n": 1,
+	} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(gitDir, "HEAD"), []byte(head), 0644))
+		repository, err := loadRepository(wtPath, "", true, "", "")
+		assert.NoError(t, err, head)
+		_, results, err := runPipeline(repository, map[string]interface{}{},
+			analysisOptions{DisableStatus: true})
+		assert.NoError(t, err, head)
+		assert.Equal(t, count, results[nil].(*hercules.CommonAnalysisResult).CommitsNumber, head)
+	}
+}
+
+func TestPrintTiming(t *testing.T) {
+	commonResult := &hercules.CommonAnalysisResult{
+		RunTime: 3 * time.Second,
+		RunTimePerItem: map[string]float64{
+			"Burndown":             1.5,
+			"*.Fork":               0.25,
+			"Burndown.Hibernation": 0.25,
+			"TreeDiff":             0.5,
+		},
+	}
+	buffer := &bytes.Buffer{}
+	printTiming(commonResult, 4*time.Second, false, buffer)
+	assert.Equal(t, `timing:
+  wall_time: 4.000
+  run_time: 3.000
+  items:
+  - "Burndown": 1.500
+  - "TreeDiff": 0.500
+  - "*.Fork": 0.250
+  - "Burndown.Hibernation": 0.250
+`, buffer.String())
+	buffer.Reset()
+	printTiming(commonResult, 4*time.Second, true, buffer)
+	assert.Equal(t, `{"wall_time":4,"run_time":3,"items":[`+
+		`{"name":"Burndown","time":1.5},{"name":"TreeDiff","time":0.5},`+
+		`{"name":"*.Fork","time":0.25},{"name":"Burndown.Hibernation","time":0.25}]}
+`, buffer.String())
+}
+
+func TestPrintResultsWriter(t *testing.T) {
+	item := hercules.Registry.Summon("Hotspots")[0].(hercules.LeafPipelineItem)
+	assert.NoError(t, item.Initialize(nil))
+	results := map[hercules.LeafPipelineItem]interface{}{
+		nil:  &hercules.CommonAnalysisResult{BeginTime: 100, EndTime: 200, CommitsNumber: 3},
+		item: item.Finalize(),
+	}
+	deployed := []hercules.LeafPipelineItem{item}
+	buffer := &bytes.Buffer{}
+	printResults("test", deployed, results, buffer)
+	assert.Contains(t, buffer.String(), "hercules:\n")
+	assert.Contains(t, buffer.String(), "  repository: test\n")
+	assert.Contains(t, buffer.String(), "  commits: 3\n")
+	assert.Contains(t, buffer.String(), "Hotspots:\n  files:\n")
+
+	buffer.Reset()
+	protobufResults("test", deployed, results, buffer)
+	message := pb.AnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &message))
+	assert.Equal(t, "test", message.Header.Repository)
+	assert.Equal(t, int32(3), message.Header.Commits)
+	assert.Contains(t, message.Contents, "Hotspots")
+}
+
+type testResultSink struct {
+	names  []string
+	common *hercules.CommonAnalysisResult
+	closed bool
+	err    error
+}
+
+func (sink *testResultSink) Consume(
+	itemName string, result interface{}, common *hercules.CommonAnalysisResult) error {
+	sink.names = append(sink.names, itemName)
+	sink.common = common
+	return sink.err
+}
+
+func (sink *testResultSink) Close() error {
+	sink.closed = true
+	return nil
+}
+
+func TestConsumeResults(t *testing.T) {
+	item := hercules.Registry.Summon("Hotspots")[0].(hercules.LeafPipelineItem)
+	assert.NoError(t, item.Initialize(nil))
+	common := &hercules.CommonAnalysisResult{BeginTime: 100, EndTime: 200, CommitsNumber: 3}
+	results := map[hercules.LeafPipelineItem]interface{}{
+		nil:  common,
+		item: item.Finalize(),
+	}
+	deployed := []hercules.LeafPipelineItem{item}
+	expected := &bytes.Buffer{}
+	printResults("test", deployed, results, expected)
+	buffer := &bytes.Buffer{}
+	sink := &testResultSink{}
+	assert.NoError(t, consumeResults([]hercules.ResultSink{
+		newStdoutSink("test", false, deployed, common, buffer), sink}, deployed, results))
+	assert.Equal(t, expected.String(), buffer.String())
+	assert.Equal(t, []string{"Hotspots"}, sink.names)
+	assert.True(t, sink.common == common)
+	assert.True(t, sink.closed)
+
+	expected.Reset()
+	protobufResults("test", deployed, results, expected)
+	buffer.Reset()
+	assert.NoError(t, consumeResults([]hercules.ResultSink{
+		newStdoutSink("test", true, deployed, common, buffer)}, deployed, results))
+	assert.Equal(t, expected.Bytes(), buffer.Bytes())
+
+	sink = &testResultSink{err: errors.New("failed")}
+	assert.EqualError(t, consumeResults([]hercules.ResultSink{sink}, deployed, results), "failed")
+	assert.False(t, sink.closed)
+	assert.Error(t, newStdoutSink("test", false, nil, common, buffer).Consume("Hotspots", nil, common))
+}
+
+func TestConsumeResultsTempFile(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"a.go": "1\n2\n"}},
+		{Author: "one", When: when.Add(time.Hour), Files: map[string]string{"a.go": "1\n3\n"}},
+	})
+	assert.NoError(t, err)
+	deployed, results, err := runPipeline(repository, map[string]interface{}{
+		leaves.ConfigLineEventsTempFile: true,
+	}, analysisOptions{DisableStatus: true, Analyses: []string{"LineEvents"}})
+	assert.NoError(t, err)
+	assert.Len(t, deployed, 1)
+	common := results[nil].(*hercules.CommonAnalysisResult)
+	text, binary := &bytes.Buffer{}, &bytes.Buffer{}
+	// e.g. the webhook and the output file serialize the same results
+	assert.NoError(t, consumeResults([]hercules.ResultSink{
+		newStdoutSink("test", false, deployed, common, text),
+		newStdoutSink("test", true, deployed, common, binary),
+	}, deployed, results))
+	assert.Contains(t, text.String(), "a.go")
+	assert.NotEmpty(t, binary.Bytes())
+	result := results[deployed[0]].(leaves.LineEventsResult)
+	assert.NoError(t, result.ForEach(func(leaves.LineEvent) error { return nil }))
+	closeResults(results)
+	assert.Error(t, result.ForEach(func(leaves.LineEvent) error { return nil }))
+	closeResults(results)
+}
+
+// commandArgsEnv makes the test binary run the command with these newline-separated
+// arguments instead of the tests, see runCommand().
+const commandArgsEnv = "HERCULES_TEST_COMMAND_ARGS"
+
+func TestMain(m *testing.M) {
+	if args := os.Getenv(commandArgsEnv); args != "" {
+		rootCmd.SetArgs(strings.Split(args, "\n"))
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runCommand executes hercules with the arguments in a subprocess, so that the exits and
+// the writes to stderr are observable.
+func runCommand(args ...string) (stdout, stderr string, err error) {
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), commandArgsEnv+"="+strings.Join(args, "\n"))
+	stdoutBuffer, stderrBuffer := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.Stdout, cmd.Stderr = stdoutBuffer, stderrBuffer
+	err = cmd.Run()
+	return stdoutBuffer.String(), stderrBuffer.String(), err
+}
+
+// assertJSONLines checks that every line of the text is a JSON object.
+func assertJSONLines(t *testing.T, text string) {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	assert.NotEqual(t, []string{""}, lines)
+	for _, line := range lines {
+		var message map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &message), line)
+	}
+}
+
+func TestLogJSON(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "hercules-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempdir)
+	repository, err := git.PlainInit(tempdir, false)
+	assert.NoError(t, err)
+	worktree, err := repository.Worktree()
+	assert.NoError(t, err)
+	// the timestamps before 1990 are reported as suspicious
+	when := time.Unix(500000000, 0)
+	for i, content := range []string{"1\n", "1\n2\n", "2\n3\n"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(tempdir, "a.go"), []byte(content), 0666))
+		_, err = worktree.Add("a.go")
+		assert.NoError(t, err)
+		_, err = worktree.Commit(fmt.Sprint(i), &git.CommitOptions{Author: &object.Signature{
+			Name: "one", Email: "one@srcd", When: when.Add(time.Duration(i) * time.Hour)}})
+		assert.NoError(t, err)
+	}
+	stdout, stderr, err := runCommand("--burndown", "--couples", "--log-json", "--quiet", tempdir)
+	assert.NoError(t, err, stderr)
+	assert.Contains(t, stdout, "Burndown:")
+	assertJSONLines(t, stderr)
+	assert.Contains(t, stderr, "suspicious commit timestamp")
+}
+
+func TestErrorsJSON(t *testing.T) {
+	for _, args := range [][]string{
+		{"--max-commits", "-1", "."},
+		{"--gzip", "."},
+		{"--stream-results", "/xxx", "/yyy"},
+		{"/xxx"},
+	} {
+		_, stderr, err := runCommand(append([]string{"--errors-json", "--quiet"}, args...)...)
+		assert.Error(t, err, args)
+		assertJSONLines(t, stderr)
+		if args[0] == "/xxx" {
+			assert.Contains(t, stderr, `"stage":"`+errorStageClone+`"`)
+		} else {
+			assert.Contains(t, stderr, `"stage":"`+errorStageConfigure+`"`, args)
+		}
+	}
+}
+
+func TestListAnalyses(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	listAnalyses(hercules.Registry.GetLeaves(), buffer)
+	output := buffer.String()
+	assert.Contains(t, output, `--couples [Couples]
+    The result is a square matrix`)
+	assert.Contains(t, output, `
+    --couples-jaccard bool [Couples.Jaccard] (default: false)
+        Additionally calculate the Jaccard similarity index of each pair of files.
+    --couples-min-cooccurrence int [Couples.MinCooccurrence] (default: 0)
+        Skip the edges with smaller weights in the edge lists, see --couples-edgelist.
+    --couples-only string [Couples.Only] (default: "")
+`)
+	assert.Contains(t, output, "    --burndown-hibernation-dir path [Burndown.HibernationDirectory]\n")
+	burndown := strings.Index(output, "--burndown [Burndown]")
+	couples := strings.Index(output, "--couples [Couples]")
+	assert.True(t, burndown >= 0)
+	assert.True(t, burndown < couples)
+	buffer.Reset()
+	listAnalyses(hercules.Registry.GetLeaves(), buffer)
+	assert.Equal(t, output, buffer.String())
+}
+
+func TestResolveHTTPToken(t *testing.T) {
+	backup := map[string]string{}
+	for _, env := range httpTokenEnvs {
+		if val, exists := os.LookupEnv(env.name); exists {
+			backup[env.name] = val
+		}
+		os.Unsetenv(env.name)
+	}
+	defer func() {
+		for _, env := range httpTokenEnvs {
+			os.Unsetenv(env.name)
+		}
+		for env, val := range backup {
+			os.Setenv(env, val)
+		}
+	}()
+	const github = "https://github.com/src-d/hercules"
+	const foreign = "https://example.com/src-d/hercules"
+	assert.Equal(t, "", resolveHTTPToken("", github))
+	os.Setenv("GITHUB_TOKEN", "github")
+	os.Setenv("GITLAB_TOKEN", "gitlab")
+	os.Setenv("SYSTEM_ACCESSTOKEN", "azure")
+	assert.Equal(t, "github", resolveHTTPToken("", github))
+	assert.Equal(t, "github", resolveHTTPToken("", "https://GitHub.com/src-d/hercules"))
+	assert.Equal(t, "gitlab", resolveHTTPToken("", "https://gitlab.com/src-d/hercules"))
+	assert.Equal(t, "azure", resolveHTTPToken("", "https://dev.azure.com/srcd/_git/hercules"))
+	// the CI tokens never leak to the other hosts
+	assert.Equal(t, "", resolveHTTPToken("", foreign))
+	assert.Equal(t, "", resolveHTTPToken("", "https://github.com.example.com/src-d/hercules"))
+	os.Setenv("HERCULES_HTTP_TOKEN", "hercules")
+	assert.Equal(t, "hercules", resolveHTTPToken("", github))
+	assert.Equal(t, "hercules", resolveHTTPToken("", foreign))
+	assert.Equal(t, "flag", resolveHTTPToken("flag", foreign))
+}
+
+func TestLoadHTTPAuth(t *testing.T) {
+	auth := loadHTTPAuth("secret")
+	assert.Equal(t, "x-token-auth", auth.Username)
+	assert.Equal(t, "secret", auth.Password)
+	auth = loadHTTPAuth("user:secret:more")
+	assert.Equal(t, "user", auth.Username)
+	assert.Equal(t, "secret:more", auth.Password)
+	assert.NotContains(t, auth.String(), "secret")
+}
+
+func TestMaskHTTPToken(t *testing.T) {
+	assert.Equal(t, "https://***@host/repo: ***", maskHTTPToken(
+		"https://secret@host/repo: secret", "secret"))
+	assert.Equal(t, "text", maskHTTPToken("text", ""))
+	_, err := loadRepository("/xxx", "", true, "", "xxx")
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "xxx")
+	assert.Contains(t, err.Error(), "***")
+}
+
+func clearProxyEnvs() func() {
+	envs := append([]string{}, noProxyEnvs...)
+	for _, schemeEnvs := range proxyEnvs {
+		envs = append(envs, schemeEnvs...)
+	}
+	backup := map[string]string{}
+	for _, env := range envs {
+		if val, exists := os.LookupEnv(env); exists {
+			backup[env] = val
+		}
+		os.Unsetenv(env)
+	}
+	return func() {
+		for _, env := range envs {
+			os.Unsetenv(env)
+		}
+		for env, val := range backup {
+			os.Setenv(env, val)
+		}
+	}
+}
+
+func TestLoadHTTPProxy(t *testing.T) {
+	defer clearProxyEnvs()()
+	proxyFor := func(proxyFunc func(*http.Request) (*url.URL, error), uri string) string {
+		req, err := http.NewRequest("GET", uri, nil)
+		assert.NoError(t, err)
+		proxyURL, err := proxyFunc(req)
+		assert.NoError(t, err)
+		if proxyURL == nil {
+			return ""
+		}
+		return proxyURL.String()
+	}
+	proxyFunc, err := loadHTTPProxy("")
+	assert.NoError(t, err)
+	assert.Nil(t, proxyFunc)
+
+	os.Setenv("HTTPS_PROXY", "https-proxy:3128")
+	os.Setenv("http_proxy", "http://http-proxy:8080")
+	os.Setenv("NO_PROXY", "internal.corp, .local:443")
+	proxyFunc, err = loadHTTPProxy("")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://https-proxy:3128", proxyFor(proxyFunc, "https://github.com/src-d/hercules"))
+	assert.Equal(t, "http://http-proxy:8080", proxyFor(proxyFunc, "http://github.com/src-d/hercules"))
+	assert.Equal(t, "", proxyFor(proxyFunc, "https://git.internal.corp/repo"))
+	assert.Equal(t, "", proxyFor(proxyFunc, "https://internal.corp:8443/repo"))
+	assert.Equal(t, "", proxyFor(proxyFunc, "https://git.local/repo"))
+	assert.Equal(t, "http://https-proxy:3128", proxyFor(proxyFunc, "https://notinternal.corp/repo"))
+
+	proxyFunc, err = loadHTTPProxy("https://override:443")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://override:443", proxyFor(proxyFunc, "https://github.com/src-d/hercules"))
+	assert.Equal(t, "https://override:443", proxyFor(proxyFunc, "http://github.com/src-d/hercules"))
+	assert.Equal(t, "", proxyFor(proxyFunc, "https://git.internal.corp/repo"))
+
+	_, err = loadHTTPProxy("http://")
+	assert.Error(t, err)
+	_, err = loadHTTPProxy("http://proxy:port")
+	assert.Error(t, err)
+}
+
+func TestMatchNoProxy(t *testing.T) {
+	assert.False(t, matchNoProxy("github.com", ""))
+	assert.True(t, matchNoProxy("github.com", "*"))
+	assert.True(t, matchNoProxy("GitHub.com", "example.com,github.com"))
+	assert.True(t, matchNoProxy("api.github.com", ".github.com"))
+	assert.False(t, matchNoProxy("notgithub.com", "github.com"))
+	assert.True(t, matchNoProxy("10.0.0.1", "10.0.0.1:8080"))
+}
+
+func TestInstallHTTPProxy(t *testing.T) {
+	defer clearProxyEnvs()()
+	backup := map[string]transport.Transport{}
+	for scheme, protocol := range gitclient.Protocols {
+		backup[scheme] = protocol
+	}
+	defer func() {
+		for scheme, protocol := range backup {
+			gitclient.InstallProtocol(scheme, protocol)
+		}
+	}()
+	assert.NoError(t, installHTTPProxy(""))
+	assert.Equal(t, backup["https"], gitclient.Protocols["https"])
+	assert.Error(t, installHTTPProxy("http://"))
+	assert.Equal(t, backup["https"], gitclient.Protocols["https"])
+
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer proxy.Close()
+	assert.NoError(t, installHTTPProxy(proxy.URL))
+	assert.NotEqual(t, backup["https"], gitclient.Protocols["https"])
+	assert.NotEqual(t, backup["http"], gitclient.Protocols["http"])
+	_, err := loadRepository("http://git.example.com/src-d/hercules", "", true, "", "")
+	assert.Error(t, err)
+	assert.Equal(t, []string{
+		"http://git.example.com/src-d/hercules/info/refs?service=git-upload-pack"}, proxied)
+}
+
+func TestTruncateCommits(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"a": "1\n"}},
+		{Author: "one", When: when.Add(time.Hour), Files: map[string]string{"a": "2\n"}},
+		{Author: "two", When: when.Add(2 * time.Hour), Parents: []int{0},
+			Files: map[string]string{"b": "1\n"}},
+		{Author: "one", When: when.Add(3 * time.Hour), Parents: []int{1, 2},
+			Files: map[string]string{"b": "1\n"}},
+		{Author: "one", When: when.Add(4 * time.Hour), Files: map[string]string{"a": "3\n"}},
+	})
+	assert.NoError(t, err)
+	commits := make([]*object.Commit, len(hashes))
+	for i, hash := range hashes {
+		commits[i], err = repository.CommitObject(hash)
+		assert.NoError(t, err)
+	}
+	truncated := func(n int) []plumbing.Hash {
+		var result []plumbing.Hash
+		for _, commit := range truncateCommits(commits, n) {
+			result = append(result, commit.Hash)
+		}
+		return result
+	}
+	assert.Equal(t, hashes, truncated(5))
+	assert.Equal(t, hashes, truncated(10))
+	assert.Equal(t, []plumbing.Hash{hashes[4]}, truncated(1))
+	// the side branch is skipped
+	assert.Equal(t, []plumbing.Hash{hashes[1], hashes[3], hashes[4]}, truncated(3))
+	assert.Equal(t, []plumbing.Hash{hashes[0], hashes[1], hashes[3], hashes[4]}, truncated(4))
+	// "git log" lists the newest commits first
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	assert.Equal(t, []plumbing.Hash{hashes[1], hashes[3], hashes[4]}, truncated(3))
+}
+
+func TestParseRepositories(t *testing.T) {
+	uris, cachePath := parseRepositories([]string{"https://github.com/src-d/hercules"})
+	assert.Equal(t, []string{"https://github.com/src-d/hercules"}, uris)
+	assert.Equal(t, "", cachePath)
+	uris, cachePath = parseRepositories([]string{"https://github.com/src-d/hercules", "/tmp/cache"})
+	assert.Equal(t, []string{"https://github.com/src-d/hercules"}, uris)
+	assert.Equal(t, "/tmp/cache", cachePath)
+	uris, cachePath = parseRepositories([]string{
+		"https://github.com/src-d/hercules", "git@github.com:src-d/go-git.git"})
+	assert.Equal(t, []string{
+		"https://github.com/src-d/hercules", "git@github.com:src-d/go-git.git"}, uris)
+	assert.Equal(t, "", cachePath)
+	uris, cachePath = parseRepositories([]string{"/tmp/one", "/tmp/two"})
+	assert.Equal(t, []string{"/tmp/one", "/tmp/two"}, uris)
+	assert.Equal(t, "", cachePath)
+	uris, cachePath = parseRepositories([]string{
+		"https://github.com/src-d/hercules", "/tmp/one", "/tmp/two"})
+	assert.Len(t, uris, 3)
+	assert.Equal(t, "", cachePath)
+}
+
+func TestRunPipelinesFailures(t *testing.T) {
+	deployed, results, analysed := runPipelines(
+		[]string{"/does/not/exist", "/does/not/exist/either"}, "", "",
+		analysisOptions{DisableStatus: true})
+	assert.Len(t, deployed, 0)
+	assert.Len(t, analysed, 0)
+	assert.Equal(t, &hercules.CommonAnalysisResult{}, results[nil])
+}
+
+func TestReportError(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	reportError(buffer, errorStageRun, errors.New("bad \"thing\""), true)
+	assert.Equal(t, "{\"error\":\"bad \\\"thing\\\"\",\"stage\":\"run\"}\n", buffer.String())
+	buffer.Reset()
+	reportError(buffer, errorStageClone, errors.New("bad thing"), false)
+	assert.True(t, strings.HasSuffix(buffer.String(), " bad thing\n"), buffer.String())
+	assert.False(t, strings.HasPrefix(buffer.String(), "{"))
+}
+
+func TestCatchPanic(t *testing.T) {
+	assert.Nil(t, catchPanic(func() error { return nil }))
+	err := catchPanic(func() error { return errors.New("returned") })
+	assert.EqualError(t, err, "returned")
+	err = catchPanic(func() error { panic("panicked") })
+	assert.EqualError(t, err, "panicked")
+	err = catchPanic(func() error { panic(errors.New("panicked error")) })
+	assert.EqualError(t, err, "panicked error")
+}
+
+func TestSetThreads(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	assert.Equal(t, 1, setThreads(1))
+	assert.Equal(t, 1, runtime.GOMAXPROCS(0))
+	assert.Equal(t, runtime.NumCPU(), setThreads(0))
+	assert.Equal(t, runtime.NumCPU(), runtime.GOMAXPROCS(0))
+}
+
+func TestPrintHeaderConfig(t *testing.T) {
+	common := &hercules.CommonAnalysisResult{
+		BeginTime: 1500000000, EndTime: 1500003600, CommitsNumber: 3,
+		Config: map[string]string{"Burndown.Granularity": "30", "TreeDiff.ExcludeGlobs": "a\"b,*.md"}}
+	buffer := &bytes.Buffer{}
+	printHeader("repo", common, buffer)
+	assert.True(t, strings.HasSuffix(buffer.String(), `  run_time: 0
+  config:
+    "Burndown.Granularity": "30"
+    "TreeDiff.ExcludeGlobs": "a\"b,*.md"
+`), buffer.String())
+	meta := newMetadata("repo", common)
+	assert.Equal(t, common.Config, meta.Config)
+	common.Config = nil
+	buffer.Reset()
+	printHeader("repo", common, buffer)
+	assert.NotContains(t, buffer.String(), "config")
 }
@@ -0,0 +1,103 @@
+// +build sqlite
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"gopkg.in/src-d/hercules.v10"
+	// pure Go, no cgo
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSink is the ResultSink which writes the results to an SQLite database. Each item which
+// implements hercules.SQLiteSerializer creates its own tables, the rest are skipped.
+type sqliteSink struct {
+	db       *sql.DB
+	deployed []hercules.LeafPipelineItem
+	logger   *log.Logger
+}
+
+// newSQLiteSink creates the database file at `path`, overwriting the existing one. It is called
+// before the analysis to fail fast.
+func newSQLiteSink(path string) (*sqliteSink, error) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// sql.Open() does not touch the file
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &sqliteSink{db: db, logger: log.New(os.Stderr, "", log.LstdFlags)}, nil
+}
+
+// Start remembers the deployed items and writes the "meta" table.
+func (sink *sqliteSink) Start(uri string, deployed []hercules.LeafPipelineItem,
+	common *hercules.CommonAnalysisResult) error {
+	sink.deployed = deployed
+	return sink.transact(func(tx *sql.Tx) error {
+		_, err := tx.Exec("CREATE TABLE meta (key TEXT PRIMARY KEY, value TEXT)")
+		if err != nil {
+			return err
+		}
+		for _, kv := range [][2]string{
+			{"version", strconv.Itoa(hercules.SchemaVersion)},
+			{"hash", hercules.BinaryGitHash},
+			{"repository", uri},
+			{"begin_unix_time", strconv.FormatInt(common.BeginTime, 10)},
+			{"end_unix_time", strconv.FormatInt(common.EndTime, 10)},
+			{"commits", strconv.Itoa(common.CommitsNumber)},
+			{"run_time", strconv.FormatInt(common.RunTime.Nanoseconds()/1000000, 10)},
+		} {
+			if _, err := tx.Exec("INSERT INTO meta VALUES (?, ?)", kv[0], kv[1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Consume writes the result of the deployed item named `itemName` in a separate transaction.
+func (sink *sqliteSink) Consume(
+	itemName string, result interface{}, common *hercules.CommonAnalysisResult) error {
+	for _, item := range sink.deployed {
+		if item.Name() != itemName {
+			continue
+		}
+		serializer, ok := item.(hercules.SQLiteSerializer)
+		if !ok {
+			sink.logger.Printf("warning: %s does not support SQLite, skipped", itemName)
+			return nil
+		}
+		return sink.transact(func(tx *sql.Tx) error {
+			return serializer.SerializeSQLite(result, tx)
+		})
+	}
+	return fmt.Errorf("%s was not deployed", itemName)
+}
+
+// Close closes the database.
+func (sink *sqliteSink) Close() error {
+	return sink.db.Close()
+}
+
+func (sink *sqliteSink) transact(fn func(tx *sql.Tx) error) error {
+	tx, err := sink.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err = fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,35 @@
+// +build !sqlite
+
+package main
+
+import (
+	"errors"
+
+	"gopkg.in/src-d/hercules.v10"
+)
+
+// sqliteSink is not supported without the "sqlite" build tag, see sqlite.go.
+type sqliteSink struct{}
+
+// newSQLiteSink fails because the SQLite driver requires a newer Go and is optional.
+func newSQLiteSink(path string) (*sqliteSink, error) {
+	return nil, errors.New("hercules was built without SQLite support, rebuild with " +
+		"\"make TAGS=sqlite\"")
+}
+
+// Start is never called because newSQLiteSink() fails.
+func (sink *sqliteSink) Start(uri string, deployed []hercules.LeafPipelineItem,
+	common *hercules.CommonAnalysisResult) error {
+	return nil
+}
+
+// Consume is never called because newSQLiteSink() fails.
+func (sink *sqliteSink) Consume(
+	itemName string, result interface{}, common *hercules.CommonAnalysisResult) error {
+	return nil
+}
+
+// Close is never called because newSQLiteSink() fails.
+func (sink *sqliteSink) Close() error {
+	return nil
+}
@@ -0,0 +1,60 @@
+// +build sqlite
+
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v10"
+	"gopkg.in/src-d/hercules.v10/leaves"
+)
+
+func TestSQLiteSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-sqlite-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "results.db")
+	// the existing file is overwritten
+	assert.NoError(t, ioutil.WriteFile(path, []byte("garbage"), 0666))
+
+	sink, err := newSQLiteSink(path)
+	assert.NoError(t, err)
+	logs := &bytes.Buffer{}
+	sink.logger = log.New(logs, "", 0)
+	couples := &leaves.CouplesAnalysis{}
+	history := &leaves.FileHistoryAnalysis{}
+	deployed := []hercules.LeafPipelineItem{couples, history}
+	common := &hercules.CommonAnalysisResult{BeginTime: 1, EndTime: 2, CommitsNumber: 3}
+	assert.NoError(t, sink.Start("repo", deployed, common))
+	assert.NoError(t, consumeResults([]hercules.ResultSink{sink}, deployed,
+		map[hercules.LeafPipelineItem]interface{}{
+			nil: common,
+			couples: leaves.CouplesResult{
+				Files: []string{"a", "b"}, FilesMatrix: []map[int]int64{{0: 1, 1: 1}, {0: 1}}},
+			history: leaves.FileHistoryResult{},
+		}))
+	assert.Equal(t, "warning: FileHistoryAnalysis does not support SQLite, skipped\n", logs.String())
+	assert.EqualError(t, sink.Consume("Unknown", nil, common), "Unknown was not deployed")
+
+	db, err := sql.Open("sqlite", path)
+	assert.NoError(t, err)
+	defer db.Close()
+	var value string
+	assert.NoError(t, db.QueryRow("SELECT value FROM meta WHERE key = 'repository'").Scan(&value))
+	assert.Equal(t, "repo", value)
+	assert.NoError(t, db.QueryRow("SELECT value FROM meta WHERE key = 'commits'").Scan(&value))
+	assert.Equal(t, "3", value)
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT SUM(count) FROM couples").Scan(&count))
+	assert.Equal(t, 3, count)
+
+	_, err = newSQLiteSink(filepath.Join(dir, "missing", "results.db"))
+	assert.Error(t, err)
+}
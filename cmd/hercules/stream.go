@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/hercules.v10"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// resultStream writes the result of each analysis as soon as it finalizes, see --stream-results.
+// The YAML stream is a sequence of documents which start with "---": one per analysis and
+// the last with the common header. The Protocol Buffers stream is a sequence of
+// pb.AnalysisResults messages, each prefixed with its length as a varint: one per analysis with
+// a single Contents entry and the last with only the Header. The NDJSON stream is a sequence
+// of streamEvent-s, one per line, see --ndjson. The header goes last because it contains
+// the run time.
+type resultStream struct {
+	protobuf bool
+	ndjson   bool
+	writer   io.Writer
+	// flush pushes the written bytes through the buffers of `writer`, e.g. bufio and gzip.
+	flush func() error
+}
+
+func newResultStream(protobuf bool, writer io.Writer, flush func() error) *resultStream {
+	if flush == nil {
+		flush = func() error { return nil }
+	}
+	return &resultStream{protobuf: protobuf, writer: writer, flush: flush}
+}
+
+// newNDJSONStream creates the resultStream which writes the newline-delimited JSON events.
+func newNDJSONStream(writer io.Writer, flush func() error) *resultStream {
+	stream := newResultStream(false, writer, flush)
+	stream.ndjson = true
+	return stream
+}
+
+const (
+	// streamEventResult is the type of the NDJSON event with the result of an analysis.
+	streamEventResult = "result"
+	// streamEventProgress is the type of the NDJSON event with the progress of the pipeline.
+	streamEventProgress = "progress"
+	// streamEventHeader is the type of the last NDJSON event with the common header.
+	streamEventHeader = "header"
+)
+
+// streamEvent is the envelope of each line of the NDJSON stream. The payload of a result
+// is the same as the analysis writes in YAML, and the payload of the header is the same
+// as the "hercules" YAML section.
+type streamEvent struct {
+	Type     string      `json:"type"`
+	Analysis string      `json:"analysis,omitempty"`
+	Payload  interface{} `json:"payload"`
+}
+
+// streamProgress is the payload of streamEventProgress.
+type streamProgress struct {
+	Step   int    `json:"step"`
+	Steps  int    `json:"steps"`
+	Action string `json:"action,omitempty"`
+}
+
+// WriteResult serializes the result of a single analysis as the next frame and flushes it.
+func (stream *resultStream) WriteResult(item hercules.LeafPipelineItem, result interface{}) error {
+	buffer := &bytes.Buffer{}
+	if stream.ndjson {
+		if err := item.Serialize(result, false, buffer); err != nil {
+			return err
+		}
+		payload, err := yamlSectionToJSON(buffer.Bytes())
+		if err != nil {
+			return fmt.Errorf("%s: %v", item.Name(), err)
+		}
+		return stream.writeEvent(streamEvent{
+			Type: streamEventResult, Analysis: item.Name(), Payload: payload})
+	}
+	if !stream.protobuf {
+		fmt.Fprintf(buffer, "---\n%s:\n", item.Name())
+		if err := item.Serialize(result, false, buffer); err != nil {
+			return err
+		}
+		return stream.write(buffer.Bytes())
+	}
+	if err := item.Serialize(result, true, buffer); err != nil {
+		return err
+	}
+	return stream.writeMessage(&pb.AnalysisResults{
+		Contents: map[string][]byte{item.Name(): buffer.Bytes()},
+	})
+}
+
+// WriteHeader writes the common header as the last frame and flushes it.
+func (stream *resultStream) WriteHeader(uri string, common *hercules.CommonAnalysisResult) error {
+	if stream.ndjson {
+		buffer := &bytes.Buffer{}
+		printHeader(uri, common, buffer)
+		var header map[string]interface{}
+		if err := goyaml.Unmarshal(buffer.Bytes(), &header); err != nil {
+			return err
+		}
+		return stream.writeEvent(streamEvent{
+			Type: streamEventHeader, Payload: jsonCompatible(header["hercules"])})
+	}
+	if !stream.protobuf {
+		buffer := &bytes.Buffer{}
+		fmt.Fprintln(buffer, "---")
+		printHeader(uri, common, buffer)
+		return stream.write(buffer.Bytes())
+	}
+	return stream.writeMessage(&pb.AnalysisResults{Header: newMetadata(uri, common)})
+}
+
+// WriteProgress writes the progress of the pipeline and flushes it. It does nothing unless
+// the stream is NDJSON.
+func (stream *resultStream) WriteProgress(step, steps int, action string) error {
+	if !stream.ndjson {
+		return nil
+	}
+	return stream.writeEvent(streamEvent{
+		Type: streamEventProgress, Payload: streamProgress{Step: step, Steps: steps, Action: action}})
+}
+
+func (stream *resultStream) writeEvent(event streamEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return stream.write(append(line, '\n'))
+}
+
+// yamlSectionToJSON parses the YAML which an analysis writes under its name, indented by two
+// spaces, and converts it to the value which encoding/json accepts.
+func yamlSectionToJSON(data []byte) (interface{}, error) {
+	var section map[string]interface{}
+	document := append([]byte("section:\n"), data...)
+	if err := goyaml.Unmarshal(document, &section); err != nil {
+		return nil, err
+	}
+	return jsonCompatible(section["section"]), nil
+}
+
+// jsonCompatible converts the maps with interface{} keys which goyaml produces to the maps
+// with string keys.
+func jsonCompatible(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, val := range typed {
+			converted[fmt.Sprint(key)] = jsonCompatible(val)
+		}
+		return converted
+	case map[string]interface{}:
+		for key, val := range typed {
+			typed[key] = jsonCompatible(val)
+		}
+		return typed
+	case []interface{}:
+		for i, val := range typed {
+			typed[i] = jsonCompatible(val)
+		}
+		return typed
+	}
+	return value
+}
+
+func (stream *resultStream) writeMessage(message *pb.AnalysisResults) error {
+	serialized, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return stream.write(append(proto.EncodeVarint(uint64(len(serialized))), serialized...))
+}
+
+func (stream *resultStream) write(frame []byte) error {
+	if _, err := stream.writer.Write(frame); err != nil {
+		return err
+	}
+	return stream.flush()
+}
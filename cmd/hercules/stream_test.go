@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v10"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+)
+
+func TestResultStreamYAML(t *testing.T) {
+	item := hercules.Registry.Summon("Hotspots")[0].(hercules.LeafPipelineItem)
+	assert.NoError(t, item.Initialize(nil))
+	common := &hercules.CommonAnalysisResult{BeginTime: 100, EndTime: 200, CommitsNumber: 3}
+	buffer := &bytes.Buffer{}
+	flushes := 0
+	stream := newResultStream(false, buffer, func() error {
+		flushes++
+		return nil
+	})
+	assert.NoError(t, stream.WriteResult(item, item.Finalize()))
+	assert.Equal(t, 1, flushes)
+	assert.True(t, strings.HasPrefix(buffer.String(), "---\nHotspots:\n  files:\n"))
+	assert.NoError(t, stream.WriteHeader("test", common))
+	assert.Equal(t, 2, flushes)
+	documents := strings.Split(buffer.String(), "---\n")
+	assert.Len(t, documents, 3)
+	assert.Equal(t, "", documents[0])
+	assert.True(t, strings.HasPrefix(documents[2], "hercules:\n"))
+	assert.Contains(t, documents[2], "  repository: test\n")
+	assert.Contains(t, documents[2], "  commits: 3\n")
+}
+
+func TestResultStreamProtobuf(t *testing.T) {
+	item := hercules.Registry.Summon("Hotspots")[0].(hercules.LeafPipelineItem)
+	assert.NoError(t, item.Initialize(nil))
+	common := &hercules.CommonAnalysisResult{BeginTime: 100, EndTime: 200, CommitsNumber: 3}
+	buffer := &bytes.Buffer{}
+	stream := newResultStream(true, buffer, nil)
+	assert.NoError(t, stream.WriteResult(item, item.Finalize()))
+	assert.NoError(t, stream.WriteHeader("test", common))
+	data := buffer.Bytes()
+	var messages []pb.AnalysisResults
+	for len(data) > 0 {
+		size, n := proto.DecodeVarint(data)
+		assert.NotZero(t, n)
+		message := pb.AnalysisResults{}
+		assert.NoError(t, proto.Unmarshal(data[n:n+int(size)], &message))
+		messages = append(messages, message)
+		data = data[n+int(size):]
+	}
+	assert.Len(t, messages, 2)
+	assert.Nil(t, messages[0].Header)
+	assert.Len(t, messages[0].Contents, 1)
+	assert.Contains(t, messages[0].Contents, "Hotspots")
+	assert.Equal(t, "test", messages[1].Header.Repository)
+	assert.Equal(t, int32(3), messages[1].Header.Commits)
+	assert.Len(t, messages[1].Contents, 0)
+
+	stream = newResultStream(true, buffer, func() error { return errors.New("failed") })
+	assert.EqualError(t, stream.WriteHeader("test", common), "failed")
+}
+
+func TestResultStreamNDJSON(t *testing.T) {
+	item := hercules.Registry.Summon("Hotspots")[0].(hercules.LeafPipelineItem)
+	assert.NoError(t, item.Initialize(nil))
+	common := &hercules.CommonAnalysisResult{BeginTime: 100, EndTime: 200, CommitsNumber: 3}
+	buffer := &bytes.Buffer{}
+	flushes := 0
+	stream := newNDJSONStream(buffer, func() error {
+		flushes++
+		return nil
+	})
+	assert.NoError(t, stream.WriteProgress(1, 10, "commit"))
+	assert.Equal(t, 1, flushes)
+	assert.NoError(t, stream.WriteResult(item, item.Finalize()))
+	assert.Equal(t, 2, flushes)
+	assert.NoError(t, stream.WriteHeader("test", common))
+	assert.Equal(t, 3, flushes)
+	lines := strings.Split(buffer.String(), "\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, "", lines[3])
+	assert.Equal(t, `{"type":"progress","payload":{"step":1,"steps":10,"action":"commit"}}`, lines[0])
+	var events []map[string]interface{}
+	for _, line := range lines[:3] {
+		event := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+	assert.Equal(t, "result", events[1]["type"])
+	assert.Equal(t, "Hotspots", events[1]["analysis"])
+	assert.Contains(t, events[1]["payload"], "files")
+	assert.Equal(t, "header", events[2]["type"])
+	assert.NotContains(t, events[2], "analysis")
+	header := events[2]["payload"].(map[string]interface{})
+	assert.Equal(t, "test", header["repository"])
+	assert.Equal(t, float64(3), header["commits"])
+
+	buffer.Reset()
+	stream = newResultStream(false, buffer, nil)
+	assert.NoError(t, stream.WriteProgress(1, 10, "commit"))
+	assert.Equal(t, 0, buffer.Len())
+}
+
+func TestYAMLSectionToJSON(t *testing.T) {
+	payload, err := yamlSectionToJSON([]byte("  ticks:\n    0:\n      1: [2, 3]\n  name: \"x\"\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"ticks": map[string]interface{}{"0": map[string]interface{}{"1": []interface{}{2, 3}}},
+		"name":  "x",
+	}, payload)
+	_, err = json.Marshal(payload)
+	assert.NoError(t, err)
+	_, err = yamlSectionToJSON([]byte("  a: [\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+	"gopkg.in/src-d/hercules.v10/leaves"
+)
+
+// lineAnalyses are the names of the analyses which track the lines like BurndownAnalysis
+// and are thus checked by --validate.
+var lineAnalyses = map[string]bool{
+	(&leaves.BurndownAnalysis{}).Name():           true,
+	(&leaves.BusFactorAnalysis{}).Name():          true,
+	(&leaves.CodeAgeAnalysis{}).Name():            true,
+	(&leaves.DirectoryOwnershipAnalysis{}).Name(): true,
+	(&leaves.LineEventsAnalysis{}).Name():         true,
+	(&leaves.OwnershipTransferAnalysis{}).Name():  true,
+}
+
+// validationAnalyses returns the line-based analyses among `enabled`, or the burndown
+// if there are none.
+func validationAnalyses(enabled []string) []string {
+	var analyses []string
+	for _, name := range enabled {
+		if lineAnalyses[name] {
+			analyses = append(analyses, name)
+		}
+	}
+	if len(analyses) == 0 {
+		analyses = []string{(&leaves.BurndownAnalysis{}).Name()}
+	}
+	sort.Strings(analyses)
+	return analyses
+}
+
+// runValidation runs the line-based analyses over the repository and collects the internal
+// integrity violations instead of the results. The violations are unique per commit and file
+// because every analysis reports the same.
+func runValidation(repository *git.Repository, facts map[string]interface{},
+	options analysisOptions) ([]leaves.IntegrityViolation, error) {
+	var enabled []string
+	for name, valPtr := range cmdlineDeployed {
+		if *valPtr {
+			enabled = append(enabled, name)
+		}
+	}
+	options.Analyses = validationAnalyses(enabled)
+	var violations []leaves.IntegrityViolation
+	facts[leaves.FactBurndownIntegrityViolations] = &violations
+	defer delete(facts, leaves.FactBurndownIntegrityViolations)
+	_, results, err := runPipeline(repository, facts, options)
+	if err != nil {
+		return nil, err
+	}
+	closeResults(results)
+	return uniqueViolations(violations), nil
+}
+
+// uniqueViolations removes the repeated violations of the same file in the same commit.
+func uniqueViolations(violations []leaves.IntegrityViolation) []leaves.IntegrityViolation {
+	type key struct {
+		commit string
+		file   string
+	}
+	seen := map[key]bool{}
+	var result []leaves.IntegrityViolation
+	for _, v := range violations {
+		k := key{v.Commit.String(), v.File}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// printViolations writes the YAML summary of the integrity violations of the repository.
+func printViolations(writer io.Writer, uri string, violations []leaves.IntegrityViolation) {
+	fmt.Fprintln(writer, "hercules:")
+	fmt.Fprintln(writer, "  repository:", uri)
+	fmt.Fprintf(writer, "  integrity_violations: %d\n", len(violations))
+	if len(violations) == 0 {
+		return
+	}
+	fmt.Fprintln(writer, "violations:")
+	for _, v := range violations {
+		fmt.Fprintf(writer, "  - commit: %s\n", v.Commit.String())
+		fmt.Fprintf(writer, "    file: %s\n", yaml.SafeString(v.File))
+		fmt.Fprintf(writer, "    expected: %d\n", v.Expected)
+		fmt.Fprintf(writer, "    actual: %d\n", v.Actual)
+		fmt.Fprintf(writer, "    message: %s\n", yaml.SafeString(v.Message))
+	}
+}
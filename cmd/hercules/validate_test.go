@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+	"gopkg.in/src-d/hercules.v10/leaves"
+)
+
+func TestValidationAnalyses(t *testing.T) {
+	assert.Equal(t, []string{"Burndown"}, validationAnalyses(nil))
+	assert.Equal(t, []string{"Burndown"}, validationAnalyses([]string{"Couples", "Devs"}))
+	assert.Equal(t, []string{"BusFactor", "CodeAge"},
+		validationAnalyses([]string{"Devs", "CodeAge", "BusFactor"}))
+	assert.Equal(t, []string{"LineEvents"}, validationAnalyses([]string{"LineEvents", "Devs"}))
+}
+
+func TestUniqueViolations(t *testing.T) {
+	hash1 := plumbing.NewHash("cce947b98a050c6d356bc6ba95030254914027b1")
+	hash2 := plumbing.NewHash("a3ee37f91f0d705ec9c41ae88426f0ae44b2fbc3")
+	violations := uniqueViolations([]leaves.IntegrityViolation{
+		{Commit: hash1, File: "a.go", Expected: 3, Actual: 5},
+		{Commit: hash1, File: "b.go", Expected: 1, Actual: 2},
+		{Commit: hash1, File: "a.go", Expected: 3, Actual: 5},
+		{Commit: hash2, File: "a.go", Expected: 4, Actual: 3},
+	})
+	assert.Equal(t, []leaves.IntegrityViolation{
+		{Commit: hash1, File: "a.go", Expected: 3, Actual: 5},
+		{Commit: hash1, File: "b.go", Expected: 1, Actual: 2},
+		{Commit: hash2, File: "a.go", Expected: 4, Actual: 3},
+	}, violations)
+}
+
+func TestPrintViolations(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	printViolations(buffer, "repo", nil)
+	assert.Equal(t, "hercules:\n  repository: repo\n  integrity_violations: 0\n", buffer.String())
+	buffer.Reset()
+	printViolations(buffer, "repo", []leaves.IntegrityViolation{{
+		Commit:   plumbing.NewHash("cce947b98a050c6d356bc6ba95030254914027b1"),
+		File:     "a.go",
+		Expected: 3,
+		Actual:   5,
+		Message:  "a.go: internal integrity error src 3 != 5",
+	}})
+	assert.Equal(t, `hercules:
+  repository: repo
+  integrity_violations: 1
+violations:
+  - commit: cce947b98a050c6d356bc6ba95030254914027b1
+    file: "a.go"
+    expected: 3
+    actual: 5
+    message: "a.go: internal integrity error src 3 != 5"
+`, buffer.String())
+}
+
+func TestRunValidation(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"a.go": "1\n2\n3\n"}},
+		{Author: "two", When: when.Add(24 * time.Hour), Files: map[string]string{"a.go": "1\nX\n3\n"}},
+	})
+	require.NoError(t, err)
+	facts := map[string]interface{}{}
+	violations, err := runValidation(repository, facts, analysisOptions{DisableStatus: true})
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+	assert.NotContains(t, facts, leaves.FactBurndownIntegrityViolations)
+}
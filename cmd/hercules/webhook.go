@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"gopkg.in/src-d/hercules.v10"
+)
+
+// webhookTokenEnv is the environment variable with the default bearer token of the webhook.
+const webhookTokenEnv = "HERCULES_WEBHOOK_TOKEN"
+
+// webhookRetryDelays are the pauses before each retry of a failed webhook request.
+var webhookRetryDelays = []time.Duration{time.Second, 5 * time.Second, 15 * time.Second}
+
+// webhookSink is the ResultSink which posts the results in Protocol Buffers format
+// (pb.AnalysisResults) to an HTTP endpoint. The failures are reported to stderr and never
+// fail the analysis.
+type webhookSink struct {
+	*stdoutSink
+	url         string
+	contentType string
+	token       string
+	client      *http.Client
+	buffer      *bytes.Buffer
+	logger      *log.Logger
+}
+
+func newWebhookSink(url, contentType, token string, uri string,
+	deployed []hercules.LeafPipelineItem, common *hercules.CommonAnalysisResult) *webhookSink {
+	buffer := &bytes.Buffer{}
+	return &webhookSink{
+		stdoutSink:  newStdoutSink(uri, true, deployed, common, buffer),
+		url:         url,
+		contentType: contentType,
+		token:       token,
+		client:      &http.Client{Timeout: time.Minute},
+		buffer:      buffer,
+		logger:      log.New(os.Stderr, "", log.LstdFlags),
+	}
+}
+
+// resolveWebhookToken returns the explicitly specified token or the value of webhookTokenEnv.
+func resolveWebhookToken(token string) string {
+	if token != "" {
+		return token
+	}
+	return os.Getenv(webhookTokenEnv)
+}
+
+// Close serializes the consumed results and posts them.
+func (sink *webhookSink) Close() error {
+	if err := sink.stdoutSink.Close(); err != nil {
+		return err
+	}
+	if err := sink.post(sink.buffer.Bytes()); err != nil {
+		sink.logger.Printf("failed to post the results to %s: %v", sink.url, err)
+	}
+	return nil
+}
+
+// post sends the body and retries on network errors, 429 and 5xx responses.
+func (sink *webhookSink) post(body []byte) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		retry, err = sink.postOnce(body)
+		if err == nil || !retry || attempt >= len(webhookRetryDelays) {
+			return err
+		}
+		time.Sleep(webhookRetryDelays[attempt])
+	}
+}
+
+func (sink *webhookSink) postOnce(body []byte) (bool, error) {
+	request, err := http.NewRequest(http.MethodPost, sink.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Content-Type", sink.contentType)
+	if sink.token != "" {
+		request.Header.Set("Authorization", "Bearer "+sink.token)
+	}
+	response, err := sink.client.Do(request)
+	if err != nil {
+		return true, err
+	}
+	defer response.Body.Close()
+	io.Copy(ioutil.Discard, response.Body)
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("HTTP %s", response.Status)
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500, err
+}
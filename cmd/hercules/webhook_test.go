@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v10"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+)
+
+func TestWebhookSink(t *testing.T) {
+	delays := webhookRetryDelays
+	webhookRetryDelays = []time.Duration{0, 0}
+	defer func() { webhookRetryDelays = delays }()
+
+	var requests int
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	common := &hercules.CommonAnalysisResult{BeginTime: 1, EndTime: 2, CommitsNumber: 3}
+	sink := newWebhookSink(server.URL, "application/octet-stream", "secret", "repo", nil, common)
+	assert.NoError(t, consumeResults([]hercules.ResultSink{sink}, nil,
+		map[hercules.LeafPipelineItem]interface{}{nil: common}))
+	assert.Equal(t, 2, requests)
+	message := pb.AnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(body, &message))
+	assert.Equal(t, "repo", message.Header.Repository)
+	assert.Equal(t, int32(3), message.Header.Commits)
+}
+
+func TestWebhookSinkFailure(t *testing.T) {
+	delays := webhookRetryDelays
+	webhookRetryDelays = []time.Duration{0, 0}
+	defer func() { webhookRetryDelays = delays }()
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	common := &hercules.CommonAnalysisResult{}
+	sink := newWebhookSink(server.URL, "application/x-protobuf", "", "repo", nil, common)
+	logs := &bytes.Buffer{}
+	sink.logger = log.New(logs, "", 0)
+	// the analysis does not fail
+	assert.NoError(t, sink.Close())
+	// client errors are not retried
+	assert.Equal(t, 1, requests)
+	assert.Contains(t, logs.String(), "failed to post the results to "+server.URL+": HTTP 400")
+
+	sink = newWebhookSink("http://127.0.0.1:0", "application/x-protobuf", "", "repo", nil, common)
+	logs.Reset()
+	sink.logger = log.New(logs, "", 0)
+	assert.NoError(t, sink.Close())
+	assert.Contains(t, logs.String(), "failed to post the results")
+}
+
+func TestResolveWebhookToken(t *testing.T) {
+	defer os.Setenv(webhookTokenEnv, os.Getenv(webhookTokenEnv))
+	os.Setenv(webhookTokenEnv, "env")
+	assert.Equal(t, "flag", resolveWebhookToken("flag"))
+	assert.Equal(t, "env", resolveWebhookToken(""))
+	os.Unsetenv(webhookTokenEnv)
+	assert.Equal(t, "", resolveWebhookToken(""))
+}
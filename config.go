@@ -0,0 +1,137 @@
+package hercules
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/leaves"
+)
+
+// PipelineConfig is the typed alternative to the facts which are passed to Pipeline.Initialize().
+// The zero values keep the defaults of the corresponding items.
+type PipelineConfig struct {
+	// Analyses are the names of the deployed leaves, e.g. "Burndown" or "Couples".
+	// See LeafPipelineItem.Name().
+	Analyses []string
+	// Features are the enabled pipeline features, e.g. "uast".
+	Features []string
+	// Commits is the analysed commit sequence. nil means Pipeline.Commits(false).
+	Commits []*object.Commit
+	// Logger replaces the default logger of the pipeline and the items.
+	Logger core.Logger
+
+	// TickSize is the duration of a single tick. See ConfigTickSize.
+	TickSize time.Duration
+	// PeopleDictPath is the path to the identities file. See identity.Detector.
+	PeopleDictPath string
+	// Granularity is the number of ticks in a single burndown band.
+	Granularity int
+	// Sampling is the number of ticks between the burndown snapshots.
+	Sampling int
+	// TrackFiles enables the burndown of each file.
+	TrackFiles bool
+	// TrackPeople enables the burndown of each developer and the overwrites matrix.
+	TrackPeople bool
+
+	// HibernationDistance is the minimum number of actions between two sequential usages of
+	// a branch to activate the hibernation optimization. See Pipeline.HibernationDistance.
+	HibernationDistance int
+	// MaxConcurrentBranches limits the number of the branches which exist at the same time.
+	// See Pipeline.MaxConcurrentBranches.
+	MaxConcurrentBranches int
+	// SkipMerges hides the merge commits from the leaves. See Pipeline.SkipMerges.
+	SkipMerges bool
+	// ContinueOnError skips the commits which fail to be analysed instead of aborting.
+	// See Pipeline.ContinueOnError.
+	ContinueOnError bool
+	// TimeSource is either TimeSourceCommitter or TimeSourceAuthor. See ConfigTimeSource.
+	TimeSource string
+
+	// Facts are passed to Pipeline.Initialize() as is and override the fields above.
+	Facts map[string]interface{}
+}
+
+// ToFacts converts the config to the facts for Pipeline.Initialize().
+func (config PipelineConfig) ToFacts() map[string]interface{} {
+	facts := map[string]interface{}{}
+	if config.Commits != nil {
+		facts[ConfigPipelineCommits] = config.Commits
+	}
+	if config.Logger != nil {
+		facts[ConfigLogger] = config.Logger
+	}
+	if config.TickSize != 0 {
+		facts[ConfigTickSize] = config.TickSize
+	}
+	if config.PeopleDictPath != "" {
+		facts[identity.ConfigIdentityDetectorPeopleDictPath] = config.PeopleDictPath
+	}
+	if config.Granularity != 0 {
+		facts[leaves.ConfigBurndownGranularity] = config.Granularity
+	}
+	if config.Sampling != 0 {
+		facts[leaves.ConfigBurndownSampling] = config.Sampling
+	}
+	if config.TrackFiles {
+		facts[leaves.ConfigBurndownTrackFiles] = true
+	}
+	if config.TrackPeople {
+		facts[leaves.ConfigBurndownTrackPeople] = true
+	}
+	if config.HibernationDistance != 0 {
+		facts[core.ConfigPipelineHibernationDistance] = config.HibernationDistance
+	}
+	if config.MaxConcurrentBranches != 0 {
+		facts[core.ConfigPipelineMaxConcurrentBranches] = config.MaxConcurrentBranches
+	}
+	if config.SkipMerges {
+		facts[ConfigPipelineSkipMerges] = true
+	}
+	if config.ContinueOnError {
+		facts[ConfigPipelineContinueOnError] = true
+	}
+	if config.TimeSource != "" {
+		facts[ConfigTimeSource] = config.TimeSource
+	}
+	for key, val := range config.Facts {
+		facts[key] = val
+	}
+	return facts
+}
+
+// NewPipelineFromConfig creates a new Pipeline, deploys the requested analyses and
+// initializes it with the facts generated from the config. The deployed leaves are returned
+// in the same order as PipelineConfig.Analyses; they are the keys of the Pipeline.Run() results.
+func NewPipelineFromConfig(repository *git.Repository, config PipelineConfig) (
+	*Pipeline, []LeafPipelineItem, error) {
+	pipeline := NewPipeline(repository)
+	for _, feature := range config.Features {
+		pipeline.SetFeature(feature)
+	}
+	facts := config.ToFacts()
+	deployed := make([]LeafPipelineItem, 0, len(config.Analyses))
+	for _, name := range config.Analyses {
+		summoned := Registry.Summon(name)
+		if len(summoned) == 0 {
+			return nil, nil, fmt.Errorf("unknown analysis: %s", name)
+		}
+		leaf, isLeaf := summoned[0].(LeafPipelineItem)
+		if !isLeaf {
+			return nil, nil, fmt.Errorf("%s is not an analysis", name)
+		}
+		if dci, ok := leaf.(DependencyConfigurablePipelineItem); ok {
+			if err := dci.ConfigureDependencies(facts); err != nil {
+				return nil, nil, fmt.Errorf("%s failed to configure: %v", name, err)
+			}
+		}
+		deployed = append(deployed, pipeline.DeployItem(leaf).(LeafPipelineItem))
+	}
+	if err := pipeline.Initialize(facts); err != nil {
+		return nil, nil, err
+	}
+	return pipeline, deployed, nil
+}
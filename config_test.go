@@ -0,0 +1,94 @@
+package hercules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+	"gopkg.in/src-d/hercules.v10/leaves"
+)
+
+func TestPipelineConfigToFacts(t *testing.T) {
+	assert.Len(t, PipelineConfig{}.ToFacts(), 0)
+	commits := []*object.Commit{}
+	logger := core.NewLogger()
+	facts := PipelineConfig{
+		Commits:               commits,
+		Logger:                logger,
+		TickSize:              time.Hour,
+		PeopleDictPath:        "people.txt",
+		Granularity:           10,
+		Sampling:              5,
+		TrackFiles:            true,
+		TrackPeople:           true,
+		HibernationDistance:   100,
+		MaxConcurrentBranches: 4,
+		SkipMerges:            true,
+		ContinueOnError:       true,
+		TimeSource:            TimeSourceAuthor,
+		Facts: map[string]interface{}{
+			leaves.ConfigBurndownSampling: 7,
+			"Custom":                      "value",
+		},
+	}.ToFacts()
+	assert.Equal(t, map[string]interface{}{
+		ConfigPipelineCommits: commits,
+		ConfigLogger:          logger,
+		ConfigTickSize:        time.Hour,
+		identity.ConfigIdentityDetectorPeopleDictPath: "people.txt",
+		leaves.ConfigBurndownGranularity:              10,
+		leaves.ConfigBurndownSampling:                 7,
+		leaves.ConfigBurndownTrackFiles:               true,
+		leaves.ConfigBurndownTrackPeople:              true,
+		core.ConfigPipelineHibernationDistance:        100,
+		core.ConfigPipelineMaxConcurrentBranches:      4,
+		ConfigPipelineSkipMerges:                      true,
+		ConfigPipelineContinueOnError:                 true,
+		ConfigTimeSource:                              TimeSourceAuthor,
+		"Custom":                                      "value",
+	}, facts)
+}
+
+func TestNewPipelineFromConfig(t *testing.T) {
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: time.Unix(1500000000, 0)}})
+	require.NoError(t, err)
+	commit, err := repository.CommitObject(hashes[0])
+	require.NoError(t, err)
+
+	pipeline, deployed, err := NewPipelineFromConfig(repository, PipelineConfig{
+		Analyses:            []string{"Burndown", "DevCadence"},
+		Commits:             []*object.Commit{commit},
+		Granularity:         10,
+		Sampling:            10,
+		TrackFiles:          true,
+		HibernationDistance: 50,
+		SkipMerges:          true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 50, pipeline.HibernationDistance)
+	assert.True(t, pipeline.SkipMerges)
+	require.Len(t, deployed, 2)
+	assert.Equal(t, "Burndown", deployed[0].Name())
+	assert.Equal(t, "DevCadence", deployed[1].Name())
+	burndown := deployed[0].(*leaves.BurndownAnalysis)
+	assert.Equal(t, 10, burndown.Granularity)
+	assert.Equal(t, 10, burndown.Sampling)
+	assert.True(t, burndown.TrackFiles)
+	assert.Equal(t, 0, burndown.PeopleNumber)
+
+	_, _, err = NewPipelineFromConfig(repository, PipelineConfig{Analyses: []string{"Whatever"}})
+	assert.EqualError(t, err, "unknown analysis: Whatever")
+	_, _, err = NewPipelineFromConfig(repository, PipelineConfig{Analyses: []string{"TreeDiff"}})
+	assert.EqualError(t, err, "TreeDiff is not an analysis")
+	_, _, err = NewPipelineFromConfig(repository, PipelineConfig{
+		Analyses: []string{"Shotness"},
+		Facts:    map[string]interface{}{leaves.ConfigShotnessBackend: "whatever"},
+	})
+	assert.Error(t, err)
+}
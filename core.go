@@ -1,6 +1,9 @@
 package hercules
 
 import (
+	"io"
+	"time"
+
 	"github.com/spf13/pflag"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
@@ -27,6 +30,8 @@ const (
 	FloatConfigurationOption = core.FloatConfigurationOption
 	// StringsConfigurationOption reflects the array of strings value type.
 	StringsConfigurationOption = core.StringsConfigurationOption
+	// PathConfigurationOption reflects the file system path value type.
+	PathConfigurationOption = core.PathConfigurationOption
 	// MessageFinalize is the status text reported before calling LeafPipelineItem.Finalize()-s.
 	MessageFinalize = core.MessageFinalize
 )
@@ -40,15 +45,33 @@ type PipelineItem = core.PipelineItem
 // FeaturedPipelineItem enables switching the automatic insertion of pipeline items on or off.
 type FeaturedPipelineItem = core.FeaturedPipelineItem
 
+// DependencyConfigurablePipelineItem is a PipelineItem whose Requires() and Features() depend on
+// the configuration.
+type DependencyConfigurablePipelineItem = core.DependencyConfigurablePipelineItem
+
+// FileFilterPipelineItem is the optional interface of PipelineItem-s which skip some of the files.
+type FileFilterPipelineItem = core.FileFilterPipelineItem
+
 // LeafPipelineItem corresponds to the top level pipeline items which produce the end results.
 type LeafPipelineItem = core.LeafPipelineItem
 
+// MergeConsumingPipelineItem is the optional interface of LeafPipelineItem-s which keep
+// consuming the merge commits with ConfigPipelineSkipMerges.
+type MergeConsumingPipelineItem = core.MergeConsumingPipelineItem
+
 // ResultMergeablePipelineItem specifies the methods to combine several analysis results together.
 type ResultMergeablePipelineItem = core.ResultMergeablePipelineItem
 
+// SQLiteSerializer is the optional interface of LeafPipelineItem-s which export their results
+// to SQLite.
+type SQLiteSerializer = core.SQLiteSerializer
+
 // CommonAnalysisResult holds the information which is always extracted at Pipeline.Run().
 type CommonAnalysisResult = core.CommonAnalysisResult
 
+// ResultSink receives the analysis results after Pipeline.Run().
+type ResultSink = core.ResultSink
+
 // NoopMerger provides an empty Merge() method suitable for PipelineItem.
 type NoopMerger = core.NoopMerger
 
@@ -60,6 +83,17 @@ func MetadataToCommonAnalysisResult(meta *core.Metadata) *CommonAnalysisResult {
 	return core.MetadataToCommonAnalysisResult(meta)
 }
 
+// ParseTickSize converts the human readable tick size such as "12h", "7d" or "week" to time.Duration.
+func ParseTickSize(value string) (time.Duration, error) {
+	return plumbing.ParseTickSize(value)
+}
+
+// CheckCompatibility returns an error if the results with the specified header cannot be read
+// by this version of Hercules.
+func CheckCompatibility(meta *core.Metadata) error {
+	return core.CheckCompatibility(meta)
+}
+
 // Pipeline is the core Hercules entity which carries several PipelineItems and executes them.
 // See the extended example of how a Pipeline works in doc.go
 type Pipeline = core.Pipeline
@@ -76,11 +110,71 @@ const (
 	// Pipeline initialization.
 	// Subsequent Run() calls are going to fail. Useful with ConfigPipelineDAGPath=true.
 	ConfigPipelineDryRun = core.ConfigPipelineDryRun
+	// ConfigPipelinePrintDAG is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which enables printing the topologically sorted items with their dependencies to stdout.
+	ConfigPipelinePrintDAG = core.ConfigPipelinePrintDAG
+	// ConfigPipelineAuthorInclude is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the regular expression to match the author emails of the analyzed commits.
+	ConfigPipelineAuthorInclude = core.ConfigPipelineAuthorInclude
+	// ConfigPipelineAuthorExclude is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the regular expression to match the author emails of the skipped commits.
+	ConfigPipelineAuthorExclude = core.ConfigPipelineAuthorExclude
+	// ConfigPipelineSkipMerges is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which disables Consume() of the merge commits in the leaf items.
+	ConfigPipelineSkipMerges = core.ConfigPipelineSkipMerges
+	// ConfigPipelineContinueOnError is the name of the Pipeline configuration option
+	// (Pipeline.Initialize()) which skips the commits which fail to be analysed instead of aborting.
+	ConfigPipelineContinueOnError = core.ConfigPipelineContinueOnError
+	// ConfigTimeSource is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which selects the commit timestamp for the ticks and the time range of the analysis.
+	ConfigTimeSource = core.ConfigTimeSource
+	// TimeSourceCommitter makes ConfigTimeSource use the committer timestamps, the default.
+	TimeSourceCommitter = core.TimeSourceCommitter
+	// TimeSourceAuthor makes ConfigTimeSource use the author timestamps.
+	TimeSourceAuthor = core.TimeSourceAuthor
+	// FactPipelineSkippedCommits is the name of the fact which maps the commits skipped by
+	// the author filters to their parents.
+	FactPipelineSkippedCommits = core.FactPipelineSkippedCommits
+	// FactPipelineFailedCommits is the name of the fact which collects the commits which
+	// failed to be analysed with ConfigPipelineContinueOnError.
+	FactPipelineFailedCommits = core.FactPipelineFailedCommits
+	// FactPipelineSkewedCommits is the name of the fact which collects the commits with
+	// out-of-order timestamps.
+	FactPipelineSkewedCommits = core.FactPipelineSkewedCommits
 	// ConfigPipelineCommits is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which allows to specify the custom commit sequence. By default, Pipeline.Commits() is used.
 	ConfigPipelineCommits = core.ConfigPipelineCommits
-	// ConfigTickSize is the number of hours per 'tick'
+	// SchemaVersion is the version of the Protocol Buffers results format written to Metadata.Version.
+	SchemaVersion = core.SchemaVersion
+	// MinimumSchemaVersion is the oldest Metadata.Version which can be read.
+	MinimumSchemaVersion = core.MinimumSchemaVersion
+	// PackedCommitsMagic is the header of the packed binary commits list format.
+	PackedCommitsMagic = core.PackedCommitsMagic
+	// ConfigTickSize is the size of each 'tick': the number of hours, a time.Duration or a string
+	// which is parsed with ParseTickSize().
 	ConfigTickSize = plumbing.ConfigTicksSinceStartTickSize
+	// ConfigPipelinePathPrefix is the name of the configuration option which limits
+	// the analysis to the files under the specified directory.
+	ConfigPipelinePathPrefix = plumbing.ConfigTreeDiffPathPrefix
+	// ConfigPipelineExcludeGlobs is the name of the configuration option which sets
+	// the glob patterns of the files to exclude from the analysis.
+	ConfigPipelineExcludeGlobs = plumbing.ConfigTreeDiffExcludeGlobs
+	// ConfigPipelineIncludeExtensions is the name of the configuration option which sets
+	// the file extensions to analyze, the rest of the files are ignored.
+	ConfigPipelineIncludeExtensions = plumbing.ConfigTreeDiffIncludeExtensions
+	// ConfigPipelineHandleSubmodules is the name of the configuration option which sets
+	// whether the submodule pointers are ignored or counted as files.
+	ConfigPipelineHandleSubmodules = plumbing.ConfigTreeDiffHandleSubmodules
+	// ConfigRenameDetectionThreshold is the name of the configuration option which sets
+	// the similarity threshold (0-100) to detect renames. Lowering it catches more refactorings
+	// but slows down the analysis.
+	ConfigRenameDetectionThreshold = plumbing.ConfigRenameAnalysisSimilarityThreshold
+	// ConfigRenameDetectionMaxFiles is the name of the configuration option which limits
+	// the number of added and deleted files in a commit to search for inexact renames.
+	ConfigRenameDetectionMaxFiles = plumbing.ConfigRenameAnalysisMaxFiles
+	// ConfigDetectCopies is the name of the configuration option which enables the detection
+	// of the copied files with the identical contents, so that they keep the line history.
+	ConfigDetectCopies = plumbing.ConfigRenameAnalysisDetectCopies
 	// ConfigLogger is used to set the logger in all pipeline items.
 	ConfigLogger = core.ConfigLogger
 )
@@ -91,11 +185,17 @@ func NewPipeline(repository *git.Repository) *Pipeline {
 }
 
 // LoadCommitsFromFile reads the file by the specified FS path and generates the sequence of commits
-// by interpreting each line as a Git commit hash.
+// by interpreting each line as a Git commit hash. "-" means the standard input.
 func LoadCommitsFromFile(path string, repository *git.Repository) ([]*object.Commit, error) {
 	return core.LoadCommitsFromFile(path, repository)
 }
 
+// LoadCommitsFromReader reads the commit hashes from `reader` either in the text format (one hash
+// per line) or in the packed binary format which starts with PackedCommitsMagic.
+func LoadCommitsFromReader(reader io.Reader, repository *git.Repository) ([]*object.Commit, error) {
+	return core.LoadCommitsFromReader(reader, repository)
+}
+
 // ForkSamePipelineItem clones items by referencing the same origin.
 func ForkSamePipelineItem(origin PipelineItem, n int) []PipelineItem {
 	return core.ForkSamePipelineItem(origin, n)
@@ -106,12 +206,45 @@ func ForkCopyPipelineItem(origin PipelineItem, n int) []PipelineItem {
 	return core.ForkCopyPipelineItem(origin, n)
 }
 
+// RunActionType is the kind of a RunAction.
+type RunActionType = core.RunActionType
+
+const (
+	// RunActionCommit consumes the commit in the branch.
+	RunActionCommit = core.RunActionCommit
+	// RunActionFork splits the first branch into the listed branches.
+	RunActionFork = core.RunActionFork
+	// RunActionMerge merges the listed branches together.
+	RunActionMerge = core.RunActionMerge
+	// RunActionEmerge starts a root branch.
+	RunActionEmerge = core.RunActionEmerge
+	// RunActionDelete removes the branch as it is no longer needed.
+	RunActionDelete = core.RunActionDelete
+	// RunActionHibernate preserves the items in the branch.
+	RunActionHibernate = core.RunActionHibernate
+	// RunActionBoot recovers the items in the branch after RunActionHibernate.
+	RunActionBoot = core.RunActionBoot
+)
+
+// RunAction is a single step of the plan which Pipeline.Run() executes.
+type RunAction = core.RunAction
+
+// BuildRunPlan returns the plan which Pipeline.Run() would execute on the specified commits
+// without running anything.
+func BuildRunPlan(commits []*object.Commit, hibernationDistance int) []RunAction {
+	return core.BuildRunPlan(commits, hibernationDistance)
+}
+
 // PipelineItemRegistry contains all the known PipelineItem-s.
 type PipelineItemRegistry = core.PipelineItemRegistry
 
 // Registry contains all known pipeline item types.
 var Registry = core.Registry
 
+// ErrEmptyRepository is returned by Pipeline.Commits(), Pipeline.HeadCommit() and Pipeline.Run()
+// if there is nothing to analyse.
+var ErrEmptyRepository = core.ErrEmptyRepository
+
 const (
 	// DependencyCommit is the name of one of the three items in `deps` supplied to PipelineItem.Consume()
 	// which always exists. It corresponds to the currently analyzed commit.
@@ -130,10 +263,16 @@ const (
 	// DependencyTick is the name of the dependency which TicksSinceStart provides - the number
 	// of ticks since the first commit in the analysed sequence.
 	DependencyTick = plumbing.DependencyTick
+	// DependencyTickTime is the name of the dependency which TicksSinceStart provides - the time
+	// when the current tick starts.
+	DependencyTickTime = plumbing.DependencyTickTime
 	// DependencyFileDiff is the name of the dependency provided by FileDiff.
 	DependencyFileDiff = plumbing.DependencyFileDiff
 	// DependencyTreeChanges is the name of the dependency provided by TreeDiff.
 	DependencyTreeChanges = plumbing.DependencyTreeChanges
+	// DependencyBranchDepth is the name of the dependency provided by BranchDepth - the number
+	// of commits since the last fork on the current branch.
+	DependencyBranchDepth = plumbing.DependencyBranchDepth
 	// DependencyUastChanges is the name of the dependency provided by Changes.
 	DependencyUastChanges = uast.DependencyUastChanges
 	// DependencyUasts is the name of the dependency provided by Extractor.
@@ -182,3 +321,6 @@ type Logger core.Logger
 
 // NewLogger returns an instance of the default Hercules logger
 func NewLogger() core.Logger { return core.NewLogger() }
+
+// NewJSONLogger returns an instance of the structured Hercules logger which writes JSON lines.
+func NewJSONLogger(writer io.Writer) core.Logger { return core.NewJSONLogger(writer) }
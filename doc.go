@@ -29,7 +29,11 @@ Then add the required analysis:
 This call will add all the needed intermediate pipeline items. Then link and execute the analysis tree:
 
   pipeline.Initialize(nil)
-  result, err := pipeline.Run(pipeline.Commits(false))
+  result, err := pipeline.Run(nil)
+
+Run(nil) analyses the commits which Initialize() listed with Pipeline.Commits(false).
+To analyse a custom sequence, set hercules.ConfigPipelineCommits in the facts like the command
+line tool does; passing the commits to Run() at the same time is an error.
 
 Finally extract the result:
 
@@ -46,6 +50,14 @@ to provide your own logger, enable people-tracking, and set a custom tick size:
     leaves.ConfigBurndownTrackPeople: true,
   })
 
+The same can be achieved with the typed PipelineConfig which deploys the analyses by name:
+
+  pipeline, analyses, err := hercules.NewPipelineFromConfig(repository, hercules.PipelineConfig{
+    Analyses:    []string{"Burndown"},
+    TickSize:    12 * time.Hour,
+    TrackPeople: true,
+  })
+
 Hercules depends heavily on https://github.com/src-d/go-git and leverages the
 diff algorithm through https://github.com/sergi/go-diff.
 
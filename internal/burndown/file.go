@@ -116,6 +116,12 @@ func (file *File) CloneDeep(allocator *rbtree.Allocator) *File {
 	return &File{tree: file.tree.CloneDeep(allocator), updaters: file.updaters}
 }
 
+// CloneDeepWithUpdaters copies the file like CloneDeep() but attaches `updaters` instead of
+// the original ones. The updaters are not notified about the copied lines.
+func (file *File) CloneDeepWithUpdaters(allocator *rbtree.Allocator, updaters ...Updater) *File {
+	return &File{tree: file.tree.CloneDeep(allocator), updaters: updaters}
+}
+
 // Delete deallocates the file.
 func (file *File) Delete() {
 	file.tree.Erase()
@@ -145,6 +145,23 @@ func TestCloneFileDeep(t *testing.T) {
 	assert.Equal(t, "0 0\n20 4\n30 1\n45 6\n50 0\n125 -1\n", dump)
 }
 
+func TestCloneFileDeepWithUpdaters(t *testing.T) {
+	file, status, _ := fixtureFile()
+	file.Update(4, 20, 10, 0)
+	// 0 0 | 20 4 | 30 0 | 110 -1               [0]: 100, [4]: 10
+	cloneStatus := map[int]int64{}
+	clone := file.CloneDeepWithUpdaters(rbtree.NewAllocator(), func(a, b, c int) {
+		updateStatusFile(cloneStatus, a, b, c)
+	})
+	assert.Len(t, cloneStatus, 0)
+	clone.Update(5, 0, 0, 20)
+	// 0 4 | 10 0 | 90 -1                       [0]: -20
+	assert.Equal(t, map[int]int64{0: -20}, cloneStatus)
+	assert.Equal(t, map[int]int64{0: 100, 4: 10}, status)
+	assert.Equal(t, "0 0\n20 4\n30 0\n110 -1\n", file.Dump())
+	assert.Equal(t, "0 4\n10 0\n90 -1\n", clone.Dump())
+}
+
 func TestLenFile(t *testing.T) {
 	file, _, _ := fixtureFile()
 	assert.Equal(t, 100, file.Len())
@@ -145,6 +145,19 @@ func mergeItems(branches [][]PipelineItem) {
 	}
 }
 
+// releaseItems calls Release() on the discarded forks. The items which are also present
+// in `kept` at the same position are skipped; `kept` may be nil.
+func releaseItems(discarded []PipelineItem, kept []PipelineItem) {
+	for i, item := range discarded {
+		if kept != nil && kept[i] == item {
+			continue
+		}
+		if releasable, ok := item.(ReleasablePipelineItem); ok {
+			releasable.Release()
+		}
+	}
+}
+
 // getMasterBranch returns the branch with the smallest index.
 func getMasterBranch(branches map[int][]PipelineItem) []PipelineItem {
 	minKey := 1 << 31
@@ -158,8 +171,9 @@ func getMasterBranch(branches map[int][]PipelineItem) []PipelineItem {
 	return minVal
 }
 
-// prepareRunPlan schedules the actions for Pipeline.Run().
-func prepareRunPlan(commits []*object.Commit, hibernationDistance int,
+// prepareRunPlan schedules the actions for Pipeline.Run(). maxBranches limits the number of
+// branches which exist at the same time, see limitLiveBranches(); 0 disables the limit.
+func prepareRunPlan(commits []*object.Commit, hibernationDistance int, maxBranches int,
 	printResult bool) []runAction {
 	hashes, dag := buildDag(commits)
 	leaveRootComponent(hashes, dag)
@@ -175,6 +189,19 @@ func prepareRunPlan(commits []*object.Commit, hibernationDistance int,
 		}
 	}
 	fmt.Printf("}\n")*/
+	if maxBranches > 0 {
+		order, peak := limitLiveBranches(orderNodes(false, true), hashes, mergedDag, maxBranches)
+		if peak > maxBranches {
+			log.Printf("warning: the commit graph requires %d concurrent branches, "+
+				"more than the limit of %d", peak, maxBranches)
+		}
+		orderNodes = func(reverse, direction bool) []string {
+			if reverse || !direction {
+				panic("the limited order is only available from parents to children")
+			}
+			return order
+		}
+	}
 	plan := generatePlan(orderNodes, hashes, mergedDag, dag, mergedSeq)
 	plan = collectGarbage(plan)
 	if hibernationDistance > 0 {
@@ -188,6 +215,74 @@ func prepareRunPlan(commits []*object.Commit, hibernationDistance int,
 	return plan
 }
 
+// RunActionType is the kind of a RunAction.
+type RunActionType int
+
+const (
+	// RunActionCommit consumes the commit in the branch.
+	RunActionCommit RunActionType = runActionCommit
+	// RunActionFork splits the first branch into the listed branches.
+	RunActionFork RunActionType = runActionFork
+	// RunActionMerge merges the listed branches together.
+	RunActionMerge RunActionType = runActionMerge
+	// RunActionEmerge starts a root branch.
+	RunActionEmerge RunActionType = runActionEmerge
+	// RunActionDelete removes the branch as it is no longer needed.
+	RunActionDelete RunActionType = runActionDelete
+	// RunActionHibernate preserves the items in the branch.
+	RunActionHibernate RunActionType = runActionHibernate
+	// RunActionBoot recovers the items in the branch after RunActionHibernate.
+	RunActionBoot RunActionType = runActionBoot
+)
+
+// String returns the lowercase name of the action, e.g. "fork".
+func (rat RunActionType) String() string {
+	switch rat {
+	case RunActionCommit:
+		return "commit"
+	case RunActionFork:
+		return "fork"
+	case RunActionMerge:
+		return "merge"
+	case RunActionEmerge:
+		return "emerge"
+	case RunActionDelete:
+		return "delete"
+	case RunActionHibernate:
+		return "hibernate"
+	case RunActionBoot:
+		return "boot"
+	}
+	return ""
+}
+
+// RunAction is a single step of the plan which Pipeline.Run() executes.
+type RunAction struct {
+	// Action is the kind of the step.
+	Action RunActionType
+	// Commit is the hash of the consumed commit, or of the commit which caused the fork,
+	// the merge or the emerge. It is zero for the other actions.
+	Commit plumbing.Hash
+	// Items are the indexes of the affected branches. RunActionCommit has a single branch,
+	// RunActionFork lists the origin first.
+	Items []int
+}
+
+// BuildRunPlan returns the plan which Pipeline.Run() would execute on the specified commits
+// without running anything. It allows to estimate how many forks, merges and commits
+// the analysis entails. hibernationDistance is the same as Pipeline.HibernationDistance.
+func BuildRunPlan(commits []*object.Commit, hibernationDistance int) []RunAction {
+	plan := prepareRunPlan(commits, hibernationDistance, 0, false)
+	result := make([]RunAction, len(plan))
+	for i, action := range plan {
+		result[i] = RunAction{Action: RunActionType(action.Action), Items: action.Items}
+		if action.Commit != nil {
+			result[i].Commit = action.Commit.Hash
+		}
+	}
+	return result
+}
+
 // printAction prints the specified action to stderr.
 func printAction(p runAction) {
 	firstItem := p.Items[0]
@@ -356,6 +451,65 @@ func bindOrderNodes(mergedDag map[plumbing.Hash][]*object.Commit) orderer {
 	}
 }
 
+// limitLiveBranches reorders the topologically sorted nodes so that the number of branches which
+// exist at the same time does not exceed `maxBranches` if possible. The original order is kept
+// while it fits the limit; otherwise, the nodes which fork the least are processed first,
+// so that the existing branches advance and merge before the new ones are forked.
+// Besides the new order, it returns the peak number of the branches.
+func limitLiveBranches(order []string, hashes map[string]*object.Commit,
+	mergedDag map[plumbing.Hash][]*object.Commit, maxBranches int) ([]string, int) {
+
+	parents := buildParents(mergedDag)
+	positions := make(map[string]int, len(order))
+	pending := make(map[plumbing.Hash]int, len(order))
+	// ready are the nodes with all the parents processed, sorted by their positions in `order`
+	var ready []string
+	for i, name := range order {
+		positions[name] = i
+		hash := hashes[name].Hash
+		pending[hash] = len(parents[hash])
+		if pending[hash] == 0 {
+			ready = append(ready, name)
+		}
+	}
+	// delta is the change of the number of branches after processing the node
+	delta := func(name string) int {
+		hash := hashes[name].Hash
+		return len(mergedDag[hash]) - len(parents[hash])
+	}
+	result := make([]string, 0, len(order))
+	live, peak := 0, 0
+	for len(ready) > 0 {
+		chosen := 0
+		if live+delta(ready[0]) > maxBranches {
+			for i, name := range ready {
+				if delta(name) < delta(ready[chosen]) {
+					chosen = i
+				}
+			}
+		}
+		name := ready[chosen]
+		ready = append(ready[:chosen], ready[chosen+1:]...)
+		result = append(result, name)
+		if live += delta(name); live > peak {
+			peak = live
+		}
+		for _, child := range mergedDag[hashes[name].Hash] {
+			if pending[child.Hash]--; pending[child.Hash] > 0 {
+				continue
+			}
+			childName := child.Hash.String()
+			index := sort.Search(len(ready), func(i int) bool {
+				return positions[ready[i]] > positions[childName]
+			})
+			ready = append(ready, "")
+			copy(ready[index+1:], ready[index:])
+			ready[index] = childName
+		}
+	}
+	return result, peak
+}
+
 // inverts `dag`
 func buildParents(dag map[plumbing.Hash][]*object.Commit) map[plumbing.Hash]map[plumbing.Hash]bool {
 	parents := map[plumbing.Hash]map[plumbing.Hash]bool{}
@@ -2,10 +2,13 @@ package core
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/hercules.v10/internal/test"
 )
 
@@ -119,3 +122,131 @@ func TestRunActionString(t *testing.T) {
 	ra = runAction{runActionBoot, nil, nil}
 	assert.Equal(t, ra.String(), "boot")
 }
+
+func TestPrepareRunPlanSingleCommit(t *testing.T) {
+	commit := &object.Commit{Hash: plumbing.NewHash("cce947b98a050c6d356bc6ba95030254914027b1")}
+	plan := prepareRunPlan([]*object.Commit{commit}, 0, 0, false)
+	assert.Len(t, plan, 2)
+	assert.Equal(t, runActionEmerge, plan[0].Action)
+	assert.Equal(t, commit, plan[0].Commit)
+	assert.Equal(t, runActionCommit, plan[1].Action)
+	assert.Equal(t, commit, plan[1].Commit)
+	assert.Equal(t, plan[0].Items, plan[1].Items)
+}
+
+// newWideRepository creates the in-memory repository where the root forks into two branches
+// and each of them forks into two more branches before merging back.
+func newWideRepository(t *testing.T) (*git.Repository, []*object.Commit) {
+	var fakeCommits []test.FakeCommit
+	for i, parents := range [][]int{
+		{}, {0}, {0}, {1}, {1}, {3, 4}, {2}, {2}, {6, 7}, {5, 8}} {
+		fakeCommits = append(fakeCommits, test.FakeCommit{
+			Author: "one", When: time.Unix(int64(1500000000+i*3600), 0), Parents: parents})
+	}
+	repository, hashes, err := test.NewMemoryRepository(fakeCommits)
+	require.NoError(t, err)
+	commits := make([]*object.Commit, len(hashes))
+	for i, hash := range hashes {
+		commits[i], err = repository.CommitObject(hash)
+		require.NoError(t, err)
+	}
+	return repository, commits
+}
+
+// countLiveBranches returns the peak number of branches which exist at the same time in the plan.
+func countLiveBranches(plan []runAction) int {
+	live := map[int]bool{}
+	peak := 0
+	for _, action := range plan {
+		switch action.Action {
+		case runActionEmerge, runActionFork:
+			for _, item := range action.Items {
+				live[item] = true
+			}
+		case runActionDelete:
+			for _, item := range action.Items {
+				delete(live, item)
+			}
+		}
+		if len(live) > peak {
+			peak = len(live)
+		}
+	}
+	return peak
+}
+
+func TestPrepareRunPlanMaxBranches(t *testing.T) {
+	_, commits := newWideRepository(t)
+	unlimited := prepareRunPlan(commits, 0, 0, false)
+	assert.Equal(t, 4, countLiveBranches(unlimited))
+	limited := prepareRunPlan(commits, 0, 3, false)
+	assert.Equal(t, 3, countLiveBranches(limited))
+	countCommits := func(plan []runAction) map[plumbing.Hash]int {
+		counts := map[plumbing.Hash]int{}
+		for _, action := range plan {
+			if action.Action == runActionCommit {
+				counts[action.Commit.Hash]++
+			}
+		}
+		return counts
+	}
+	assert.Equal(t, countCommits(unlimited), countCommits(limited))
+	// the limit cannot be reached: the root forks into two branches and one of them into two more
+	assert.Equal(t, 3, countLiveBranches(prepareRunPlan(commits, 0, 1, false)))
+}
+
+func TestPipelineRunMaxBranches(t *testing.T) {
+	repository, commits := newWideRepository(t)
+	pipeline := NewPipeline(repository)
+	item := &mergeCountingPipelineItem{}
+	pipeline.AddItem(item)
+	assert.Error(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits:               commits,
+		ConfigPipelineMaxConcurrentBranches: -1,
+	}))
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits:               commits,
+		ConfigPipelineMaxConcurrentBranches: 3,
+	}))
+	assert.Equal(t, 3, pipeline.MaxConcurrentBranches)
+	_, err := pipeline.Run(nil)
+	assert.NoError(t, err)
+	assert.True(t, item.Merges > 0)
+	assert.Equal(t, 7+item.Merges, item.Commits)
+}
+
+func TestBuildRunPlan(t *testing.T) {
+	_, commits := newWideRepository(t)
+	plan := BuildRunPlan(commits, 0)
+	counts := map[RunActionType]int{}
+	consumed := map[plumbing.Hash]bool{}
+	for _, action := range plan {
+		counts[action.Action]++
+		assert.NotEmpty(t, action.Items)
+		switch action.Action {
+		case RunActionCommit:
+			assert.Len(t, action.Items, 1)
+			consumed[action.Commit] = true
+		case RunActionDelete:
+			assert.True(t, action.Commit.IsZero())
+		}
+	}
+	assert.Equal(t, 1, counts[RunActionEmerge])
+	assert.Equal(t, 3, counts[RunActionFork])
+	assert.Equal(t, 3, counts[RunActionMerge])
+	assert.Len(t, consumed, len(commits))
+	for _, commit := range commits {
+		assert.True(t, consumed[commit.Hash])
+	}
+	assert.Zero(t, counts[RunActionHibernate])
+	hibernated := BuildRunPlan(commits, 1)
+	counts = map[RunActionType]int{}
+	for _, action := range hibernated {
+		counts[action.Action]++
+	}
+	assert.NotZero(t, counts[RunActionHibernate])
+	assert.NotZero(t, counts[RunActionBoot])
+	assert.Equal(t, "fork", RunActionFork.String())
+	assert.Equal(t, "boot", RunActionBoot.String())
+	assert.Equal(t, "", RunActionType(100).String())
+}
@@ -1,10 +1,15 @@
 package core
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"log"
 	"os"
 	"runtime/debug"
 	"strings"
+	"sync"
+	"time"
 )
 
 // ConfigLogger is the key for the pipeline's logger
@@ -80,6 +85,76 @@ func (d *DefaultLogger) logStacktraceToErr() {
 	d.E.Println("stacktrace:\n" + strings.Join(captureStacktrace(4), "\n"))
 }
 
+// JSONLogger is the structured logger which writes each message as a separate JSON object
+// on a single line. The objects have "level", "message" and "time" fields; critical messages
+// additionally carry "stacktrace".
+type JSONLogger struct {
+	writer io.Writer
+	lock   sync.Mutex
+}
+
+// jsonLogRecord is the JSON object written by JSONLogger.
+type jsonLogRecord struct {
+	Level      string   `json:"level"`
+	Message    string   `json:"message"`
+	Time       string   `json:"time"`
+	Stacktrace []string `json:"stacktrace,omitempty"`
+}
+
+// NewJSONLogger returns a logger which writes JSON lines to the specified writer.
+func NewJSONLogger(writer io.Writer) Logger {
+	return &JSONLogger{writer: writer}
+}
+
+// Info writes an "info" message.
+func (j *JSONLogger) Info(v ...interface{}) { j.write("info", fmt.Sprintln(v...), nil) }
+
+// Infof writes an "info" message with printf-style formatting.
+func (j *JSONLogger) Infof(f string, v ...interface{}) { j.write("info", fmt.Sprintf(f, v...), nil) }
+
+// Warn writes a "warning" message.
+func (j *JSONLogger) Warn(v ...interface{}) { j.write("warning", fmt.Sprintln(v...), nil) }
+
+// Warnf writes a "warning" message with printf-style formatting.
+func (j *JSONLogger) Warnf(f string, v ...interface{}) {
+	j.write("warning", fmt.Sprintf(f, v...), nil)
+}
+
+// Error writes an "error" message.
+func (j *JSONLogger) Error(v ...interface{}) { j.write("error", fmt.Sprintln(v...), nil) }
+
+// Errorf writes an "error" message with printf-style formatting.
+func (j *JSONLogger) Errorf(f string, v ...interface{}) {
+	j.write("error", fmt.Sprintf(f, v...), nil)
+}
+
+// Critical writes a "critical" message together with the current stacktrace.
+func (j *JSONLogger) Critical(v ...interface{}) {
+	j.write("critical", fmt.Sprintln(v...), captureStacktrace(3))
+}
+
+// Criticalf writes a "critical" message with printf-style formatting together with
+// the current stacktrace.
+func (j *JSONLogger) Criticalf(f string, v ...interface{}) {
+	j.write("critical", fmt.Sprintf(f, v...), captureStacktrace(3))
+}
+
+func (j *JSONLogger) write(level, message string, stacktrace []string) {
+	record, err := json.Marshal(jsonLogRecord{
+		Level:      level,
+		Message:    strings.TrimRight(message, "\n"),
+		Time:       time.Now().UTC().Format(time.RFC3339Nano),
+		Stacktrace: stacktrace,
+	})
+	if err != nil {
+		// cannot happen: all the fields are strings
+		panic(err)
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.writer.Write(append(record, '\n'))
+}
+
 func captureStacktrace(skip int) []string {
 	stack := string(debug.Stack())
 	lines := strings.Split(stack, "\n")
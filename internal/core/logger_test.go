@@ -2,7 +2,10 @@ package core
 
 import (
 	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -53,14 +56,59 @@ func TestLogger(t *testing.T) {
 	l.Critical(v...)
 	assert.Contains(t, eBuf.String(), "[ERROR]")
 	assert.Contains(t, eBuf.String(), "internal/core.TestLogger")
-	assert.Contains(t, eBuf.String(), "internal/core/logger_test.go:53")
+	assert.Contains(t, eBuf.String(), "internal/core/logger_test.go:56")
 	eBuf.Reset()
 
 	l.Criticalf(f, v...)
 	assert.Contains(t, eBuf.String(), "[ERROR]")
 	assert.Contains(t, eBuf.String(), "-")
 	assert.Contains(t, eBuf.String(), "internal/core.TestLogger")
-	assert.Contains(t, eBuf.String(), "internal/core/logger_test.go:59")
+	assert.Contains(t, eBuf.String(), "internal/core/logger_test.go:62")
 	println(eBuf.String())
 	eBuf.Reset()
 }
+
+func TestJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewJSONLogger(&buf)
+	parse := func() map[string]interface{} {
+		record := map[string]interface{}{}
+		assert.True(t, strings.HasSuffix(buf.String(), "}\n"))
+		assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		buf.Reset()
+		_, err := time.Parse(time.RFC3339Nano, record["time"].(string))
+		assert.NoError(t, err)
+		return record
+	}
+
+	l.Info("hello", "world")
+	record := parse()
+	assert.Equal(t, "info", record["level"])
+	assert.Equal(t, "hello world", record["message"])
+	assert.NotContains(t, record, "stacktrace")
+
+	l.Infof("%s-%s\n", "hello", "world")
+	assert.Equal(t, "hello-world", parse()["message"])
+
+	l.Warn("hello")
+	assert.Equal(t, "warning", parse()["level"])
+	l.Warnf("%d", 1)
+	assert.Equal(t, "1", parse()["message"])
+
+	l.Error("multi\nline")
+	record = parse()
+	assert.Equal(t, "error", record["level"])
+	assert.Equal(t, "multi\nline", record["message"])
+	l.Errorf("%s", "\"quoted\"")
+	assert.Equal(t, "\"quoted\"", parse()["message"])
+
+	l.Critical("hello")
+	record = parse()
+	assert.Equal(t, "critical", record["level"])
+	assert.Contains(t, record["stacktrace"].([]interface{})[0], "internal/core.TestJSONLogger")
+	l.Criticalf("%s", "hello")
+	record = parse()
+	assert.Equal(t, "critical", record["level"])
+	assert.Contains(t, record["stacktrace"].([]interface{})[0], "internal/core.TestJSONLogger")
+}
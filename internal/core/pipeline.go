@@ -2,20 +2,27 @@ package core
 
 import (
 	"bufio"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime/debug"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/go-git.v4/plumbing/storer"
 	"gopkg.in/src-d/hercules.v10/internal/pb"
@@ -128,6 +135,16 @@ type FeaturedPipelineItem interface {
 	Features() []string
 }
 
+// DependencyConfigurablePipelineItem is a PipelineItem whose Requires() and Features() depend on
+// the configuration. Such items must be configured with ConfigureDependencies() before
+// Pipeline.DeployItem().
+type DependencyConfigurablePipelineItem interface {
+	PipelineItem
+	// ConfigureDependencies sets the properties which Requires() and Features() depend on.
+	// Configure() is still called later with the same facts.
+	ConfigureDependencies(facts map[string]interface{}) error
+}
+
 // DisposablePipelineItem enables resources cleanup after finishing running the pipeline.
 type DisposablePipelineItem interface {
 	PipelineItem
@@ -139,6 +156,36 @@ type DisposablePipelineItem interface {
 	Dispose()
 }
 
+// RestorablePipelineItem is the optional interface of PipelineItem-s which are able to undo
+// a Consume() of the commit which fails to be analysed, see Pipeline.ContinueOnError.
+type RestorablePipelineItem interface {
+	PipelineItem
+	// Snapshot returns the copy of the state which the next Consume() is going to change.
+	// It is called before each commit, so it must be cheap.
+	Snapshot() interface{}
+	// Restore returns to the state saved by Snapshot().
+	Restore(snapshot interface{})
+}
+
+// ReleasablePipelineItem is the optional interface of PipelineItem-s which hold shared resources
+// in every fork, e.g. reference counted buffers. Pipeline.Run() calls Release() on the forks
+// which it discards before Dispose(). The items which fork with ForkSamePipelineItem must not
+// implement it.
+type ReleasablePipelineItem interface {
+	PipelineItem
+	// Release frees the resources which are held by this fork. The fork remains usable.
+	Release()
+}
+
+// FileFilterPipelineItem is the optional interface of PipelineItem-s which skip some of the files,
+// e.g. by path or by language. Pipeline.FilesAtHead() applies the deployed filters.
+type FileFilterPipelineItem interface {
+	PipelineItem
+	// FilterFile returns whether the file `name` with the tree `entry` which belongs to `tree`
+	// is going to be analysed. The item must be configured.
+	FilterFile(tree *object.Tree, name string, entry *object.TreeEntry) bool
+}
+
 // LeafPipelineItem corresponds to the top level pipeline items which produce the end results.
 type LeafPipelineItem interface {
 	PipelineItem
@@ -154,6 +201,15 @@ type LeafPipelineItem interface {
 	Serialize(result interface{}, binary bool, writer io.Writer) error
 }
 
+// MergeConsumingPipelineItem is the optional interface of LeafPipelineItem-s which keep
+// consuming the merge commits when Pipeline.SkipMerges is set because they reconcile
+// the branches in Merge() or follow the files through the merges.
+type MergeConsumingPipelineItem interface {
+	LeafPipelineItem
+	// ConsumesMerges returns true if the item must Consume() the merge commits.
+	ConsumesMerges() bool
+}
+
 // ResultMergeablePipelineItem specifies the methods to combine several analysis results together.
 type ResultMergeablePipelineItem interface {
 	LeafPipelineItem
@@ -163,6 +219,15 @@ type ResultMergeablePipelineItem interface {
 	MergeResults(r1, r2 interface{}, c1, c2 *CommonAnalysisResult) interface{}
 }
 
+// SQLiteSerializer is the optional interface of LeafPipelineItem-s which export their results
+// to SQLite, see `hercules --sqlite`.
+type SQLiteSerializer interface {
+	LeafPipelineItem
+	// SerializeSQLite creates the item's tables and inserts the object returned by Finalize().
+	// The "meta" (key, value) table already exists and may be appended to.
+	SerializeSQLite(result interface{}, tx *sql.Tx) error
+}
+
 // HibernateablePipelineItem is the interface to allow pipeline items to be frozen (compacted, unloaded)
 // while they are not needed in the hosting branch.
 type HibernateablePipelineItem interface {
@@ -173,6 +238,14 @@ type HibernateablePipelineItem interface {
 	Boot() error
 }
 
+// ResultSink receives the analysis results after Pipeline.Run(), e.g. to write them to a database
+// or to a message queue. Plugins register their sinks with Registry.RegisterSink() in init().
+type ResultSink interface {
+	// Consume receives the result of the LeafPipelineItem named `itemName` as returned by
+	// Finalize(). `common` is the same for all the items in the pipeline.
+	Consume(itemName string, result interface{}, common *CommonAnalysisResult) error
+}
+
 // CommonAnalysisResult holds the information which is always extracted at Pipeline.Run().
 type CommonAnalysisResult struct {
 	// BeginTime is the time of the first commit in the analysed sequence.
@@ -185,6 +258,14 @@ type CommonAnalysisResult struct {
 	RunTime time.Duration
 	// RunTimePerItem is the time elapsed by each PipelineItem.
 	RunTimePerItem map[string]float64
+	// SkewedCommits is the number of commits with out-of-order timestamps which distort the ticks.
+	SkewedCommits int
+	// FailedCommits is the number of commits which were skipped because some PipelineItem
+	// failed to Consume() them, see Pipeline.ContinueOnError.
+	FailedCommits int
+	// Config is the effective configuration of the analysis: the values of the configuration
+	// options of the deployed items and of the Pipeline, formatted with FormatConfigValue().
+	Config map[string]string
 }
 
 // Copy produces a deep clone of the object.
@@ -194,6 +275,12 @@ func (car CommonAnalysisResult) Copy() CommonAnalysisResult {
 	for key, val := range car.RunTimePerItem {
 		result.RunTimePerItem[key] = val
 	}
+	if car.Config != nil {
+		result.Config = map[string]string{}
+		for key, val := range car.Config {
+			result.Config[key] = val
+		}
+	}
 	return result
 }
 
@@ -208,8 +295,9 @@ func (car *CommonAnalysisResult) EndTimeAsTime() time.Time {
 }
 
 // Merge combines the CommonAnalysisResult with an other one.
-// We choose the earlier BeginTime, the later EndTime, sum the number of commits and the
-// elapsed run times.
+// We choose the earlier BeginTime, the later EndTime, sum the number of commits, the number
+// of skewed and failed commits and the elapsed run times. Config keeps only the options
+// which have the same values in both, since the rest cannot be reproduced.
 func (car *CommonAnalysisResult) Merge(other *CommonAnalysisResult) {
 	if car.EndTime == 0 || other.BeginTime == 0 {
 		panic("Merging with an uninitialized CommonAnalysisResult")
@@ -221,10 +309,17 @@ func (car *CommonAnalysisResult) Merge(other *CommonAnalysisResult) {
 		car.EndTime = other.EndTime
 	}
 	car.CommitsNumber += other.CommitsNumber
+	car.SkewedCommits += other.SkewedCommits
+	car.FailedCommits += other.FailedCommits
 	car.RunTime += other.RunTime
 	for key, val := range other.RunTimePerItem {
 		car.RunTimePerItem[key] += val
 	}
+	for key, val := range car.Config {
+		if otherVal, exists := other.Config[key]; !exists || otherVal != val {
+			delete(car.Config, key)
+		}
+	}
 }
 
 // FillMetadata copies the data to a Protobuf message.
@@ -234,12 +329,44 @@ func (car *CommonAnalysisResult) FillMetadata(meta *pb.Metadata) *pb.Metadata {
 	meta.Commits = int32(car.CommitsNumber)
 	meta.RunTime = car.RunTime.Nanoseconds() / 1e6
 	meta.RunTimePerItem = car.RunTimePerItem
+	meta.SkewedCommits = int32(car.SkewedCommits)
+	meta.FailedCommits = int32(car.FailedCommits)
+	meta.Config = car.Config
 	return meta
 }
 
 // Metadata is defined in internal/pb/pb.pb.go - header of the binary file.
 type Metadata = pb.Metadata
 
+const (
+	// SchemaVersion is the version of the Protocol Buffers results format written to Metadata.Version.
+	// It is above legacyCombinedSchemaVersion so that the newer formats are told apart.
+	SchemaVersion = 11
+	// MinimumSchemaVersion is the oldest Metadata.Version which can be read.
+	MinimumSchemaVersion = 1
+	// legacyCombinedSchemaVersion is the last version which the older binaries wrote to
+	// Metadata.Version: "hercules combine" wrote the binary version instead of the schema version.
+	// All the versions up to this one have the same format, which SchemaVersion continues.
+	legacyCombinedSchemaVersion = 10
+)
+
+// CheckCompatibility returns an error if the results with the specified header cannot be read
+// by this version of Hercules.
+func CheckCompatibility(meta *Metadata) error {
+	if meta == nil {
+		return errors.New("corrupted header")
+	}
+	if meta.Version > SchemaVersion {
+		return fmt.Errorf("the results schema version %d is newer than the supported %d, "+
+			"please upgrade Hercules", meta.Version, SchemaVersion)
+	}
+	if meta.Version < MinimumSchemaVersion {
+		return fmt.Errorf("the results schema version %d is older than the minimum supported %d",
+			meta.Version, MinimumSchemaVersion)
+	}
+	return nil
+}
+
 // MetadataToCommonAnalysisResult copies the data from a Protobuf message.
 func MetadataToCommonAnalysisResult(meta *Metadata) *CommonAnalysisResult {
 	return &CommonAnalysisResult{
@@ -248,7 +375,23 @@ func MetadataToCommonAnalysisResult(meta *Metadata) *CommonAnalysisResult {
 		CommitsNumber:  int(meta.Commits),
 		RunTime:        time.Duration(meta.RunTime * 1e6),
 		RunTimePerItem: meta.RunTimePerItem,
+		SkewedCommits:  int(meta.SkewedCommits),
+		FailedCommits:  int(meta.FailedCommits),
+		Config:         meta.Config,
+	}
+}
+
+// FormatConfigValue converts the value of a configuration option to string for
+// CommonAnalysisResult.Config. The lists are joined with commas. The second returned value
+// is false if the value is not serializable, e.g. a Logger.
+func FormatConfigValue(value interface{}) (string, bool) {
+	switch val := value.(type) {
+	case bool, int, int64, float32, float64, string, time.Duration:
+		return fmt.Sprint(val), true
+	case []string:
+		return strings.Join(val, ","), true
 	}
+	return "", false
 }
 
 // Pipeline is the core Hercules entity which carries several PipelineItems and executes them.
@@ -257,12 +400,28 @@ type Pipeline struct {
 	// OnProgress is the callback which is invoked in Analyse() to output it's
 	// progress. The first argument is the number of complete steps, the
 	// second is the total number of steps and the third is some description of the current action.
+	// The steps are weighted if WeightedProgress is true. Hibernating and booting
+	// the branches do not count.
 	OnProgress func(int, int, string)
 
+	// WeightedProgress makes each commit weigh 1 + the number of the changed files in OnProgress
+	// instead of 1, so that the ETA does not jump between the small and the big commits.
+	// The changes are counted by diffing every commit against its parent before the run,
+	// so it is off by default: the extra history walk is noticeable on big repositories.
+	WeightedProgress bool
+
+	// OnResult is the callback which is invoked in Run() right after each LeafPipelineItem
+	// finalizes. It allows to write the results before the rest of the items finalize.
+	OnResult func(LeafPipelineItem, interface{})
+
 	// HibernationDistance is the minimum number of actions between two sequential usages of
 	// a branch to activate the hibernation optimization (cpu-memory trade-off). 0 disables.
 	HibernationDistance int
 
+	// MaxConcurrentBranches limits the number of the branches which exist at the same time
+	// by reordering the commits, trading the parallelism for memory. 0 disables.
+	MaxConcurrentBranches int
+
 	// DryRun indicates whether the items are not executed.
 	DryRun bool
 
@@ -272,9 +431,60 @@ type Pipeline struct {
 	// PrintActions indicates whether to print the taken actions during the execution.
 	PrintActions bool
 
+	// PrintDAG indicates whether to print the resolved items DAG to stdout.
+	PrintDAG bool
+
+	// AuthorInclude limits the analysis to the commits with the matching author emails.
+	// Merge commits are never filtered. nil disables this filter.
+	AuthorInclude *regexp.Regexp
+
+	// AuthorExclude skips the commits with the matching author emails.
+	// Merge commits are never filtered. nil disables this filter.
+	AuthorExclude *regexp.Regexp
+
+	// SkipMerges indicates whether the leaf items do not Consume() the merge commits.
+	// The other items still process them to keep their state consistent, and so do
+	// the MergeConsumingPipelineItem-s which need the merges to reconcile the branches.
+	SkipMerges bool
+
+	// ContinueOnError makes Run() log the Consume() errors and skip the failed commits
+	// instead of aborting. The RestorablePipelineItem-s which have consumed a failed commit,
+	// including the failed one, restore their state, the items after the failed one do not
+	// consume it, and the rest keep it. The leaves which are not RestorablePipelineItem-s
+	// consume each commit after the rest of the items. The changes of a failed commit are
+	// never attributed to the next commits. The number of the failed commits is reported
+	// in CommonAnalysisResult.FailedCommits, see also FactPipelineFailedCommits.
+	ContinueOnError bool
+
+	// TimeSource is either TimeSourceCommitter or TimeSourceAuthor and selects the commit
+	// timestamp which defines CommonAnalysisResult.BeginTime and EndTime. See CommitTime().
+	TimeSource string
+
 	// Repository points to the analysed Git repository struct from go-git.
 	repository *git.Repository
 
+	// skippedCommits maps the commits filtered by author to their parents.
+	// It is shared with the items through FactPipelineSkippedCommits.
+	skippedCommits map[plumbing.Hash][]plumbing.Hash
+
+	// failedCommits are the commits which failed to be analysed with ContinueOnError.
+	// It is shared with the items through FactPipelineFailedCommits.
+	failedCommits map[plumbing.Hash]bool
+
+	// skewedCommits are the commits with out-of-order timestamps.
+	// It is shared with the items through FactPipelineSkewedCommits.
+	skewedCommits map[plumbing.Hash]bool
+
+	// commits is the value of ConfigPipelineCommits which Run(nil) analyses.
+	commits []*object.Commit
+
+	// explicitCommits indicates whether ConfigPipelineCommits was supplied to Initialize()
+	// rather than listed by it.
+	explicitCommits bool
+
+	// config is the effective configuration recorded in CommonAnalysisResult.Config.
+	config map[string]string
+
 	// Items are the registered building blocks in the pipeline. The order defines the
 	// execution sequence.
 	items []PipelineItem
@@ -291,7 +501,9 @@ type Pipeline struct {
 
 const (
 	// ConfigPipelineDAGPath is the name of the Pipeline configuration option (Pipeline.Initialize())
-	// which enables saving the items DAG to the specified file.
+	// which enables saving the items DAG to the specified file. The format is Graphviz unless
+	// the path ends with ".json", then the resolved items and the execution plan are written
+	// as JSON instead.
 	ConfigPipelineDAGPath = "Pipeline.DAGPath"
 	// ConfigPipelineDryRun is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which disables Configure() and Initialize() invocation on each PipelineItem during the
@@ -299,7 +511,9 @@ const (
 	// Subsequent Run() calls are going to fail. Useful with ConfigPipelineDAGPath=true.
 	ConfigPipelineDryRun = "Pipeline.DryRun"
 	// ConfigPipelineCommits is the name of the Pipeline configuration option (Pipeline.Initialize())
-	// which allows to specify the custom commit sequence. By default, Pipeline.Commits() is used.
+	// which allows to specify the custom commit sequence ([]*object.Commit). By default,
+	// Pipeline.Commits() is used. Pipeline.Run(nil) analyses this sequence; passing the commits
+	// to Run() as well is an error if the option was set explicitly.
 	ConfigPipelineCommits = "Pipeline.Commits"
 	// ConfigPipelineDumpPlan is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which outputs the execution plan to stderr.
@@ -308,9 +522,51 @@ const (
 	// which is the minimum number of actions between two sequential usages of
 	// a branch to activate the hibernation optimization (cpu-memory trade-off). 0 disables.
 	ConfigPipelineHibernationDistance = "Pipeline.HibernationDistance"
+	// ConfigPipelineMaxConcurrentBranches is the name of the Pipeline configuration option
+	// (Pipeline.Initialize()) which limits the number of the branches which exist at the same time.
+	// 0 disables.
+	ConfigPipelineMaxConcurrentBranches = "Pipeline.MaxConcurrentBranches"
 	// ConfigPipelinePrintActions is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which enables printing the taken actions of the execution plan to stderr.
 	ConfigPipelinePrintActions = "Pipeline.PrintActions"
+	// ConfigPipelinePrintDAG is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which enables printing the topologically sorted items with their dependencies to stdout.
+	ConfigPipelinePrintDAG = "Pipeline.PrintDAG"
+	// ConfigPipelineAuthorInclude is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the regular expression to match the author emails of the analyzed commits.
+	ConfigPipelineAuthorInclude = "Pipeline.AuthorInclude"
+	// ConfigPipelineAuthorExclude is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the regular expression to match the author emails of the skipped commits.
+	ConfigPipelineAuthorExclude = "Pipeline.AuthorExclude"
+	// ConfigPipelineSkipMerges is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which disables Consume() of the merge commits in the leaf items. It changes the burndown
+	// line totals and is only appropriate for the churn-style metrics.
+	ConfigPipelineSkipMerges = "Pipeline.SkipMerges"
+	// ConfigPipelineContinueOnError is the name of the Pipeline configuration option
+	// (Pipeline.Initialize()) which makes Run() skip the commits which fail to Consume()
+	// instead of aborting, see Pipeline.ContinueOnError.
+	ConfigPipelineContinueOnError = "Pipeline.ContinueOnError"
+	// ConfigTimeSource is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which selects the commit timestamp for the ticks and the time range of the analysis:
+	// TimeSourceCommitter (the default, like `git log`) or TimeSourceAuthor.
+	ConfigTimeSource = "Pipeline.TimeSource"
+	// TimeSourceCommitter makes ConfigTimeSource use the committer timestamps.
+	TimeSourceCommitter = "committer"
+	// TimeSourceAuthor makes ConfigTimeSource use the author timestamps. They are older than
+	// the committer timestamps of the rebased and cherry-picked commits.
+	TimeSourceAuthor = "author"
+	// FactPipelineSkippedCommits is the name of the fact which maps the commits skipped by
+	// the author filters to their parents. It is filled during Pipeline.Run(). The changes
+	// of the skipped commits are attributed to the next analyzed commits.
+	FactPipelineSkippedCommits = "Pipeline.SkippedCommits"
+	// FactPipelineFailedCommits is the name of the fact which collects the commits which
+	// failed to be analysed with ConfigPipelineContinueOnError. It is filled during
+	// Pipeline.Run(). Its size is reported in CommonAnalysisResult.FailedCommits.
+	FactPipelineFailedCommits = "Pipeline.FailedCommits"
+	// FactPipelineSkewedCommits is the name of the fact which collects the commits with
+	// out-of-order timestamps, see TicksSinceStart. Its size is reported in
+	// CommonAnalysisResult.SkewedCommits.
+	FactPipelineSkewedCommits = "Pipeline.SkewedCommits"
 	// DependencyCommit is the name of one of the three items in `deps` supplied to PipelineItem.Consume()
 	// which always exists. It corresponds to the currently analyzed commit.
 	DependencyCommit = "commit"
@@ -444,6 +700,10 @@ func (pipeline *Pipeline) Len() int {
 	return len(pipeline.items)
 }
 
+// ErrEmptyRepository is returned by Pipeline.Commits(), Pipeline.HeadCommit() and Pipeline.Run()
+// if there is nothing to analyse.
+var ErrEmptyRepository = errors.New("repository has no commits")
+
 // Commits returns the list of commits from the history similar to `git log` over the HEAD.
 // `firstParent` specifies whether to leave only the first parent after each merge
 // (`git log --first-parent`) - effectively decreasing the accuracy but increasing performance.
@@ -481,10 +741,20 @@ func (pipeline *Pipeline) Commits(firstParent bool) ([]*object.Commit, error) {
 	return result, err
 }
 
-// HeadCommit returns the latest commit in the repository (HEAD).
+// HeadCommit returns the latest commit in the repository (HEAD). The detached HEAD is used
+// as is, and if HEAD points to a missing branch, the last branch in the alphabetical order
+// is taken.
 func (pipeline *Pipeline) HeadCommit() ([]*object.Commit, error) {
 	repository := pipeline.repository
-	head, err := repository.Head()
+	var head *plumbing.Reference
+	var err error
+	if ref, errr := repository.Reference(plumbing.HEAD, false); errr == nil &&
+		ref.Type() == plumbing.HashReference {
+		// detached HEAD, e.g. after "git checkout <commit>" or in CI
+		head = ref
+	} else {
+		head, err = repository.Head()
+	}
 	if err == plumbing.ErrReferenceNotFound {
 		refs, errr := repository.References()
 		if errr != nil {
@@ -493,6 +763,10 @@ func (pipeline *Pipeline) HeadCommit() ([]*object.Commit, error) {
 		var refnames []string
 		refByName := map[string]*plumbing.Reference{}
 		err = refs.ForEach(func(ref *plumbing.Reference) error {
+			if ref.Type() != plumbing.HashReference {
+				// e.g. HEAD -> refs/heads/master in a freshly initialized repository
+				return nil
+			}
 			refname := ref.Name().String()
 			refnames = append(refnames, refname)
 			refByName[refname] = ref
@@ -502,6 +776,9 @@ func (pipeline *Pipeline) HeadCommit() ([]*object.Commit, error) {
 			}
 			return nil
 		})
+		if len(refnames) == 0 {
+			return nil, ErrEmptyRepository
+		}
 		if head == nil {
 			sort.Strings(refnames)
 			headName := refnames[len(refnames)-1]
@@ -514,11 +791,78 @@ func (pipeline *Pipeline) HeadCommit() ([]*object.Commit, error) {
 	}
 	commit, err := repository.CommitObject(head.Hash())
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrapf(err, "unable to load the HEAD commit %s", head.Hash().String())
 	}
 	return []*object.Commit{commit}, nil
 }
 
+// SingleCommit returns the commit which `revision` resolves to preceded by its first parent,
+// so that running the pipeline yields the changes of that commit alone on top of the state
+// of the parent. The merge commits are thus diffed against the first parent. The root commits
+// are rejected since they have nothing to be compared with.
+func (pipeline *Pipeline) SingleCommit(revision string) ([]*object.Commit, error) {
+	hash, err := pipeline.repository.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to resolve %s", revision)
+	}
+	commit, err := pipeline.repository.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+	if commit.NumParents() == 0 {
+		return nil, errors.Errorf("%s has no parents", commit.Hash.String())
+	}
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to load the parent of %s", commit.Hash.String())
+	}
+	return []*object.Commit{parent, commit}, nil
+}
+
+// FilesAtHead returns the sorted paths of the files in the HEAD tree which pass the filters
+// of the deployed FileFilterPipelineItem-s, that is, the files which are going to be analysed
+// at HEAD. Call it after Initialize() so that the filters are configured.
+func (pipeline *Pipeline) FilesAtHead() ([]string, error) {
+	heads, err := pipeline.HeadCommit()
+	if err != nil {
+		return nil, err
+	}
+	tree, err := heads[0].Tree()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the HEAD tree")
+	}
+	var filters []FileFilterPipelineItem
+	for _, item := range pipeline.items {
+		if filter, ok := item.(FileFilterPipelineItem); ok {
+			filters = append(filters, filter)
+		}
+	}
+	var files []string
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+OUTER:
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to walk the HEAD tree")
+		}
+		if !entry.Mode.IsFile() && entry.Mode != filemode.Submodule {
+			continue
+		}
+		for _, filter := range filters {
+			if !filter.FilterFile(tree, name, &entry) {
+				continue OUTER
+			}
+		}
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
 type sortablePipelineItems []PipelineItem
 
 func (items sortablePipelineItems) Len() int {
@@ -653,15 +997,105 @@ func (pipeline *Pipeline) resolve(dumpPath string) error {
 		}
 	}
 	if dumpPath != "" {
-		// If there is a floating difference, uncomment this:
-		// fmt.Fprint(os.Stderr, graphCopy.DebugDump())
-		ioutil.WriteFile(dumpPath, []byte(graphCopy.Serialize(strplan)), 0666)
+		var dump []byte
+		if strings.EqualFold(filepath.Ext(dumpPath), ".json") {
+			var err error
+			dump, err = pipeline.serializePlanJSON()
+			if err != nil {
+				return err
+			}
+		} else {
+			// If there is a floating difference, uncomment this:
+			// fmt.Fprint(os.Stderr, graphCopy.DebugDump())
+			dump = []byte(graphCopy.Serialize(strplan))
+		}
+		ioutil.WriteFile(dumpPath, dump, 0666)
 		absPath, _ := filepath.Abs(dumpPath)
 		pipeline.l.Infof("Wrote the DAG to %s\n", absPath)
 	}
 	return nil
 }
 
+// planJSON is the document which ConfigPipelineDAGPath writes if the path ends with ".json".
+type planJSON struct {
+	// Items are the names of the resolved items in the execution order.
+	Items []string `json:"items"`
+	// Plan is the sequence of the actions which Run() executes.
+	Plan []planStepJSON `json:"plan"`
+}
+
+// planStepJSON is a single RunAction in planJSON.
+type planStepJSON struct {
+	Action   string `json:"action"`
+	Commit   string `json:"commit,omitempty"`
+	Branches []int  `json:"branches"`
+}
+
+// serializePlanJSON converts the resolved items and the execution plan of the commits
+// from ConfigPipelineCommits to JSON.
+func (pipeline *Pipeline) serializePlanJSON() ([]byte, error) {
+	doc := planJSON{
+		Items: make([]string, len(pipeline.items)),
+		Plan:  []planStepJSON{},
+	}
+	for i, item := range pipeline.items {
+		doc.Items[i] = item.Name()
+	}
+	if len(pipeline.commits) > 0 {
+		plan := prepareRunPlan(pipeline.commits, pipeline.HibernationDistance,
+			pipeline.MaxConcurrentBranches, false)
+		for _, action := range plan {
+			step := planStepJSON{
+				Action:   RunActionType(action.Action).String(),
+				Branches: action.Items,
+			}
+			if action.Commit != nil {
+				step.Commit = action.Commit.Hash.String()
+			}
+			doc.Plan = append(doc.Plan, step)
+		}
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// isSkipped returns true if the commit is not a merge and its author email does not pass
+// AuthorInclude or AuthorExclude. The merges are kept to preserve the DAG connectivity.
+func (pipeline *Pipeline) isSkipped(commit *object.Commit) bool {
+	if commit.NumParents() > 1 {
+		return false
+	}
+	email := commit.Author.Email
+	if pipeline.AuthorInclude != nil && !pipeline.AuthorInclude.MatchString(email) {
+		return true
+	}
+	return pipeline.AuthorExclude != nil && pipeline.AuthorExclude.MatchString(email)
+}
+
+// printDAG writes the resolved items in the execution order together with what they require
+// and provide. Each requirement is followed by the name of the preceding item which satisfies it.
+func (pipeline *Pipeline) printDAG(writer io.Writer) {
+	providers := map[string]string{}
+	for i, item := range pipeline.items {
+		fmt.Fprintf(writer, "%d. %s\n", i+1, item.Name())
+		if requires := item.Requires(); len(requires) > 0 {
+			fmt.Fprintln(writer, "   requires:")
+			for _, key := range requires {
+				provider, exists := providers[key]
+				if !exists {
+					provider = "?"
+				}
+				fmt.Fprintf(writer, "     %s <- %s\n", key, provider)
+			}
+		}
+		if provides := item.Provides(); len(provides) > 0 {
+			fmt.Fprintf(writer, "   provides: %s\n", strings.Join(provides, ", "))
+			for _, key := range provides {
+				providers[key] = item.Name()
+			}
+		}
+	}
+}
+
 // Initialize prepares the pipeline for the execution (Run()). This function
 // resolves the execution DAG, Configure()-s and Initialize()-s the items in it in the
 // topological dependency order. `facts` are passed inside Configure(). They are mutable.
@@ -687,15 +1121,42 @@ func (pipeline *Pipeline) Initialize(facts map[string]interface{}) error {
 		facts[ConfigLogger] = pipeline.l
 	}
 
-	if _, exists := facts[ConfigPipelineCommits]; !exists {
-		var err error
-		facts[ConfigPipelineCommits], err = pipeline.Commits(false)
+	if val, exists := facts[ConfigPipelineCommits]; exists {
+		commits, ok := val.([]*object.Commit)
+		if !ok {
+			err := fmt.Errorf("%s must be []*object.Commit (got %T)", ConfigPipelineCommits, val)
+			pipeline.l.Error(err)
+			return err
+		}
+		pipeline.commits = commits
+		pipeline.explicitCommits = true
+	} else {
+		commits, err := pipeline.Commits(false)
 		if err != nil {
 			pipeline.l.Errorf("failed to list the commits: %v", err)
 			return err
 		}
+		facts[ConfigPipelineCommits] = commits
+		pipeline.commits = commits
+		pipeline.explicitCommits = false
 	}
 	pipeline.PrintActions, _ = facts[ConfigPipelinePrintActions].(bool)
+	pipeline.SkipMerges, _ = facts[ConfigPipelineSkipMerges].(bool)
+	pipeline.ContinueOnError, _ = facts[ConfigPipelineContinueOnError].(bool)
+	if val, exists := facts[ConfigTimeSource].(string); exists && val != "" {
+		if val != TimeSourceCommitter && val != TimeSourceAuthor {
+			err := fmt.Errorf("--time-source must be either %s or %s (got %s)",
+				TimeSourceCommitter, TimeSourceAuthor, val)
+			pipeline.l.Error(err)
+			return err
+		}
+		pipeline.TimeSource = val
+	}
+	if pipeline.TimeSource == "" {
+		pipeline.TimeSource = TimeSourceCommitter
+	}
+	// the items read the normalized value
+	facts[ConfigTimeSource] = pipeline.TimeSource
 	if val, exists := facts[ConfigPipelineHibernationDistance].(int); exists {
 		if val < 0 {
 			err := fmt.Errorf("--hibernation-distance cannot be negative (got %d)", val)
@@ -704,11 +1165,48 @@ func (pipeline *Pipeline) Initialize(facts map[string]interface{}) error {
 		}
 		pipeline.HibernationDistance = val
 	}
+	if val, exists := facts[ConfigPipelineMaxConcurrentBranches].(int); exists {
+		if val < 0 {
+			err := fmt.Errorf("--max-branches cannot be negative (got %d)", val)
+			pipeline.l.Error(err)
+			return err
+		}
+		pipeline.MaxConcurrentBranches = val
+	}
+	for _, filter := range []struct {
+		key    string
+		target **regexp.Regexp
+	}{{ConfigPipelineAuthorInclude, &pipeline.AuthorInclude},
+		{ConfigPipelineAuthorExclude, &pipeline.AuthorExclude}} {
+		val, exists := facts[filter.key].(string)
+		if !exists || val == "" {
+			continue
+		}
+		re, err := regexp.Compile(val)
+		if err != nil {
+			err = errors.Wrapf(err, "invalid %s", filter.key)
+			pipeline.l.Error(err)
+			return err
+		}
+		*filter.target = re
+	}
+	pipeline.skippedCommits = map[plumbing.Hash][]plumbing.Hash{}
+	facts[FactPipelineSkippedCommits] = pipeline.skippedCommits
+	pipeline.failedCommits = map[plumbing.Hash]bool{}
+	facts[FactPipelineFailedCommits] = pipeline.failedCommits
+	pipeline.skewedCommits = map[plumbing.Hash]bool{}
+	facts[FactPipelineSkewedCommits] = pipeline.skewedCommits
 	dumpPath, _ := facts[ConfigPipelineDAGPath].(string)
 	err := pipeline.resolve(dumpPath)
 	if err != nil {
 		return err
 	}
+	if printDAG, exists := facts[ConfigPipelinePrintDAG].(bool); exists {
+		pipeline.PrintDAG = printDAG
+	}
+	if pipeline.PrintDAG {
+		pipeline.printDAG(os.Stdout)
+	}
 	if dumpPlan, exists := facts[ConfigPipelineDumpPlan].(bool); exists {
 		pipeline.DumpPlan = dumpPlan
 	}
@@ -733,6 +1231,7 @@ func (pipeline *Pipeline) Initialize(facts map[string]interface{}) error {
 			return errors.Wrapf(err, "%s failed to initialize", item.Name())
 		}
 	}
+	pipeline.config = pipeline.effectiveConfig(facts)
 	if pipeline.HibernationDistance > 0 {
 		// if we want hibernation, then we want to minimize RSS
 		debug.SetGCPercent(20) // the default is 100
@@ -741,14 +1240,49 @@ func (pipeline *Pipeline) Initialize(facts map[string]interface{}) error {
 	return nil
 }
 
+// effectiveConfig collects the values of the configuration options of the items from `facts`,
+// falling back to the defaults, together with the Pipeline options which affect the results.
+func (pipeline *Pipeline) effectiveConfig(facts map[string]interface{}) map[string]string {
+	config := map[string]string{}
+	for _, item := range pipeline.items {
+		for _, opt := range item.ListConfigurationOptions() {
+			val, exists := facts[opt.Name]
+			if !exists {
+				val = opt.Default
+			}
+			if text, ok := FormatConfigValue(val); ok {
+				config[opt.Name] = text
+			}
+		}
+	}
+	config[ConfigPipelineSkipMerges] = strconv.FormatBool(pipeline.SkipMerges)
+	config[ConfigPipelineContinueOnError] = strconv.FormatBool(pipeline.ContinueOnError)
+	config[ConfigTimeSource] = pipeline.TimeSource
+	if pipeline.AuthorInclude != nil {
+		config[ConfigPipelineAuthorInclude] = pipeline.AuthorInclude.String()
+	}
+	if pipeline.AuthorExclude != nil {
+		config[ConfigPipelineAuthorExclude] = pipeline.AuthorExclude.String()
+	}
+	return config
+}
+
 // Run method executes the pipeline.
 //
 // `commits` is a slice with the git commits to analyse. Multiple branches are supported.
+// nil means the commits from ConfigPipelineCommits, or Pipeline.Commits() if it was not set
+// in Initialize(). It is an error to pass non-nil `commits` if ConfigPipelineCommits was set.
 //
 // Returns the mapping from each LeafPipelineItem to the corresponding analysis result.
 // There is always a "nil" record with CommonAnalysisResult.
 func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]interface{}, error) {
 	startRunTime := time.Now()
+	if commits == nil {
+		commits = pipeline.commits
+	} else if pipeline.explicitCommits {
+		return nil, fmt.Errorf("the commits were passed to Run() while %s is set, "+
+			"call Run(nil) instead", ConfigPipelineCommits)
+	}
 	cleanReturn := false
 	defer func() {
 		if !cleanReturn {
@@ -762,8 +1296,13 @@ func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]in
 	if onProgress == nil {
 		onProgress = func(int, int, string) {}
 	}
-	plan := prepareRunPlan(commits, pipeline.HibernationDistance, pipeline.DumpPlan)
-	progressSteps := len(plan) + 2
+	if len(commits) == 0 {
+		return nil, ErrEmptyRepository
+	}
+	plan := prepareRunPlan(commits, pipeline.HibernationDistance, pipeline.MaxConcurrentBranches,
+		pipeline.DumpPlan)
+	progressOffsets := pipeline.progressOffsets(plan)
+	progressSteps := progressOffsets[len(plan)] + 2
 	branches := map[int][]PipelineItem{}
 	// we will need rootClone if there is more than one root branch
 	var rootClone []PipelineItem
@@ -807,7 +1346,7 @@ func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]in
 
 	commitIndex := 0
 	for index, step := range plan {
-		onProgress(index+1, progressSteps, step.String())
+		onProgress(progressOffsets[index+1], progressSteps, step.String())
 		if pipeline.DryRun {
 			continue
 		}
@@ -820,19 +1359,47 @@ func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]in
 		firstItem := step.Items[0]
 		switch step.Action {
 		case runActionCommit:
+			if pipeline.isSkipped(step.Commit) {
+				if pipeline.skippedCommits == nil {
+					pipeline.skippedCommits = map[plumbing.Hash][]plumbing.Hash{}
+				}
+				pipeline.skippedCommits[step.Commit.Hash] = step.Commit.ParentHashes
+				continue
+			}
 			state := map[string]interface{}{
 				DependencyCommit:  step.Commit,
 				DependencyIndex:   commitIndex,
 				DependencyIsMerge: isMerge(index, step.Commit.Hash),
 			}
-			for _, item := range branches[firstItem] {
+			skipLeaves := pipeline.SkipMerges && state[DependencyIsMerge].(bool)
+			items := branches[firstItem]
+			var restorable []RestorablePipelineItem
+			var snapshots []interface{}
+			if pipeline.ContinueOnError {
+				items = orderForRollback(items)
+			}
+			failed := false
+			for _, item := range items {
+				if skipLeaves && hidesMerges(item) {
+					continue
+				}
 				startTime := time.Now()
+				if pipeline.ContinueOnError {
+					if casted, ok := item.(RestorablePipelineItem); ok {
+						restorable = append(restorable, casted)
+						snapshots = append(snapshots, casted.Snapshot())
+					}
+				}
 				update, err := item.Consume(state)
 				runTimePerItem[item.Name()] += time.Now().Sub(startTime).Seconds()
 				if err != nil {
 					pipeline.l.Errorf("%s failed on commit #%d (%d) %s: %v\n",
 						item.Name(), commitIndex+1, index+1, step.Commit.Hash.String(), err)
-					return nil, err
+					if !pipeline.ContinueOnError {
+						return nil, err
+					}
+					failed = true
+					break
 				}
 				for _, key := range item.Provides() {
 					val, ok := update[key]
@@ -844,7 +1411,19 @@ func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]in
 					state[key] = val
 				}
 			}
-			commitTime := step.Commit.Committer.When.Unix()
+			if failed {
+				// undo the commit in the items which are able to restore their state;
+				// the rest of the items either have consumed it completely or have not seen it
+				for i := len(restorable) - 1; i >= 0; i-- {
+					restorable[i].Restore(snapshots[i])
+				}
+				if pipeline.failedCommits == nil {
+					pipeline.failedCommits = map[plumbing.Hash]bool{}
+				}
+				pipeline.failedCommits[step.Commit.Hash] = true
+				continue
+			}
+			commitTime := CommitTime(step.Commit, pipeline.TimeSource).Unix()
 			if commitTime > newestTime {
 				newestTime = commitTime
 			}
@@ -870,6 +1449,7 @@ func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]in
 				branches[firstItem] = cloneItems(rootClone, 1)[0]
 			}
 		case runActionDelete:
+			releaseItems(branches[firstItem], nil)
 			delete(branches, firstItem)
 		case runActionHibernate:
 			for _, item := range step.Items {
@@ -901,32 +1481,143 @@ func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]in
 			}
 		}
 	}
-	onProgress(len(plan)+1, progressSteps, MessageFinalize)
+	onProgress(progressOffsets[len(plan)]+1, progressSteps, MessageFinalize)
 	result := map[LeafPipelineItem]interface{}{}
 	if !pipeline.DryRun {
-		for index, item := range getMasterBranch(branches) {
+		master := getMasterBranch(branches)
+		releaseItems(rootClone, nil)
+		for _, branch := range branches {
+			releaseItems(branch, master)
+		}
+		for index, item := range master {
 			if casted, ok := item.(DisposablePipelineItem); ok {
 				casted.Dispose()
 			}
 			if casted, ok := item.(LeafPipelineItem); ok {
-				result[pipeline.items[index].(LeafPipelineItem)] = casted.Finalize()
+				leaf := pipeline.items[index].(LeafPipelineItem)
+				result[leaf] = casted.Finalize()
+				if pipeline.OnResult != nil {
+					pipeline.OnResult(leaf, result[leaf])
+				}
 			}
 		}
 	}
 	onProgress(progressSteps, progressSteps, "")
+	config := map[string]string{}
+	for key, val := range pipeline.config {
+		config[key] = val
+	}
 	result[nil] = &CommonAnalysisResult{
-		BeginTime:      plan[0].Commit.Committer.When.Unix(),
+		BeginTime:      CommitTime(plan[0].Commit, pipeline.TimeSource).Unix(),
 		EndTime:        newestTime,
 		CommitsNumber:  len(commits),
 		RunTime:        time.Since(startRunTime),
 		RunTimePerItem: runTimePerItem,
+		SkewedCommits:  len(pipeline.skewedCommits),
+		FailedCommits:  len(pipeline.failedCommits),
+		Config:         config,
+	}
+	if len(pipeline.failedCommits) > 0 {
+		pipeline.l.Warnf("skipped %d commits which failed to be analysed\n", len(pipeline.failedCommits))
 	}
 	cleanReturn = true
 	return result, nil
 }
 
+// hidesMerges returns true if Pipeline.SkipMerges applies to the item: it is a leaf which
+// does not need the merge commits.
+func hidesMerges(item PipelineItem) bool {
+	if _, isLeaf := item.(LeafPipelineItem); !isLeaf {
+		return false
+	}
+	consumer, ok := item.(MergeConsumingPipelineItem)
+	return !ok || !consumer.ConsumesMerges()
+}
+
+// orderForRollback returns the items of the branch in the order which lets Run() undo
+// a failed commit in as many items as possible. The leaves which are not RestorablePipelineItem-s
+// consume the commit last, after every other item has succeeded. The leaves do not provide
+// dependencies, so moving them keeps the topological order valid.
+func orderForRollback(items []PipelineItem) []PipelineItem {
+	ordered := make([]PipelineItem, 0, len(items))
+	var unrestorable []PipelineItem
+	for _, item := range items {
+		_, isLeaf := item.(LeafPipelineItem)
+		_, isRestorable := item.(RestorablePipelineItem)
+		if isLeaf && !isRestorable {
+			unrestorable = append(unrestorable, item)
+		} else {
+			ordered = append(ordered, item)
+		}
+	}
+	return append(ordered, unrestorable...)
+}
+
+// progressOffsets returns the number of the complete progress steps before each action
+// of the plan plus the total in the end. Each action weighs 1, except for the commits
+// if WeightedProgress is true and for hibernating and booting the branches, which weigh 0:
+// they depend on the memory pressure rather than on the history, so counting them would
+// make the ETA meaningless.
+func (pipeline *Pipeline) progressOffsets(plan []runAction) []int {
+	offsets := make([]int, len(plan)+1)
+	weights := map[plumbing.Hash]int{}
+	for i, step := range plan {
+		weight := 1
+		switch {
+		case step.Action == runActionHibernate || step.Action == runActionBoot:
+			weight = 0
+		case pipeline.WeightedProgress && step.Action == runActionCommit:
+			var exists bool
+			weight, exists = weights[step.Commit.Hash]
+			if !exists {
+				weight = 1 + countChangedFiles(step.Commit)
+				weights[step.Commit.Hash] = weight
+			}
+		}
+		offsets[i+1] = offsets[i] + weight
+	}
+	return offsets
+}
+
+// countChangedFiles returns the number of the files changed by the commit against its first
+// parent or the number of the files in the tree of a root commit. The errors yield 0.
+func countChangedFiles(commit *object.Commit) int {
+	tree, err := commit.Tree()
+	if err != nil {
+		return 0
+	}
+	if commit.NumParents() == 0 {
+		count := 0
+		walker := object.NewTreeWalker(tree, true, nil)
+		defer walker.Close()
+		for {
+			_, entry, err := walker.Next()
+			if err != nil {
+				return count
+			}
+			if entry.Mode.IsFile() {
+				count++
+			}
+		}
+	}
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return 0
+	}
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return 0
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return 0
+	}
+	return len(changes)
+}
+
 // LoadCommitsFromFile reads the file by the specified FS path and generates the sequence of commits
-// by interpreting each line as a Git commit hash.
+// by interpreting each line as a Git commit hash. "-" means the standard input.
+// See LoadCommitsFromReader() about the supported formats.
 func LoadCommitsFromFile(path string, repository *git.Repository) ([]*object.Commit, error) {
 	var file io.ReadCloser
 	if path != "-" {
@@ -939,22 +1630,85 @@ func LoadCommitsFromFile(path string, repository *git.Repository) ([]*object.Com
 	} else {
 		file = os.Stdin
 	}
-	scanner := bufio.NewScanner(file)
-	var commits []*object.Commit
-	for scanner.Scan() {
-		hash := plumbing.NewHash(scanner.Text())
-		if len(hash) != 20 {
-			return nil, errors.New("invalid commit hash " + scanner.Text())
-		}
+	return LoadCommitsFromReader(file, repository)
+}
+
+// PackedCommitsMagic is the header of the binary commits list format accepted by
+// LoadCommitsFromReader(). It is followed by the big-endian uint32 number of hashes
+// and then by the raw 20-byte hashes.
+const PackedCommitsMagic = "HERCCMTS"
+
+// LoadCommitsFromReader reads the list of commit hashes from `reader` and resolves them
+// in the same order. If the stream starts with PackedCommitsMagic, it is parsed as the packed
+// binary format, otherwise each line is interpreted as a hex Git commit hash.
+func LoadCommitsFromReader(reader io.Reader, repository *git.Repository) ([]*object.Commit, error) {
+	buffered := bufio.NewReader(reader)
+	var hashes []plumbing.Hash
+	var err error
+	if header, _ := buffered.Peek(len(PackedCommitsMagic)); string(header) == PackedCommitsMagic {
+		hashes, err = readPackedCommitHashes(buffered)
+	} else {
+		hashes, err = readTextCommitHashes(buffered)
+	}
+	if err != nil {
+		return nil, err
+	}
+	commits := make([]*object.Commit, 0, len(hashes))
+	for _, hash := range hashes {
 		commit, err := repository.CommitObject(hash)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrapf(err, "failed to resolve commit %s", hash.String())
 		}
 		commits = append(commits, commit)
 	}
 	return commits, nil
 }
 
+// maxPackedCommitsPrealloc is the maximum number of the hashes which readPackedCommitHashes()
+// allocates before reading them.
+const maxPackedCommitsPrealloc = 1 << 16
+
+func readPackedCommitHashes(reader io.Reader) ([]plumbing.Hash, error) {
+	header := make([]byte, len(PackedCommitsMagic)+4)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, errors.Wrap(err, "truncated packed commits header")
+	}
+	count := int(binary.BigEndian.Uint32(header[len(PackedCommitsMagic):]))
+	// the count comes from the input, so do not trust it with the allocation
+	capacity := count
+	if capacity > maxPackedCommitsPrealloc {
+		capacity = maxPackedCommitsPrealloc
+	}
+	hashes := make([]plumbing.Hash, 0, capacity)
+	for i := 0; i < count; i++ {
+		var hash plumbing.Hash
+		if _, err := io.ReadFull(reader, hash[:]); err != nil {
+			return nil, errors.Wrapf(err, "truncated packed commit hash #%d", i)
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+func readTextCommitHashes(reader io.Reader) ([]plumbing.Hash, error) {
+	scanner := bufio.NewScanner(reader)
+	var hashes []plumbing.Hash
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var hash plumbing.Hash
+		decoded, err := hex.DecodeString(line)
+		if err != nil || len(decoded) != len(hash) {
+			return nil, errors.New("invalid commit hash " + line)
+		}
+		copy(hash[:], decoded)
+		hashes = append(hashes, hash)
+	}
+	return hashes, scanner.Err()
+}
+
 // GetSensibleRemote extracts a remote URL of the repository to identify it.
 func GetSensibleRemote(repository *git.Repository) string {
 	if r, err := repository.Remotes(); err == nil && len(r) > 0 {
@@ -962,3 +1716,12 @@ func GetSensibleRemote(repository *git.Repository) string {
 	}
 	return "<no remote>"
 }
+
+// CommitTime returns the author timestamp of the commit if `source` is TimeSourceAuthor and
+// the committer timestamp otherwise.
+func CommitTime(commit *object.Commit, source string) time.Time {
+	if source == TimeSourceAuthor {
+		return commit.Author.When
+	}
+	return commit.Committer.When
+}
@@ -2,11 +2,16 @@ package core
 
 import (
 	"bytes"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +20,7 @@ import (
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
 	"gopkg.in/src-d/hercules.v10/internal/pb"
 	"gopkg.in/src-d/hercules.v10/internal/test"
 )
@@ -409,6 +415,71 @@ func TestPipelineOnProgress(t *testing.T) {
 	assert.Equal(t, 4, progressOk)
 }
 
+func TestPipelineOnProgressWeighted(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"README.md": "readme\n", "main.go": "package main\n", "lib/lib.go": "package lib\n"}},
+		{Author: "one", When: when.Add(time.Hour), Files: map[string]string{
+			"main.go": "package main\n\nfunc main() {}\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := NewPipeline(repository)
+	pipeline.PrintActions = false
+	pipeline.WeightedProgress = true
+	var steps, totals []int
+	pipeline.OnProgress = func(step int, total int, action string) {
+		steps = append(steps, step)
+		totals = append(totals, total)
+	}
+	commits, err := pipeline.Commits(false)
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	_, err = pipeline.Run(commits)
+	assert.NoError(t, err)
+	// emerge 1, the root commit 1 + 3 files, the second commit 1 + 1 file, finalize 1
+	assert.Equal(t, []int{1, 5, 7, 8, 9}, steps)
+	assert.Equal(t, []int{9, 9, 9, 9, 9}, totals)
+}
+
+func TestPipelineProgressOffsetsHibernation(t *testing.T) {
+	commit := &object.Commit{Hash: plumbing.NewHash("af9ddc0db70f09f3f27b4b98e415592a7485171c")}
+	plan := []runAction{
+		{Action: runActionEmerge, Items: []int{rootBranchIndex}},
+		{Action: runActionCommit, Commit: commit, Items: []int{rootBranchIndex}},
+		{Action: runActionHibernate, Items: []int{rootBranchIndex}},
+		{Action: runActionBoot, Items: []int{rootBranchIndex}},
+		{Action: runActionCommit, Commit: commit, Items: []int{rootBranchIndex}},
+	}
+	pipeline := NewPipeline(test.Repository)
+	assert.Equal(t, []int{0, 1, 2, 2, 2, 3}, pipeline.progressOffsets(plan))
+}
+
+func TestPipelineOnResult(t *testing.T) {
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: time.Unix(1500000000, 0), Files: map[string]string{
+			"main.go": "package main\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := NewPipeline(repository)
+	pipeline.PrintActions = false
+	item := &testPipelineItem{}
+	pipeline.AddItem(item)
+	var leaves []LeafPipelineItem
+	var results []interface{}
+	pipeline.OnResult = func(leaf LeafPipelineItem, result interface{}) {
+		leaves = append(leaves, leaf)
+		results = append(results, result)
+	}
+	commits, err := pipeline.Commits(false)
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	result, err := pipeline.Run(commits)
+	assert.NoError(t, err)
+	assert.Equal(t, []LeafPipelineItem{item}, leaves)
+	assert.Equal(t, []interface{}{result[item]}, results)
+}
+
 func TestPipelineCommitsFull(t *testing.T) {
 	pipeline := NewPipeline(test.Repository)
 	commits, err := pipeline.Commits(false)
@@ -451,6 +522,55 @@ func TestPipelineHeadCommit(t *testing.T) {
 	assert.Equal(t, head.Hash(), commits[0].Hash)
 }
 
+func TestPipelineHeadCommitDetached(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"a.go": "a\n"}},
+		{Author: "two", When: when.Add(time.Hour), Files: map[string]string{"a.go": "b\n"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repository.Storer.SetReference(
+		plumbing.NewHashReference(plumbing.HEAD, hashes[0])))
+	pipeline := NewPipeline(repository)
+	commits, err := pipeline.HeadCommit()
+	assert.NoError(t, err)
+	require.Len(t, commits, 1)
+	assert.Equal(t, hashes[0], commits[0].Hash)
+	commits, err = pipeline.Commits(false)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 1)
+
+	// the object is missing, e.g. a linked worktree opened without the main repository
+	missing := plumbing.NewHash("cce947b98a050c6d356bc6ba95030254914027b1")
+	require.NoError(t, repository.Storer.SetReference(
+		plumbing.NewHashReference(plumbing.HEAD, missing)))
+	_, err = pipeline.HeadCommit()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), missing.String())
+}
+
+func TestPipelineEmptyRepository(t *testing.T) {
+	repository, err := git.Init(memory.NewStorage(), nil)
+	require.NoError(t, err)
+	pipeline := NewPipeline(repository)
+	commits, err := pipeline.Commits(false)
+	assert.Equal(t, ErrEmptyRepository, err)
+	assert.Nil(t, commits)
+	commits, err = pipeline.Commits(true)
+	assert.Equal(t, ErrEmptyRepository, err)
+	assert.Nil(t, commits)
+	commits, err = pipeline.HeadCommit()
+	assert.Equal(t, ErrEmptyRepository, err)
+	assert.Nil(t, commits)
+	assert.Equal(t, ErrEmptyRepository, pipeline.Initialize(map[string]interface{}{}))
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits: []*object.Commit{},
+	}))
+	result, err := pipeline.Run(nil)
+	assert.Equal(t, ErrEmptyRepository, err)
+	assert.Nil(t, result)
+}
+
 func TestLoadCommitsFromFile(t *testing.T) {
 	tmp, err := ioutil.TempFile("", "hercules-test-")
 	assert.Nil(t, err)
@@ -485,6 +605,53 @@ func TestLoadCommitsFromFile(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestLoadCommitsFromReader(t *testing.T) {
+	hashes := []plumbing.Hash{
+		plumbing.NewHash("6db8065cdb9bb0758f36a7e75fc72ab95f9e8145"),
+		plumbing.NewHash("cce947b98a050c6d356bc6ba95030254914027b1"),
+	}
+	commits, err := LoadCommitsFromReader(strings.NewReader(
+		"\n"+hashes[0].String()+"\r\n  "+hashes[1].String()+"\n\n"), test.Repository)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 2)
+	assert.Equal(t, hashes[0], commits[0].Hash)
+	assert.Equal(t, hashes[1], commits[1].Hash)
+
+	packed := &bytes.Buffer{}
+	packed.WriteString(PackedCommitsMagic)
+	binary.Write(packed, binary.BigEndian, uint32(len(hashes)))
+	for _, hash := range hashes {
+		packed.Write(hash[:])
+	}
+	commits, err = LoadCommitsFromReader(bytes.NewReader(packed.Bytes()), test.Repository)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 2)
+	assert.Equal(t, hashes[0], commits[0].Hash)
+	assert.Equal(t, hashes[1], commits[1].Hash)
+
+	commits, err = LoadCommitsFromReader(
+		bytes.NewReader(packed.Bytes()[:packed.Len()-1]), test.Repository)
+	assert.Nil(t, commits)
+	assert.Error(t, err)
+	commits, err = LoadCommitsFromReader(
+		strings.NewReader(PackedCommitsMagic+"\x00"), test.Repository)
+	assert.Nil(t, commits)
+	assert.Error(t, err)
+	// the huge count must not be allocated in advance
+	commits, err = LoadCommitsFromReader(
+		strings.NewReader(PackedCommitsMagic+"\xff\xff\xff\xff"+string(hashes[0][:])),
+		test.Repository)
+	assert.Nil(t, commits)
+	assert.EqualError(t, err, "truncated packed commit hash #1: EOF")
+	commits, err = LoadCommitsFromReader(
+		strings.NewReader(hashes[0].String()[:39]), test.Repository)
+	assert.Nil(t, commits)
+	assert.Error(t, err)
+	commits, err = LoadCommitsFromReader(strings.NewReader(""), test.Repository)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 0)
+}
+
 func TestPipelineDeps(t *testing.T) {
 	pipeline := NewPipeline(test.Repository)
 	item1 := &dependingTestPipelineItem{}
@@ -601,44 +768,546 @@ C 1 af9ddc0db70f09f3f27b4b98e415592a7485171c
 `, stream.String())
 }
 
+func TestPipelinePrintDAG(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&dependingTestPipelineItem{})
+	pipeline.AddItem(&testPipelineItem{})
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineDryRun: true, ConfigPipelinePrintDAG: true}))
+	assert.True(t, pipeline.PrintDAG)
+	buffer := &bytes.Buffer{}
+	pipeline.printDAG(buffer)
+	assert.Equal(t, `1. Test
+   provides: test
+2. Test2
+   requires:
+     test <- Test
+   provides: test2
+`, buffer.String())
+}
+
+func TestPipelineDumpDAG(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"README.md": "readme\n"}},
+		{Author: "one", When: when.Add(time.Hour), Files: map[string]string{"main.go": "package main\n"}},
+	})
+	require.NoError(t, err)
+	dir, err := ioutil.TempDir("", "hercules-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pipeline := NewPipeline(repository)
+	pipeline.AddItem(&dependingTestPipelineItem{})
+	pipeline.AddItem(&testPipelineItem{})
+	dotPath := filepath.Join(dir, "dag.dot")
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineDryRun: true, ConfigPipelineDAGPath: dotPath}))
+	dump, err := ioutil.ReadFile(dotPath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(dump), "digraph Hercules {\n"))
+
+	pipeline = NewPipeline(repository)
+	pipeline.AddItem(&dependingTestPipelineItem{})
+	pipeline.AddItem(&testPipelineItem{})
+	jsonPath := filepath.Join(dir, "dag.JSON")
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineDryRun: true, ConfigPipelineDAGPath: jsonPath}))
+	dump, err = ioutil.ReadFile(jsonPath)
+	require.NoError(t, err)
+	var doc planJSON
+	require.NoError(t, json.Unmarshal(dump, &doc))
+	assert.Equal(t, []string{"Test", "Test2"}, doc.Items)
+	assert.Equal(t, []planStepJSON{
+		{Action: "emerge", Commit: hashes[0].String(), Branches: []int{rootBranchIndex}},
+		{Action: "commit", Commit: hashes[0].String(), Branches: []int{rootBranchIndex}},
+		{Action: "commit", Commit: hashes[1].String(), Branches: []int{rootBranchIndex}},
+	}, doc.Plan)
+}
+
+func TestPipelineAuthorFilters(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&testPipelineItem{})
+	facts := map[string]interface{}{
+		ConfigPipelineDryRun:        true,
+		ConfigPipelineAuthorInclude: "@srcd$",
+		ConfigPipelineAuthorExclude: "^bot",
+	}
+	assert.NoError(t, pipeline.Initialize(facts))
+	assert.Equal(t, "@srcd$", pipeline.AuthorInclude.String())
+	assert.Equal(t, "^bot", pipeline.AuthorExclude.String())
+	assert.Equal(t, pipeline.skippedCommits, facts[FactPipelineSkippedCommits])
+	commit := func(email string, parents int) *object.Commit {
+		return &object.Commit{
+			Author:       object.Signature{Email: email},
+			ParentHashes: make([]plumbing.Hash, parents),
+		}
+	}
+	assert.False(t, pipeline.isSkipped(commit("one@srcd", 1)))
+	assert.True(t, pipeline.isSkipped(commit("one@github", 1)))
+	assert.True(t, pipeline.isSkipped(commit("bot@srcd", 0)))
+	assert.False(t, pipeline.isSkipped(commit("bot@srcd", 2)))
+	assert.Error(t, pipeline.Initialize(map[string]interface{}{ConfigPipelineAuthorExclude: "("}))
+}
+
+func TestPipelineRunAuthorExclude(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	item := &testPipelineItem{}
+	pipeline.AddItem(item)
+	commits, err := pipeline.Commits(true)
+	assert.NoError(t, err)
+	commits = commits[:3]
+	excluded := commits[1].Author.Email
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineAuthorExclude: "^" + regexp.QuoteMeta(excluded) + "$",
+	}))
+	_, err = pipeline.Run(commits)
+	assert.NoError(t, err)
+	expected := map[plumbing.Hash][]plumbing.Hash{}
+	for _, commit := range commits {
+		if commit.Author.Email == excluded {
+			expected[commit.Hash] = commit.ParentHashes
+		}
+	}
+	assert.Equal(t, expected, pipeline.skippedCommits)
+}
+
+// mergeCountingPipelineItem is a leaf which counts the consumed commits.
+type mergeCountingPipelineItem struct {
+	NoopMerger
+	Commits int
+	Merges  int
+	// FailOn is the set of commits which fail to be consumed.
+	FailOn map[plumbing.Hash]bool
+}
+
+func (item *mergeCountingPipelineItem) Name() string {
+	return "MergeCounter"
+}
+
+func (item *mergeCountingPipelineItem) Provides() []string {
+	return []string{}
+}
+
+func (item *mergeCountingPipelineItem) Requires() []string {
+	return []string{}
+}
+
+func (item *mergeCountingPipelineItem) ListConfigurationOptions() []ConfigurationOption {
+	return []ConfigurationOption{}
+}
+
+func (item *mergeCountingPipelineItem) Configure(facts map[string]interface{}) error {
+	return nil
+}
+
+func (item *mergeCountingPipelineItem) Initialize(repository *git.Repository) error {
+	return nil
+}
+
+func (item *mergeCountingPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if item.FailOn[deps[DependencyCommit].(*object.Commit).Hash] {
+		return nil, errors.New("failed")
+	}
+	item.Commits++
+	if deps[DependencyIsMerge].(bool) {
+		item.Merges++
+	}
+	return nil, nil
+}
+
+func (item *mergeCountingPipelineItem) Fork(n int) []PipelineItem {
+	return ForkSamePipelineItem(item, n)
+}
+
+func (item *mergeCountingPipelineItem) Flag() string {
+	return "merge-counter"
+}
+
+func (item *mergeCountingPipelineItem) Description() string {
+	return "Counts the consumed commits."
+}
+
+func (item *mergeCountingPipelineItem) Finalize() interface{} {
+	return nil
+}
+
+func (item *mergeCountingPipelineItem) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	return nil
+}
+
+// newMergeRepository creates the in-memory repository with two branches and a merge commit.
+func newMergeRepository(t *testing.T) (*git.Repository, []*object.Commit) {
+	var fakeCommits []test.FakeCommit
+	for i, parents := range [][]int{{}, {0}, {0}, {1, 2}} {
+		fakeCommits = append(fakeCommits, test.FakeCommit{
+			Author: "one", When: time.Unix(int64(1500000000+i*3600), 0), Parents: parents})
+	}
+	repository, hashes, err := test.NewMemoryRepository(fakeCommits)
+	require.NoError(t, err)
+	commits := make([]*object.Commit, len(hashes))
+	for i, hash := range hashes {
+		commits[i], err = repository.CommitObject(hash)
+		require.NoError(t, err)
+	}
+	return repository, commits
+}
+
+func TestPipelineRunSkipMerges(t *testing.T) {
+	repository, commits := newMergeRepository(t)
+	for _, skip := range []bool{false, true} {
+		pipeline := NewPipeline(repository)
+		item := &mergeCountingPipelineItem{}
+		pipeline.AddItem(item)
+		assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+			ConfigPipelineCommits:    commits,
+			ConfigPipelineSkipMerges: skip,
+		}))
+		assert.Equal(t, skip, pipeline.SkipMerges)
+		_, err := pipeline.Run(nil)
+		assert.NoError(t, err)
+		if skip {
+			assert.Equal(t, 3, item.Commits)
+			assert.Equal(t, 0, item.Merges)
+		} else {
+			assert.True(t, item.Merges > 0)
+			assert.Equal(t, 3+item.Merges, item.Commits)
+		}
+	}
+}
+
+type mergeConsumingPipelineItem struct {
+	mergeCountingPipelineItem
+}
+
+func (item *mergeConsumingPipelineItem) ConsumesMerges() bool {
+	return true
+}
+
+func (item *mergeConsumingPipelineItem) Fork(n int) []PipelineItem {
+	return ForkSamePipelineItem(item, n)
+}
+
+func TestPipelineRunSkipMergesConsumingItem(t *testing.T) {
+	repository, commits := newMergeRepository(t)
+	pipeline := NewPipeline(repository)
+	counter := &mergeCountingPipelineItem{}
+	consumer := &mergeConsumingPipelineItem{}
+	pipeline.AddItem(counter)
+	pipeline.AddItem(consumer)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits:    commits,
+		ConfigPipelineSkipMerges: true,
+	}))
+	_, err := pipeline.Run(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, counter.Merges)
+	assert.True(t, consumer.Merges > 0)
+	assert.Equal(t, 3+consumer.Merges, consumer.Commits)
+}
+
+func TestPipelineRunCommitsFact(t *testing.T) {
+	repository, commits := newMergeRepository(t)
+	pipeline := NewPipeline(repository)
+	item := &mergeCountingPipelineItem{}
+	pipeline.AddItem(item)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits: commits[:2],
+	}))
+	_, err := pipeline.Run(commits)
+	assert.EqualError(t, err, "the commits were passed to Run() while Pipeline.Commits is set, "+
+		"call Run(nil) instead")
+	result, err := pipeline.Run(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, item.Commits)
+	assert.Equal(t, 2, result[nil].(*CommonAnalysisResult).CommitsNumber)
+
+	// the listed commits are the default but may be replaced
+	pipeline = NewPipeline(repository)
+	item = &mergeCountingPipelineItem{}
+	pipeline.AddItem(item)
+	facts := map[string]interface{}{}
+	assert.NoError(t, pipeline.Initialize(facts))
+	assert.Len(t, facts[ConfigPipelineCommits], 4)
+	_, err = pipeline.Run(commits[:1])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, item.Commits)
+
+	assert.EqualError(t, NewPipeline(repository).Initialize(map[string]interface{}{
+		ConfigPipelineCommits: "HEAD",
+	}), "Pipeline.Commits must be []*object.Commit (got string)")
+}
+
+func TestPipelineRunContinueOnError(t *testing.T) {
+	repository, commits := newMergeRepository(t)
+	failOn := map[plumbing.Hash]bool{commits[1].Hash: true}
+	pipeline := NewPipeline(repository)
+	item := &mergeCountingPipelineItem{FailOn: failOn}
+	pipeline.AddItem(item)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits: commits,
+	}))
+	assert.False(t, pipeline.ContinueOnError)
+	_, err := pipeline.Run(nil)
+	assert.EqualError(t, err, "failed")
+
+	pipeline = NewPipeline(repository)
+	item = &mergeCountingPipelineItem{FailOn: failOn}
+	pipeline.AddItem(item)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits:         commits,
+		ConfigPipelineContinueOnError: true,
+	}))
+	assert.True(t, pipeline.ContinueOnError)
+	result, err := pipeline.Run(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2+item.Merges, item.Commits)
+	common := result[nil].(*CommonAnalysisResult)
+	assert.Equal(t, 1, common.FailedCommits)
+	assert.Equal(t, 4, common.CommitsNumber)
+}
+
+// halfFailingPipelineItem is a leaf which counts the commits in two steps and fails between
+// them on the specified commits.
+type halfFailingPipelineItem struct {
+	mergeCountingPipelineItem
+	Steps map[string]int
+}
+
+func (item *halfFailingPipelineItem) Name() string {
+	return "HalfFailing"
+}
+
+func (item *halfFailingPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	item.Steps["first"]++
+	if item.FailOn[deps[DependencyCommit].(*object.Commit).Hash] {
+		return nil, errors.New("failed")
+	}
+	item.Steps["second"]++
+	return nil, nil
+}
+
+func (item *halfFailingPipelineItem) Fork(n int) []PipelineItem {
+	return ForkCopyPipelineItem(item, n)
+}
+
+func (item *halfFailingPipelineItem) Snapshot() interface{} {
+	steps := map[string]int{}
+	for key, val := range item.Steps {
+		steps[key] = val
+	}
+	return steps
+}
+
+func (item *halfFailingPipelineItem) Restore(snapshot interface{}) {
+	// the forks share Steps
+	for key := range item.Steps {
+		delete(item.Steps, key)
+	}
+	for key, val := range snapshot.(map[string]int) {
+		item.Steps[key] = val
+	}
+}
+
+func TestPipelineRunContinueOnErrorRestore(t *testing.T) {
+	repository, commits := newMergeRepository(t)
+	pipeline := NewPipeline(repository)
+	counter := &mergeCountingPipelineItem{}
+	pipeline.AddItem(counter)
+	item := &halfFailingPipelineItem{Steps: map[string]int{}}
+	item.FailOn = map[plumbing.Hash]bool{commits[1].Hash: true}
+	pipeline.AddItem(item)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits:         commits,
+		ConfigPipelineContinueOnError: true,
+	}))
+	assert.Equal(t, []PipelineItem{item, counter}, orderForRollback(pipeline.items))
+	result, err := pipeline.Run(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result[nil].(*CommonAnalysisResult).FailedCommits)
+	assert.Equal(t, map[plumbing.Hash]bool{commits[1].Hash: true}, pipeline.failedCommits)
+	// the failed commit is neither half-counted nor consumed by the leaves after the failed one
+	assert.Equal(t, item.Steps["second"], item.Steps["first"])
+	assert.Equal(t, counter.Commits, item.Steps["first"])
+	assert.Equal(t, 2+counter.Merges, counter.Commits)
+}
+
+// releasingPipelineItem counts its forks which have not been released yet.
+type releasingPipelineItem struct {
+	NoopMerger
+	Live *int
+}
+
+func (item *releasingPipelineItem) Name() string {
+	return "Releasing"
+}
+
+func (item *releasingPipelineItem) Provides() []string {
+	return []string{}
+}
+
+func (item *releasingPipelineItem) Requires() []string {
+	return []string{}
+}
+
+func (item *releasingPipelineItem) ListConfigurationOptions() []ConfigurationOption {
+	return []ConfigurationOption{}
+}
+
+func (item *releasingPipelineItem) Configure(facts map[string]interface{}) error {
+	return nil
+}
+
+func (item *releasingPipelineItem) Initialize(repository *git.Repository) error {
+	return nil
+}
+
+func (item *releasingPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (item *releasingPipelineItem) Fork(n int) []PipelineItem {
+	result := make([]PipelineItem, n)
+	for i := range result {
+		result[i] = &releasingPipelineItem{Live: item.Live}
+	}
+	*item.Live += n
+	return result
+}
+
+func (item *releasingPipelineItem) Release() {
+	*item.Live--
+}
+
+func TestPipelineRunRelease(t *testing.T) {
+	repository, commits := newMergeRepository(t)
+	for _, continueOnError := range []bool{false, true} {
+		pipeline := NewPipeline(repository)
+		item := &releasingPipelineItem{Live: new(int)}
+		*item.Live = 1
+		pipeline.AddItem(item)
+		counter := &mergeCountingPipelineItem{}
+		if continueOnError {
+			counter.FailOn = map[plumbing.Hash]bool{commits[1].Hash: true}
+		}
+		pipeline.AddItem(counter)
+		assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+			ConfigPipelineCommits:         commits,
+			ConfigPipelineContinueOnError: continueOnError,
+		}))
+		_, err := pipeline.Run(nil)
+		assert.NoError(t, err)
+		// only the master branch remains
+		assert.Equal(t, 1, *item.Live)
+	}
+}
+
+func TestPipelineRunConfig(t *testing.T) {
+	repository, _ := newMergeRepository(t)
+	pipeline := NewPipeline(repository)
+	pipeline.AddItem(&testPipelineItem{})
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineAuthorExclude: "bot@", ConfigPipelineSkipMerges: true}))
+	assert.Equal(t, map[string]string{
+		"TestOption":                  "10",
+		ConfigPipelineAuthorExclude:   "bot@",
+		ConfigPipelineSkipMerges:      "true",
+		ConfigPipelineContinueOnError: "false",
+		ConfigTimeSource:              TimeSourceCommitter,
+	}, pipeline.config)
+
+	pipeline = NewPipeline(repository)
+	pipeline.AddItem(&mergeCountingPipelineItem{})
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{ConfigTimeSource: TimeSourceAuthor}))
+	result, err := pipeline.Run(nil)
+	assert.NoError(t, err)
+	config := result[nil].(*CommonAnalysisResult).Config
+	assert.Equal(t, TimeSourceAuthor, config[ConfigTimeSource])
+	config[ConfigTimeSource] = "xxx"
+	assert.Equal(t, TimeSourceAuthor, pipeline.config[ConfigTimeSource])
+}
+
+func TestFormatConfigValue(t *testing.T) {
+	for _, value := range []struct {
+		value    interface{}
+		expected string
+	}{{true, "true"}, {7, "7"}, {float32(0.5), "0.5"}, {"text", "text"},
+		{[]string{"a", "b"}, "a,b"}, {[]string{}, ""}, {time.Hour, "1h0m0s"}} {
+		text, ok := FormatConfigValue(value.value)
+		assert.True(t, ok, value.expected)
+		assert.Equal(t, value.expected, text)
+	}
+	_, ok := FormatConfigValue(NewLogger())
+	assert.False(t, ok)
+	_, ok = FormatConfigValue(nil)
+	assert.False(t, ok)
+}
+
 func TestCommonAnalysisResultCopy(t *testing.T) {
 	c1 := CommonAnalysisResult{
 		BeginTime: 1513620635, EndTime: 1513720635, CommitsNumber: 1, RunTime: 100,
-		RunTimePerItem: map[string]float64{"one": 1, "two": 2}}
+		RunTimePerItem: map[string]float64{"one": 1, "two": 2}, Config: map[string]string{"a": "1"}}
 	c2 := c1.Copy()
 	assert.Equal(t, c1, c2)
 	c2.RunTimePerItem["one"] = 100500
 	assert.Equal(t, c1.RunTimePerItem["one"], float64(1))
+	c2.Config["a"] = "2"
+	assert.Equal(t, "1", c1.Config["a"])
 }
 
 func TestCommonAnalysisResultMerge(t *testing.T) {
 	c1 := CommonAnalysisResult{
 		BeginTime: 1513620635, EndTime: 1513720635, CommitsNumber: 1, RunTime: 100,
-		RunTimePerItem: map[string]float64{"one": 1, "two": 2}}
+		RunTimePerItem: map[string]float64{"one": 1, "two": 2},
+		Config:         map[string]string{"a": "1", "b": "2", "c": "3"}}
 	assert.Equal(t, c1.BeginTimeAsTime().Unix(), int64(1513620635))
 	assert.Equal(t, c1.EndTimeAsTime().Unix(), int64(1513720635))
 	c2 := CommonAnalysisResult{
 		BeginTime: 1513620535, EndTime: 1513730635, CommitsNumber: 2, RunTime: 200,
-		RunTimePerItem: map[string]float64{"two": 4, "three": 8}}
+		RunTimePerItem: map[string]float64{"two": 4, "three": 8}, SkewedCommits: 1, FailedCommits: 3,
+		Config: map[string]string{"a": "1", "b": "3"}}
 	c1.Merge(&c2)
 	assert.Equal(t, c1.BeginTime, int64(1513620535))
 	assert.Equal(t, c1.EndTime, int64(1513730635))
 	assert.Equal(t, c1.CommitsNumber, 3)
+	assert.Equal(t, c1.SkewedCommits, 1)
+	assert.Equal(t, c1.FailedCommits, 3)
 	assert.Equal(t, c1.RunTime.Nanoseconds(), int64(300))
 	assert.Equal(t, c1.RunTimePerItem, map[string]float64{"one": 1, "two": 6, "three": 8})
+	assert.Equal(t, map[string]string{"a": "1"}, c1.Config)
 }
 
 func TestCommonAnalysisResultMetadata(t *testing.T) {
 	c1 := &CommonAnalysisResult{
 		BeginTime: 1513620635, EndTime: 1513720635, CommitsNumber: 1, RunTime: 100 * 1e6,
-		RunTimePerItem: map[string]float64{"one": 1, "two": 2}}
+		RunTimePerItem: map[string]float64{"one": 1, "two": 2}, SkewedCommits: 2, FailedCommits: 1,
+		Config: map[string]string{"a": "1"}}
 	meta := &pb.Metadata{}
 	c1 = MetadataToCommonAnalysisResult(c1.FillMetadata(meta))
 	assert.Equal(t, c1.BeginTimeAsTime().Unix(), int64(1513620635))
 	assert.Equal(t, c1.EndTimeAsTime().Unix(), int64(1513720635))
 	assert.Equal(t, c1.CommitsNumber, 1)
+	assert.Equal(t, c1.SkewedCommits, 2)
+	assert.Equal(t, c1.FailedCommits, 1)
 	assert.Equal(t, c1.RunTime.Nanoseconds(), int64(100*1e6))
 	assert.Equal(t, c1.RunTimePerItem, map[string]float64{"one": 1, "two": 2})
+	assert.Equal(t, map[string]string{"a": "1"}, c1.Config)
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	assert.NoError(t, CheckCompatibility(&pb.Metadata{Version: SchemaVersion}))
+	assert.NoError(t, CheckCompatibility(&pb.Metadata{Version: MinimumSchemaVersion}))
+	for version := int32(MinimumSchemaVersion); version <= legacyCombinedSchemaVersion; version++ {
+		assert.NoError(t, CheckCompatibility(&pb.Metadata{Version: version}), version)
+	}
+	assert.True(t, SchemaVersion > legacyCombinedSchemaVersion)
+	err := CheckCompatibility(&pb.Metadata{Version: SchemaVersion + 1})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "newer")
+	err = CheckCompatibility(&pb.Metadata{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "older")
+	assert.Error(t, CheckCompatibility(nil))
 }
 
 func TestConfigurationOptionTypeString(t *testing.T) {
@@ -675,7 +1344,7 @@ func TestPrepareRunPlanTiny(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	plan := prepareRunPlan([]*object.Commit{rootCommit}, 0, true)
+	plan := prepareRunPlan([]*object.Commit{rootCommit}, 0, 0, true)
 	assert.Len(t, plan, 2)
 	assert.Equal(t, runActionEmerge, plan[0].Action)
 	assert.Equal(t, rootBranchIndex, plan[0].Items[0])
@@ -702,7 +1371,7 @@ func TestPrepareRunPlanSmall(t *testing.T) {
 		}
 		return nil
 	})
-	plan := prepareRunPlan(commits, 0, false)
+	plan := prepareRunPlan(commits, 0, 0, false)
 	/*for _, p := range plan {
 		if p.Commit != nil {
 			fmt.Println(p.Action, p.Commit.Hash.String(), p.Items)
@@ -830,7 +1499,7 @@ func TestPrepareRunPlanBig(t *testing.T) {
 				}
 				return nil
 			})
-			plan := prepareRunPlan(commits, 0, false)
+			plan := prepareRunPlan(commits, 0, 0, false)
 			/*for _, p := range plan {
 				if p.Commit != nil {
 					fmt.Println(p.Action, p.Commit.Hash.String(), p.Items)
@@ -925,3 +1594,119 @@ func TestPipelineRunHibernation(t *testing.T) {
 	_, err = pipeline.Run(commits)
 	assert.Error(t, err)
 }
+
+// markdownFilterPipelineItem skips the Markdown files.
+type markdownFilterPipelineItem struct {
+	mergeCountingPipelineItem
+}
+
+func (item *markdownFilterPipelineItem) FilterFile(
+	tree *object.Tree, name string, entry *object.TreeEntry) bool {
+	return !strings.HasSuffix(name, ".md")
+}
+
+func TestPipelineSingleCommit(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"main.go": "package main\n"}},
+		{Author: "one", When: when.Add(time.Hour), Files: map[string]string{"a.go": "package a\n"}},
+		{Author: "two", When: when.Add(2 * time.Hour), Parents: []int{0},
+			Files: map[string]string{"b.go": "package b\n"}},
+		{Author: "one", When: when.Add(3 * time.Hour), Parents: []int{1, 2},
+			Files: map[string]string{"b.go": "package b\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := NewPipeline(repository)
+	commits, err := pipeline.SingleCommit(hashes[1].String())
+	assert.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, hashes[0], commits[0].Hash)
+	assert.Equal(t, hashes[1], commits[1].Hash)
+	commits, err = pipeline.SingleCommit(hashes[3].String())
+	assert.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, hashes[1], commits[0].Hash)
+	assert.Equal(t, hashes[3], commits[1].Hash)
+	result, err := pipeline.Run(commits)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result[nil].(*CommonAnalysisResult).CommitsNumber)
+	commits, err = pipeline.SingleCommit(hashes[0].String())
+	assert.EqualError(t, err, hashes[0].String()+" has no parents")
+	assert.Nil(t, commits)
+	commits, err = pipeline.SingleCommit(plumbing.ZeroHash.String())
+	assert.Error(t, err)
+	assert.Nil(t, commits)
+}
+
+func TestPipelineTimeSource(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	// the second commit was rebased a day later
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when},
+		{Author: "one", When: when.Add(time.Hour), CommitterWhen: when.Add(24 * time.Hour)},
+	})
+	require.NoError(t, err)
+	for source, end := range map[string]time.Time{
+		"":                  when.Add(24 * time.Hour),
+		TimeSourceCommitter: when.Add(24 * time.Hour),
+		TimeSourceAuthor:    when.Add(time.Hour),
+	} {
+		pipeline := NewPipeline(repository)
+		facts := map[string]interface{}{ConfigTimeSource: source}
+		require.NoError(t, pipeline.Initialize(facts))
+		if source == "" {
+			assert.Equal(t, TimeSourceCommitter, pipeline.TimeSource)
+		} else {
+			assert.Equal(t, source, pipeline.TimeSource)
+		}
+		assert.Equal(t, pipeline.TimeSource, facts[ConfigTimeSource])
+		commits, err := pipeline.Commits(false)
+		require.NoError(t, err)
+		result, err := pipeline.Run(commits)
+		require.NoError(t, err)
+		common := result[nil].(*CommonAnalysisResult)
+		assert.Equal(t, when.Unix(), common.BeginTime, source)
+		assert.Equal(t, end.Unix(), common.EndTime, source)
+	}
+	pipeline := NewPipeline(repository)
+	assert.EqualError(t, pipeline.Initialize(map[string]interface{}{ConfigTimeSource: "tagger"}),
+		"--time-source must be either committer or author (got tagger)")
+}
+
+func TestPipelineFilesAtHead(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"README.md": "readme\n", "main.go": "package main\n", "lib/lib.go": "package lib\n"}},
+		{Author: "one", When: when.Add(time.Hour), Files: map[string]string{
+			"lib/util/util.go": "package util\n", "docs/index.md": "index\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := NewPipeline(repository)
+	files, err := pipeline.FilesAtHead()
+	assert.NoError(t, err)
+	head, err := repository.Head()
+	require.NoError(t, err)
+	commit, err := repository.CommitObject(head.Hash())
+	require.NoError(t, err)
+	tree, err := commit.Tree()
+	require.NoError(t, err)
+	var expected []string
+	require.NoError(t, tree.Files().ForEach(func(file *object.File) error {
+		expected = append(expected, file.Name)
+		return nil
+	}))
+	assert.ElementsMatch(t, expected, files)
+	assert.Equal(t, []string{
+		"README.md", "docs/index.md", "lib/lib.go", "lib/util/util.go", "main.go"}, files)
+	pipeline.AddItem(&markdownFilterPipelineItem{})
+	files, err = pipeline.FilesAtHead()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"lib/lib.go", "lib/util/util.go", "main.go"}, files)
+
+	empty, err := git.Init(memory.NewStorage(), nil)
+	require.NoError(t, err)
+	files, err = NewPipeline(empty).FilesAtHead()
+	assert.Equal(t, ErrEmptyRepository, err)
+	assert.Nil(t, files)
+}
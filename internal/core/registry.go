@@ -17,6 +17,7 @@ type PipelineItemRegistry struct {
 	registered   map[string]reflect.Type
 	flags        map[string]reflect.Type
 	featureFlags arrayFeatureFlags
+	sinks        []ResultSink
 }
 
 // Register adds another PipelineItem to the registry.
@@ -36,6 +37,58 @@ func (registry *PipelineItemRegistry) Register(example PipelineItem) {
 	}
 }
 
+// Unregister removes the PipelineItem named `name` from the registry, so that it is neither
+// summoned nor listed among the leaves and the plumbing items anymore. It does nothing if there
+// is no such item.
+func (registry *PipelineItemRegistry) Unregister(name string) {
+	t, exists := registry.registered[name]
+	if !exists {
+		return
+	}
+	delete(registry.registered, name)
+	for flag, ft := range registry.flags {
+		if ft == t {
+			delete(registry.flags, flag)
+		}
+	}
+	for dep, ts := range registry.provided {
+		var rest []reflect.Type
+		for _, pt := range ts {
+			if pt != t {
+				rest = append(rest, pt)
+			}
+		}
+		if len(rest) == 0 {
+			delete(registry.provided, dep)
+		} else {
+			registry.provided[dep] = rest
+		}
+	}
+}
+
+// Override replaces the PipelineItem with the same Name() by `example`, e.g. by a customized
+// type which embeds the original. Unlike Register(), it does not leave the original among the
+// providers of the same entities. It is equivalent to Register() if there is no such item.
+//
+// The built-in items are registered in init() of their packages, so Override() must be called
+// after those have run, e.g. in main() or in init() of a package which imports hercules.
+// It must also be called before AddFlags() because the command line flags are created
+// for the items which are registered at that moment.
+func (registry *PipelineItemRegistry) Override(example PipelineItem) {
+	registry.Unregister(example.Name())
+	registry.Register(example)
+}
+
+// RegisterSink adds another ResultSink to the registry.
+func (registry *PipelineItemRegistry) RegisterSink(sink ResultSink) {
+	registry.sinks = append(registry.sinks, sink)
+}
+
+// GetSinks returns all ResultSink-s registered in the order of registration.
+func (registry *PipelineItemRegistry) GetSinks() []ResultSink {
+	return append([]ResultSink{}, registry.sinks...)
+}
+
 // Summon searches for PipelineItem-s which provide the specified entity or named after
 // the specified string. It materializes all the found types and returns them.
 func (registry *PipelineItemRegistry) Summon(providesOrName string) []PipelineItem {
@@ -260,13 +313,14 @@ func (registry *PipelineItemRegistry) AddFlags(flagSet *pflag.FlagSet) (
 		// Pipeline flags
 		iface := interface{}("")
 		ptr1 := (**string)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
-		*ptr1 = flagSet.String("dump-dag", "", "Write the pipeline DAG to a Graphviz file.")
+		*ptr1 = flagSet.String("dump-dag", "", "Write the pipeline DAG to a Graphviz file, "+
+			"or the items and the execution plan to a JSON file if the path ends with .json.")
 		flags[ConfigPipelineDAGPath] = iface
 		PathifyFlagValue(flagSet.Lookup("dump-dag"))
 		iface = interface{}(true)
 		ptr2 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
 		*ptr2 = flagSet.Bool("dry-run", false, "Do not run any analyses - only resolve the DAG. "+
-			"Useful for --dump-dag or --dump-plan.")
+			"Useful for --dump-dag, --print-dag or --dump-plan.")
 		flags[ConfigPipelineDryRun] = iface
 		iface = interface{}(true)
 		ptr3 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
@@ -278,10 +332,50 @@ func (registry *PipelineItemRegistry) AddFlags(flagSet *pflag.FlagSet) (
 			"Minimum number of actions between two sequential usages of a branch to activate "+
 				"the hibernation optimization (cpu-memory trade-off). 0 disables.")
 		flags[ConfigPipelineHibernationDistance] = iface
+		iface = interface{}(0)
+		ptr10 := (**int)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr10 = flagSet.Int("max-branches", 0,
+			"Maximum number of branches which exist at the same time. The commits are reordered "+
+				"to merge some branches before forking the others (parallelism-memory trade-off). "+
+				"0 disables.")
+		flags[ConfigPipelineMaxConcurrentBranches] = iface
 		iface = interface{}(true)
 		ptr5 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
 		*ptr5 = flagSet.Bool("print-actions", false, "Print the executed actions to stderr.")
 		flags[ConfigPipelinePrintActions] = iface
+		iface = interface{}(true)
+		ptr6 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr6 = flagSet.Bool("print-dag", false, "Print the resolved pipeline items with their "+
+			"dependencies to stdout. Works with --dry-run.")
+		flags[ConfigPipelinePrintDAG] = iface
+		iface = interface{}("")
+		ptr7 := (**string)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr7 = flagSet.String("author-filter", "", "Analyze only the commits with the author "+
+			"emails matching this regular expression. The changes of the skipped commits are "+
+			"attributed to the next analyzed commits. Merges are never skipped.")
+		flags[ConfigPipelineAuthorInclude] = iface
+		iface = interface{}("")
+		ptr8 := (**string)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr8 = flagSet.String("author-exclude", "", "Skip the commits with the author emails "+
+			"matching this regular expression. The changes of the skipped commits are "+
+			"attributed to the next analyzed commits. Merges are never skipped.")
+		flags[ConfigPipelineAuthorExclude] = iface
+		iface = interface{}(true)
+		ptr9 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr9 = flagSet.Bool("no-merges", false, "Do not feed the merge commits to the analyses. "+
+			"The analyses which reconcile the branches on the merges, e.g. the burndown, still see them.")
+		flags[ConfigPipelineSkipMerges] = iface
+		iface = interface{}(true)
+		ptr12 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr12 = flagSet.Bool("continue-on-error", false, "Log and skip the commits which fail "+
+			"to be analysed instead of aborting. The results become partial.")
+		flags[ConfigPipelineContinueOnError] = iface
+		iface = interface{}("")
+		ptr11 := (**string)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr11 = flagSet.String("time-source", TimeSourceCommitter, "Which commit timestamps "+
+			"define the ticks and the analysed time range: \""+TimeSourceCommitter+"\" (like "+
+			"git log) or \""+TimeSourceAuthor+"\". They differ in the rebased commits.")
+		flags[ConfigTimeSource] = iface
 	}
 	var features []string
 	for f := range registry.featureFlags.Choices {
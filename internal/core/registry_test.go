@@ -196,6 +196,70 @@ func TestRegistrySummon(t *testing.T) {
 	assert.Equal(t, summoned[0].Name(), (&testPipelineItem{}).Name())
 }
 
+// customTestPipelineItem replaces testPipelineItem, see TestRegistryOverride.
+type customTestPipelineItem struct {
+	testPipelineItem
+}
+
+func TestRegistryUnregister(t *testing.T) {
+	reg := getRegistry()
+	reg.Register(&testPipelineItem{})
+	reg.Register(&dummyPipelineItem{})
+	reg.Unregister("whatever")
+	reg.Unregister((&testPipelineItem{}).Name())
+	assert.Len(t, reg.Summon((&testPipelineItem{}).Name()), 0)
+	assert.Len(t, reg.Summon((&testPipelineItem{}).Provides()[0]), 0)
+	assert.NotContains(t, reg.provided, (&testPipelineItem{}).Provides()[0])
+	assert.Len(t, reg.GetLeaves(), 0)
+	assert.Len(t, reg.Summon((&dummyPipelineItem{}).Provides()[0]), 2)
+}
+
+func TestRegistryOverride(t *testing.T) {
+	reg := getRegistry()
+	reg.Register(&testPipelineItem{})
+	reg.Override(&customTestPipelineItem{})
+	summoned := reg.Summon((&testPipelineItem{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.IsType(t, &customTestPipelineItem{}, summoned[0])
+	summoned = reg.Summon((&testPipelineItem{}).Provides()[0])
+	assert.Len(t, summoned, 1)
+	assert.IsType(t, &customTestPipelineItem{}, summoned[0])
+	leaves := reg.GetLeaves()
+	assert.Len(t, leaves, 1)
+	assert.IsType(t, &customTestPipelineItem{}, leaves[0])
+	// Register() would leave both
+	reg.Register(&testPipelineItem{})
+	assert.Len(t, reg.Summon((&testPipelineItem{}).Provides()[0]), 2)
+	reg = getRegistry()
+	reg.Override(&customTestPipelineItem{})
+	assert.Len(t, reg.Summon((&testPipelineItem{}).Name()), 1)
+}
+
+type dummyResultSink struct {
+	names []string
+}
+
+func (sink *dummyResultSink) Consume(
+	itemName string, result interface{}, common *CommonAnalysisResult) error {
+	sink.names = append(sink.names, itemName)
+	return nil
+}
+
+func TestRegistrySinks(t *testing.T) {
+	reg := getRegistry()
+	assert.Len(t, reg.GetSinks(), 0)
+	sink1 := &dummyResultSink{}
+	sink2 := &dummyResultSink{}
+	reg.RegisterSink(sink1)
+	reg.RegisterSink(sink2)
+	sinks := reg.GetSinks()
+	assert.Len(t, sinks, 2)
+	assert.True(t, sinks[0] == sink1)
+	assert.True(t, sinks[1] == sink2)
+	sinks[0] = nil
+	assert.True(t, reg.GetSinks()[0] == sink1)
+}
+
 func TestRegistryAddFlags(t *testing.T) {
 	reg := getRegistry()
 	reg.Register(&testPipelineItem{})
@@ -208,13 +272,20 @@ func TestRegistryAddFlags(t *testing.T) {
 		Run:   func(cmd *cobra.Command, args []string) {},
 	}
 	facts, deployed := reg.AddFlags(testCmd.Flags())
-	assert.Len(t, facts, 7)
+	assert.Len(t, facts, 14)
 	assert.IsType(t, 0, facts[(&testPipelineItem{}).ListConfigurationOptions()[0].Name])
 	assert.IsType(t, true, facts[(&dummyPipelineItem{}).ListConfigurationOptions()[0].Name])
 	assert.Contains(t, facts, ConfigPipelineDryRun)
 	assert.Contains(t, facts, ConfigPipelineDAGPath)
 	assert.Contains(t, facts, ConfigPipelineDumpPlan)
 	assert.Contains(t, facts, ConfigPipelineHibernationDistance)
+	assert.Contains(t, facts, ConfigPipelineMaxConcurrentBranches)
+	assert.Contains(t, facts, ConfigPipelinePrintDAG)
+	assert.Contains(t, facts, ConfigPipelineAuthorInclude)
+	assert.Contains(t, facts, ConfigPipelineAuthorExclude)
+	assert.Contains(t, facts, ConfigPipelineSkipMerges)
+	assert.Contains(t, facts, ConfigPipelineContinueOnError)
+	assert.Contains(t, facts, ConfigTimeSource)
 	assert.Len(t, deployed, 1)
 	assert.Contains(t, deployed, (&testPipelineItem{}).Name())
 	assert.NotNil(t, testCmd.Flags().Lookup((&testPipelineItem{}).Flag()))
@@ -223,7 +294,12 @@ func TestRegistryAddFlags(t *testing.T) {
 	assert.NotNil(t, testCmd.Flags().Lookup("dump-plan"))
 	assert.NotNil(t, testCmd.Flags().Lookup("dry-run"))
 	assert.NotNil(t, testCmd.Flags().Lookup("hibernation-distance"))
+	assert.NotNil(t, testCmd.Flags().Lookup("max-branches"))
 	assert.NotNil(t, testCmd.Flags().Lookup("print-actions"))
+	assert.NotNil(t, testCmd.Flags().Lookup("print-dag"))
+	assert.NotNil(t, testCmd.Flags().Lookup("author-filter"))
+	assert.NotNil(t, testCmd.Flags().Lookup("author-exclude"))
+	assert.NotNil(t, testCmd.Flags().Lookup("no-merges"))
 	assert.NotNil(t, testCmd.Flags().Lookup(
 		(&testPipelineItem{}).ListConfigurationOptions()[0].Flag))
 	assert.NotNil(t, testCmd.Flags().Lookup(
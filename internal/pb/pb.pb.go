@@ -36,10 +36,16 @@ type Metadata struct {
 	// duration of the analysis in milliseconds
 	RunTime int64 `protobuf:"varint,7,opt,name=run_time,json=runTime,proto3" json:"run_time,omitempty"`
 	// time taken by each pipeline item in seconds
-	RunTimePerItem       map[string]float64 `protobuf:"bytes,8,rep,name=run_time_per_item,json=runTimePerItem,proto3" json:"run_time_per_item,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	RunTimePerItem map[string]float64 `protobuf:"bytes,8,rep,name=run_time_per_item,json=runTimePerItem,proto3" json:"run_time_per_item,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// number of commits with out-of-order timestamps
+	SkewedCommits int32 `protobuf:"varint,9,opt,name=skewed_commits,json=skewedCommits,proto3" json:"skewed_commits,omitempty"`
+	// number of commits skipped because of the analysis errors
+	FailedCommits int32 `protobuf:"varint,10,opt,name=failed_commits,json=failedCommits,proto3" json:"failed_commits,omitempty"`
+	// effective configuration options of the analysis
+	Config               map[string]string `protobuf:"bytes,11,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *Metadata) Reset()         { *m = Metadata{} }
@@ -122,6 +128,27 @@ func (m *Metadata) GetRunTimePerItem() map[string]float64 {
 	return nil
 }
 
+func (m *Metadata) GetSkewedCommits() int32 {
+	if m != nil {
+		return m.SkewedCommits
+	}
+	return 0
+}
+
+func (m *Metadata) GetFailedCommits() int32 {
+	if m != nil {
+		return m.FailedCommits
+	}
+	return 0
+}
+
+func (m *Metadata) GetConfig() map[string]string {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
 type BurndownSparseMatrixRow struct {
 	// the first `len(column)` elements are stored,
 	// the rest `number_of_columns - len(column)` values are zeros
@@ -280,7 +307,24 @@ type BurndownAnalysisResults struct {
 	// How many lines belong to relevant developers for each file. The order is the same as in `files`.
 	FilesOwnership []*FilesOwnership `protobuf:"bytes,7,rep,name=files_ownership,json=filesOwnership,proto3" json:"files_ownership,omitempty"`
 	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
-	TickSize             int64    `protobuf:"varint,8,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	TickSize int64 `protobuf:"varint,8,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	// this is included if `--burndown-author-activity` was specified
+	ActiveAuthors *BurndownSparseMatrix `protobuf:"bytes,9,opt,name=active_authors,json=activeAuthors,proto3" json:"active_authors,omitempty"`
+	// how many trailing ticks define an active developer
+	ActiveAuthorsWindow int32 `protobuf:"varint,10,opt,name=active_authors_window,json=activeAuthorsWindow,proto3" json:"active_authors_window,omitempty"`
+	// this is included if `--burndown-commit-counts` was specified
+	CommitCounts *BurndownSparseMatrix `protobuf:"bytes,11,opt,name=commit_counts,json=commitCounts,proto3" json:"commit_counts,omitempty"`
+	// this is included if `--burndown-old-vs-new-threshold` was specified:
+	// [tick][old added, old removed, new added, new removed]
+	OldVsNew *BurndownSparseMatrix `protobuf:"bytes,12,opt,name=old_vs_new,json=oldVsNew,proto3" json:"old_vs_new,omitempty"`
+	// how many ticks old the lines must be to be considered old
+	OldVsNewThreshold int32 `protobuf:"varint,13,opt,name=old_vs_new_threshold,json=oldVsNewThreshold,proto3" json:"old_vs_new_threshold,omitempty"`
+	// this is included if `--burndown-sample-commits` was specified:
+	// the hash of the last commit processed before each sample boundary
+	SampleCommits []string `protobuf:"bytes,14,rep,name=sample_commits,json=sampleCommits,proto3" json:"sample_commits,omitempty"`
+	// this is included if `--burndown-total-lines` was specified:
+	// the number of alive lines at the end of each tick
+	TotalLines           []int64  `protobuf:"varint,15,rep,packed,name=total_lines,json=totalLines,proto3" json:"total_lines,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -366,6 +410,55 @@ func (m *BurndownAnalysisResults) GetTickSize() int64 {
 	return 0
 }
 
+func (m *BurndownAnalysisResults) GetActiveAuthors() *BurndownSparseMatrix {
+	if m != nil {
+		return m.ActiveAuthors
+	}
+	return nil
+}
+
+func (m *BurndownAnalysisResults) GetActiveAuthorsWindow() int32 {
+	if m != nil {
+		return m.ActiveAuthorsWindow
+	}
+	return 0
+}
+
+func (m *BurndownAnalysisResults) GetCommitCounts() *BurndownSparseMatrix {
+	if m != nil {
+		return m.CommitCounts
+	}
+	return nil
+}
+
+func (m *BurndownAnalysisResults) GetOldVsNew() *BurndownSparseMatrix {
+	if m != nil {
+		return m.OldVsNew
+	}
+	return nil
+}
+
+func (m *BurndownAnalysisResults) GetOldVsNewThreshold() int32 {
+	if m != nil {
+		return m.OldVsNewThreshold
+	}
+	return 0
+}
+
+func (m *BurndownAnalysisResults) GetSampleCommits() []string {
+	if m != nil {
+		return m.SampleCommits
+	}
+	return nil
+}
+
+func (m *BurndownAnalysisResults) GetTotalLines() []int64 {
+	if m != nil {
+		return m.TotalLines
+	}
+	return nil
+}
+
 type CompressedSparseRowMatrix struct {
 	NumberOfRows    int32 `protobuf:"varint,1,opt,name=number_of_rows,json=numberOfRows,proto3" json:"number_of_rows,omitempty"`
 	NumberOfColumns int32 `protobuf:"varint,2,opt,name=number_of_columns,json=numberOfColumns,proto3" json:"number_of_columns,omitempty"`
@@ -437,6 +530,77 @@ func (m *CompressedSparseRowMatrix) GetIndptr() []int64 {
 	return nil
 }
 
+type CompressedSparseRowFloatMatrix struct {
+	NumberOfRows    int32 `protobuf:"varint,1,opt,name=number_of_rows,json=numberOfRows,proto3" json:"number_of_rows,omitempty"`
+	NumberOfColumns int32 `protobuf:"varint,2,opt,name=number_of_columns,json=numberOfColumns,proto3" json:"number_of_columns,omitempty"`
+	// same layout as CompressedSparseRowMatrix, but the values are floating point
+	Data                 []float32 `protobuf:"fixed32,3,rep,packed,name=data,proto3" json:"data,omitempty"`
+	Indices              []int32   `protobuf:"varint,4,rep,packed,name=indices,proto3" json:"indices,omitempty"`
+	Indptr               []int64   `protobuf:"varint,5,rep,packed,name=indptr,proto3" json:"indptr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CompressedSparseRowFloatMatrix) Reset()         { *m = CompressedSparseRowFloatMatrix{} }
+func (m *CompressedSparseRowFloatMatrix) String() string { return proto.CompactTextString(m) }
+func (*CompressedSparseRowFloatMatrix) ProtoMessage()    {}
+func (*CompressedSparseRowFloatMatrix) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{6}
+}
+func (m *CompressedSparseRowFloatMatrix) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompressedSparseRowFloatMatrix.Unmarshal(m, b)
+}
+func (m *CompressedSparseRowFloatMatrix) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompressedSparseRowFloatMatrix.Marshal(b, m, deterministic)
+}
+func (m *CompressedSparseRowFloatMatrix) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompressedSparseRowFloatMatrix.Merge(m, src)
+}
+func (m *CompressedSparseRowFloatMatrix) XXX_Size() int {
+	return xxx_messageInfo_CompressedSparseRowFloatMatrix.Size(m)
+}
+func (m *CompressedSparseRowFloatMatrix) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompressedSparseRowFloatMatrix.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompressedSparseRowFloatMatrix proto.InternalMessageInfo
+
+func (m *CompressedSparseRowFloatMatrix) GetNumberOfRows() int32 {
+	if m != nil {
+		return m.NumberOfRows
+	}
+	return 0
+}
+
+func (m *CompressedSparseRowFloatMatrix) GetNumberOfColumns() int32 {
+	if m != nil {
+		return m.NumberOfColumns
+	}
+	return 0
+}
+
+func (m *CompressedSparseRowFloatMatrix) GetData() []float32 {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *CompressedSparseRowFloatMatrix) GetIndices() []int32 {
+	if m != nil {
+		return m.Indices
+	}
+	return nil
+}
+
+func (m *CompressedSparseRowFloatMatrix) GetIndptr() []int64 {
+	if m != nil {
+		return m.Indptr
+	}
+	return nil
+}
+
 type Couples struct {
 	// name of each `matrix`'s row and column
 	Index []string `protobuf:"bytes,1,rep,name=index,proto3" json:"index,omitempty"`
@@ -451,7 +615,7 @@ func (m *Couples) Reset()         { *m = Couples{} }
 func (m *Couples) String() string { return proto.CompactTextString(m) }
 func (*Couples) ProtoMessage()    {}
 func (*Couples) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{6}
+	return fileDescriptor_f80abaa17e25ccc8, []int{7}
 }
 func (m *Couples) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Couples.Unmarshal(m, b)
@@ -496,7 +660,7 @@ func (m *TouchedFiles) Reset()         { *m = TouchedFiles{} }
 func (m *TouchedFiles) String() string { return proto.CompactTextString(m) }
 func (*TouchedFiles) ProtoMessage()    {}
 func (*TouchedFiles) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{7}
+	return fileDescriptor_f80abaa17e25ccc8, []int{8}
 }
 func (m *TouchedFiles) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TouchedFiles.Unmarshal(m, b)
@@ -529,17 +693,23 @@ type CouplesAnalysisResults struct {
 	// order corresponds to `people_couples::index`
 	PeopleFiles []*TouchedFiles `protobuf:"bytes,8,rep,name=people_files,json=peopleFiles,proto3" json:"people_files,omitempty"`
 	// order corresponds to `file_couples::index`
-	FilesLines           []int32  `protobuf:"varint,9,rep,packed,name=files_lines,json=filesLines,proto3" json:"files_lines,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	FilesLines []int32 `protobuf:"varint,9,rep,packed,name=files_lines,json=filesLines,proto3" json:"files_lines,omitempty"`
+	// Jaccard similarity index of each pair of files, included if `--couples-jaccard` was specified;
+	// rows and cols order correspond to `file_couples::index`
+	FilesJaccard *CompressedSparseRowFloatMatrix `protobuf:"bytes,10,opt,name=files_jaccard,json=filesJaccard,proto3" json:"files_jaccard,omitempty"`
+	// embeddings of the files, included if `--couples-embeddings` was specified;
+	// order corresponds to `file_couples::index`
+	FilesEmbeddings      []*FileEmbedding `protobuf:"bytes,11,rep,name=files_embeddings,json=filesEmbeddings,proto3" json:"files_embeddings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *CouplesAnalysisResults) Reset()         { *m = CouplesAnalysisResults{} }
 func (m *CouplesAnalysisResults) String() string { return proto.CompactTextString(m) }
 func (*CouplesAnalysisResults) ProtoMessage()    {}
 func (*CouplesAnalysisResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{8}
+	return fileDescriptor_f80abaa17e25ccc8, []int{9}
 }
 func (m *CouplesAnalysisResults) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CouplesAnalysisResults.Unmarshal(m, b)
@@ -587,6 +757,58 @@ func (m *CouplesAnalysisResults) GetFilesLines() []int32 {
 	return nil
 }
 
+func (m *CouplesAnalysisResults) GetFilesJaccard() *CompressedSparseRowFloatMatrix {
+	if m != nil {
+		return m.FilesJaccard
+	}
+	return nil
+}
+
+func (m *CouplesAnalysisResults) GetFilesEmbeddings() []*FileEmbedding {
+	if m != nil {
+		return m.FilesEmbeddings
+	}
+	return nil
+}
+
+type FileEmbedding struct {
+	Vector               []float32 `protobuf:"fixed32,1,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *FileEmbedding) Reset()         { *m = FileEmbedding{} }
+func (m *FileEmbedding) String() string { return proto.CompactTextString(m) }
+func (*FileEmbedding) ProtoMessage()    {}
+func (*FileEmbedding) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{10}
+}
+func (m *FileEmbedding) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileEmbedding.Unmarshal(m, b)
+}
+func (m *FileEmbedding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileEmbedding.Marshal(b, m, deterministic)
+}
+func (m *FileEmbedding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileEmbedding.Merge(m, src)
+}
+func (m *FileEmbedding) XXX_Size() int {
+	return xxx_messageInfo_FileEmbedding.Size(m)
+}
+func (m *FileEmbedding) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileEmbedding.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileEmbedding proto.InternalMessageInfo
+
+func (m *FileEmbedding) GetVector() []float32 {
+	if m != nil {
+		return m.Vector
+	}
+	return nil
+}
+
 type UASTChange struct {
 	FileName             string   `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
 	SrcBefore            string   `protobuf:"bytes,2,opt,name=src_before,json=srcBefore,proto3" json:"src_before,omitempty"`
@@ -602,7 +824,7 @@ func (m *UASTChange) Reset()         { *m = UASTChange{} }
 func (m *UASTChange) String() string { return proto.CompactTextString(m) }
 func (*UASTChange) ProtoMessage()    {}
 func (*UASTChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{9}
+	return fileDescriptor_f80abaa17e25ccc8, []int{11}
 }
 func (m *UASTChange) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UASTChange.Unmarshal(m, b)
@@ -668,7 +890,7 @@ func (m *UASTChangesSaverResults) Reset()         { *m = UASTChangesSaverResults
 func (m *UASTChangesSaverResults) String() string { return proto.CompactTextString(m) }
 func (*UASTChangesSaverResults) ProtoMessage()    {}
 func (*UASTChangesSaverResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{10}
+	return fileDescriptor_f80abaa17e25ccc8, []int{12}
 }
 func (m *UASTChangesSaverResults) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UASTChangesSaverResults.Unmarshal(m, b)
@@ -709,7 +931,7 @@ func (m *ShotnessRecord) Reset()         { *m = ShotnessRecord{} }
 func (m *ShotnessRecord) String() string { return proto.CompactTextString(m) }
 func (*ShotnessRecord) ProtoMessage()    {}
 func (*ShotnessRecord) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{11}
+	return fileDescriptor_f80abaa17e25ccc8, []int{13}
 }
 func (m *ShotnessRecord) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ShotnessRecord.Unmarshal(m, b)
@@ -768,7 +990,7 @@ func (m *ShotnessAnalysisResults) Reset()         { *m = ShotnessAnalysisResults
 func (m *ShotnessAnalysisResults) String() string { return proto.CompactTextString(m) }
 func (*ShotnessAnalysisResults) ProtoMessage()    {}
 func (*ShotnessAnalysisResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{12}
+	return fileDescriptor_f80abaa17e25ccc8, []int{14}
 }
 func (m *ShotnessAnalysisResults) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ShotnessAnalysisResults.Unmarshal(m, b)
@@ -807,7 +1029,7 @@ func (m *FileHistory) Reset()         { *m = FileHistory{} }
 func (m *FileHistory) String() string { return proto.CompactTextString(m) }
 func (*FileHistory) ProtoMessage()    {}
 func (*FileHistory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{13}
+	return fileDescriptor_f80abaa17e25ccc8, []int{15}
 }
 func (m *FileHistory) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FileHistory.Unmarshal(m, b)
@@ -852,7 +1074,7 @@ func (m *FileHistoryResultMessage) Reset()         { *m = FileHistoryResultMessa
 func (m *FileHistoryResultMessage) String() string { return proto.CompactTextString(m) }
 func (*FileHistoryResultMessage) ProtoMessage()    {}
 func (*FileHistoryResultMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{14}
+	return fileDescriptor_f80abaa17e25ccc8, []int{16}
 }
 func (m *FileHistoryResultMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FileHistoryResultMessage.Unmarshal(m, b)
@@ -879,733 +1101,2834 @@ func (m *FileHistoryResultMessage) GetFiles() map[string]*FileHistory {
 	return nil
 }
 
-type LineStats struct {
-	Added                int32    `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
-	Removed              int32    `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
-	Changed              int32    `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"`
+type BinaryChurnAnalysisResults struct {
+	// number of added binary files in each tick
+	Added []int64 `protobuf:"varint,1,rep,packed,name=added,proto3" json:"added,omitempty"`
+	// number of removed binary files in each tick
+	Removed []int64 `protobuf:"varint,2,rep,packed,name=removed,proto3" json:"removed,omitempty"`
+	// added - removed
+	Deltas []int64 `protobuf:"varint,3,rep,packed,name=deltas,proto3" json:"deltas,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *LineStats) Reset()         { *m = LineStats{} }
-func (m *LineStats) String() string { return proto.CompactTextString(m) }
-func (*LineStats) ProtoMessage()    {}
-func (*LineStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{15}
+func (m *BinaryChurnAnalysisResults) Reset()         { *m = BinaryChurnAnalysisResults{} }
+func (m *BinaryChurnAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*BinaryChurnAnalysisResults) ProtoMessage()    {}
+func (*BinaryChurnAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{17}
 }
-func (m *LineStats) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_LineStats.Unmarshal(m, b)
+func (m *BinaryChurnAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BinaryChurnAnalysisResults.Unmarshal(m, b)
 }
-func (m *LineStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_LineStats.Marshal(b, m, deterministic)
+func (m *BinaryChurnAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BinaryChurnAnalysisResults.Marshal(b, m, deterministic)
 }
-func (m *LineStats) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LineStats.Merge(m, src)
+func (m *BinaryChurnAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BinaryChurnAnalysisResults.Merge(m, src)
 }
-func (m *LineStats) XXX_Size() int {
-	return xxx_messageInfo_LineStats.Size(m)
+func (m *BinaryChurnAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_BinaryChurnAnalysisResults.Size(m)
 }
-func (m *LineStats) XXX_DiscardUnknown() {
-	xxx_messageInfo_LineStats.DiscardUnknown(m)
+func (m *BinaryChurnAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_BinaryChurnAnalysisResults.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LineStats proto.InternalMessageInfo
+var xxx_messageInfo_BinaryChurnAnalysisResults proto.InternalMessageInfo
 
-func (m *LineStats) GetAdded() int32 {
+func (m *BinaryChurnAnalysisResults) GetAdded() []int64 {
 	if m != nil {
 		return m.Added
 	}
-	return 0
+	return nil
 }
 
-func (m *LineStats) GetRemoved() int32 {
+func (m *BinaryChurnAnalysisResults) GetRemoved() []int64 {
 	if m != nil {
 		return m.Removed
 	}
-	return 0
+	return nil
 }
 
-func (m *LineStats) GetChanged() int32 {
+func (m *BinaryChurnAnalysisResults) GetDeltas() []int64 {
 	if m != nil {
-		return m.Changed
+		return m.Deltas
+	}
+	return nil
+}
+
+func (m *BinaryChurnAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
 	}
 	return 0
 }
 
-type DevTick struct {
-	Commits              int32                 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
-	Stats                *LineStats            `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
-	Languages            map[string]*LineStats `protobuf:"bytes,3,rep,name=languages,proto3" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+type FileGenesis struct {
+	Tick int32 `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	// index in FileGenesisResults.author_index, -1 if the author is unknown
+	Author               int32    `protobuf:"varint,2,opt,name=author,proto3" json:"author,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DevTick) Reset()         { *m = DevTick{} }
-func (m *DevTick) String() string { return proto.CompactTextString(m) }
-func (*DevTick) ProtoMessage()    {}
-func (*DevTick) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{16}
+func (m *FileGenesis) Reset()         { *m = FileGenesis{} }
+func (m *FileGenesis) String() string { return proto.CompactTextString(m) }
+func (*FileGenesis) ProtoMessage()    {}
+func (*FileGenesis) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{18}
 }
-func (m *DevTick) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DevTick.Unmarshal(m, b)
+func (m *FileGenesis) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileGenesis.Unmarshal(m, b)
 }
-func (m *DevTick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DevTick.Marshal(b, m, deterministic)
+func (m *FileGenesis) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileGenesis.Marshal(b, m, deterministic)
 }
-func (m *DevTick) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DevTick.Merge(m, src)
+func (m *FileGenesis) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileGenesis.Merge(m, src)
 }
-func (m *DevTick) XXX_Size() int {
-	return xxx_messageInfo_DevTick.Size(m)
+func (m *FileGenesis) XXX_Size() int {
+	return xxx_messageInfo_FileGenesis.Size(m)
 }
-func (m *DevTick) XXX_DiscardUnknown() {
-	xxx_messageInfo_DevTick.DiscardUnknown(m)
+func (m *FileGenesis) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileGenesis.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DevTick proto.InternalMessageInfo
+var xxx_messageInfo_FileGenesis proto.InternalMessageInfo
 
-func (m *DevTick) GetCommits() int32 {
+func (m *FileGenesis) GetTick() int32 {
 	if m != nil {
-		return m.Commits
+		return m.Tick
 	}
 	return 0
 }
 
-func (m *DevTick) GetStats() *LineStats {
+func (m *FileGenesis) GetAuthor() int32 {
 	if m != nil {
-		return m.Stats
+		return m.Author
 	}
-	return nil
+	return 0
 }
 
-func (m *DevTick) GetLanguages() map[string]*LineStats {
-	if m != nil {
-		return m.Languages
-	}
-	return nil
+type FileGenesisResults struct {
+	Files       map[string]*FileGenesis `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	AuthorIndex []string                `protobuf:"bytes,2,rep,name=author_index,json=authorIndex,proto3" json:"author_index,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,3,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type TickDevs struct {
-	Devs                 map[int32]*DevTick `protobuf:"bytes,1,rep,name=devs,proto3" json:"devs,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+func (m *FileGenesisResults) Reset()         { *m = FileGenesisResults{} }
+func (m *FileGenesisResults) String() string { return proto.CompactTextString(m) }
+func (*FileGenesisResults) ProtoMessage()    {}
+func (*FileGenesisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{19}
 }
-
-func (m *TickDevs) Reset()         { *m = TickDevs{} }
-func (m *TickDevs) String() string { return proto.CompactTextString(m) }
-func (*TickDevs) ProtoMessage()    {}
-func (*TickDevs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{17}
+func (m *FileGenesisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileGenesisResults.Unmarshal(m, b)
 }
-func (m *TickDevs) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TickDevs.Unmarshal(m, b)
+func (m *FileGenesisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileGenesisResults.Marshal(b, m, deterministic)
 }
-func (m *TickDevs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TickDevs.Marshal(b, m, deterministic)
+func (m *FileGenesisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileGenesisResults.Merge(m, src)
 }
-func (m *TickDevs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TickDevs.Merge(m, src)
+func (m *FileGenesisResults) XXX_Size() int {
+	return xxx_messageInfo_FileGenesisResults.Size(m)
 }
-func (m *TickDevs) XXX_Size() int {
-	return xxx_messageInfo_TickDevs.Size(m)
+func (m *FileGenesisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileGenesisResults.DiscardUnknown(m)
 }
-func (m *TickDevs) XXX_DiscardUnknown() {
-	xxx_messageInfo_TickDevs.DiscardUnknown(m)
+
+var xxx_messageInfo_FileGenesisResults proto.InternalMessageInfo
+
+func (m *FileGenesisResults) GetFiles() map[string]*FileGenesis {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *FileGenesisResults) GetAuthorIndex() []string {
+	if m != nil {
+		return m.AuthorIndex
+	}
+	return nil
+}
+
+func (m *FileGenesisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type Hotspot struct {
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// total number of edits
+	Edits int64 `protobuf:"varint,2,opt,name=edits,proto3" json:"edits,omitempty"`
+	// number of edits in each tick
+	Series               []int64  `protobuf:"varint,3,rep,packed,name=series,proto3" json:"series,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Hotspot) Reset()         { *m = Hotspot{} }
+func (m *Hotspot) String() string { return proto.CompactTextString(m) }
+func (*Hotspot) ProtoMessage()    {}
+func (*Hotspot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{20}
+}
+func (m *Hotspot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Hotspot.Unmarshal(m, b)
+}
+func (m *Hotspot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Hotspot.Marshal(b, m, deterministic)
+}
+func (m *Hotspot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Hotspot.Merge(m, src)
+}
+func (m *Hotspot) XXX_Size() int {
+	return xxx_messageInfo_Hotspot.Size(m)
+}
+func (m *Hotspot) XXX_DiscardUnknown() {
+	xxx_messageInfo_Hotspot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Hotspot proto.InternalMessageInfo
+
+func (m *Hotspot) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Hotspot) GetEdits() int64 {
+	if m != nil {
+		return m.Edits
+	}
+	return 0
+}
+
+func (m *Hotspot) GetSeries() []int64 {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+type HotspotsAnalysisResults struct {
+	// sorted by the number of edits in descending order
+	Files []*Hotspot `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,2,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HotspotsAnalysisResults) Reset()         { *m = HotspotsAnalysisResults{} }
+func (m *HotspotsAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*HotspotsAnalysisResults) ProtoMessage()    {}
+func (*HotspotsAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{21}
+}
+func (m *HotspotsAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HotspotsAnalysisResults.Unmarshal(m, b)
+}
+func (m *HotspotsAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HotspotsAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *HotspotsAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HotspotsAnalysisResults.Merge(m, src)
+}
+func (m *HotspotsAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_HotspotsAnalysisResults.Size(m)
+}
+func (m *HotspotsAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_HotspotsAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HotspotsAnalysisResults proto.InternalMessageInfo
+
+func (m *HotspotsAnalysisResults) GetFiles() []*Hotspot {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *HotspotsAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type BusFactor struct {
+	// file path or directory prefix ending with "/"
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// number of surviving lines with known authors
+	Lines int32 `protobuf:"varint,2,opt,name=lines,proto3" json:"lines,omitempty"`
+	// minimum number of developers who together own more than half of the lines
+	BusFactor int32 `protobuf:"varint,3,opt,name=bus_factor,json=busFactor,proto3" json:"bus_factor,omitempty"`
+	// order corresponds to the owned lines in descending order, indexes in dev_index
+	Owners               []int32  `protobuf:"varint,4,rep,packed,name=owners,proto3" json:"owners,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BusFactor) Reset()         { *m = BusFactor{} }
+func (m *BusFactor) String() string { return proto.CompactTextString(m) }
+func (*BusFactor) ProtoMessage()    {}
+func (*BusFactor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{22}
+}
+func (m *BusFactor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BusFactor.Unmarshal(m, b)
+}
+func (m *BusFactor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BusFactor.Marshal(b, m, deterministic)
+}
+func (m *BusFactor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BusFactor.Merge(m, src)
+}
+func (m *BusFactor) XXX_Size() int {
+	return xxx_messageInfo_BusFactor.Size(m)
+}
+func (m *BusFactor) XXX_DiscardUnknown() {
+	xxx_messageInfo_BusFactor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BusFactor proto.InternalMessageInfo
+
+func (m *BusFactor) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *BusFactor) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *BusFactor) GetBusFactor() int32 {
+	if m != nil {
+		return m.BusFactor
+	}
+	return 0
+}
+
+func (m *BusFactor) GetOwners() []int32 {
+	if m != nil {
+		return m.Owners
+	}
+	return nil
+}
+
+type BusFactorAnalysisResults struct {
+	// sorted by path
+	Files                []*BusFactor `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Directories          []*BusFactor `protobuf:"bytes,2,rep,name=directories,proto3" json:"directories,omitempty"`
+	DevIndex             []string     `protobuf:"bytes,3,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *BusFactorAnalysisResults) Reset()         { *m = BusFactorAnalysisResults{} }
+func (m *BusFactorAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*BusFactorAnalysisResults) ProtoMessage()    {}
+func (*BusFactorAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{23}
+}
+func (m *BusFactorAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BusFactorAnalysisResults.Unmarshal(m, b)
+}
+func (m *BusFactorAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BusFactorAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *BusFactorAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BusFactorAnalysisResults.Merge(m, src)
+}
+func (m *BusFactorAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_BusFactorAnalysisResults.Size(m)
+}
+func (m *BusFactorAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_BusFactorAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BusFactorAnalysisResults proto.InternalMessageInfo
+
+func (m *BusFactorAnalysisResults) GetFiles() []*BusFactor {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *BusFactorAnalysisResults) GetDirectories() []*BusFactor {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+func (m *BusFactorAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type CommitSizeTick struct {
+	// number of commits in each size class
+	Counts               []int64  `protobuf:"varint,1,rep,packed,name=counts,proto3" json:"counts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitSizeTick) Reset()         { *m = CommitSizeTick{} }
+func (m *CommitSizeTick) String() string { return proto.CompactTextString(m) }
+func (*CommitSizeTick) ProtoMessage()    {}
+func (*CommitSizeTick) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{24}
+}
+func (m *CommitSizeTick) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitSizeTick.Unmarshal(m, b)
+}
+func (m *CommitSizeTick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitSizeTick.Marshal(b, m, deterministic)
+}
+func (m *CommitSizeTick) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitSizeTick.Merge(m, src)
+}
+func (m *CommitSizeTick) XXX_Size() int {
+	return xxx_messageInfo_CommitSizeTick.Size(m)
+}
+func (m *CommitSizeTick) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitSizeTick.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitSizeTick proto.InternalMessageInfo
+
+func (m *CommitSizeTick) GetCounts() []int64 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+type CommitSizeAnalysisResults struct {
+	// inclusive upper limits of the size classes, the last class is unbounded
+	Bounds []int32                   `protobuf:"varint,1,rep,packed,name=bounds,proto3" json:"bounds,omitempty"`
+	Ticks  map[int32]*CommitSizeTick `protobuf:"bytes,2,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,3,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitSizeAnalysisResults) Reset()         { *m = CommitSizeAnalysisResults{} }
+func (m *CommitSizeAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*CommitSizeAnalysisResults) ProtoMessage()    {}
+func (*CommitSizeAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{25}
+}
+func (m *CommitSizeAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitSizeAnalysisResults.Unmarshal(m, b)
+}
+func (m *CommitSizeAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitSizeAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *CommitSizeAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitSizeAnalysisResults.Merge(m, src)
+}
+func (m *CommitSizeAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_CommitSizeAnalysisResults.Size(m)
+}
+func (m *CommitSizeAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitSizeAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitSizeAnalysisResults proto.InternalMessageInfo
+
+func (m *CommitSizeAnalysisResults) GetBounds() []int32 {
+	if m != nil {
+		return m.Bounds
+	}
+	return nil
+}
+
+func (m *CommitSizeAnalysisResults) GetTicks() map[int32]*CommitSizeTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *CommitSizeAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type LineStats struct {
+	Added                int32    `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Removed              int32    `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	Changed              int32    `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LineStats) Reset()         { *m = LineStats{} }
+func (m *LineStats) String() string { return proto.CompactTextString(m) }
+func (*LineStats) ProtoMessage()    {}
+func (*LineStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{26}
+}
+func (m *LineStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LineStats.Unmarshal(m, b)
+}
+func (m *LineStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LineStats.Marshal(b, m, deterministic)
+}
+func (m *LineStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LineStats.Merge(m, src)
+}
+func (m *LineStats) XXX_Size() int {
+	return xxx_messageInfo_LineStats.Size(m)
+}
+func (m *LineStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_LineStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LineStats proto.InternalMessageInfo
+
+func (m *LineStats) GetAdded() int32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *LineStats) GetRemoved() int32 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+func (m *LineStats) GetChanged() int32 {
+	if m != nil {
+		return m.Changed
+	}
+	return 0
+}
+
+type DevTick struct {
+	Commits              int32                 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	Stats                *LineStats            `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+	Languages            map[string]*LineStats `protobuf:"bytes,3,rep,name=languages,proto3" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *DevTick) Reset()         { *m = DevTick{} }
+func (m *DevTick) String() string { return proto.CompactTextString(m) }
+func (*DevTick) ProtoMessage()    {}
+func (*DevTick) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{27}
+}
+func (m *DevTick) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DevTick.Unmarshal(m, b)
+}
+func (m *DevTick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DevTick.Marshal(b, m, deterministic)
+}
+func (m *DevTick) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DevTick.Merge(m, src)
+}
+func (m *DevTick) XXX_Size() int {
+	return xxx_messageInfo_DevTick.Size(m)
+}
+func (m *DevTick) XXX_DiscardUnknown() {
+	xxx_messageInfo_DevTick.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DevTick proto.InternalMessageInfo
+
+func (m *DevTick) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *DevTick) GetStats() *LineStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (m *DevTick) GetLanguages() map[string]*LineStats {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+type TickDevs struct {
+	Devs                 map[int32]*DevTick `protobuf:"bytes,1,rep,name=devs,proto3" json:"devs,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *TickDevs) Reset()         { *m = TickDevs{} }
+func (m *TickDevs) String() string { return proto.CompactTextString(m) }
+func (*TickDevs) ProtoMessage()    {}
+func (*TickDevs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{28}
+}
+func (m *TickDevs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TickDevs.Unmarshal(m, b)
+}
+func (m *TickDevs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TickDevs.Marshal(b, m, deterministic)
+}
+func (m *TickDevs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TickDevs.Merge(m, src)
+}
+func (m *TickDevs) XXX_Size() int {
+	return xxx_messageInfo_TickDevs.Size(m)
+}
+func (m *TickDevs) XXX_DiscardUnknown() {
+	xxx_messageInfo_TickDevs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TickDevs proto.InternalMessageInfo
+
+func (m *TickDevs) GetDevs() map[int32]*DevTick {
+	if m != nil {
+		return m.Devs
+	}
+	return nil
+}
+
+type DevsAnalysisResults struct {
+	Ticks map[int32]*TickDevs `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// developer identities, the indexes correspond to TickDevs' keys.
+	DevIndex []string `protobuf:"bytes,2,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// the number of distinct developers in each tick, the unmatched authors are not counted.
+	ActiveDevs []int32 `protobuf:"varint,3,rep,packed,name=active_devs,json=activeDevs,proto3" json:"active_devs,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,8,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DevsAnalysisResults) Reset()         { *m = DevsAnalysisResults{} }
+func (m *DevsAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*DevsAnalysisResults) ProtoMessage()    {}
+func (*DevsAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{29}
+}
+func (m *DevsAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DevsAnalysisResults.Unmarshal(m, b)
+}
+func (m *DevsAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DevsAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *DevsAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DevsAnalysisResults.Merge(m, src)
+}
+func (m *DevsAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_DevsAnalysisResults.Size(m)
+}
+func (m *DevsAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_DevsAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DevsAnalysisResults proto.InternalMessageInfo
+
+func (m *DevsAnalysisResults) GetTicks() map[int32]*TickDevs {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *DevsAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *DevsAnalysisResults) GetActiveDevs() []int32 {
+	if m != nil {
+		return m.ActiveDevs
+	}
+	return nil
+}
+
+func (m *DevsAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type Sentiment struct {
+	Value                float32  `protobuf:"fixed32,1,opt,name=value,proto3" json:"value,omitempty"`
+	Comments             []string `protobuf:"bytes,2,rep,name=comments,proto3" json:"comments,omitempty"`
+	Commits              []string `protobuf:"bytes,3,rep,name=commits,proto3" json:"commits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Sentiment) Reset()         { *m = Sentiment{} }
+func (m *Sentiment) String() string { return proto.CompactTextString(m) }
+func (*Sentiment) ProtoMessage()    {}
+func (*Sentiment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{30}
+}
+func (m *Sentiment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Sentiment.Unmarshal(m, b)
+}
+func (m *Sentiment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Sentiment.Marshal(b, m, deterministic)
+}
+func (m *Sentiment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Sentiment.Merge(m, src)
+}
+func (m *Sentiment) XXX_Size() int {
+	return xxx_messageInfo_Sentiment.Size(m)
+}
+func (m *Sentiment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Sentiment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Sentiment proto.InternalMessageInfo
+
+func (m *Sentiment) GetValue() float32 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Sentiment) GetComments() []string {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+func (m *Sentiment) GetCommits() []string {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+type CommentSentimentResults struct {
+	SentimentByTick      map[int32]*Sentiment `protobuf:"bytes,1,rep,name=sentiment_by_tick,json=sentimentByTick,proto3" json:"sentiment_by_tick,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CommentSentimentResults) Reset()         { *m = CommentSentimentResults{} }
+func (m *CommentSentimentResults) String() string { return proto.CompactTextString(m) }
+func (*CommentSentimentResults) ProtoMessage()    {}
+func (*CommentSentimentResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{31}
+}
+func (m *CommentSentimentResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommentSentimentResults.Unmarshal(m, b)
+}
+func (m *CommentSentimentResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommentSentimentResults.Marshal(b, m, deterministic)
+}
+func (m *CommentSentimentResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommentSentimentResults.Merge(m, src)
+}
+func (m *CommentSentimentResults) XXX_Size() int {
+	return xxx_messageInfo_CommentSentimentResults.Size(m)
+}
+func (m *CommentSentimentResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommentSentimentResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommentSentimentResults proto.InternalMessageInfo
+
+func (m *CommentSentimentResults) GetSentimentByTick() map[int32]*Sentiment {
+	if m != nil {
+		return m.SentimentByTick
+	}
+	return nil
+}
+
+type CommitFile struct {
+	Name                 string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Language             string     `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	Stats                *LineStats `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CommitFile) Reset()         { *m = CommitFile{} }
+func (m *CommitFile) String() string { return proto.CompactTextString(m) }
+func (*CommitFile) ProtoMessage()    {}
+func (*CommitFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{32}
+}
+func (m *CommitFile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitFile.Unmarshal(m, b)
+}
+func (m *CommitFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitFile.Marshal(b, m, deterministic)
+}
+func (m *CommitFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitFile.Merge(m, src)
+}
+func (m *CommitFile) XXX_Size() int {
+	return xxx_messageInfo_CommitFile.Size(m)
+}
+func (m *CommitFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitFile proto.InternalMessageInfo
+
+func (m *CommitFile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CommitFile) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *CommitFile) GetStats() *LineStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type Commit struct {
+	Hash                 string        `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	WhenUnixTime         int64         `protobuf:"varint,2,opt,name=when_unix_time,json=whenUnixTime,proto3" json:"when_unix_time,omitempty"`
+	Author               int32         `protobuf:"varint,3,opt,name=author,proto3" json:"author,omitempty"`
+	Files                []*CommitFile `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Commit) Reset()         { *m = Commit{} }
+func (m *Commit) String() string { return proto.CompactTextString(m) }
+func (*Commit) ProtoMessage()    {}
+func (*Commit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{33}
+}
+func (m *Commit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Commit.Unmarshal(m, b)
+}
+func (m *Commit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Commit.Marshal(b, m, deterministic)
+}
+func (m *Commit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Commit.Merge(m, src)
+}
+func (m *Commit) XXX_Size() int {
+	return xxx_messageInfo_Commit.Size(m)
+}
+func (m *Commit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Commit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Commit proto.InternalMessageInfo
+
+func (m *Commit) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Commit) GetWhenUnixTime() int64 {
+	if m != nil {
+		return m.WhenUnixTime
+	}
+	return 0
+}
+
+func (m *Commit) GetAuthor() int32 {
+	if m != nil {
+		return m.Author
+	}
+	return 0
+}
+
+func (m *Commit) GetFiles() []*CommitFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type CommitsAnalysisResults struct {
+	Commits              []*Commit `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
+	AuthorIndex          []string  `protobuf:"bytes,2,rep,name=author_index,json=authorIndex,proto3" json:"author_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CommitsAnalysisResults) Reset()         { *m = CommitsAnalysisResults{} }
+func (m *CommitsAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*CommitsAnalysisResults) ProtoMessage()    {}
+func (*CommitsAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{34}
+}
+func (m *CommitsAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitsAnalysisResults.Unmarshal(m, b)
+}
+func (m *CommitsAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitsAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *CommitsAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitsAnalysisResults.Merge(m, src)
+}
+func (m *CommitsAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_CommitsAnalysisResults.Size(m)
+}
+func (m *CommitsAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitsAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitsAnalysisResults proto.InternalMessageInfo
+
+func (m *CommitsAnalysisResults) GetCommits() []*Commit {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+func (m *CommitsAnalysisResults) GetAuthorIndex() []string {
+	if m != nil {
+		return m.AuthorIndex
+	}
+	return nil
+}
+
+type Typo struct {
+	Wrong                string   `protobuf:"bytes,1,opt,name=wrong,proto3" json:"wrong,omitempty"`
+	Correct              string   `protobuf:"bytes,2,opt,name=correct,proto3" json:"correct,omitempty"`
+	Commit               string   `protobuf:"bytes,3,opt,name=commit,proto3" json:"commit,omitempty"`
+	File                 string   `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	Line                 int32    `protobuf:"varint,5,opt,name=line,proto3" json:"line,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Typo) Reset()         { *m = Typo{} }
+func (m *Typo) String() string { return proto.CompactTextString(m) }
+func (*Typo) ProtoMessage()    {}
+func (*Typo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{35}
+}
+func (m *Typo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Typo.Unmarshal(m, b)
+}
+func (m *Typo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Typo.Marshal(b, m, deterministic)
+}
+func (m *Typo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Typo.Merge(m, src)
+}
+func (m *Typo) XXX_Size() int {
+	return xxx_messageInfo_Typo.Size(m)
+}
+func (m *Typo) XXX_DiscardUnknown() {
+	xxx_messageInfo_Typo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Typo proto.InternalMessageInfo
+
+func (m *Typo) GetWrong() string {
+	if m != nil {
+		return m.Wrong
+	}
+	return ""
+}
+
+func (m *Typo) GetCorrect() string {
+	if m != nil {
+		return m.Correct
+	}
+	return ""
+}
+
+func (m *Typo) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *Typo) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *Typo) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+type TyposDataset struct {
+	Typos                []*Typo  `protobuf:"bytes,1,rep,name=typos,proto3" json:"typos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TyposDataset) Reset()         { *m = TyposDataset{} }
+func (m *TyposDataset) String() string { return proto.CompactTextString(m) }
+func (*TyposDataset) ProtoMessage()    {}
+func (*TyposDataset) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{36}
+}
+func (m *TyposDataset) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TyposDataset.Unmarshal(m, b)
+}
+func (m *TyposDataset) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TyposDataset.Marshal(b, m, deterministic)
+}
+func (m *TyposDataset) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TyposDataset.Merge(m, src)
+}
+func (m *TyposDataset) XXX_Size() int {
+	return xxx_messageInfo_TyposDataset.Size(m)
+}
+func (m *TyposDataset) XXX_DiscardUnknown() {
+	xxx_messageInfo_TyposDataset.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TyposDataset proto.InternalMessageInfo
+
+func (m *TyposDataset) GetTypos() []*Typo {
+	if m != nil {
+		return m.Typos
+	}
+	return nil
+}
+
+type ImportsPerTick struct {
+	Counts               map[int32]int64 `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ImportsPerTick) Reset()         { *m = ImportsPerTick{} }
+func (m *ImportsPerTick) String() string { return proto.CompactTextString(m) }
+func (*ImportsPerTick) ProtoMessage()    {}
+func (*ImportsPerTick) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{37}
+}
+func (m *ImportsPerTick) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportsPerTick.Unmarshal(m, b)
+}
+func (m *ImportsPerTick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportsPerTick.Marshal(b, m, deterministic)
+}
+func (m *ImportsPerTick) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportsPerTick.Merge(m, src)
+}
+func (m *ImportsPerTick) XXX_Size() int {
+	return xxx_messageInfo_ImportsPerTick.Size(m)
+}
+func (m *ImportsPerTick) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportsPerTick.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportsPerTick proto.InternalMessageInfo
+
+func (m *ImportsPerTick) GetCounts() map[int32]int64 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+type ImportsPerLanguage struct {
+	Ticks                map[string]*ImportsPerTick `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *ImportsPerLanguage) Reset()         { *m = ImportsPerLanguage{} }
+func (m *ImportsPerLanguage) String() string { return proto.CompactTextString(m) }
+func (*ImportsPerLanguage) ProtoMessage()    {}
+func (*ImportsPerLanguage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{38}
+}
+func (m *ImportsPerLanguage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportsPerLanguage.Unmarshal(m, b)
+}
+func (m *ImportsPerLanguage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportsPerLanguage.Marshal(b, m, deterministic)
+}
+func (m *ImportsPerLanguage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportsPerLanguage.Merge(m, src)
+}
+func (m *ImportsPerLanguage) XXX_Size() int {
+	return xxx_messageInfo_ImportsPerLanguage.Size(m)
+}
+func (m *ImportsPerLanguage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportsPerLanguage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportsPerLanguage proto.InternalMessageInfo
+
+func (m *ImportsPerLanguage) GetTicks() map[string]*ImportsPerTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+type ImportsPerDeveloper struct {
+	Languages            map[string]*ImportsPerLanguage `protobuf:"bytes,1,rep,name=languages,proto3" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *ImportsPerDeveloper) Reset()         { *m = ImportsPerDeveloper{} }
+func (m *ImportsPerDeveloper) String() string { return proto.CompactTextString(m) }
+func (*ImportsPerDeveloper) ProtoMessage()    {}
+func (*ImportsPerDeveloper) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{39}
+}
+func (m *ImportsPerDeveloper) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportsPerDeveloper.Unmarshal(m, b)
+}
+func (m *ImportsPerDeveloper) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportsPerDeveloper.Marshal(b, m, deterministic)
+}
+func (m *ImportsPerDeveloper) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportsPerDeveloper.Merge(m, src)
+}
+func (m *ImportsPerDeveloper) XXX_Size() int {
+	return xxx_messageInfo_ImportsPerDeveloper.Size(m)
+}
+func (m *ImportsPerDeveloper) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportsPerDeveloper.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportsPerDeveloper proto.InternalMessageInfo
+
+func (m *ImportsPerDeveloper) GetLanguages() map[string]*ImportsPerLanguage {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+type ImportsPerDeveloperResults struct {
+	Imports     []*ImportsPerDeveloper `protobuf:"bytes,1,rep,name=imports,proto3" json:"imports,omitempty"`
+	AuthorIndex []string               `protobuf:"bytes,2,rep,name=author_index,json=authorIndex,proto3" json:"author_index,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,3,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportsPerDeveloperResults) Reset()         { *m = ImportsPerDeveloperResults{} }
+func (m *ImportsPerDeveloperResults) String() string { return proto.CompactTextString(m) }
+func (*ImportsPerDeveloperResults) ProtoMessage()    {}
+func (*ImportsPerDeveloperResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{40}
+}
+func (m *ImportsPerDeveloperResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportsPerDeveloperResults.Unmarshal(m, b)
+}
+func (m *ImportsPerDeveloperResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportsPerDeveloperResults.Marshal(b, m, deterministic)
+}
+func (m *ImportsPerDeveloperResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportsPerDeveloperResults.Merge(m, src)
+}
+func (m *ImportsPerDeveloperResults) XXX_Size() int {
+	return xxx_messageInfo_ImportsPerDeveloperResults.Size(m)
+}
+func (m *ImportsPerDeveloperResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportsPerDeveloperResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportsPerDeveloperResults proto.InternalMessageInfo
+
+func (m *ImportsPerDeveloperResults) GetImports() []*ImportsPerDeveloper {
+	if m != nil {
+		return m.Imports
+	}
+	return nil
+}
+
+func (m *ImportsPerDeveloperResults) GetAuthorIndex() []string {
+	if m != nil {
+		return m.AuthorIndex
+	}
+	return nil
+}
+
+func (m *ImportsPerDeveloperResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type DevCadence struct {
+	// sorted commit timestamps, Unix seconds
+	Commits []int64 `protobuf:"varint,1,rep,packed,name=commits,proto3" json:"commits,omitempty"`
+	// mean time between the commits in hours
+	MeanGap float64 `protobuf:"fixed64,2,opt,name=mean_gap,json=meanGap,proto3" json:"mean_gap,omitempty"`
+	// median time between the commits in hours
+	MedianGap float64 `protobuf:"fixed64,3,opt,name=median_gap,json=medianGap,proto3" json:"median_gap,omitempty"`
+	// number of gaps in each class, see DevCadenceAnalysisResults.gap_bounds
+	GapHistogram []int64 `protobuf:"varint,4,rep,packed,name=gap_histogram,json=gapHistogram,proto3" json:"gap_histogram,omitempty"`
+	// the longest run of consecutive ticks with commits
+	LongestActiveStreak int32 `protobuf:"varint,5,opt,name=longest_active_streak,json=longestActiveStreak,proto3" json:"longest_active_streak,omitempty"`
+	// the longest run of consecutive ticks without commits between the first and the last commit
+	LongestInactiveStreak int32    `protobuf:"varint,6,opt,name=longest_inactive_streak,json=longestInactiveStreak,proto3" json:"longest_inactive_streak,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *DevCadence) Reset()         { *m = DevCadence{} }
+func (m *DevCadence) String() string { return proto.CompactTextString(m) }
+func (*DevCadence) ProtoMessage()    {}
+func (*DevCadence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{41}
+}
+func (m *DevCadence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DevCadence.Unmarshal(m, b)
+}
+func (m *DevCadence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DevCadence.Marshal(b, m, deterministic)
+}
+func (m *DevCadence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DevCadence.Merge(m, src)
+}
+func (m *DevCadence) XXX_Size() int {
+	return xxx_messageInfo_DevCadence.Size(m)
+}
+func (m *DevCadence) XXX_DiscardUnknown() {
+	xxx_messageInfo_DevCadence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DevCadence proto.InternalMessageInfo
+
+func (m *DevCadence) GetCommits() []int64 {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+func (m *DevCadence) GetMeanGap() float64 {
+	if m != nil {
+		return m.MeanGap
+	}
+	return 0
+}
+
+func (m *DevCadence) GetMedianGap() float64 {
+	if m != nil {
+		return m.MedianGap
+	}
+	return 0
+}
+
+func (m *DevCadence) GetGapHistogram() []int64 {
+	if m != nil {
+		return m.GapHistogram
+	}
+	return nil
+}
+
+func (m *DevCadence) GetLongestActiveStreak() int32 {
+	if m != nil {
+		return m.LongestActiveStreak
+	}
+	return 0
+}
+
+func (m *DevCadence) GetLongestInactiveStreak() int32 {
+	if m != nil {
+		return m.LongestInactiveStreak
+	}
+	return 0
+}
+
+type DevCadenceAnalysisResults struct {
+	// the keys are the indexes in dev_index
+	Developers map[int32]*DevCadence `protobuf:"bytes,1,rep,name=developers,proto3" json:"developers,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DevIndex   []string              `protobuf:"bytes,2,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// inclusive upper limits of the gap classes in hours, the last class is unbounded
+	GapBounds []int32 `protobuf:"varint,3,rep,packed,name=gap_bounds,json=gapBounds,proto3" json:"gap_bounds,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DevCadenceAnalysisResults) Reset()         { *m = DevCadenceAnalysisResults{} }
+func (m *DevCadenceAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*DevCadenceAnalysisResults) ProtoMessage()    {}
+func (*DevCadenceAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{42}
+}
+func (m *DevCadenceAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DevCadenceAnalysisResults.Unmarshal(m, b)
+}
+func (m *DevCadenceAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DevCadenceAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *DevCadenceAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DevCadenceAnalysisResults.Merge(m, src)
+}
+func (m *DevCadenceAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_DevCadenceAnalysisResults.Size(m)
+}
+func (m *DevCadenceAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_DevCadenceAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DevCadenceAnalysisResults proto.InternalMessageInfo
+
+func (m *DevCadenceAnalysisResults) GetDevelopers() map[int32]*DevCadence {
+	if m != nil {
+		return m.Developers
+	}
+	return nil
+}
+
+func (m *DevCadenceAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *DevCadenceAnalysisResults) GetGapBounds() []int32 {
+	if m != nil {
+		return m.GapBounds
+	}
+	return nil
+}
+
+func (m *DevCadenceAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type DevFocus struct {
+	// number of distinct edited files
+	Files int32 `protobuf:"varint,1,opt,name=files,proto3" json:"files,omitempty"`
+	// number of file edits, each commit edits a file at most once
+	Edits int64 `protobuf:"varint,2,opt,name=edits,proto3" json:"edits,omitempty"`
+	// Shannon entropy of the edits distribution across the files in bits
+	Entropy float64 `protobuf:"fixed64,3,opt,name=entropy,proto3" json:"entropy,omitempty"`
+	// Gini coefficient of the edits across the edited files
+	Gini float64 `protobuf:"fixed64,4,opt,name=gini,proto3" json:"gini,omitempty"`
+	// 2^-entropy: 1 means a single file, 1/N means N equally edited files
+	Focus                float64  `protobuf:"fixed64,5,opt,name=focus,proto3" json:"focus,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DevFocus) Reset()         { *m = DevFocus{} }
+func (m *DevFocus) String() string { return proto.CompactTextString(m) }
+func (*DevFocus) ProtoMessage()    {}
+func (*DevFocus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{43}
+}
+func (m *DevFocus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DevFocus.Unmarshal(m, b)
+}
+func (m *DevFocus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DevFocus.Marshal(b, m, deterministic)
+}
+func (m *DevFocus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DevFocus.Merge(m, src)
+}
+func (m *DevFocus) XXX_Size() int {
+	return xxx_messageInfo_DevFocus.Size(m)
+}
+func (m *DevFocus) XXX_DiscardUnknown() {
+	xxx_messageInfo_DevFocus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DevFocus proto.InternalMessageInfo
+
+func (m *DevFocus) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *DevFocus) GetEdits() int64 {
+	if m != nil {
+		return m.Edits
+	}
+	return 0
+}
+
+func (m *DevFocus) GetEntropy() float64 {
+	if m != nil {
+		return m.Entropy
+	}
+	return 0
+}
+
+func (m *DevFocus) GetGini() float64 {
+	if m != nil {
+		return m.Gini
+	}
+	return 0
+}
+
+func (m *DevFocus) GetFocus() float64 {
+	if m != nil {
+		return m.Focus
+	}
+	return 0
+}
+
+type DevFocusTimeline struct {
+	// over the whole analysed range
+	Total *DevFocus `protobuf:"bytes,1,opt,name=total,proto3" json:"total,omitempty"`
+	// the keys are the ticks with the developer's edits
+	Ticks                map[int32]*DevFocus `protobuf:"bytes,2,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *DevFocusTimeline) Reset()         { *m = DevFocusTimeline{} }
+func (m *DevFocusTimeline) String() string { return proto.CompactTextString(m) }
+func (*DevFocusTimeline) ProtoMessage()    {}
+func (*DevFocusTimeline) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{44}
+}
+func (m *DevFocusTimeline) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DevFocusTimeline.Unmarshal(m, b)
+}
+func (m *DevFocusTimeline) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DevFocusTimeline.Marshal(b, m, deterministic)
+}
+func (m *DevFocusTimeline) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DevFocusTimeline.Merge(m, src)
+}
+func (m *DevFocusTimeline) XXX_Size() int {
+	return xxx_messageInfo_DevFocusTimeline.Size(m)
+}
+func (m *DevFocusTimeline) XXX_DiscardUnknown() {
+	xxx_messageInfo_DevFocusTimeline.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DevFocusTimeline proto.InternalMessageInfo
+
+func (m *DevFocusTimeline) GetTotal() *DevFocus {
+	if m != nil {
+		return m.Total
+	}
+	return nil
 }
 
-var xxx_messageInfo_TickDevs proto.InternalMessageInfo
+func (m *DevFocusTimeline) GetTicks() map[int32]*DevFocus {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+type DevFocusAnalysisResults struct {
+	// the keys are the indexes in dev_index
+	Developers map[int32]*DevFocusTimeline `protobuf:"bytes,1,rep,name=developers,proto3" json:"developers,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DevIndex   []string                    `protobuf:"bytes,2,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,3,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DevFocusAnalysisResults) Reset()         { *m = DevFocusAnalysisResults{} }
+func (m *DevFocusAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*DevFocusAnalysisResults) ProtoMessage()    {}
+func (*DevFocusAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{45}
+}
+func (m *DevFocusAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DevFocusAnalysisResults.Unmarshal(m, b)
+}
+func (m *DevFocusAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DevFocusAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *DevFocusAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DevFocusAnalysisResults.Merge(m, src)
+}
+func (m *DevFocusAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_DevFocusAnalysisResults.Size(m)
+}
+func (m *DevFocusAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_DevFocusAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DevFocusAnalysisResults proto.InternalMessageInfo
+
+func (m *DevFocusAnalysisResults) GetDevelopers() map[int32]*DevFocusTimeline {
+	if m != nil {
+		return m.Developers
+	}
+	return nil
+}
+
+func (m *DevFocusAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *DevFocusAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type DevMergeCounts struct {
+	// number of merge commits
+	Merges int32 `protobuf:"varint,1,opt,name=merges,proto3" json:"merges,omitempty"`
+	// number of regular commits
+	Regular              int32    `protobuf:"varint,2,opt,name=regular,proto3" json:"regular,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DevMergeCounts) Reset()         { *m = DevMergeCounts{} }
+func (m *DevMergeCounts) String() string { return proto.CompactTextString(m) }
+func (*DevMergeCounts) ProtoMessage()    {}
+func (*DevMergeCounts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{46}
+}
+func (m *DevMergeCounts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DevMergeCounts.Unmarshal(m, b)
+}
+func (m *DevMergeCounts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DevMergeCounts.Marshal(b, m, deterministic)
+}
+func (m *DevMergeCounts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DevMergeCounts.Merge(m, src)
+}
+func (m *DevMergeCounts) XXX_Size() int {
+	return xxx_messageInfo_DevMergeCounts.Size(m)
+}
+func (m *DevMergeCounts) XXX_DiscardUnknown() {
+	xxx_messageInfo_DevMergeCounts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DevMergeCounts proto.InternalMessageInfo
+
+func (m *DevMergeCounts) GetMerges() int32 {
+	if m != nil {
+		return m.Merges
+	}
+	return 0
+}
+
+func (m *DevMergeCounts) GetRegular() int32 {
+	if m != nil {
+		return m.Regular
+	}
+	return 0
+}
+
+type DevMergeRatioTicks struct {
+	// the keys are the ticks with the developer's commits
+	Ticks                map[int32]*DevMergeCounts `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *DevMergeRatioTicks) Reset()         { *m = DevMergeRatioTicks{} }
+func (m *DevMergeRatioTicks) String() string { return proto.CompactTextString(m) }
+func (*DevMergeRatioTicks) ProtoMessage()    {}
+func (*DevMergeRatioTicks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{47}
+}
+func (m *DevMergeRatioTicks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DevMergeRatioTicks.Unmarshal(m, b)
+}
+func (m *DevMergeRatioTicks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DevMergeRatioTicks.Marshal(b, m, deterministic)
+}
+func (m *DevMergeRatioTicks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DevMergeRatioTicks.Merge(m, src)
+}
+func (m *DevMergeRatioTicks) XXX_Size() int {
+	return xxx_messageInfo_DevMergeRatioTicks.Size(m)
+}
+func (m *DevMergeRatioTicks) XXX_DiscardUnknown() {
+	xxx_messageInfo_DevMergeRatioTicks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DevMergeRatioTicks proto.InternalMessageInfo
+
+func (m *DevMergeRatioTicks) GetTicks() map[int32]*DevMergeCounts {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+type DevMergeRatioAnalysisResults struct {
+	// the keys are the indexes in dev_index
+	Developers map[int32]*DevMergeRatioTicks `protobuf:"bytes,1,rep,name=developers,proto3" json:"developers,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DevIndex   []string                      `protobuf:"bytes,2,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,3,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DevMergeRatioAnalysisResults) Reset()         { *m = DevMergeRatioAnalysisResults{} }
+func (m *DevMergeRatioAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*DevMergeRatioAnalysisResults) ProtoMessage()    {}
+func (*DevMergeRatioAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{48}
+}
+func (m *DevMergeRatioAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DevMergeRatioAnalysisResults.Unmarshal(m, b)
+}
+func (m *DevMergeRatioAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DevMergeRatioAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *DevMergeRatioAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DevMergeRatioAnalysisResults.Merge(m, src)
+}
+func (m *DevMergeRatioAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_DevMergeRatioAnalysisResults.Size(m)
+}
+func (m *DevMergeRatioAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_DevMergeRatioAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DevMergeRatioAnalysisResults proto.InternalMessageInfo
+
+func (m *DevMergeRatioAnalysisResults) GetDevelopers() map[int32]*DevMergeRatioTicks {
+	if m != nil {
+		return m.Developers
+	}
+	return nil
+}
+
+func (m *DevMergeRatioAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *DevMergeRatioAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type Punchcard struct {
+	// 7 rows by 24 columns, row-major: the days of the week starting from Sunday by the hours
+	Counts               []int64  `protobuf:"varint,1,rep,packed,name=counts,proto3" json:"counts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Punchcard) Reset()         { *m = Punchcard{} }
+func (m *Punchcard) String() string { return proto.CompactTextString(m) }
+func (*Punchcard) ProtoMessage()    {}
+func (*Punchcard) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{49}
+}
+func (m *Punchcard) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Punchcard.Unmarshal(m, b)
+}
+func (m *Punchcard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Punchcard.Marshal(b, m, deterministic)
+}
+func (m *Punchcard) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Punchcard.Merge(m, src)
+}
+func (m *Punchcard) XXX_Size() int {
+	return xxx_messageInfo_Punchcard.Size(m)
+}
+func (m *Punchcard) XXX_DiscardUnknown() {
+	xxx_messageInfo_Punchcard.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Punchcard proto.InternalMessageInfo
+
+func (m *Punchcard) GetCounts() []int64 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+type PunchcardAnalysisResults struct {
+	// commits of all the developers
+	Total *Punchcard `protobuf:"bytes,1,opt,name=total,proto3" json:"total,omitempty"`
+	// the keys are the indexes in dev_index, empty unless the developers are split
+	Developers map[int32]*Punchcard `protobuf:"bytes,2,rep,name=developers,proto3" json:"developers,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DevIndex   []string             `protobuf:"bytes,3,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// IANA time zone name of the hours, empty means the committers' own time zones
+	Timezone             string   `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PunchcardAnalysisResults) Reset()         { *m = PunchcardAnalysisResults{} }
+func (m *PunchcardAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*PunchcardAnalysisResults) ProtoMessage()    {}
+func (*PunchcardAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{50}
+}
+func (m *PunchcardAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PunchcardAnalysisResults.Unmarshal(m, b)
+}
+func (m *PunchcardAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PunchcardAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *PunchcardAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PunchcardAnalysisResults.Merge(m, src)
+}
+func (m *PunchcardAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_PunchcardAnalysisResults.Size(m)
+}
+func (m *PunchcardAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_PunchcardAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PunchcardAnalysisResults proto.InternalMessageInfo
+
+func (m *PunchcardAnalysisResults) GetTotal() *Punchcard {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+func (m *PunchcardAnalysisResults) GetDevelopers() map[int32]*Punchcard {
+	if m != nil {
+		return m.Developers
+	}
+	return nil
+}
+
+func (m *PunchcardAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *PunchcardAnalysisResults) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+type DirectoryOwnership struct {
+	// the keys are the indexes in dev_index, -1 stands for the unidentified developers
+	Lines                map[int32]int64 `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *DirectoryOwnership) Reset()         { *m = DirectoryOwnership{} }
+func (m *DirectoryOwnership) String() string { return proto.CompactTextString(m) }
+func (*DirectoryOwnership) ProtoMessage()    {}
+func (*DirectoryOwnership) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{51}
+}
+func (m *DirectoryOwnership) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DirectoryOwnership.Unmarshal(m, b)
+}
+func (m *DirectoryOwnership) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DirectoryOwnership.Marshal(b, m, deterministic)
+}
+func (m *DirectoryOwnership) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DirectoryOwnership.Merge(m, src)
+}
+func (m *DirectoryOwnership) XXX_Size() int {
+	return xxx_messageInfo_DirectoryOwnership.Size(m)
+}
+func (m *DirectoryOwnership) XXX_DiscardUnknown() {
+	xxx_messageInfo_DirectoryOwnership.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DirectoryOwnership proto.InternalMessageInfo
+
+func (m *DirectoryOwnership) GetLines() map[int32]int64 {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+type TickDirectoryOwnership struct {
+	// the keys are the directory prefixes ending with "/", "" stands for the root
+	Directories          map[string]*DirectoryOwnership `protobuf:"bytes,1,rep,name=directories,proto3" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *TickDirectoryOwnership) Reset()         { *m = TickDirectoryOwnership{} }
+func (m *TickDirectoryOwnership) String() string { return proto.CompactTextString(m) }
+func (*TickDirectoryOwnership) ProtoMessage()    {}
+func (*TickDirectoryOwnership) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{52}
+}
+func (m *TickDirectoryOwnership) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TickDirectoryOwnership.Unmarshal(m, b)
+}
+func (m *TickDirectoryOwnership) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TickDirectoryOwnership.Marshal(b, m, deterministic)
+}
+func (m *TickDirectoryOwnership) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TickDirectoryOwnership.Merge(m, src)
+}
+func (m *TickDirectoryOwnership) XXX_Size() int {
+	return xxx_messageInfo_TickDirectoryOwnership.Size(m)
+}
+func (m *TickDirectoryOwnership) XXX_DiscardUnknown() {
+	xxx_messageInfo_TickDirectoryOwnership.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TickDirectoryOwnership proto.InternalMessageInfo
+
+func (m *TickDirectoryOwnership) GetDirectories() map[string]*DirectoryOwnership {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+type DirectoryOwnershipAnalysisResults struct {
+	// the ticks without commits are omitted, they repeat the previous tick
+	Ticks    map[int32]*TickDirectoryOwnership `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DevIndex []string                          `protobuf:"bytes,2,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// how many leading path components make the directory prefix
+	Depth int32 `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DirectoryOwnershipAnalysisResults) Reset()         { *m = DirectoryOwnershipAnalysisResults{} }
+func (m *DirectoryOwnershipAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*DirectoryOwnershipAnalysisResults) ProtoMessage()    {}
+func (*DirectoryOwnershipAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{53}
+}
+func (m *DirectoryOwnershipAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DirectoryOwnershipAnalysisResults.Unmarshal(m, b)
+}
+func (m *DirectoryOwnershipAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DirectoryOwnershipAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *DirectoryOwnershipAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DirectoryOwnershipAnalysisResults.Merge(m, src)
+}
+func (m *DirectoryOwnershipAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_DirectoryOwnershipAnalysisResults.Size(m)
+}
+func (m *DirectoryOwnershipAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_DirectoryOwnershipAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DirectoryOwnershipAnalysisResults proto.InternalMessageInfo
+
+func (m *DirectoryOwnershipAnalysisResults) GetTicks() map[int32]*TickDirectoryOwnership {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *DirectoryOwnershipAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *DirectoryOwnershipAnalysisResults) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *DirectoryOwnershipAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type CodeAgeAnalysisResults struct {
+	// the keys are the line ages in ticks, the values are the numbers of lines alive at HEAD
+	Ages map[int32]int64 `protobuf:"bytes,1,rep,name=ages,proto3" json:"ages,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// the median line age in ticks of each file alive at HEAD
+	FileMedians map[string]float64 `protobuf:"bytes,2,rep,name=file_medians,json=fileMedians,proto3" json:"file_medians,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,3,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CodeAgeAnalysisResults) Reset()         { *m = CodeAgeAnalysisResults{} }
+func (m *CodeAgeAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*CodeAgeAnalysisResults) ProtoMessage()    {}
+func (*CodeAgeAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{54}
+}
+func (m *CodeAgeAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CodeAgeAnalysisResults.Unmarshal(m, b)
+}
+func (m *CodeAgeAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CodeAgeAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *CodeAgeAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeAgeAnalysisResults.Merge(m, src)
+}
+func (m *CodeAgeAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_CodeAgeAnalysisResults.Size(m)
+}
+func (m *CodeAgeAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeAgeAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeAgeAnalysisResults proto.InternalMessageInfo
+
+func (m *CodeAgeAnalysisResults) GetAges() map[int32]int64 {
+	if m != nil {
+		return m.Ages
+	}
+	return nil
+}
 
-func (m *TickDevs) GetDevs() map[int32]*DevTick {
+func (m *CodeAgeAnalysisResults) GetFileMedians() map[string]float64 {
 	if m != nil {
-		return m.Devs
+		return m.FileMedians
 	}
 	return nil
 }
 
-type DevsAnalysisResults struct {
-	Ticks map[int32]*TickDevs `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// developer identities, the indexes correspond to TickDevs' keys.
-	DevIndex []string `protobuf:"bytes,2,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+func (m *CodeAgeAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type TestRatioAnalysisResults struct {
+	// number of alive lines in the test files at the end of each tick
+	TestLines []int64 `protobuf:"varint,1,rep,packed,name=test_lines,json=testLines,proto3" json:"test_lines,omitempty"`
+	// number of alive lines in the rest of the files at the end of each tick
+	ProductionLines []int64 `protobuf:"varint,2,rep,packed,name=production_lines,json=productionLines,proto3" json:"production_lines,omitempty"`
+	// test_lines divided by production_lines in each tick, 0 if there are no production lines
+	Ratios []float64 `protobuf:"fixed64,3,rep,packed,name=ratios,proto3" json:"ratios,omitempty"`
 	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
-	TickSize             int64    `protobuf:"varint,8,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	TickSize             int64    `protobuf:"varint,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DevsAnalysisResults) Reset()         { *m = DevsAnalysisResults{} }
-func (m *DevsAnalysisResults) String() string { return proto.CompactTextString(m) }
-func (*DevsAnalysisResults) ProtoMessage()    {}
-func (*DevsAnalysisResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{18}
+func (m *TestRatioAnalysisResults) Reset()         { *m = TestRatioAnalysisResults{} }
+func (m *TestRatioAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*TestRatioAnalysisResults) ProtoMessage()    {}
+func (*TestRatioAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{55}
 }
-func (m *DevsAnalysisResults) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DevsAnalysisResults.Unmarshal(m, b)
+func (m *TestRatioAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestRatioAnalysisResults.Unmarshal(m, b)
 }
-func (m *DevsAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DevsAnalysisResults.Marshal(b, m, deterministic)
+func (m *TestRatioAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestRatioAnalysisResults.Marshal(b, m, deterministic)
 }
-func (m *DevsAnalysisResults) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DevsAnalysisResults.Merge(m, src)
+func (m *TestRatioAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestRatioAnalysisResults.Merge(m, src)
 }
-func (m *DevsAnalysisResults) XXX_Size() int {
-	return xxx_messageInfo_DevsAnalysisResults.Size(m)
+func (m *TestRatioAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_TestRatioAnalysisResults.Size(m)
 }
-func (m *DevsAnalysisResults) XXX_DiscardUnknown() {
-	xxx_messageInfo_DevsAnalysisResults.DiscardUnknown(m)
+func (m *TestRatioAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestRatioAnalysisResults.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DevsAnalysisResults proto.InternalMessageInfo
+var xxx_messageInfo_TestRatioAnalysisResults proto.InternalMessageInfo
 
-func (m *DevsAnalysisResults) GetTicks() map[int32]*TickDevs {
+func (m *TestRatioAnalysisResults) GetTestLines() []int64 {
 	if m != nil {
-		return m.Ticks
+		return m.TestLines
 	}
 	return nil
 }
 
-func (m *DevsAnalysisResults) GetDevIndex() []string {
+func (m *TestRatioAnalysisResults) GetProductionLines() []int64 {
 	if m != nil {
-		return m.DevIndex
+		return m.ProductionLines
 	}
 	return nil
 }
 
-func (m *DevsAnalysisResults) GetTickSize() int64 {
+func (m *TestRatioAnalysisResults) GetRatios() []float64 {
+	if m != nil {
+		return m.Ratios
+	}
+	return nil
+}
+
+func (m *TestRatioAnalysisResults) GetTickSize() int64 {
 	if m != nil {
 		return m.TickSize
 	}
 	return 0
 }
 
-type Sentiment struct {
-	Value                float32  `protobuf:"fixed32,1,opt,name=value,proto3" json:"value,omitempty"`
-	Comments             []string `protobuf:"bytes,2,rep,name=comments,proto3" json:"comments,omitempty"`
-	Commits              []string `protobuf:"bytes,3,rep,name=commits,proto3" json:"commits,omitempty"`
+type CommentRatioSeries struct {
+	// number of alive comment lines at the end of each tick
+	Comment []int64 `protobuf:"varint,1,rep,packed,name=comment,proto3" json:"comment,omitempty"`
+	// number of alive code lines at the end of each tick
+	Code []int64 `protobuf:"varint,2,rep,packed,name=code,proto3" json:"code,omitempty"`
+	// number of alive blank lines at the end of each tick
+	Blank []int64 `protobuf:"varint,3,rep,packed,name=blank,proto3" json:"blank,omitempty"`
+	// comment divided by code in each tick, 0 if there is no code
+	Ratios               []float64 `protobuf:"fixed64,4,rep,packed,name=ratios,proto3" json:"ratios,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CommentRatioSeries) Reset()         { *m = CommentRatioSeries{} }
+func (m *CommentRatioSeries) String() string { return proto.CompactTextString(m) }
+func (*CommentRatioSeries) ProtoMessage()    {}
+func (*CommentRatioSeries) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{56}
+}
+func (m *CommentRatioSeries) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommentRatioSeries.Unmarshal(m, b)
+}
+func (m *CommentRatioSeries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommentRatioSeries.Marshal(b, m, deterministic)
+}
+func (m *CommentRatioSeries) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommentRatioSeries.Merge(m, src)
+}
+func (m *CommentRatioSeries) XXX_Size() int {
+	return xxx_messageInfo_CommentRatioSeries.Size(m)
+}
+func (m *CommentRatioSeries) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommentRatioSeries.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommentRatioSeries proto.InternalMessageInfo
+
+func (m *CommentRatioSeries) GetComment() []int64 {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+func (m *CommentRatioSeries) GetCode() []int64 {
+	if m != nil {
+		return m.Code
+	}
+	return nil
+}
+
+func (m *CommentRatioSeries) GetBlank() []int64 {
+	if m != nil {
+		return m.Blank
+	}
+	return nil
+}
+
+func (m *CommentRatioSeries) GetRatios() []float64 {
+	if m != nil {
+		return m.Ratios
+	}
+	return nil
+}
+
+type CommentRatioAnalysisResults struct {
+	// language -> line counts through time
+	Languages map[string]*CommentRatioSeries `protobuf:"bytes,1,rep,name=languages,proto3" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,2,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Sentiment) Reset()         { *m = Sentiment{} }
-func (m *Sentiment) String() string { return proto.CompactTextString(m) }
-func (*Sentiment) ProtoMessage()    {}
-func (*Sentiment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{19}
+func (m *CommentRatioAnalysisResults) Reset()         { *m = CommentRatioAnalysisResults{} }
+func (m *CommentRatioAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*CommentRatioAnalysisResults) ProtoMessage()    {}
+func (*CommentRatioAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{57}
 }
-func (m *Sentiment) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Sentiment.Unmarshal(m, b)
+func (m *CommentRatioAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommentRatioAnalysisResults.Unmarshal(m, b)
 }
-func (m *Sentiment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Sentiment.Marshal(b, m, deterministic)
+func (m *CommentRatioAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommentRatioAnalysisResults.Marshal(b, m, deterministic)
 }
-func (m *Sentiment) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Sentiment.Merge(m, src)
+func (m *CommentRatioAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommentRatioAnalysisResults.Merge(m, src)
 }
-func (m *Sentiment) XXX_Size() int {
-	return xxx_messageInfo_Sentiment.Size(m)
+func (m *CommentRatioAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_CommentRatioAnalysisResults.Size(m)
 }
-func (m *Sentiment) XXX_DiscardUnknown() {
-	xxx_messageInfo_Sentiment.DiscardUnknown(m)
+func (m *CommentRatioAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommentRatioAnalysisResults.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Sentiment proto.InternalMessageInfo
+var xxx_messageInfo_CommentRatioAnalysisResults proto.InternalMessageInfo
 
-func (m *Sentiment) GetValue() float32 {
+func (m *CommentRatioAnalysisResults) GetLanguages() map[string]*CommentRatioSeries {
 	if m != nil {
-		return m.Value
+		return m.Languages
+	}
+	return nil
+}
+
+func (m *CommentRatioAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
 	}
 	return 0
 }
 
-func (m *Sentiment) GetComments() []string {
+type LeadTimeHistogram struct {
+	// the keys are the delays in ticks between the commits and their releases, the values are the numbers of commits
+	Delays map[int32]int64 `protobuf:"bytes,1,rep,name=delays,proto3" json:"delays,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// number of commits which are not contained in any tag
+	Untagged             int64    `protobuf:"varint,2,opt,name=untagged,proto3" json:"untagged,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeadTimeHistogram) Reset()         { *m = LeadTimeHistogram{} }
+func (m *LeadTimeHistogram) String() string { return proto.CompactTextString(m) }
+func (*LeadTimeHistogram) ProtoMessage()    {}
+func (*LeadTimeHistogram) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{58}
+}
+func (m *LeadTimeHistogram) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeadTimeHistogram.Unmarshal(m, b)
+}
+func (m *LeadTimeHistogram) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeadTimeHistogram.Marshal(b, m, deterministic)
+}
+func (m *LeadTimeHistogram) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeadTimeHistogram.Merge(m, src)
+}
+func (m *LeadTimeHistogram) XXX_Size() int {
+	return xxx_messageInfo_LeadTimeHistogram.Size(m)
+}
+func (m *LeadTimeHistogram) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeadTimeHistogram.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeadTimeHistogram proto.InternalMessageInfo
+
+func (m *LeadTimeHistogram) GetDelays() map[int32]int64 {
 	if m != nil {
-		return m.Comments
+		return m.Delays
 	}
 	return nil
 }
 
-func (m *Sentiment) GetCommits() []string {
+func (m *LeadTimeHistogram) GetUntagged() int64 {
 	if m != nil {
-		return m.Commits
+		return m.Untagged
 	}
-	return nil
+	return 0
 }
 
-type CommentSentimentResults struct {
-	SentimentByTick      map[int32]*Sentiment `protobuf:"bytes,1,rep,name=sentiment_by_tick,json=sentimentByTick,proto3" json:"sentiment_by_tick,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+type LeadTimeAnalysisResults struct {
+	// histogram of the commits in each tick
+	Ticks []*LeadTimeHistogram `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,2,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CommentSentimentResults) Reset()         { *m = CommentSentimentResults{} }
-func (m *CommentSentimentResults) String() string { return proto.CompactTextString(m) }
-func (*CommentSentimentResults) ProtoMessage()    {}
-func (*CommentSentimentResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{20}
+func (m *LeadTimeAnalysisResults) Reset()         { *m = LeadTimeAnalysisResults{} }
+func (m *LeadTimeAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*LeadTimeAnalysisResults) ProtoMessage()    {}
+func (*LeadTimeAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{59}
 }
-func (m *CommentSentimentResults) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CommentSentimentResults.Unmarshal(m, b)
+func (m *LeadTimeAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeadTimeAnalysisResults.Unmarshal(m, b)
 }
-func (m *CommentSentimentResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CommentSentimentResults.Marshal(b, m, deterministic)
+func (m *LeadTimeAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeadTimeAnalysisResults.Marshal(b, m, deterministic)
 }
-func (m *CommentSentimentResults) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommentSentimentResults.Merge(m, src)
+func (m *LeadTimeAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeadTimeAnalysisResults.Merge(m, src)
 }
-func (m *CommentSentimentResults) XXX_Size() int {
-	return xxx_messageInfo_CommentSentimentResults.Size(m)
+func (m *LeadTimeAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_LeadTimeAnalysisResults.Size(m)
 }
-func (m *CommentSentimentResults) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommentSentimentResults.DiscardUnknown(m)
+func (m *LeadTimeAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeadTimeAnalysisResults.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CommentSentimentResults proto.InternalMessageInfo
+var xxx_messageInfo_LeadTimeAnalysisResults proto.InternalMessageInfo
 
-func (m *CommentSentimentResults) GetSentimentByTick() map[int32]*Sentiment {
+func (m *LeadTimeAnalysisResults) GetTicks() []*LeadTimeHistogram {
 	if m != nil {
-		return m.SentimentByTick
+		return m.Ticks
 	}
 	return nil
 }
 
-type CommitFile struct {
-	Name                 string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Language             string     `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
-	Stats                *LineStats `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *LeadTimeAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
 }
 
-func (m *CommitFile) Reset()         { *m = CommitFile{} }
-func (m *CommitFile) String() string { return proto.CompactTextString(m) }
-func (*CommitFile) ProtoMessage()    {}
-func (*CommitFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{21}
+type Resurrection struct {
+	Path         string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	DeletionTick int32  `protobuf:"varint,2,opt,name=deletion_tick,json=deletionTick,proto3" json:"deletion_tick,omitempty"`
+	// index in ResurrectionAnalysisResults.author_index, -1 if the author is unknown
+	DeletionAuthor   int32 `protobuf:"varint,3,opt,name=deletion_author,json=deletionAuthor,proto3" json:"deletion_author,omitempty"`
+	ResurrectionTick int32 `protobuf:"varint,4,opt,name=resurrection_tick,json=resurrectionTick,proto3" json:"resurrection_tick,omitempty"`
+	// index in ResurrectionAnalysisResults.author_index, -1 if the author is unknown
+	ResurrectionAuthor int32 `protobuf:"varint,5,opt,name=resurrection_author,json=resurrectionAuthor,proto3" json:"resurrection_author,omitempty"`
+	// true if the file was renamed away and later renamed back, false if it was deleted and added again
+	RenameBack           bool     `protobuf:"varint,6,opt,name=rename_back,json=renameBack,proto3" json:"rename_back,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *CommitFile) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CommitFile.Unmarshal(m, b)
+
+func (m *Resurrection) Reset()         { *m = Resurrection{} }
+func (m *Resurrection) String() string { return proto.CompactTextString(m) }
+func (*Resurrection) ProtoMessage()    {}
+func (*Resurrection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{60}
 }
-func (m *CommitFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CommitFile.Marshal(b, m, deterministic)
+func (m *Resurrection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Resurrection.Unmarshal(m, b)
 }
-func (m *CommitFile) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommitFile.Merge(m, src)
+func (m *Resurrection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Resurrection.Marshal(b, m, deterministic)
 }
-func (m *CommitFile) XXX_Size() int {
-	return xxx_messageInfo_CommitFile.Size(m)
+func (m *Resurrection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Resurrection.Merge(m, src)
 }
-func (m *CommitFile) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommitFile.DiscardUnknown(m)
+func (m *Resurrection) XXX_Size() int {
+	return xxx_messageInfo_Resurrection.Size(m)
+}
+func (m *Resurrection) XXX_DiscardUnknown() {
+	xxx_messageInfo_Resurrection.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CommitFile proto.InternalMessageInfo
+var xxx_messageInfo_Resurrection proto.InternalMessageInfo
 
-func (m *CommitFile) GetName() string {
+func (m *Resurrection) GetPath() string {
 	if m != nil {
-		return m.Name
+		return m.Path
 	}
 	return ""
 }
 
-func (m *CommitFile) GetLanguage() string {
+func (m *Resurrection) GetDeletionTick() int32 {
 	if m != nil {
-		return m.Language
+		return m.DeletionTick
 	}
-	return ""
+	return 0
 }
 
-func (m *CommitFile) GetStats() *LineStats {
+func (m *Resurrection) GetDeletionAuthor() int32 {
 	if m != nil {
-		return m.Stats
+		return m.DeletionAuthor
+	}
+	return 0
+}
+
+func (m *Resurrection) GetResurrectionTick() int32 {
+	if m != nil {
+		return m.ResurrectionTick
+	}
+	return 0
+}
+
+func (m *Resurrection) GetResurrectionAuthor() int32 {
+	if m != nil {
+		return m.ResurrectionAuthor
+	}
+	return 0
+}
+
+func (m *Resurrection) GetRenameBack() bool {
+	if m != nil {
+		return m.RenameBack
+	}
+	return false
+}
+
+type ResurrectionAnalysisResults struct {
+	Resurrections []*Resurrection `protobuf:"bytes,1,rep,name=resurrections,proto3" json:"resurrections,omitempty"`
+	AuthorIndex   []string        `protobuf:"bytes,2,rep,name=author_index,json=authorIndex,proto3" json:"author_index,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,3,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResurrectionAnalysisResults) Reset()         { *m = ResurrectionAnalysisResults{} }
+func (m *ResurrectionAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*ResurrectionAnalysisResults) ProtoMessage()    {}
+func (*ResurrectionAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{61}
+}
+func (m *ResurrectionAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResurrectionAnalysisResults.Unmarshal(m, b)
+}
+func (m *ResurrectionAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResurrectionAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *ResurrectionAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResurrectionAnalysisResults.Merge(m, src)
+}
+func (m *ResurrectionAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_ResurrectionAnalysisResults.Size(m)
+}
+func (m *ResurrectionAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResurrectionAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResurrectionAnalysisResults proto.InternalMessageInfo
+
+func (m *ResurrectionAnalysisResults) GetResurrections() []*Resurrection {
+	if m != nil {
+		return m.Resurrections
 	}
 	return nil
 }
 
-type Commit struct {
-	Hash                 string        `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	WhenUnixTime         int64         `protobuf:"varint,2,opt,name=when_unix_time,json=whenUnixTime,proto3" json:"when_unix_time,omitempty"`
-	Author               int32         `protobuf:"varint,3,opt,name=author,proto3" json:"author,omitempty"`
-	Files                []*CommitFile `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+func (m *ResurrectionAnalysisResults) GetAuthorIndex() []string {
+	if m != nil {
+		return m.AuthorIndex
+	}
+	return nil
 }
 
-func (m *Commit) Reset()         { *m = Commit{} }
-func (m *Commit) String() string { return proto.CompactTextString(m) }
-func (*Commit) ProtoMessage()    {}
-func (*Commit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{22}
+func (m *ResurrectionAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
 }
-func (m *Commit) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Commit.Unmarshal(m, b)
+
+type OwnershipTransfer struct {
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Tick int32  `protobuf:"varint,2,opt,name=tick,proto3" json:"tick,omitempty"`
+	// index in OwnershipTransferAnalysisResults.author_index
+	FromAuthor int32 `protobuf:"varint,3,opt,name=from_author,json=fromAuthor,proto3" json:"from_author,omitempty"`
+	// index in OwnershipTransferAnalysisResults.author_index
+	ToAuthor             int32    `protobuf:"varint,4,opt,name=to_author,json=toAuthor,proto3" json:"to_author,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *Commit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Commit.Marshal(b, m, deterministic)
+
+func (m *OwnershipTransfer) Reset()         { *m = OwnershipTransfer{} }
+func (m *OwnershipTransfer) String() string { return proto.CompactTextString(m) }
+func (*OwnershipTransfer) ProtoMessage()    {}
+func (*OwnershipTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{62}
 }
-func (m *Commit) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Commit.Merge(m, src)
+func (m *OwnershipTransfer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OwnershipTransfer.Unmarshal(m, b)
+}
+func (m *OwnershipTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OwnershipTransfer.Marshal(b, m, deterministic)
 }
-func (m *Commit) XXX_Size() int {
-	return xxx_messageInfo_Commit.Size(m)
+func (m *OwnershipTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OwnershipTransfer.Merge(m, src)
 }
-func (m *Commit) XXX_DiscardUnknown() {
-	xxx_messageInfo_Commit.DiscardUnknown(m)
+func (m *OwnershipTransfer) XXX_Size() int {
+	return xxx_messageInfo_OwnershipTransfer.Size(m)
+}
+func (m *OwnershipTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_OwnershipTransfer.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Commit proto.InternalMessageInfo
+var xxx_messageInfo_OwnershipTransfer proto.InternalMessageInfo
 
-func (m *Commit) GetHash() string {
+func (m *OwnershipTransfer) GetPath() string {
 	if m != nil {
-		return m.Hash
+		return m.Path
 	}
 	return ""
 }
 
-func (m *Commit) GetWhenUnixTime() int64 {
+func (m *OwnershipTransfer) GetTick() int32 {
 	if m != nil {
-		return m.WhenUnixTime
+		return m.Tick
 	}
 	return 0
 }
 
-func (m *Commit) GetAuthor() int32 {
+func (m *OwnershipTransfer) GetFromAuthor() int32 {
 	if m != nil {
-		return m.Author
+		return m.FromAuthor
 	}
 	return 0
 }
 
-func (m *Commit) GetFiles() []*CommitFile {
+func (m *OwnershipTransfer) GetToAuthor() int32 {
 	if m != nil {
-		return m.Files
+		return m.ToAuthor
 	}
-	return nil
+	return 0
 }
 
-type CommitsAnalysisResults struct {
-	Commits              []*Commit `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
-	AuthorIndex          []string  `protobuf:"bytes,2,rep,name=author_index,json=authorIndex,proto3" json:"author_index,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type OwnershipTransferAnalysisResults struct {
+	// sorted by tick and then by path
+	Transfers   []*OwnershipTransfer `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	AuthorIndex []string             `protobuf:"bytes,2,rep,name=author_index,json=authorIndex,proto3" json:"author_index,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,3,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CommitsAnalysisResults) Reset()         { *m = CommitsAnalysisResults{} }
-func (m *CommitsAnalysisResults) String() string { return proto.CompactTextString(m) }
-func (*CommitsAnalysisResults) ProtoMessage()    {}
-func (*CommitsAnalysisResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{23}
+func (m *OwnershipTransferAnalysisResults) Reset()         { *m = OwnershipTransferAnalysisResults{} }
+func (m *OwnershipTransferAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*OwnershipTransferAnalysisResults) ProtoMessage()    {}
+func (*OwnershipTransferAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{63}
 }
-func (m *CommitsAnalysisResults) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CommitsAnalysisResults.Unmarshal(m, b)
+func (m *OwnershipTransferAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OwnershipTransferAnalysisResults.Unmarshal(m, b)
 }
-func (m *CommitsAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CommitsAnalysisResults.Marshal(b, m, deterministic)
+func (m *OwnershipTransferAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OwnershipTransferAnalysisResults.Marshal(b, m, deterministic)
 }
-func (m *CommitsAnalysisResults) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommitsAnalysisResults.Merge(m, src)
+func (m *OwnershipTransferAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OwnershipTransferAnalysisResults.Merge(m, src)
 }
-func (m *CommitsAnalysisResults) XXX_Size() int {
-	return xxx_messageInfo_CommitsAnalysisResults.Size(m)
+func (m *OwnershipTransferAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_OwnershipTransferAnalysisResults.Size(m)
 }
-func (m *CommitsAnalysisResults) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommitsAnalysisResults.DiscardUnknown(m)
+func (m *OwnershipTransferAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_OwnershipTransferAnalysisResults.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CommitsAnalysisResults proto.InternalMessageInfo
+var xxx_messageInfo_OwnershipTransferAnalysisResults proto.InternalMessageInfo
 
-func (m *CommitsAnalysisResults) GetCommits() []*Commit {
+func (m *OwnershipTransferAnalysisResults) GetTransfers() []*OwnershipTransfer {
 	if m != nil {
-		return m.Commits
+		return m.Transfers
 	}
 	return nil
 }
 
-func (m *CommitsAnalysisResults) GetAuthorIndex() []string {
+func (m *OwnershipTransferAnalysisResults) GetAuthorIndex() []string {
 	if m != nil {
 		return m.AuthorIndex
 	}
 	return nil
 }
 
-type Typo struct {
-	Wrong                string   `protobuf:"bytes,1,opt,name=wrong,proto3" json:"wrong,omitempty"`
-	Correct              string   `protobuf:"bytes,2,opt,name=correct,proto3" json:"correct,omitempty"`
-	Commit               string   `protobuf:"bytes,3,opt,name=commit,proto3" json:"commit,omitempty"`
-	File                 string   `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
-	Line                 int32    `protobuf:"varint,5,opt,name=line,proto3" json:"line,omitempty"`
+func (m *OwnershipTransferAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type RefactorEvent struct {
+	Commit string `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	Tick   int32  `protobuf:"varint,2,opt,name=tick,proto3" json:"tick,omitempty"`
+	// number of renamed or moved files
+	Renames int32 `protobuf:"varint,3,opt,name=renames,proto3" json:"renames,omitempty"`
+	// number of changed files including the renamed ones
+	Changes int32 `protobuf:"varint,4,opt,name=changes,proto3" json:"changes,omitempty"`
+	// index in RefactorDetectionAnalysisResults.author_index, -1 if the author is unknown
+	Author               int32    `protobuf:"varint,5,opt,name=author,proto3" json:"author,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Typo) Reset()         { *m = Typo{} }
-func (m *Typo) String() string { return proto.CompactTextString(m) }
-func (*Typo) ProtoMessage()    {}
-func (*Typo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{24}
+func (m *RefactorEvent) Reset()         { *m = RefactorEvent{} }
+func (m *RefactorEvent) String() string { return proto.CompactTextString(m) }
+func (*RefactorEvent) ProtoMessage()    {}
+func (*RefactorEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{64}
 }
-func (m *Typo) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Typo.Unmarshal(m, b)
+func (m *RefactorEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefactorEvent.Unmarshal(m, b)
 }
-func (m *Typo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Typo.Marshal(b, m, deterministic)
+func (m *RefactorEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefactorEvent.Marshal(b, m, deterministic)
 }
-func (m *Typo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Typo.Merge(m, src)
+func (m *RefactorEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefactorEvent.Merge(m, src)
 }
-func (m *Typo) XXX_Size() int {
-	return xxx_messageInfo_Typo.Size(m)
+func (m *RefactorEvent) XXX_Size() int {
+	return xxx_messageInfo_RefactorEvent.Size(m)
 }
-func (m *Typo) XXX_DiscardUnknown() {
-	xxx_messageInfo_Typo.DiscardUnknown(m)
+func (m *RefactorEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefactorEvent.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Typo proto.InternalMessageInfo
+var xxx_messageInfo_RefactorEvent proto.InternalMessageInfo
 
-func (m *Typo) GetWrong() string {
+func (m *RefactorEvent) GetCommit() string {
 	if m != nil {
-		return m.Wrong
+		return m.Commit
 	}
 	return ""
 }
 
-func (m *Typo) GetCorrect() string {
+func (m *RefactorEvent) GetTick() int32 {
 	if m != nil {
-		return m.Correct
+		return m.Tick
 	}
-	return ""
+	return 0
 }
 
-func (m *Typo) GetCommit() string {
+func (m *RefactorEvent) GetRenames() int32 {
 	if m != nil {
-		return m.Commit
+		return m.Renames
 	}
-	return ""
+	return 0
 }
 
-func (m *Typo) GetFile() string {
+func (m *RefactorEvent) GetChanges() int32 {
 	if m != nil {
-		return m.File
+		return m.Changes
 	}
-	return ""
+	return 0
 }
 
-func (m *Typo) GetLine() int32 {
+func (m *RefactorEvent) GetAuthor() int32 {
 	if m != nil {
-		return m.Line
+		return m.Author
 	}
 	return 0
 }
 
-type TyposDataset struct {
-	Typos                []*Typo  `protobuf:"bytes,1,rep,name=typos,proto3" json:"typos,omitempty"`
+type RefactorDetectionAnalysisResults struct {
+	// in the order of the analysed commits
+	Events      []*RefactorEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	AuthorIndex []string         `protobuf:"bytes,2,rep,name=author_index,json=authorIndex,proto3" json:"author_index,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize             int64    `protobuf:"varint,3,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TyposDataset) Reset()         { *m = TyposDataset{} }
-func (m *TyposDataset) String() string { return proto.CompactTextString(m) }
-func (*TyposDataset) ProtoMessage()    {}
-func (*TyposDataset) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{25}
+func (m *RefactorDetectionAnalysisResults) Reset()         { *m = RefactorDetectionAnalysisResults{} }
+func (m *RefactorDetectionAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*RefactorDetectionAnalysisResults) ProtoMessage()    {}
+func (*RefactorDetectionAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{65}
 }
-func (m *TyposDataset) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TyposDataset.Unmarshal(m, b)
+func (m *RefactorDetectionAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefactorDetectionAnalysisResults.Unmarshal(m, b)
 }
-func (m *TyposDataset) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TyposDataset.Marshal(b, m, deterministic)
+func (m *RefactorDetectionAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefactorDetectionAnalysisResults.Marshal(b, m, deterministic)
 }
-func (m *TyposDataset) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TyposDataset.Merge(m, src)
+func (m *RefactorDetectionAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefactorDetectionAnalysisResults.Merge(m, src)
 }
-func (m *TyposDataset) XXX_Size() int {
-	return xxx_messageInfo_TyposDataset.Size(m)
+func (m *RefactorDetectionAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_RefactorDetectionAnalysisResults.Size(m)
 }
-func (m *TyposDataset) XXX_DiscardUnknown() {
-	xxx_messageInfo_TyposDataset.DiscardUnknown(m)
+func (m *RefactorDetectionAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefactorDetectionAnalysisResults.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TyposDataset proto.InternalMessageInfo
+var xxx_messageInfo_RefactorDetectionAnalysisResults proto.InternalMessageInfo
 
-func (m *TyposDataset) GetTypos() []*Typo {
+func (m *RefactorDetectionAnalysisResults) GetEvents() []*RefactorEvent {
 	if m != nil {
-		return m.Typos
+		return m.Events
 	}
 	return nil
 }
 
-type ImportsPerTick struct {
-	Counts               map[int32]int64 `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+func (m *RefactorDetectionAnalysisResults) GetAuthorIndex() []string {
+	if m != nil {
+		return m.AuthorIndex
+	}
+	return nil
+}
+
+func (m *RefactorDetectionAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type ImportCouplingAnalysisResults struct {
+	// paths of the files in the last commit which are supported by the import parsers
+	Files []string `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	// order corresponds to `files`, the values are the indexes of the imported files in `files`
+	Imports              []*TouchedFiles `protobuf:"bytes,2,rep,name=imports,proto3" json:"imports,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *ImportsPerTick) Reset()         { *m = ImportsPerTick{} }
-func (m *ImportsPerTick) String() string { return proto.CompactTextString(m) }
-func (*ImportsPerTick) ProtoMessage()    {}
-func (*ImportsPerTick) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{26}
+func (m *ImportCouplingAnalysisResults) Reset()         { *m = ImportCouplingAnalysisResults{} }
+func (m *ImportCouplingAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*ImportCouplingAnalysisResults) ProtoMessage()    {}
+func (*ImportCouplingAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{66}
 }
-func (m *ImportsPerTick) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ImportsPerTick.Unmarshal(m, b)
+func (m *ImportCouplingAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportCouplingAnalysisResults.Unmarshal(m, b)
 }
-func (m *ImportsPerTick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ImportsPerTick.Marshal(b, m, deterministic)
+func (m *ImportCouplingAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportCouplingAnalysisResults.Marshal(b, m, deterministic)
 }
-func (m *ImportsPerTick) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ImportsPerTick.Merge(m, src)
+func (m *ImportCouplingAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportCouplingAnalysisResults.Merge(m, src)
 }
-func (m *ImportsPerTick) XXX_Size() int {
-	return xxx_messageInfo_ImportsPerTick.Size(m)
+func (m *ImportCouplingAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_ImportCouplingAnalysisResults.Size(m)
 }
-func (m *ImportsPerTick) XXX_DiscardUnknown() {
-	xxx_messageInfo_ImportsPerTick.DiscardUnknown(m)
+func (m *ImportCouplingAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportCouplingAnalysisResults.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ImportsPerTick proto.InternalMessageInfo
+var xxx_messageInfo_ImportCouplingAnalysisResults proto.InternalMessageInfo
 
-func (m *ImportsPerTick) GetCounts() map[int32]int64 {
+func (m *ImportCouplingAnalysisResults) GetFiles() []string {
 	if m != nil {
-		return m.Counts
+		return m.Files
 	}
 	return nil
 }
 
-type ImportsPerLanguage struct {
-	Ticks                map[string]*ImportsPerTick `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+func (m *ImportCouplingAnalysisResults) GetImports() []*TouchedFiles {
+	if m != nil {
+		return m.Imports
+	}
+	return nil
 }
 
-func (m *ImportsPerLanguage) Reset()         { *m = ImportsPerLanguage{} }
-func (m *ImportsPerLanguage) String() string { return proto.CompactTextString(m) }
-func (*ImportsPerLanguage) ProtoMessage()    {}
-func (*ImportsPerLanguage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{27}
+type LineEvent struct {
+	Tick int32 `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	// index in LineEventsAnalysisResults.author_index, -1 if the author is unknown
+	Author               int32    `protobuf:"varint,2,opt,name=author,proto3" json:"author,omitempty"`
+	File                 string   `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	Added                int32    `protobuf:"varint,4,opt,name=added,proto3" json:"added,omitempty"`
+	Removed              int32    `protobuf:"varint,5,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *ImportsPerLanguage) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ImportsPerLanguage.Unmarshal(m, b)
+
+func (m *LineEvent) Reset()         { *m = LineEvent{} }
+func (m *LineEvent) String() string { return proto.CompactTextString(m) }
+func (*LineEvent) ProtoMessage()    {}
+func (*LineEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{67}
 }
-func (m *ImportsPerLanguage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ImportsPerLanguage.Marshal(b, m, deterministic)
+func (m *LineEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LineEvent.Unmarshal(m, b)
 }
-func (m *ImportsPerLanguage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ImportsPerLanguage.Merge(m, src)
+func (m *LineEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LineEvent.Marshal(b, m, deterministic)
 }
-func (m *ImportsPerLanguage) XXX_Size() int {
-	return xxx_messageInfo_ImportsPerLanguage.Size(m)
+func (m *LineEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LineEvent.Merge(m, src)
 }
-func (m *ImportsPerLanguage) XXX_DiscardUnknown() {
-	xxx_messageInfo_ImportsPerLanguage.DiscardUnknown(m)
+func (m *LineEvent) XXX_Size() int {
+	return xxx_messageInfo_LineEvent.Size(m)
+}
+func (m *LineEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LineEvent.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ImportsPerLanguage proto.InternalMessageInfo
+var xxx_messageInfo_LineEvent proto.InternalMessageInfo
 
-func (m *ImportsPerLanguage) GetTicks() map[string]*ImportsPerTick {
+func (m *LineEvent) GetTick() int32 {
 	if m != nil {
-		return m.Ticks
+		return m.Tick
 	}
-	return nil
+	return 0
 }
 
-type ImportsPerDeveloper struct {
-	Languages            map[string]*ImportsPerLanguage `protobuf:"bytes,1,rep,name=languages,proto3" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
-	XXX_unrecognized     []byte                         `json:"-"`
-	XXX_sizecache        int32                          `json:"-"`
+func (m *LineEvent) GetAuthor() int32 {
+	if m != nil {
+		return m.Author
+	}
+	return 0
 }
 
-func (m *ImportsPerDeveloper) Reset()         { *m = ImportsPerDeveloper{} }
-func (m *ImportsPerDeveloper) String() string { return proto.CompactTextString(m) }
-func (*ImportsPerDeveloper) ProtoMessage()    {}
-func (*ImportsPerDeveloper) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{28}
-}
-func (m *ImportsPerDeveloper) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ImportsPerDeveloper.Unmarshal(m, b)
-}
-func (m *ImportsPerDeveloper) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ImportsPerDeveloper.Marshal(b, m, deterministic)
-}
-func (m *ImportsPerDeveloper) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ImportsPerDeveloper.Merge(m, src)
-}
-func (m *ImportsPerDeveloper) XXX_Size() int {
-	return xxx_messageInfo_ImportsPerDeveloper.Size(m)
-}
-func (m *ImportsPerDeveloper) XXX_DiscardUnknown() {
-	xxx_messageInfo_ImportsPerDeveloper.DiscardUnknown(m)
+func (m *LineEvent) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
 }
 
-var xxx_messageInfo_ImportsPerDeveloper proto.InternalMessageInfo
+func (m *LineEvent) GetAdded() int32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
 
-func (m *ImportsPerDeveloper) GetLanguages() map[string]*ImportsPerLanguage {
+func (m *LineEvent) GetRemoved() int32 {
 	if m != nil {
-		return m.Languages
+		return m.Removed
 	}
-	return nil
+	return 0
 }
 
-type ImportsPerDeveloperResults struct {
-	Imports     []*ImportsPerDeveloper `protobuf:"bytes,1,rep,name=imports,proto3" json:"imports,omitempty"`
-	AuthorIndex []string               `protobuf:"bytes,2,rep,name=author_index,json=authorIndex,proto3" json:"author_index,omitempty"`
+// LineEventsAnalysis serializes this message length-delimited, followed by the length-delimited
+// LineEvent-s in the order of the analysed commits.
+type LineEventsAnalysisResults struct {
+	AuthorIndex []string `protobuf:"bytes,1,rep,name=author_index,json=authorIndex,proto3" json:"author_index,omitempty"`
 	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
-	TickSize             int64    `protobuf:"varint,3,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	TickSize             int64    `protobuf:"varint,2,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ImportsPerDeveloperResults) Reset()         { *m = ImportsPerDeveloperResults{} }
-func (m *ImportsPerDeveloperResults) String() string { return proto.CompactTextString(m) }
-func (*ImportsPerDeveloperResults) ProtoMessage()    {}
-func (*ImportsPerDeveloperResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{29}
+func (m *LineEventsAnalysisResults) Reset()         { *m = LineEventsAnalysisResults{} }
+func (m *LineEventsAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*LineEventsAnalysisResults) ProtoMessage()    {}
+func (*LineEventsAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{68}
 }
-func (m *ImportsPerDeveloperResults) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ImportsPerDeveloperResults.Unmarshal(m, b)
+func (m *LineEventsAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LineEventsAnalysisResults.Unmarshal(m, b)
 }
-func (m *ImportsPerDeveloperResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ImportsPerDeveloperResults.Marshal(b, m, deterministic)
+func (m *LineEventsAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LineEventsAnalysisResults.Marshal(b, m, deterministic)
 }
-func (m *ImportsPerDeveloperResults) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ImportsPerDeveloperResults.Merge(m, src)
+func (m *LineEventsAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LineEventsAnalysisResults.Merge(m, src)
 }
-func (m *ImportsPerDeveloperResults) XXX_Size() int {
-	return xxx_messageInfo_ImportsPerDeveloperResults.Size(m)
+func (m *LineEventsAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_LineEventsAnalysisResults.Size(m)
 }
-func (m *ImportsPerDeveloperResults) XXX_DiscardUnknown() {
-	xxx_messageInfo_ImportsPerDeveloperResults.DiscardUnknown(m)
+func (m *LineEventsAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_LineEventsAnalysisResults.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ImportsPerDeveloperResults proto.InternalMessageInfo
-
-func (m *ImportsPerDeveloperResults) GetImports() []*ImportsPerDeveloper {
-	if m != nil {
-		return m.Imports
-	}
-	return nil
-}
+var xxx_messageInfo_LineEventsAnalysisResults proto.InternalMessageInfo
 
-func (m *ImportsPerDeveloperResults) GetAuthorIndex() []string {
+func (m *LineEventsAnalysisResults) GetAuthorIndex() []string {
 	if m != nil {
 		return m.AuthorIndex
 	}
 	return nil
 }
 
-func (m *ImportsPerDeveloperResults) GetTickSize() int64 {
+func (m *LineEventsAnalysisResults) GetTickSize() int64 {
 	if m != nil {
 		return m.TickSize
 	}
@@ -1625,7 +3948,7 @@ func (m *AnalysisResults) Reset()         { *m = AnalysisResults{} }
 func (m *AnalysisResults) String() string { return proto.CompactTextString(m) }
 func (*AnalysisResults) ProtoMessage()    {}
 func (*AnalysisResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{30}
+	return fileDescriptor_f80abaa17e25ccc8, []int{69}
 }
 func (m *AnalysisResults) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalysisResults.Unmarshal(m, b)
@@ -1661,6 +3984,7 @@ func (m *AnalysisResults) GetContents() map[string][]byte {
 
 func init() {
 	proto.RegisterType((*Metadata)(nil), "Metadata")
+	proto.RegisterMapType((map[string]string)(nil), "Metadata.ConfigEntry")
 	proto.RegisterMapType((map[string]float64)(nil), "Metadata.RunTimePerItemEntry")
 	proto.RegisterType((*BurndownSparseMatrixRow)(nil), "BurndownSparseMatrixRow")
 	proto.RegisterType((*BurndownSparseMatrix)(nil), "BurndownSparseMatrix")
@@ -1668,9 +3992,11 @@ func init() {
 	proto.RegisterMapType((map[int32]int32)(nil), "FilesOwnership.ValueEntry")
 	proto.RegisterType((*BurndownAnalysisResults)(nil), "BurndownAnalysisResults")
 	proto.RegisterType((*CompressedSparseRowMatrix)(nil), "CompressedSparseRowMatrix")
+	proto.RegisterType((*CompressedSparseRowFloatMatrix)(nil), "CompressedSparseRowFloatMatrix")
 	proto.RegisterType((*Couples)(nil), "Couples")
 	proto.RegisterType((*TouchedFiles)(nil), "TouchedFiles")
 	proto.RegisterType((*CouplesAnalysisResults)(nil), "CouplesAnalysisResults")
+	proto.RegisterType((*FileEmbedding)(nil), "FileEmbedding")
 	proto.RegisterType((*UASTChange)(nil), "UASTChange")
 	proto.RegisterType((*UASTChangesSaverResults)(nil), "UASTChangesSaverResults")
 	proto.RegisterType((*ShotnessRecord)(nil), "ShotnessRecord")
@@ -1680,6 +4006,17 @@ func init() {
 	proto.RegisterMapType((map[int32]*LineStats)(nil), "FileHistory.ChangesByDeveloperEntry")
 	proto.RegisterType((*FileHistoryResultMessage)(nil), "FileHistoryResultMessage")
 	proto.RegisterMapType((map[string]*FileHistory)(nil), "FileHistoryResultMessage.FilesEntry")
+	proto.RegisterType((*BinaryChurnAnalysisResults)(nil), "BinaryChurnAnalysisResults")
+	proto.RegisterType((*FileGenesis)(nil), "FileGenesis")
+	proto.RegisterType((*FileGenesisResults)(nil), "FileGenesisResults")
+	proto.RegisterMapType((map[string]*FileGenesis)(nil), "FileGenesisResults.FilesEntry")
+	proto.RegisterType((*Hotspot)(nil), "Hotspot")
+	proto.RegisterType((*HotspotsAnalysisResults)(nil), "HotspotsAnalysisResults")
+	proto.RegisterType((*BusFactor)(nil), "BusFactor")
+	proto.RegisterType((*BusFactorAnalysisResults)(nil), "BusFactorAnalysisResults")
+	proto.RegisterType((*CommitSizeTick)(nil), "CommitSizeTick")
+	proto.RegisterType((*CommitSizeAnalysisResults)(nil), "CommitSizeAnalysisResults")
+	proto.RegisterMapType((map[int32]*CommitSizeTick)(nil), "CommitSizeAnalysisResults.TicksEntry")
 	proto.RegisterType((*LineStats)(nil), "LineStats")
 	proto.RegisterType((*DevTick)(nil), "DevTick")
 	proto.RegisterMapType((map[string]*LineStats)(nil), "DevTick.LanguagesEntry")
@@ -1702,6 +4039,47 @@ func init() {
 	proto.RegisterType((*ImportsPerDeveloper)(nil), "ImportsPerDeveloper")
 	proto.RegisterMapType((map[string]*ImportsPerLanguage)(nil), "ImportsPerDeveloper.LanguagesEntry")
 	proto.RegisterType((*ImportsPerDeveloperResults)(nil), "ImportsPerDeveloperResults")
+	proto.RegisterType((*DevCadence)(nil), "DevCadence")
+	proto.RegisterType((*DevCadenceAnalysisResults)(nil), "DevCadenceAnalysisResults")
+	proto.RegisterMapType((map[int32]*DevCadence)(nil), "DevCadenceAnalysisResults.DevelopersEntry")
+	proto.RegisterType((*DevFocus)(nil), "DevFocus")
+	proto.RegisterType((*DevFocusTimeline)(nil), "DevFocusTimeline")
+	proto.RegisterMapType((map[int32]*DevFocus)(nil), "DevFocusTimeline.TicksEntry")
+	proto.RegisterType((*DevFocusAnalysisResults)(nil), "DevFocusAnalysisResults")
+	proto.RegisterMapType((map[int32]*DevFocusTimeline)(nil), "DevFocusAnalysisResults.DevelopersEntry")
+	proto.RegisterType((*DevMergeCounts)(nil), "DevMergeCounts")
+	proto.RegisterType((*DevMergeRatioTicks)(nil), "DevMergeRatioTicks")
+	proto.RegisterMapType((map[int32]*DevMergeCounts)(nil), "DevMergeRatioTicks.TicksEntry")
+	proto.RegisterType((*DevMergeRatioAnalysisResults)(nil), "DevMergeRatioAnalysisResults")
+	proto.RegisterMapType((map[int32]*DevMergeRatioTicks)(nil), "DevMergeRatioAnalysisResults.DevelopersEntry")
+	proto.RegisterType((*Punchcard)(nil), "Punchcard")
+	proto.RegisterType((*PunchcardAnalysisResults)(nil), "PunchcardAnalysisResults")
+	proto.RegisterMapType((map[int32]*Punchcard)(nil), "PunchcardAnalysisResults.DevelopersEntry")
+	proto.RegisterType((*DirectoryOwnership)(nil), "DirectoryOwnership")
+	proto.RegisterMapType((map[int32]int64)(nil), "DirectoryOwnership.LinesEntry")
+	proto.RegisterType((*TickDirectoryOwnership)(nil), "TickDirectoryOwnership")
+	proto.RegisterMapType((map[string]*DirectoryOwnership)(nil), "TickDirectoryOwnership.DirectoriesEntry")
+	proto.RegisterType((*DirectoryOwnershipAnalysisResults)(nil), "DirectoryOwnershipAnalysisResults")
+	proto.RegisterMapType((map[int32]*TickDirectoryOwnership)(nil), "DirectoryOwnershipAnalysisResults.TicksEntry")
+	proto.RegisterType((*CodeAgeAnalysisResults)(nil), "CodeAgeAnalysisResults")
+	proto.RegisterMapType((map[int32]int64)(nil), "CodeAgeAnalysisResults.AgesEntry")
+	proto.RegisterMapType((map[string]float64)(nil), "CodeAgeAnalysisResults.FileMediansEntry")
+	proto.RegisterType((*TestRatioAnalysisResults)(nil), "TestRatioAnalysisResults")
+	proto.RegisterType((*CommentRatioSeries)(nil), "CommentRatioSeries")
+	proto.RegisterType((*CommentRatioAnalysisResults)(nil), "CommentRatioAnalysisResults")
+	proto.RegisterMapType((map[string]*CommentRatioSeries)(nil), "CommentRatioAnalysisResults.LanguagesEntry")
+	proto.RegisterType((*LeadTimeHistogram)(nil), "LeadTimeHistogram")
+	proto.RegisterMapType((map[int32]int64)(nil), "LeadTimeHistogram.DelaysEntry")
+	proto.RegisterType((*LeadTimeAnalysisResults)(nil), "LeadTimeAnalysisResults")
+	proto.RegisterType((*Resurrection)(nil), "Resurrection")
+	proto.RegisterType((*ResurrectionAnalysisResults)(nil), "ResurrectionAnalysisResults")
+	proto.RegisterType((*OwnershipTransfer)(nil), "OwnershipTransfer")
+	proto.RegisterType((*OwnershipTransferAnalysisResults)(nil), "OwnershipTransferAnalysisResults")
+	proto.RegisterType((*RefactorEvent)(nil), "RefactorEvent")
+	proto.RegisterType((*RefactorDetectionAnalysisResults)(nil), "RefactorDetectionAnalysisResults")
+	proto.RegisterType((*ImportCouplingAnalysisResults)(nil), "ImportCouplingAnalysisResults")
+	proto.RegisterType((*LineEvent)(nil), "LineEvent")
+	proto.RegisterType((*LineEventsAnalysisResults)(nil), "LineEventsAnalysisResults")
 	proto.RegisterType((*AnalysisResults)(nil), "AnalysisResults")
 	proto.RegisterMapType((map[string][]byte)(nil), "AnalysisResults.ContentsEntry")
 }
@@ -1709,114 +4087,224 @@ func init() {
 func init() { proto.RegisterFile("pb.proto", fileDescriptor_f80abaa17e25ccc8) }
 
 var fileDescriptor_f80abaa17e25ccc8 = []byte{
-	// 1741 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0xcd, 0x6f, 0xdb, 0xca,
-	0x11, 0x07, 0xf5, 0xad, 0x91, 0x2c, 0xd5, 0x6b, 0x37, 0xe6, 0x63, 0xe0, 0x44, 0x61, 0xfd, 0x5a,
-	0xbf, 0xa6, 0x8f, 0xef, 0xc1, 0xe9, 0x03, 0x92, 0xf4, 0x52, 0x5b, 0x6e, 0x10, 0x03, 0xf9, 0xa4,
-	0x9c, 0x14, 0xbd, 0x44, 0xa0, 0xc5, 0xb5, 0xc5, 0x46, 0x5a, 0x12, 0xbb, 0xa4, 0x64, 0x05, 0x2d,
-	0xd0, 0x53, 0x4f, 0xbd, 0xf6, 0xda, 0x5b, 0x2f, 0x2d, 0x7a, 0xea, 0xbf, 0x50, 0x14, 0x28, 0x7a,
-	0xeb, 0x1f, 0xd1, 0xbf, 0xa3, 0xd8, 0x2f, 0x7e, 0xc8, 0xb4, 0xe3, 0xde, 0x38, 0x33, 0xbf, 0xdd,
-	0x9d, 0xf9, 0xcd, 0xec, 0xcc, 0x4a, 0xd0, 0x8a, 0xce, 0x9c, 0x88, 0x86, 0x71, 0x68, 0xff, 0xb7,
-	0x02, 0xad, 0x97, 0x38, 0xf6, 0x7c, 0x2f, 0xf6, 0x90, 0x09, 0xcd, 0x05, 0xa6, 0x2c, 0x08, 0x89,
-	0x69, 0x0c, 0x8c, 0xfd, 0xba, 0xab, 0x45, 0x84, 0xa0, 0x36, 0xf5, 0xd8, 0xd4, 0xac, 0x0c, 0x8c,
-	0xfd, 0xb6, 0x2b, 0xbe, 0xd1, 0x3d, 0x00, 0x8a, 0xa3, 0x90, 0x05, 0x71, 0x48, 0x57, 0x66, 0x55,
-	0x58, 0x72, 0x1a, 0xf4, 0x43, 0xe8, 0x9f, 0xe1, 0x8b, 0x80, 0x8c, 0x13, 0x12, 0x5c, 0x8e, 0xe3,
-	0x60, 0x8e, 0xcd, 0xda, 0xc0, 0xd8, 0xaf, 0xba, 0x1b, 0x42, 0xfd, 0x8e, 0x04, 0x97, 0xa7, 0xc1,
-	0x1c, 0x23, 0x1b, 0x36, 0x30, 0xf1, 0x73, 0xa8, 0xba, 0x40, 0x75, 0x30, 0xf1, 0x53, 0x8c, 0x09,
-	0xcd, 0x49, 0x38, 0x9f, 0x07, 0x31, 0x33, 0x1b, 0xd2, 0x33, 0x25, 0xa2, 0x2f, 0xa0, 0x45, 0x13,
-	0x22, 0x17, 0x36, 0xc5, 0xc2, 0x26, 0x4d, 0x88, 0x58, 0xf4, 0x1c, 0x36, 0xb5, 0x69, 0x1c, 0x61,
-	0x3a, 0x0e, 0x62, 0x3c, 0x37, 0x5b, 0x83, 0xea, 0x7e, 0xe7, 0x60, 0xd7, 0xd1, 0x41, 0x3b, 0xae,
-	0x44, 0xbf, 0xc1, 0xf4, 0x24, 0xc6, 0xf3, 0x5f, 0x90, 0x98, 0xae, 0xdc, 0x1e, 0x2d, 0x28, 0xad,
-	0x43, 0xd8, 0x2a, 0x81, 0xa1, 0xef, 0x41, 0xf5, 0x23, 0x5e, 0x09, 0xae, 0xda, 0x2e, 0xff, 0x44,
-	0xdb, 0x50, 0x5f, 0x78, 0xb3, 0x04, 0x0b, 0xa2, 0x0c, 0x57, 0x0a, 0x4f, 0x2b, 0x8f, 0x0d, 0xfb,
-	0x11, 0xec, 0x1c, 0x25, 0x94, 0xf8, 0xe1, 0x92, 0x8c, 0x22, 0x8f, 0x32, 0xfc, 0xd2, 0x8b, 0x69,
-	0x70, 0xe9, 0x86, 0x4b, 0x19, 0xdc, 0x2c, 0x99, 0x13, 0x66, 0x1a, 0x83, 0xea, 0xfe, 0x86, 0xab,
-	0x45, 0xfb, 0x2f, 0x06, 0x6c, 0x97, 0xad, 0xe2, 0xf9, 0x20, 0xde, 0x1c, 0xab, 0xa3, 0xc5, 0x37,
-	0xda, 0x83, 0x1e, 0x49, 0xe6, 0x67, 0x98, 0x8e, 0xc3, 0xf3, 0x31, 0x0d, 0x97, 0x4c, 0x38, 0x51,
-	0x77, 0xbb, 0x52, 0xfb, 0xfa, 0xdc, 0x0d, 0x97, 0x0c, 0xfd, 0x18, 0x36, 0x33, 0x94, 0x3e, 0xb6,
-	0x2a, 0x80, 0x7d, 0x0d, 0x1c, 0x4a, 0x35, 0xfa, 0x09, 0xd4, 0xc4, 0x3e, 0x35, 0xc1, 0x99, 0xe9,
-	0x5c, 0x13, 0x80, 0x2b, 0x50, 0xf6, 0x6f, 0xa0, 0xf7, 0x2c, 0x98, 0x61, 0xf6, 0x7a, 0x49, 0x30,
-	0x65, 0xd3, 0x20, 0x42, 0xdf, 0x6a, 0x36, 0x0c, 0xb1, 0x81, 0xe5, 0x14, 0xed, 0xce, 0x7b, 0x6e,
-	0x94, 0x8c, 0x4b, 0xa0, 0xf5, 0x18, 0x20, 0x53, 0xe6, 0xf9, 0xad, 0x97, 0xf0, 0x5b, 0xcf, 0xf3,
-	0xfb, 0xfb, 0x6a, 0x46, 0xf0, 0x21, 0xf1, 0x66, 0x2b, 0x16, 0x30, 0x17, 0xb3, 0x64, 0x16, 0x33,
-	0x34, 0x80, 0xce, 0x05, 0xf5, 0x48, 0x32, 0xf3, 0x68, 0x10, 0xeb, 0xfd, 0xf2, 0x2a, 0x64, 0x41,
-	0x8b, 0x79, 0xf3, 0x68, 0x16, 0x90, 0x0b, 0xb5, 0x75, 0x2a, 0xa3, 0x6f, 0xa0, 0x19, 0xd1, 0xf0,
-	0xd7, 0x78, 0x12, 0x0b, 0x9e, 0x3a, 0x07, 0xdf, 0x2f, 0x27, 0x42, 0xa3, 0xd0, 0x43, 0xa8, 0x9f,
-	0xf3, 0x40, 0x15, 0x6f, 0xd7, 0xc0, 0x25, 0x06, 0x7d, 0x0d, 0x8d, 0x08, 0x87, 0xd1, 0x8c, 0x97,
-	0xfd, 0x0d, 0x68, 0x05, 0x42, 0x27, 0x80, 0xe4, 0xd7, 0x38, 0x20, 0x31, 0xa6, 0xde, 0x24, 0xe6,
-	0xb7, 0xb5, 0x21, 0xfc, 0xb2, 0x9c, 0x61, 0x38, 0x8f, 0x28, 0x66, 0x0c, 0xfb, 0x72, 0xb1, 0x1b,
-	0x2e, 0xd5, 0xfa, 0x4d, 0xb9, 0xea, 0x24, 0x5b, 0x84, 0x1e, 0x43, 0x5f, 0xb8, 0x30, 0x0e, 0x75,
-	0x42, 0xcc, 0xa6, 0x70, 0xa1, 0xbf, 0x96, 0x27, 0xb7, 0x77, 0x5e, 0xcc, 0xeb, 0x5d, 0x68, 0xc7,
-	0xc1, 0xe4, 0xe3, 0x98, 0x05, 0x9f, 0xb0, 0xd9, 0x12, 0x97, 0xae, 0xc5, 0x15, 0xa3, 0xe0, 0x13,
-	0xb6, 0xff, 0x6e, 0xc0, 0x17, 0xd7, 0xfa, 0x51, 0x52, 0xa4, 0xc6, 0x6d, 0x8b, 0xb4, 0x52, 0x5e,
-	0xa4, 0x08, 0x6a, 0xfc, 0x1e, 0x9b, 0xd5, 0x41, 0x75, 0xbf, 0xea, 0xd6, 0x74, 0x23, 0x0b, 0x88,
-	0x1f, 0x4c, 0x54, 0x0e, 0xea, 0xae, 0x16, 0xd1, 0x1d, 0x68, 0x04, 0xc4, 0x8f, 0x62, 0x2a, 0xe8,
-	0xae, 0xba, 0x4a, 0xb2, 0x47, 0xd0, 0x1c, 0x86, 0x49, 0xc4, 0x33, 0xb2, 0x0d, 0xf5, 0x80, 0xf8,
-	0xf8, 0x52, 0x54, 0x6d, 0xdb, 0x95, 0x02, 0x3a, 0x80, 0xc6, 0x5c, 0x84, 0x20, 0xfc, 0xb8, 0x99,
-	0x6c, 0x85, 0xb4, 0xf7, 0xa0, 0x7b, 0x1a, 0x26, 0x93, 0x29, 0xf6, 0x05, 0xa1, 0x7c, 0x67, 0x59,
-	0x18, 0x86, 0x70, 0x4a, 0x0a, 0xf6, 0xbf, 0x0c, 0xb8, 0xa3, 0xce, 0x5e, 0x2f, 0xdc, 0x87, 0xd0,
-	0xe5, 0x98, 0xf1, 0x44, 0x9a, 0x55, 0x9e, 0x5b, 0x8e, 0x82, 0xbb, 0x1d, 0x6e, 0xd5, 0x7e, 0x7f,
-	0x03, 0x3d, 0x55, 0x1a, 0x1a, 0xde, 0x5c, 0x83, 0x6f, 0x48, 0xbb, 0x5e, 0xf0, 0x2d, 0x74, 0xd5,
-	0x02, 0xe9, 0x95, 0x6c, 0x8d, 0x1b, 0x4e, 0xde, 0x67, 0xb7, 0x23, 0x21, 0x32, 0x80, 0xfb, 0xd0,
-	0x91, 0x25, 0x33, 0x0b, 0x08, 0x66, 0x66, 0x5b, 0x84, 0x01, 0x42, 0xf5, 0x82, 0x6b, 0xec, 0x3f,
-	0x1b, 0x00, 0xef, 0x0e, 0x47, 0xa7, 0xc3, 0xa9, 0x47, 0x2e, 0x30, 0x2f, 0x14, 0xe1, 0x7f, 0xae,
-	0x57, 0xb5, 0xb8, 0xe2, 0x15, 0xef, 0x57, 0xbb, 0x00, 0x8c, 0x4e, 0xc6, 0x67, 0xf8, 0x3c, 0xa4,
-	0x58, 0x4d, 0x96, 0x36, 0xa3, 0x93, 0x23, 0xa1, 0xe0, 0x6b, 0xb9, 0xd9, 0x3b, 0x8f, 0x31, 0x55,
-	0xd3, 0xa5, 0xc5, 0xe8, 0xe4, 0x90, 0xcb, 0xdc, 0x91, 0xc4, 0x63, 0xb1, 0x5e, 0x5c, 0x93, 0xc3,
-	0x87, 0xab, 0xd4, 0xea, 0x5d, 0x10, 0x92, 0x5a, 0x5e, 0x97, 0x9b, 0x73, 0x8d, 0x58, 0x6f, 0xff,
-	0x1c, 0x76, 0x32, 0x37, 0xd9, 0xc8, 0x5b, 0x60, 0xaa, 0x39, 0xff, 0x12, 0x9a, 0x13, 0xa9, 0x56,
-	0x6d, 0xab, 0xe3, 0x64, 0x50, 0x57, 0xdb, 0xec, 0x7f, 0x18, 0xd0, 0x1b, 0x4d, 0xc3, 0x98, 0x60,
-	0xc6, 0x5c, 0x3c, 0x09, 0xa9, 0xcf, 0x2b, 0x31, 0x5e, 0x45, 0x69, 0x53, 0xe6, 0xdf, 0x69, 0xa3,
-	0xae, 0xe4, 0x1a, 0x35, 0x82, 0x1a, 0x27, 0x41, 0x05, 0x25, 0xbe, 0xd1, 0x13, 0x68, 0x4d, 0xc2,
-	0x84, 0xdf, 0x4e, 0xdd, 0x36, 0x76, 0x9d, 0xe2, 0xf6, 0x3c, 0x8b, 0xc2, 0x2e, 0x1b, 0x66, 0x0a,
-	0xb7, 0x7e, 0x06, 0x1b, 0x05, 0xd3, 0xff, 0xd5, 0x36, 0x8f, 0x61, 0x47, 0x1f, 0xb3, 0x5e, 0x7c,
-	0x5f, 0x41, 0x93, 0x8a, 0x93, 0x35, 0x11, 0xfd, 0x35, 0x8f, 0x5c, 0x6d, 0xb7, 0xff, 0x63, 0x40,
-	0x87, 0x57, 0xc8, 0xf3, 0x80, 0x89, 0xd1, 0x9f, 0x1b, 0xd7, 0xf2, 0x12, 0xa5, 0xe3, 0xfa, 0x3d,
-	0x6c, 0x2b, 0x06, 0xc7, 0x67, 0xab, 0xb1, 0x8f, 0x17, 0x78, 0x16, 0x46, 0x98, 0x9a, 0x15, 0x71,
-	0xc2, 0x9e, 0x93, 0xdb, 0xc5, 0x51, 0xd9, 0x39, 0x5a, 0x1d, 0x6b, 0x98, 0x0c, 0x1d, 0x4d, 0xae,
-	0x18, 0xac, 0xb7, 0xb0, 0x73, 0x0d, 0xbc, 0x84, 0x8e, 0x41, 0x9e, 0x8e, 0xce, 0x01, 0x38, 0xbc,
-	0x78, 0x47, 0xb1, 0x17, 0xb3, 0x3c, 0x35, 0x7f, 0x32, 0xc0, 0xcc, 0xb9, 0x23, 0x69, 0x79, 0x89,
-	0x19, 0xf3, 0x2e, 0x30, 0x7a, 0x9a, 0xbf, 0xca, 0x6b, 0x8e, 0x17, 0x90, 0xb2, 0x97, 0xaa, 0x21,
-	0x27, 0x96, 0x58, 0xcf, 0x00, 0x32, 0x65, 0xc9, 0x23, 0xc2, 0x2e, 0xba, 0xd7, 0x2d, 0xec, 0x9d,
-	0x73, 0xf0, 0x1d, 0xb4, 0x53, 0xc7, 0x79, 0x8a, 0x3d, 0xdf, 0xc7, 0xbe, 0x8a, 0x53, 0x0a, 0x3c,
-	0x11, 0x14, 0xcf, 0xc3, 0x05, 0xf6, 0x55, 0xea, 0xb5, 0x28, 0x52, 0x24, 0x08, 0xf3, 0xd5, 0xf4,
-	0xd7, 0xa2, 0xfd, 0x4f, 0x03, 0x9a, 0xc7, 0x78, 0x71, 0x1a, 0x4c, 0x3e, 0x16, 0x13, 0x59, 0x78,
-	0x77, 0x0d, 0xa0, 0xce, 0xf8, 0xc1, 0x65, 0x1c, 0x0a, 0x03, 0xfa, 0x0e, 0xda, 0x33, 0x8f, 0x5c,
-	0x24, 0x1e, 0xbf, 0x4a, 0x55, 0x41, 0xd3, 0x8e, 0xa3, 0x36, 0x76, 0x5e, 0x68, 0x8b, 0x64, 0x26,
-	0x43, 0x5a, 0xcf, 0xa1, 0x57, 0x34, 0x96, 0x30, 0x74, 0xbb, 0x04, 0x2e, 0xa0, 0xc5, 0xcf, 0x3a,
-	0xc6, 0x0b, 0x86, 0x7e, 0x04, 0x35, 0x1f, 0x2f, 0x74, 0xba, 0xb6, 0x1c, 0x6d, 0xe0, 0x0e, 0x29,
-	0x1f, 0x04, 0xc0, 0x3a, 0x84, 0x76, 0xaa, 0x2a, 0x29, 0x9d, 0x7b, 0xc5, 0x93, 0x5b, 0x3a, 0xa0,
-	0xfc, 0xb9, 0xff, 0x36, 0x60, 0x8b, 0xef, 0xb1, 0x7e, 0xa1, 0xbe, 0x83, 0x3a, 0x9f, 0x92, 0xda,
-	0x89, 0xfb, 0x4e, 0x09, 0x48, 0x38, 0xa6, 0xcb, 0x45, 0xa0, 0x79, 0x23, 0xf4, 0xf1, 0x62, 0x2c,
-	0x67, 0x52, 0x45, 0x5c, 0xa7, 0x96, 0x8f, 0x17, 0x27, 0x62, 0x2c, 0xdd, 0x34, 0x8a, 0xad, 0x21,
-	0x40, 0xb6, 0x5d, 0x49, 0x30, 0xf7, 0x8b, 0xc1, 0xb4, 0x53, 0x56, 0xf2, 0xd1, 0xfc, 0x12, 0xda,
-	0x23, 0x4c, 0xf8, 0x23, 0x9a, 0xc4, 0x59, 0x23, 0xe1, 0xbb, 0x54, 0x14, 0x8c, 0xbf, 0x9e, 0x78,
-	0x59, 0x60, 0x22, 0xca, 0x41, 0x38, 0xa8, 0xe5, 0x7c, 0x05, 0x55, 0x0b, 0xad, 0x80, 0x77, 0xd0,
-	0x9d, 0xa1, 0x84, 0xa5, 0x07, 0x68, 0xaa, 0x7e, 0x05, 0x9b, 0x4c, 0xeb, 0x78, 0xa3, 0xe0, 0x21,
-	0x29, 0xda, 0xbe, 0x76, 0xae, 0x59, 0xe4, 0xa4, 0x8a, 0xa3, 0x15, 0x0f, 0x44, 0x92, 0xd8, 0x67,
-	0x45, 0xad, 0xf5, 0x0a, 0xb6, 0xcb, 0x80, 0xb7, 0x69, 0x13, 0xd9, 0x89, 0x39, 0x7e, 0x3e, 0x00,
-	0x0c, 0x45, 0x44, 0xfc, 0x96, 0x96, 0x3e, 0xcc, 0x2d, 0x68, 0xe9, 0xf2, 0xd6, 0x83, 0x4c, 0xcb,
-	0xd9, 0x35, 0xaa, 0x5d, 0x73, 0x8d, 0xec, 0xdf, 0x42, 0x43, 0xee, 0x9f, 0xfe, 0x08, 0x33, 0x72,
-	0x3f, 0xc2, 0xf6, 0xa0, 0xb7, 0x9c, 0xe2, 0xfc, 0x6f, 0xac, 0x8a, 0x28, 0x82, 0x2e, 0xd7, 0xa6,
-	0x3f, 0x9f, 0xee, 0x40, 0xc3, 0x4b, 0xe2, 0x69, 0x48, 0xd5, 0x5d, 0x57, 0x12, 0x7a, 0x50, 0x7c,
-	0xa9, 0x76, 0x9c, 0x2c, 0x12, 0xfd, 0x3a, 0xf9, 0xc0, 0x1f, 0x27, 0x22, 0x61, 0xeb, 0xe5, 0xfc,
-	0xa0, 0xd8, 0xe4, 0x3b, 0x07, 0x4d, 0xb5, 0x3c, 0x6b, 0x12, 0x0f, 0xa0, 0x2b, 0x4f, 0x2a, 0x54,
-	0x6f, 0x47, 0xea, 0x44, 0x01, 0xdb, 0x0b, 0xa8, 0x9d, 0xae, 0xa2, 0x90, 0x57, 0xd6, 0x92, 0x86,
-	0xe4, 0x42, 0x45, 0x27, 0x05, 0x59, 0x3d, 0x94, 0xf2, 0xb7, 0xb7, 0x9c, 0xa0, 0x5a, 0xe4, 0x21,
-	0xc9, 0x53, 0x14, 0xa5, 0x4a, 0x4a, 0x87, 0x6b, 0x2d, 0x37, 0x5c, 0x11, 0xd4, 0xf8, 0x83, 0x45,
-	0x3c, 0x03, 0xea, 0xae, 0xf8, 0xb6, 0x1f, 0x42, 0x97, 0x9f, 0xcb, 0x8e, 0xbd, 0xd8, 0x63, 0x38,
-	0x46, 0x77, 0xa1, 0x1e, 0x73, 0x59, 0xc5, 0x52, 0x77, 0xb8, 0xd5, 0x95, 0x3a, 0xfb, 0x77, 0x06,
-	0xf4, 0x4e, 0xe6, 0x51, 0x48, 0x63, 0xf6, 0x06, 0x53, 0xd1, 0x19, 0x1f, 0xf1, 0xf3, 0x13, 0x92,
-	0x06, 0x7f, 0xd7, 0x29, 0x02, 0xe4, 0xb8, 0x56, 0x37, 0x59, 0x41, 0xad, 0x27, 0xd0, 0xc9, 0xa9,
-	0x3f, 0x37, 0xa8, 0xab, 0xf9, 0x32, 0xfb, 0xa3, 0x01, 0x28, 0x3b, 0x41, 0x77, 0x48, 0xf4, 0xd3,
-	0x62, 0x4f, 0xb9, 0xe7, 0x5c, 0xc5, 0x5c, 0x6d, 0x29, 0xd6, 0xc9, 0x75, 0x8d, 0x41, 0xf5, 0xd7,
-	0x2f, 0x8b, 0x95, 0xdf, 0x5f, 0x8b, 0x2d, 0xef, 0xd7, 0x5f, 0x0d, 0xd8, 0xca, 0xac, 0xe9, 0xe8,
-	0x45, 0x87, 0xf9, 0xee, 0x2f, 0x9d, 0xfb, 0x81, 0x53, 0x02, 0xbc, 0x61, 0x12, 0xbc, 0xbd, 0xc5,
-	0x24, 0xf8, 0xaa, 0xe8, 0xe9, 0x56, 0x49, 0xfc, 0x79, 0x6f, 0xff, 0x60, 0x80, 0x55, 0xe2, 0x84,
-	0x2e, 0x69, 0x07, 0x9a, 0x81, 0xb4, 0x2a, 0x97, 0xb7, 0xcb, 0x5c, 0x76, 0x35, 0xe8, 0x16, 0xf5,
-	0x5d, 0x6c, 0xd0, 0xd5, 0xb5, 0xdf, 0x4a, 0x7f, 0x33, 0xa0, 0x7f, 0xf5, 0x5a, 0x35, 0xa6, 0xd8,
-	0xf3, 0x31, 0x15, 0x61, 0xf2, 0xae, 0xac, 0xff, 0xaa, 0x70, 0x95, 0x01, 0x3d, 0xe5, 0xfd, 0x96,
-	0xc4, 0x69, 0xbf, 0xe5, 0x79, 0x5f, 0x9f, 0x23, 0x43, 0x05, 0x48, 0x5f, 0x8b, 0x52, 0x94, 0xaf,
-	0xc5, 0x9c, 0xe9, 0x73, 0x7f, 0x62, 0x74, 0x73, 0xf4, 0x9d, 0x35, 0xc4, 0x9f, 0x46, 0x8f, 0xfe,
-	0x17, 0x00, 0x00, 0xff, 0xff, 0x01, 0xe4, 0xea, 0x45, 0x40, 0x12, 0x00, 0x00,
+	// 3494 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x3a, 0x4d, 0x6c, 0x1b, 0xc7,
+	0xd5, 0x58, 0xfe, 0x88, 0xe4, 0xa3, 0x48, 0x59, 0x2b, 0x45, 0xa2, 0xe9, 0xd8, 0x96, 0xd7, 0x76,
+	0x2c, 0xc7, 0xf1, 0x3a, 0x90, 0x93, 0x7c, 0xb1, 0xf3, 0xe1, 0xc3, 0xa7, 0x9f, 0x38, 0x96, 0x13,
+	0x27, 0xce, 0x4a, 0x71, 0x50, 0x14, 0x08, 0xbb, 0xe2, 0x8e, 0xc8, 0x8d, 0xc9, 0x5d, 0x62, 0x66,
+	0x49, 0x99, 0x6e, 0x0b, 0xb4, 0x40, 0x81, 0x00, 0x4d, 0x4e, 0x45, 0x7b, 0xe8, 0x25, 0x87, 0x02,
+	0xbd, 0xf4, 0xe7, 0xd2, 0x5e, 0xda, 0x7b, 0xd1, 0x43, 0x8f, 0x3d, 0xf5, 0xdc, 0x73, 0x81, 0xa0,
+	0xe7, 0x5e, 0x8a, 0xf9, 0xdb, 0x9d, 0xe1, 0x2e, 0x29, 0xc9, 0x46, 0x7b, 0xdb, 0xf7, 0x37, 0xf3,
+	0xe6, 0xcd, 0x9b, 0xf7, 0xde, 0xbc, 0x59, 0x28, 0x0f, 0x0e, 0xec, 0x01, 0x0e, 0xa3, 0xd0, 0xfa,
+	0xba, 0x00, 0xe5, 0x87, 0x28, 0x72, 0x3d, 0x37, 0x72, 0xcd, 0x06, 0x94, 0x46, 0x08, 0x13, 0x3f,
+	0x0c, 0x1a, 0xc6, 0x9a, 0xb1, 0x5e, 0x74, 0x24, 0x68, 0x9a, 0x50, 0xe8, 0xba, 0xa4, 0xdb, 0xc8,
+	0xad, 0x19, 0xeb, 0x15, 0x87, 0x7d, 0x9b, 0x17, 0x00, 0x30, 0x1a, 0x84, 0xc4, 0x8f, 0x42, 0x3c,
+	0x6e, 0xe4, 0x19, 0x45, 0xc1, 0x98, 0xaf, 0xc0, 0xc2, 0x01, 0xea, 0xf8, 0x41, 0x6b, 0x18, 0xf8,
+	0x4f, 0x5b, 0x91, 0xdf, 0x47, 0x8d, 0xc2, 0x9a, 0xb1, 0x9e, 0x77, 0x6a, 0x0c, 0xfd, 0x49, 0xe0,
+	0x3f, 0xdd, 0xf7, 0xfb, 0xc8, 0xb4, 0xa0, 0x86, 0x02, 0x4f, 0xe1, 0x2a, 0x32, 0xae, 0x2a, 0x0a,
+	0xbc, 0x98, 0xa7, 0x01, 0xa5, 0x76, 0xd8, 0xef, 0xfb, 0x11, 0x69, 0xcc, 0x71, 0xcd, 0x04, 0x68,
+	0x9e, 0x85, 0x32, 0x1e, 0x06, 0x5c, 0xb0, 0xc4, 0x04, 0x4b, 0x78, 0x18, 0x30, 0xa1, 0xfb, 0xb0,
+	0x28, 0x49, 0xad, 0x01, 0xc2, 0x2d, 0x3f, 0x42, 0xfd, 0x46, 0x79, 0x2d, 0xbf, 0x5e, 0xdd, 0x38,
+	0x6f, 0xcb, 0x45, 0xdb, 0x0e, 0xe7, 0x7e, 0x84, 0xf0, 0x6e, 0x84, 0xfa, 0xef, 0x06, 0x11, 0x1e,
+	0x3b, 0x75, 0xac, 0x21, 0xcd, 0xab, 0x50, 0x27, 0x4f, 0xd0, 0x11, 0xf2, 0x5a, 0x52, 0x8b, 0x0a,
+	0xd3, 0xa2, 0xc6, 0xb1, 0xdb, 0x42, 0x97, 0xab, 0x50, 0x3f, 0x74, 0xfd, 0x9e, 0xc2, 0x06, 0x9c,
+	0x8d, 0x63, 0x25, 0xdb, 0x4d, 0x98, 0x6b, 0x87, 0xc1, 0xa1, 0xdf, 0x69, 0x54, 0x99, 0x32, 0x2f,
+	0x25, 0xca, 0x6c, 0x33, 0x3c, 0x57, 0x42, 0x30, 0x35, 0x37, 0x61, 0x29, 0x43, 0x47, 0xf3, 0x0c,
+	0xe4, 0x9f, 0xa0, 0x31, 0xdb, 0xa8, 0x8a, 0x43, 0x3f, 0xcd, 0x65, 0x28, 0x8e, 0xdc, 0xde, 0x10,
+	0xb1, 0x5d, 0x32, 0x1c, 0x0e, 0xdc, 0xcd, 0xbd, 0x6d, 0x34, 0xef, 0x40, 0x55, 0x19, 0xf9, 0x38,
+	0xd1, 0x8a, 0x22, 0x6a, 0xdd, 0x86, 0xd5, 0xad, 0x21, 0x0e, 0xbc, 0xf0, 0x28, 0xd8, 0x1b, 0xb8,
+	0x98, 0xa0, 0x87, 0x6e, 0x84, 0xfd, 0xa7, 0x4e, 0x78, 0xc4, 0x37, 0xa5, 0x37, 0xec, 0x07, 0xa4,
+	0x61, 0xac, 0xe5, 0xd7, 0x6b, 0x8e, 0x04, 0xad, 0x5f, 0x19, 0xb0, 0x9c, 0x25, 0x45, 0xfd, 0x28,
+	0x70, 0xfb, 0x48, 0x4c, 0xcd, 0xbe, 0xcd, 0x2b, 0x50, 0x0f, 0x86, 0xfd, 0x03, 0x84, 0x5b, 0xe1,
+	0x61, 0x0b, 0x87, 0x47, 0x84, 0x29, 0x51, 0x74, 0xe6, 0x39, 0xf6, 0xa3, 0x43, 0x27, 0x3c, 0x22,
+	0xe6, 0xab, 0xb0, 0x98, 0x70, 0xc9, 0x69, 0xf3, 0x8c, 0x71, 0x41, 0x32, 0x6e, 0x73, 0xb4, 0xf9,
+	0x1a, 0x14, 0xd8, 0x38, 0x05, 0x66, 0xde, 0x86, 0x3d, 0x65, 0x01, 0x0e, 0xe3, 0xb2, 0xbe, 0x07,
+	0xf5, 0x7b, 0x7e, 0x0f, 0x91, 0x8f, 0x8e, 0x02, 0x84, 0x49, 0xd7, 0x1f, 0x98, 0xaf, 0x4b, 0x6b,
+	0x18, 0x6c, 0x80, 0xa6, 0xad, 0xd3, 0xed, 0xc7, 0x94, 0xc8, 0x37, 0x89, 0x33, 0x36, 0xdf, 0x06,
+	0x48, 0x90, 0xaa, 0x7d, 0x8b, 0x19, 0xf6, 0x2d, 0xaa, 0xf6, 0xfd, 0x7b, 0x31, 0x31, 0xf0, 0x66,
+	0xe0, 0xf6, 0xc6, 0xc4, 0x27, 0x0e, 0x22, 0xc3, 0x5e, 0x44, 0xcc, 0x35, 0xa8, 0x76, 0xb0, 0x1b,
+	0x0c, 0x7b, 0x2e, 0xf6, 0x23, 0x39, 0x9e, 0x8a, 0x32, 0x9b, 0x50, 0x26, 0x6e, 0x7f, 0xd0, 0xf3,
+	0x83, 0x8e, 0x18, 0x3a, 0x86, 0xcd, 0x5b, 0x50, 0x1a, 0xe0, 0xf0, 0x73, 0xd4, 0x8e, 0x98, 0x9d,
+	0xa8, 0x9f, 0x65, 0x1a, 0x42, 0x72, 0x99, 0x37, 0xa0, 0x78, 0x48, 0x17, 0x2a, 0xec, 0x36, 0x85,
+	0x9d, 0xf3, 0x50, 0x27, 0x1e, 0xa0, 0x70, 0xd0, 0xa3, 0xc7, 0x75, 0x06, 0xb7, 0x60, 0x32, 0x77,
+	0xc1, 0xe4, 0x5f, 0x2d, 0x3f, 0x88, 0x10, 0x76, 0xdb, 0x11, 0x8d, 0x32, 0x73, 0x4c, 0xaf, 0xa6,
+	0xbd, 0x1d, 0xf6, 0x07, 0x18, 0x11, 0x82, 0x3c, 0x2e, 0xec, 0x84, 0x47, 0x42, 0x7e, 0x91, 0x4b,
+	0xed, 0x26, 0x42, 0xe6, 0xdb, 0xb0, 0xc0, 0x54, 0x68, 0x85, 0x72, 0x43, 0x1a, 0x25, 0xa6, 0xc2,
+	0xc2, 0xc4, 0x3e, 0x39, 0xf5, 0x43, 0x7d, 0x5f, 0xcf, 0x41, 0x25, 0xf2, 0xdb, 0x4f, 0x5a, 0xc4,
+	0x7f, 0x86, 0x1a, 0x65, 0x16, 0x2c, 0xca, 0x14, 0xb1, 0xe7, 0x3f, 0x43, 0xe6, 0xff, 0x42, 0x9d,
+	0x4e, 0x30, 0x42, 0x2d, 0x77, 0x18, 0x75, 0x43, 0xcc, 0xcf, 0xf8, 0xd4, 0x85, 0xd5, 0x38, 0xf3,
+	0x26, 0xe7, 0x35, 0x37, 0xe0, 0x25, 0x5d, 0xba, 0x75, 0xe4, 0x53, 0x21, 0x11, 0x01, 0x96, 0x34,
+	0xee, 0x4f, 0x19, 0xc9, 0xbc, 0x0b, 0x35, 0x1e, 0x27, 0x5a, 0xed, 0x70, 0x18, 0x44, 0xa4, 0x51,
+	0x9d, 0x35, 0xe1, 0x3c, 0xe7, 0xdd, 0x66, 0xac, 0xe6, 0x6d, 0x80, 0xb0, 0xe7, 0xb5, 0x46, 0xa4,
+	0x15, 0xa0, 0xa3, 0xc6, 0xfc, 0x2c, 0xc1, 0x72, 0xd8, 0xf3, 0x1e, 0x93, 0x0f, 0xd1, 0x91, 0x79,
+	0x0b, 0x96, 0x13, 0xa1, 0x56, 0xd4, 0xc5, 0x88, 0x74, 0xc3, 0x9e, 0xd7, 0xa8, 0x31, 0x1d, 0x17,
+	0x25, 0xdf, 0xbe, 0x24, 0xb0, 0xb8, 0x47, 0xdd, 0x09, 0xc5, 0x01, 0xad, 0xbe, 0x96, 0x5f, 0xaf,
+	0x38, 0x35, 0x8e, 0x95, 0x01, 0xed, 0x22, 0x54, 0xa3, 0x30, 0x72, 0x7b, 0xad, 0x9e, 0x1f, 0x20,
+	0xd2, 0x58, 0x58, 0xcb, 0xaf, 0xe7, 0x1d, 0x60, 0xa8, 0x0f, 0x28, 0xc6, 0xfa, 0xbd, 0x01, 0x67,
+	0xa7, 0xee, 0x71, 0x46, 0x00, 0x30, 0x4e, 0x1a, 0x00, 0x72, 0xd9, 0x01, 0xc0, 0x84, 0x02, 0x0d,
+	0xa7, 0x8d, 0x3c, 0xd3, 0xa4, 0x20, 0x93, 0x9b, 0x1f, 0x78, 0x7e, 0x5b, 0xf8, 0x77, 0xd1, 0x91,
+	0xa0, 0xb9, 0x02, 0x73, 0x7e, 0xe0, 0x0d, 0x22, 0xcc, 0x5c, 0x39, 0xef, 0x08, 0xc8, 0xfa, 0x83,
+	0x01, 0x17, 0x32, 0xb4, 0xbe, 0xd7, 0x0b, 0xdd, 0xe8, 0xbf, 0xa2, 0x7a, 0xee, 0xb9, 0x55, 0xdf,
+	0x83, 0xd2, 0x76, 0x38, 0x1c, 0xd0, 0x83, 0xba, 0x0c, 0x45, 0x3f, 0xf0, 0xd0, 0x53, 0x16, 0xcc,
+	0x2a, 0x0e, 0x07, 0xcc, 0x0d, 0x98, 0xeb, 0xb3, 0x25, 0x34, 0x72, 0xc7, 0x9e, 0x41, 0xc1, 0x69,
+	0x5d, 0x81, 0xf9, 0xfd, 0x70, 0xd8, 0xee, 0x22, 0xef, 0x9e, 0x2f, 0x46, 0xe6, 0xf1, 0xc2, 0x60,
+	0x4a, 0x71, 0xc0, 0xfa, 0x4b, 0x0e, 0x56, 0xc4, 0xdc, 0x93, 0xf1, 0xec, 0x06, 0xcc, 0x53, 0x9e,
+	0x56, 0x9b, 0x93, 0xc5, 0xf1, 0x2f, 0xdb, 0x82, 0xdd, 0xa9, 0x52, 0xaa, 0xd4, 0xfb, 0x16, 0xd4,
+	0x45, 0xc4, 0x90, 0xec, 0xa5, 0x09, 0xf6, 0x1a, 0xa7, 0x4b, 0x81, 0xd7, 0x61, 0x5e, 0x08, 0x70,
+	0xad, 0x78, 0xa6, 0xaf, 0xd9, 0xaa, 0xce, 0x4e, 0x95, 0xb3, 0xf0, 0x05, 0x5c, 0x84, 0x2a, 0x8f,
+	0x24, 0xdc, 0x6f, 0x2b, 0x6c, 0x19, 0xc0, 0x50, 0xcc, 0x6f, 0xcd, 0x1d, 0xa8, 0x71, 0x86, 0xcf,
+	0xdd, 0x76, 0xdb, 0xc5, 0x1e, 0x3b, 0xcd, 0xd5, 0x8d, 0x8b, 0xf6, 0x6c, 0xb7, 0x70, 0xd8, 0x32,
+	0xc9, 0x03, 0x2e, 0x64, 0xde, 0x81, 0x33, 0x7c, 0x14, 0xd4, 0x3f, 0x40, 0x9e, 0xe7, 0x07, 0x1d,
+	0x22, 0x32, 0x7f, 0x9d, 0x45, 0xac, 0x77, 0x25, 0xda, 0xe1, 0x81, 0x2d, 0x86, 0x89, 0x75, 0x0d,
+	0x6a, 0x1a, 0x07, 0xdd, 0xf0, 0x11, 0x6a, 0x47, 0x21, 0x66, 0x46, 0xcf, 0x39, 0x02, 0xb2, 0x7e,
+	0x69, 0x00, 0x7c, 0xb2, 0xb9, 0xb7, 0xbf, 0xdd, 0x75, 0x83, 0x0e, 0xa2, 0x91, 0x8e, 0x59, 0x5a,
+	0x49, 0xb6, 0x65, 0x8a, 0xf8, 0x90, 0x26, 0xdc, 0xf3, 0x00, 0x04, 0xb7, 0x5b, 0x07, 0xe8, 0x30,
+	0xc4, 0x32, 0xe3, 0x57, 0x08, 0x6e, 0x6f, 0x31, 0x04, 0x95, 0xa5, 0x64, 0xf7, 0x30, 0x42, 0x58,
+	0x94, 0x75, 0x65, 0x82, 0xdb, 0x9b, 0x14, 0xa6, 0x26, 0x1b, 0xba, 0x24, 0x92, 0xc2, 0x05, 0x46,
+	0x06, 0x8a, 0x12, 0xd2, 0xe7, 0x81, 0x41, 0x42, 0xbc, 0xc8, 0x07, 0xa7, 0x18, 0x26, 0x6f, 0xfd,
+	0x3f, 0xac, 0x26, 0x6a, 0x92, 0x3d, 0x77, 0x84, 0xb0, 0xf4, 0x8e, 0xab, 0x50, 0x6a, 0x73, 0xb4,
+	0xc8, 0xbb, 0x55, 0x3b, 0x61, 0x75, 0x24, 0xcd, 0xfa, 0x93, 0x01, 0xf5, 0xbd, 0x6e, 0x18, 0x05,
+	0x88, 0x10, 0x07, 0xb5, 0x43, 0xec, 0xd1, 0x33, 0x13, 0x8d, 0x07, 0x71, 0x55, 0x41, 0xbf, 0xe3,
+	0x4a, 0x23, 0xa7, 0x54, 0x1a, 0x26, 0x14, 0xa8, 0x11, 0xc4, 0xa2, 0xd8, 0xb7, 0x79, 0x07, 0xca,
+	0x2c, 0xfa, 0x22, 0x2c, 0xf3, 0xde, 0x79, 0x5b, 0x1f, 0xde, 0xde, 0x16, 0x74, 0x9e, 0xf1, 0x63,
+	0xf6, 0xe6, 0x3b, 0x50, 0xd3, 0x48, 0xa7, 0xca, 0xfb, 0x3b, 0xb0, 0x2a, 0xa7, 0x99, 0x3c, 0x26,
+	0xd7, 0xa1, 0x84, 0xd9, 0xcc, 0xd2, 0x10, 0x0b, 0x13, 0x1a, 0x39, 0x92, 0x6e, 0xfd, 0xd5, 0x80,
+	0x2a, 0x75, 0x90, 0xfb, 0x3e, 0x61, 0x35, 0xb7, 0x52, 0x27, 0xf3, 0xe3, 0x2e, 0x41, 0xf3, 0x31,
+	0x2c, 0x0b, 0x0b, 0xb6, 0x0e, 0xc6, 0x2d, 0x0f, 0x8d, 0x50, 0x2f, 0x1c, 0x20, 0xdc, 0xc8, 0xb1,
+	0x19, 0xae, 0xd8, 0xca, 0x28, 0xb6, 0xd8, 0x9d, 0xad, 0xf1, 0x8e, 0x64, 0xe3, 0x4b, 0x37, 0xdb,
+	0x29, 0x42, 0xf3, 0x63, 0x58, 0x9d, 0xc2, 0x9e, 0x61, 0x8e, 0x35, 0xd5, 0x1c, 0xd5, 0x0d, 0xb0,
+	0xe9, 0x31, 0xdb, 0x8b, 0xdc, 0x88, 0xa8, 0xa6, 0xf9, 0xda, 0x80, 0x86, 0xa2, 0x0e, 0x37, 0xcb,
+	0x43, 0x44, 0x88, 0xdb, 0x41, 0xe6, 0x5d, 0x35, 0xe8, 0x4c, 0x28, 0xae, 0x71, 0x32, 0x82, 0xd8,
+	0x33, 0x2e, 0xd2, 0xbc, 0x07, 0x90, 0x20, 0x33, 0xaa, 0x60, 0x4b, 0x57, 0x6f, 0x5e, 0x1b, 0x5b,
+	0x51, 0xf0, 0x87, 0x06, 0x34, 0xb7, 0xfc, 0xc0, 0xc5, 0xe3, 0xed, 0xee, 0x10, 0xa7, 0xca, 0xb6,
+	0x65, 0x28, 0xba, 0x9e, 0x87, 0x3c, 0xa6, 0x62, 0xde, 0xe1, 0x00, 0xdd, 0x1a, 0x8c, 0xfa, 0xe1,
+	0x08, 0x79, 0xcc, 0xe6, 0x79, 0x47, 0x82, 0xf4, 0x4c, 0x7b, 0xa8, 0x17, 0xb9, 0x44, 0xe4, 0x2b,
+	0x01, 0xe9, 0xe5, 0x4a, 0x41, 0x2f, 0x57, 0xac, 0x3b, 0x7c, 0xe3, 0xdf, 0x43, 0x01, 0x22, 0x3e,
+	0x4b, 0x1b, 0x94, 0x24, 0x8c, 0xcd, 0xbe, 0xe9, 0xb8, 0xbc, 0x18, 0x11, 0xde, 0x27, 0x20, 0xea,
+	0x34, 0xa6, 0x22, 0x2b, 0xd5, 0x7e, 0x43, 0xb7, 0xec, 0x05, 0x3b, 0xcd, 0x93, 0xb6, 0xa9, 0x79,
+	0x09, 0xe6, 0xf9, 0xb0, 0x2d, 0x9e, 0x65, 0x72, 0xcc, 0xed, 0xaa, 0x1c, 0xb7, 0x4b, 0x51, 0xfa,
+	0x3a, 0xf2, 0xfa, 0x3a, 0x9e, 0x6f, 0x4f, 0xa4, 0x56, 0xca, 0x9e, 0xbc, 0x0f, 0xa5, 0xfb, 0x61,
+	0x44, 0x06, 0x61, 0x44, 0x6d, 0x31, 0x70, 0xa3, 0xae, 0x0c, 0x07, 0xf4, 0x9b, 0xee, 0x09, 0xf2,
+	0xe8, 0xb1, 0xc8, 0xb1, 0xf9, 0x39, 0x40, 0x2d, 0x44, 0x10, 0xf6, 0x51, 0x6c, 0x79, 0x0e, 0x59,
+	0x8f, 0x61, 0x55, 0x0c, 0x96, 0x3a, 0x9c, 0x17, 0x74, 0x2b, 0x95, 0x6d, 0xc1, 0x28, 0xed, 0xa1,
+	0x2d, 0x36, 0x37, 0xb1, 0x69, 0x3d, 0xa8, 0x6c, 0x0d, 0xc9, 0x3d, 0x97, 0x86, 0xec, 0x69, 0x6a,
+	0xf2, 0x5c, 0x24, 0xe2, 0x05, 0x03, 0x68, 0x4c, 0x3d, 0x18, 0x92, 0xd6, 0x21, 0x93, 0x13, 0x97,
+	0x9e, 0xca, 0x41, 0x3c, 0xd0, 0x0a, 0xcc, 0xf1, 0x52, 0x58, 0x54, 0x07, 0x02, 0xb2, 0xbe, 0x30,
+	0xa0, 0x11, 0x4f, 0x97, 0xbe, 0x5b, 0x68, 0xeb, 0x00, 0x3b, 0xe6, 0x94, 0x2b, 0x79, 0x0d, 0xaa,
+	0x9e, 0x8f, 0x59, 0x7a, 0xf1, 0x99, 0x46, 0x93, 0x7c, 0x2a, 0x99, 0xae, 0xdb, 0x43, 0x23, 0xe1,
+	0x04, 0x79, 0xe6, 0x04, 0x65, 0x0f, 0x8d, 0x98, 0x07, 0x58, 0xeb, 0x50, 0xe7, 0xb5, 0x22, 0xb5,
+	0xc2, 0xbe, 0xf0, 0x4d, 0x51, 0xf4, 0xf2, 0x43, 0x22, 0x20, 0xeb, 0x6f, 0xbc, 0x52, 0x14, 0xac,
+	0x93, 0x4a, 0xaf, 0xc0, 0xdc, 0x41, 0x38, 0x0c, 0x3c, 0x59, 0x72, 0x08, 0xc8, 0x7c, 0x07, 0x8a,
+	0xd4, 0xc6, 0x52, 0xc9, 0xab, 0xf6, 0xd4, 0x21, 0x6c, 0x3a, 0xbb, 0xf4, 0x60, 0x26, 0x33, 0xdb,
+	0x3d, 0x77, 0x01, 0x12, 0x89, 0x8c, 0x88, 0x76, 0x55, 0x77, 0xcf, 0x05, 0x5b, 0x5f, 0xa7, 0xea,
+	0xa1, 0x9f, 0x40, 0x25, 0x0e, 0x77, 0x6a, 0x8c, 0x60, 0x1b, 0x9d, 0x11, 0x23, 0x28, 0x5e, 0x82,
+	0x94, 0xc2, 0x83, 0xaf, 0x27, 0xf6, 0x5f, 0x82, 0xd6, 0x9f, 0x0d, 0x28, 0xed, 0xa0, 0x11, 0xb3,
+	0xaa, 0x16, 0xfe, 0xb5, 0x36, 0xc9, 0x1a, 0x14, 0x09, 0x9d, 0x38, 0x2b, 0xf2, 0x32, 0x82, 0xf9,
+	0x26, 0x54, 0x7a, 0x6e, 0xd0, 0x19, 0xba, 0x1d, 0x71, 0x1c, 0xaa, 0x1b, 0xab, 0xb6, 0x18, 0xd8,
+	0xfe, 0x40, 0x52, 0xb8, 0xe5, 0x12, 0xce, 0xe6, 0x7d, 0xa8, 0xeb, 0xc4, 0x8c, 0x33, 0x7c, 0xb2,
+	0xb0, 0x3f, 0x82, 0x32, 0x9d, 0x6b, 0x07, 0x8d, 0x88, 0x79, 0x0d, 0x0a, 0x1e, 0x1a, 0x49, 0xe7,
+	0x5c, 0xb2, 0x25, 0x81, 0x2a, 0x24, 0x74, 0x60, 0x0c, 0xcd, 0x4d, 0xa8, 0xc4, 0xa8, 0x8c, 0xed,
+	0xb9, 0xa0, 0xcf, 0x5c, 0x96, 0x0b, 0x52, 0xe7, 0xfd, 0x87, 0x01, 0x4b, 0x74, 0x8c, 0x49, 0x67,
+	0x7b, 0x53, 0x3a, 0x15, 0x57, 0xe2, 0xa2, 0x9d, 0xc1, 0x94, 0xed, 0x4e, 0xc9, 0x41, 0xc8, 0xe9,
+	0x07, 0x81, 0x96, 0x4f, 0xe2, 0x9a, 0xc8, 0x96, 0x97, 0xe7, 0x15, 0x27, 0x47, 0xb1, 0x85, 0xcf,
+	0xba, 0xa2, 0x36, 0xb7, 0x8f, 0x71, 0xc6, 0x8b, 0xfa, 0x6a, 0x2b, 0xb1, 0xd9, 0xd4, 0xe5, 0x7e,
+	0x0a, 0x95, 0x3d, 0x14, 0x44, 0x7e, 0x1f, 0x05, 0x51, 0x52, 0x9f, 0xd0, 0x51, 0x72, 0x82, 0x8d,
+	0x76, 0x15, 0xa8, 0xdf, 0xa0, 0x20, 0x22, 0x72, 0x05, 0x12, 0x56, 0x5d, 0x2c, 0xaf, 0x55, 0x18,
+	0xb4, 0x30, 0x5b, 0xdd, 0xe6, 0x6c, 0xf1, 0x04, 0xd2, 0x96, 0xdf, 0x82, 0x45, 0x22, 0x71, 0xb4,
+	0xfe, 0x10, 0xb9, 0x8a, 0xda, 0xf5, 0xa6, 0x3d, 0x45, 0xc8, 0x8e, 0x11, 0x5b, 0x63, 0xba, 0x10,
+	0x6e, 0xe5, 0x05, 0xa2, 0x63, 0x9b, 0x1f, 0xc2, 0x72, 0x16, 0xe3, 0x49, 0xaa, 0x8f, 0x64, 0x46,
+	0xc5, 0x3e, 0x9f, 0x01, 0xf0, 0x33, 0x4c, 0x13, 0x4d, 0x66, 0xc3, 0xaa, 0x09, 0x65, 0xe9, 0xff,
+	0xb2, 0x3e, 0x96, 0x70, 0x72, 0xce, 0x0a, 0x53, 0xce, 0x99, 0xf5, 0x7d, 0x98, 0xe3, 0xe3, 0xc7,
+	0x4d, 0x55, 0x43, 0x69, 0xaa, 0x5e, 0x81, 0xfa, 0x51, 0x17, 0xa9, 0x3d, 0x53, 0x9e, 0x43, 0xe6,
+	0x29, 0x36, 0x6e, 0x87, 0x26, 0x99, 0x3d, 0xaf, 0x66, 0x76, 0xf3, 0x92, 0xde, 0xc1, 0xa9, 0xda,
+	0xc9, 0x4a, 0xe4, 0xf5, 0xec, 0x33, 0x58, 0xe1, 0xc8, 0x94, 0xbf, 0x5f, 0xd2, 0x6b, 0xc7, 0xea,
+	0x46, 0x49, 0x88, 0x27, 0x51, 0xe4, 0xf8, 0x64, 0x6f, 0x8d, 0xa0, 0xb0, 0x3f, 0x1e, 0x84, 0xd4,
+	0xb3, 0x8e, 0x70, 0x18, 0x74, 0xc4, 0xea, 0x38, 0xc0, 0xbd, 0x07, 0xd3, 0xac, 0x21, 0x0a, 0x73,
+	0x09, 0xf2, 0x84, 0x40, 0x67, 0x11, 0x26, 0x9d, 0x6b, 0xc7, 0x46, 0x62, 0x35, 0x7b, 0x41, 0xa9,
+	0xd9, 0x4d, 0x28, 0xd0, 0xc4, 0xc8, 0x6e, 0x17, 0x45, 0x87, 0x7d, 0x5b, 0x37, 0x60, 0x9e, 0xce,
+	0x4b, 0x76, 0xdc, 0xc8, 0x25, 0x28, 0x32, 0xcf, 0x41, 0x31, 0xa2, 0xb0, 0x58, 0x4b, 0xd1, 0xa6,
+	0x54, 0x87, 0xe3, 0xac, 0x1f, 0x18, 0x50, 0xdf, 0xed, 0x0f, 0x42, 0x1c, 0x91, 0x47, 0x08, 0xb3,
+	0xd0, 0x79, 0x5b, 0x4b, 0x48, 0xd5, 0x8d, 0x73, 0xb6, 0xce, 0xc0, 0x6f, 0x01, 0x24, 0x6e, 0xcd,
+	0x52, 0x80, 0xf7, 0x55, 0x63, 0xf4, 0x71, 0xf5, 0x7f, 0x5e, 0x75, 0xb3, 0x9f, 0x19, 0x60, 0x26,
+	0x33, 0xc8, 0x10, 0x4a, 0x8b, 0x30, 0x35, 0xe8, 0x5c, 0xb0, 0xd3, 0x3c, 0xe9, 0x98, 0x33, 0x3d,
+	0x4b, 0x55, 0xa6, 0x64, 0x29, 0x7d, 0x6d, 0xaa, 0x5e, 0xbf, 0x36, 0x60, 0x29, 0xa1, 0xc6, 0x15,
+	0xbd, 0xb9, 0xa9, 0xa6, 0x07, 0xae, 0xdc, 0x65, 0x3b, 0x83, 0x71, 0x46, 0xaa, 0xf8, 0xf8, 0x04,
+	0xa9, 0xe2, 0xba, 0xae, 0xe9, 0x52, 0xc6, 0xfa, 0x55, 0x6d, 0xbf, 0x32, 0xa0, 0x99, 0xa1, 0x84,
+	0x74, 0x69, 0x1b, 0x4a, 0x3e, 0xa7, 0x0a, 0x95, 0x97, 0xb3, 0x54, 0x76, 0x24, 0xd3, 0x8b, 0x16,
+	0xb3, 0xd6, 0x3f, 0x0d, 0x80, 0x1d, 0x34, 0xda, 0x76, 0x3d, 0x14, 0xb4, 0xd1, 0xe4, 0x6d, 0x2c,
+	0xaf, 0xbd, 0x5a, 0xf4, 0x91, 0x1b, 0xb4, 0x3a, 0xee, 0x40, 0x74, 0xeb, 0x4b, 0x14, 0x7e, 0xcf,
+	0x1d, 0xd0, 0x62, 0xaf, 0x8f, 0x3c, 0x5f, 0x10, 0xf3, 0x8c, 0x58, 0xe1, 0x18, 0x4a, 0xbe, 0x0c,
+	0xb5, 0x8e, 0x3b, 0x68, 0x75, 0x7d, 0x12, 0x85, 0x1d, 0xec, 0xf6, 0xd9, 0x51, 0xcf, 0x3b, 0xf3,
+	0x1d, 0x77, 0x70, 0x5f, 0xe2, 0x68, 0x37, 0xb2, 0x17, 0xd2, 0x3b, 0x59, 0xd4, 0x12, 0xe9, 0x86,
+	0x44, 0x18, 0xb9, 0x4f, 0xc4, 0x89, 0x59, 0x12, 0xc4, 0x4d, 0x46, 0xdb, 0x63, 0x24, 0xf3, 0x2d,
+	0x58, 0x95, 0x32, 0x7e, 0xa0, 0x4b, 0xf1, 0x27, 0x17, 0x39, 0xe4, 0x6e, 0xe0, 0x2a, 0x72, 0xd6,
+	0x57, 0x39, 0x38, 0x9b, 0xac, 0x79, 0x32, 0xa8, 0x3c, 0x00, 0x88, 0xef, 0x9a, 0x72, 0x13, 0x5e,
+	0xb5, 0xa7, 0xf2, 0xdb, 0xf1, 0xa6, 0x08, 0xf7, 0x51, 0xa4, 0x67, 0x67, 0xd6, 0xf3, 0x00, 0xd4,
+	0x2e, 0xa2, 0x3c, 0xe4, 0x89, 0xb5, 0xd2, 0x71, 0x07, 0x5b, 0x0c, 0x31, 0xf3, 0x2e, 0xd5, 0x7c,
+	0x00, 0x0b, 0x13, 0xf3, 0x66, 0x1c, 0xe5, 0x4b, 0xba, 0x67, 0x56, 0x95, 0x45, 0xa8, 0x1e, 0xf9,
+	0x0c, 0xca, 0x3b, 0x68, 0x74, 0x2f, 0x6c, 0x0f, 0xb5, 0x06, 0x99, 0x11, 0x37, 0xc8, 0xa6, 0x5c,
+	0x45, 0x1a, 0x50, 0x42, 0x41, 0x84, 0xc3, 0xc1, 0x58, 0xec, 0xb9, 0x04, 0x69, 0xb4, 0xeb, 0xf8,
+	0x81, 0xcf, 0xb4, 0x36, 0x1c, 0xf6, 0xcd, 0x46, 0xa6, 0x53, 0xb0, 0x0d, 0x35, 0x1c, 0x0e, 0x58,
+	0xbf, 0x31, 0xe0, 0x8c, 0x9c, 0x9c, 0xe6, 0x09, 0x1a, 0x18, 0x69, 0x51, 0xc0, 0x3a, 0xb1, 0x0d,
+	0x43, 0x14, 0x05, 0x92, 0xc3, 0xe1, 0x78, 0x73, 0x43, 0x2f, 0x9e, 0x5f, 0xb6, 0x27, 0x87, 0xc8,
+	0x08, 0x38, 0xa7, 0xae, 0x44, 0x92, 0x49, 0x13, 0x53, 0x7d, 0x63, 0xc0, 0xaa, 0xc4, 0x4f, 0xfa,
+	0xcd, 0xfd, 0x0c, 0xbf, 0x59, 0xb7, 0xa7, 0x70, 0x3f, 0xbf, 0xd7, 0xcc, 0xac, 0xfd, 0x1f, 0x9d,
+	0xc4, 0x2d, 0xae, 0xe9, 0x2b, 0x5d, 0x4c, 0x59, 0x4f, 0x5d, 0xf1, 0x16, 0xd4, 0x77, 0xd0, 0xe8,
+	0x21, 0xc2, 0x1d, 0x24, 0xfa, 0xf8, 0x2b, 0x30, 0xd7, 0xa7, 0xa0, 0xf4, 0x11, 0x01, 0xf1, 0x9b,
+	0x40, 0x87, 0x3e, 0xf3, 0x24, 0x37, 0x01, 0x06, 0xb2, 0xc4, 0x21, 0x07, 0x71, 0xdc, 0xc8, 0x0f,
+	0xd9, 0x46, 0xa4, 0x13, 0x47, 0x9a, 0xe7, 0x34, 0x89, 0x63, 0xda, 0xf5, 0x46, 0x57, 0x5f, 0x5d,
+	0xdb, 0xbf, 0x0c, 0x78, 0x59, 0x9b, 0x73, 0x72, 0x4b, 0x1f, 0x66, 0x6c, 0xe9, 0x4d, 0x7b, 0x96,
+	0xc8, 0x7f, 0x68, 0x5f, 0x9d, 0x93, 0xec, 0x6b, 0x2a, 0x11, 0xa5, 0xed, 0xa9, 0xae, 0xfe, 0x32,
+	0x54, 0x1e, 0x0d, 0x83, 0x76, 0x97, 0x35, 0x7c, 0xa7, 0x5d, 0x6e, 0xbf, 0xcc, 0x41, 0x23, 0xe6,
+	0xca, 0xb8, 0x90, 0xab, 0xe7, 0x14, 0xec, 0x98, 0x53, 0x1e, 0xd4, 0x5d, 0xcd, 0x80, 0xfc, 0xb4,
+	0x5e, 0xb7, 0xa7, 0x0d, 0x78, 0x72, 0xe3, 0x4d, 0xdc, 0xd6, 0x69, 0x7d, 0x4b, 0x2b, 0xcf, 0x67,
+	0x61, 0x20, 0xcb, 0xae, 0x18, 0x6e, 0xee, 0x9e, 0xc4, 0x76, 0xa9, 0x42, 0x5b, 0x59, 0x4a, 0x62,
+	0xb2, 0x1f, 0x51, 0x47, 0x16, 0x1d, 0x84, 0x71, 0xf2, 0x48, 0xf7, 0x86, 0x6c, 0x81, 0xc4, 0x8e,
+	0x9c, 0xe2, 0x61, 0x45, 0xb5, 0x74, 0x64, 0xc6, 0x4c, 0x1f, 0x60, 0x13, 0xe4, 0xa9, 0x0a, 0xb1,
+	0x3f, 0x1a, 0xb0, 0xc2, 0xee, 0x49, 0x69, 0x55, 0x1e, 0xe8, 0x1d, 0x10, 0x19, 0x85, 0xb2, 0xb9,
+	0x63, 0x3d, 0x7d, 0xa9, 0x9a, 0x2a, 0xdc, 0xdc, 0x83, 0x33, 0x93, 0x0c, 0x27, 0x29, 0x7f, 0xd2,
+	0xf3, 0xa8, 0xba, 0x7f, 0x99, 0x83, 0x4b, 0x69, 0x8e, 0x49, 0xcf, 0xda, 0xd6, 0x43, 0xc3, 0x4d,
+	0xfb, 0x58, 0x91, 0xd3, 0x5e, 0x6b, 0x97, 0xa1, 0xe8, 0xa1, 0x41, 0xd4, 0x15, 0xd7, 0x11, 0x0e,
+	0xcc, 0xce, 0xb9, 0x1f, 0x1f, 0x13, 0x79, 0x6e, 0xea, 0x96, 0x58, 0x9d, 0x62, 0x75, 0xd5, 0x1a,
+	0xbf, 0x63, 0x2f, 0x4f, 0x1e, 0xda, 0xec, 0xa0, 0xf4, 0x5d, 0xbe, 0xa0, 0x14, 0xae, 0x97, 0xec,
+	0x6c, 0x36, 0x7b, 0x33, 0x2e, 0x5b, 0x19, 0xbb, 0xf9, 0xbe, 0x78, 0xb0, 0xe2, 0xe5, 0x97, 0x3c,
+	0x73, 0xeb, 0xd3, 0xc4, 0xe9, 0x3d, 0xeb, 0x21, 0x67, 0x15, 0x1e, 0x70, 0x98, 0x60, 0x66, 0xc7,
+	0xa4, 0xff, 0x81, 0xca, 0x66, 0xe7, 0x39, 0xdc, 0xb7, 0xf9, 0x7f, 0x70, 0x66, 0x72, 0xda, 0xd3,
+	0xfc, 0x1a, 0x62, 0xfd, 0xdc, 0x80, 0xc6, 0x3e, 0x22, 0x51, 0x66, 0xc8, 0x3e, 0x0f, 0x10, 0xd1,
+	0x82, 0x30, 0x39, 0x90, 0x79, 0xa7, 0x42, 0x31, 0xfc, 0x79, 0xec, 0x3a, 0x9c, 0x19, 0xe0, 0xd0,
+	0x1b, 0xb2, 0x77, 0xf9, 0x96, 0x6c, 0x5c, 0x52, 0xa6, 0x85, 0x04, 0xcf, 0x59, 0x57, 0x60, 0x0e,
+	0xd3, 0x19, 0x78, 0x69, 0x66, 0x38, 0x02, 0x9a, 0xdd, 0xe3, 0x1e, 0x80, 0x29, 0x7a, 0x03, 0x4c,
+	0xbb, 0x3d, 0xd6, 0x9c, 0x95, 0x55, 0x35, 0x0a, 0x22, 0xb5, 0xaa, 0x46, 0x01, 0xbb, 0x2b, 0xb6,
+	0x43, 0x0f, 0x09, 0x1d, 0xd8, 0x37, 0x5d, 0xf9, 0x41, 0xcf, 0x0d, 0x9e, 0x88, 0x0e, 0x2f, 0x07,
+	0x14, 0x75, 0x0a, 0xaa, 0x3a, 0xb4, 0xfd, 0x78, 0x4e, 0x9d, 0x72, 0xd2, 0x20, 0xbb, 0xe9, 0x5b,
+	0xd0, 0x0d, 0x7b, 0x86, 0xc0, 0xf4, 0xdb, 0xd0, 0xcc, 0x46, 0xf1, 0xf3, 0x5d, 0x95, 0xd2, 0xb6,
+	0x52, 0x37, 0xfa, 0x17, 0x06, 0x2c, 0x7e, 0x80, 0x5c, 0x8f, 0xd6, 0x25, 0xc9, 0x4d, 0xe1, 0x2d,
+	0xf6, 0xf6, 0xe0, 0x8e, 0x93, 0x70, 0x9b, 0xe2, 0xb1, 0x77, 0x18, 0x83, 0xb8, 0xf9, 0x72, 0x6e,
+	0x9a, 0x23, 0x86, 0x41, 0xe4, 0x76, 0x3a, 0xa2, 0x55, 0x99, 0x77, 0x62, 0x98, 0xde, 0x8a, 0x15,
+	0x91, 0x53, 0x05, 0xe3, 0xef, 0xc0, 0xaa, 0x9c, 0x7f, 0xd2, 0xf4, 0xeb, 0x7a, 0x14, 0x33, 0xd3,
+	0x8a, 0x66, 0x36, 0x74, 0x27, 0x5b, 0xf0, 0xdf, 0x18, 0x30, 0x4f, 0x87, 0x64, 0x5d, 0x07, 0xf1,
+	0x6b, 0x5b, 0xaa, 0x0d, 0x7f, 0x19, 0x6a, 0x1e, 0xea, 0x21, 0xe6, 0xd6, 0x54, 0x52, 0xfe, 0x91,
+	0x24, 0x91, 0xac, 0x63, 0x70, 0x0d, 0x16, 0x62, 0x26, 0xad, 0x1b, 0x53, 0x97, 0x68, 0xfe, 0xbb,
+	0x87, 0x79, 0x03, 0x16, 0xb1, 0x32, 0x23, 0x1f, 0xb1, 0xc0, 0x58, 0xcf, 0xa8, 0x04, 0x36, 0xea,
+	0x2d, 0x58, 0xd2, 0x98, 0xc5, 0xc8, 0xfc, 0xe2, 0x66, 0xaa, 0x24, 0x31, 0xfa, 0x45, 0xa8, 0x62,
+	0x44, 0xfb, 0x52, 0xad, 0x03, 0xb7, 0xcd, 0xef, 0x6a, 0x65, 0x07, 0x38, 0x6a, 0xcb, 0x6d, 0x3f,
+	0xb1, 0x7e, 0x62, 0xc0, 0x39, 0x75, 0xc5, 0x93, 0x86, 0xbd, 0x0d, 0x35, 0x75, 0x58, 0x69, 0xe0,
+	0x9a, 0xad, 0x0a, 0x39, 0x3a, 0xcf, 0x0b, 0xdf, 0x94, 0xc7, 0xb0, 0x18, 0xc7, 0xf0, 0x7d, 0xec,
+	0x06, 0xe4, 0x10, 0x65, 0xbf, 0x88, 0xc8, 0x87, 0xad, 0x9c, 0xf2, 0xb0, 0x45, 0xdf, 0xed, 0x71,
+	0xd8, 0xd7, 0xad, 0x0e, 0x14, 0x25, 0x6c, 0x42, 0xa7, 0x0e, 0x25, 0x99, 0x5b, 0xba, 0x1c, 0x85,
+	0x9c, 0x68, 0xfd, 0xd4, 0x80, 0xb5, 0xd4, 0xdc, 0x93, 0x46, 0x79, 0x1d, 0x2a, 0x91, 0x20, 0x25,
+	0x1e, 0x97, 0x92, 0x72, 0x12, 0xa6, 0x17, 0xb6, 0xc8, 0x17, 0x06, 0xd4, 0x1c, 0xc4, 0xdf, 0x78,
+	0xde, 0x1d, 0xd1, 0x70, 0x96, 0xb4, 0xc4, 0x8c, 0xc9, 0x96, 0x58, 0xca, 0x24, 0xec, 0xbe, 0x40,
+	0xb7, 0x5c, 0xfe, 0x14, 0x27, 0xc1, 0xe4, 0xe5, 0x80, 0x08, 0x4b, 0x48, 0x50, 0xe9, 0x22, 0x16,
+	0xb5, 0xf7, 0xc1, 0x1f, 0x1b, 0xb0, 0x26, 0x35, 0xd9, 0x41, 0x51, 0xb6, 0xd7, 0xbc, 0x02, 0x73,
+	0x68, 0x84, 0x92, 0x7e, 0x59, 0xdd, 0xd6, 0x94, 0x77, 0x04, 0xf5, 0x85, 0xcd, 0xf2, 0x19, 0x9c,
+	0xe7, 0x2d, 0x1b, 0xf6, 0x9b, 0x87, 0x1f, 0x74, 0x32, 0x5e, 0x5b, 0x93, 0x87, 0xac, 0x8a, 0xbc,
+	0x64, 0x5f, 0x4b, 0x3a, 0x3f, 0xb9, 0xac, 0xff, 0x40, 0x24, 0xd5, 0xfa, 0x2e, 0x7f, 0x95, 0xe1,
+	0x16, 0x3f, 0xc5, 0x2b, 0x6a, 0xe6, 0xcf, 0x04, 0xf1, 0xab, 0x4e, 0x61, 0xca, 0xab, 0x4e, 0x51,
+	0x7b, 0xd5, 0xb1, 0xbe, 0x0d, 0x67, 0xe3, 0xc9, 0x33, 0xfa, 0xb1, 0xba, 0xe5, 0x8c, 0x63, 0x2c,
+	0x37, 0x19, 0xe9, 0x7e, 0x6b, 0xc0, 0x42, 0x7a, 0xcc, 0xb9, 0x2e, 0x72, 0x3d, 0x84, 0xe3, 0x6e,
+	0x80, 0xfc, 0xf5, 0xd4, 0x11, 0x04, 0xf3, 0x2e, 0x6d, 0xfe, 0x07, 0x51, 0xdc, 0xfc, 0xa7, 0x39,
+	0x61, 0x32, 0xab, 0x6d, 0x0b, 0x86, 0xf8, 0x8f, 0x08, 0x0e, 0xf2, 0x3f, 0x22, 0x14, 0xd2, 0x71,
+	0x95, 0xc8, 0xbc, 0x12, 0xfb, 0x0f, 0xe6, 0xd8, 0x1f, 0xc9, 0xb7, 0xff, 0x3d, 0x00, 0xcd, 0xc7,
+	0x4a, 0x2e, 0x9d, 0x2c, 0x00, 0x00,
 }
@@ -95,3 +95,32 @@ func MapToCompressedSparseRowMatrix(matrix []map[int]int64) *CompressedSparseRow
 	}
 	return &r
 }
+
+// MapToCompressedSparseRowFloatMatrix takes a floating point matrix in DOK format and converts
+// it to a Protobuf CSR. It is the floating point twin of MapToCompressedSparseRowMatrix.
+// CSR format: https://en.wikipedia.org/wiki/Sparse_matrix#Compressed_sparse_row_.28CSR.2C_CRS_or_Yale_format.29
+func MapToCompressedSparseRowFloatMatrix(matrix []map[int]float32) *CompressedSparseRowFloatMatrix {
+	r := CompressedSparseRowFloatMatrix{
+		NumberOfRows:    int32(len(matrix)),
+		NumberOfColumns: int32(len(matrix)),
+		Data:            make([]float32, 0),
+		Indices:         make([]int32, 0),
+		Indptr:          make([]int64, 1),
+	}
+	r.Indptr[0] = 0
+	for _, row := range matrix {
+		order := make([]int, len(row))
+		i := 0
+		for col := range row {
+			order[i] = col
+			i++
+		}
+		sort.Ints(order)
+		for _, col := range order {
+			r.Data = append(r.Data, row[col])
+			r.Indices = append(r.Indices, int32(col))
+		}
+		r.Indptr = append(r.Indptr, r.Indptr[len(r.Indptr)-1]+int64(len(row)))
+	}
+	return &r
+}
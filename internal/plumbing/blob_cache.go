@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"runtime"
+	"sync"
 
 	"github.com/pkg/errors"
 	"gopkg.in/src-d/go-git.v4"
@@ -24,6 +26,11 @@ type CachedBlob struct {
 	object.Blob
 	// Data is the read contents of the blob object.
 	Data []byte
+
+	// lines and linesErr are the memoized results of CountLines(), valid if linesCounted is true.
+	lines        int
+	linesErr     error
+	linesCounted bool
 }
 
 // Reader returns a reader allow the access to the content of the blob
@@ -49,11 +56,28 @@ func (b *CachedBlob) Cache() error {
 			b.Hash.String(), size, b.Size)
 	}
 	b.Data = buf.Bytes()
+	b.linesCounted = false
 	return nil
 }
 
 // CountLines returns the number of lines in the blob or (0, ErrorBinary) if it is binary.
+// BlobCache precomputes the result for every blob it loads.
 func (b *CachedBlob) CountLines() (int, error) {
+	if b.linesCounted {
+		return b.lines, b.linesErr
+	}
+	return b.countLines()
+}
+
+// precountLines memoizes the result of CountLines(). The blob must not be modified afterwards.
+func (b *CachedBlob) precountLines() {
+	if !b.linesCounted {
+		b.lines, b.linesErr = b.countLines()
+		b.linesCounted = true
+	}
+}
+
+func (b *CachedBlob) countLines() (int, error) {
 	if len(b.Data) == 0 {
 		return 0, nil
 	}
@@ -78,14 +102,21 @@ func (b *CachedBlob) CountLines() (int, error) {
 // It must provide the old and the new objects; "blobCache" rotates and allows to not load
 // the same blobs twice. Outdated objects are removed so "blobCache" never grows big.
 type BlobCache struct {
-	core.NoopMerger
 	// Specifies how to handle the situation when we encounter a git submodule - an object
 	// without the blob. If true, we look inside .gitmodules and if we don't find it,
 	// raise an error. If false, we do not look inside .gitmodules and always succeed.
 	FailOnMissingSubmodules bool
+	// Mmap enables storing the blob contents in temporary memory-mapped files instead of
+	// the Go heap. It reduces the peak memory usage if there are huge blobs.
+	Mmap bool
 
 	repository *git.Repository
 	cache      map[plumbing.Hash]*CachedBlob
+	// mmapStorage is shared among the forks and is nil if Mmap is disabled or unavailable.
+	mmapStorage *mmapBlobStorage
+	// lastResult is the cache returned by the previous Consume(); its mapped blobs are
+	// referenced until the next Consume().
+	lastResult map[plumbing.Hash]*CachedBlob
 
 	l core.Logger
 }
@@ -94,6 +125,9 @@ const (
 	// ConfigBlobCacheFailOnMissingSubmodules is the name of the configuration option for
 	// BlobCache.Configure() to check if the referenced submodules are registered in .gitignore.
 	ConfigBlobCacheFailOnMissingSubmodules = "BlobCache.FailOnMissingSubmodules"
+	// ConfigBlobCacheMmap is the name of the configuration option for BlobCache.Configure()
+	// to store the blob contents in temporary memory-mapped files.
+	ConfigBlobCacheMmap = "BlobCache.Mmap"
 	// DependencyBlobCache identifies the dependency provided by BlobCache.
 	DependencyBlobCache = "blob_cache"
 )
@@ -126,6 +160,12 @@ func (blobCache *BlobCache) ListConfigurationOptions() []core.ConfigurationOptio
 			"Override this if you want to ensure that your repository is integral.",
 		Flag:    "fail-on-missing-submodules",
 		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name: ConfigBlobCacheMmap,
+		Description: "Store the contents of the blobs in temporary memory-mapped files " +
+			"to reduce the memory usage on repositories with huge files.",
+		Flag:    "blob-cache-mmap",
+		Type:    core.BoolConfigurationOption,
 		Default: false}}
 	return options[:]
 }
@@ -140,15 +180,30 @@ func (blobCache *BlobCache) Configure(facts map[string]interface{}) error {
 	if val, exists := facts[ConfigBlobCacheFailOnMissingSubmodules].(bool); exists {
 		blobCache.FailOnMissingSubmodules = val
 	}
+	if val, exists := facts[ConfigBlobCacheMmap].(bool); exists {
+		blobCache.Mmap = val
+	}
 	return nil
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (blobCache *BlobCache) Initialize(repository *git.Repository) error {
-	blobCache.l = core.NewLogger()
+	if blobCache.l == nil {
+		blobCache.l = core.NewLogger()
+	}
 	blobCache.repository = repository
 	blobCache.cache = map[plumbing.Hash]*CachedBlob{}
+	blobCache.lastResult = nil
+	blobCache.mmapStorage = nil
+	if blobCache.Mmap {
+		storage, err := newMmapBlobStorage()
+		if err != nil {
+			blobCache.l.Warnf("falling back to the in-memory blob cache: %v\n", err)
+		} else {
+			blobCache.mmapStorage = storage
+		}
+	}
 	return nil
 }
 
@@ -180,7 +235,7 @@ func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]int
 				blobCache.l.Errorf("file to %s %s: %v\n", change.To.Name, change.To.TreeEntry.Hash, err)
 			} else {
 				cb := &CachedBlob{Blob: *blob}
-				err = cb.Cache()
+				err = blobCache.cacheBlob(cb)
 				if err == nil {
 					cache[change.To.TreeEntry.Hash] = cb
 					newCache[change.To.TreeEntry.Hash] = cb
@@ -204,7 +259,7 @@ func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]int
 					}
 				} else {
 					cb := &CachedBlob{Blob: *blob}
-					err = cb.Cache()
+					err = blobCache.cacheBlob(cb)
 					if err == nil {
 						cache[change.From.TreeEntry.Hash] = cb
 					} else {
@@ -221,7 +276,7 @@ func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]int
 				blobCache.l.Errorf("file to %s: %v\n", change.To.Name, err)
 			} else {
 				cb := &CachedBlob{Blob: *blob}
-				err = cb.Cache()
+				err = blobCache.cacheBlob(cb)
 				if err == nil {
 					cache[change.To.TreeEntry.Hash] = cb
 					newCache[change.To.TreeEntry.Hash] = cb
@@ -238,7 +293,7 @@ func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]int
 					blobCache.l.Errorf("file from %s: %v\n", change.From.Name, err)
 				} else {
 					cb := &CachedBlob{Blob: *blob}
-					err = cb.Cache()
+					err = blobCache.cacheBlob(cb)
 					if err == nil {
 						cache[change.From.TreeEntry.Hash] = cb
 					} else {
@@ -251,10 +306,69 @@ func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]int
 			return nil, err
 		}
 	}
+	precountLines(cache, runtime.GOMAXPROCS(0))
+	if blobCache.mmapStorage != nil {
+		blobCache.mmapStorage.acquire(cache)
+		blobCache.mmapStorage.acquire(newCache)
+		for _, prev := range [...]map[plumbing.Hash]*CachedBlob{blobCache.lastResult, blobCache.cache} {
+			if err := blobCache.mmapStorage.release(prev); err != nil {
+				return nil, err
+			}
+		}
+		blobCache.lastResult = cache
+	}
 	blobCache.cache = newCache
 	return map[string]interface{}{DependencyBlobCache: cache}, nil
 }
 
+// cacheBlob loads the contents of the blob either to the memory or to a memory-mapped file.
+func (blobCache *BlobCache) cacheBlob(blob *CachedBlob) error {
+	if blobCache.mmapStorage != nil {
+		return blobCache.mmapStorage.load(blob)
+	}
+	return blob.Cache()
+}
+
+// Dispose unmaps the memory-mapped blobs and removes the temporary files.
+func (blobCache *BlobCache) Dispose() {
+	if blobCache.mmapStorage == nil {
+		return
+	}
+	if err := blobCache.mmapStorage.dispose(); err != nil {
+		blobCache.l.Errorf("failed to dispose the memory-mapped blobs: %v\n", err)
+	}
+	blobCache.mmapStorage = nil
+	blobCache.cache = map[plumbing.Hash]*CachedBlob{}
+	blobCache.lastResult = nil
+}
+
+// precountLines calls CachedBlob.precountLines() on every blob in the cache using the
+// specified number of parallel workers. The blobs are unique by hash, and those which were
+// carried over from the previous commit are already counted, so the work is never repeated.
+func precountLines(cache map[plumbing.Hash]*CachedBlob, workers int) {
+	blobs := make(chan *CachedBlob, len(cache))
+	for _, blob := range cache {
+		if !blob.linesCounted {
+			blobs <- blob
+		}
+	}
+	close(blobs)
+	if workers > len(blobs) {
+		workers = len(blobs)
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for blob := range blobs {
+				blob.precountLines()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 // Fork clones this PipelineItem.
 func (blobCache *BlobCache) Fork(n int) []core.PipelineItem {
 	caches := make([]core.PipelineItem, n)
@@ -263,15 +377,59 @@ func (blobCache *BlobCache) Fork(n int) []core.PipelineItem {
 		for k, v := range blobCache.cache {
 			cache[k] = v
 		}
+		if blobCache.mmapStorage != nil {
+			blobCache.mmapStorage.acquire(cache)
+		}
 		caches[i] = &BlobCache{
 			FailOnMissingSubmodules: blobCache.FailOnMissingSubmodules,
+			Mmap:                    blobCache.Mmap,
 			repository:              blobCache.repository,
 			cache:                   cache,
+			mmapStorage:             blobCache.mmapStorage,
+			l:                       blobCache.l,
 		}
 	}
 	return caches
 }
 
+// Merge combines the caches of the branches which have consumed the same merge commit and
+// releases the other branches: the pipeline drops them after the merge.
+func (blobCache *BlobCache) Merge(branches []core.PipelineItem) {
+	adopted := map[plumbing.Hash]*CachedBlob{}
+	for _, branch := range branches {
+		for hash, blob := range branch.(*BlobCache).cache {
+			if _, exists := blobCache.cache[hash]; !exists {
+				if _, exists = adopted[hash]; !exists {
+					adopted[hash] = blob
+				}
+			}
+		}
+	}
+	if blobCache.mmapStorage != nil {
+		blobCache.mmapStorage.acquire(adopted)
+	}
+	for hash, blob := range adopted {
+		blobCache.cache[hash] = blob
+	}
+	for _, branch := range branches {
+		branch.(*BlobCache).Release()
+	}
+}
+
+// Release drops the references of this fork to the memory-mapped blobs, so that they are unmapped
+// as soon as no other fork needs them. The fork continues with an empty cache.
+func (blobCache *BlobCache) Release() {
+	if blobCache.mmapStorage != nil {
+		for _, prev := range [...]map[plumbing.Hash]*CachedBlob{blobCache.lastResult, blobCache.cache} {
+			if err := blobCache.mmapStorage.release(prev); err != nil {
+				blobCache.l.Errorf("failed to release the memory-mapped blobs: %v\n", err)
+			}
+		}
+	}
+	blobCache.cache = map[plumbing.Hash]*CachedBlob{}
+	blobCache.lastResult = nil
+}
+
 // FileGetter defines a function which loads the Git file by
 // the specified path. The state can be arbitrary though here it always
 // corresponds to the currently processed commit.
@@ -1,6 +1,7 @@
 package plumbing
 
 import (
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -33,11 +34,14 @@ func TestBlobCacheConfigureInitialize(t *testing.T) {
 	assert.False(t, cache.FailOnMissingSubmodules)
 	facts := map[string]interface{}{}
 	facts[ConfigBlobCacheFailOnMissingSubmodules] = true
+	facts[ConfigBlobCacheMmap] = true
 	cache.Configure(facts)
 	assert.True(t, cache.FailOnMissingSubmodules)
+	assert.True(t, cache.Mmap)
 	facts = map[string]interface{}{}
 	cache.Configure(facts)
 	assert.True(t, cache.FailOnMissingSubmodules)
+	assert.True(t, cache.Mmap)
 }
 
 func TestBlobCacheMetadata(t *testing.T) {
@@ -49,8 +53,9 @@ func TestBlobCacheMetadata(t *testing.T) {
 	changes := &TreeDiff{}
 	assert.Equal(t, cache.Requires()[0], changes.Provides()[0])
 	opts := cache.ListConfigurationOptions()
-	assert.Len(t, opts, 1)
+	assert.Len(t, opts, 2)
 	assert.Equal(t, opts[0].Name, ConfigBlobCacheFailOnMissingSubmodules)
+	assert.Equal(t, opts[1].Name, ConfigBlobCacheMmap)
 }
 
 func TestBlobCacheRegistration(t *testing.T) {
@@ -444,6 +449,62 @@ func TestBlobCacheFork(t *testing.T) {
 	assert.Len(t, cache1.cache, 2)
 	assert.Len(t, cache2.cache, 1)
 	assert.Equal(t, cache1.cache[hash].Size, cache2.cache[hash].Size)
-	// just for the sake of it
 	cache1.Merge([]core.PipelineItem{cache2})
+	assert.Len(t, cache1.cache, 2)
+	assert.Len(t, cache2.cache, 0)
+}
+
+func TestCachedBlobPrecountLines(t *testing.T) {
+	cache := map[plumbing.Hash]*CachedBlob{}
+	for i, data := range []string{"one\ntwo\n", "one\ntwo", "", "\x00binary"} {
+		var hash plumbing.Hash
+		hash[0] = byte(i + 1)
+		cache[hash] = &CachedBlob{Data: []byte(data)}
+	}
+	precountLines(cache, 3)
+	for _, blob := range cache {
+		assert.True(t, blob.linesCounted)
+		lines, err := blob.countLines()
+		assert.Equal(t, lines, blob.lines)
+		assert.Equal(t, err, blob.linesErr)
+		memoLines, memoErr := blob.CountLines()
+		assert.Equal(t, lines, memoLines)
+		assert.Equal(t, err, memoErr)
+	}
+	precountLines(map[plumbing.Hash]*CachedBlob{}, 4)
+}
+
+// fixtureManyBlobs generates the blob cache of a commit which adds many files.
+func fixtureManyBlobs() map[plumbing.Hash]*CachedBlob {
+	cache := map[plumbing.Hash]*CachedBlob{}
+	line := []byte("\tfmt.Println(\"the quick brown fox jumps over the lazy dog\")\n")
+	for i := 0; i < 500; i++ {
+		var hash plumbing.Hash
+		hash[0], hash[1] = byte(i), byte(i>>8)
+		data := make([]byte, 0, len(line)*(1000+i))
+		for j := 0; j < 1000+i; j++ {
+			data = append(data, line...)
+		}
+		cache[hash] = &CachedBlob{Data: data}
+	}
+	return cache
+}
+
+func benchmarkPrecountLines(b *testing.B, workers int) {
+	cache := fixtureManyBlobs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, blob := range cache {
+			blob.linesCounted = false
+		}
+		precountLines(cache, workers)
+	}
+}
+
+func BenchmarkPrecountLinesSequential(b *testing.B) {
+	benchmarkPrecountLines(b, 1)
+}
+
+func BenchmarkPrecountLinesParallel(b *testing.B) {
+	benchmarkPrecountLines(b, runtime.GOMAXPROCS(0))
 }
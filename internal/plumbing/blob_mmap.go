@@ -0,0 +1,142 @@
+package plumbing
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/src-d/go-git.v4/plumbing"
+)
+
+// mmapBlobStorage keeps the contents of CachedBlob-s in temporary memory-mapped files instead of
+// the Go heap, so that the kernel is able to evict the pages of huge blobs. The mapped blobs are
+// reference counted by the BlobCache forks which hold them and unmapped when nobody needs them.
+type mmapBlobStorage struct {
+	// dir is the temporary directory for the mapped files.
+	dir  string
+	lock sync.Mutex
+	// refs maps the mapped blobs to the number of BlobCache-s which reference them.
+	refs map[*CachedBlob]int
+}
+
+// newMmapBlobStorage creates the temporary directory and checks that mmap works.
+func newMmapBlobStorage() (*mmapBlobStorage, error) {
+	dir, err := ioutil.TempDir("", "hercules-blobs-")
+	if err != nil {
+		return nil, err
+	}
+	storage := &mmapBlobStorage{dir: dir, refs: map[*CachedBlob]int{}}
+	data, err := storage.mapReader(strings.NewReader("\n"), 1)
+	if err == nil {
+		err = munmapFile(data)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return storage, nil
+}
+
+// load reads the underlying blob object and maps the contents to CachedBlob.Data.
+// It is the memory-mapped equivalent of CachedBlob.Cache().
+func (storage *mmapBlobStorage) load(blob *CachedBlob) error {
+	if blob.Size == 0 {
+		// nothing to map
+		return blob.Cache()
+	}
+	reader, err := blob.Blob.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	data, err := storage.mapReader(reader, blob.Size)
+	if err != nil {
+		return fmt.Errorf("%s: %v", blob.Hash.String(), err)
+	}
+	blob.Data = data
+	blob.linesCounted = false
+	storage.lock.Lock()
+	storage.refs[blob] = 0
+	storage.lock.Unlock()
+	return nil
+}
+
+// mapReader copies `size` bytes from `reader` to a new temporary file and maps it.
+func (storage *mmapBlobStorage) mapReader(reader io.Reader, size int64) ([]byte, error) {
+	file, err := ioutil.TempFile(storage.dir, "blob-")
+	if err != nil {
+		return nil, err
+	}
+	// the mapping outlives the file
+	defer os.Remove(file.Name())
+	defer file.Close()
+	written, err := io.Copy(file, reader)
+	if err != nil {
+		return nil, err
+	}
+	if written != size {
+		return nil, fmt.Errorf("incomplete read: %d while the declared size is %d", written, size)
+	}
+	return mmapFile(file.Fd(), int(size))
+}
+
+// acquire increments the reference counters of the mapped blobs in `cache`.
+func (storage *mmapBlobStorage) acquire(cache map[plumbing.Hash]*CachedBlob) {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	for _, blob := range cache {
+		if n, exists := storage.refs[blob]; exists {
+			storage.refs[blob] = n + 1
+		}
+	}
+}
+
+// release decrements the reference counters of the mapped blobs in `cache` and unmaps
+// those which are no longer referenced.
+func (storage *mmapBlobStorage) release(cache map[plumbing.Hash]*CachedBlob) error {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	var firstErr error
+	for _, blob := range cache {
+		n, exists := storage.refs[blob]
+		if !exists {
+			continue
+		}
+		if n > 1 {
+			storage.refs[blob] = n - 1
+			continue
+		}
+		delete(storage.refs, blob)
+		if err := storage.unmap(blob); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// dispose unmaps all the remaining blobs and deletes the temporary directory.
+func (storage *mmapBlobStorage) dispose() error {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	var firstErr error
+	for blob := range storage.refs {
+		if err := storage.unmap(blob); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	storage.refs = map[*CachedBlob]int{}
+	if err := os.RemoveAll(storage.dir); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+func (storage *mmapBlobStorage) unmap(blob *CachedBlob) error {
+	data := blob.Data
+	// better fail with an empty blob than with a segmentation fault
+	blob.Data = nil
+	return munmapFile(data)
+}
@@ -0,0 +1,19 @@
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
+
+package plumbing
+
+import (
+	"errors"
+)
+
+var errMmapUnsupported = errors.New("mmap is not supported on this platform")
+
+// mmapFile always fails on this platform, so BlobCache falls back to the in-memory storage.
+func mmapFile(fd uintptr, size int) ([]byte, error) {
+	return nil, errMmapUnsupported
+}
+
+// munmapFile is never called on this platform.
+func munmapFile(data []byte) error {
+	return errMmapUnsupported
+}
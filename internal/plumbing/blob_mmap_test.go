@@ -0,0 +1,171 @@
+package plumbing
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+)
+
+func storeTestBlob(t *testing.T, repo *git.Repository, data string) plumbing.Hash {
+	obj := repo.Storer.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	writer, err := obj.Writer()
+	require.NoError(t, err)
+	_, err = writer.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	hash, err := repo.Storer.SetEncodedObject(obj)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestMmapBlobStorage(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	require.NoError(t, err)
+	storage, err := newMmapBlobStorage()
+	if err != nil {
+		t.Skipf("mmap is not available: %v", err)
+	}
+	blobOf := func(data string) *CachedBlob {
+		blob, err := repo.BlobObject(storeTestBlob(t, repo, data))
+		require.NoError(t, err)
+		return &CachedBlob{Blob: *blob}
+	}
+	blob1, blob2, empty := blobOf("one\ntwo\n"), blobOf("three"), blobOf("")
+	for _, blob := range []*CachedBlob{blob1, blob2, empty} {
+		assert.NoError(t, storage.load(blob))
+	}
+	assert.Equal(t, "one\ntwo\n", string(blob1.Data))
+	assert.Equal(t, "three", string(blob2.Data))
+	assert.Len(t, empty.Data, 0)
+	lines, err := blob1.CountLines()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lines)
+	assert.Len(t, storage.refs, 2)
+
+	cache1 := map[plumbing.Hash]*CachedBlob{
+		blob1.Hash: blob1, blob2.Hash: blob2, empty.Hash: empty}
+	cache2 := map[plumbing.Hash]*CachedBlob{blob2.Hash: blob2}
+	storage.acquire(cache1)
+	storage.acquire(cache2)
+	assert.Equal(t, map[*CachedBlob]int{blob1: 1, blob2: 2}, storage.refs)
+	assert.NoError(t, storage.release(cache1))
+	assert.Nil(t, blob1.Data)
+	assert.Equal(t, "three", string(blob2.Data))
+	assert.Equal(t, map[*CachedBlob]int{blob2: 1}, storage.refs)
+	files, err := ioutil.ReadDir(storage.dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 0)
+	assert.NoError(t, storage.dispose())
+	assert.Nil(t, blob2.Data)
+	assert.Len(t, storage.refs, 0)
+	_, err = os.Stat(storage.dir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBlobCacheMmapConsume(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	require.NoError(t, err)
+	hash1 := storeTestBlob(t, repo, "one\n")
+	hash2 := storeTestBlob(t, repo, "one\ntwo\n")
+	hash3 := storeTestBlob(t, repo, "three\n")
+	blobCache := &BlobCache{Mmap: true}
+	require.NoError(t, blobCache.Initialize(repo))
+	if blobCache.mmapStorage == nil {
+		t.Skip("mmap is not supported")
+	}
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: hash}}
+	}
+	consume := func(blobCache *BlobCache, changes ...*object.Change) map[plumbing.Hash]*CachedBlob {
+		result, err := blobCache.Consume(map[string]interface{}{
+			core.DependencyCommit: &object.Commit{},
+			DependencyTreeChanges: object.Changes(changes),
+		})
+		require.NoError(t, err)
+		return result[DependencyBlobCache].(map[plumbing.Hash]*CachedBlob)
+	}
+	cache := consume(blobCache, &object.Change{To: entry("file", hash1)})
+	first := cache[hash1]
+	assert.Equal(t, "one\n", string(first.Data))
+	forked := blobCache.Fork(1)[0].(*BlobCache)
+	cache = consume(blobCache,
+		&object.Change{From: entry("file", hash1), To: entry("file", hash2)})
+	assert.Equal(t, "one\n", string(cache[hash1].Data))
+	assert.Equal(t, "one\ntwo\n", string(cache[hash2].Data))
+	consume(blobCache, &object.Change{To: entry("other", hash3)})
+	// the fork still references the first blob
+	assert.Equal(t, "one\n", string(first.Data))
+	cache = consume(forked, &object.Change{From: entry("file", hash1)})
+	assert.Equal(t, "one\n", string(cache[hash1].Data))
+	consume(forked, &object.Change{To: entry("other", hash3)})
+	assert.Nil(t, first.Data)
+	storage := blobCache.mmapStorage
+	blobCache.Dispose()
+	assert.Len(t, storage.refs, 0)
+	assert.Nil(t, blobCache.mmapStorage)
+	_, err = os.Stat(storage.dir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBlobCacheMmapMergeRelease(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	require.NoError(t, err)
+	hash1 := storeTestBlob(t, repo, "one\n")
+	hash2 := storeTestBlob(t, repo, "two\n")
+	hash3 := storeTestBlob(t, repo, "three\n")
+	blobCache := &BlobCache{Mmap: true}
+	require.NoError(t, blobCache.Initialize(repo))
+	if blobCache.mmapStorage == nil {
+		t.Skip("mmap is not supported")
+	}
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: hash}}
+	}
+	consume := func(blobCache *BlobCache, changes ...*object.Change) map[plumbing.Hash]*CachedBlob {
+		result, err := blobCache.Consume(map[string]interface{}{
+			core.DependencyCommit: &object.Commit{},
+			DependencyTreeChanges: object.Changes(changes),
+		})
+		require.NoError(t, err)
+		return result[DependencyBlobCache].(map[plumbing.Hash]*CachedBlob)
+	}
+	first := consume(blobCache, &object.Change{To: entry("one", hash1)})[hash1]
+	storage := blobCache.mmapStorage
+	// the last result and the cache
+	assert.Equal(t, 2, storage.refs[first])
+	forks := blobCache.Fork(2)
+	assert.Equal(t, 4, storage.refs[first])
+	// the discarded fork releases its references
+	forks[1].(*BlobCache).Release()
+	assert.Equal(t, 3, storage.refs[first])
+	assert.Len(t, forks[1].(*BlobCache).cache, 0)
+	forked := forks[0].(*BlobCache)
+	consume(blobCache, &object.Change{To: entry("two", hash2)})
+	assert.Equal(t, 1, storage.refs[first])
+	second := consume(forked, &object.Change{To: entry("three", hash3)})[hash3]
+	assert.Nil(t, first.Data)
+	assert.Equal(t, 2, storage.refs[second])
+	blobCache.Merge([]core.PipelineItem{forked})
+	assert.Len(t, blobCache.cache, 2)
+	assert.Equal(t, second, blobCache.cache[hash3])
+	assert.Len(t, forked.cache, 0)
+	assert.Nil(t, forked.lastResult)
+	// the merged branch adopts the blob of the dropped one
+	assert.Equal(t, 1, storage.refs[second])
+	assert.Equal(t, "three\n", string(second.Data))
+	consume(blobCache, &object.Change{To: entry("four", hash1)})
+	assert.Nil(t, second.Data)
+	assert.Len(t, storage.refs, 1)
+	blobCache.Dispose()
+}
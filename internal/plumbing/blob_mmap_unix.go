@@ -0,0 +1,19 @@
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package plumbing
+
+import (
+	"syscall"
+)
+
+// mmapFile maps the first `size` bytes of the file with the specified descriptor into memory.
+// The mapping is private so that writes never reach the file.
+func mmapFile(fd uintptr, size int) ([]byte, error) {
+	return syscall.Mmap(int(fd), 0, size,
+		syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_PRIVATE)
+}
+
+// munmapFile releases the memory previously returned by mmapFile().
+func munmapFile(data []byte) error {
+	return syscall.Munmap(data)
+}
@@ -0,0 +1,95 @@
+package plumbing
+
+import (
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+)
+
+// BranchDepth provides the number of commits since the last fork on the current branch
+// for every commit. It is a PipelineItem.
+// Pipeline.Run() forks and merges the items following the run plan, so the depth is derived
+// directly from the plan's fork and merge actions: both reset the counter of every involved branch.
+// Commits on the root branch which precede the first fork are counted from the beginning.
+type BranchDepth struct {
+	// depth is the number of commits consumed by the current branch since the last fork or merge.
+	depth int
+
+	l core.Logger
+}
+
+const (
+	// DependencyBranchDepth is the name of the dependency provided by BranchDepth - the number
+	// of commits since the last fork on the current branch.
+	DependencyBranchDepth = "branch_depth"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (bd *BranchDepth) Name() string {
+	return "BranchDepth"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (bd *BranchDepth) Provides() []string {
+	return []string{DependencyBranchDepth}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (bd *BranchDepth) Requires() []string {
+	return []string{}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (bd *BranchDepth) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (bd *BranchDepth) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		bd.l = l
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (bd *BranchDepth) Initialize(repository *git.Repository) error {
+	if bd.l == nil {
+		bd.l = core.NewLogger()
+	}
+	bd.depth = 0
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (bd *BranchDepth) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	bd.depth++
+	return map[string]interface{}{DependencyBranchDepth: bd.depth}, nil
+}
+
+// Fork clones this PipelineItem. The fork point becomes the new origin for all the branches.
+func (bd *BranchDepth) Fork(n int) []core.PipelineItem {
+	bd.depth = 0
+	return core.ForkCopyPipelineItem(bd, n)
+}
+
+// Merge combines several branches together. The merge point becomes the new origin
+// for all the branches.
+func (bd *BranchDepth) Merge(branches []core.PipelineItem) {
+	bd.depth = 0
+	for _, branch := range branches {
+		branch.(*BranchDepth).depth = 0
+	}
+}
+
+func init() {
+	core.Registry.Register(&BranchDepth{})
+}
@@ -0,0 +1,63 @@
+package plumbing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureBranchDepth() *BranchDepth {
+	bd := BranchDepth{}
+	bd.Configure(map[string]interface{}{})
+	bd.Initialize(test.Repository)
+	return &bd
+}
+
+func TestBranchDepthMeta(t *testing.T) {
+	bd := fixtureBranchDepth()
+	assert.Equal(t, bd.Name(), "BranchDepth")
+	assert.Len(t, bd.Provides(), 1)
+	assert.Equal(t, bd.Provides()[0], DependencyBranchDepth)
+	assert.Len(t, bd.Requires(), 0)
+	assert.Len(t, bd.ListConfigurationOptions(), 0)
+	logger := core.NewLogger()
+	assert.NoError(t, bd.Configure(map[string]interface{}{
+		core.ConfigLogger: logger,
+	}))
+	assert.Equal(t, logger, bd.l)
+}
+
+func TestBranchDepthRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&BranchDepth{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "BranchDepth")
+	summoned = core.Registry.Summon((&BranchDepth{}).Provides()[0])
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "BranchDepth")
+}
+
+func TestBranchDepthConsumeForkMerge(t *testing.T) {
+	bd := fixtureBranchDepth()
+	consume := func(item *BranchDepth) int {
+		res, err := item.Consume(map[string]interface{}{})
+		assert.Nil(t, err)
+		return res[DependencyBranchDepth].(int)
+	}
+	assert.Equal(t, 1, consume(bd))
+	assert.Equal(t, 2, consume(bd))
+	clones := bd.Fork(2)
+	assert.Len(t, clones, 2)
+	bd2 := clones[0].(*BranchDepth)
+	bd3 := clones[1].(*BranchDepth)
+	assert.Equal(t, 1, consume(bd))
+	assert.Equal(t, 1, consume(bd2))
+	assert.Equal(t, 2, consume(bd2))
+	assert.Equal(t, 3, consume(bd2))
+	assert.Equal(t, 1, consume(bd3))
+	bd.Merge([]core.PipelineItem{bd2})
+	assert.Equal(t, 1, consume(bd))
+	assert.Equal(t, 1, consume(bd2))
+	assert.Equal(t, 2, consume(bd3))
+}
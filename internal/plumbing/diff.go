@@ -1,8 +1,10 @@
 package plumbing
 
 import (
+	"fmt"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/sergi/go-diff/diffmatchpatch"
 	"gopkg.in/src-d/go-git.v4"
@@ -19,6 +21,12 @@ type FileDiff struct {
 	CleanupDisabled  bool
 	WhitespaceIgnore bool
 	Timeout          time.Duration
+	// Algorithm is the name of the line diff algorithm: DiffAlgorithmMyers (default),
+	// DiffAlgorithmPatience or DiffAlgorithmHistogram.
+	Algorithm string
+	// MaxLines is the maximum number of lines on either side of a modification to be diffed.
+	// The bigger files are treated as deleted and inserted again. 0 means no limit.
+	MaxLines int
 
 	l core.Logger
 }
@@ -39,6 +47,14 @@ const (
 	// ConfigFileDiffTimeout is the number of milliseconds a single diff calculation may elapse.
 	// We need this timeout to avoid spending too much time comparing big or "bad" files.
 	ConfigFileDiffTimeout = "FileDiff.Timeout"
+
+	// ConfigFileDiffAlgorithm is the name of the configuration option (FileDiff.Configure())
+	// which selects the line diff algorithm: "myers", "patience" or "histogram".
+	ConfigFileDiffAlgorithm = "FileDiff.Algorithm"
+
+	// ConfigFileDiffMaxLines is the name of the configuration option (FileDiff.Configure())
+	// to set FileDiff.MaxLines. The huge generated files can stall the diff for minutes.
+	ConfigFileDiffMaxLines = "FileDiff.MaxLines"
 )
 
 // FileDiffData is the type of the dependency provided by FileDiff.
@@ -88,6 +104,22 @@ func (diff *FileDiff) ListConfigurationOptions() []core.ConfigurationOption {
 			Flag:        "diff-timeout",
 			Type:        core.IntConfigurationOption,
 			Default:     1000},
+		{
+			Name: ConfigFileDiffAlgorithm,
+			Description: "Line diff algorithm: \"" + DiffAlgorithmMyers + "\", \"" +
+				DiffAlgorithmPatience + "\" or \"" + DiffAlgorithmHistogram + "\". " +
+				"Patience and histogram often attribute moved blocks of code more accurately.",
+			Flag:    "diff-algorithm",
+			Type:    core.StringConfigurationOption,
+			Default: DiffAlgorithmMyers},
+		{
+			Name: ConfigFileDiffMaxLines,
+			Description: "Do not diff the modified files with more lines on either side and " +
+				"treat them as entirely removed and added instead, which resets the line " +
+				"ownership. 0 means no limit.",
+			Flag:    "diff-max-lines",
+			Type:    core.IntConfigurationOption,
+			Default: 0},
 	}
 
 	return options[:]
@@ -110,22 +142,51 @@ func (diff *FileDiff) Configure(facts map[string]interface{}) error {
 		}
 		diff.Timeout = time.Duration(val) * time.Millisecond
 	}
+	if val, exists := facts[ConfigFileDiffAlgorithm].(string); exists {
+		switch val {
+		case "", DiffAlgorithmMyers:
+			diff.Algorithm = DiffAlgorithmMyers
+		case DiffAlgorithmPatience, DiffAlgorithmHistogram:
+			diff.Algorithm = val
+		default:
+			return fmt.Errorf("unsupported diff algorithm: %s", val)
+		}
+	}
+	if val, exists := facts[ConfigFileDiffMaxLines].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("%s may not be negative: %d", ConfigFileDiffMaxLines, val)
+		}
+		diff.MaxLines = val
+	}
 	return nil
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (diff *FileDiff) Initialize(repository *git.Repository) error {
-	diff.l = core.NewLogger()
+	if diff.l == nil {
+		diff.l = core.NewLogger()
+	}
 	return nil
 }
 
+// stripWhitespace removes all the whitespace except the line breaks if ignoreWhitespace is true.
+// The last line is terminated so that it survives even if it was whitespace-only: the number
+// of lines stays the same and the diff positions match the original text.
 func stripWhitespace(str string, ignoreWhitespace bool) string {
-	if ignoreWhitespace {
-		response := strings.Replace(str, " ", "", -1)
-		return response
+	if !ignoreWhitespace {
+		return str
 	}
-	return str
+	stripped := strings.Map(func(r rune) rune {
+		if r != '\n' && unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, str)
+	if str != "" && !strings.HasSuffix(str, "\n") {
+		stripped += "\n"
+	}
+	return stripped
 }
 
 // Consume runs this PipelineItem on the next commit data.
@@ -152,7 +213,28 @@ func (diff *FileDiff) Consume(deps map[string]interface{}) (map[string]interface
 			dmp := diffmatchpatch.New()
 			dmp.DiffTimeout = diff.Timeout
 			src, dst, _ := dmp.DiffLinesToRunes(stripWhitespace(strFrom, diff.WhitespaceIgnore), stripWhitespace(strTo, diff.WhitespaceIgnore))
-			diffs := dmp.DiffMainRunes(src, dst, false)
+			if diff.MaxLines > 0 && (len(src) > diff.MaxLines || len(dst) > diff.MaxLines) {
+				diff.l.Warnf("%s: skipped the diff of %d -> %d lines, the limit is %d\n",
+					change.To.Name, len(src), len(dst), diff.MaxLines)
+				result[change.To.Name] = FileDiffData{
+					OldLinesOfCode: len(src),
+					NewLinesOfCode: len(dst),
+					Diffs:          replacementDiff(src, dst),
+				}
+				continue
+			}
+			myers := func(src, dst []rune) []diffmatchpatch.Diff {
+				return dmp.DiffMainRunes(src, dst, false)
+			}
+			var diffs []diffmatchpatch.Diff
+			switch diff.Algorithm {
+			case DiffAlgorithmPatience:
+				diffs = dmp.DiffCleanupMerge(patienceDiff(src, dst, myers))
+			case DiffAlgorithmHistogram:
+				diffs = dmp.DiffCleanupMerge(histogramDiff(src, dst, myers))
+			default:
+				diffs = myers(src, dst)
+			}
 			if !diff.CleanupDisabled {
 				diffs = dmp.DiffCleanupMerge(dmp.DiffCleanupSemanticLossless(diffs))
 			}
@@ -168,6 +250,18 @@ func (diff *FileDiff) Consume(deps map[string]interface{}) (map[string]interface
 	return map[string]interface{}{DependencyFileDiff: result}, nil
 }
 
+// replacementDiff is the diff which removes all the `src` lines and inserts all the `dst` lines.
+func replacementDiff(src, dst []rune) []diffmatchpatch.Diff {
+	var diffs []diffmatchpatch.Diff
+	if len(src) > 0 {
+		diffs = append(diffs, diffmatchpatch.Diff{Type: diffmatchpatch.DiffDelete, Text: string(src)})
+	}
+	if len(dst) > 0 {
+		diffs = append(diffs, diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: string(dst)})
+	}
+	return diffs
+}
+
 // Fork clones this PipelineItem.
 func (diff *FileDiff) Fork(n int) []core.PipelineItem {
 	return core.ForkSamePipelineItem(diff, n)
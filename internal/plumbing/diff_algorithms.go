@@ -0,0 +1,198 @@
+package plumbing
+
+import (
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const (
+	// DiffAlgorithmMyers is the classic diffmatchpatch line diff. It is the default.
+	DiffAlgorithmMyers = "myers"
+	// DiffAlgorithmPatience anchors the diff on the lines which are unique in both files.
+	DiffAlgorithmPatience = "patience"
+	// DiffAlgorithmHistogram anchors the diff on the least frequent common lines,
+	// similar to `git diff --histogram`.
+	DiffAlgorithmHistogram = "histogram"
+
+	// histogramMaxChainLength limits the number of occurrences of a line which is considered
+	// as an anchor candidate by the histogram diff.
+	histogramMaxChainLength = 64
+)
+
+// lineDiffer calculates the diff between two files encoded with DiffLinesToRunes().
+type lineDiffer func(src, dst []rune) []diffmatchpatch.Diff
+
+// diffStripCommon calls `differ` on the parts of `src` and `dst` which remain after removing
+// the common prefix and suffix, and wraps the result with the corresponding DiffEqual-s.
+func diffStripCommon(src, dst []rune, differ lineDiffer) []diffmatchpatch.Diff {
+	prefix := 0
+	for prefix < len(src) && prefix < len(dst) && src[prefix] == dst[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(src)-prefix && suffix < len(dst)-prefix &&
+		src[len(src)-suffix-1] == dst[len(dst)-suffix-1] {
+		suffix++
+	}
+	var diffs []diffmatchpatch.Diff
+	if prefix > 0 {
+		diffs = append(diffs, diffmatchpatch.Diff{
+			Type: diffmatchpatch.DiffEqual, Text: string(src[:prefix])})
+	}
+	middleSrc, middleDst := src[prefix:len(src)-suffix], dst[prefix:len(dst)-suffix]
+	switch {
+	case len(middleSrc) == 0 && len(middleDst) == 0:
+	case len(middleSrc) == 0:
+		diffs = append(diffs, diffmatchpatch.Diff{
+			Type: diffmatchpatch.DiffInsert, Text: string(middleDst)})
+	case len(middleDst) == 0:
+		diffs = append(diffs, diffmatchpatch.Diff{
+			Type: diffmatchpatch.DiffDelete, Text: string(middleSrc)})
+	default:
+		diffs = append(diffs, differ(middleSrc, middleDst)...)
+	}
+	if suffix > 0 {
+		diffs = append(diffs, diffmatchpatch.Diff{
+			Type: diffmatchpatch.DiffEqual, Text: string(src[len(src)-suffix:])})
+	}
+	return diffs
+}
+
+// patienceDiff implements the patience diff algorithm: the lines which occur exactly once
+// in both `src` and `dst` and form the longest common subsequence become the anchors,
+// and the gaps between them are diffed recursively. `fallback` is used when there are no anchors.
+func patienceDiff(src, dst []rune, fallback lineDiffer) []diffmatchpatch.Diff {
+	var differ lineDiffer
+	differ = func(src, dst []rune) []diffmatchpatch.Diff {
+		type occurrence struct {
+			srcCount, dstCount int
+			dstIndex           int
+		}
+		occurrences := map[rune]*occurrence{}
+		for _, line := range src {
+			occ := occurrences[line]
+			if occ == nil {
+				occ = &occurrence{}
+				occurrences[line] = occ
+			}
+			occ.srcCount++
+		}
+		for i, line := range dst {
+			if occ := occurrences[line]; occ != nil {
+				occ.dstCount++
+				occ.dstIndex = i
+			}
+		}
+		// the pairs of unique lines in the order of `src`
+		var srcAnchors, dstAnchors []int
+		for i, line := range src {
+			if occ := occurrences[line]; occ.srcCount == 1 && occ.dstCount == 1 {
+				srcAnchors = append(srcAnchors, i)
+				dstAnchors = append(dstAnchors, occ.dstIndex)
+			}
+		}
+		if len(srcAnchors) == 0 {
+			return fallback(src, dst)
+		}
+		var diffs []diffmatchpatch.Diff
+		srcPos, dstPos := 0, 0
+		for _, index := range longestIncreasingSubsequence(dstAnchors) {
+			srcAnchor, dstAnchor := srcAnchors[index], dstAnchors[index]
+			diffs = append(diffs, diffStripCommon(
+				src[srcPos:srcAnchor], dst[dstPos:dstAnchor], differ)...)
+			diffs = append(diffs, diffmatchpatch.Diff{
+				Type: diffmatchpatch.DiffEqual, Text: string(src[srcAnchor])})
+			srcPos, dstPos = srcAnchor+1, dstAnchor+1
+		}
+		return append(diffs, diffStripCommon(src[srcPos:], dst[dstPos:], differ)...)
+	}
+	return diffStripCommon(src, dst, differ)
+}
+
+// longestIncreasingSubsequence returns the indexes of the elements which form the longest
+// strictly increasing subsequence of `seq`.
+func longestIncreasingSubsequence(seq []int) []int {
+	// tails[k] is the index of the smallest tail of an increasing subsequence of length k+1
+	var tails []int
+	prev := make([]int, len(seq))
+	for i, val := range seq {
+		low, high := 0, len(tails)
+		for low < high {
+			mid := (low + high) / 2
+			if seq[tails[mid]] < val {
+				low = mid + 1
+			} else {
+				high = mid
+			}
+		}
+		if low > 0 {
+			prev[i] = tails[low-1]
+		} else {
+			prev[i] = -1
+		}
+		if low == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[low] = i
+		}
+	}
+	result := make([]int, len(tails))
+	if len(tails) == 0 {
+		return result
+	}
+	for i, k := len(tails)-1, tails[len(tails)-1]; i >= 0; i-- {
+		result[i] = k
+		k = prev[k]
+	}
+	return result
+}
+
+// histogramDiff implements the histogram diff algorithm: the longest common region which
+// contains the least frequent lines of `src` becomes the anchor, and the parts before and after
+// it are diffed recursively. `fallback` is used when there are no suitable common lines.
+func histogramDiff(src, dst []rune, fallback lineDiffer) []diffmatchpatch.Diff {
+	var differ lineDiffer
+	differ = func(src, dst []rune) []diffmatchpatch.Diff {
+		positions := map[rune][]int{}
+		for i, line := range src {
+			positions[line] = append(positions[line], i)
+		}
+		bestCount := histogramMaxChainLength + 1
+		bestSrc, bestDst, bestLen := 0, 0, 0
+		for dstIndex := 0; dstIndex < len(dst); dstIndex++ {
+			srcIndexes := positions[dst[dstIndex]]
+			if len(srcIndexes) == 0 || len(srcIndexes) > bestCount {
+				continue
+			}
+			for _, srcIndex := range srcIndexes {
+				srcStart, dstStart := srcIndex, dstIndex
+				for srcStart > 0 && dstStart > 0 && src[srcStart-1] == dst[dstStart-1] {
+					srcStart--
+					dstStart--
+				}
+				length := srcIndex - srcStart + 1
+				for srcStart+length < len(src) && dstStart+length < len(dst) &&
+					src[srcStart+length] == dst[dstStart+length] {
+					length++
+				}
+				count := len(srcIndexes)
+				for _, line := range src[srcStart : srcStart+length] {
+					if n := len(positions[line]); n < count {
+						count = n
+					}
+				}
+				if count < bestCount || (count == bestCount && length > bestLen) {
+					bestCount, bestSrc, bestDst, bestLen = count, srcStart, dstStart, length
+				}
+			}
+		}
+		if bestLen == 0 {
+			return fallback(src, dst)
+		}
+		diffs := diffStripCommon(src[:bestSrc], dst[:bestDst], differ)
+		diffs = append(diffs, diffmatchpatch.Diff{
+			Type: diffmatchpatch.DiffEqual, Text: string(src[bestSrc : bestSrc+bestLen])})
+		return append(diffs, diffStripCommon(
+			src[bestSrc+bestLen:], dst[bestDst+bestLen:], differ)...)
+	}
+	return diffStripCommon(src, dst, differ)
+}
@@ -1,6 +1,8 @@
 package plumbing_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 	"unicode/utf8"
@@ -25,19 +27,27 @@ func TestFileDiffMeta(t *testing.T) {
 	assert.Equal(t, len(fd.Requires()), 2)
 	assert.Equal(t, fd.Requires()[0], items.DependencyTreeChanges)
 	assert.Equal(t, fd.Requires()[1], items.DependencyBlobCache)
-	assert.Len(t, fd.ListConfigurationOptions(), 3)
+	assert.Len(t, fd.ListConfigurationOptions(), 5)
 	assert.Equal(t, fd.ListConfigurationOptions()[0].Name, items.ConfigFileDiffDisableCleanup)
 	assert.Equal(t, fd.ListConfigurationOptions()[1].Name, items.ConfigFileWhitespaceIgnore)
 	assert.Equal(t, fd.ListConfigurationOptions()[2].Name, items.ConfigFileDiffTimeout)
+	assert.Equal(t, fd.ListConfigurationOptions()[3].Name, items.ConfigFileDiffAlgorithm)
+	assert.Equal(t, fd.ListConfigurationOptions()[4].Name, items.ConfigFileDiffMaxLines)
 	assert.NoError(t, fd.Configure(map[string]interface{}{
 		core.ConfigLogger:                  core.NewLogger(),
 		items.ConfigFileDiffDisableCleanup: true,
 		items.ConfigFileWhitespaceIgnore:   true,
 		items.ConfigFileDiffTimeout:        500,
+		items.ConfigFileDiffMaxLines:       10000,
 	}))
 	assert.True(t, fd.CleanupDisabled)
 	assert.True(t, fd.WhitespaceIgnore)
 	assert.Equal(t, 500*time.Millisecond, fd.Timeout)
+	assert.Equal(t, 10000, fd.MaxLines)
+	assert.Error(t, fd.Configure(map[string]interface{}{
+		items.ConfigFileDiffMaxLines: -1,
+	}))
+	assert.Equal(t, 10000, fd.MaxLines)
 }
 
 func TestFileDiffRegistration(t *testing.T) {
@@ -322,6 +332,43 @@ func TestFileDiffWhitespaceDarkMagic(t *testing.T) {
 	assert.Equal(t, magicDiffs.NewLinesOfCode, plainDiffs.NewLinesOfCode)
 }
 
+func TestFileDiffWhitespaceIgnore(t *testing.T) {
+	before := "a b\nc\n  "
+	after := "a\tb\r\nd\n\t"
+	hashBefore := plumbing.NewHash("1111111111111111111111111111111111111111")
+	hashAfter := plumbing.NewHash("2222222222222222222222222222222222222222")
+	deps := map[string]interface{}{
+		items.DependencyBlobCache: map[plumbing.Hash]*items.CachedBlob{
+			hashBefore: {Blob: object.Blob{Hash: hashBefore}, Data: []byte(before)},
+			hashAfter:  {Blob: object.Blob{Hash: hashAfter}, Data: []byte(after)},
+		},
+		items.DependencyTreeChanges: object.Changes{&object.Change{
+			From: object.ChangeEntry{Name: "a", TreeEntry: object.TreeEntry{Name: "a", Hash: hashBefore}},
+			To:   object.ChangeEntry{Name: "a", TreeEntry: object.TreeEntry{Name: "a", Hash: hashAfter}},
+		}},
+	}
+	fd := fixtures.FileDiff()
+	fd.WhitespaceIgnore = true
+	res, err := fd.Consume(deps)
+	assert.NoError(t, err)
+	diff := res[items.DependencyFileDiff].(map[string]items.FileDiffData)["a"]
+	// the whitespace-only last line is kept
+	assert.Equal(t, 3, diff.OldLinesOfCode)
+	assert.Equal(t, 3, diff.NewLinesOfCode)
+	var equal, inserted, deleted int
+	for _, edit := range diff.Diffs {
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			equal += utf8.RuneCountInString(edit.Text)
+		case diffmatchpatch.DiffInsert:
+			inserted += utf8.RuneCountInString(edit.Text)
+		case diffmatchpatch.DiffDelete:
+			deleted += utf8.RuneCountInString(edit.Text)
+		}
+	}
+	assert.Equal(t, []int{2, 1, 1}, []int{equal, inserted, deleted})
+}
+
 func TestFileDiffFork(t *testing.T) {
 	fd1 := fixtures.FileDiff()
 	clones := fd1.Fork(1)
@@ -330,3 +377,185 @@ func TestFileDiffFork(t *testing.T) {
 	assert.True(t, fd1 == fd2)
 	fd1.Merge([]core.PipelineItem{fd2})
 }
+
+// fixtureMovedBlocks returns two revisions of a file in which a function was moved
+// from the end to the beginning.
+func fixtureMovedBlocks() (string, string) {
+	before := `package main
+
+func foo() {
+	if x {
+		return
+	}
+}
+
+func bar() {
+	if y {
+		return
+	}
+}
+
+func baz() {
+	for {
+		return
+	}
+}
+`
+	after := `package main
+
+func baz() {
+	for {
+		return
+	}
+}
+
+func foo() {
+	if x {
+		return
+	}
+}
+
+func bar() {
+	if y {
+		return
+	}
+}
+`
+	return before, after
+}
+
+// attributeLines returns the line numbers of the new revision which were inserted
+// according to the diff, that is, which are attributed to the new commit by burndown.
+func attributeLines(diffs []diffmatchpatch.Diff) []int {
+	var inserted []int
+	line := 0
+	for _, edit := range diffs {
+		length := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			line += length
+		case diffmatchpatch.DiffInsert:
+			for i := 0; i < length; i++ {
+				inserted = append(inserted, line)
+				line++
+			}
+		}
+	}
+	return inserted
+}
+
+func TestFileDiffAlgorithmMovedBlocks(t *testing.T) {
+	before, after := fixtureMovedBlocks()
+	hashBefore := plumbing.NewHash("1111111111111111111111111111111111111111")
+	hashAfter := plumbing.NewHash("2222222222222222222222222222222222222222")
+	cache := map[plumbing.Hash]*items.CachedBlob{
+		hashBefore: {Data: []byte(before)},
+		hashAfter:  {Data: []byte(after)},
+	}
+	changes := object.Changes{&object.Change{
+		From: object.ChangeEntry{Name: "main.go", TreeEntry: object.TreeEntry{
+			Name: "main.go", Mode: 0100644, Hash: hashBefore}},
+		To: object.ChangeEntry{Name: "main.go", TreeEntry: object.TreeEntry{
+			Name: "main.go", Mode: 0100644, Hash: hashAfter}},
+	}}
+	deps := map[string]interface{}{
+		items.DependencyBlobCache:   cache,
+		items.DependencyTreeChanges: changes,
+	}
+	attribution := map[string][]int{}
+	for _, algorithm := range []string{
+		items.DiffAlgorithmMyers, items.DiffAlgorithmPatience, items.DiffAlgorithmHistogram} {
+		fd := fixtures.FileDiff()
+		assert.NoError(t, fd.Configure(map[string]interface{}{
+			items.ConfigFileDiffAlgorithm: algorithm,
+		}))
+		assert.Equal(t, algorithm, fd.Algorithm)
+		res, err := fd.Consume(deps)
+		assert.NoError(t, err)
+		diffs := res[items.DependencyFileDiff].(map[string]items.FileDiffData)["main.go"]
+		assert.Equal(t, 19, diffs.OldLinesOfCode)
+		assert.Equal(t, 19, diffs.NewLinesOfCode)
+		attribution[algorithm] = attributeLines(diffs.Diffs)
+	}
+	// the moved function "baz" occupies lines 2-7
+	moved := []int{2, 3, 4, 5, 6, 7}
+	assert.NotEqual(t, moved, attribution[items.DiffAlgorithmMyers])
+	assert.Equal(t, moved, attribution[items.DiffAlgorithmPatience])
+	assert.Equal(t, moved, attribution[items.DiffAlgorithmHistogram])
+}
+
+func TestFileDiffAlgorithmInvalid(t *testing.T) {
+	fd := fixtures.FileDiff()
+	assert.Error(t, fd.Configure(map[string]interface{}{
+		items.ConfigFileDiffAlgorithm: "minimal",
+	}))
+}
+
+// fixtureHugeModification returns the dependencies of FileDiff.Consume() with a generated
+// file of `lines` lines every third of which is changed.
+func fixtureHugeModification(lines int) map[string]interface{} {
+	var before, after strings.Builder
+	for i := 0; i < lines; i++ {
+		fmt.Fprintf(&before, "var x%d = %d\n", i, i)
+		if i%3 == 0 {
+			fmt.Fprintf(&after, "var y%d = %d\n", i, i*2)
+		} else {
+			fmt.Fprintf(&after, "var x%d = %d\n", i, i)
+		}
+	}
+	hashBefore := plumbing.NewHash("1111111111111111111111111111111111111111")
+	hashAfter := plumbing.NewHash("2222222222222222222222222222222222222222")
+	return map[string]interface{}{
+		items.DependencyBlobCache: map[plumbing.Hash]*items.CachedBlob{
+			hashBefore: {Data: []byte(before.String())},
+			hashAfter:  {Data: []byte(after.String())},
+		},
+		items.DependencyTreeChanges: object.Changes{&object.Change{
+			From: object.ChangeEntry{Name: "gen.go", TreeEntry: object.TreeEntry{
+				Name: "gen.go", Mode: 0100644, Hash: hashBefore}},
+			To: object.ChangeEntry{Name: "gen.go", TreeEntry: object.TreeEntry{
+				Name: "gen.go", Mode: 0100644, Hash: hashAfter}},
+		}},
+	}
+}
+
+func TestFileDiffMaxLines(t *testing.T) {
+	deps := fixtureHugeModification(2000)
+	fd := fixtures.FileDiff()
+	assert.NoError(t, fd.Configure(map[string]interface{}{items.ConfigFileDiffMaxLines: 1000}))
+	res, err := fd.Consume(deps)
+	assert.NoError(t, err)
+	diffs := res[items.DependencyFileDiff].(map[string]items.FileDiffData)["gen.go"]
+	assert.Equal(t, 2000, diffs.OldLinesOfCode)
+	assert.Equal(t, 2000, diffs.NewLinesOfCode)
+	assert.Len(t, diffs.Diffs, 2)
+	assert.Equal(t, diffmatchpatch.DiffDelete, diffs.Diffs[0].Type)
+	assert.Equal(t, 2000, utf8.RuneCountInString(diffs.Diffs[0].Text))
+	assert.Equal(t, diffmatchpatch.DiffInsert, diffs.Diffs[1].Type)
+	assert.Equal(t, 2000, utf8.RuneCountInString(diffs.Diffs[1].Text))
+
+	// under the limit
+	assert.NoError(t, fd.Configure(map[string]interface{}{items.ConfigFileDiffMaxLines: 2000}))
+	res, err = fd.Consume(deps)
+	assert.NoError(t, err)
+	diffs = res[items.DependencyFileDiff].(map[string]items.FileDiffData)["gen.go"]
+	assert.True(t, len(diffs.Diffs) > 2)
+}
+
+func benchmarkFileDiffHugeModification(b *testing.B, maxLines int) {
+	deps := fixtureHugeModification(20000)
+	fd := fixtures.FileDiff()
+	fd.Configure(map[string]interface{}{items.ConfigFileDiffMaxLines: maxLines})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fd.Consume(deps)
+	}
+}
+
+func BenchmarkFileDiffHugeModification(b *testing.B) {
+	benchmarkFileDiffHugeModification(b, 0)
+}
+
+func BenchmarkFileDiffHugeModificationMaxLines(b *testing.B) {
+	benchmarkFileDiffHugeModification(b, 10000)
+}
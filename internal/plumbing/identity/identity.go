@@ -2,6 +2,9 @@ package identity
 
 import (
 	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
 	"os"
 	"sort"
 	"strings"
@@ -24,6 +27,9 @@ type Detector struct {
 	// ExactSignatures chooses the matching algorithm: opportunistic email || name
 	// or exact email && name
 	ExactSignatures bool
+	// Normalize enables the extra canonicalization of the signatures: "+suffix"-es are removed
+	// from the email local parts and the whitespace in the names is collapsed.
+	Normalize bool
 
 	l core.Logger
 }
@@ -46,10 +52,19 @@ const (
 	// ConfigIdentityDetectorPeopleDictPath is the name of the configuration option
 	// (Detector.Configure()) which allows to set the external PeopleDict mapping from a file.
 	ConfigIdentityDetectorPeopleDictPath = "IdentityDetector.PeopleDictPath"
+	// FactIdentityDetectorPeopleDictInline is the name of the fact which carries the contents
+	// of the external PeopleDict mapping as []byte, in the same format as the file set with
+	// ConfigIdentityDetectorPeopleDictPath. It takes precedence over the file, and
+	// Detector.Configure() sets it to the file contents if it is missing.
+	FactIdentityDetectorPeopleDictInline = "IdentityDetector.PeopleDictInline"
 	// ConfigIdentityDetectorExactSignatures is the name of the configuration option
 	// (Detector.Configure()) which changes the matching algorithm to exact signature (name + email)
 	// correspondence.
 	ConfigIdentityDetectorExactSignatures = "IdentityDetector.ExactSignatures"
+	// ConfigIdentityDetectorNormalize is the name of the configuration option
+	// (Detector.Configure()) which enables the normalization of names and emails before
+	// matching them.
+	ConfigIdentityDetectorNormalize = "IdentityDetector.Normalize"
 	// FactIdentityDetectorPeopleCount is the name of the fact which is inserted in
 	// Detector.Configure(). It is equal to the overall number of unique authors
 	// (the length of ReversedPeopleDict).
@@ -91,6 +106,12 @@ func (detector *Detector) ListConfigurationOptions() []core.ConfigurationOption
 			"identities and should not be normally used.",
 		Flag:    "exact-signatures",
 		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name: ConfigIdentityDetectorNormalize,
+		Description: "Match the identities after removing \"+suffix\"-es from the emails and " +
+			"collapsing the whitespace in the names, e.g. \"John.Doe+ci@X.com\" = \"john.doe@x.com\".",
+		Flag:    "normalize-identities",
+		Type:    core.BoolConfigurationOption,
 		Default: false},
 	}
 	return options[:]
@@ -112,13 +133,25 @@ func (detector *Detector) Configure(facts map[string]interface{}) error {
 	if val, exists := facts[ConfigIdentityDetectorExactSignatures].(bool); exists {
 		detector.ExactSignatures = val
 	}
+	if val, exists := facts[ConfigIdentityDetectorNormalize].(bool); exists {
+		detector.Normalize = val
+	}
 	if detector.PeopleDict == nil || detector.ReversedPeopleDict == nil {
+		inline, _ := facts[FactIdentityDetectorPeopleDictInline].([]byte)
 		peopleDictPath, _ := facts[ConfigIdentityDetectorPeopleDictPath].(string)
-		if peopleDictPath != "" {
-			err := detector.LoadPeopleDict(peopleDictPath)
+		if inline == nil && peopleDictPath != "" {
+			var err error
+			inline, err = ioutil.ReadFile(peopleDictPath)
 			if err != nil {
 				return errors.Errorf("failed to load %s: %v", peopleDictPath, err)
 			}
+			facts[FactIdentityDetectorPeopleDictInline] = inline
+		}
+		if inline != nil {
+			err := detector.ReadPeopleDict(bytes.NewReader(inline))
+			if err != nil {
+				return errors.Errorf("failed to parse the people dict: %v", err)
+			}
 			facts[FactIdentityDetectorPeopleCount] = len(detector.ReversedPeopleDict) - 1
 		} else {
 			if _, exists := facts[core.ConfigPipelineCommits]; !exists {
@@ -138,7 +171,9 @@ func (detector *Detector) Configure(facts map[string]interface{}) error {
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (detector *Detector) Initialize(repository *git.Repository) error {
-	detector.l = core.NewLogger()
+	if detector.l == nil {
+		detector.l = core.NewLogger()
+	}
 	return nil
 }
 
@@ -153,12 +188,12 @@ func (detector *Detector) Consume(deps map[string]interface{}) (map[string]inter
 	var exists bool
 	signature := commit.Author
 	if !detector.ExactSignatures {
-		authorID, exists = detector.PeopleDict[strings.ToLower(signature.Email)]
+		authorID, exists = detector.PeopleDict[detector.canonicalEmail(signature.Email)]
 		if !exists {
-			authorID, exists = detector.PeopleDict[strings.ToLower(signature.Name)]
+			authorID, exists = detector.PeopleDict[detector.canonicalName(signature.Name)]
 		}
 	} else {
-		authorID, exists = detector.PeopleDict[strings.ToLower(signature.String())]
+		authorID, exists = detector.PeopleDict[detector.canonicalSignature(signature)]
 	}
 	if !exists {
 		authorID = AuthorMissing
@@ -180,18 +215,26 @@ func (detector *Detector) LoadPeopleDict(path string) error {
 		return err
 	}
 	defer file.Close()
-	scanner := bufio.NewScanner(file)
+	return detector.ReadPeopleDict(file)
+}
+
+// ReadPeopleDict loads author signatures in the LoadPeopleDict() format from a reader.
+func (detector *Detector) ReadPeopleDict(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
 	dict := make(map[string]int)
 	var reverseDict []string
 	size := 0
 	for scanner.Scan() {
 		ids := strings.Split(scanner.Text(), "|")
 		for _, id := range ids {
-			dict[strings.ToLower(id)] = size
+			dict[detector.canonicalIdentity(id)] = size
 		}
 		reverseDict = append(reverseDict, ids[0])
 		size++
 	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
 	reverseDict = append(reverseDict, AuthorMissingName)
 	detector.PeopleDict = dict
 	detector.ReversedPeopleDict = reverseDict
@@ -212,9 +255,9 @@ func (detector *Detector) GeneratePeopleDict(commits []*object.Commit) {
 		if err == nil {
 			mailmap := ParseMailmap(mailMapContents)
 			for key, val := range mailmap {
-				key = strings.ToLower(key)
-				toEmail := strings.ToLower(val.Email)
-				toName := strings.ToLower(val.Name)
+				key = detector.canonicalIdentity(key)
+				toEmail := detector.canonicalEmail(val.Email)
+				toName := detector.canonicalName(val.Name)
 				id, exists := dict[toEmail]
 				if !exists {
 					id, exists = dict[toName]
@@ -263,8 +306,8 @@ func (detector *Detector) GeneratePeopleDict(commits []*object.Commit) {
 
 	for _, commit := range commits {
 		if !detector.ExactSignatures {
-			email := strings.ToLower(commit.Author.Email)
-			name := strings.ToLower(commit.Author.Name)
+			email := detector.canonicalEmail(commit.Author.Email)
+			name := detector.canonicalName(commit.Author.Name)
 			id, exists := dict[email]
 			if exists {
 				_, exists := dict[name]
@@ -286,7 +329,7 @@ func (detector *Detector) GeneratePeopleDict(commits []*object.Commit) {
 			names[size] = append(names[size], name)
 			size++
 		} else { // !detector.ExactSignatures
-			sig := strings.ToLower(commit.Author.String())
+			sig := detector.canonicalSignature(commit.Author)
 			if _, exists := dict[sig]; !exists {
 				dict[sig] = size
 				size++
@@ -309,6 +352,65 @@ func (detector *Detector) GeneratePeopleDict(commits []*object.Commit) {
 	detector.ReversedPeopleDict = reverseDict
 }
 
+// canonicalEmail returns the key of `email` in PeopleDict.
+func (detector *Detector) canonicalEmail(email string) string {
+	if detector.Normalize {
+		return NormalizeEmail(email)
+	}
+	return strings.ToLower(email)
+}
+
+// canonicalName returns the key of `name` in PeopleDict.
+func (detector *Detector) canonicalName(name string) string {
+	if detector.Normalize {
+		return NormalizeName(name)
+	}
+	return strings.ToLower(name)
+}
+
+// canonicalIdentity returns the key of `id` in PeopleDict; `id` is either an email or a name.
+func (detector *Detector) canonicalIdentity(id string) string {
+	if detector.Normalize {
+		return NormalizeIdentity(id)
+	}
+	return strings.ToLower(id)
+}
+
+// canonicalSignature returns the key of `signature` in PeopleDict if ExactSignatures is set.
+func (detector *Detector) canonicalSignature(signature object.Signature) string {
+	if detector.Normalize {
+		return NormalizeName(signature.Name) + " <" + NormalizeEmail(signature.Email) + ">"
+	}
+	return strings.ToLower(signature.String())
+}
+
+// NormalizeEmail lowercases the email, trims the surrounding whitespace and removes
+// the "+suffix" from the local part: "John.Doe+ci@X.com" -> "john.doe@x.com".
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return email
+	}
+	if plus := strings.IndexByte(email[:at], '+'); plus > 0 {
+		email = email[:plus] + email[at:]
+	}
+	return email
+}
+
+// NormalizeName lowercases the name and collapses the whitespace: " John   Doe" -> "john doe".
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// NormalizeIdentity calls NormalizeEmail() if `id` contains "@" and NormalizeName() otherwise.
+func NormalizeIdentity(id string) string {
+	if strings.ContainsRune(id, '@') {
+		return NormalizeEmail(id)
+	}
+	return NormalizeName(id)
+}
+
 // MergedIndex is the result of merging `rd1[First]` and `rd2[Second]`: the index in the final reversed
 // dictionary. -1 for `First` or `Second` means that the corresponding string does not exist
 // in respectively `rd1` and `rd2`.
@@ -353,17 +455,35 @@ type identityPair struct {
 }
 
 // MergeReversedDictsIdentities joins two identity lists together, excluding duplicates.
-// The strings are split by "|" and we find the connected components..
+// The strings are split by "|" and we find the connected components. If `normalize` is true,
+// which corresponds to Detector.Normalize, the parts are compared after NormalizeIdentity()
+// so that the results of Detector with and without Normalize merge consistently; the first seen
+// spelling of each part is preserved. Otherwise, the parts must match exactly.
 // The returned mapping's keys are the unique strings in `rd1 ∪ rd2`, and the values are:
 // 1. Index after merging.
 // 2. Corresponding index in the first array - `rd1`. -1 means that it does not exist.
 // 3. Corresponding index in the second array - `rd2`. -1 means that it does not exist.
-func MergeReversedDictsIdentities(rd1, rd2 []string) (map[string]MergedIndex, []string) {
+func MergeReversedDictsIdentities(rd1, rd2 []string, normalize bool) (
+	map[string]MergedIndex, []string) {
 
 	vocabulary := map[string]identityPair{}
+	// originals maps the normalized parts to their first seen spelling
+	originals := map[string]string{}
+	splitParts := func(s string) []string {
+		parts := strings.Split(s, "|")
+		for i, p := range parts {
+			if normalize {
+				parts[i] = NormalizeIdentity(p)
+			}
+			if _, exists := originals[parts[i]]; !exists {
+				originals[parts[i]] = p
+			}
+		}
+		return parts
+	}
 	vertices1 := make([][]string, len(rd1))
 	for i, s := range rd1 {
-		parts := strings.Split(s, "|")
+		parts := splitParts(s)
 		vertices1[i] = parts
 		for _, p := range parts {
 			vocabulary[p] = identityPair{i, -1}
@@ -371,7 +491,7 @@ func MergeReversedDictsIdentities(rd1, rd2 []string) (map[string]MergedIndex, []
 	}
 	vertices2 := make([][]string, len(rd2))
 	for i, s := range rd2 {
-		parts := strings.Split(s, "|")
+		parts := splitParts(s)
 		vertices2[i] = parts
 		for _, p := range parts {
 			if ip, exists := vocabulary[p]; !exists {
@@ -462,8 +582,8 @@ func MergeReversedDictsIdentities(rd1, rd2 []string) (map[string]MergedIndex, []
 		}
 		// place emails after names
 		sort.Slice(ids, func(i, j int) bool {
-			iid := ids[i]
-			jid := ids[j]
+			iid := originals[ids[i]]
+			jid := originals[ids[j]]
 			iHasAt := strings.ContainsRune(iid, '@')
 			jHasAt := strings.ContainsRune(jid, '@')
 			if iHasAt == jHasAt {
@@ -471,7 +591,11 @@ func MergeReversedDictsIdentities(rd1, rd2 []string) (map[string]MergedIndex, []
 			}
 			return jHasAt
 		})
-		mergedStrings = append(mergedStrings, strings.Join(ids, "|"))
+		spelled := make([]string, len(ids))
+		for i, key := range ids {
+			spelled[i] = originals[key]
+		}
+		mergedStrings = append(mergedStrings, strings.Join(spelled, "|"))
 		for _, key := range ids {
 			ipair := vocabulary[key]
 			if ipair.Index1 >= 0 {
@@ -39,9 +39,10 @@ func TestIdentityDetectorMeta(t *testing.T) {
 	assert.Equal(t, len(id.Provides()), 1)
 	assert.Equal(t, id.Provides()[0], DependencyAuthor)
 	opts := id.ListConfigurationOptions()
-	assert.Len(t, opts, 2)
+	assert.Len(t, opts, 3)
 	assert.Equal(t, opts[0].Name, ConfigIdentityDetectorPeopleDictPath)
 	assert.Equal(t, opts[1].Name, ConfigIdentityDetectorExactSignatures)
+	assert.Equal(t, opts[2].Name, ConfigIdentityDetectorNormalize)
 	logger := core.NewLogger()
 	assert.NoError(t, id.Configure(map[string]interface{}{
 		core.ConfigLogger: logger,
@@ -101,6 +102,7 @@ Vadim|vadim@sourced.tech`)
 	delete(facts, FactIdentityDetectorPeopleDict)
 	delete(facts, FactIdentityDetectorReversedPeopleDict)
 	delete(facts, ConfigIdentityDetectorPeopleDictPath)
+	delete(facts, FactIdentityDetectorPeopleDictInline)
 	commits := make([]*object.Commit, 0)
 	iter, err := test.Repository.CommitObjects()
 	commit, err := iter.Next()
@@ -130,6 +132,43 @@ func TestIdentityDetectorRegistration(t *testing.T) {
 	assert.Equal(t, summoned[0].Name(), "IdentityDetector")
 }
 
+func TestIdentityDetectorConfigureInlinePeopleDict(t *testing.T) {
+	tmpf, err := ioutil.TempFile("", "hercules-test-")
+	assert.Nil(t, err)
+	defer os.Remove(tmpf.Name())
+	_, err = tmpf.WriteString(`Egor|egor@sourced.tech
+Vadim|vadim@sourced.tech`)
+	assert.Nil(t, err)
+	assert.Nil(t, tmpf.Close())
+	id := &Detector{}
+	facts := map[string]interface{}{
+		ConfigIdentityDetectorPeopleDictPath: tmpf.Name(),
+	}
+	assert.Nil(t, id.Configure(facts))
+	assert.Equal(t, []byte("Egor|egor@sourced.tech\nVadim|vadim@sourced.tech"),
+		facts[FactIdentityDetectorPeopleDictInline])
+	assert.Equal(t, []string{"Egor", "Vadim", AuthorMissingName}, id.ReversedPeopleDict)
+	assert.Equal(t, 2, facts[FactIdentityDetectorPeopleCount])
+
+	id = &Detector{}
+	facts = map[string]interface{}{
+		ConfigIdentityDetectorPeopleDictPath: tmpf.Name(),
+		FactIdentityDetectorPeopleDictInline: []byte("Máximo|maximo@sourced.tech"),
+	}
+	assert.Nil(t, id.Configure(facts))
+	assert.Equal(t, []string{"Máximo", AuthorMissingName}, id.ReversedPeopleDict)
+	assert.Equal(t, 0, id.PeopleDict["maximo@sourced.tech"])
+	assert.Equal(t, 1, facts[FactIdentityDetectorPeopleCount])
+
+	id = &Detector{}
+	facts = map[string]interface{}{
+		ConfigIdentityDetectorPeopleDictPath: "/does/not/exist",
+		FactIdentityDetectorPeopleDictInline: []byte("Máximo|maximo@sourced.tech"),
+	}
+	assert.Nil(t, id.Configure(facts))
+	assert.Equal(t, []string{"Máximo", AuthorMissingName}, id.ReversedPeopleDict)
+}
+
 func TestIdentityDetectorConfigureEmpty(t *testing.T) {
 	id := Detector{}
 	assert.Panics(t, func() { id.Configure(map[string]interface{}{}) })
@@ -414,6 +453,75 @@ func TestIdentityDetectorGeneratePeopleDictMailmap(t *testing.T) {
 		"strange guy|vadim markovtsev|gmarkhor@gmail.com|vadim@athenian.co|vadim@sourced.tech")
 }
 
+func TestIdentityDetectorNormalize(t *testing.T) {
+	assert.Equal(t, "john.doe@x.com", NormalizeEmail(" John.Doe+ci@X.COM "))
+	assert.Equal(t, "john.doe@x.com", NormalizeEmail("john.doe@x.com"))
+	assert.Equal(t, "+ci@x.com", NormalizeEmail("+ci@x.com"))
+	assert.Equal(t, "john+doe", NormalizeEmail("John+Doe"))
+	assert.Equal(t, "john doe", NormalizeName("  John \t Doe "))
+	assert.Equal(t, "john.doe@x.com", NormalizeIdentity("John.Doe+tag@x.com"))
+	assert.Equal(t, "john doe", NormalizeIdentity("John  Doe"))
+}
+
+func TestIdentityDetectorGeneratePeopleDictNormalize(t *testing.T) {
+	commit := func(name, email string) *object.Commit {
+		return &object.Commit{Author: object.Signature{Name: name, Email: email}}
+	}
+	commits := []*object.Commit{
+		commit("John Doe", "John.Doe@x.com"),
+		commit("John  Doe", "john.doe+ci@X.COM"),
+		commit("J. Doe", "john.doe+bot@x.com"),
+		commit("Jane Doe", "jane@x.com"),
+		getFakeCommitWithFile(".mailmap", ""),
+	}
+	id := fixtureIdentityDetector()
+	id.GeneratePeopleDict(commits)
+	assert.Len(t, id.ReversedPeopleDict, 5)
+	id = &Detector{}
+	assert.NoError(t, id.Configure(map[string]interface{}{
+		ConfigIdentityDetectorNormalize: true,
+		core.ConfigPipelineCommits:      commits,
+	}))
+	assert.True(t, id.Normalize)
+	assert.Equal(t, []string{
+		"j. doe|john doe|john.doe@x.com",
+		"jane doe|jane@x.com",
+		"vadim markovtsev|vadim@sourced.tech",
+	}, id.ReversedPeopleDict)
+	for i, c := range commits[:3] {
+		res, err := id.Consume(map[string]interface{}{core.DependencyCommit: c})
+		assert.NoError(t, err, i)
+		assert.Equal(t, 0, res[DependencyAuthor].(int), i)
+	}
+
+	id = fixtureIdentityDetector()
+	id.ExactSignatures = true
+	id.Normalize = true
+	id.GeneratePeopleDict(commits)
+	assert.Contains(t, id.ReversedPeopleDict, "john doe <john.doe@x.com>")
+	assert.Len(t, id.ReversedPeopleDict, 4)
+	res, err := id.Consume(map[string]interface{}{core.DependencyCommit: commits[1]})
+	assert.NoError(t, err)
+	assert.Equal(t, id.PeopleDict["john doe <john.doe@x.com>"], res[DependencyAuthor].(int))
+}
+
+func TestIdentityDetectorMergeReversedDictsIdentitiesNormalized(t *testing.T) {
+	pa1 := [...]string{"John Doe|john.doe+ci@x.com", "jane|jane@x.com"}
+	pa2 := [...]string{"john doe|john.doe@x.com"}
+	// the exact matching is the default
+	people, merged := MergeReversedDictsIdentities(pa1[:], pa2[:], false)
+	assert.Len(t, people, 3)
+	assert.Equal(t, []string{
+		"John Doe|john.doe+ci@x.com", "jane|jane@x.com", "john doe|john.doe@x.com"}, merged)
+	assert.Equal(t, MergedIndex{2, -1, 0}, people["john doe|john.doe@x.com"])
+	people, merged = MergeReversedDictsIdentities(pa1[:], pa2[:], true)
+	assert.Len(t, people, 3)
+	assert.Equal(t, []string{"John Doe|john.doe+ci@x.com", "jane|jane@x.com"}, merged)
+	assert.Equal(t, MergedIndex{0, 0, -1}, people["John Doe|john.doe+ci@x.com"])
+	assert.Equal(t, MergedIndex{0, -1, 0}, people["john doe|john.doe@x.com"])
+	assert.Equal(t, MergedIndex{1, 1, -1}, people["jane|jane@x.com"])
+}
+
 func TestIdentityDetectorMergeReversedDictsLiteral(t *testing.T) {
 	pa1 := [...]string{"one|one@one", "two|aaa@two"}
 	pa2 := [...]string{"two|aaa@two", "three|one@one"}
@@ -437,7 +545,7 @@ func TestIdentityDetectorMergeReversedDictsLiteral(t *testing.T) {
 func TestIdentityDetectorMergeReversedDictsIdentities(t *testing.T) {
 	pa1 := [...]string{"one|one@one", "two|aaa@two"}
 	pa2 := [...]string{"two|aaa@two", "three|one@one"}
-	people, merged := MergeReversedDictsIdentities(pa1[:], pa2[:])
+	people, merged := MergeReversedDictsIdentities(pa1[:], pa2[:], false)
 	assert.Len(t, people, 3)
 	assert.Len(t, merged, 2)
 	assert.Equal(t, people["one|one@one"], MergedIndex{0, 0, -1})
@@ -449,7 +557,7 @@ func TestIdentityDetectorMergeReversedDictsIdentities(t *testing.T) {
 func TestIdentityDetectorMergeReversedDictsIdentitiesStrikeBack(t *testing.T) {
 	pa1 := [...]string{"one|one@one", "two|aaa@two", "three|three@three"}
 	pa2 := [...]string{"two|aaa@two", "three|one@one"}
-	people, merged := MergeReversedDictsIdentities(pa1[:], pa2[:])
+	people, merged := MergeReversedDictsIdentities(pa1[:], pa2[:], false)
 	assert.Len(t, people, 4)
 	assert.Len(t, merged, 2)
 	assert.Equal(t, people["one|one@one"], MergedIndex{0, 0, -1})
@@ -459,7 +567,7 @@ func TestIdentityDetectorMergeReversedDictsIdentitiesStrikeBack(t *testing.T) {
 	assert.Equal(t, merged, []string{"one|three|one@one|three@three", "two|aaa@two"})
 
 	pa1 = [...]string{"one|one@one", "two|aaa@two", "three|aaa@two"}
-	people, merged = MergeReversedDictsIdentities(pa1[:], pa2[:])
+	people, merged = MergeReversedDictsIdentities(pa1[:], pa2[:], false)
 	assert.Len(t, people, 4)
 	assert.Len(t, merged, 1)
 	assert.Equal(t, people["one|one@one"], MergedIndex{0, 0, -1})
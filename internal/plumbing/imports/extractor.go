@@ -61,10 +61,10 @@ func (ex *Extractor) Requires() []string {
 func (ex *Extractor) ListConfigurationOptions() []core.ConfigurationOption {
 	return []core.ConfigurationOption{{
 		Name:        ConfigImportsGoroutines,
-		Description: "Specifies the number of goroutines to run in parallel for the imports extraction.",
+		Description: "Specifies the number of goroutines to run in parallel for the imports extraction, 0 means GOMAXPROCS.",
 		Flag:        "import-goroutines",
 		Type:        core.IntConfigurationOption,
-		Default:     runtime.NumCPU()}, {
+		Default:     0}, {
 		Name:        ConfigMaxFileSize,
 		Description: "Specifies the file size threshold. Files that exceed it are ignored.",
 		Flag:        "import-max-file-size",
@@ -79,12 +79,12 @@ func (ex *Extractor) Configure(facts map[string]interface{}) error {
 		ex.l = l
 	}
 	if gr, exists := facts[ConfigImportsGoroutines].(int); exists {
-		if gr < 1 {
+		if gr < 0 {
 			if ex.l != nil {
 				ex.l.Warnf("invalid number of goroutines for the imports extraction: %d. Set to %d.",
-					gr, runtime.NumCPU())
+					gr, runtime.GOMAXPROCS(0))
 			}
-			gr = runtime.NumCPU()
+			gr = 0
 		}
 		ex.Goroutines = gr
 	}
@@ -103,9 +103,11 @@ func (ex *Extractor) Configure(facts map[string]interface{}) error {
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (ex *Extractor) Initialize(repository *git.Repository) error {
-	ex.l = core.NewLogger()
+	if ex.l == nil {
+		ex.l = core.NewLogger()
+	}
 	if ex.Goroutines < 1 {
-		ex.Goroutines = runtime.NumCPU()
+		ex.Goroutines = runtime.GOMAXPROCS(0)
 	}
 	if ex.MaxFileSize == 0 {
 		ex.MaxFileSize = DefaultMaxFileSize
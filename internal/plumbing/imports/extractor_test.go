@@ -21,7 +21,7 @@ func fixtureExtractor() *Extractor {
 
 func TestExtractorConfigureInitialize(t *testing.T) {
 	ex := fixtureExtractor()
-	assert.Equal(t, runtime.NumCPU(), ex.Goroutines)
+	assert.Equal(t, runtime.GOMAXPROCS(0), ex.Goroutines)
 	facts := map[string]interface{}{}
 	facts[ConfigImportsGoroutines] = 7
 	facts[ConfigMaxFileSize] = 8
@@ -31,8 +31,10 @@ func TestExtractorConfigureInitialize(t *testing.T) {
 	facts[ConfigImportsGoroutines] = -1
 	facts[ConfigMaxFileSize] = -8
 	assert.NoError(t, ex.Configure(facts))
-	assert.Equal(t, runtime.NumCPU(), ex.Goroutines)
+	assert.Equal(t, 0, ex.Goroutines)
 	assert.Equal(t, DefaultMaxFileSize, ex.MaxFileSize)
+	assert.NoError(t, ex.Initialize(test.Repository))
+	assert.Equal(t, runtime.GOMAXPROCS(0), ex.Goroutines)
 	assert.NotNil(t, ex.l)
 }
 
@@ -45,7 +47,7 @@ func TestExtractorMetadata(t *testing.T) {
 	opts := ex.ListConfigurationOptions()
 	assert.Len(t, opts, 2)
 	assert.Equal(t, opts[0].Name, ConfigImportsGoroutines)
-	assert.Equal(t, opts[0].Default.(int), runtime.NumCPU())
+	assert.Equal(t, opts[0].Default.(int), 0)
 	assert.Equal(t, opts[1].Name, ConfigMaxFileSize)
 	assert.Equal(t, opts[1].Default.(int), DefaultMaxFileSize)
 }
@@ -59,7 +59,9 @@ func (langs *LanguagesDetection) Configure(facts map[string]interface{}) error {
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (langs *LanguagesDetection) Initialize(repository *git.Repository) error {
-	langs.l = core.NewLogger()
+	if langs.l == nil {
+		langs.l = core.NewLogger()
+	}
 	return nil
 }
 
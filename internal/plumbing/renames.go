@@ -2,6 +2,7 @@ package plumbing
 
 import (
 	"fmt"
+	"io"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -32,6 +33,15 @@ type RenameAnalysis struct {
 	// Timeout is the maximum time allowed to spend computing renames in a single commit.
 	Timeout time.Duration
 
+	// MaxFiles is the maximum number of added and deleted files in a single commit for which
+	// the similarity-based rename detection runs, similar to cgit's diff.renameLimit.
+	// Exact renames are always detected. 0 disables the limit.
+	MaxFiles int
+
+	// DetectCopies enables searching for the inserted files which are identical to the files
+	// in the previous commit, see DependencyCopies.
+	DetectCopies bool
+
 	repository *git.Repository
 
 	l core.Logger
@@ -55,6 +65,20 @@ const (
 	// computing renames in a single commit.
 	ConfigRenameAnalysisTimeout = "RenameAnalysis.Timeout"
 
+	// ConfigRenameAnalysisMaxFiles is the name of the configuration option
+	// (RenameAnalysis.Configure()) which sets the maximum number of added and deleted files
+	// in a single commit for which the similarity-based rename detection runs.
+	ConfigRenameAnalysisMaxFiles = "RenameAnalysis.MaxFiles"
+
+	// ConfigRenameAnalysisDetectCopies is the name of the configuration option
+	// (RenameAnalysis.Configure()) which enables the detection of copied files.
+	ConfigRenameAnalysisDetectCopies = "RenameAnalysis.DetectCopies"
+
+	// DependencyCopies is the name of the dependency provided by RenameAnalysis. It maps
+	// the paths of the inserted files to the paths of the files which have the same contents
+	// in the previous commit and still exist. It is nil if DetectCopies is disabled.
+	DependencyCopies = "copies"
+
 	// RenameAnalysisMinimumSize is the minimum size of a blob to be considered.
 	RenameAnalysisMinimumSize = 32
 
@@ -79,7 +103,7 @@ func (ra *RenameAnalysis) Name() string {
 // Each produced entity will be inserted into `deps` of dependent Consume()-s according
 // to this list. Also used by core.Registry to build the global map of providers.
 func (ra *RenameAnalysis) Provides() []string {
-	return []string{DependencyTreeChanges}
+	return []string{DependencyTreeChanges, DependencyCopies}
 }
 
 // Requires returns the list of names of entities which are needed by this PipelineItem.
@@ -92,17 +116,32 @@ func (ra *RenameAnalysis) Requires() []string {
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (ra *RenameAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
 	options := [...]core.ConfigurationOption{{
-		Name:        ConfigRenameAnalysisSimilarityThreshold,
-		Description: "The threshold on the similarity index used to detect renames.",
-		Flag:        "M",
-		Type:        core.IntConfigurationOption,
-		Default:     RenameAnalysisDefaultThreshold}, {
+		Name: ConfigRenameAnalysisSimilarityThreshold,
+		Description: "The threshold on the similarity index used to detect renames (0-100). " +
+			"Lower values catch more refactorings but slow down the analysis.",
+		Flag:    "M",
+		Type:    core.IntConfigurationOption,
+		Default: RenameAnalysisDefaultThreshold}, {
 		Name: ConfigRenameAnalysisTimeout,
 		Description: "The maximum time (milliseconds) allowed to spend computing " +
 			"renames in a single commit. 0 sets the default.",
 		Flag:    "renames-timeout",
 		Type:    core.IntConfigurationOption,
-		Default: RenameAnalysisDefaultTimeout},
+		Default: RenameAnalysisDefaultTimeout}, {
+		Name: ConfigRenameAnalysisMaxFiles,
+		Description: "The maximum number of added and deleted files in a single commit " +
+			"to search for renames by similarity. Exact renames are always detected. " +
+			"0 disables the limit.",
+		Flag:    "renames-max-files",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name: ConfigRenameAnalysisDetectCopies,
+		Description: "Detect the files which are copied from other files with the identical " +
+			"contents, so that they keep the line history. Walks the whole tree of each " +
+			"commit with new files.",
+		Flag:    "detect-copies",
+		Type:    core.BoolConfigurationOption,
+		Default: false},
 	}
 	return options[:]
 }
@@ -121,13 +160,24 @@ func (ra *RenameAnalysis) Configure(facts map[string]interface{}) error {
 		}
 		ra.Timeout = time.Duration(val) * time.Millisecond
 	}
+	if val, exists := facts[ConfigRenameAnalysisMaxFiles].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("negative renames detection files limit is not allowed: %d", val)
+		}
+		ra.MaxFiles = val
+	}
+	if val, exists := facts[ConfigRenameAnalysisDetectCopies].(bool); exists {
+		ra.DetectCopies = val
+	}
 	return nil
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (ra *RenameAnalysis) Initialize(repository *git.Repository) error {
-	ra.l = core.NewLogger()
+	if ra.l == nil {
+		ra.l = core.NewLogger()
+	}
 	if ra.SimilarityThreshold < 0 || ra.SimilarityThreshold > 100 {
 		ra.l.Warnf("adjusted the similarity threshold to %d\n",
 			RenameAnalysisDefaultThreshold)
@@ -202,6 +252,14 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 		}
 	}
 
+	if ra.MaxFiles > 0 && len(stillAdded)+len(stillDeleted) > ra.MaxFiles {
+		ra.l.Warnf("%s: too many added and deleted files (%d > %d), skipped the inexact "+
+			"rename detection\n", ra.Name(), len(stillAdded)+len(stillDeleted), ra.MaxFiles)
+		reducedChanges = append(reducedChanges, stillAdded...)
+		reducedChanges = append(reducedChanges, stillDeleted...)
+		return ra.result(reducedChanges, deps)
+	}
+
 	// Stage 2 - apply the similarity threshold
 	// n^2 but actually linear
 	// We sort the blobs by size and do the single linear scan.
@@ -398,7 +456,85 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 	for _, change := range smallChanges {
 		reducedChanges = append(reducedChanges, change)
 	}
-	return map[string]interface{}{DependencyTreeChanges: reducedChanges}, nil
+	return ra.result(reducedChanges, deps)
+}
+
+// result returns the output of Consume() with the copies detected in `changes`.
+func (ra *RenameAnalysis) result(
+	changes object.Changes, deps map[string]interface{}) (map[string]interface{}, error) {
+	var copies map[string]string
+	if ra.DetectCopies {
+		var err error
+		copies, err = detectCopies(changes, deps[core.DependencyCommit].(*object.Commit))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return map[string]interface{}{DependencyTreeChanges: changes, DependencyCopies: copies}, nil
+}
+
+// detectCopies finds the inserted files which have the same contents as the files in
+// the previous commit which still exist. The sources are the unchanged files and the previous
+// versions of the modified or renamed files. The source with the most similar name wins.
+func detectCopies(changes object.Changes, commit *object.Commit) (map[string]string, error) {
+	copies := map[string]string{}
+	var inserted object.Changes
+	touched := map[string]bool{}
+	wanted := map[plumbing.Hash]bool{}
+	for _, change := range changes {
+		if change.To.Name == "" {
+			continue
+		}
+		touched[change.To.Name] = true
+		if change.From.Name == "" {
+			inserted = append(inserted, change)
+			wanted[change.To.TreeEntry.Hash] = true
+		}
+	}
+	if len(inserted) == 0 {
+		return copies, nil
+	}
+	sources := map[plumbing.Hash][]string{}
+	for _, change := range changes {
+		if change.From.Name != "" && change.To.Name != "" && wanted[change.From.TreeEntry.Hash] {
+			sources[change.From.TreeEntry.Hash] = append(
+				sources[change.From.TreeEntry.Hash], change.From.Name)
+		}
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !entry.Mode.IsFile() || touched[name] || !wanted[entry.Hash] {
+			continue
+		}
+		sources[entry.Hash] = append(sources[entry.Hash], name)
+	}
+	for _, change := range inserted {
+		names := sources[change.To.TreeEntry.Hash]
+		if len(names) == 0 {
+			continue
+		}
+		candidates := make([]int, len(names))
+		for i := range candidates {
+			candidates[i] = i
+		}
+		sortRenameCandidates(candidates, filepath.Base(change.To.Name), func(i int) string {
+			return names[i]
+		})
+		copies[change.To.Name] = names[candidates[0]]
+	}
+	return copies, nil
 }
 
 // Fork clones this PipelineItem.
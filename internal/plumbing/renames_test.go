@@ -3,6 +3,7 @@ package plumbing
 import (
 	"bytes"
 	"compress/gzip"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
@@ -26,23 +27,33 @@ func fixtureRenameAnalysis() *RenameAnalysis {
 func TestRenameAnalysisMeta(t *testing.T) {
 	ra := fixtureRenameAnalysis()
 	assert.Equal(t, ra.Name(), "RenameAnalysis")
-	assert.Equal(t, len(ra.Provides()), 1)
+	assert.Equal(t, len(ra.Provides()), 2)
 	assert.Equal(t, ra.Provides()[0], DependencyTreeChanges)
+	assert.Equal(t, ra.Provides()[1], DependencyCopies)
 	assert.Equal(t, len(ra.Requires()), 2)
 	assert.Equal(t, ra.Requires()[0], DependencyBlobCache)
 	assert.Equal(t, ra.Requires()[1], DependencyTreeChanges)
 	opts := ra.ListConfigurationOptions()
-	assert.Len(t, opts, 2)
+	assert.Len(t, opts, 4)
 	assert.Equal(t, opts[0].Name, ConfigRenameAnalysisSimilarityThreshold)
 	assert.Equal(t, opts[1].Name, ConfigRenameAnalysisTimeout)
+	assert.Equal(t, opts[2].Name, ConfigRenameAnalysisMaxFiles)
+	assert.Equal(t, opts[3].Name, ConfigRenameAnalysisDetectCopies)
 	ra.SimilarityThreshold = 0
 
 	assert.NoError(t, ra.Configure(map[string]interface{}{
 		ConfigRenameAnalysisSimilarityThreshold: 70,
 		ConfigRenameAnalysisTimeout:             1000,
+		ConfigRenameAnalysisMaxFiles:            100,
+		ConfigRenameAnalysisDetectCopies:        true,
 	}))
 	assert.Equal(t, ra.SimilarityThreshold, 70)
 	assert.Equal(t, ra.Timeout, time.Second)
+	assert.Equal(t, ra.MaxFiles, 100)
+	assert.True(t, ra.DetectCopies)
+	assert.Error(t, ra.Configure(map[string]interface{}{
+		ConfigRenameAnalysisMaxFiles: -1,
+	}))
 
 	logger := core.NewLogger()
 	assert.NoError(t, ra.Configure(map[string]interface{}{
@@ -152,6 +163,49 @@ func TestRenameAnalysisConsume(t *testing.T) {
 	assert.Equal(t, len(renamed), 3)
 }
 
+func TestRenameAnalysisConsumeSimilar(t *testing.T) {
+	var before, after bytes.Buffer
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&before, "line number %d of the file\n", i)
+		if i%10 == 0 {
+			fmt.Fprintf(&after, "edited line number %d\n", i)
+		} else {
+			fmt.Fprintf(&after, "line number %d of the file\n", i)
+		}
+	}
+	hashBefore := plumbing.NewHash("1111111111111111111111111111111111111111")
+	hashAfter := plumbing.NewHash("2222222222222222222222222222222222222222")
+	cache := map[plumbing.Hash]*CachedBlob{}
+	for hash, data := range map[plumbing.Hash][]byte{
+		hashBefore: before.Bytes(), hashAfter: after.Bytes()} {
+		blob := &CachedBlob{Data: data}
+		blob.Hash = hash
+		blob.Size = int64(len(data))
+		cache[hash] = blob
+	}
+	deps := map[string]interface{}{
+		DependencyBlobCache: cache,
+		DependencyTreeChanges: object.Changes{
+			&object.Change{From: object.ChangeEntry{Name: "old.txt", TreeEntry: object.TreeEntry{
+				Name: "old.txt", Mode: 0100644, Hash: hashBefore}}},
+			&object.Change{To: object.ChangeEntry{Name: "new.txt", TreeEntry: object.TreeEntry{
+				Name: "new.txt", Mode: 0100644, Hash: hashAfter}}},
+		},
+	}
+	ra := fixtureRenameAnalysis()
+	res, err := ra.Consume(deps)
+	assert.NoError(t, err)
+	changes := res[DependencyTreeChanges].(object.Changes)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "old.txt", changes[0].From.Name)
+	assert.Equal(t, "new.txt", changes[0].To.Name)
+
+	ra.MaxFiles = 1
+	res, err = ra.Consume(deps)
+	assert.NoError(t, err)
+	assert.Len(t, res[DependencyTreeChanges].(object.Changes), 2)
+}
+
 func TestSortableChanges(t *testing.T) {
 	changes := sortableChanges{
 		sortableChange{
@@ -221,8 +275,8 @@ func TestBlobsAreCloseFlakyBug(t *testing.T) {
 	if err != nil {
 		t.Fatalf("get baa64828831d174f40140e4b3cfa77d1e917a2c1 %v", err)
 	}
-	blob1 := &CachedBlob{*gitBlob1, nil}
-	blob2 := &CachedBlob{*gitBlob2, nil}
+	blob1 := &CachedBlob{Blob: *gitBlob1}
+	blob2 := &CachedBlob{Blob: *gitBlob2}
 	err = blob1.Cache()
 	if err != nil {
 		t.Fatalf("read 29c9fafd6a2fae8cd20298c3f60115bc31a4c0f2 %v", err)
@@ -371,3 +425,80 @@ func TestBlobsAreCloseBug2(t *testing.T) {
 	assert.Nil(t, err)
 	assert.False(t, result)
 }
+
+func TestRenameAnalysisDetectCopies(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"src/main.go": "a\nb\n", "src/util.go": "c\n", "old.txt": "d\n"}},
+		// exact copy of an unchanged file, copy of the previous version of a modified file,
+		// rename and a new file
+		{Author: "two", When: when, Files: map[string]string{
+			"lib/main.go": "a\nb\n", "src/util.go": "changed\n", "lib/util.go": "c\n",
+			"new.txt": "d\n", "other.go": "e\n"}, Deleted: []string{"old.txt"}},
+	})
+	assert.NoError(t, err)
+	run := func(detect bool) []map[string]interface{} {
+		td := fixtureTreeDiff()
+		assert.NoError(t, td.Initialize(repository))
+		cache := &BlobCache{}
+		assert.NoError(t, cache.Initialize(repository))
+		ra := fixtureRenameAnalysis()
+		assert.NoError(t, ra.Configure(map[string]interface{}{
+			ConfigRenameAnalysisDetectCopies: detect}))
+		var results []map[string]interface{}
+		for _, hash := range hashes {
+			commit, err := repository.CommitObject(hash)
+			assert.NoError(t, err)
+			deps := map[string]interface{}{core.DependencyCommit: commit}
+			res, err := td.Consume(deps)
+			assert.NoError(t, err)
+			deps[DependencyTreeChanges] = res[DependencyTreeChanges]
+			res, err = cache.Consume(deps)
+			assert.NoError(t, err)
+			deps[DependencyBlobCache] = res[DependencyBlobCache]
+			res, err = ra.Consume(deps)
+			assert.NoError(t, err)
+			results = append(results, res)
+		}
+		return results
+	}
+	results := run(false)
+	assert.Nil(t, results[1][DependencyCopies])
+	results = run(true)
+	assert.Equal(t, map[string]string{}, results[0][DependencyCopies])
+	assert.Equal(t, map[string]string{
+		"lib/main.go": "src/main.go", "lib/util.go": "src/util.go"}, results[1][DependencyCopies])
+	changes := results[1][DependencyTreeChanges].(object.Changes)
+	names := map[string]string{}
+	for _, change := range changes {
+		names[change.To.Name] = change.From.Name
+	}
+	// the copies stay insertions
+	assert.Equal(t, map[string]string{
+		"lib/main.go": "", "lib/util.go": "", "src/util.go": "src/util.go",
+		"new.txt": "old.txt", "other.go": ""}, names)
+}
+
+func TestRenameAnalysisDetectCopiesNameSimilarity(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"a/zzz.go": "x\n", "b/copy.go": "x\n"}},
+		{Author: "one", When: when, Files: map[string]string{"c/copy.go": "x\n"}},
+	})
+	assert.NoError(t, err)
+	commit1, err := repository.CommitObject(hashes[0])
+	assert.NoError(t, err)
+	commit2, err := repository.CommitObject(hashes[1])
+	assert.NoError(t, err)
+	tree1, err := commit1.Tree()
+	assert.NoError(t, err)
+	tree2, err := commit2.Tree()
+	assert.NoError(t, err)
+	changes, err := object.DiffTree(tree1, tree2)
+	assert.NoError(t, err)
+	copies, err := detectCopies(changes, commit2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"c/copy.go": "b/copy.go"}, copies)
+}
@@ -1,6 +1,9 @@
 package plumbing
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/src-d/go-git.v4"
@@ -14,11 +17,24 @@ import (
 type TicksSinceStart struct {
 	core.NoopMerger
 	TickSize time.Duration
+	// TimeSource selects the commit timestamps, see core.ConfigTimeSource.
+	TimeSource string
+	// SkewTolerance is how far a commit may go back or ahead in time relative to the previous
+	// commits, or ahead of the current time, before it is considered skewed.
+	SkewTolerance time.Duration
+	// SkewPolicy decides what to do with the skewed commits, see ConfigTicksSinceStartSkewPolicy.
+	SkewPolicy string
 
 	remote       string
 	tick0        *time.Time
 	previousTick int
-	commits      map[int][]plumbing.Hash
+	previousTime time.Time
+	// aheadTime is the timestamp of the last commit which jumped ahead of previousTime.
+	// The next commit confirms the jump if it is ahead too, e.g. after a long pause.
+	aheadTime time.Time
+	commits   map[int][]plumbing.Hash
+	// skewedCommits references Pipeline.skewedCommits, see core.FactPipelineSkewedCommits.
+	skewedCommits map[plumbing.Hash]bool
 
 	l core.Logger
 }
@@ -28,19 +44,97 @@ const (
 	// of ticks since the first commit in the analysed sequence.
 	DependencyTick = "tick"
 
+	// DependencyTickTime is the name of the dependency which TicksSinceStart provides - the time
+	// when the current tick starts, that is, the first commit's time floored to the tick size
+	// plus the tick number multiplied by the tick size.
+	DependencyTickTime = "tick_time"
+
 	// FactCommitsByTick contains the mapping between day indices and the corresponding commits.
 	FactCommitsByTick = "TicksSinceStart.Commits"
 
 	// FactTickSize contains the time.Duration of each tick.
 	FactTickSize = "TicksSinceStart.TickSize"
 
-	// ConfigTicksSinceStartTickSize sets the size of each 'tick' in hours.
+	// ConfigTicksSinceStartTickSize sets the size of each 'tick'. The value is either the int
+	// number of hours, a time.Duration or a string which is parsed with ParseTickSize().
 	ConfigTicksSinceStartTickSize = "TicksSinceStart.TickSize"
 
 	// DefaultTicksSinceStartTickSize is the default number of hours in each 'tick' (24*hour = 1day).
 	DefaultTicksSinceStartTickSize = 24
+
+	// ConfigTicksSinceStartSkewTolerance sets TicksSinceStart.SkewTolerance. The format is
+	// the same as of ConfigTicksSinceStartTickSize.
+	ConfigTicksSinceStartSkewTolerance = "TicksSinceStart.SkewTolerance"
+
+	// DefaultTicksSinceStartSkewTolerance is the default TicksSinceStart.SkewTolerance.
+	DefaultTicksSinceStartSkewTolerance = 7 * 24 * time.Hour
+
+	// ConfigTicksSinceStartSkewPolicy sets what happens to the skewed commits: SkewPolicyWarn,
+	// SkewPolicyClamp or SkewPolicySkip.
+	ConfigTicksSinceStartSkewPolicy = "TicksSinceStart.SkewPolicy"
+
+	// SkewPolicyWarn only reports the skewed commits. The ticks never go back, so a commit
+	// from the past gets the previous tick, while a commit from the future moves the following
+	// commits to its tick.
+	SkewPolicyWarn = "warn"
+
+	// SkewPolicyClamp assigns the previous tick to the skewed commits in both directions.
+	SkewPolicyClamp = "clamp"
+
+	// SkewPolicySkip assigns the previous tick to the skewed commits like SkewPolicyClamp and
+	// additionally leaves them out of FactCommitsByTick.
+	SkewPolicySkip = "skip"
 )
 
+// namedTickSizes are the tick sizes which ParseTickSize() accepts by name.
+var namedTickSizes = map[string]time.Duration{
+	"hour":  time.Hour,
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
+}
+
+// tickSizeSuffixes are the units which ParseTickSize() accepts in addition to time.ParseDuration().
+var tickSizeSuffixes = map[byte]time.Duration{
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// ParseTickSize converts the human readable tick size to time.Duration. It accepts the named
+// units from hour to month (30 days), Go durations such as "12h", the numbers of days or weeks
+// such as "7d" and "2w", and the bare numbers of hours. The result must be a positive whole number
+// of seconds since the YAML output stores the tick size in seconds.
+func ParseTickSize(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if size, exists := namedTickSizes[value]; exists {
+		return size, nil
+	}
+	if value == "" {
+		return 0, fmt.Errorf("empty tick size")
+	}
+	var size time.Duration
+	var err error
+	if hours, errAtoi := strconv.Atoi(value); errAtoi == nil {
+		size = time.Duration(hours) * time.Hour
+	} else if unit, exists := tickSizeSuffixes[value[len(value)-1]]; exists {
+		var number float64
+		number, err = strconv.ParseFloat(value[:len(value)-1], 64)
+		size = time.Duration(number * float64(unit))
+	} else {
+		size, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid tick size %q", value)
+	}
+	if size <= 0 {
+		return 0, fmt.Errorf("the tick size must be positive: %q", value)
+	}
+	if size%time.Second != 0 {
+		return 0, fmt.Errorf("the tick size must be a whole number of seconds: %q", value)
+	}
+	return size, nil
+}
+
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
 func (ticks *TicksSinceStart) Name() string {
 	return "TicksSinceStart"
@@ -50,7 +144,7 @@ func (ticks *TicksSinceStart) Name() string {
 // Each produced entity will be inserted into `deps` of dependent Consume()-s according
 // to this list. Also used by core.Registry to build the global map of providers.
 func (ticks *TicksSinceStart) Provides() []string {
-	return []string{DependencyTick}
+	return []string{DependencyTick, DependencyTickTime}
 }
 
 // Requires returns the list of names of entities which are needed by this PipelineItem.
@@ -63,11 +157,26 @@ func (ticks *TicksSinceStart) Requires() []string {
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (ticks *TicksSinceStart) ListConfigurationOptions() []core.ConfigurationOption {
 	return []core.ConfigurationOption{{
-		Name:        ConfigTicksSinceStartTickSize,
-		Description: "How long each 'tick' represents in hours.",
-		Flag:        "tick-size",
-		Type:        core.IntConfigurationOption,
-		Default:     DefaultTicksSinceStartTickSize},
+		Name: ConfigTicksSinceStartTickSize,
+		Description: "How long each 'tick' represents: a duration such as 12h, 7d or 2w, " +
+			"one of hour, day, week, month (30 days) or the number of hours.",
+		Flag:    "tick-size",
+		Type:    core.StringConfigurationOption,
+		Default: fmt.Sprintf("%dh", DefaultTicksSinceStartTickSize)}, {
+		Name: ConfigTicksSinceStartSkewTolerance,
+		Description: "How far a commit may go back or ahead in time relative to the previous " +
+			"commits, or ahead of the current time, before it is reported as skewed; the format is " +
+			"the same as of --tick-size.",
+		Flag:    "skew-tolerance",
+		Type:    core.StringConfigurationOption,
+		Default: "7d"}, {
+		Name: ConfigTicksSinceStartSkewPolicy,
+		Description: "What to do with the commits with skewed timestamps: \"" + SkewPolicyWarn +
+			"\" only reports them, \"" + SkewPolicyClamp + "\" assigns them the previous tick " +
+			"and \"" + SkewPolicySkip + "\" also excludes them from the commits by tick.",
+		Flag:    "skew-policy",
+		Type:    core.StringConfigurationOption,
+		Default: SkewPolicyWarn},
 	}
 }
 
@@ -76,10 +185,48 @@ func (ticks *TicksSinceStart) Configure(facts map[string]interface{}) error {
 	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
 		ticks.l = l
 	}
-	if val, exists := facts[ConfigTicksSinceStartTickSize].(int); exists {
+	ticks.TickSize = DefaultTicksSinceStartTickSize * time.Hour
+	switch val := facts[ConfigTicksSinceStartTickSize].(type) {
+	case int:
 		ticks.TickSize = time.Duration(val) * time.Hour
-	} else {
-		ticks.TickSize = DefaultTicksSinceStartTickSize * time.Hour
+	case time.Duration:
+		ticks.TickSize = val
+	case string:
+		var err error
+		ticks.TickSize, err = ParseTickSize(val)
+		if err != nil {
+			return err
+		}
+	}
+	if ticks.TickSize <= 0 {
+		return fmt.Errorf("the tick size must be positive: %v", ticks.TickSize)
+	}
+	if val, exists := facts[core.ConfigTimeSource].(string); exists {
+		ticks.TimeSource = val
+	}
+	ticks.SkewTolerance = DefaultTicksSinceStartSkewTolerance
+	switch val := facts[ConfigTicksSinceStartSkewTolerance].(type) {
+	case time.Duration:
+		ticks.SkewTolerance = val
+	case string:
+		var err error
+		ticks.SkewTolerance, err = ParseTickSize(val)
+		if err != nil {
+			return fmt.Errorf("invalid skew tolerance: %v", err)
+		}
+	}
+	ticks.SkewPolicy = SkewPolicyWarn
+	if val, exists := facts[ConfigTicksSinceStartSkewPolicy].(string); exists && val != "" {
+		switch val {
+		case SkewPolicyWarn, SkewPolicyClamp, SkewPolicySkip:
+			ticks.SkewPolicy = val
+		default:
+			return fmt.Errorf("--skew-policy must be one of %s, %s or %s (got %s)",
+				SkewPolicyWarn, SkewPolicyClamp, SkewPolicySkip, val)
+		}
+	}
+	if val, exists := facts[core.FactPipelineSkewedCommits].(map[plumbing.Hash]bool); exists {
+		ticks.skewedCommits = val
 	}
 	if ticks.commits == nil {
 		ticks.commits = map[int][]plumbing.Hash{}
@@ -92,12 +239,25 @@ func (ticks *TicksSinceStart) Configure(facts map[string]interface{}) error {
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (ticks *TicksSinceStart) Initialize(repository *git.Repository) error {
-	ticks.l = core.NewLogger()
+	if ticks.l == nil {
+		ticks.l = core.NewLogger()
+	}
 	if ticks.TickSize == 0 {
 		ticks.TickSize = DefaultTicksSinceStartTickSize * time.Hour
 	}
+	if ticks.SkewTolerance == 0 {
+		ticks.SkewTolerance = DefaultTicksSinceStartSkewTolerance
+	}
+	if ticks.SkewPolicy == "" {
+		ticks.SkewPolicy = SkewPolicyWarn
+	}
+	if ticks.skewedCommits == nil {
+		ticks.skewedCommits = map[plumbing.Hash]bool{}
+	}
 	ticks.tick0 = &time.Time{}
 	ticks.previousTick = 0
+	ticks.previousTime = time.Time{}
+	ticks.aheadTime = time.Time{}
 	if len(ticks.commits) > 0 {
 		keys := make([]int, len(ticks.commits))
 		for key := range ticks.commits {
@@ -119,24 +279,44 @@ func (ticks *TicksSinceStart) Initialize(repository *git.Repository) error {
 func (ticks *TicksSinceStart) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
 	commit := deps[core.DependencyCommit].(*object.Commit)
 	index := deps[core.DependencyIndex].(int)
+	commitTime := core.CommitTime(commit, ticks.TimeSource)
 	if index == 0 {
 		// first iteration - initialize the file objects from the tree
 		// our precision is 1 day
-		tick0 := commit.Committer.When
-		if tick0.Unix() < 631152000 { // 01.01.1990, that was 30 years ago
-			ticks.l.Warnf("suspicious committer timestamp in %s > %s: %d",
-				ticks.remote, commit.Hash.String(), tick0.Unix())
+		if commitTime.Unix() < 631152000 { // 01.01.1990, that was 30 years ago
+			ticks.l.Warnf("suspicious commit timestamp in %s > %s: %d",
+				ticks.remote, commit.Hash.String(), commitTime.Unix())
 		}
-		*ticks.tick0 = FloorTime(tick0, ticks.TickSize)
+		*ticks.tick0 = FloorTime(commitTime, ticks.TickSize)
+		ticks.previousTime = commitTime
 	}
 
-	tick := int(commit.Committer.When.Sub(*ticks.tick0) / ticks.TickSize)
+	tick := int(commitTime.Sub(*ticks.tick0) / ticks.TickSize)
+	skewed := ticks.isSkewed(commit, commitTime)
+	if skewed && ticks.SkewPolicy != SkewPolicyWarn {
+		tick = ticks.previousTick
+	}
 	if tick < ticks.previousTick {
 		// rebase works miracles, but we need the monotonous time
 		tick = ticks.previousTick
 	}
+	if !skewed && commitTime.After(ticks.previousTime) {
+		ticks.previousTime = commitTime
+	}
 
 	ticks.previousTick = tick
+	if !skewed || ticks.SkewPolicy != SkewPolicySkip {
+		ticks.addCommit(tick, commit)
+	}
+
+	return map[string]interface{}{
+		DependencyTick:     tick,
+		DependencyTickTime: ticks.tick0.Add(time.Duration(tick) * ticks.TickSize),
+	}, nil
+}
+
+// addCommit appends the commit to FactCommitsByTick unless it is already there.
+func (ticks *TicksSinceStart) addCommit(tick int, commit *object.Commit) {
 	tickCommits := ticks.commits[tick]
 	if tickCommits == nil {
 		tickCommits = []plumbing.Hash{}
@@ -154,8 +334,36 @@ func (ticks *TicksSinceStart) Consume(deps map[string]interface{}) (map[string]i
 	if !exists {
 		ticks.commits[tick] = append(tickCommits, commit.Hash)
 	}
+}
 
-	return map[string]interface{}{DependencyTick: tick}, nil
+// isSkewed returns true if the commit goes back or ahead in time relative to the previous
+// commits, or ahead of the current time, by more than SkewTolerance. A jump ahead is not
+// skewed if the next commit jumps ahead too: the history really paused. The skewed commits
+// are reported once even though the merge commits are consumed by several branches.
+func (ticks *TicksSinceStart) isSkewed(commit *object.Commit, commitTime time.Time) bool {
+	var direction string
+	aheadTime := ticks.aheadTime
+	ticks.aheadTime = time.Time{}
+	if ticks.previousTime.Sub(commitTime) > ticks.SkewTolerance {
+		direction = "back"
+	} else if commitTime.Sub(time.Now()) > ticks.SkewTolerance {
+		direction = "into the future"
+	} else if commitTime.Sub(ticks.previousTime) > ticks.SkewTolerance {
+		if !aheadTime.IsZero() && aheadTime.Sub(commitTime) <= ticks.SkewTolerance {
+			return false
+		}
+		ticks.aheadTime = commitTime
+		direction = "ahead"
+	} else {
+		return false
+	}
+	if !ticks.skewedCommits[commit.Hash] {
+		ticks.skewedCommits[commit.Hash] = true
+		ticks.l.Warnf("skewed commit timestamp in %s > %s: %s jumps %s from %s",
+			ticks.remote, commit.Hash.String(), commitTime.Format(time.RFC3339), direction,
+			ticks.previousTime.Format(time.RFC3339))
+	}
+	return true
 }
 
 // Fork clones this PipelineItem.
@@ -27,10 +27,11 @@ func fixtureTicksSinceStart(config ...map[string]interface{}) *TicksSinceStart {
 func TestTicksSinceStartMeta(t *testing.T) {
 	tss := fixtureTicksSinceStart()
 	assert.Equal(t, tss.Name(), "TicksSinceStart")
-	assert.Equal(t, len(tss.Provides()), 1)
+	assert.Equal(t, len(tss.Provides()), 2)
 	assert.Equal(t, tss.Provides()[0], DependencyTick)
+	assert.Equal(t, tss.Provides()[1], DependencyTickTime)
 	assert.Equal(t, len(tss.Requires()), 0)
-	assert.Len(t, tss.ListConfigurationOptions(), 1)
+	assert.Len(t, tss.ListConfigurationOptions(), 3)
 	logger := core.NewLogger()
 	assert.NoError(t, tss.Configure(map[string]interface{}{
 		core.ConfigLogger: logger,
@@ -38,6 +39,54 @@ func TestTicksSinceStartMeta(t *testing.T) {
 	assert.Equal(t, logger, tss.l)
 }
 
+func TestTicksSinceStartConfigureTickSize(t *testing.T) {
+	tss := &TicksSinceStart{}
+	facts := map[string]interface{}{}
+	assert.NoError(t, tss.Configure(facts))
+	assert.Equal(t, 24*time.Hour, tss.TickSize)
+	assert.Equal(t, 24*time.Hour, facts[FactTickSize])
+	facts[ConfigTicksSinceStartTickSize] = 12
+	assert.NoError(t, tss.Configure(facts))
+	assert.Equal(t, 12*time.Hour, tss.TickSize)
+	facts[ConfigTicksSinceStartTickSize] = "7d"
+	assert.NoError(t, tss.Configure(facts))
+	assert.Equal(t, 7*24*time.Hour, tss.TickSize)
+	assert.Equal(t, 7*24*time.Hour, facts[FactTickSize])
+	facts[ConfigTicksSinceStartTickSize] = 90 * time.Minute
+	assert.NoError(t, tss.Configure(facts))
+	assert.Equal(t, 90*time.Minute, tss.TickSize)
+	facts[ConfigTicksSinceStartTickSize] = "fortnight"
+	assert.Error(t, tss.Configure(facts))
+	facts[ConfigTicksSinceStartTickSize] = -1
+	assert.Error(t, tss.Configure(facts))
+}
+
+func TestParseTickSize(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"24h":    24 * time.Hour,
+		"168h":   168 * time.Hour,
+		"7d":     7 * 24 * time.Hour,
+		"1.5d":   36 * time.Hour,
+		"2w":     14 * 24 * time.Hour,
+		"day":    24 * time.Hour,
+		" Week ": 7 * 24 * time.Hour,
+		"month":  30 * 24 * time.Hour,
+		"hour":   time.Hour,
+		"48":     48 * time.Hour,
+		"90m":    90 * time.Minute,
+	} {
+		size, err := ParseTickSize(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, size, value)
+		// the YAML output stores the tick size in seconds
+		assert.Equal(t, size, time.Duration(int(size.Seconds()))*time.Second, value)
+	}
+	for _, value := range []string{"", "d", "xd", "0", "-1h", "0w", "1.5s", "10ms", "year"} {
+		_, err := ParseTickSize(value)
+		assert.Error(t, err, value)
+	}
+}
+
 func TestTicksSinceStartRegistration(t *testing.T) {
 	summoned := core.Registry.Summon((&TicksSinceStart{}).Name())
 	assert.Len(t, summoned, 1)
@@ -138,6 +187,7 @@ func TestTicksSinceStartConsumeWithTickSize(t *testing.T) {
 	res, err := tss.Consume(deps)
 	assert.Nil(t, err)
 	assert.Equal(t, 0, res[DependencyTick].(int))
+	assert.Equal(t, *tss.tick0, res[DependencyTickTime].(time.Time))
 	assert.Equal(t, 0, tss.previousTick)
 	assert.Equal(t, 18, tss.tick0.Hour())  // 18 UTC+1
 	assert.Equal(t, 0, tss.tick0.Minute()) // 30
@@ -150,6 +200,7 @@ func TestTicksSinceStartConsumeWithTickSize(t *testing.T) {
 	res, err = tss.Consume(deps)
 	assert.Nil(t, err)
 	assert.Equal(t, 24, res[DependencyTick].(int)) // 1 day later
+	assert.Equal(t, tss.tick0.Add(24*time.Hour), res[DependencyTickTime].(time.Time))
 	assert.Equal(t, 24, tss.previousTick)
 
 	commit, _ = test.Repository.CommitObject(plumbing.NewHash(
@@ -171,6 +222,185 @@ func TestTicksSinceStartConsumeWithTickSize(t *testing.T) {
 		tss.commits[24])
 }
 
+func TestTicksSinceStartTickTime(t *testing.T) {
+	tss := fixtureTicksSinceStart(map[string]interface{}{
+		ConfigTicksSinceStartTickSize: "12h",
+	})
+	start := time.Date(2019, 3, 1, 15, 30, 0, 0, time.UTC)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: start},
+		{Author: "one", When: start.Add(50 * time.Hour)},
+	})
+	assert.NoError(t, err)
+	var times []time.Time
+	for i, hash := range hashes {
+		commit, err := repository.CommitObject(hash)
+		assert.NoError(t, err)
+		res, err := tss.Consume(map[string]interface{}{
+			core.DependencyCommit: commit,
+			core.DependencyIndex:  i,
+		})
+		assert.NoError(t, err)
+		times = append(times, res[DependencyTickTime].(time.Time))
+	}
+	assert.True(t, times[0].Equal(time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)), times[0])
+	// tick 4
+	assert.True(t, times[1].Equal(time.Date(2019, 3, 3, 12, 0, 0, 0, time.UTC)), times[1])
+}
+
+func TestTicksSinceStartTimeSource(t *testing.T) {
+	start := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	// the last two commits were written on the second and the third day and rebased on the fourth
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: start},
+		{Author: "one", When: start.Add(day), CommitterWhen: start.Add(3 * day)},
+		{Author: "one", When: start.Add(2 * day), CommitterWhen: start.Add(3*day + time.Hour)},
+	})
+	assert.NoError(t, err)
+	for source, expected := range map[string][]int{
+		"":                       {0, 3, 3},
+		core.TimeSourceCommitter: {0, 3, 3},
+		core.TimeSourceAuthor:    {0, 1, 2},
+	} {
+		tss := fixtureTicksSinceStart(map[string]interface{}{core.ConfigTimeSource: source})
+		assert.Equal(t, source, tss.TimeSource)
+		var ticks []int
+		for i, hash := range hashes {
+			commit, err := repository.CommitObject(hash)
+			assert.NoError(t, err)
+			res, err := tss.Consume(map[string]interface{}{
+				core.DependencyCommit: commit,
+				core.DependencyIndex:  i,
+			})
+			assert.NoError(t, err)
+			ticks = append(ticks, res[DependencyTick].(int))
+		}
+		assert.Equal(t, expected, ticks, source)
+	}
+}
+
+func TestTicksSinceStartConfigureSkew(t *testing.T) {
+	tss := &TicksSinceStart{}
+	facts := map[string]interface{}{}
+	assert.NoError(t, tss.Configure(facts))
+	assert.Equal(t, DefaultTicksSinceStartSkewTolerance, tss.SkewTolerance)
+	assert.Equal(t, SkewPolicyWarn, tss.SkewPolicy)
+	facts[ConfigTicksSinceStartSkewTolerance] = "2w"
+	facts[ConfigTicksSinceStartSkewPolicy] = SkewPolicySkip
+	assert.NoError(t, tss.Configure(facts))
+	assert.Equal(t, 14*24*time.Hour, tss.SkewTolerance)
+	assert.Equal(t, SkewPolicySkip, tss.SkewPolicy)
+	facts[ConfigTicksSinceStartSkewTolerance] = "never"
+	assert.Error(t, tss.Configure(facts))
+	facts[ConfigTicksSinceStartSkewTolerance] = time.Hour
+	facts[ConfigTicksSinceStartSkewPolicy] = "ignore"
+	assert.EqualError(t, tss.Configure(facts),
+		"--skew-policy must be one of warn, clamp or skip (got ignore)")
+}
+
+func TestTicksSinceStartSkew(t *testing.T) {
+	start := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	future := time.Now().Add(365 * day)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: start},
+		{Author: "one", When: start.Add(day)},
+		{Author: "one", When: start.Add(-30 * day)},
+		{Author: "one", When: start.Add(2 * day)},
+		{Author: "one", When: future},
+		{Author: "one", When: start.Add(3 * day)},
+	})
+	assert.NoError(t, err)
+	futureTick := int(FloorTime(future, day).Sub(start.Truncate(day)) / day)
+	for policy, expected := range map[string][]int{
+		SkewPolicyWarn:  {0, 1, 1, 2, futureTick, futureTick},
+		SkewPolicyClamp: {0, 1, 1, 2, 2, 3},
+		SkewPolicySkip:  {0, 1, 1, 2, 2, 3},
+	} {
+		skewed := map[plumbing.Hash]bool{}
+		tss := fixtureTicksSinceStart(map[string]interface{}{
+			ConfigTicksSinceStartSkewPolicy: policy,
+			core.FactPipelineSkewedCommits:  skewed,
+		})
+		var capture bytes.Buffer
+		tss.l.(*core.DefaultLogger).W.SetOutput(&capture)
+		var ticks []int
+		for i, hash := range hashes {
+			commit, err := repository.CommitObject(hash)
+			assert.NoError(t, err)
+			res, err := tss.Consume(map[string]interface{}{
+				core.DependencyCommit: commit,
+				core.DependencyIndex:  i,
+			})
+			assert.NoError(t, err)
+			ticks = append(ticks, res[DependencyTick].(int))
+		}
+		assert.Equal(t, expected, ticks, policy)
+		assert.Equal(t, map[plumbing.Hash]bool{hashes[2]: true, hashes[4]: true}, skewed, policy)
+		output := capture.String()
+		assert.Contains(t, output, hashes[2].String()+": 2019-01-30T12:00:00Z jumps back", policy)
+		assert.Contains(t, output, hashes[4].String(), policy)
+		assert.Contains(t, output, "into the future", policy)
+		commits := 0
+		for _, tickCommits := range tss.commits {
+			commits += len(tickCommits)
+		}
+		if policy == SkewPolicySkip {
+			assert.Equal(t, 4, commits)
+			assert.NotContains(t, tss.commits[1], hashes[2])
+		} else {
+			assert.Equal(t, 6, commits, policy)
+		}
+	}
+}
+
+func TestTicksSinceStartSkewAhead(t *testing.T) {
+	start := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: start},
+		{Author: "one", When: start.Add(day)},
+		{Author: "one", When: start.Add(400 * day)},
+		{Author: "one", When: start.Add(2 * day)},
+		{Author: "one", When: start.Add(3 * day)},
+		// a real pause: two commits in a row jump ahead
+		{Author: "one", When: start.Add(30 * day)},
+		{Author: "one", When: start.Add(31 * day)},
+		{Author: "one", When: start.Add(32 * day)},
+	})
+	assert.NoError(t, err)
+	for policy, expected := range map[string][]int{
+		SkewPolicyWarn:  {0, 1, 400, 400, 400, 400, 400, 400},
+		SkewPolicyClamp: {0, 1, 1, 2, 3, 3, 31, 32},
+		SkewPolicySkip:  {0, 1, 1, 2, 3, 3, 31, 32},
+	} {
+		skewed := map[plumbing.Hash]bool{}
+		tss := fixtureTicksSinceStart(map[string]interface{}{
+			ConfigTicksSinceStartSkewPolicy: policy,
+			core.FactPipelineSkewedCommits:  skewed,
+		})
+		var capture bytes.Buffer
+		tss.l.(*core.DefaultLogger).W.SetOutput(&capture)
+		var ticks []int
+		for i, hash := range hashes {
+			commit, err := repository.CommitObject(hash)
+			assert.NoError(t, err)
+			res, err := tss.Consume(map[string]interface{}{
+				core.DependencyCommit: commit,
+				core.DependencyIndex:  i,
+			})
+			assert.NoError(t, err)
+			ticks = append(ticks, res[DependencyTick].(int))
+		}
+		assert.Equal(t, expected, ticks, policy)
+		assert.Equal(t, map[plumbing.Hash]bool{hashes[2]: true, hashes[5]: true}, skewed, policy)
+		output := capture.String()
+		assert.Contains(t, output, hashes[2].String()+": 2020-04-04T12:00:00Z jumps ahead", policy)
+		assert.NotContains(t, output, "jumps back", policy)
+	}
+}
+
 func TestTicksCommits(t *testing.T) {
 	tss := fixtureTicksSinceStart()
 	tss.commits[0] = []plumbing.Hash{plumbing.NewHash(
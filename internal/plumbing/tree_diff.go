@@ -5,11 +5,13 @@ import (
 	"io"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/src-d/enry/v2"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
 
 	"gopkg.in/src-d/hercules.v10/internal/core"
@@ -26,10 +28,40 @@ type TreeDiff struct {
 	// Languages is the set of allowed languages. The values must be lower case. The default
 	// (empty) set disables the language filter.
 	Languages map[string]bool
+	// PathPrefix limits the analysis to the files under the specified directory.
+	// The empty string disables this filter.
+	PathPrefix string
+	// ExcludeGlobs is the list of glob patterns of the files which must never be analyzed.
+	// "**" matches any number of directories, patterns without "/" match the file name
+	// or the name of any parent directory.
+	ExcludeGlobs []string
+	// IncludeExtensions is the list of file extensions to analyze, lower case with the leading
+	// dot, e.g. ".go". The files with other or without extensions are ignored. The empty list
+	// disables this filter.
+	IncludeExtensions []string
+	// PathCaseInsensitive converts the file paths to lower case so that the names which differ
+	// only in case, e.g. committed from macOS and Windows, refer to the same file.
+	PathCaseInsensitive bool
+	// HandleSubmodules defines what to do with the submodule gitlinks: SubmodulesIgnore drops
+	// them from the changes, SubmodulesCountAsFile passes them as files without contents.
+	HandleSubmodules string
+	// HandleSymlinks defines what to do with the symbolic links: SymlinksIgnore drops them
+	// from the changes, SymlinksCount passes them as files which contain the target path.
+	HandleSymlinks string
 
 	previousTree   *object.Tree
 	previousCommit plumbing.Hash
 	repository     *git.Repository
+	// skippedCommits references Pipeline.skippedCommits, see core.FactPipelineSkippedCommits.
+	skippedCommits map[plumbing.Hash][]plumbing.Hash
+	// failedCommits references Pipeline.failedCommits, see core.FactPipelineFailedCommits.
+	failedCommits map[plumbing.Hash]bool
+	// excludedPaths is the set of distinct paths filtered by ExcludeGlobs, shared among the forks.
+	excludedPaths map[string]bool
+	// pathCases maps the paths to their names with PathCaseInsensitive, see normalizePathCase().
+	pathCases map[string]string
+	// lowerPaths maps the lower case paths to the set of the original paths.
+	lowerPaths map[string]map[string]bool
 
 	l core.Logger
 }
@@ -55,6 +87,40 @@ const (
 	// ConfigTreeDiffFilterRegexp is the name of the configuration option
 	// (TreeDiff.Configure()) which makes FileDiff consider only those files which have names matching this regexp.
 	ConfigTreeDiffFilterRegexp = "TreeDiff.FilteredRegexes"
+
+	// ConfigTreeDiffPathPrefix is the name of the configuration option (TreeDiff.Configure())
+	// which limits the analysis to the files under the specified directory.
+	ConfigTreeDiffPathPrefix = "TreeDiff.PathPrefix"
+
+	// ConfigTreeDiffExcludeGlobs is the name of the configuration option (TreeDiff.Configure())
+	// which sets the glob patterns of the files to exclude from the analysis.
+	ConfigTreeDiffExcludeGlobs = "TreeDiff.ExcludeGlobs"
+
+	// ConfigTreeDiffPathCaseInsensitive is the name of the configuration option
+	// (TreeDiff.Configure()) which makes the file paths case-insensitive.
+	ConfigTreeDiffPathCaseInsensitive = "TreeDiff.PathCaseInsensitive"
+
+	// ConfigTreeDiffIncludeExtensions is the name of the configuration option
+	// (TreeDiff.Configure()) which sets the file extensions to analyze.
+	ConfigTreeDiffIncludeExtensions = "TreeDiff.IncludeExtensions"
+
+	// ConfigTreeDiffHandleSubmodules is the name of the configuration option
+	// (TreeDiff.Configure()) which sets TreeDiff.HandleSubmodules.
+	ConfigTreeDiffHandleSubmodules = "TreeDiff.HandleSubmodules"
+	// SubmodulesIgnore is the value of ConfigTreeDiffHandleSubmodules to drop the gitlinks.
+	SubmodulesIgnore = "ignore"
+	// SubmodulesCountAsFile is the value of ConfigTreeDiffHandleSubmodules to treat each gitlink
+	// as an empty file which changes whenever the submodule pointer is updated.
+	SubmodulesCountAsFile = "count-as-file"
+
+	// ConfigTreeDiffHandleSymlinks is the name of the configuration option
+	// (TreeDiff.Configure()) which sets TreeDiff.HandleSymlinks.
+	ConfigTreeDiffHandleSymlinks = "TreeDiff.HandleSymlinks"
+	// SymlinksIgnore is the value of ConfigTreeDiffHandleSymlinks to drop the symbolic links.
+	SymlinksIgnore = "ignore"
+	// SymlinksCount is the value of ConfigTreeDiffHandleSymlinks to treat each symbolic link
+	// as a one-line file with the target path.
+	SymlinksCount = "count"
 )
 
 // defaultBlacklistedPrefixes is the list of file path prefixes which should be skipped by default.
@@ -116,7 +182,50 @@ func (treediff *TreeDiff) ListConfigurationOptions() []core.ConfigurationOption
 		Description: "Whitelist regexp to determine which files to analyze.",
 		Flag:        "whitelist",
 		Type:        core.StringConfigurationOption,
-		Default:     ""},
+		Default:     ""}, {
+
+		Name: ConfigTreeDiffPathPrefix,
+		Description: "Analyze only the files under this directory. Files moved across the " +
+			"boundary are treated as inserted or deleted. The value is in the UNIX format " +
+			"(\"path/to/dir\").",
+		Flag:    "path-prefix",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
+
+		Name: ConfigTreeDiffExcludeGlobs,
+		Description: "Glob pattern of the files to exclude from the analysis, e.g. \"*.pb.go\" " +
+			"or \"node_modules/\". \"**\" matches any number of directories. May be repeated.",
+		Flag:    "exclude",
+		Type:    core.StringsConfigurationOption,
+		Default: []string{}}, {
+
+		Name: ConfigTreeDiffPathCaseInsensitive,
+		Description: "Treat the file paths which differ only in case as the same file. " +
+			"The paths are reported in lower case.",
+		Flag:    "path-case-insensitive",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+
+		Name: ConfigTreeDiffIncludeExtensions,
+		Description: "Analyze only the files with the specified extensions, e.g. \"go,py,js\". " +
+			"The comparison is case-insensitive.",
+		Flag:    "include-ext",
+		Type:    core.StringsConfigurationOption,
+		Default: []string{}}, {
+
+		Name: ConfigTreeDiffHandleSubmodules,
+		Description: "How to handle the submodule pointers: \"" + SubmodulesIgnore +
+			"\" skips them, \"" + SubmodulesCountAsFile + "\" treats them as empty files.",
+		Flag:    "submodules",
+		Type:    core.StringConfigurationOption,
+		Default: SubmodulesIgnore}, {
+
+		Name: ConfigTreeDiffHandleSymlinks,
+		Description: "How to handle the symbolic links: \"" + SymlinksIgnore +
+			"\" skips them, \"" + SymlinksCount + "\" counts the target paths as the contents.",
+		Flag:    "symlinks",
+		Type:    core.StringConfigurationOption,
+		Default: SymlinksIgnore},
 	}
 	return options[:]
 }
@@ -139,25 +248,137 @@ func (treediff *TreeDiff) Configure(facts map[string]interface{}) error {
 		treediff.Languages[allLanguages] = true
 	}
 
+	if val, exists := facts[core.FactPipelineSkippedCommits].(map[plumbing.Hash][]plumbing.Hash); exists {
+		treediff.skippedCommits = val
+	}
+	if val, exists := facts[core.FactPipelineFailedCommits].(map[plumbing.Hash]bool); exists {
+		treediff.failedCommits = val
+	}
 	if val, exists := facts[ConfigTreeDiffFilterRegexp].(string); exists {
 		treediff.NameFilter = regexp.MustCompile(val)
 	}
+	if val, exists := facts[ConfigTreeDiffPathPrefix].(string); exists {
+		treediff.PathPrefix = normalizePathPrefix(val)
+	}
+	if val, exists := facts[ConfigTreeDiffExcludeGlobs].([]string); exists {
+		treediff.ExcludeGlobs = nil
+		for _, glob := range val {
+			glob = strings.TrimSpace(glob)
+			if glob == "" {
+				continue
+			}
+			if _, err := path.Match(glob, ""); err != nil {
+				return fmt.Errorf("invalid exclusion pattern %q: %v", glob, err)
+			}
+			treediff.ExcludeGlobs = append(treediff.ExcludeGlobs, glob)
+		}
+	}
+	if val, exists := facts[ConfigTreeDiffPathCaseInsensitive].(bool); exists {
+		treediff.PathCaseInsensitive = val
+	}
+	if val, exists := facts[ConfigTreeDiffIncludeExtensions].([]string); exists {
+		treediff.IncludeExtensions = nil
+		for _, ext := range val {
+			ext = strings.ToLower(strings.TrimSpace(ext))
+			if ext == "" || ext == "." {
+				continue
+			}
+			if !strings.HasPrefix(ext, ".") {
+				ext = "." + ext
+			}
+			treediff.IncludeExtensions = append(treediff.IncludeExtensions, ext)
+		}
+	}
+	if val, exists := facts[ConfigTreeDiffHandleSubmodules].(string); exists {
+		if val != SubmodulesIgnore && val != SubmodulesCountAsFile {
+			return fmt.Errorf("%s must be either \"%s\" or \"%s\": %s",
+				ConfigTreeDiffHandleSubmodules, SubmodulesIgnore, SubmodulesCountAsFile, val)
+		}
+		treediff.HandleSubmodules = val
+	}
+	if val, exists := facts[ConfigTreeDiffHandleSymlinks].(string); exists {
+		if val != SymlinksIgnore && val != SymlinksCount {
+			return fmt.Errorf("%s must be either \"%s\" or \"%s\": %s",
+				ConfigTreeDiffHandleSymlinks, SymlinksIgnore, SymlinksCount, val)
+		}
+		treediff.HandleSymlinks = val
+	}
 	return nil
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (treediff *TreeDiff) Initialize(repository *git.Repository) error {
-	treediff.l = core.NewLogger()
+	if treediff.l == nil {
+		treediff.l = core.NewLogger()
+	}
 	treediff.previousTree = nil
 	treediff.repository = repository
+	treediff.excludedPaths = map[string]bool{}
+	treediff.pathCases = map[string]string{}
+	treediff.lowerPaths = map[string]map[string]bool{}
 	if treediff.Languages == nil {
 		treediff.Languages = map[string]bool{}
 		treediff.Languages[allLanguages] = true
 	}
+	if treediff.HandleSubmodules == "" {
+		treediff.HandleSubmodules = SubmodulesIgnore
+	}
+	if treediff.HandleSymlinks == "" {
+		treediff.HandleSymlinks = SymlinksIgnore
+	}
 	return nil
 }
 
+// followsPreviousCommit checks whether `commit` is a child of the previously consumed commit,
+// possibly through the commits skipped by the pipeline.
+func (treediff *TreeDiff) followsPreviousCommit(commit *object.Commit) bool {
+	if treediff.previousCommit == plumbing.ZeroHash {
+		return true
+	}
+	visited := map[plumbing.Hash]bool{}
+	queue := append([]plumbing.Hash{}, commit.ParentHashes...)
+	for len(queue) > 0 {
+		hash := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if hash == treediff.previousCommit {
+			return true
+		}
+		if visited[hash] {
+			continue
+		}
+		visited[hash] = true
+		queue = append(queue, treediff.skippedCommits[hash]...)
+	}
+	return false
+}
+
+// rebaseOnFailedParent makes the next diff start from the tree of the parent of `commit` which
+// failed to be analysed before this TreeDiff consumed it, so that the changes of the failed
+// commit are not attributed to `commit`. It returns false if there is no such parent.
+func (treediff *TreeDiff) rebaseOnFailedParent(commit *object.Commit) (bool, error) {
+	if treediff.previousCommit != plumbing.ZeroHash && treediff.followsPreviousCommit(commit) {
+		return false, nil
+	}
+	for i, hash := range commit.ParentHashes {
+		if !treediff.failedCommits[hash] {
+			continue
+		}
+		parent, err := commit.Parent(i)
+		if err != nil {
+			return false, err
+		}
+		tree, err := parent.Tree()
+		if err != nil {
+			return false, err
+		}
+		treediff.previousTree = tree
+		treediff.previousCommit = hash
+		return true, nil
+	}
+	return false, nil
+}
+
 // Consume runs this PipelineItem on the next commit data.
 // `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
 // Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
@@ -165,13 +386,11 @@ func (treediff *TreeDiff) Initialize(repository *git.Repository) error {
 // in Provides(). If there was an error, nil is returned.
 func (treediff *TreeDiff) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
 	commit := deps[core.DependencyCommit].(*object.Commit)
-	pass := false
-	for _, hash := range commit.ParentHashes {
-		if hash == treediff.previousCommit {
-			pass = true
-		}
+	rebased, err := treediff.rebaseOnFailedParent(commit)
+	if err != nil {
+		return nil, err
 	}
-	if !pass && treediff.previousCommit != plumbing.ZeroHash {
+	if !rebased && !treediff.followsPreviousCommit(commit) {
 		err := fmt.Errorf("%s > %s", treediff.previousCommit.String(), commit.Hash.String())
 		treediff.l.Critical(err)
 		return nil, err
@@ -215,10 +434,19 @@ func (treediff *TreeDiff) Consume(deps map[string]interface{}) (map[string]inter
 		if err != nil {
 			return nil, err
 		}
+		if treediff.HandleSubmodules == SubmodulesCountAsFile {
+			// tree.Files() skips the gitlinks
+			if diffs, err = appendSubmodules(diffs, tree); err != nil {
+				return nil, err
+			}
+		}
 	}
 	treediff.previousTree = tree
 	treediff.previousCommit = commit.Hash
 	diffs = treediff.filterDiffs(diffs)
+	if treediff.PathCaseInsensitive {
+		diffs = treediff.normalizePathCase(diffs, tree)
+	}
 	return map[string]interface{}{DependencyTreeChanges: diffs}, nil
 }
 
@@ -227,6 +455,21 @@ func (treediff *TreeDiff) filterDiffs(diffs object.Changes) object.Changes {
 	filteredDiffs := make(object.Changes, 0, len(diffs))
 OUTER:
 	for _, change := range diffs {
+		if change = treediff.filterSubmodules(change); change == nil {
+			continue
+		}
+		if change = treediff.filterSymlinks(change); change == nil {
+			continue
+		}
+		if change = treediff.filterPathPrefix(change); change == nil {
+			continue
+		}
+		if change = treediff.filterExcludeGlobs(change); change == nil {
+			continue
+		}
+		if change = treediff.filterIncludeExtensions(change); change == nil {
+			continue
+		}
 		if len(treediff.SkipFiles) > 0 && (enry.IsVendor(change.To.Name) || enry.IsVendor(change.From.Name)) {
 			continue
 		}
@@ -257,9 +500,363 @@ OUTER:
 	return filteredDiffs
 }
 
+// FilterFile returns whether the file would pass the configured filters if it was inserted.
+// It implements core.FileFilterPipelineItem and does not count the excluded paths.
+func (treediff *TreeDiff) FilterFile(tree *object.Tree, name string, entry *object.TreeEntry) bool {
+	excludedPaths := treediff.excludedPaths
+	treediff.excludedPaths = nil
+	defer func() { treediff.excludedPaths = excludedPaths }()
+	change := &object.Change{To: object.ChangeEntry{Name: name, Tree: tree, TreeEntry: *entry}}
+	return len(treediff.filterDiffs(object.Changes{change})) == 1
+}
+
+// filterSubmodules returns the part of the change which is not a submodule gitlink or nil
+// if the change is completely about gitlinks, unless HandleSubmodules is SubmodulesCountAsFile.
+// Replacing a file with a submodule is converted to a deletion and vice versa.
+func (treediff *TreeDiff) filterSubmodules(change *object.Change) *object.Change {
+	if treediff.HandleSubmodules == SubmodulesCountAsFile {
+		return change
+	}
+	return filterMode(change, filemode.Submodule)
+}
+
+// filterSymlinks returns the part of the change which is not a symbolic link or nil
+// if the change is completely about symbolic links, unless HandleSymlinks is SymlinksCount.
+// Replacing a file with a symbolic link is converted to a deletion and vice versa.
+func (treediff *TreeDiff) filterSymlinks(change *object.Change) *object.Change {
+	if treediff.HandleSymlinks == SymlinksCount {
+		return change
+	}
+	return filterMode(change, filemode.Symlink)
+}
+
+// filterMode returns the part of the change which does not have the specified mode or nil.
+func filterMode(change *object.Change, mode filemode.FileMode) *object.Change {
+	fromLink := change.From.TreeEntry.Mode == mode
+	toLink := change.To.TreeEntry.Mode == mode
+	switch {
+	case !fromLink && !toLink:
+		return change
+	case !fromLink && change.From.Name != "":
+		return &object.Change{From: change.From}
+	case !toLink && change.To.Name != "":
+		return &object.Change{To: change.To}
+	default:
+		return nil
+	}
+}
+
+// appendSubmodules adds the insertions of all the submodule gitlinks in `tree`.
+func appendSubmodules(diffs object.Changes, tree *object.Tree) (object.Changes, error) {
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			return diffs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if entry.Mode != filemode.Submodule {
+			continue
+		}
+		diffs = append(diffs, &object.Change{
+			To: object.ChangeEntry{Name: name, Tree: tree, TreeEntry: object.TreeEntry{
+				Name: name, Mode: entry.Mode, Hash: entry.Hash}}})
+	}
+}
+
+// filterPathPrefix returns the part of the change which belongs to PathPrefix or nil
+// if the change is completely outside. Renames across the boundary are converted
+// to deletions or insertions.
+func (treediff *TreeDiff) filterPathPrefix(change *object.Change) *object.Change {
+	if treediff.PathPrefix == "" {
+		return change
+	}
+	return splitChange(change, func(name string) bool {
+		return strings.HasPrefix(name, treediff.PathPrefix)
+	})
+}
+
+// filterExcludeGlobs returns the part of the change which does not match ExcludeGlobs or nil
+// if the change is completely excluded. Renames from or to the excluded files are converted
+// to insertions or deletions.
+func (treediff *TreeDiff) filterExcludeGlobs(change *object.Change) *object.Change {
+	if len(treediff.ExcludeGlobs) == 0 {
+		return change
+	}
+	return splitChange(change, func(name string) bool {
+		for _, glob := range treediff.ExcludeGlobs {
+			if MatchGlob(glob, name) {
+				if treediff.excludedPaths != nil {
+					treediff.excludedPaths[name] = true
+				}
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// filterIncludeExtensions returns the part of the change which has one of IncludeExtensions
+// or nil if the change is completely excluded. Renames which change the extension to or from
+// an included one are converted to insertions or deletions.
+func (treediff *TreeDiff) filterIncludeExtensions(change *object.Change) *object.Change {
+	if len(treediff.IncludeExtensions) == 0 {
+		return change
+	}
+	return splitChange(change, func(name string) bool {
+		ext := strings.ToLower(path.Ext(name))
+		for _, included := range treediff.IncludeExtensions {
+			if ext == included {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// normalizePathCase converts the names in the changes to lower case. A deletion and an insertion
+// which end up with the same name, that is, a rename which changes only the case, are joined
+// into a single modification or dropped if the contents are the same. The paths which collide
+// in the same tree, e.g. Foo.go and foo.go, keep their original case: when the second one
+// appears, the first one is renamed back from the lower case name. Each path keeps its name
+// until it is deleted.
+func (treediff *TreeDiff) normalizePathCase(diffs object.Changes, tree *object.Tree) object.Changes {
+	if treediff.pathCases == nil {
+		treediff.pathCases = map[string]string{}
+		treediff.lowerPaths = map[string]map[string]bool{}
+	}
+	mappedName := func(name string) string {
+		if mapped, exists := treediff.pathCases[name]; exists {
+			return mapped
+		}
+		return strings.ToLower(name)
+	}
+	fromNames := make([]string, len(diffs))
+	for i, change := range diffs {
+		fromNames[i] = mappedName(change.From.Name)
+	}
+	// release the names of the deleted paths first
+	for _, change := range diffs {
+		if name := change.From.Name; name != "" && name != change.To.Name {
+			lower := strings.ToLower(name)
+			delete(treediff.pathCases, name)
+			delete(treediff.lowerPaths[lower], name)
+			if len(treediff.lowerPaths[lower]) == 0 {
+				delete(treediff.lowerPaths, lower)
+			}
+		}
+	}
+	inserted := map[string][]string{}
+	for _, change := range diffs {
+		if name := change.To.Name; name != "" && name != change.From.Name {
+			if _, exists := treediff.pathCases[name]; !exists {
+				lower := strings.ToLower(name)
+				inserted[lower] = append(inserted[lower], name)
+			}
+		}
+	}
+	// the unchanged paths which must be renamed back to the original case
+	var restored []string
+	for lower, names := range inserted {
+		existing := treediff.lowerPaths[lower]
+		if existing == nil {
+			existing = map[string]bool{}
+			treediff.lowerPaths[lower] = existing
+		}
+		collision := len(names) > 1 || len(existing) > 0
+		for other := range existing {
+			if treediff.pathCases[other] != other {
+				treediff.pathCases[other] = other
+				restored = append(restored, other)
+			}
+		}
+		for _, name := range names {
+			existing[name] = true
+			if collision {
+				treediff.pathCases[name] = name
+			} else {
+				treediff.pathCases[name] = lower
+			}
+		}
+	}
+	normalized := make(object.Changes, 0, len(diffs)+len(restored))
+	deletions := map[string]int{}
+	insertions := map[string]int{}
+	changed := map[string]bool{}
+	for i, change := range diffs {
+		lowered := *change
+		if lowered.From.Name != "" {
+			if fromNames[i] != lowered.From.Name {
+				lowered.From.TreeEntry.Name = strings.ToLower(lowered.From.TreeEntry.Name)
+			}
+			lowered.From.Name = fromNames[i]
+		}
+		if lowered.To.Name != "" {
+			changed[lowered.To.Name] = true
+			if toName := mappedName(lowered.To.Name); toName != lowered.To.Name {
+				lowered.To.TreeEntry.Name = strings.ToLower(lowered.To.TreeEntry.Name)
+				lowered.To.Name = toName
+			}
+		}
+		var pair int
+		var exists bool
+		if lowered.To.Name == "" {
+			pair, exists = insertions[lowered.From.Name]
+			if exists {
+				delete(insertions, lowered.From.Name)
+				normalized[pair].From = lowered.From
+			} else {
+				deletions[lowered.From.Name] = len(normalized)
+			}
+		} else if lowered.From.Name == "" {
+			pair, exists = deletions[lowered.To.Name]
+			if exists {
+				delete(deletions, lowered.To.Name)
+				normalized[pair].To = lowered.To
+			} else {
+				insertions[lowered.To.Name] = len(normalized)
+			}
+		}
+		if !exists {
+			normalized = append(normalized, &lowered)
+		}
+	}
+	sort.Strings(restored)
+	for _, name := range restored {
+		if changed[name] || tree == nil {
+			// the modification already carries the rename
+			continue
+		}
+		entry, err := tree.FindEntry(name)
+		if err != nil {
+			continue
+		}
+		to := object.ChangeEntry{Name: name, Tree: tree, TreeEntry: *entry}
+		from := to
+		from.Name = strings.ToLower(name)
+		normalized = append(normalized, &object.Change{From: from, To: to})
+	}
+	result := normalized[:0]
+	for _, change := range normalized {
+		if change.From.Name != "" && change.From.Name == change.To.Name &&
+			change.From.TreeEntry.Hash == change.To.TreeEntry.Hash {
+			continue
+		}
+		result = append(result, change)
+	}
+	return result
+}
+
+// splitChange keeps the sides of the change whose names pass the filter. The result is nil
+// if neither side passes.
+func splitChange(change *object.Change, pass func(name string) bool) *object.Change {
+	fromPasses := change.From.Name != "" && pass(change.From.Name)
+	toPasses := change.To.Name != "" && pass(change.To.Name)
+	switch {
+	case fromPasses == (change.From.Name != "") && toPasses == (change.To.Name != ""):
+		return change
+	case fromPasses:
+		return &object.Change{From: change.From}
+	case toPasses:
+		return &object.Change{To: change.To}
+	default:
+		return nil
+	}
+}
+
+// MatchGlob checks whether the file name matches the glob pattern. Besides path.Match() syntax,
+// "**" matches zero or more directories, a trailing "/" matches everything inside the directory
+// and the patterns without "/" are applied to every path component.
+func MatchGlob(glob, name string) bool {
+	dir := strings.HasSuffix(glob, "/")
+	glob = strings.TrimSuffix(glob, "/")
+	if !strings.Contains(glob, "/") {
+		glob = "**/" + glob + "/**"
+	} else if dir {
+		glob += "/**"
+	}
+	return matchGlobParts(strings.Split(glob, "/"), strings.Split(name, "/"))
+}
+
+// ValidateGlob returns path.ErrBadPattern if MatchGlob() cannot apply the glob pattern.
+func ValidateGlob(glob string) error {
+	for _, part := range strings.Split(strings.TrimSuffix(glob, "/"), "/") {
+		if part == "**" {
+			continue
+		}
+		if _, err := path.Match(part, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func matchGlobParts(glob, name []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlobParts(glob[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(glob[0], name[0]); !matched {
+			return false
+		}
+		glob = glob[1:]
+		name = name[1:]
+	}
+	return len(name) == 0
+}
+
+// normalizePathPrefix converts the user-supplied directory to the form which can be
+// directly compared with the tree entry names.
+func normalizePathPrefix(prefix string) string {
+	prefix = strings.Trim(strings.TrimSpace(prefix), "/")
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "/"
+}
+
 // Fork clones this PipelineItem.
 func (treediff *TreeDiff) Fork(n int) []core.PipelineItem {
-	return core.ForkCopyPipelineItem(treediff, n)
+	clones := core.ForkCopyPipelineItem(treediff, n)
+	if !treediff.PathCaseInsensitive {
+		return clones
+	}
+	// the branches can add and delete different files
+	for _, clone := range clones {
+		clone := clone.(*TreeDiff)
+		clone.pathCases = make(map[string]string, len(treediff.pathCases))
+		for key, val := range treediff.pathCases {
+			clone.pathCases[key] = val
+		}
+		clone.lowerPaths = make(map[string]map[string]bool, len(treediff.lowerPaths))
+		for key, val := range treediff.lowerPaths {
+			paths := make(map[string]bool, len(val))
+			for name := range val {
+				paths[name] = true
+			}
+			clone.lowerPaths[key] = paths
+		}
+	}
+	return clones
+}
+
+// Dispose reports the number of distinct paths which were excluded by ExcludeGlobs.
+func (treediff *TreeDiff) Dispose() {
+	if len(treediff.ExcludeGlobs) > 0 {
+		treediff.l.Infof("%s: excluded %d paths matching %s\n", treediff.Name(),
+			len(treediff.excludedPaths), strings.Join(treediff.ExcludeGlobs, ", "))
+	}
 }
 
 // checkLanguage returns whether the blob corresponds to the list of required languages.
@@ -1,10 +1,14 @@
 package plumbing
 
 import (
+	"path"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
 	"gopkg.in/src-d/hercules.v10/internal/core"
@@ -25,7 +29,7 @@ func TestTreeDiffMeta(t *testing.T) {
 	assert.Equal(t, len(td.Provides()), 1)
 	assert.Equal(t, td.Provides()[0], DependencyTreeChanges)
 	opts := td.ListConfigurationOptions()
-	assert.Len(t, opts, 4)
+	assert.Len(t, opts, 10)
 	logger := core.NewLogger()
 	assert.NoError(t, td.Configure(map[string]interface{}{
 		core.ConfigLogger: logger,
@@ -170,6 +174,349 @@ func TestTreeDiffConsumeSkip(t *testing.T) {
 	assert.Equal(t, 31, len(changes))
 }
 
+func TestTreeDiffConsumePathPrefix(t *testing.T) {
+	td := fixtureTreeDiff()
+	commit, _ := test.Repository.CommitObject(plumbing.NewHash(
+		"aefdedf7cafa6ee110bae9a3910bf5088fdeb5a9"))
+	deps := map[string]interface{}{}
+	deps[core.DependencyCommit] = commit
+	prevCommit, _ := test.Repository.CommitObject(plumbing.NewHash(
+		"1e076dc56989bc6aa1ef5f55901696e9e01423d4"))
+	td.previousTree, _ = prevCommit.Tree()
+	assert.Nil(t, td.Configure(map[string]interface{}{
+		ConfigTreeDiffPathPrefix: "/vendor",
+	}))
+	assert.Equal(t, "vendor/", td.PathPrefix)
+	res, err := td.Consume(deps)
+	assert.NoError(t, err)
+	changes := res[DependencyTreeChanges].(object.Changes)
+	assert.NotEmpty(t, changes)
+	for _, change := range changes {
+		action, _ := change.Action()
+		if action == merkletrie.Delete {
+			assert.True(t, strings.HasPrefix(change.From.Name, "vendor/"))
+		} else {
+			assert.True(t, strings.HasPrefix(change.To.Name, "vendor/"))
+		}
+	}
+}
+
+func TestTreeDiffFilterPathPrefixRenames(t *testing.T) {
+	td := fixtureTreeDiff()
+	td.PathPrefix = normalizePathPrefix("services/payments/")
+	inside := object.ChangeEntry{Name: "services/payments/main.go"}
+	inside2 := object.ChangeEntry{Name: "services/payments/app.go"}
+	outside := object.ChangeEntry{Name: "services/billing/main.go"}
+	change := &object.Change{From: inside, To: inside2}
+	assert.Equal(t, change, td.filterPathPrefix(change))
+	assert.Nil(t, td.filterPathPrefix(&object.Change{From: outside, To: outside}))
+	assert.Nil(t, td.filterPathPrefix(&object.Change{To: outside}))
+	filtered := td.filterPathPrefix(&object.Change{From: inside, To: outside})
+	assert.Equal(t, inside, filtered.From)
+	assert.Equal(t, "", filtered.To.Name)
+	filtered = td.filterPathPrefix(&object.Change{From: outside, To: inside})
+	assert.Equal(t, "", filtered.From.Name)
+	assert.Equal(t, inside, filtered.To)
+	assert.Equal(t, "", normalizePathPrefix(" / "))
+}
+
+func TestTreeDiffConfigureExcludeGlobs(t *testing.T) {
+	td := fixtureTreeDiff()
+	assert.Nil(t, td.Configure(map[string]interface{}{
+		ConfigTreeDiffExcludeGlobs: []string{"vendor/", " ", "*.pb.go"},
+	}))
+	assert.Equal(t, []string{"vendor/", "*.pb.go"}, td.ExcludeGlobs)
+	assert.NotNil(t, td.Configure(map[string]interface{}{
+		ConfigTreeDiffExcludeGlobs: []string{"[x"},
+	}))
+}
+
+func TestTreeDiffMatchGlob(t *testing.T) {
+	assert.True(t, MatchGlob("*.pb.go", "pb.pb.go"))
+	assert.True(t, MatchGlob("*.pb.go", "internal/pb/pb.pb.go"))
+	assert.False(t, MatchGlob("*.pb.go", "internal/pb/utils.go"))
+	assert.True(t, MatchGlob("vendor/", "vendor/a/b.go"))
+	assert.True(t, MatchGlob("node_modules", "web/node_modules/x/index.js"))
+	assert.False(t, MatchGlob("node_modules", "web/node_modules.js"))
+	assert.True(t, MatchGlob("internal/**/*.go", "internal/core/forks.go"))
+	assert.True(t, MatchGlob("internal/**/*.go", "internal/x.go"))
+	assert.False(t, MatchGlob("internal/**/*.go", "cmd/internal/x.go"))
+	assert.True(t, MatchGlob("cmd/", "cmd/hercules/root.go"))
+	assert.False(t, MatchGlob("cmd/hercules", "cmd/hercules/root.go"))
+	assert.True(t, MatchGlob("cmd/*", "cmd/root.go"))
+}
+
+func TestTreeDiffValidateGlob(t *testing.T) {
+	assert.NoError(t, ValidateGlob("src/**"))
+	assert.NoError(t, ValidateGlob("internal/**/*.go"))
+	assert.NoError(t, ValidateGlob("vendor/"))
+	assert.Equal(t, path.ErrBadPattern, ValidateGlob("src/["))
+	assert.Equal(t, path.ErrBadPattern, ValidateGlob("[/**"))
+}
+
+func TestTreeDiffFilterExcludeGlobs(t *testing.T) {
+	td := fixtureTreeDiff()
+	td.ExcludeGlobs = []string{"vendor/", "*.pb.go"}
+	kept := object.ChangeEntry{Name: "main.go"}
+	excluded := object.ChangeEntry{Name: "vendor/lib/lib.go"}
+	excluded2 := object.ChangeEntry{Name: "pb/pb.pb.go"}
+	change := &object.Change{From: kept, To: kept}
+	assert.Equal(t, change, td.filterExcludeGlobs(change))
+	assert.Nil(t, td.filterExcludeGlobs(&object.Change{From: excluded}))
+	assert.Nil(t, td.filterExcludeGlobs(&object.Change{From: excluded, To: excluded2}))
+	filtered := td.filterExcludeGlobs(&object.Change{From: kept, To: excluded})
+	assert.Equal(t, kept, filtered.From)
+	assert.Equal(t, "", filtered.To.Name)
+	filtered = td.filterExcludeGlobs(&object.Change{From: excluded, To: kept})
+	assert.Equal(t, "", filtered.From.Name)
+	assert.Equal(t, kept, filtered.To)
+	assert.Len(t, td.excludedPaths, 2)
+	td.Dispose()
+}
+
+func TestTreeDiffIncludeExtensions(t *testing.T) {
+	td := fixtureTreeDiff()
+	assert.Nil(t, td.Configure(map[string]interface{}{
+		ConfigTreeDiffIncludeExtensions: []string{"go", " .PY", "", "."},
+	}))
+	assert.Equal(t, []string{".go", ".py"}, td.IncludeExtensions)
+	kept := object.ChangeEntry{Name: "cmd/main.go"}
+	kept2 := object.ChangeEntry{Name: "setup.PY"}
+	excluded := object.ChangeEntry{Name: "README.md"}
+	excluded2 := object.ChangeEntry{Name: "Makefile"}
+	change := &object.Change{From: kept, To: kept2}
+	assert.Equal(t, change, td.filterIncludeExtensions(change))
+	assert.Nil(t, td.filterIncludeExtensions(&object.Change{To: excluded2}))
+	assert.Nil(t, td.filterIncludeExtensions(&object.Change{From: excluded, To: excluded2}))
+	filtered := td.filterIncludeExtensions(&object.Change{From: kept, To: excluded})
+	assert.Equal(t, kept, filtered.From)
+	assert.Equal(t, "", filtered.To.Name)
+	filtered = td.filterIncludeExtensions(&object.Change{From: excluded, To: kept})
+	assert.Equal(t, "", filtered.From.Name)
+	assert.Equal(t, kept, filtered.To)
+	td.IncludeExtensions = nil
+	assert.Equal(t, change, td.filterIncludeExtensions(change))
+	change = &object.Change{To: excluded}
+	assert.Equal(t, change, td.filterIncludeExtensions(change))
+}
+
+func TestTreeDiffPathCaseInsensitive(t *testing.T) {
+	td := fixtureTreeDiff()
+	assert.Nil(t, td.Configure(map[string]interface{}{ConfigTreeDiffPathCaseInsensitive: true}))
+	assert.True(t, td.PathCaseInsensitive)
+	when := time.Unix(1500000000, 0)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"Foo.go": "a\nb\n", "README": "x\n"}},
+		// case-only rename with a modification
+		{Author: "one", When: when, Files: map[string]string{"foo.go": "a\nc\n"},
+			Deleted: []string{"Foo.go"}},
+		// pure case-only rename
+		{Author: "one", When: when, Files: map[string]string{"FOO.go": "a\nc\n"},
+			Deleted: []string{"foo.go"}},
+		{Author: "one", When: when, Deleted: []string{"FOO.go"}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, td.Initialize(repository))
+	consume := func(i int) object.Changes {
+		commit, err := repository.CommitObject(hashes[i])
+		assert.NoError(t, err)
+		res, err := td.Consume(map[string]interface{}{core.DependencyCommit: commit})
+		assert.NoError(t, err)
+		return res[DependencyTreeChanges].(object.Changes)
+	}
+	changes := consume(0)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, "foo.go", changes[0].To.Name)
+	assert.Equal(t, "foo.go", changes[0].To.TreeEntry.Name)
+	assert.Equal(t, "readme", changes[1].To.Name)
+	changes = consume(1)
+	assert.Len(t, changes, 1)
+	action, err := changes[0].Action()
+	assert.NoError(t, err)
+	assert.Equal(t, merkletrie.Modify, action)
+	assert.Equal(t, "foo.go", changes[0].From.Name)
+	assert.Equal(t, "foo.go", changes[0].To.Name)
+	assert.NotEqual(t, changes[0].From.TreeEntry.Hash, changes[0].To.TreeEntry.Hash)
+	assert.Len(t, consume(2), 0)
+	changes = consume(3)
+	assert.Len(t, changes, 1)
+	action, err = changes[0].Action()
+	assert.NoError(t, err)
+	assert.Equal(t, merkletrie.Delete, action)
+	assert.Equal(t, "foo.go", changes[0].From.Name)
+}
+
+func TestTreeDiffPathCaseCollision(t *testing.T) {
+	td := fixtureTreeDiff()
+	assert.Nil(t, td.Configure(map[string]interface{}{ConfigTreeDiffPathCaseInsensitive: true}))
+	when := time.Unix(1500000000, 0)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"Foo.go": "a\n", "foo.go": "b\n", "Bar.go": "c\n"}},
+		// bar.go collides with Bar.go which was known as bar.go
+		{Author: "one", When: when, Files: map[string]string{"bar.go": "d\n"}},
+		{Author: "one", When: when, Files: map[string]string{"Bar.go": "e\n", "foo.go": "f\n"},
+			Deleted: []string{"Foo.go"}},
+		// the names are kept until the paths are deleted
+		{Author: "one", When: when, Files: map[string]string{"Baz.go": "g\n"}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, td.Initialize(repository))
+	consume := func(i int) map[string]string {
+		commit, err := repository.CommitObject(hashes[i])
+		assert.NoError(t, err)
+		res, err := td.Consume(map[string]interface{}{core.DependencyCommit: commit})
+		assert.NoError(t, err)
+		result := map[string]string{}
+		for _, change := range res[DependencyTreeChanges].(object.Changes) {
+			result[change.From.Name] = change.To.Name
+		}
+		return result
+	}
+	commit, err := repository.CommitObject(hashes[0])
+	assert.NoError(t, err)
+	res, err := td.Consume(map[string]interface{}{core.DependencyCommit: commit})
+	assert.NoError(t, err)
+	var names []string
+	for _, change := range res[DependencyTreeChanges].(object.Changes) {
+		assert.Equal(t, "", change.From.Name)
+		names = append(names, change.To.Name)
+	}
+	assert.Equal(t, []string{"bar.go", "Foo.go", "foo.go"}, names)
+	// Bar.go is renamed back to its original case
+	assert.Equal(t, map[string]string{"": "bar.go", "bar.go": "Bar.go"}, consume(1))
+	assert.Equal(t, map[string]string{"Bar.go": "Bar.go", "Foo.go": "", "foo.go": "foo.go"}, consume(2))
+	assert.Equal(t, map[string]string{"": "baz.go"}, consume(3))
+}
+
+func TestTreeDiffSubmodules(t *testing.T) {
+	link1 := plumbing.NewHash("0123456789012345678901234567890123456789")
+	link2 := plumbing.NewHash("9876543210987654321098765432109876543210")
+	when := time.Unix(1500000000, 0)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"main.go": "a\n"},
+			Submodules: map[string]plumbing.Hash{"lib/sub": link1}},
+		// submodule pointer update together with a regular change
+		{Author: "one", When: when, Files: map[string]string{"main.go": "b\n"},
+			Submodules: map[string]plumbing.Hash{"lib/sub": link2}},
+		// submodule pointer update only
+		{Author: "one", When: when, Submodules: map[string]plumbing.Hash{"lib/sub": link1}},
+	})
+	assert.NoError(t, err)
+	run := func(handle string) []object.Changes {
+		td := fixtureTreeDiff()
+		if handle != "" {
+			assert.NoError(t, td.Configure(map[string]interface{}{
+				ConfigTreeDiffHandleSubmodules: handle}))
+		}
+		assert.NoError(t, td.Initialize(repository))
+		var result []object.Changes
+		for _, hash := range hashes {
+			commit, err := repository.CommitObject(hash)
+			assert.NoError(t, err)
+			res, err := td.Consume(map[string]interface{}{core.DependencyCommit: commit})
+			assert.NoError(t, err)
+			result = append(result, res[DependencyTreeChanges].(object.Changes))
+		}
+		return result
+	}
+	names := func(changes object.Changes) []string {
+		var result []string
+		for _, change := range changes {
+			result = append(result, change.From.Name+">"+change.To.Name)
+		}
+		return result
+	}
+	for _, handle := range []string{"", SubmodulesIgnore} {
+		changes := run(handle)
+		assert.Equal(t, []string{">main.go"}, names(changes[0]), handle)
+		assert.Equal(t, []string{"main.go>main.go"}, names(changes[1]), handle)
+		assert.Len(t, changes[2], 0, handle)
+	}
+	changes := run(SubmodulesCountAsFile)
+	assert.Equal(t, []string{">main.go", ">lib/sub"}, names(changes[0]))
+	assert.Equal(t, filemode.Submodule, changes[0][1].To.TreeEntry.Mode)
+	assert.Equal(t, link1, changes[0][1].To.TreeEntry.Hash)
+	assert.Equal(t, []string{"lib/sub>lib/sub", "main.go>main.go"}, names(changes[1]))
+	assert.Equal(t, []string{"lib/sub>lib/sub"}, names(changes[2]))
+	assert.Equal(t, link1, changes[2][0].To.TreeEntry.Hash)
+
+	td := fixtureTreeDiff()
+	assert.Error(t, td.Configure(map[string]interface{}{ConfigTreeDiffHandleSubmodules: "xxx"}))
+	// a file replaced with a submodule is a deletion
+	change := &object.Change{
+		From: object.ChangeEntry{Name: "x", TreeEntry: object.TreeEntry{Mode: filemode.Regular}},
+		To:   object.ChangeEntry{Name: "x", TreeEntry: object.TreeEntry{Mode: filemode.Submodule}},
+	}
+	assert.Equal(t, &object.Change{From: change.From}, td.filterSubmodules(change))
+	change.From, change.To = change.To, change.From
+	assert.Equal(t, &object.Change{To: change.To}, td.filterSubmodules(change))
+}
+
+func TestTreeDiffSymlinks(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"main.go": "a\n"}},
+		// symlink addition
+		{Author: "one", When: when, Symlinks: map[string]string{"link.go": "main.go"}},
+		// symlink modification together with a regular change
+		{Author: "one", When: when, Files: map[string]string{"main.go": "b\n", "lib.go": "c\n"},
+			Symlinks: map[string]string{"link.go": "lib.go"}},
+		// symlink replaced with a regular file
+		{Author: "one", When: when, Files: map[string]string{"link.go": "d\n"}},
+	})
+	assert.NoError(t, err)
+	run := func(handle string) []object.Changes {
+		td := fixtureTreeDiff()
+		if handle != "" {
+			assert.NoError(t, td.Configure(map[string]interface{}{
+				ConfigTreeDiffHandleSymlinks: handle}))
+		}
+		assert.NoError(t, td.Initialize(repository))
+		var result []object.Changes
+		for _, hash := range hashes {
+			commit, err := repository.CommitObject(hash)
+			assert.NoError(t, err)
+			res, err := td.Consume(map[string]interface{}{core.DependencyCommit: commit})
+			assert.NoError(t, err)
+			result = append(result, res[DependencyTreeChanges].(object.Changes))
+		}
+		return result
+	}
+	names := func(changes object.Changes) []string {
+		var result []string
+		for _, change := range changes {
+			result = append(result, change.From.Name+">"+change.To.Name)
+		}
+		return result
+	}
+	for _, handle := range []string{"", SymlinksIgnore} {
+		changes := run(handle)
+		assert.Equal(t, []string{">main.go"}, names(changes[0]), handle)
+		assert.Len(t, changes[1], 0, handle)
+		assert.Equal(t, []string{">lib.go", "main.go>main.go"}, names(changes[2]), handle)
+		assert.Equal(t, []string{">link.go"}, names(changes[3]), handle)
+	}
+	changes := run(SymlinksCount)
+	assert.Equal(t, []string{">main.go"}, names(changes[0]))
+	assert.Equal(t, []string{">link.go"}, names(changes[1]))
+	assert.Equal(t, filemode.Symlink, changes[1][0].To.TreeEntry.Mode)
+	assert.Equal(t, []string{">lib.go", "link.go>link.go", "main.go>main.go"}, names(changes[2]))
+	assert.Equal(t, []string{"link.go>link.go"}, names(changes[3]))
+	assert.Equal(t, filemode.Regular, changes[3][0].To.TreeEntry.Mode)
+
+	// the initial tree
+	td := fixtureTreeDiff()
+	assert.NoError(t, td.Initialize(repository))
+	commit, err := repository.CommitObject(hashes[2])
+	assert.NoError(t, err)
+	res, err := td.Consume(map[string]interface{}{core.DependencyCommit: commit})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{">lib.go", ">main.go"}, names(res[DependencyTreeChanges].(object.Changes)))
+
+	assert.Error(t, td.Configure(map[string]interface{}{ConfigTreeDiffHandleSymlinks: "xxx"}))
+}
+
 func TestTreeDiffConsumeOnlyFilesThatMatchFilter(t *testing.T) {
 	// consume without skipping
 	td := fixtureTreeDiff()
@@ -304,3 +651,89 @@ func TestTreeDiffCheckLanguageEmpty(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, lang)
 }
+
+func TestTreeDiffFollowsSkippedCommits(t *testing.T) {
+	td := fixtureTreeDiff()
+	hash1 := plumbing.NewHash("1111111111111111111111111111111111111111")
+	hash2 := plumbing.NewHash("2222222222222222222222222222222222222222")
+	hash3 := plumbing.NewHash("3333333333333333333333333333333333333333")
+	commit := &object.Commit{ParentHashes: []plumbing.Hash{hash3}}
+	assert.True(t, td.followsPreviousCommit(commit))
+	td.previousCommit = hash1
+	assert.False(t, td.followsPreviousCommit(commit))
+	skipped := map[plumbing.Hash][]plumbing.Hash{hash3: {hash2}, hash2: {hash1}}
+	assert.NoError(t, td.Configure(map[string]interface{}{core.FactPipelineSkippedCommits: skipped}))
+	assert.True(t, td.followsPreviousCommit(commit))
+	delete(skipped, hash2)
+	assert.False(t, td.followsPreviousCommit(commit))
+	commit.ParentHashes = []plumbing.Hash{hash1}
+	assert.True(t, td.followsPreviousCommit(commit))
+}
+
+func TestTreeDiffRebaseOnFailedParent(t *testing.T) {
+	td := fixtureTreeDiff()
+	failed := map[plumbing.Hash]bool{}
+	assert.NoError(t, td.Configure(map[string]interface{}{core.FactPipelineFailedCommits: failed}))
+	when := time.Unix(1500000000, 0)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"a.go": "a\n"}},
+		{Author: "one", When: when, Files: map[string]string{"a.go": "a\nb\n", "b.go": "b\n"}},
+		{Author: "one", When: when, Files: map[string]string{"b.go": "b\nc\n"}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, td.Initialize(repository))
+	consume := func(i int) (object.Changes, error) {
+		commit, err := repository.CommitObject(hashes[i])
+		assert.NoError(t, err)
+		res, err := td.Consume(map[string]interface{}{core.DependencyCommit: commit})
+		if err != nil {
+			return nil, err
+		}
+		return res[DependencyTreeChanges].(object.Changes), nil
+	}
+	_, err = consume(0)
+	assert.NoError(t, err)
+	// the pipeline failed on the second commit before TreeDiff consumed it
+	_, err = consume(2)
+	assert.Error(t, err)
+	failed[hashes[1]] = true
+	changes, err := consume(2)
+	assert.NoError(t, err)
+	// the changes of the failed commit are not included
+	assert.Len(t, changes, 1)
+	action, err := changes[0].Action()
+	assert.NoError(t, err)
+	assert.Equal(t, merkletrie.Modify, action)
+	assert.Equal(t, "b.go", changes[0].To.Name)
+	assert.Equal(t, hashes[2], td.previousCommit)
+}
+
+func TestTreeDiffFilterFile(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"README.md": "readme\n", "main.go": "package main\n", "lib/lib.go": "package lib\n",
+			"lib/lib_test.go": "package lib\n"},
+			Submodules: map[string]plumbing.Hash{
+				"lib/sub": plumbing.NewHash("0123456789012345678901234567890123456789")}},
+	})
+	assert.NoError(t, err)
+	filesAtHead := func(facts map[string]interface{}) []string {
+		pipeline := core.NewPipeline(repository)
+		td := pipeline.DeployItem(&TreeDiff{}).(*TreeDiff)
+		assert.NoError(t, pipeline.Initialize(facts))
+		td.excludedPaths["x"] = true
+		files, err := pipeline.FilesAtHead()
+		assert.NoError(t, err)
+		assert.Len(t, td.excludedPaths, 1)
+		return files
+	}
+	assert.Equal(t, []string{"README.md", "lib/lib.go", "lib/lib_test.go", "main.go"},
+		filesAtHead(map[string]interface{}{}))
+	assert.Equal(t, []string{"README.md", "lib/lib.go", "lib/lib_test.go", "lib/sub", "main.go"},
+		filesAtHead(map[string]interface{}{ConfigTreeDiffHandleSubmodules: SubmodulesCountAsFile}))
+	assert.Equal(t, []string{"lib/lib.go"}, filesAtHead(map[string]interface{}{
+		ConfigTreeDiffExcludeGlobs: []string{"*_test.go"}, ConfigTreeDiffPathPrefix: "lib"}))
+	assert.Equal(t, []string{"README.md"}, filesAtHead(map[string]interface{}{
+		ConfigTreeDiffIncludeExtensions: []string{"md"}}))
+}
@@ -62,7 +62,9 @@ func (ref *FileDiffRefiner) Configure(facts map[string]interface{}) error {
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (ref *FileDiffRefiner) Initialize(repository *git.Repository) error {
-	ref.l = core.NewLogger()
+	if ref.l == nil {
+		ref.l = core.NewLogger()
+	}
 	return nil
 }
 
@@ -187,7 +187,9 @@ func (exr *Extractor) Configure(facts map[string]interface{}) error {
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (exr *Extractor) Initialize(repository *git.Repository) error {
-	exr.l = core.NewLogger()
+	if exr.l == nil {
+		exr.l = core.NewLogger()
+	}
 	if exr.Context == nil {
 		exr.Context = func() (context.Context, context.CancelFunc) {
 			return context.WithTimeout(context.Background(),
@@ -403,7 +405,9 @@ func (uc *Changes) Configure(facts map[string]interface{}) error {
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (uc *Changes) Initialize(repository *git.Repository) error {
-	uc.l = core.NewLogger()
+	if uc.l == nil {
+		uc.l = core.NewLogger()
+	}
 	uc.cache = map[plumbing.Hash]nodes.Node{}
 	return nil
 }
@@ -534,7 +538,9 @@ func (saver *ChangesSaver) Configure(facts map[string]interface{}) error {
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (saver *ChangesSaver) Initialize(repository *git.Repository) error {
-	saver.l = core.NewLogger()
+	if saver.l == nil {
+		saver.l = core.NewLogger()
+	}
 	saver.repository = repository
 	saver.result = [][]Change{}
 	saver.OneShotMergeProcessor.Initialize()
@@ -0,0 +1,209 @@
+package test
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+)
+
+// FakeCommit declares a single commit of the repository built by NewMemoryRepository.
+type FakeCommit struct {
+	// Author is the name of the author and the committer.
+	Author string
+	// Email is the email of the author and the committer. The default is "<Author>@srcd".
+	Email string
+	// When is the author and the committer time.
+	When time.Time
+	// CommitterWhen overrides the committer time, e.g. to imitate a rebase. Zero means When.
+	CommitterWhen time.Time
+	// Message is the commit message. The default is the index of the commit.
+	Message string
+	// Parents are the indexes of the previous commits which are the parents of this one.
+	// nil means the previous commit, an empty slice means a root commit.
+	Parents []int
+	// Files maps the paths to the new file contents. The rest of the files are inherited
+	// from the first parent. Nested directories are created automatically.
+	Files map[string]string
+	// Submodules maps the paths to the commit hashes of the submodule gitlinks. They are
+	// inherited from the first parent the same way as Files.
+	Submodules map[string]plumbing.Hash
+	// Symlinks maps the paths to the targets of the symbolic links. They are inherited
+	// from the first parent the same way as Files.
+	Symlinks map[string]string
+	// Deleted are the paths of the files which are removed from the first parent's tree.
+	// A rename is a deletion plus the same contents under a new path.
+	Deleted []string
+}
+
+// NewMemoryRepository builds an in-memory Git repository from the declarative list of commits.
+// The returned hashes correspond to `commits`. refs/heads/master points to the last commit.
+func NewMemoryRepository(commits []FakeCommit) (*git.Repository, []plumbing.Hash, error) {
+	repository, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	hashes := make([]plumbing.Hash, len(commits))
+	snapshots := make([]map[string]fakeFile, len(commits))
+	for i, commit := range commits {
+		parents := commit.Parents
+		if parents == nil && i > 0 {
+			parents = []int{i - 1}
+		}
+		files := map[string]fakeFile{}
+		for _, parent := range parents {
+			if parent < 0 || parent >= i {
+				return nil, nil, fmt.Errorf("commit %d: invalid parent %d", i, parent)
+			}
+		}
+		if len(parents) > 0 {
+			for path, contents := range snapshots[parents[0]] {
+				files[path] = contents
+			}
+		}
+		for _, path := range commit.Deleted {
+			if _, exists := files[path]; !exists {
+				return nil, nil, fmt.Errorf("commit %d: cannot delete %s: no such file", i, path)
+			}
+			delete(files, path)
+		}
+		for path, contents := range commit.Files {
+			files[path] = fakeFile{Contents: contents}
+		}
+		for path, hash := range commit.Submodules {
+			files[path] = fakeFile{Submodule: hash}
+		}
+		for path, target := range commit.Symlinks {
+			files[path] = fakeFile{Contents: target, Symlink: true}
+		}
+		snapshots[i] = files
+		tree, err := storeTree(repository.Storer, files)
+		if err != nil {
+			return nil, nil, err
+		}
+		email := commit.Email
+		if email == "" {
+			email = commit.Author + "@srcd"
+		}
+		message := commit.Message
+		if message == "" {
+			message = fmt.Sprint(i)
+		}
+		signature := object.Signature{Name: commit.Author, Email: email, When: commit.When}
+		committer := signature
+		if !commit.CommitterWhen.IsZero() {
+			committer.When = commit.CommitterWhen
+		}
+		gitCommit := &object.Commit{
+			Author: signature, Committer: committer, Message: message, TreeHash: tree}
+		for _, parent := range parents {
+			gitCommit.ParentHashes = append(gitCommit.ParentHashes, hashes[parent])
+		}
+		if hashes[i], err = storeObject(repository.Storer, gitCommit); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(hashes) > 0 {
+		err = repository.Storer.SetReference(plumbing.NewHashReference(
+			plumbing.Master, hashes[len(hashes)-1]))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return repository, hashes, nil
+}
+
+// fakeFile is either a regular file, a symbolic link to Contents if Symlink is true
+// or a submodule gitlink if Submodule is not zero.
+type fakeFile struct {
+	Contents  string
+	Submodule plumbing.Hash
+	Symlink   bool
+}
+
+func storeObject(storer storage.Storer, encoder interface {
+	Encode(plumbing.EncodedObject) error
+}) (plumbing.Hash, error) {
+	obj := storer.NewEncodedObject()
+	if err := encoder.Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return storer.SetEncodedObject(obj)
+}
+
+// storeTree writes the blobs and the (sub)trees of `files` and returns the root tree hash.
+func storeTree(storer storage.Storer, files map[string]fakeFile) (plumbing.Hash, error) {
+	blobs := map[string]fakeFile{}
+	dirs := map[string]map[string]fakeFile{}
+	for path, file := range files {
+		if slash := strings.IndexByte(path, '/'); slash >= 0 {
+			dir := dirs[path[:slash]]
+			if dir == nil {
+				dir = map[string]fakeFile{}
+				dirs[path[:slash]] = dir
+			}
+			dir[path[slash+1:]] = file
+		} else {
+			blobs[path] = file
+		}
+	}
+	tree := &object.Tree{}
+	for name, file := range blobs {
+		if !file.Submodule.IsZero() {
+			// the commit belongs to another repository and is not stored
+			tree.Entries = append(tree.Entries, object.TreeEntry{
+				Name: name, Mode: filemode.Submodule, Hash: file.Submodule})
+			continue
+		}
+		obj := storer.NewEncodedObject()
+		obj.SetType(plumbing.BlobObject)
+		writer, err := obj.Writer()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		if _, err = writer.Write([]byte(file.Contents)); err != nil {
+			return plumbing.ZeroHash, err
+		}
+		if err = writer.Close(); err != nil {
+			return plumbing.ZeroHash, err
+		}
+		hash, err := storer.SetEncodedObject(obj)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		mode := filemode.Regular
+		if file.Symlink {
+			mode = filemode.Symlink
+		}
+		tree.Entries = append(tree.Entries, object.TreeEntry{Name: name, Mode: mode, Hash: hash})
+	}
+	for name, subfiles := range dirs {
+		if _, exists := blobs[name]; exists {
+			return plumbing.ZeroHash, fmt.Errorf("%s is both a file and a directory", name)
+		}
+		hash, err := storeTree(storer, subfiles)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		tree.Entries = append(tree.Entries, object.TreeEntry{
+			Name: name, Mode: filemode.Dir, Hash: hash})
+	}
+	// Git sorts the directories as if their names ended with "/"
+	sortKey := func(entry object.TreeEntry) string {
+		if entry.Mode == filemode.Dir {
+			return entry.Name + "/"
+		}
+		return entry.Name
+	}
+	sort.Slice(tree.Entries, func(i, j int) bool {
+		return sortKey(tree.Entries[i]) < sortKey(tree.Entries[j])
+	})
+	return storeObject(storer, tree)
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func TestNewMemoryRepository(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, hashes, err := NewMemoryRepository([]FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"README.md": "hello\n", "src/main.go": "package main\n", "src/lib/lib.go": "package lib\n"}},
+		{Author: "two", Email: "two@example.com", When: when.Add(time.Hour), Message: "rename",
+			Files: map[string]string{"src/app.go": "package main\n"}, Deleted: []string{"src/main.go"}},
+		{Author: "one", When: when.Add(2 * time.Hour), Parents: []int{0},
+			Files: map[string]string{"logo.png": "\x89PNG\x00\x01"}},
+		{Author: "one", When: when.Add(3 * time.Hour), Parents: []int{1, 2},
+			Files: map[string]string{"logo.png": "\x89PNG\x00\x01"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, hashes, 4)
+
+	files := func(hash plumbing.Hash) map[string]string {
+		commit, err := repository.CommitObject(hash)
+		require.NoError(t, err)
+		iter, err := commit.Files()
+		require.NoError(t, err)
+		result := map[string]string{}
+		require.NoError(t, iter.ForEach(func(file *object.File) error {
+			contents, err := file.Contents()
+			result[file.Name] = contents
+			return err
+		}))
+		return result
+	}
+	assert.Equal(t, map[string]string{
+		"README.md": "hello\n", "src/main.go": "package main\n", "src/lib/lib.go": "package lib\n",
+	}, files(hashes[0]))
+	assert.Equal(t, map[string]string{
+		"README.md": "hello\n", "src/app.go": "package main\n", "src/lib/lib.go": "package lib\n",
+	}, files(hashes[1]))
+	assert.Equal(t, map[string]string{
+		"README.md": "hello\n", "src/app.go": "package main\n", "src/lib/lib.go": "package lib\n",
+		"logo.png": "\x89PNG\x00\x01",
+	}, files(hashes[3]))
+
+	commit, err := repository.CommitObject(hashes[1])
+	require.NoError(t, err)
+	assert.Equal(t, "two", commit.Author.Name)
+	assert.Equal(t, "two@example.com", commit.Committer.Email)
+	assert.Equal(t, "rename", commit.Message)
+	assert.Equal(t, []plumbing.Hash{hashes[0]}, commit.ParentHashes)
+	commit, err = repository.CommitObject(hashes[2])
+	require.NoError(t, err)
+	assert.Equal(t, "one@srcd", commit.Author.Email)
+	assert.Equal(t, "2", commit.Message)
+	assert.Equal(t, []plumbing.Hash{hashes[0]}, commit.ParentHashes)
+	commit, err = repository.CommitObject(hashes[3])
+	require.NoError(t, err)
+	assert.Equal(t, []plumbing.Hash{hashes[1], hashes[2]}, commit.ParentHashes)
+	head, err := repository.Head()
+	require.NoError(t, err)
+	assert.Equal(t, hashes[3], head.Hash())
+
+	// the same declaration always produces the same hashes
+	_, hashes2, err := NewMemoryRepository([]FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"README.md": "hello\n", "src/main.go": "package main\n", "src/lib/lib.go": "package lib\n"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hashes[0], hashes2[0])
+}
+
+func TestNewMemoryRepositorySubmodules(t *testing.T) {
+	link := plumbing.NewHash("0123456789012345678901234567890123456789")
+	repository, hashes, err := NewMemoryRepository([]FakeCommit{
+		{Author: "one", Files: map[string]string{"README.md": "hello\n"},
+			Submodules: map[string]plumbing.Hash{"lib/sub": link}},
+		{Author: "one", Deleted: []string{"lib/sub"}},
+	})
+	require.NoError(t, err)
+	commit, err := repository.CommitObject(hashes[0])
+	require.NoError(t, err)
+	tree, err := commit.Tree()
+	require.NoError(t, err)
+	entry, err := tree.FindEntry("lib/sub")
+	require.NoError(t, err)
+	assert.Equal(t, filemode.Submodule, entry.Mode)
+	assert.Equal(t, link, entry.Hash)
+	commit, err = repository.CommitObject(hashes[1])
+	require.NoError(t, err)
+	tree, err = commit.Tree()
+	require.NoError(t, err)
+	_, err = tree.FindEntry("lib/sub")
+	assert.Error(t, err)
+}
+
+func TestNewMemoryRepositoryErrors(t *testing.T) {
+	_, _, err := NewMemoryRepository([]FakeCommit{{Parents: []int{0}}})
+	assert.EqualError(t, err, "commit 0: invalid parent 0")
+	_, _, err = NewMemoryRepository([]FakeCommit{{Deleted: []string{"file"}}})
+	assert.EqualError(t, err, "commit 0: cannot delete file: no such file")
+	_, _, err = NewMemoryRepository([]FakeCommit{{Files: map[string]string{"a": "", "a/b": ""}}})
+	assert.EqualError(t, err, "a is both a file and a directory")
+	repository, hashes, err := NewMemoryRepository(nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, repository)
+	assert.Len(t, hashes, 0)
+}
@@ -0,0 +1,242 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+)
+
+// BinaryChurnAnalysis counts the binary files which were added and removed in each tick.
+// BurndownAnalysis skips the binary files, so this is the way to see the churn of assets.
+// It is a LeafPipelineItem.
+type BinaryChurnAnalysis struct {
+	core.NoopMerger
+	// added is the number of binary files added in each tick.
+	added []int64
+	// removed is the number of binary files removed in each tick.
+	removed []int64
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// BinaryChurnResult is returned by BinaryChurnAnalysis.Finalize() and carries the number
+// of added and removed binary files in each tick.
+type BinaryChurnResult struct {
+	// Added is the number of binary files added in each tick.
+	Added []int64
+	// Removed is the number of binary files removed in each tick.
+	Removed []int64
+
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (churn *BinaryChurnAnalysis) Name() string {
+	return "BinaryChurn"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (churn *BinaryChurnAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (churn *BinaryChurnAnalysis) Requires() []string {
+	return []string{items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (churn *BinaryChurnAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (churn *BinaryChurnAnalysis) Flag() string {
+	return "binary-churn"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (churn *BinaryChurnAnalysis) Description() string {
+	return "Counts the binary files which were added and removed in each tick."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (churn *BinaryChurnAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		churn.l = l
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		churn.tickSize = val
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (churn *BinaryChurnAnalysis) Initialize(repository *git.Repository) error {
+	if churn.l == nil {
+		churn.l = core.NewLogger()
+	}
+	churn.added = nil
+	churn.removed = nil
+	if churn.tickSize == 0 {
+		churn.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (churn *BinaryChurnAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if deps[core.DependencyIsMerge].(bool) {
+		// the diff of a merge commit repeats the binary files which were added or removed
+		// in the merged branch, so we ignore it like LinesStatsCalculator does
+		return nil, nil
+	}
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*items.CachedBlob)
+	tick := deps[items.DependencyTick].(int)
+	isBinary := func(hash plumbing.Hash) bool {
+		_, err := cache[hash].CountLines()
+		return err == items.ErrorBinary
+	}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		var added, removed bool
+		switch action {
+		case merkletrie.Insert:
+			added = isBinary(change.To.TreeEntry.Hash)
+		case merkletrie.Delete:
+			removed = isBinary(change.From.TreeEntry.Hash)
+		case merkletrie.Modify:
+			// the file became binary or stopped being binary
+			wasBinary, isBinaryNow := isBinary(change.From.TreeEntry.Hash), isBinary(change.To.TreeEntry.Hash)
+			added = !wasBinary && isBinaryNow
+			removed = wasBinary && !isBinaryNow
+		}
+		if !added && !removed {
+			continue
+		}
+		for len(churn.added) <= tick {
+			churn.added = append(churn.added, 0)
+			churn.removed = append(churn.removed, 0)
+		}
+		if added {
+			churn.added[tick]++
+		} else {
+			churn.removed[tick]++
+		}
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (churn *BinaryChurnAnalysis) Finalize() interface{} {
+	return BinaryChurnResult{
+		Added:    churn.added,
+		Removed:  churn.removed,
+		tickSize: churn.tickSize,
+	}
+}
+
+// Fork clones this PipelineItem.
+func (churn *BinaryChurnAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(churn, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (churn *BinaryChurnAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	churnResult := result.(BinaryChurnResult)
+	if binary {
+		return churn.serializeBinary(&churnResult, writer)
+	}
+	churn.serializeText(&churnResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to BinaryChurnResult.
+func (churn *BinaryChurnAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.BinaryChurnAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	return BinaryChurnResult{
+		Added:    message.Added,
+		Removed:  message.Removed,
+		tickSize: time.Duration(message.TickSize),
+	}, nil
+}
+
+func (churn *BinaryChurnAnalysis) serializeText(result *BinaryChurnResult, writer io.Writer) {
+	printInts := func(name string, values []int64) {
+		fmt.Fprintf(writer, "  %s: [", name)
+		for i, val := range values {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprint(writer, val)
+		}
+		fmt.Fprintln(writer, "]")
+	}
+	printInts("added", result.Added)
+	printInts("removed", result.Removed)
+	printInts("deltas", result.Deltas())
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (churn *BinaryChurnAnalysis) serializeBinary(result *BinaryChurnResult, writer io.Writer) error {
+	message := pb.BinaryChurnAnalysisResults{
+		Added:    result.Added,
+		Removed:  result.Removed,
+		Deltas:   result.Deltas(),
+		TickSize: int64(result.tickSize),
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// Deltas returns the change of the number of binary files in each tick.
+func (bcr BinaryChurnResult) Deltas() []int64 {
+	deltas := make([]int64, len(bcr.Added))
+	for i := range deltas {
+		deltas[i] = bcr.Added[i] - bcr.Removed[i]
+	}
+	return deltas
+}
+
+// GetTickSize returns the tick size used to generate this binary churn result.
+func (bcr BinaryChurnResult) GetTickSize() time.Duration {
+	return bcr.tickSize
+}
+
+func init() {
+	core.Registry.Register(&BinaryChurnAnalysis{})
+}
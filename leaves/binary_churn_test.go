@@ -0,0 +1,141 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureBinaryChurn() *BinaryChurnAnalysis {
+	churn := BinaryChurnAnalysis{}
+	churn.Initialize(test.Repository)
+	return &churn
+}
+
+func TestBinaryChurnMeta(t *testing.T) {
+	churn := fixtureBinaryChurn()
+	assert.Equal(t, churn.Name(), "BinaryChurn")
+	assert.Len(t, churn.Provides(), 0)
+	assert.Equal(t, []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyTick},
+		churn.Requires())
+	assert.Len(t, churn.ListConfigurationOptions(), 0)
+	assert.Equal(t, churn.Flag(), "binary-churn")
+	assert.Equal(t, 24*time.Hour, churn.tickSize)
+	logger := core.NewLogger()
+	assert.NoError(t, churn.Configure(map[string]interface{}{
+		core.ConfigLogger:  logger,
+		items.FactTickSize: time.Hour,
+	}))
+	assert.Equal(t, logger, churn.l)
+	assert.Equal(t, time.Hour, churn.tickSize)
+}
+
+func TestBinaryChurnRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&BinaryChurnAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "BinaryChurn")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&BinaryChurnAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestBinaryChurnFork(t *testing.T) {
+	churn1 := fixtureBinaryChurn()
+	clones := churn1.Fork(1)
+	assert.Len(t, clones, 1)
+	churn2 := clones[0].(*BinaryChurnAnalysis)
+	assert.True(t, churn1 == churn2)
+	churn1.Merge([]core.PipelineItem{churn2})
+}
+
+func bakeBinaryChurn(t *testing.T) *BinaryChurnAnalysis {
+	churn := fixtureBinaryChurn()
+	textHash := plumbing.NewHash("1111111111111111111111111111111111111111")
+	binaryHash := plumbing.NewHash("2222222222222222222222222222222222222222")
+	binaryHash2 := plumbing.NewHash("3333333333333333333333333333333333333333")
+	cache := map[plumbing.Hash]*items.CachedBlob{
+		textHash:    {Data: []byte("text\n")},
+		binaryHash:  {Data: []byte("\x00\x01\x02")},
+		binaryHash2: {Data: []byte("\x00\x02\x03")},
+	}
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: hash}}
+	}
+	consume := func(tick int, merge bool, changes ...*object.Change) {
+		res, err := churn.Consume(map[string]interface{}{
+			core.DependencyIsMerge:      merge,
+			items.DependencyTreeChanges: object.Changes(changes),
+			items.DependencyBlobCache:   cache,
+			items.DependencyTick:        tick,
+		})
+		assert.Nil(t, res)
+		assert.NoError(t, err)
+	}
+	consume(0, false,
+		&object.Change{To: entry("logo.png", binaryHash)},
+		&object.Change{To: entry("icon.png", binaryHash2)},
+		&object.Change{To: entry("README.md", textHash)})
+	consume(1, false,
+		&object.Change{From: entry("README.md", textHash), To: entry("README.md", textHash)})
+	consume(2, false,
+		&object.Change{From: entry("logo.png", binaryHash)},
+		&object.Change{From: entry("icon.png", binaryHash2), To: entry("icon.png", binaryHash)})
+	consume(2, true,
+		&object.Change{To: entry("merged.png", binaryHash)})
+	consume(3, false,
+		&object.Change{From: entry("icon.png", binaryHash), To: entry("icon.png", textHash)},
+		&object.Change{From: entry("README.md", textHash), To: entry("README.png", binaryHash)},
+		&object.Change{From: entry("README.md", textHash)})
+	return churn
+}
+
+func TestBinaryChurnConsumeFinalize(t *testing.T) {
+	churn := bakeBinaryChurn(t)
+	result := churn.Finalize().(BinaryChurnResult)
+	assert.Equal(t, []int64{2, 0, 0, 1}, result.Added)
+	assert.Equal(t, []int64{0, 0, 1, 1}, result.Removed)
+	assert.Equal(t, []int64{2, 0, -1, 0}, result.Deltas())
+	assert.Equal(t, 24*time.Hour, result.GetTickSize())
+	assert.Len(t, fixtureBinaryChurn().Finalize().(BinaryChurnResult).Deltas(), 0)
+}
+
+func TestBinaryChurnSerialize(t *testing.T) {
+	churn := bakeBinaryChurn(t)
+	result := churn.Finalize()
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, churn.Serialize(result, false, buffer))
+	assert.Equal(t, `  added: [2, 0, 0, 1]
+  removed: [0, 0, 1, 1]
+  deltas: [2, 0, -1, 0]
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, churn.Serialize(result, true, buffer))
+	msg := pb.BinaryChurnAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []int64{2, 0, 0, 1}, msg.Added)
+	assert.Equal(t, []int64{0, 0, 1, 1}, msg.Removed)
+	assert.Equal(t, []int64{2, 0, -1, 0}, msg.Deltas)
+	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	deserialized, err := churn.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
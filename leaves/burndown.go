@@ -8,10 +8,11 @@ import (
 	"log"
 	"math"
 	"os"
+	"runtime"
 	"sort"
+	"strings"
 	"sync"
 	"time"
-	"unicode/utf8"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/sergi/go-diff/diffmatchpatch"
@@ -37,22 +38,69 @@ type BurndownAnalysis struct {
 	// memory. 30 ticks is usually enough.
 	Granularity int
 	// Sampling sets how detailed is the statistic - the size of the interval in
-	// ticks between consecutive measurements. It may not be greater than Granularity. Try 15 or 30.
+	// ticks between consecutive measurements. It may be less than Granularity but not greater,
+	// otherwise it is adjusted to Granularity. Try 15 or 30.
 	Sampling int
 
 	// TrackFiles enables or disables the fine-grained per-file burndown analysis.
 	// It does not change the project level burndown results.
 	TrackFiles bool
+	// FilesGlob restricts TrackFiles to the paths which match this glob pattern, e.g. "src/**",
+	// to save the memory and the output size. Empty tracks all the files.
+	FilesGlob string
 
 	// PeopleNumber is the number of developers for which to collect the burndown stats. 0 disables it.
 	PeopleNumber int
 
+	// MaxPeople caps the number of developers in the result: the top MaxPeople developers by
+	// the number of added lines are kept and the rest are merged into the synthetic
+	// BurndownOthers developer. 0 keeps everybody.
+	MaxPeople int
+
+	// AuthorActivity enables the "active-author survival" matrix which excludes the lines
+	// written by the developers who have not committed within AuthorActivityWindow ticks.
+	// It requires PeopleNumber to be greater than 0.
+	AuthorActivity bool
+
+	// AuthorActivityWindow is the number of trailing ticks in which a developer must have
+	// committed in order to be considered active.
+	AuthorActivityWindow int
+
+	// CommitCounts enables counting the commits which inserted lines into each band,
+	// see BurndownResult.GlobalCommitCounts.
+	CommitCounts bool
+
+	// SampleCommits enables recording the last commit processed before each sample boundary,
+	// see BurndownResult.SampleCommits.
+	SampleCommits bool
+
+	// TotalLines enables summing the alive lines in each tick, see BurndownResult.TotalLines.
+	TotalLines bool
+
+	// IgnoreWhitespace makes the line diffs ignore the whitespace changes, so that reformatting
+	// does not reset the line ownership. ConfigureDependencies() turns on
+	// FileDiff.WhitespaceIgnore for that, so the rest of the FileDiff users ignore them too.
+	IgnoreWhitespace bool
+	// MeasureUnit is the name of the unit which the files are measured in: MeasureUnitLines
+	// (the default) or MeasureUnitBytes. All the burndown numbers are in this unit.
+	MeasureUnit string
+	// OldVsNewThreshold is the age in ticks starting from which the changed lines are considered
+	// old, see BurndownResult.OldVsNew. 0 disables the old vs. new lines counting.
+	OldVsNewThreshold int
+
+	// HighPrecision makes MergeResults() resample and sum the matrices in float64 instead of
+	// float32 and round the merged values to the nearest integer instead of truncating them,
+	// so that the error does not accumulate when many results are combined.
+	HighPrecision bool
+
 	// TickSize indicates the size of each time granule: day, hour, week, etc.
 	TickSize time.Duration
 
 	// HibernationThreshold sets the hibernation threshold for the underlying
 	// RBTree allocator. It is useful to trade CPU time for reduced peak memory consumption
-	// if there are many branches.
+	// if there are many branches. The allocator is compressed only if it holds at least this
+	// many nodes when the pipeline hibernates the branch, that is, Pipeline.HibernationDistance
+	// decides when and HibernationThreshold decides whether. 0 compresses every branch.
 	HibernationThreshold int
 
 	// HibernationToDisk specifies whether the hibernated RBTree allocator must be saved on disk
@@ -81,14 +129,27 @@ type BurndownAnalysis struct {
 	// map[12][10] = -3
 	// map[12][12] = 10
 	globalHistory sparseHistory
+	// globalCommits is the number of commits with insertions per tick, in the globalHistory format.
+	globalCommits sparseHistory
+	// sampleCommits is the log of the consumed commits and their ticks in the processing order.
+	// It is shared between the forks so that the order is global.
+	sampleCommits *[]tickCommit
+	// inserted indicates whether the currently consumed commit has inserted any lines.
+	inserted bool
+	// oldVsNew is the number of old and new added and removed lines per tick, see BurndownResult.OldVsNew.
+	oldVsNew map[int][]int64
 	// fileHistories is the daily deltas of each file's daily line counts.
 	fileHistories map[string]sparseHistory
 	// peopleHistories is the daily deltas of each person's daily line counts.
 	peopleHistories []sparseHistory
+	// peopleActivity is the set of ticks with at least one commit for each person.
+	peopleActivity []map[int]bool
 	// files is the mapping <file path> -> *File.
 	files map[string]*burndown.File
 	// fileAllocator is the allocator for RBTree-s in `files`.
 	fileAllocator *rbtree.Allocator
+	// measurer measures the files in MeasureUnit.
+	measurer BlobMeasurer
 	// hibernatedFileName is the path to the serialized `fileAllocator`.
 	hibernatedFileName string
 	// mergedFiles is used during merges to record the real file hashes
@@ -108,6 +169,19 @@ type BurndownAnalysis struct {
 	previousTick int
 	// references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
+	// normalizeIdentities references IdentityDetector.Normalize, see MergeResults().
+	normalizeIdentities bool
+	// linesOnly disables all the histories, so that only the lines of the files are tracked,
+	// see lineTracker. Finalize() must not be called then.
+	linesOnly bool
+	// lineEvents is called back with the number of inserted and removed lines of each file
+	// changed by a regular commit, see LineEventsAnalysis. It is shared between the forks.
+	lineEvents func(path string, inserted, removed int)
+	// violations collects the integrity violations instead of failing the analysis,
+	// see FactBurndownIntegrityViolations. It is shared between the forks.
+	violations *[]IntegrityViolation
+	// commit is the hash of the consumed commit which is recorded in the violations.
+	commit plumbing.Hash
 
 	l core.Logger
 }
@@ -134,6 +208,32 @@ type BurndownResult struct {
 	// The rest of the elements are equal the number of line removals by the corresponding
 	// authors in reversedPeopleDict: 2 -> 0, 3 -> 1, etc.
 	PeopleMatrix DenseHistory
+	// [number of samples][number of bands]
+	// The same as GlobalHistory but only the lines which belong to the developers with at least
+	// one commit within the trailing activity window are counted. Empty unless
+	// BurndownAnalysis.AuthorActivity is enabled.
+	ActiveAuthorsHistory DenseHistory
+	// [number of samples][number of bands]
+	// The number of commits which inserted lines into each band, as of each sample.
+	// It helps to tell whether the lines of a band come from a few big or many small commits.
+	// Empty unless BurndownAnalysis.CommitCounts is enabled.
+	GlobalCommitCounts DenseHistory
+	// [number of samples]
+	// The last commit processed before each sample boundary, so that each row of GlobalHistory
+	// can be mapped to a snapshot of the repository.
+	// Empty unless BurndownAnalysis.SampleCommits is enabled.
+	SampleCommits []plumbing.Hash
+	// [number of ticks]
+	// The number of alive lines at the end of each tick, that is, the sum over the bands
+	// without the sampling.
+	// Empty unless BurndownAnalysis.TotalLines is enabled.
+	TotalLines []int64
+	// [number of ticks][4]
+	// The number of changed lines in each tick: old added, old removed, new added, new removed.
+	// The removed lines are old if they were written at least BurndownAnalysis.OldVsNewThreshold
+	// ticks before; the added lines are old if they replace at least one old line.
+	// Empty unless BurndownAnalysis.OldVsNewThreshold is positive.
+	OldVsNew DenseHistory
 
 	// The following members are private.
 
@@ -147,6 +247,10 @@ type BurndownResult struct {
 	// such as merging several results together.
 	sampling    int
 	granularity int
+	// activityWindow is copied from BurndownAnalysis.AuthorActivityWindow.
+	activityWindow int
+	// oldVsNewThreshold is copied from BurndownAnalysis.OldVsNewThreshold.
+	oldVsNewThreshold int
 }
 
 const (
@@ -156,11 +260,34 @@ const (
 	ConfigBurndownSampling = "Burndown.Sampling"
 	// ConfigBurndownTrackFiles enables burndown collection for files.
 	ConfigBurndownTrackFiles = "Burndown.TrackFiles"
+	// ConfigBurndownFilesGlob is the name of the option to set BurndownAnalysis.FilesGlob.
+	ConfigBurndownFilesGlob = "Burndown.FilesGlob"
 	// ConfigBurndownTrackPeople enables burndown collection for authors.
 	ConfigBurndownTrackPeople = "Burndown.TrackPeople"
+	// ConfigBurndownMaxPeople is the name of the option to set BurndownAnalysis.MaxPeople.
+	ConfigBurndownMaxPeople = "Burndown.MaxPeople"
+	// ConfigBurndownAuthorActivity enables the "active-author survival" matrix.
+	ConfigBurndownAuthorActivity = "Burndown.AuthorActivity"
+	// ConfigBurndownAuthorActivityWindow is the name of the option to set
+	// BurndownAnalysis.AuthorActivityWindow.
+	ConfigBurndownAuthorActivityWindow = "Burndown.AuthorActivityWindow"
+	// ConfigBurndownCommitCounts enables the commit counts matrix.
+	ConfigBurndownCommitCounts = "Burndown.CommitCounts"
+	// ConfigBurndownSampleCommits enables recording the last commit of each sample.
+	ConfigBurndownSampleCommits = "Burndown.SampleCommits"
+	// ConfigBurndownTotalLines enables counting the alive lines in each tick.
+	ConfigBurndownTotalLines = "Burndown.TotalLines"
+	// ConfigBurndownIgnoreWhitespace is the name of the option to set
+	// BurndownAnalysis.IgnoreWhitespace.
+	ConfigBurndownIgnoreWhitespace = "Burndown.IgnoreWhitespace"
+	// ConfigBurndownMeasureUnit is the name of the option to set BurndownAnalysis.MeasureUnit.
+	ConfigBurndownMeasureUnit = "Burndown.MeasureUnit"
+	// ConfigBurndownOldVsNewThreshold is the name of the option to set
+	// BurndownAnalysis.OldVsNewThreshold.
+	ConfigBurndownOldVsNewThreshold = "Burndown.OldVsNewThreshold"
 	// ConfigBurndownHibernationThreshold sets the hibernation threshold for the underlying
-	// RBTree allocator. It is useful to trade CPU time for reduced peak memory consumption
-	// if there are many branches.
+	// RBTree allocator, see BurndownAnalysis.HibernationThreshold. It has no effect unless
+	// core.ConfigPipelineHibernationDistance is positive.
 	ConfigBurndownHibernationThreshold = "Burndown.HibernationThreshold"
 	// ConfigBurndownHibernationToDisk sets whether the hibernated RBTree allocator must be saved
 	// on disk rather than kept in memory.
@@ -170,16 +297,50 @@ const (
 	ConfigBurndownHibernationDirectory = "Burndown.HibernationDirectory"
 	// ConfigBurndownDebug enables some extra debug assertions.
 	ConfigBurndownDebug = "Burndown.Debug"
+	// ConfigBurndownHighPrecision is the name of the option to set BurndownAnalysis.HighPrecision.
+	ConfigBurndownHighPrecision = "Burndown.HighPrecision"
+	// FactBurndownIntegrityViolations is the *[]IntegrityViolation which collects the internal
+	// integrity errors of BurndownAnalysis and of the analyses which embed it. If it is set,
+	// the trees are validated after each change and the broken files are restarted from their
+	// current contents instead of failing the analysis.
+	FactBurndownIntegrityViolations = "Burndown.IntegrityViolations"
 	// DefaultBurndownGranularity is the default number of ticks for BurndownAnalysis.Granularity
 	// and BurndownAnalysis.Sampling.
 	DefaultBurndownGranularity = 30
+	// DefaultBurndownAuthorActivityWindow is the default number of ticks for
+	// BurndownAnalysis.AuthorActivityWindow.
+	DefaultBurndownAuthorActivityWindow = 90
 	// authorSelf is the internal author index which is used in BurndownAnalysis.Finalize() to
 	// format the author overwrites matrix.
 	authorSelf = identity.AuthorMissing - 1
+	// BurndownOthers is the name of the synthetic developer which owns the lines of everybody
+	// beyond BurndownAnalysis.MaxPeople.
+	BurndownOthers = "others"
 )
 
 type sparseHistory = map[int]map[int]int64
 
+// tickCommit is the commit hash consumed at the specified tick.
+type tickCommit struct {
+	tick int
+	hash plumbing.Hash
+}
+
+// IntegrityViolation is the internal integrity error of BurndownAnalysis, which is caused by
+// the corrupted diffs or by a bug in the line tracking, see FactBurndownIntegrityViolations.
+type IntegrityViolation struct {
+	// Commit is the hash of the commit which triggered the violation.
+	Commit plumbing.Hash
+	// File is the path of the broken file.
+	File string
+	// Expected is the size of the file according to the diff, in MeasureUnit.
+	Expected int
+	// Actual is the size of the file according to the tracked line history, in MeasureUnit.
+	Actual int
+	// Message describes the violation.
+	Message string
+}
+
 // DenseHistory is the matrix [number of samples][number of bands] -> number of lines.
 //                                    y                  x
 type DenseHistory = [][]int64
@@ -202,7 +363,7 @@ func (analyser *BurndownAnalysis) Provides() []string {
 func (analyser *BurndownAnalysis) Requires() []string {
 	return []string{
 		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
-		items.DependencyTick, identity.DependencyAuthor}
+		items.DependencyTick, identity.DependencyAuthor, items.DependencyCopies}
 }
 
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
@@ -223,14 +384,73 @@ func (analyser *BurndownAnalysis) ListConfigurationOptions() []core.Configuratio
 		Flag:        "burndown-files",
 		Type:        core.BoolConfigurationOption,
 		Default:     false}, {
+		Name: ConfigBurndownFilesGlob,
+		Description: "Record the per-file statistics only for the paths which match this glob " +
+			"pattern, e.g. \"src/**\"; requires --burndown-files. \"**\" matches any number " +
+			"of directories.",
+		Flag:    "burndown-files-glob",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
 		Name:        ConfigBurndownTrackPeople,
 		Description: "Record detailed statistics per each developer.",
 		Flag:        "burndown-people",
 		Type:        core.BoolConfigurationOption,
 		Default:     false}, {
+		Name: ConfigBurndownMaxPeople,
+		Description: "Keep only this many developers with the most added lines and merge the rest " +
+			"into \"" + BurndownOthers + "\"; requires --burndown-people. 0 keeps everybody.",
+		Flag:    "burndown-max-people",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name: ConfigBurndownAuthorActivity,
+		Description: "Record the burndown of the lines which belong to the developers who committed " +
+			"within the trailing activity window; requires --burndown-people.",
+		Flag:    "burndown-author-activity",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name:        ConfigBurndownAuthorActivityWindow,
+		Description: "How many trailing time ticks to consider when deciding whether a developer is active.",
+		Flag:        "burndown-author-activity-window",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultBurndownAuthorActivityWindow}, {
+		Name:        ConfigBurndownCommitCounts,
+		Description: "Record how many commits inserted lines into each band.",
+		Flag:        "burndown-commit-counts",
+		Type:        core.BoolConfigurationOption,
+		Default:     false}, {
+		Name:        ConfigBurndownSampleCommits,
+		Description: "Record the hash of the last commit processed before each sample boundary.",
+		Flag:        "burndown-sample-commits",
+		Type:        core.BoolConfigurationOption,
+		Default:     false}, {
+		Name:        ConfigBurndownTotalLines,
+		Description: "Record the total number of alive lines at the end of each tick.",
+		Flag:        "burndown-total-lines",
+		Type:        core.BoolConfigurationOption,
+		Default:     false}, {
+		Name: ConfigBurndownIgnoreWhitespace,
+		Description: "Do not change the line ownership on whitespace-only edits. This turns on " +
+			"--no-diff-whitespace, so the other analyses ignore the whitespace as well.",
+		Flag:    "burndown-ignore-whitespace",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name: ConfigBurndownMeasureUnit,
+		Description: "The unit to measure the files in: \"" + MeasureUnitLines + "\" or \"" +
+			MeasureUnitBytes + "\". Bytes do not over-weight the verbose languages.",
+		Flag:    "burndown-unit",
+		Type:    core.StringConfigurationOption,
+		Default: MeasureUnitLines}, {
+		Name: ConfigBurndownOldVsNewThreshold,
+		Description: "Count the added and removed lines in each tick as old or new, the old being " +
+			"at least this many ticks old. 0 disables.",
+		Flag:    "burndown-old-vs-new-threshold",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
 		Name: ConfigBurndownHibernationThreshold,
-		Description: "The minimum size for the allocated memory in each branch to be compressed." +
-			"0 disables this optimization. Lower values trade CPU time more. Sane examples: Nx1000.",
+		Description: "The minimum number of the allocated line intervals in each branch to be " +
+			"compressed when it hibernates; requires --hibernation-distance to be greater than zero. " +
+			"0 compresses every hibernated branch. Lower values trade CPU time more. " +
+			"Sane examples: Nx1000.",
 		Flag:    "burndown-hibernation-threshold",
 		Type:    core.IntConfigurationOption,
 		Default: 0}, {
@@ -250,7 +470,13 @@ func (analyser *BurndownAnalysis) ListConfigurationOptions() []core.Configuratio
 		Description: "Validate the trees at each step.",
 		Flag:        "burndown-debug",
 		Type:        core.BoolConfigurationOption,
-		Default:     false},
+		Default:     false}, {
+		Name: ConfigBurndownHighPrecision,
+		Description: "Merge the burndown matrices of several repositories in float64 and round " +
+			"instead of truncating to reduce the drift when combining many results.",
+		Flag:    "burndown-high-precision",
+		Type:    core.BoolConfigurationOption,
+		Default: false},
 	}
 	return options[:]
 }
@@ -262,15 +488,31 @@ func (analyser *BurndownAnalysis) Configure(facts map[string]interface{}) error
 	} else {
 		analyser.l = core.NewLogger()
 	}
+	if val, exists := facts[identity.ConfigIdentityDetectorNormalize].(bool); exists {
+		analyser.normalizeIdentities = val
+	}
 	if val, exists := facts[ConfigBurndownGranularity].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("%s may not be negative: %d", ConfigBurndownGranularity, val)
+		}
 		analyser.Granularity = val
 	}
 	if val, exists := facts[ConfigBurndownSampling].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("%s may not be negative: %d", ConfigBurndownSampling, val)
+		}
 		analyser.Sampling = val
 	}
 	if val, exists := facts[ConfigBurndownTrackFiles].(bool); exists {
 		analyser.TrackFiles = val
 	}
+	if val, exists := facts[ConfigBurndownFilesGlob].(string); exists {
+		val = strings.TrimSpace(val)
+		if err := items.ValidateGlob(val); err != nil {
+			return fmt.Errorf("invalid %s pattern %q: %v", ConfigBurndownFilesGlob, val, err)
+		}
+		analyser.FilesGlob = val
+	}
 	if people, exists := facts[ConfigBurndownTrackPeople].(bool); people {
 		if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
 			if val < 0 {
@@ -282,7 +524,62 @@ func (analyser *BurndownAnalysis) Configure(facts map[string]interface{}) error
 	} else if exists {
 		analyser.PeopleNumber = 0
 	}
+	if val, exists := facts[FactBurndownIntegrityViolations].(*[]IntegrityViolation); exists {
+		analyser.violations = val
+	} else if val, _ := facts[core.ConfigPipelineContinueOnError].(bool); val {
+		// the skipped commits leave the files which they change out of sync, restart them
+		analyser.violations = &[]IntegrityViolation{}
+	}
+	if val, exists := facts[ConfigBurndownMaxPeople].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("MaxPeople may not be negative: %d", val)
+		}
+		analyser.MaxPeople = val
+	}
+	if val, exists := facts[ConfigBurndownAuthorActivity].(bool); exists {
+		analyser.AuthorActivity = val
+	}
+	if val, exists := facts[ConfigBurndownAuthorActivityWindow].(int); exists {
+		if val <= 0 {
+			return fmt.Errorf("AuthorActivityWindow must be positive: %d", val)
+		}
+		analyser.AuthorActivityWindow = val
+	}
+	if val, exists := facts[ConfigBurndownCommitCounts].(bool); exists {
+		analyser.CommitCounts = val
+	}
+	if val, exists := facts[ConfigBurndownSampleCommits].(bool); exists {
+		analyser.SampleCommits = val
+	}
+	if val, exists := facts[ConfigBurndownTotalLines].(bool); exists {
+		analyser.TotalLines = val
+	}
+	if val, exists := facts[ConfigBurndownIgnoreWhitespace].(bool); exists {
+		analyser.IgnoreWhitespace = val
+	}
+	if ignore, _ := facts[items.ConfigFileWhitespaceIgnore].(bool); analyser.IgnoreWhitespace && !ignore {
+		analyser.l.Warnf("%s has no effect without %s, see ConfigureDependencies()",
+			ConfigBurndownIgnoreWhitespace, items.ConfigFileWhitespaceIgnore)
+	}
+	if val, exists := facts[ConfigBurndownMeasureUnit].(string); exists {
+		if _, err := NewBlobMeasurer(val); err != nil {
+			return err
+		}
+		analyser.MeasureUnit = val
+	}
+	if val, exists := facts[ConfigBurndownOldVsNewThreshold].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("OldVsNewThreshold may not be negative: %d", val)
+		}
+		analyser.OldVsNewThreshold = val
+	}
+	if analyser.AuthorActivity && analyser.PeopleNumber == 0 {
+		return errors.New("the author activity tracking requires --burndown-people")
+	}
 	if val, exists := facts[ConfigBurndownHibernationThreshold].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("HibernationThreshold may not be negative: %d", val)
+		}
 		analyser.HibernationThreshold = val
 	}
 	if val, exists := facts[ConfigBurndownHibernationToDisk].(bool); exists {
@@ -294,6 +591,9 @@ func (analyser *BurndownAnalysis) Configure(facts map[string]interface{}) error
 	if val, exists := facts[ConfigBurndownDebug].(bool); exists {
 		analyser.Debug = val
 	}
+	if val, exists := facts[ConfigBurndownHighPrecision].(bool); exists {
+		analyser.HighPrecision = val
+	}
 	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
 		analyser.TickSize = val
 	}
@@ -305,6 +605,21 @@ func (analyser *BurndownAnalysis) Flag() string {
 	return "burndown"
 }
 
+// ConsumesMerges returns true because Merge() reconciles the files of the branches with the merge
+// commits and --no-merges would lose the lines written while resolving the conflicts.
+func (analyser *BurndownAnalysis) ConsumesMerges() bool {
+	return true
+}
+
+// ConfigureDependencies enables FileDiff.WhitespaceIgnore if IgnoreWhitespace is requested,
+// because Burndown reuses the line diffs of FileDiff.
+func (analyser *BurndownAnalysis) ConfigureDependencies(facts map[string]interface{}) error {
+	if val, _ := facts[ConfigBurndownIgnoreWhitespace].(bool); val {
+		facts[items.ConfigFileWhitespaceIgnore] = true
+	}
+	return nil
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (analyser *BurndownAnalysis) Description() string {
 	return "Line burndown stats indicate the numbers of lines which were last edited within " +
@@ -314,20 +629,29 @@ func (analyser *BurndownAnalysis) Description() string {
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (analyser *BurndownAnalysis) Initialize(repository *git.Repository) error {
-	analyser.l = core.NewLogger()
-	if analyser.Granularity <= 0 {
+	if analyser.l == nil {
+		analyser.l = core.NewLogger()
+	}
+	if analyser.Granularity < 0 {
+		return fmt.Errorf("%s may not be negative: %d", ConfigBurndownGranularity, analyser.Granularity)
+	}
+	if analyser.Granularity == 0 {
 		analyser.l.Warnf("adjusted the granularity to %d ticks\n",
 			DefaultBurndownGranularity)
 		analyser.Granularity = DefaultBurndownGranularity
 	}
-	if analyser.Sampling <= 0 {
+	if analyser.Sampling < 0 {
+		return fmt.Errorf("%s may not be negative: %d", ConfigBurndownSampling, analyser.Sampling)
+	}
+	if analyser.Sampling == 0 {
 		analyser.l.Warnf("adjusted the sampling to %d ticks\n",
 			DefaultBurndownGranularity)
 		analyser.Sampling = DefaultBurndownGranularity
 	}
 	if analyser.Sampling > analyser.Granularity {
-		analyser.l.Warnf("granularity may not be less than sampling, adjusted to %d\n",
-			analyser.Granularity)
+		// each band must be sampled at least once
+		analyser.l.Warnf("sampling (%d) may not be greater than granularity (%d), adjusted to %d\n",
+			analyser.Sampling, analyser.Granularity, analyser.Granularity)
 		analyser.Sampling = analyser.Granularity
 	}
 	if analyser.TickSize == 0 {
@@ -335,13 +659,35 @@ func (analyser *BurndownAnalysis) Initialize(repository *git.Repository) error {
 		analyser.l.Warnf("tick size was not set, adjusted to %v\n", def)
 		analyser.TickSize = items.DefaultTicksSinceStartTickSize * time.Hour
 	}
+	if time.Duration(analyser.Granularity) > math.MaxInt64/analyser.TickSize {
+		return fmt.Errorf("%s is too big: %d ticks of %v each", ConfigBurndownGranularity,
+			analyser.Granularity, analyser.TickSize)
+	}
+	measurer, err := NewBlobMeasurer(analyser.MeasureUnit)
+	if err != nil {
+		return err
+	}
+	analyser.measurer = measurer
 	analyser.repository = repository
 	analyser.globalHistory = sparseHistory{}
+	analyser.globalCommits = sparseHistory{}
+	analyser.sampleCommits = &[]tickCommit{}
+	analyser.oldVsNew = map[int][]int64{}
 	analyser.fileHistories = map[string]sparseHistory{}
 	if analyser.PeopleNumber < 0 {
 		return fmt.Errorf("PeopleNumber is negative: %d", analyser.PeopleNumber)
 	}
 	analyser.peopleHistories = make([]sparseHistory, analyser.PeopleNumber)
+	if analyser.AuthorActivity {
+		if analyser.AuthorActivityWindow <= 0 {
+			analyser.l.Warnf("adjusted the author activity window to %d ticks\n",
+				DefaultBurndownAuthorActivityWindow)
+			analyser.AuthorActivityWindow = DefaultBurndownAuthorActivityWindow
+		}
+		analyser.peopleActivity = make([]map[int]bool, analyser.PeopleNumber)
+	} else {
+		analyser.peopleActivity = nil
+	}
 	analyser.files = map[string]*burndown.File{}
 	analyser.fileAllocator = rbtree.NewAllocator()
 	analyser.fileAllocator.HibernationThreshold = analyser.HibernationThreshold
@@ -369,6 +715,7 @@ func (analyser *BurndownAnalysis) Consume(deps map[string]interface{}) (map[stri
 	if !deps[core.DependencyIsMerge].(bool) {
 		analyser.tick = tick
 		analyser.onNewTick()
+		analyser.recordActivity(author, tick)
 	} else {
 		// effectively disables the status updates if the commit is a merge
 		// we will analyse the conflicts resolution in Merge()
@@ -376,14 +723,31 @@ func (analyser *BurndownAnalysis) Consume(deps map[string]interface{}) (map[stri
 		analyser.mergedFiles = map[string]bool{}
 		analyser.mergedAuthor = author
 	}
+	if analyser.violations != nil {
+		if commit, ok := deps[core.DependencyCommit].(*object.Commit); ok {
+			analyser.commit = commit.Hash
+		}
+	}
 	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*items.CachedBlob)
 	treeDiffs := deps[items.DependencyTreeChanges].(object.Changes)
 	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	analyser.inserted = false
+	// the copies must be cloned before the sources change
+	copies, _ := deps[items.DependencyCopies].(map[string]string)
+	copied := map[string]bool{}
+	if analyser.tick != burndown.TreeMergeMark {
+		for name, source := range copies {
+			copied[name] = analyser.handleCopy(source, name)
+		}
+	}
 	for _, change := range treeDiffs {
 		action, _ := change.Action()
 		var err error
 		switch action {
 		case merkletrie.Insert:
+			if copied[change.To.Name] {
+				continue
+			}
 			err = analyser.handleInsertion(change, author, cache)
 		case merkletrie.Delete:
 			err = analyser.handleDeletion(change, author, cache)
@@ -394,6 +758,18 @@ func (analyser *BurndownAnalysis) Consume(deps map[string]interface{}) (map[stri
 			return nil, err
 		}
 	}
+	if analyser.CommitCounts && analyser.inserted && analyser.tick != burndown.TreeMergeMark {
+		ticks := analyser.globalCommits[tick]
+		if ticks == nil {
+			ticks = map[int]int64{}
+			analyser.globalCommits[tick] = ticks
+		}
+		ticks[tick]++
+	}
+	if analyser.SampleCommits {
+		*analyser.sampleCommits = append(*analyser.sampleCommits, tickCommit{
+			tick: tick, hash: deps[core.DependencyCommit].(*object.Commit).Hash})
+	}
 	// in case there is a merge analyser.tick equals to TreeMergeMark
 	analyser.tick = tick
 	return nil, nil
@@ -516,26 +892,12 @@ func (analyser *BurndownAnalysis) Finalize() interface{} {
 	fileHistories := map[string]DenseHistory{}
 	fileOwnership := map[string]map[int]int{}
 	for key, history := range analyser.fileHistories {
-		if len(history) == 0 {
+		if len(history) == 0 || !analyser.matchFilesGlob(key) {
+			// the file could be renamed outside of FilesGlob
 			continue
 		}
 		fileHistories[key], _ = analyser.groupSparseHistory(history, lastTick)
-		file := analyser.files[key]
-		previousLine := 0
-		previousAuthor := identity.AuthorMissing
-		ownership := map[int]int{}
-		fileOwnership[key] = ownership
-		file.ForEach(func(line, value int) {
-			length := line - previousLine
-			if length > 0 {
-				ownership[previousAuthor] += length
-			}
-			previousLine = line
-			previousAuthor, _ = analyser.unpackPersonWithTick(int(value))
-			if previousAuthor == identity.AuthorMissing {
-				previousAuthor = -1
-			}
-		})
+		fileOwnership[key] = analyser.fileOwnership(analyser.files[key])
 	}
 	peopleHistories := make([]DenseHistory, analyser.PeopleNumber)
 	for i, history := range analyser.peopleHistories {
@@ -549,6 +911,37 @@ func (analyser *BurndownAnalysis) Finalize() interface{} {
 			}
 		}
 	}
+	var activeAuthorsHistory DenseHistory
+	if analyser.AuthorActivity {
+		activeAuthorsHistory = analyser.groupActiveAuthorsHistory(peopleHistories, globalHistory, lastTick)
+	}
+	var globalCommitCounts DenseHistory
+	if analyser.CommitCounts {
+		if len(analyser.globalCommits) > 0 {
+			globalCommitCounts, _ = analyser.groupSparseHistory(analyser.globalCommits, lastTick)
+		} else {
+			globalCommitCounts = make(DenseHistory, len(globalHistory))
+			for i, gh := range globalHistory {
+				globalCommitCounts[i] = make([]int64, len(gh))
+			}
+		}
+	}
+	var sampleCommits []plumbing.Hash
+	if analyser.SampleCommits {
+		sampleCommits = analyser.groupSampleCommits(len(globalHistory))
+	}
+	var totalLines []int64
+	if analyser.TotalLines {
+		totalLines = analyser.groupTotalLines(lastTick)
+	}
+	var oldVsNew DenseHistory
+	if analyser.OldVsNewThreshold > 0 {
+		oldVsNew = make(DenseHistory, lastTick+1)
+		for tick := range oldVsNew {
+			oldVsNew[tick] = make([]int64, 4)
+			copy(oldVsNew[tick], analyser.oldVsNew[tick])
+		}
+	}
 	var peopleMatrix DenseHistory
 	if len(analyser.matrix) > 0 {
 		peopleMatrix = make(DenseHistory, analyser.PeopleNumber)
@@ -565,16 +958,110 @@ func (analyser *BurndownAnalysis) Finalize() interface{} {
 			}
 		}
 	}
-	return BurndownResult{
-		GlobalHistory:      globalHistory,
-		FileHistories:      fileHistories,
-		FileOwnership:      fileOwnership,
-		PeopleHistories:    peopleHistories,
-		PeopleMatrix:       peopleMatrix,
-		tickSize:           analyser.TickSize,
-		reversedPeopleDict: analyser.reversedPeopleDict,
-		sampling:           analyser.Sampling,
-		granularity:        analyser.Granularity,
+	result := BurndownResult{
+		GlobalHistory:        globalHistory,
+		FileHistories:        fileHistories,
+		FileOwnership:        fileOwnership,
+		PeopleHistories:      peopleHistories,
+		PeopleMatrix:         peopleMatrix,
+		ActiveAuthorsHistory: activeAuthorsHistory,
+		GlobalCommitCounts:   globalCommitCounts,
+		SampleCommits:        sampleCommits,
+		TotalLines:           totalLines,
+		OldVsNew:             oldVsNew,
+		tickSize:             analyser.TickSize,
+		reversedPeopleDict:   analyser.reversedPeopleDict,
+		sampling:             analyser.Sampling,
+		granularity:          analyser.Granularity,
+		activityWindow:       analyser.AuthorActivityWindow,
+		oldVsNewThreshold:    analyser.OldVsNewThreshold,
+	}
+	if analyser.MaxPeople > 0 && analyser.PeopleNumber > analyser.MaxPeople {
+		capBurndownPeople(&result, analyser.MaxPeople)
+	}
+	return result
+}
+
+// capBurndownPeople keeps the maxPeople developers with the most added lines in the result and
+// merges the rest into BurndownOthers, which is appended to the end of reversedPeopleDict.
+// The kept developers preserve their relative order.
+func capBurndownPeople(result *BurndownResult, maxPeople int) {
+	peopleNumber := len(result.PeopleHistories)
+	if peopleNumber <= maxPeople {
+		return
+	}
+	added := make([]int64, peopleNumber)
+	for i, row := range result.PeopleMatrix {
+		added[i] = row[0]
+	}
+	order := make([]int, peopleNumber)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return added[order[i]] > added[order[j]]
+	})
+	kept := order[:maxPeople]
+	sort.Ints(kept)
+	// mapping maps the old developer indexes to the new ones; the tail maps to maxPeople
+	mapping := make([]int, peopleNumber)
+	for i := range mapping {
+		mapping[i] = maxPeople
+	}
+	reversedPeopleDict := make([]string, 0, maxPeople+1)
+	for newIndex, oldIndex := range kept {
+		mapping[oldIndex] = newIndex
+		if oldIndex < len(result.reversedPeopleDict) {
+			reversedPeopleDict = append(reversedPeopleDict, result.reversedPeopleDict[oldIndex])
+		} else {
+			reversedPeopleDict = append(reversedPeopleDict, "")
+		}
+	}
+	result.reversedPeopleDict = append(reversedPeopleDict, BurndownOthers)
+
+	peopleHistories := make([]DenseHistory, maxPeople+1)
+	for oldIndex, history := range result.PeopleHistories {
+		newIndex := mapping[oldIndex]
+		if peopleHistories[newIndex] == nil {
+			peopleHistories[newIndex] = make(DenseHistory, len(history))
+			for y, row := range history {
+				peopleHistories[newIndex][y] = make([]int64, len(row))
+			}
+		}
+		merged := peopleHistories[newIndex]
+		for y, row := range history {
+			for x, val := range row {
+				merged[y][x] += val
+			}
+		}
+	}
+	result.PeopleHistories = peopleHistories
+
+	if result.PeopleMatrix != nil {
+		peopleMatrix := make(DenseHistory, maxPeople+1)
+		for i := range peopleMatrix {
+			peopleMatrix[i] = make([]int64, maxPeople+3)
+		}
+		for oldIndex, row := range result.PeopleMatrix {
+			mrow := peopleMatrix[mapping[oldIndex]]
+			mrow[0] += row[0]
+			mrow[1] += row[1]
+			for key, val := range row[2:] {
+				mrow[mapping[key]+2] += val
+			}
+		}
+		result.PeopleMatrix = peopleMatrix
+	}
+
+	for file, ownership := range result.FileOwnership {
+		capped := map[int]int{}
+		for dev, lines := range ownership {
+			if dev >= 0 && dev < peopleNumber {
+				dev = mapping[dev]
+			}
+			capped[dev] += lines
+		}
+		result.FileOwnership[file] = capped
 	}
 }
 
@@ -641,6 +1128,26 @@ func (analyser *BurndownAnalysis) Deserialize(pbmessage []byte) (interface{}, er
 			result.PeopleMatrix[i][msg.PeopleInteraction.Indices[j]] = msg.PeopleInteraction.Data[j]
 		}
 	}
+	if msg.ActiveAuthors != nil {
+		result.ActiveAuthorsHistory = convertCSR(msg.ActiveAuthors)
+		result.activityWindow = int(msg.ActiveAuthorsWindow)
+	}
+	if msg.CommitCounts != nil {
+		result.GlobalCommitCounts = convertCSR(msg.CommitCounts)
+	}
+	if len(msg.SampleCommits) > 0 {
+		result.SampleCommits = make([]plumbing.Hash, len(msg.SampleCommits))
+		for i, hash := range msg.SampleCommits {
+			result.SampleCommits[i] = plumbing.NewHash(hash)
+		}
+	}
+	if len(msg.TotalLines) > 0 {
+		result.TotalLines = msg.TotalLines
+	}
+	if msg.OldVsNew != nil {
+		result.OldVsNew = convertCSR(msg.OldVsNew)
+		result.oldVsNewThreshold = int(msg.OldVsNewThreshold)
+	}
 	return result, nil
 }
 
@@ -650,7 +1157,7 @@ func (analyser *BurndownAnalysis) MergeResults(
 	bar1 := r1.(BurndownResult)
 	bar2 := r2.(BurndownResult)
 	if bar1.tickSize != bar2.tickSize {
-		return fmt.Errorf("mismatching tick sizes (r1: %d, r2: %d) received",
+		return fmt.Errorf("mismatching tick sizes (r1: %v, r2: %v) received",
 			bar1.tickSize, bar2.tickSize)
 	}
 	// for backwards-compatibility, if no tick size is present set to default
@@ -671,9 +1178,20 @@ func (analyser *BurndownAnalysis) MergeResults(
 	} else {
 		merged.granularity = bar2.granularity
 	}
+	merged.activityWindow = bar1.activityWindow
+	if merged.activityWindow == 0 {
+		merged.activityWindow = bar2.activityWindow
+	}
+	merged.oldVsNewThreshold = bar1.oldVsNewThreshold
+	if merged.oldVsNewThreshold == 0 {
+		merged.oldVsNewThreshold = bar2.oldVsNewThreshold
+	}
+	if len(bar1.OldVsNew) > 0 || len(bar2.OldVsNew) > 0 {
+		merged.OldVsNew = mergeOldVsNew(bar1.OldVsNew, bar2.OldVsNew, analyser.TickSize, c1, c2)
+	}
 	var people map[string]identity.MergedIndex
 	people, merged.reversedPeopleDict = identity.MergeReversedDictsIdentities(
-		bar1.reversedPeopleDict, bar2.reversedPeopleDict)
+		bar1.reversedPeopleDict, bar2.reversedPeopleDict, analyser.normalizeIdentities)
 	var wg sync.WaitGroup
 	if len(bar1.GlobalHistory) > 0 || len(bar2.GlobalHistory) > 0 {
 		wg.Add(1)
@@ -687,15 +1205,45 @@ func (analyser *BurndownAnalysis) MergeResults(
 				c1, c2)
 		}()
 	}
-	// we don't merge files
+	if len(bar1.ActiveAuthorsHistory) > 0 || len(bar2.ActiveAuthorsHistory) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			merged.ActiveAuthorsHistory = analyser.mergeMatrices(
+				bar1.ActiveAuthorsHistory, bar2.ActiveAuthorsHistory,
+				bar1.granularity, bar1.sampling,
+				bar2.granularity, bar2.sampling,
+				bar1.tickSize,
+				c1, c2)
+		}()
+	}
+	if len(bar1.GlobalCommitCounts) > 0 || len(bar2.GlobalCommitCounts) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			merged.GlobalCommitCounts = analyser.mergeMatrices(
+				bar1.GlobalCommitCounts, bar2.GlobalCommitCounts,
+				bar1.granularity, bar1.sampling,
+				bar2.granularity, bar2.sampling,
+				bar1.tickSize,
+				c1, c2)
+		}()
+	}
+	// we don't merge files, sample commits and total lines
 	if len(merged.reversedPeopleDict) > 0 {
 		if len(bar1.PeopleHistories) > 0 || len(bar2.PeopleHistories) > 0 {
 			merged.PeopleHistories = make([]DenseHistory, len(merged.reversedPeopleDict))
+			// there can be thousands of developers, so limit the number of parallel merges
+			slots := make(chan struct{}, runtime.GOMAXPROCS(0))
 			for i, key := range merged.reversedPeopleDict {
 				ptrs := people[key]
 				wg.Add(1)
+				slots <- struct{}{}
 				go func(i int) {
-					defer wg.Done()
+					defer func() {
+						<-slots
+						wg.Done()
+					}()
 					var m1, m2 DenseHistory
 					if ptrs.First >= 0 {
 						m1 = bar1.PeopleHistories[ptrs.First]
@@ -757,6 +1305,29 @@ func (analyser *BurndownAnalysis) MergeResults(
 	return merged
 }
 
+// mergeOldVsNew sums two [number of ticks][4] matrices, shifting the rows so that they
+// start from the earliest beginning time.
+func mergeOldVsNew(m1, m2 DenseHistory, tickSize time.Duration,
+	c1, c2 *core.CommonAnalysisResult) DenseHistory {
+	commonMerged := c1.Copy()
+	commonMerged.Merge(c2)
+	begin := roundTime(commonMerged.BeginTimeAsTime(), tickSize, false)
+	var result DenseHistory
+	add := func(matrix DenseHistory, offset int) {
+		for len(result) < len(matrix)+offset {
+			result = append(result, make([]int64, 4))
+		}
+		for tick, row := range matrix {
+			for i, val := range row {
+				result[tick+offset][i] += val
+			}
+		}
+	}
+	add(m1, roundTime(c1.BeginTimeAsTime(), tickSize, false)-begin)
+	add(m2, roundTime(c2.BeginTimeAsTime(), tickSize, false)-begin)
+	return result
+}
+
 func roundTime(t time.Time, d time.Duration, dir bool) int {
 	if !dir {
 		t = items.FloorTime(t, d)
@@ -791,25 +1362,53 @@ func (analyser *BurndownAnalysis) mergeMatrices(
 
 	size := roundTime(commonMerged.EndTimeAsTime(), tickSize, true) -
 		roundTime(commonMerged.BeginTimeAsTime(), tickSize, false)
+	offset1 := roundTime(c1.BeginTimeAsTime(), tickSize, false) -
+		roundTime(commonMerged.BeginTimeAsTime(), tickSize, false)
+	offset2 := roundTime(c2.BeginTimeAsTime(), tickSize, false) -
+		roundTime(commonMerged.BeginTimeAsTime(), tickSize, false)
+	result := make(DenseHistory, (size+sampling-1)/sampling)
+	for i := range result {
+		result[i] = make([]int64, (size+granularity-1)/granularity)
+	}
+
+	if analyser.HighPrecision {
+		perTick := make([][]float64, size+granularity)
+		for i := range perTick {
+			perTick[i] = make([]float64, size+sampling)
+		}
+		if len(m1) > 0 {
+			addBurndownMatrix64(m1, granularity1, sampling1, perTick, offset1)
+		}
+		if len(m2) > 0 {
+			addBurndownMatrix64(m2, granularity2, sampling2, perTick, offset2)
+		}
+		for i := range result {
+			sampledIndex := (i+1)*sampling - 1
+			for j := range result[i] {
+				accum := float64(0)
+				for k := j * granularity; k < (j+1)*granularity; k++ {
+					accum += perTick[sampledIndex][k]
+				}
+				result[i][j] = int64(math.Round(accum))
+			}
+		}
+		return result
+	}
+
 	perTick := make([][]float32, size+granularity)
 	for i := range perTick {
 		perTick[i] = make([]float32, size+sampling)
 	}
 	if len(m1) > 0 {
-		addBurndownMatrix(m1, granularity1, sampling1, perTick,
-			roundTime(c1.BeginTimeAsTime(), tickSize, false)-roundTime(commonMerged.BeginTimeAsTime(), tickSize, false))
+		addBurndownMatrix(m1, granularity1, sampling1, perTick, offset1)
 	}
 	if len(m2) > 0 {
-		addBurndownMatrix(m2, granularity2, sampling2, perTick,
-			roundTime(c2.BeginTimeAsTime(), tickSize, false)-roundTime(commonMerged.BeginTimeAsTime(), tickSize, false))
+		addBurndownMatrix(m2, granularity2, sampling2, perTick, offset2)
 	}
-
 	// convert daily to [][]int64
-	result := make(DenseHistory, (size+sampling-1)/sampling)
 	for i := range result {
-		result[i] = make([]int64, (size+granularity-1)/granularity)
 		sampledIndex := (i+1)*sampling - 1
-		for j := 0; j < len(result[i]); j++ {
+		for j := range result[i] {
 			accum := float32(0)
 			for k := j * granularity; k < (j+1)*granularity; k++ {
 				accum += perTick[sampledIndex][k]
@@ -827,6 +1426,30 @@ func (analyser *BurndownAnalysis) mergeMatrices(
 // Columns: *at least* len(matrix[...]) * granularity + offset
 // `matrix` can be sparse, so that the last columns which are equal to 0 are truncated.
 func addBurndownMatrix(matrix DenseHistory, granularity, sampling int, accPerTick [][]float32, offset int) {
+	perTick := resampleBurndownMatrix(
+		matrix, granularity, sampling, len(accPerTick), len(accPerTick[0]), offset)
+	for y, row := range perTick {
+		for x, val := range row {
+			accPerTick[y][x] += float32(val)
+		}
+	}
+}
+
+// addBurndownMatrix64 is addBurndownMatrix with the float64 accumulator,
+// see BurndownAnalysis.HighPrecision.
+func addBurndownMatrix64(matrix DenseHistory, granularity, sampling int, accPerTick [][]float64, offset int) {
+	perTick := resampleBurndownMatrix(
+		matrix, granularity, sampling, len(accPerTick), len(accPerTick[0]), offset)
+	for y, row := range perTick {
+		for x, val := range row {
+			accPerTick[y][x] += val
+		}
+	}
+}
+
+// resampleBurndownMatrix explodes `matrix` to the [rows][cols] per-tick matrix for
+// addBurndownMatrix() and addBurndownMatrix64().
+func resampleBurndownMatrix(matrix DenseHistory, granularity, sampling int, rows, cols int, offset int) [][]float64 {
 	// Determine the maximum number of bands; the actual one may be larger but we do not care
 	maxCols := 0
 	for _, row := range matrix {
@@ -835,17 +1458,15 @@ func addBurndownMatrix(matrix DenseHistory, granularity, sampling int, accPerTic
 		}
 	}
 	neededRows := len(matrix)*sampling + offset
-	if len(accPerTick) < neededRows {
-		log.Panicf("merge bug: too few per-tick rows: required %d, have %d",
-			neededRows, len(accPerTick))
+	if rows < neededRows {
+		log.Panicf("merge bug: too few per-tick rows: required %d, have %d", neededRows, rows)
 	}
-	if len(accPerTick[0]) < maxCols {
-		log.Panicf("merge bug: too few per-tick cols: required %d, have %d",
-			maxCols, len(accPerTick[0]))
+	if cols < maxCols {
+		log.Panicf("merge bug: too few per-tick cols: required %d, have %d", maxCols, cols)
 	}
-	perTick := make([][]float32, len(accPerTick))
-	for i, row := range accPerTick {
-		perTick[i] = make([]float32, len(row))
+	perTick := make([][]float64, rows)
+	for i := range perTick {
+		perTick[i] = make([]float64, cols)
 	}
 	for x := 0; x < maxCols; x++ {
 		for y := 0; y < len(matrix); y++ {
@@ -853,23 +1474,23 @@ func addBurndownMatrix(matrix DenseHistory, granularity, sampling int, accPerTic
 				// the future is zeros
 				continue
 			}
-			decay := func(startIndex int, startVal float32) {
+			decay := func(startIndex int, startVal float64) {
 				if startVal == 0 {
 					return
 				}
-				k := float32(matrix[y][x]) / startVal // <= 1
-				scale := float32((y+1)*sampling - startIndex)
+				k := float64(matrix[y][x]) / startVal // <= 1
+				scale := float64((y+1)*sampling - startIndex)
 				for i := x * granularity; i < (x+1)*granularity; i++ {
 					initial := perTick[startIndex-1+offset][i+offset]
 					for j := startIndex; j < (y+1)*sampling; j++ {
-						perTick[j+offset][i+offset] = initial * (1 + (k-1)*float32(j-startIndex+1)/scale)
+						perTick[j+offset][i+offset] = initial * (1 + (k-1)*float64(j-startIndex+1)/scale)
 					}
 				}
 			}
-			raise := func(finishIndex int, finishVal float32) {
-				var initial float32
+			raise := func(finishIndex int, finishVal float64) {
+				var initial float64
 				if y > 0 {
-					initial = float32(matrix[y-1][x])
+					initial = float64(matrix[y-1][x])
 				}
 				startIndex := y * sampling
 				if startIndex < x*granularity {
@@ -878,7 +1499,7 @@ func addBurndownMatrix(matrix DenseHistory, granularity, sampling int, accPerTic
 				if startIndex == finishIndex {
 					return
 				}
-				avg := (finishVal - initial) / float32(finishIndex-startIndex)
+				avg := (finishVal - initial) / float64(finishIndex-startIndex)
 				for j := y * sampling; j < finishIndex; j++ {
 					for i := startIndex; i <= j; i++ {
 						perTick[j+offset][i+offset] = avg
@@ -917,10 +1538,10 @@ func addBurndownMatrix(matrix DenseHistory, granularity, sampling int, accPerTic
 				//   /
 				//  / y
 				if x*granularity <= y*sampling {
-					raise((y+1)*sampling, float32(matrix[y][x]))
+					raise((y+1)*sampling, float64(matrix[y][x]))
 				} else if (y+1)*sampling > x*granularity {
-					raise((y+1)*sampling, float32(matrix[y][x]))
-					avg := float32(matrix[y][x]) / float32((y+1)*sampling-x*granularity)
+					raise((y+1)*sampling, float64(matrix[y][x]))
+					avg := float64(matrix[y][x]) / float64((y+1)*sampling-x*granularity)
 					for j := x * granularity; j < (y+1)*sampling; j++ {
 						for i := x * granularity; i <= j; i++ {
 							perTick[j+offset][i+offset] = avg
@@ -938,26 +1559,26 @@ func addBurndownMatrix(matrix DenseHistory, granularity, sampling int, accPerTic
 				//      /    y+1
 				//     /
 				//    y
-				v1 := float32(matrix[y-1][x])
-				v2 := float32(matrix[y][x])
-				var peak float32
-				delta := float32((x+1)*granularity - y*sampling)
-				var scale float32
-				var previous float32
+				v1 := float64(matrix[y-1][x])
+				v2 := float64(matrix[y][x])
+				var peak float64
+				delta := float64((x+1)*granularity - y*sampling)
+				var scale float64
+				var previous float64
 				if y > 0 && (y-1)*sampling >= x*granularity {
 					// x*g <= (y-1)*s <= y*s <= (x+1)*g <= (y+1)*s
 					//           |________|.......^
 					if y > 1 {
-						previous = float32(matrix[y-2][x])
+						previous = float64(matrix[y-2][x])
 					}
-					scale = float32(sampling)
+					scale = float64(sampling)
 				} else {
 					// (y-1)*s < x*g <= y*s <= (x+1)*g <= (y+1)*s
 					//            |______|.......^
 					if y == 0 {
-						scale = float32(sampling)
+						scale = float64(sampling)
 					} else {
-						scale = float32(y*sampling - x*granularity)
+						scale = float64(y*sampling - x*granularity)
 					}
 				}
 				peak = v1 + (v1-previous)/scale*delta
@@ -966,8 +1587,8 @@ func addBurndownMatrix(matrix DenseHistory, granularity, sampling int, accPerTic
 					if y < len(matrix)-1 {
 						// y*s <= (x+1)*g <= (y+1)*s < (y+2)*s
 						//           ^.........|_________|
-						k := (v2 - float32(matrix[y+1][x])) / float32(sampling) // > 0
-						peak = float32(matrix[y][x]) + k*float32((y+1)*sampling-(x+1)*granularity)
+						k := (v2 - float64(matrix[y+1][x])) / float64(sampling) // > 0
+						peak = float64(matrix[y][x]) + k*float64((y+1)*sampling-(x+1)*granularity)
 						// peak > v2 > v1
 					} else {
 						peak = v2
@@ -979,20 +1600,14 @@ func addBurndownMatrix(matrix DenseHistory, granularity, sampling int, accPerTic
 			} else {
 				// (x+1)*granularity < y*sampling
 				// y*sampling..(y+1)sampling
-				decay(y*sampling, float32(matrix[y-1][x]))
+				decay(y*sampling, float64(matrix[y-1][x]))
 			}
 		}
 	}
 	for y := len(matrix) * sampling; y+offset < len(perTick); y++ {
 		copy(perTick[y+offset], perTick[len(matrix)*sampling-1+offset])
 	}
-	// the original matrix has been resampled by tick
-	// add it to the accumulator
-	for y, row := range perTick {
-		for x, val := range row {
-			accPerTick[y][x] += val
-		}
-	}
+	return perTick
 }
 
 func (analyser *BurndownAnalysis) serializeText(result *BurndownResult, writer io.Writer) {
@@ -1045,6 +1660,33 @@ func (analyser *BurndownAnalysis) serializeText(result *BurndownResult, writer i
 		fmt.Fprintln(writer, "  people_interaction: |-")
 		yaml.PrintMatrix(writer, result.PeopleMatrix, 4, "", false)
 	}
+	if len(result.ActiveAuthorsHistory) > 0 {
+		fmt.Fprintln(writer, "  active_authors_window:", result.activityWindow)
+		yaml.PrintMatrix(writer, result.ActiveAuthorsHistory, 2, "active_authors", true)
+	}
+	if len(result.GlobalCommitCounts) > 0 {
+		yaml.PrintMatrix(writer, result.GlobalCommitCounts, 2, "commit_counts", true)
+	}
+	if len(result.SampleCommits) > 0 {
+		fmt.Fprintln(writer, "  sample_commits:")
+		for _, hash := range result.SampleCommits {
+			fmt.Fprintln(writer, "    -", hash.String())
+		}
+	}
+	if len(result.TotalLines) > 0 {
+		fmt.Fprint(writer, "  total_lines: [")
+		for i, val := range result.TotalLines {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprint(writer, val)
+		}
+		fmt.Fprintln(writer, "]")
+	}
+	if len(result.OldVsNew) > 0 {
+		fmt.Fprintln(writer, "  old_vs_new_threshold:", result.oldVsNewThreshold)
+		yaml.PrintMatrix(writer, result.OldVsNew, 2, "old_vs_new", true)
+	}
 }
 
 func (analyser *BurndownAnalysis) serializeBinary(result *BurndownResult, writer io.Writer) error {
@@ -1084,6 +1726,26 @@ func (analyser *BurndownAnalysis) serializeBinary(result *BurndownResult, writer
 	if result.PeopleMatrix != nil {
 		message.PeopleInteraction = pb.DenseToCompressedSparseRowMatrix(result.PeopleMatrix)
 	}
+	if len(result.ActiveAuthorsHistory) > 0 {
+		message.ActiveAuthors = pb.ToBurndownSparseMatrix(result.ActiveAuthorsHistory, "active_authors")
+		message.ActiveAuthorsWindow = int32(result.activityWindow)
+	}
+	if len(result.GlobalCommitCounts) > 0 {
+		message.CommitCounts = pb.ToBurndownSparseMatrix(result.GlobalCommitCounts, "commit_counts")
+	}
+	if len(result.SampleCommits) > 0 {
+		message.SampleCommits = make([]string, len(result.SampleCommits))
+		for i, hash := range result.SampleCommits {
+			message.SampleCommits[i] = hash.String()
+		}
+	}
+	if len(result.TotalLines) > 0 {
+		message.TotalLines = result.TotalLines
+	}
+	if len(result.OldVsNew) > 0 {
+		message.OldVsNew = pb.ToBurndownSparseMatrix(result.OldVsNew, "old_vs_new")
+		message.OldVsNewThreshold = int32(result.oldVsNewThreshold)
+	}
 	serialized, err := proto.Marshal(&message)
 	if err != nil {
 		return err
@@ -1133,6 +1795,26 @@ func (analyser *BurndownAnalysis) unpackPersonWithTick(value int) (int, int) {
 	return value >> burndown.TreeMaxBinPower, value & burndown.TreeMergeMark
 }
 
+// fileOwnership counts the lines of `file` which belong to each developer.
+// identity.AuthorMissing is mapped to -1.
+func (analyser *BurndownAnalysis) fileOwnership(file *burndown.File) map[int]int {
+	previousLine := 0
+	previousAuthor := identity.AuthorMissing
+	ownership := map[int]int{}
+	file.ForEach(func(line, value int) {
+		length := line - previousLine
+		if length > 0 {
+			ownership[previousAuthor] += length
+		}
+		previousLine = line
+		previousAuthor, _ = analyser.unpackPersonWithTick(int(value))
+		if previousAuthor == identity.AuthorMissing {
+			previousAuthor = -1
+		}
+	})
+	return ownership
+}
+
 func (analyser *BurndownAnalysis) onNewTick() {
 	if analyser.tick > analyser.previousTick {
 		analyser.previousTick = analyser.tick
@@ -1140,6 +1822,62 @@ func (analyser *BurndownAnalysis) onNewTick() {
 	analyser.mergedAuthor = identity.AuthorMissing
 }
 
+// recordActivity remembers that `author` committed at `tick`.
+func (analyser *BurndownAnalysis) recordActivity(author int, tick int) {
+	if analyser.peopleActivity == nil || author == identity.AuthorMissing {
+		return
+	}
+	ticks := analyser.peopleActivity[author]
+	if ticks == nil {
+		ticks = map[int]bool{}
+		analyser.peopleActivity[author] = ticks
+	}
+	ticks[tick] = true
+}
+
+// isActive returns true if `author` committed within the activity window which ends at `tick`.
+func (analyser *BurndownAnalysis) isActive(author int, tick int) bool {
+	ticks := analyser.peopleActivity[author]
+	if len(ticks) > analyser.AuthorActivityWindow {
+		for t := tick; t > tick-analyser.AuthorActivityWindow; t-- {
+			if ticks[t] {
+				return true
+			}
+		}
+		return false
+	}
+	for t := range ticks {
+		if t <= tick && t > tick-analyser.AuthorActivityWindow {
+			return true
+		}
+	}
+	return false
+}
+
+// groupActiveAuthorsHistory sums the people burndowns for each sample, skipping the developers
+// who were inactive at the time of that sample.
+func (analyser *BurndownAnalysis) groupActiveAuthorsHistory(
+	peopleHistories []DenseHistory, globalHistory DenseHistory, lastTick int) DenseHistory {
+	result := make(DenseHistory, len(globalHistory))
+	for i, gh := range globalHistory {
+		result[i] = make([]int64, len(gh))
+		// the state of each sample is recorded at its last tick
+		tick := (i+1)*analyser.Sampling - 1
+		if tick > lastTick {
+			tick = lastTick
+		}
+		for person, history := range peopleHistories {
+			if i >= len(history) || !analyser.isActive(person, tick) {
+				continue
+			}
+			for j, val := range history[i] {
+				result[i][j] += val
+			}
+		}
+	}
+	return result
+}
+
 func (analyser *BurndownAnalysis) updateGlobal(currentTime, previousTime, delta int) {
 	_, curTick := analyser.unpackPersonWithTick(currentTime)
 	_, prevTick := analyser.unpackPersonWithTick(previousTime)
@@ -1150,6 +1888,9 @@ func (analyser *BurndownAnalysis) updateGlobal(currentTime, previousTime, delta
 		analyser.globalHistory[curTick] = currentHistory
 	}
 	currentHistory[prevTick] += int64(delta)
+	if delta > 0 && curTick == prevTick {
+		analyser.inserted = true
+	}
 }
 
 // updateFile is bound to the specific `history` in the closure.
@@ -1212,9 +1953,28 @@ func (analyser *BurndownAnalysis) updateMatrix(currentTime, previousTime, delta
 func (analyser *BurndownAnalysis) newFile(
 	hash plumbing.Hash, name string, author int, tick int, size int) (*burndown.File, error) {
 
+	updaters := analyser.fileUpdaters(name)
+	if analyser.PeopleNumber > 0 {
+		tick = analyser.packPersonWithTick(author, tick)
+	}
+	return burndown.NewFile(tick, size, analyser.fileAllocator, updaters...), nil
+}
+
+// matchFilesGlob returns true if the per-file history of `name` must be recorded according
+// to FilesGlob.
+func (analyser *BurndownAnalysis) matchFilesGlob(name string) bool {
+	return analyser.FilesGlob == "" || items.MatchGlob(analyser.FilesGlob, name)
+}
+
+// fileUpdaters returns the callbacks which record the line changes of the file `name`
+// in the global, the file and the people histories.
+func (analyser *BurndownAnalysis) fileUpdaters(name string) []burndown.Updater {
+	if analyser.linesOnly {
+		return nil
+	}
 	updaters := make([]burndown.Updater, 1)
 	updaters[0] = analyser.updateGlobal
-	if analyser.TrackFiles {
+	if analyser.TrackFiles && analyser.matchFilesGlob(name) {
 		history := analyser.fileHistories[name]
 		if history == nil {
 			// can be not nil if the file was created in a future branch
@@ -1228,15 +1988,57 @@ func (analyser *BurndownAnalysis) newFile(
 	if analyser.PeopleNumber > 0 {
 		updaters = append(updaters, analyser.updateAuthor)
 		updaters = append(updaters, analyser.updateMatrix)
-		tick = analyser.packPersonWithTick(author, tick)
 	}
-	return burndown.NewFile(tick, size, analyser.fileAllocator, updaters...), nil
+	return updaters
+}
+
+// countOldVsNew classifies the lines which are about to be removed from `file` starting at `position`
+// as old or new by their age and the inserted lines as old if they replace at least one old line.
+func (analyser *BurndownAnalysis) countOldVsNew(file *burndown.File, position, inserted, removed int) {
+	if analyser.OldVsNewThreshold == 0 || analyser.tick == burndown.TreeMergeMark {
+		return
+	}
+	oldRemoved, newRemoved := 0, 0
+	if removed > 0 {
+		end := position + removed
+		previousLine, previousValue := 0, 0
+		file.ForEach(func(line, value int) {
+			from, to := previousLine, line
+			if from < position {
+				from = position
+			}
+			if to > end {
+				to = end
+			}
+			if to > from {
+				_, tick := analyser.unpackPersonWithTick(previousValue)
+				if analyser.tick-tick >= analyser.OldVsNewThreshold {
+					oldRemoved += to - from
+				} else {
+					newRemoved += to - from
+				}
+			}
+			previousLine, previousValue = line, value
+		})
+	}
+	counts := analyser.oldVsNew[analyser.tick]
+	if counts == nil {
+		counts = make([]int64, 4)
+		analyser.oldVsNew[analyser.tick] = counts
+	}
+	if oldRemoved > 0 {
+		counts[0] += int64(inserted)
+	} else {
+		counts[2] += int64(inserted)
+	}
+	counts[1] += int64(oldRemoved)
+	counts[3] += int64(newRemoved)
 }
 
 func (analyser *BurndownAnalysis) handleInsertion(
 	change *object.Change, author int, cache map[plumbing.Hash]*items.CachedBlob) error {
 	blob := cache[change.To.TreeEntry.Hash]
-	lines, err := blob.CountLines()
+	lines, err := analyser.measurer.Measure(blob)
 	if err != nil {
 		// binary
 		return nil
@@ -1252,6 +2054,8 @@ func (analyser *BurndownAnalysis) handleInsertion(
 	}
 	file, err = analyser.newFile(hash, name, author, analyser.tick, lines)
 	analyser.files[name] = file
+	analyser.countOldVsNew(file, 0, lines, 0)
+	analyser.reportLineEvent(name, lines, 0)
 	delete(analyser.deletions, name)
 	if analyser.tick == burndown.TreeMergeMark {
 		analyser.mergedFiles[name] = true
@@ -1259,6 +2063,37 @@ func (analyser *BurndownAnalysis) handleInsertion(
 	return err
 }
 
+// handleCopy initializes the file `to` with the line history of the file `from`, which has
+// the identical contents. The copied lines are recorded as inserted in the current tick by their
+// original authors at their original ticks. It returns false if `from` is not tracked.
+func (analyser *BurndownAnalysis) handleCopy(from, to string) bool {
+	source, exists := analyser.files[from]
+	if !exists {
+		// binary or filtered
+		return false
+	}
+	if _, exists = analyser.files[to]; exists {
+		return false
+	}
+	updaters := analyser.fileUpdaters(to)
+	file := source.CloneDeepWithUpdaters(analyser.fileAllocator, updaters...)
+	previousLine, previousValue := 0, 0
+	file.ForEach(func(line, value int) {
+		if length := line - previousLine; length > 0 {
+			lineAuthor, _ := analyser.unpackPersonWithTick(previousValue)
+			currentValue := analyser.packPersonWithTick(lineAuthor, analyser.tick)
+			for _, update := range updaters {
+				update(currentValue, previousValue, length)
+			}
+		}
+		previousLine, previousValue = line, value
+	})
+	analyser.files[to] = file
+	analyser.reportLineEvent(to, file.Len(), 0)
+	delete(analyser.deletions, to)
+	return true
+}
+
 func (analyser *BurndownAnalysis) handleDeletion(
 	change *object.Change, author int, cache map[plumbing.Hash]*items.CachedBlob) error {
 
@@ -1271,7 +2106,7 @@ func (analyser *BurndownAnalysis) handleDeletion(
 	}
 	file, exists := analyser.files[name]
 	blob := cache[change.From.TreeEntry.Hash]
-	lines, err := blob.CountLines()
+	lines, err := analyser.measurer.Measure(blob)
 	if exists && err != nil {
 		return fmt.Errorf("previous version of %s unexpectedly became binary", name)
 	}
@@ -1288,6 +2123,8 @@ func (analyser *BurndownAnalysis) handleDeletion(
 		// Early removal in one branch with pre-merge changes in another is not handled correctly.
 	}
 	analyser.deletions[name] = true
+	analyser.countOldVsNew(file, 0, 0, lines)
+	analyser.reportLineEvent(name, 0, lines)
 	file.Update(analyser.packPersonWithTick(author, tick), 0, 0, lines)
 	file.Delete()
 	delete(analyser.files, name)
@@ -1328,6 +2165,8 @@ func (analyser *BurndownAnalysis) handleModification(
 		if err != nil {
 			return err
 		}
+		// the file is replaced if it is moved into FilesGlob
+		file = analyser.files[change.To.Name]
 	}
 
 	// Check for binary changes
@@ -1347,42 +2186,45 @@ func (analyser *BurndownAnalysis) handleModification(
 		return nil
 	}
 
-	thisDiffs := diffs[change.To.Name]
-	if file.Len() != thisDiffs.OldLinesOfCode {
+	blobDiff := analyser.measurer.Diff(blobFrom, blobTo, diffs[change.To.Name])
+	if file.Len() != blobDiff.OldSize {
 		analyser.l.Infof("====TREE====\n%s", file.Dump())
-		return fmt.Errorf("%s: internal integrity error src %d != %d %s -> %s",
-			change.To.Name, thisDiffs.OldLinesOfCode, file.Len(),
-			change.From.TreeEntry.Hash.String(), change.To.TreeEntry.Hash.String())
+		return analyser.violate(change, author, cache, blobDiff.OldSize, file.Len(),
+			fmt.Errorf("%s: internal integrity error src %d != %d %s -> %s",
+				change.To.Name, blobDiff.OldSize, file.Len(),
+				change.From.TreeEntry.Hash.String(), change.To.TreeEntry.Hash.String()))
 	}
 
-	// we do not call RunesToDiffLines so the number of lines equals
-	// to the rune count
 	position := 0
-	pending := diffmatchpatch.Diff{Text: ""}
+	pending := BlobEdit{}
+	inserted, removed := 0, 0
 
-	apply := func(edit diffmatchpatch.Diff) {
-		length := utf8.RuneCountInString(edit.Text)
+	apply := func(edit BlobEdit) {
 		if edit.Type == diffmatchpatch.DiffInsert {
-			file.Update(analyser.packPersonWithTick(author, analyser.tick), position, length, 0)
-			position += length
+			analyser.countOldVsNew(file, position, edit.Length, 0)
+			file.Update(analyser.packPersonWithTick(author, analyser.tick), position, edit.Length, 0)
+			position += edit.Length
+			inserted += edit.Length
 		} else {
-			file.Update(analyser.packPersonWithTick(author, analyser.tick), position, 0, length)
+			analyser.countOldVsNew(file, position, 0, edit.Length)
+			file.Update(analyser.packPersonWithTick(author, analyser.tick), position, 0, edit.Length)
+			removed += edit.Length
 		}
 		if analyser.Debug {
 			file.Validate()
 		}
 	}
 
-	for _, edit := range thisDiffs.Diffs {
+	for _, edit := range blobDiff.Edits {
 		dumpBefore := ""
 		if analyser.Debug {
 			dumpBefore = file.Dump()
 		}
-		length := utf8.RuneCountInString(edit.Text)
+		length := edit.Length
 		debugError := func() {
 			analyser.l.Errorf("%s: internal diff error\n", change.To.Name)
 			analyser.l.Errorf("Update(%d, %d, %d (0), %d (0))\n", analyser.tick, position,
-				length, utf8.RuneCountInString(pending.Text))
+				length, pending.Length)
 			if dumpBefore != "" {
 				analyser.l.Errorf("====TREE BEFORE====\n%s====END====\n", dumpBefore)
 			}
@@ -1390,50 +2232,112 @@ func (analyser *BurndownAnalysis) handleModification(
 		}
 		switch edit.Type {
 		case diffmatchpatch.DiffEqual:
-			if pending.Text != "" {
+			if pending.Length > 0 {
 				apply(pending)
-				pending.Text = ""
+				pending.Length = 0
 			}
 			position += length
 		case diffmatchpatch.DiffInsert:
-			if pending.Text != "" {
+			if pending.Length > 0 {
 				if pending.Type == diffmatchpatch.DiffInsert {
 					debugError()
-					return errors.New("DiffInsert may not appear after DiffInsert")
+					return analyser.violate(change, author, cache, blobDiff.NewSize, file.Len(),
+						errors.New("DiffInsert may not appear after DiffInsert"))
 				}
+				analyser.countOldVsNew(file, position, length, pending.Length)
 				file.Update(analyser.packPersonWithTick(author, analyser.tick), position, length,
-					utf8.RuneCountInString(pending.Text))
+					pending.Length)
 				if analyser.Debug {
 					file.Validate()
 				}
 				position += length
-				pending.Text = ""
+				inserted += length
+				removed += pending.Length
+				pending.Length = 0
 			} else {
 				pending = edit
 			}
 		case diffmatchpatch.DiffDelete:
-			if pending.Text != "" {
+			if pending.Length > 0 {
 				debugError()
-				return errors.New("DiffDelete may not appear after DiffInsert/DiffDelete")
+				return analyser.violate(change, author, cache, blobDiff.NewSize, file.Len(),
+					errors.New("DiffDelete may not appear after DiffInsert/DiffDelete"))
 			}
 			pending = edit
 		default:
 			debugError()
-			return fmt.Errorf("diff operation is not supported: %d", edit.Type)
+			return analyser.violate(change, author, cache, blobDiff.NewSize, file.Len(),
+				fmt.Errorf("diff operation is not supported: %d", edit.Type))
 		}
 	}
-	if pending.Text != "" {
+	if pending.Length > 0 {
 		apply(pending)
-		pending.Text = ""
+		pending.Length = 0
+	}
+	if file.Len() != blobDiff.NewSize {
+		return analyser.violate(change, author, cache, blobDiff.NewSize, file.Len(),
+			fmt.Errorf("%s: internal integrity error dst %d != %d %s -> %s",
+				change.To.Name, blobDiff.NewSize, file.Len(),
+				change.From.TreeEntry.Hash.String(), change.To.TreeEntry.Hash.String()))
+	}
+	if analyser.violations != nil && analyser.tick != burndown.TreeMergeMark {
+		if err := validateFile(file); err != nil {
+			return analyser.violate(change, author, cache, blobDiff.NewSize, file.Len(),
+				fmt.Errorf("%s: internal integrity error: %v", change.To.Name, err))
+		}
 	}
-	if file.Len() != thisDiffs.NewLinesOfCode {
-		return fmt.Errorf("%s: internal integrity error dst %d != %d %s -> %s",
-			change.To.Name, thisDiffs.NewLinesOfCode, file.Len(),
-			change.From.TreeEntry.Hash.String(), change.To.TreeEntry.Hash.String())
+	analyser.reportLineEvent(change.To.Name, inserted, removed)
+	return nil
+}
+
+// violate records the integrity violation of the modified file and restarts its line history
+// from the new contents if the violations are collected, see FactBurndownIntegrityViolations.
+// Otherwise, it returns `err`.
+func (analyser *BurndownAnalysis) violate(
+	change *object.Change, author int, cache map[plumbing.Hash]*items.CachedBlob,
+	expected, actual int, err error) error {
+
+	if analyser.violations == nil {
+		return err
 	}
+	analyser.l.Warnf("%s: %v\n", analyser.commit.String(), err)
+	*analyser.violations = append(*analyser.violations, IntegrityViolation{
+		Commit:   analyser.commit,
+		File:     change.To.Name,
+		Expected: expected,
+		Actual:   actual,
+		Message:  err.Error(),
+	})
+	name := change.To.Name
+	if file, exists := analyser.files[name]; exists {
+		file.Update(analyser.packPersonWithTick(author, analyser.tick), 0, 0, file.Len())
+		file.Delete()
+		delete(analyser.files, name)
+	}
+	return analyser.handleInsertion(change, author, cache)
+}
+
+// validateFile checks the invariants of the file's tree and converts the panic to an error.
+func validateFile(file *burndown.File) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	file.Validate()
 	return nil
 }
 
+// reportLineEvent calls back lineEvents unless it is not set, the file has not changed
+// or the current commit is a merge.
+func (analyser *BurndownAnalysis) reportLineEvent(path string, inserted, removed int) {
+	if analyser.lineEvents == nil || analyser.tick == burndown.TreeMergeMark ||
+		inserted+removed == 0 {
+		return
+	}
+	analyser.lineEvents(path, inserted, removed)
+}
+
 func (analyser *BurndownAnalysis) handleRename(from, to string) error {
 	if from == to {
 		return nil
@@ -1449,7 +2353,8 @@ func (analyser *BurndownAnalysis) handleRename(from, to string) error {
 		analyser.mergedFiles[from] = false
 	}
 
-	if analyser.TrackFiles {
+	// the files created outside of FilesGlob do not have the histories
+	if analyser.TrackFiles && (analyser.fileHistories[from] != nil || analyser.matchFilesGlob(from)) {
 		history := analyser.fileHistories[from]
 		if history == nil {
 			var futureRename string
@@ -1489,6 +2394,19 @@ func (analyser *BurndownAnalysis) handleRename(from, to string) error {
 		}
 		delete(analyser.fileHistories, from)
 		analyser.fileHistories[to] = history
+	} else if analyser.TrackFiles && analyser.matchFilesGlob(to) {
+		// the file is moved into FilesGlob: attach the history and record the current lines
+		renamed := file.CloneDeepWithUpdaters(analyser.fileAllocator, analyser.fileUpdaters(to)...)
+		file.Delete()
+		analyser.files[to] = renamed
+		history := analyser.fileHistories[to]
+		previousLine, previousValue := 0, 0
+		renamed.ForEach(func(line, value int) {
+			if line > previousLine {
+				analyser.updateFile(history, previousValue, previousValue, line-previousLine)
+			}
+			previousLine, previousValue = line, value
+		})
 	}
 	analyser.renames[from] = to
 	return nil
@@ -1520,7 +2438,7 @@ func (analyser *BurndownAnalysis) groupSparseHistory(
 	samples := lastTick/analyser.Sampling + 1
 	bands := lastTick/analyser.Granularity + 1
 	result := make(DenseHistory, samples)
-	for i := 0; i < bands; i++ {
+	for i := 0; i < samples; i++ {
 		result[i] = make([]int64, bands)
 	}
 	prevsi := 0
@@ -1541,6 +2459,51 @@ func (analyser *BurndownAnalysis) groupSparseHistory(
 	return result, lastTick
 }
 
+// groupTotalLines sums the line count deltas of the global history in each tick up to `lastTick`.
+// The running sum of all the deltas is the number of alive lines, the same as the sum over
+// the bands of the corresponding GlobalHistory sample.
+func (analyser *BurndownAnalysis) groupTotalLines(lastTick int) []int64 {
+	result := make([]int64, lastTick+1)
+	for tick, deltas := range analyser.globalHistory {
+		for _, delta := range deltas {
+			result[tick] += delta
+		}
+	}
+	var sum int64
+	for tick, delta := range result {
+		sum += delta
+		result[tick] = sum
+	}
+	return result
+}
+
+// groupSampleCommits finds the last processed commit which precedes each sample boundary.
+// The samples without commits inherit the hash from the previous sample.
+func (analyser *BurndownAnalysis) groupSampleCommits(samples int) []plumbing.Hash {
+	// the index of the last logged commit in each sample, -1 if there is none
+	last := make([]int, samples)
+	for i := range last {
+		last[i] = -1
+	}
+	for i, tc := range *analyser.sampleCommits {
+		if si := tc.tick / analyser.Sampling; si < samples {
+			last[si] = i
+		}
+	}
+	result := make([]plumbing.Hash, samples)
+	best := -1
+	for si, i := range last {
+		// the merges may arrive after the commits with bigger ticks
+		if i > best {
+			best = i
+		}
+		if best >= 0 {
+			result[si] = (*analyser.sampleCommits)[best].hash
+		}
+	}
+	return result
+}
+
 // GetTickSize returns the tick size used to generate this burndown analysis result.
 func (br BurndownResult) GetTickSize() time.Duration {
 	return br.tickSize
@@ -15,6 +15,7 @@ import (
 	"gopkg.in/src-d/hercules.v10/internal/test/fixtures"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
@@ -40,7 +41,7 @@ func TestBurndownMeta(t *testing.T) {
 	assert.Len(t, bd.Provides(), 0)
 	required := [...]string{
 		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
-		items.DependencyTick, identity.DependencyAuthor}
+		items.DependencyTick, identity.DependencyAuthor, items.DependencyCopies}
 	for _, name := range required {
 		assert.Contains(t, bd.Requires(), name)
 	}
@@ -51,7 +52,10 @@ func TestBurndownMeta(t *testing.T) {
 		case ConfigBurndownGranularity, ConfigBurndownSampling, ConfigBurndownTrackFiles,
 			ConfigBurndownTrackPeople, ConfigBurndownHibernationThreshold,
 			ConfigBurndownHibernationToDisk, ConfigBurndownHibernationDirectory,
-			ConfigBurndownDebug:
+			ConfigBurndownDebug, ConfigBurndownAuthorActivity, ConfigBurndownAuthorActivityWindow,
+			ConfigBurndownCommitCounts, ConfigBurndownSampleCommits, ConfigBurndownIgnoreWhitespace,
+			ConfigBurndownOldVsNewThreshold, ConfigBurndownMeasureUnit, ConfigBurndownHighPrecision,
+			ConfigBurndownMaxPeople, ConfigBurndownFilesGlob, ConfigBurndownTotalLines:
 			matches++
 		}
 	}
@@ -70,11 +74,16 @@ func TestBurndownConfigure(t *testing.T) {
 	facts[ConfigBurndownGranularity] = 100
 	facts[ConfigBurndownSampling] = 200
 	facts[ConfigBurndownTrackFiles] = true
+	facts[ConfigBurndownFilesGlob] = " src/** "
 	facts[ConfigBurndownTrackPeople] = true
 	facts[ConfigBurndownDebug] = true
 	facts[ConfigBurndownHibernationThreshold] = 100
 	facts[ConfigBurndownHibernationToDisk] = true
 	facts[ConfigBurndownHibernationDirectory] = "xxx"
+	facts[ConfigBurndownIgnoreWhitespace] = true
+	facts[ConfigBurndownMeasureUnit] = MeasureUnitBytes
+	facts[ConfigBurndownHighPrecision] = true
+	facts[ConfigBurndownMaxPeople] = 3
 	facts[items.FactTickSize] = 24 * time.Hour
 	facts[identity.FactIdentityDetectorPeopleCount] = 5
 	facts[identity.FactIdentityDetectorReversedPeopleDict] = bd.Requires()
@@ -82,11 +91,32 @@ func TestBurndownConfigure(t *testing.T) {
 	assert.Equal(t, bd.Granularity, 100)
 	assert.Equal(t, bd.Sampling, 200)
 	assert.Equal(t, bd.TrackFiles, true)
+	assert.Equal(t, "src/**", bd.FilesGlob)
 	assert.Equal(t, bd.PeopleNumber, 5)
+	assert.Equal(t, bd.MaxPeople, 3)
 	assert.Equal(t, bd.HibernationThreshold, 100)
 	assert.True(t, bd.HibernationToDisk)
+	facts[ConfigBurndownHibernationThreshold] = -1
+	assert.EqualError(t, bd.Configure(facts), "HibernationThreshold may not be negative: -1")
+	assert.Equal(t, bd.HibernationThreshold, 100)
+	facts[ConfigBurndownHibernationThreshold] = 100
+	facts[ConfigBurndownMaxPeople] = -1
+	assert.EqualError(t, bd.Configure(facts), "MaxPeople may not be negative: -1")
+	facts[ConfigBurndownMaxPeople] = 3
+	facts[ConfigBurndownMeasureUnit] = "tokens"
+	assert.EqualError(t, bd.Configure(facts), "unsupported measure unit: tokens")
+	assert.Equal(t, MeasureUnitBytes, bd.MeasureUnit)
+	facts[ConfigBurndownMeasureUnit] = MeasureUnitBytes
+	facts[ConfigBurndownFilesGlob] = "src/["
+	assert.EqualError(t, bd.Configure(facts),
+		`invalid Burndown.FilesGlob pattern "src/[": syntax error in pattern`)
+	assert.Equal(t, "src/**", bd.FilesGlob)
+	delete(facts, ConfigBurndownFilesGlob)
 	assert.Equal(t, bd.HibernationDirectory, "xxx")
 	assert.Equal(t, bd.Debug, true)
+	assert.True(t, bd.HighPrecision)
+	assert.True(t, bd.IgnoreWhitespace)
+	assert.Equal(t, MeasureUnitBytes, bd.MeasureUnit)
 	assert.Equal(t, bd.TickSize, 24*time.Hour)
 	assert.Equal(t, bd.reversedPeopleDict, bd.Requires())
 	facts[ConfigBurndownTrackPeople] = false
@@ -103,6 +133,585 @@ func TestBurndownConfigure(t *testing.T) {
 	assert.Equal(t, bd.reversedPeopleDict, bd.Requires())
 }
 
+func TestBurndownConfigureAuthorActivity(t *testing.T) {
+	bd := BurndownAnalysis{}
+	facts := map[string]interface{}{}
+	facts[ConfigBurndownTrackPeople] = true
+	facts[ConfigBurndownAuthorActivity] = true
+	facts[ConfigBurndownAuthorActivityWindow] = 10
+	facts[identity.FactIdentityDetectorPeopleCount] = 2
+	facts[identity.FactIdentityDetectorReversedPeopleDict] = []string{"one", "two"}
+	assert.Nil(t, bd.Configure(facts))
+	assert.True(t, bd.AuthorActivity)
+	assert.Equal(t, 10, bd.AuthorActivityWindow)
+	facts[ConfigBurndownAuthorActivityWindow] = 0
+	assert.Error(t, bd.Configure(facts))
+	facts[ConfigBurndownAuthorActivityWindow] = 10
+	facts[ConfigBurndownTrackPeople] = false
+	assert.Error(t, bd.Configure(facts))
+	bd = BurndownAnalysis{AuthorActivity: true, PeopleNumber: 2}
+	assert.Nil(t, bd.Initialize(test.Repository))
+	assert.Equal(t, DefaultBurndownAuthorActivityWindow, bd.AuthorActivityWindow)
+	assert.Len(t, bd.peopleActivity, 2)
+}
+
+func TestBurndownActiveAuthorsHistory(t *testing.T) {
+	bd := BurndownAnalysis{
+		Granularity:          10,
+		Sampling:             10,
+		PeopleNumber:         2,
+		AuthorActivity:       true,
+		AuthorActivityWindow: 10,
+	}
+	assert.Nil(t, bd.Initialize(test.Repository))
+	bd.recordActivity(0, 0)
+	bd.recordActivity(1, 5)
+	bd.recordActivity(1, 25)
+	bd.recordActivity(identity.AuthorMissing, 25)
+	assert.True(t, bd.isActive(0, 9))
+	assert.False(t, bd.isActive(0, 10))
+	assert.True(t, bd.isActive(1, 14))
+	assert.False(t, bd.isActive(1, 15))
+	assert.False(t, bd.isActive(1, 24))
+	assert.True(t, bd.isActive(1, 25))
+	peopleHistories := []DenseHistory{
+		{{10, 0, 0}, {8, 0, 0}, {6, 0, 0}},
+		{{5, 0, 0}, {5, 3, 0}, {4, 3, 7}},
+	}
+	globalHistory := DenseHistory{{15, 0, 0}, {13, 3, 0}, {10, 3, 7}}
+	active := bd.groupActiveAuthorsHistory(peopleHistories, globalHistory, 25)
+	assert.Equal(t, DenseHistory{{15, 0, 0}, {0, 0, 0}, {4, 3, 7}}, active)
+}
+
+func TestBurndownSerializeActiveAuthors(t *testing.T) {
+	bd := &BurndownAnalysis{}
+	result := BurndownResult{
+		GlobalHistory:        DenseHistory{{15, 0}, {13, 3}},
+		FileHistories:        map[string]DenseHistory{},
+		FileOwnership:        map[string]map[int]int{},
+		ActiveAuthorsHistory: DenseHistory{{15, 0}, {5, 3}},
+		tickSize:             24 * time.Hour,
+		sampling:             10,
+		granularity:          10,
+		activityWindow:       30,
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, bd.Serialize(result, false, buffer))
+	assert.Equal(t, `  granularity: 10
+  sampling: 10
+  tick_size: 86400
+  "project": |-
+    15  0
+    13  3
+  active_authors_window: 30
+  "active_authors": |-
+    15  0
+     5  3
+`, buffer.String())
+	buffer = &bytes.Buffer{}
+	assert.NoError(t, bd.Serialize(result, true, buffer))
+	msg := pb.BurndownAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, "active_authors", msg.ActiveAuthors.Name)
+	assert.Equal(t, int32(30), msg.ActiveAuthorsWindow)
+	deserialized, err := bd.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	dresult := deserialized.(BurndownResult)
+	assert.Equal(t, result.ActiveAuthorsHistory, dresult.ActiveAuthorsHistory)
+	assert.Equal(t, 30, dresult.activityWindow)
+}
+
+func TestBurndownCommitCounts(t *testing.T) {
+	bd := BurndownAnalysis{}
+	assert.Nil(t, bd.Configure(map[string]interface{}{ConfigBurndownCommitCounts: true}))
+	assert.True(t, bd.CommitCounts)
+	bd.Granularity = 10
+	bd.Sampling = 10
+	assert.Nil(t, bd.Initialize(test.Repository))
+	bd.updateGlobal(3, 0, -2)
+	assert.False(t, bd.inserted)
+	bd.updateGlobal(3, 3, 5)
+	assert.True(t, bd.inserted)
+	bd.globalHistory = sparseHistory{
+		0:  {0: 10},
+		5:  {5: 3},
+		12: {12: 4, 0: -2},
+		25: {25: 1},
+	}
+	bd.globalCommits = sparseHistory{
+		0:  {0: 2},
+		5:  {5: 1},
+		12: {12: 3},
+		25: {25: 1},
+	}
+	result := bd.Finalize().(BurndownResult)
+	assert.Equal(t, DenseHistory{{3, 0, 0}, {3, 3, 0}, {3, 3, 1}}, result.GlobalCommitCounts)
+	bd.CommitCounts = false
+	result = bd.Finalize().(BurndownResult)
+	assert.Nil(t, result.GlobalCommitCounts)
+}
+
+func TestBurndownSerializeCommitCounts(t *testing.T) {
+	bd := &BurndownAnalysis{}
+	result := BurndownResult{
+		GlobalHistory:      DenseHistory{{15, 0}, {13, 3}},
+		FileHistories:      map[string]DenseHistory{},
+		FileOwnership:      map[string]map[int]int{},
+		GlobalCommitCounts: DenseHistory{{2, 0}, {2, 1}},
+		tickSize:           24 * time.Hour,
+		sampling:           10,
+		granularity:        10,
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, bd.Serialize(result, false, buffer))
+	assert.Equal(t, `  granularity: 10
+  sampling: 10
+  tick_size: 86400
+  "project": |-
+    15  0
+    13  3
+  "commit_counts": |-
+    2 0
+    2 1
+`, buffer.String())
+	buffer = &bytes.Buffer{}
+	assert.NoError(t, bd.Serialize(result, true, buffer))
+	msg := pb.BurndownAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, "commit_counts", msg.CommitCounts.Name)
+	deserialized, err := bd.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result.GlobalCommitCounts, deserialized.(BurndownResult).GlobalCommitCounts)
+}
+
+func TestBurndownSampleCommits(t *testing.T) {
+	bd := BurndownAnalysis{}
+	assert.Nil(t, bd.Configure(map[string]interface{}{ConfigBurndownSampleCommits: true}))
+	assert.True(t, bd.SampleCommits)
+	bd.Granularity = 10
+	bd.Sampling = 10
+	assert.Nil(t, bd.Initialize(test.Repository))
+	bd.globalHistory = sparseHistory{
+		0:  {0: 10},
+		5:  {5: 3},
+		35: {35: 1},
+	}
+	hash := func(i int) plumbing.Hash {
+		return plumbing.NewHash(fmt.Sprintf("%040d", i))
+	}
+	// the merge commit at tick 5 is processed after the commit at tick 12 and wins
+	*bd.sampleCommits = []tickCommit{
+		{tick: 0, hash: hash(1)}, {tick: 5, hash: hash(2)}, {tick: 12, hash: hash(3)},
+		{tick: 5, hash: hash(4)}, {tick: 35, hash: hash(5)},
+	}
+	result := bd.Finalize().(BurndownResult)
+	assert.Equal(t, []plumbing.Hash{hash(4), hash(4), hash(4), hash(5)}, result.SampleCommits)
+	bd.SampleCommits = false
+	result = bd.Finalize().(BurndownResult)
+	assert.Nil(t, result.SampleCommits)
+}
+
+func TestBurndownSampleCommitsConsume(t *testing.T) {
+	bd := BurndownAnalysis{SampleCommits: true, Granularity: 30, Sampling: 30}
+	assert.Nil(t, bd.Initialize(test.Repository))
+	hash := plumbing.NewHash("cce947b98a050c6d356bc6ba95030254914027b1")
+	deps := map[string]interface{}{
+		identity.DependencyAuthor:   0,
+		items.DependencyTick:        0,
+		core.DependencyIsMerge:      false,
+		core.DependencyCommit:       &object.Commit{Hash: hash},
+		items.DependencyBlobCache:   map[plumbing.Hash]*items.CachedBlob{},
+		items.DependencyTreeChanges: object.Changes{},
+		items.DependencyFileDiff:    map[string]items.FileDiffData{},
+	}
+	_, err := bd.Consume(deps)
+	assert.NoError(t, err)
+	assert.Equal(t, []tickCommit{{tick: 0, hash: hash}}, *bd.sampleCommits)
+	clone := bd.Fork(1)[0].(*BurndownAnalysis)
+	deps[items.DependencyTick] = 1
+	_, err = clone.Consume(deps)
+	assert.NoError(t, err)
+	// the log is shared between the forks
+	assert.Len(t, *bd.sampleCommits, 2)
+}
+
+func TestBurndownIntegrityViolations(t *testing.T) {
+	var violations []IntegrityViolation
+	bd := BurndownAnalysis{Granularity: 30, Sampling: 30}
+	assert.NoError(t, bd.Configure(map[string]interface{}{
+		FactBurndownIntegrityViolations: &violations,
+	}))
+	assert.NoError(t, bd.Initialize(test.Repository))
+	blob := func(hash string, data string) *items.CachedBlob {
+		return &items.CachedBlob{Blob: object.Blob{Hash: plumbing.NewHash(hash)}, Data: []byte(data)}
+	}
+	from := blob("0000000000000000000000000000000000000001", "1\n2\n3\n")
+	to := blob("0000000000000000000000000000000000000002", "1\n2\nX\n")
+	change := &object.Change{
+		From: object.ChangeEntry{Name: "a.go", TreeEntry: object.TreeEntry{Name: "a.go", Hash: from.Hash}},
+		To:   object.ChangeEntry{Name: "a.go", TreeEntry: object.TreeEntry{Name: "a.go", Hash: to.Hash}},
+	}
+	// the tracked file is longer than the diff tells
+	file, err := bd.newFile(from.Hash, "a.go", 0, 0, 5)
+	assert.NoError(t, err)
+	bd.files["a.go"] = file
+	commit := plumbing.NewHash("cce947b98a050c6d356bc6ba95030254914027b1")
+	deps := map[string]interface{}{
+		identity.DependencyAuthor:   0,
+		items.DependencyTick:        1,
+		core.DependencyIsMerge:      false,
+		core.DependencyCommit:       &object.Commit{Hash: commit},
+		items.DependencyBlobCache:   map[plumbing.Hash]*items.CachedBlob{from.Hash: from, to.Hash: to},
+		items.DependencyTreeChanges: object.Changes{change},
+		items.DependencyFileDiff: map[string]items.FileDiffData{"a.go": {
+			OldLinesOfCode: 3, NewLinesOfCode: 3, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "ab"},
+				{Type: diffmatchpatch.DiffDelete, Text: "c"},
+				{Type: diffmatchpatch.DiffInsert, Text: "d"},
+			}}},
+	}
+	_, err = bd.Consume(deps)
+	assert.NoError(t, err)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, commit, violations[0].Commit)
+	assert.Equal(t, "a.go", violations[0].File)
+	assert.Equal(t, 3, violations[0].Expected)
+	assert.Equal(t, 5, violations[0].Actual)
+	assert.Contains(t, violations[0].Message, "internal integrity error src 3 != 5")
+	// the file is restarted from its new contents
+	assert.Equal(t, 3, bd.files["a.go"].Len())
+	assert.Equal(t, map[int]map[int]int64{0: {0: 5}, 1: {0: -5, 1: 3}}, bd.globalHistory)
+
+	// without the collector, the violation fails the analysis
+	bd = BurndownAnalysis{Granularity: 30, Sampling: 30}
+	assert.NoError(t, bd.Initialize(test.Repository))
+	file, err = bd.newFile(from.Hash, "a.go", 0, 0, 5)
+	assert.NoError(t, err)
+	bd.files["a.go"] = file
+	_, err = bd.Consume(deps)
+	assert.Error(t, err)
+}
+
+func TestBurndownCopies(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "zoe", When: when, Files: map[string]string{"a.go": "1\n2\n3\n"}},
+		// copy the previous version of a.go and modify a.go
+		{Author: "adam", When: when.Add(48 * time.Hour), Files: map[string]string{
+			"a.go": "1\n2\nX\n", "b.go": "1\n2\n3\n"}},
+		{Author: "adam", When: when.Add(72 * time.Hour), Deleted: []string{"a.go"}},
+	})
+	assert.NoError(t, err)
+	run := func(detect bool) (*BurndownAnalysis, BurndownResult) {
+		pipeline := core.NewPipeline(repository)
+		pipeline.PrintActions = false
+		bd := pipeline.DeployItem(&BurndownAnalysis{}).(*BurndownAnalysis)
+		commits, err := pipeline.Commits(false)
+		assert.NoError(t, err)
+		assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+			ConfigBurndownGranularity:              1,
+			ConfigBurndownSampling:                 1,
+			ConfigBurndownTrackPeople:              true,
+			ConfigBurndownTrackFiles:               true,
+			items.ConfigRenameAnalysisDetectCopies: detect,
+		}))
+		results, err := pipeline.Run(commits)
+		assert.NoError(t, err)
+		return bd, results[bd].(BurndownResult)
+	}
+	// adam is 0, zoe is 1
+	bd, result := run(true)
+	assert.Equal(t, map[int]int{1: 3}, bd.fileOwnership(bd.files["b.go"]))
+	assert.Equal(t, DenseHistory{
+		{3, 0, 0, 0}, {3, 0, 0, 0}, {5, 0, 1, 0}, {3, 0, 0, 0}}, result.GlobalHistory)
+	assert.Equal(t, DenseHistory{
+		{0, 0, 0, 0}, {0, 0, 0, 0}, {3, 0, 0, 0}, {3, 0, 0, 0}}, result.FileHistories["b.go"])
+	assert.Equal(t, DenseHistory{
+		{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 0}}, result.PeopleHistories[0])
+	assert.Equal(t, DenseHistory{
+		{3, 0, 0, 0}, {3, 0, 0, 0}, {5, 0, 0, 0}, {3, 0, 0, 0}}, result.PeopleHistories[1])
+	bd, result = run(false)
+	assert.Equal(t, map[int]int{0: 3}, bd.fileOwnership(bd.files["b.go"]))
+	assert.Equal(t, DenseHistory{
+		{3, 0, 0, 0}, {3, 0, 0, 0}, {2, 0, 4, 0}, {0, 0, 3, 0}}, result.GlobalHistory)
+}
+
+func TestBurndownFilesGlob(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "zoe", When: when, Files: map[string]string{
+			"src/a.go": "1\n2\n3\n", "src/b/c.go": "1\n", "d.go": "1\n2\n", "e.go": "e\ne\ne\ne\n",
+			"src/f.go": "f\nf\nf\nf\nf\n"}},
+		{Author: "zoe", When: when.Add(24 * time.Hour), Files: map[string]string{
+			"src/a.go": "1\n2\n3\n4\n", "d.go": "1\n2\n3\n"}},
+		// move e.go into src and src/f.go out of it
+		{Author: "zoe", When: when.Add(48 * time.Hour), Deleted: []string{"e.go"},
+			Files: map[string]string{"src/e.go": "e\ne\ne\ne\n"}},
+		{Author: "zoe", When: when.Add(49 * time.Hour), Deleted: []string{"src/f.go"},
+			Files: map[string]string{"f.go": "f\nf\nf\nf\nf\n"}},
+		// the history of src/e.go continues after the move
+		{Author: "zoe", When: when.Add(72 * time.Hour), Files: map[string]string{
+			"src/e.go": "e\ne\ne\n"}},
+	})
+	assert.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	bd := pipeline.DeployItem(&BurndownAnalysis{}).(*BurndownAnalysis)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigBurndownGranularity: 1,
+		ConfigBurndownSampling:    1,
+		ConfigBurndownTrackFiles:  true,
+		ConfigBurndownFilesGlob:   "src/**",
+	}))
+	results, err := pipeline.Run(nil)
+	assert.NoError(t, err)
+	result := results[bd].(BurndownResult)
+	assert.Len(t, result.FileHistories, 3)
+	assert.Equal(t, DenseHistory{{3, 0, 0, 0}, {3, 1, 0, 0}, {3, 1, 0, 0}, {3, 1, 0, 0}},
+		result.FileHistories["src/a.go"])
+	assert.Equal(t, DenseHistory{{1, 0, 0, 0}, {1, 0, 0, 0}, {1, 0, 0, 0}, {1, 0, 0, 0}},
+		result.FileHistories["src/b/c.go"])
+	// the lines of e.go keep their ticks after the move into FilesGlob
+	assert.Equal(t, DenseHistory{{4, 0, 0, 0}, {4, 0, 0, 0}, {4, 0, 0, 0}, {3, 0, 0, 0}},
+		result.FileHistories["src/e.go"])
+	assert.Equal(t, DenseHistory{{15, 0, 0, 0}, {15, 2, 0, 0}, {15, 2, 0, 0}, {14, 2, 0, 0}},
+		result.GlobalHistory)
+	assert.NotContains(t, bd.fileHistories, "d.go")
+	assert.Contains(t, bd.fileHistories, "f.go")
+}
+
+// failingFileDiff fails to consume the specified commit after TreeDiff has consumed it.
+type failingFileDiff struct {
+	items.FileDiff
+	failOn plumbing.Hash
+}
+
+func (diff *failingFileDiff) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if deps[core.DependencyCommit].(*object.Commit).Hash == diff.failOn {
+		return nil, errors.New("failed")
+	}
+	return diff.FileDiff.Consume(deps)
+}
+
+func (diff *failingFileDiff) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(diff, n)
+}
+
+func TestBurndownContinueOnError(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "zoe", When: when, Files: map[string]string{"a.go": "1\n2\n3\n"}},
+		{Author: "zoe", When: when.Add(24 * time.Hour), Files: map[string]string{
+			"a.go": "1\n2\n3\n4\n", "b.go": "b\n"}},
+		{Author: "zoe", When: when.Add(48 * time.Hour), Files: map[string]string{
+			"a.go": "1\n2\n3\n4\n5\n", "b.go": "b\nc\n"}},
+	})
+	assert.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	pipeline.AddItem(&failingFileDiff{failOn: hashes[1]})
+	bd := pipeline.DeployItem(&BurndownAnalysis{}).(*BurndownAnalysis)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigBurndownGranularity:          1,
+		ConfigBurndownSampling:             1,
+		ConfigBurndownTrackFiles:           true,
+		core.ConfigPipelineContinueOnError: true,
+	}))
+	results, err := pipeline.Run(nil)
+	assert.NoError(t, err)
+	// the failed commit is skipped and the files which it changed are restarted in the next one
+	// instead of breaking the following commits
+	assert.Equal(t, 1, results[nil].(*core.CommonAnalysisResult).FailedCommits)
+	result := results[bd].(BurndownResult)
+	assert.Equal(t, DenseHistory{{3, 0, 0}, {3, 0, 0}, {0, 0, 7}}, result.GlobalHistory)
+	assert.Equal(t, DenseHistory{{3, 0, 0}, {3, 0, 0}, {0, 0, 5}}, result.FileHistories["a.go"])
+	assert.Equal(t, DenseHistory{{0, 0, 0}, {0, 0, 0}, {0, 0, 2}}, result.FileHistories["b.go"])
+}
+
+func TestBurndownPathCaseCollision(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "zoe", When: when, Files: map[string]string{
+			"Foo.go": "1\n", "foo.go": "1\n2\n", "Bar.go": "1\n"}},
+		{Author: "zoe", When: when.Add(24 * time.Hour), Files: map[string]string{
+			"Foo.go": "1\n2\n", "bar.go": "1\n2\n3\n"}},
+	})
+	assert.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	bd := pipeline.DeployItem(&BurndownAnalysis{}).(*BurndownAnalysis)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigBurndownGranularity:               1,
+		ConfigBurndownSampling:                  1,
+		ConfigBurndownTrackFiles:                true,
+		items.ConfigTreeDiffPathCaseInsensitive: true,
+	}))
+	results, err := pipeline.Run(nil)
+	assert.NoError(t, err)
+	result := results[bd].(BurndownResult)
+	assert.Equal(t, DenseHistory{{4, 0}, {4, 4}}, result.GlobalHistory)
+	assert.Len(t, result.FileHistories, 4)
+	assert.Contains(t, result.FileHistories, "Foo.go")
+	assert.Contains(t, result.FileHistories, "foo.go")
+	assert.Contains(t, result.FileHistories, "Bar.go")
+	assert.Contains(t, result.FileHistories, "bar.go")
+}
+
+func TestBurndownMeasureBytes(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "zoe", When: when, Files: map[string]string{
+			"a.go": "package a\n\nfunc A() {}\n", "b.txt": "hello"}},
+		{Author: "adam", When: when.Add(48 * time.Hour), Files: map[string]string{
+			"a.go": "package a\n\nfunc A() {\n\treturn\n}\n", "b.txt": "hello\nworld\n"}},
+		{Author: "adam", When: when.Add(72 * time.Hour), Files: map[string]string{
+			"a.go": "package a\n\n  func A() {\n\treturn\n}\n"}},
+	})
+	assert.NoError(t, err)
+	for _, facts := range []map[string]interface{}{
+		{},
+		{items.ConfigFileWhitespaceIgnore: true},
+		{ConfigBurndownIgnoreWhitespace: true},
+	} {
+		pipeline := core.NewPipeline(repository)
+		pipeline.PrintActions = false
+		bd := pipeline.DeployItem(&BurndownAnalysis{}).(*BurndownAnalysis)
+		commits, err := pipeline.Commits(false)
+		assert.NoError(t, err)
+		facts[ConfigBurndownGranularity] = 1
+		facts[ConfigBurndownSampling] = 1
+		facts[ConfigBurndownMeasureUnit] = MeasureUnitBytes
+		facts[ConfigBurndownDebug] = true
+		assert.NoError(t, bd.ConfigureDependencies(facts))
+		assert.NoError(t, pipeline.Initialize(facts))
+		results, err := pipeline.Run(commits)
+		assert.NoError(t, err, facts)
+		assert.Equal(t, 34, bd.files["a.go"].Len(), facts)
+		assert.Equal(t, 12, bd.files["b.txt"].Len(), facts)
+		history := results[bd].(BurndownResult).GlobalHistory
+		var total int64
+		for _, val := range history[len(history)-1] {
+			total += val
+		}
+		assert.Equal(t, int64(46), total, facts)
+		total = 0
+		for _, val := range history[0] {
+			total += val
+		}
+		assert.Equal(t, int64(28), total, facts)
+	}
+}
+
+func TestBurndownSerializeSampleCommits(t *testing.T) {
+	bd := &BurndownAnalysis{}
+	result := BurndownResult{
+		GlobalHistory: DenseHistory{{15, 0}, {13, 3}},
+		FileHistories: map[string]DenseHistory{},
+		FileOwnership: map[string]map[int]int{},
+		SampleCommits: []plumbing.Hash{
+			plumbing.NewHash("cce947b98a050c6d356bc6ba95030254914027b1"),
+			plumbing.NewHash("a3ee37f91f0d705ec9c41ae88426f0ae44b2fbc3"),
+		},
+		tickSize:    24 * time.Hour,
+		sampling:    10,
+		granularity: 10,
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, bd.Serialize(result, false, buffer))
+	assert.Equal(t, `  granularity: 10
+  sampling: 10
+  tick_size: 86400
+  "project": |-
+    15  0
+    13  3
+  sample_commits:
+    - cce947b98a050c6d356bc6ba95030254914027b1
+    - a3ee37f91f0d705ec9c41ae88426f0ae44b2fbc3
+`, buffer.String())
+	buffer = &bytes.Buffer{}
+	assert.NoError(t, bd.Serialize(result, true, buffer))
+	msg := pb.BurndownAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []string{"cce947b98a050c6d356bc6ba95030254914027b1",
+		"a3ee37f91f0d705ec9c41ae88426f0ae44b2fbc3"}, msg.SampleCommits)
+	deserialized, err := bd.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result.SampleCommits, deserialized.(BurndownResult).SampleCommits)
+}
+
+func TestBurndownTotalLines(t *testing.T) {
+	bd := BurndownAnalysis{}
+	assert.NoError(t, bd.Configure(map[string]interface{}{ConfigBurndownTotalLines: true}))
+	assert.True(t, bd.TotalLines)
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"a.go": "1\n2\n3\n"}},
+		{Author: "one", When: when.Add(day), Files: map[string]string{"a.go": "1\n2\n3\n4\n"}},
+		{Author: "two", When: when.Add(2 * day), Parents: []int{0},
+			Files: map[string]string{"b.go": "x\ny\n"}},
+		// the merge resolution inserts one more line
+		{Author: "one", When: when.Add(4 * day), Parents: []int{1, 2},
+			Files: map[string]string{"a.go": "1\n2\n3\n4\n5\n", "b.go": "x\ny\n"}},
+	})
+	assert.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	burndown := pipeline.DeployItem(&BurndownAnalysis{}).(*BurndownAnalysis)
+	commits, err := pipeline.Commits(false)
+	assert.NoError(t, err)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigBurndownGranularity: 1,
+		ConfigBurndownSampling:    1,
+		ConfigBurndownTotalLines:  true,
+	}))
+	results, err := pipeline.Run(commits)
+	assert.NoError(t, err)
+	result := results[burndown].(BurndownResult)
+	assert.Equal(t, []int64{3, 4, 6, 6, 7}, result.TotalLines)
+	// the same as the sums over the bands
+	sums := make([]int64, len(result.GlobalHistory))
+	for i, bands := range result.GlobalHistory {
+		for _, lines := range bands {
+			sums[i] += lines
+		}
+	}
+	assert.Equal(t, sums, result.TotalLines)
+	burndown.TotalLines = false
+	assert.Nil(t, burndown.Finalize().(BurndownResult).TotalLines)
+}
+
+func TestBurndownSerializeTotalLines(t *testing.T) {
+	bd := &BurndownAnalysis{}
+	result := BurndownResult{
+		GlobalHistory: DenseHistory{{15, 0}, {13, 3}},
+		FileHistories: map[string]DenseHistory{},
+		FileOwnership: map[string]map[int]int{},
+		TotalLines:    []int64{15, 15, 17, 16},
+		tickSize:      24 * time.Hour,
+		sampling:      2,
+		granularity:   2,
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, bd.Serialize(result, false, buffer))
+	assert.Equal(t, `  granularity: 2
+  sampling: 2
+  tick_size: 86400
+  "project": |-
+    15  0
+    13  3
+  total_lines: [15, 15, 17, 16]
+`, buffer.String())
+	buffer = &bytes.Buffer{}
+	assert.NoError(t, bd.Serialize(result, true, buffer))
+	msg := pb.BurndownAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []int64{15, 15, 17, 16}, msg.TotalLines)
+	deserialized, err := bd.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result.TotalLines, deserialized.(BurndownResult).TotalLines)
+}
+
 func TestBurndownRegistration(t *testing.T) {
 	summoned := core.Registry.Summon((&BurndownAnalysis{}).Name())
 	assert.Len(t, summoned, 1)
@@ -120,8 +729,8 @@ func TestBurndownRegistration(t *testing.T) {
 
 func TestBurndownInitialize(t *testing.T) {
 	bd := BurndownAnalysis{}
-	bd.Sampling = -10
-	bd.Granularity = DefaultBurndownGranularity
+	bd.Sampling = 0
+	bd.Granularity = 0
 	bd.HibernationThreshold = 10
 	assert.Nil(t, bd.Initialize(test.Repository))
 	assert.Equal(t, bd.Sampling, DefaultBurndownGranularity)
@@ -132,11 +741,51 @@ func TestBurndownInitialize(t *testing.T) {
 	assert.Nil(t, bd.Initialize(test.Repository))
 	assert.Equal(t, bd.Sampling, DefaultBurndownGranularity-1)
 	assert.Equal(t, bd.Granularity, DefaultBurndownGranularity-1)
+	bd.Sampling = 7
+	bd.Granularity = 30
+	assert.Nil(t, bd.Initialize(test.Repository))
+	assert.Equal(t, bd.Sampling, 7)
+	assert.Equal(t, bd.Granularity, 30)
+	bd.Sampling = -10
+	assert.Error(t, bd.Initialize(test.Repository))
 	bd.Sampling = DefaultBurndownGranularity - 1
 	bd.Granularity = -10
-	assert.Nil(t, bd.Initialize(test.Repository))
-	assert.Equal(t, bd.Sampling, DefaultBurndownGranularity-1)
-	assert.Equal(t, bd.Granularity, DefaultBurndownGranularity)
+	assert.Error(t, bd.Initialize(test.Repository))
+	bd.Granularity = 1 << 20
+	bd.Sampling = 1
+	bd.TickSize = 30 * 24 * time.Hour
+	assert.EqualError(t, bd.Initialize(test.Repository),
+		"Burndown.Granularity is too big: 1048576 ticks of 720h0m0s each")
+}
+
+func TestBurndownConfigureNegativeSampling(t *testing.T) {
+	bd := BurndownAnalysis{}
+	assert.Error(t, bd.Configure(map[string]interface{}{ConfigBurndownSampling: -1}))
+	assert.Error(t, bd.Configure(map[string]interface{}{ConfigBurndownGranularity: -1}))
+	assert.NoError(t, bd.Configure(map[string]interface{}{
+		ConfigBurndownSampling: 7, ConfigBurndownGranularity: 30}))
+	assert.Equal(t, 7, bd.Sampling)
+	assert.Equal(t, 30, bd.Granularity)
+}
+
+func TestBurndownGroupSparseHistoryFineSampling(t *testing.T) {
+	bd := BurndownAnalysis{Sampling: 7, Granularity: 30}
+	history := sparseHistory{
+		0:  {0: 100},
+		40: {0: -10, 40: 50},
+	}
+	result, lastTick := bd.groupSparseHistory(history, 60)
+	assert.Equal(t, 60, lastTick)
+	// 60/7+1 samples, 60/30+1 bands
+	assert.Len(t, result, 9)
+	for i, sample := range result {
+		assert.Len(t, sample, 3)
+		if i < 5 {
+			assert.Equal(t, []int64{100, 0, 0}, sample, i)
+		} else {
+			assert.Equal(t, []int64{90, 50, 0}, sample, i)
+		}
+	}
 }
 
 func TestBurndownConsumeFinalize(t *testing.T) {
@@ -1217,6 +1866,7 @@ func TestBurndownMergeGlobalHistory_withDifferentTickSizes(t *testing.T) {
 	merged := bd.MergeResults(res1, res2, &c1, &c2)
 	assert.IsType(t, errors.New(""), merged)
 	assert.Contains(t, merged.(error).Error(), "mismatching tick sizes")
+	assert.Contains(t, merged.(error).Error(), "13h0m0s")
 }
 
 func TestBurndownMergeNils(t *testing.T) {
@@ -1514,6 +2164,79 @@ func TestBurndownMergeMatrices(t *testing.T) {
 	}
 }
 
+func TestBurndownCapPeople(t *testing.T) {
+	history := func(val int64) DenseHistory {
+		return DenseHistory{{val, 0}, {val, val}}
+	}
+	result := BurndownResult{
+		PeopleHistories: []DenseHistory{history(1), history(2), history(3), history(4)},
+		PeopleMatrix: DenseHistory{
+			{10, 1, 0, -1, -2, -3},
+			{5, 0, 0, 0, 0, -1},
+			{20, 2, -4, 0, 0, 0},
+			{1, 0, 0, 0, -1, 0},
+		},
+		FileOwnership: map[string]map[int]int{
+			"a.go": {0: 3, 1: 4, 3: 5, -1: 6},
+			"b.go": {2: 7},
+		},
+		reversedPeopleDict: []string{"one", "two", "three", "four"},
+	}
+	capBurndownPeople(&result, 4)
+	assert.Len(t, result.reversedPeopleDict, 4)
+	capBurndownPeople(&result, 2)
+	assert.Equal(t, []string{"one", "three", BurndownOthers}, result.reversedPeopleDict)
+	assert.Equal(t, []DenseHistory{history(1), history(3), history(6)}, result.PeopleHistories)
+	assert.Equal(t, DenseHistory{
+		{10, 1, 0, -2, -4},
+		{20, 2, -4, 0, 0},
+		{6, 0, 0, -1, -1},
+	}, result.PeopleMatrix)
+	assert.Equal(t, map[string]map[int]int{
+		"a.go": {0: 3, 2: 9, -1: 6},
+		"b.go": {1: 7},
+	}, result.FileOwnership)
+}
+
+func TestBurndownMergeMatricesHighPrecision(t *testing.T) {
+	h := DenseHistory{}
+	for y := 0; y < 12; y++ {
+		row := make([]int64, 12)
+		for x := 0; x <= y; x++ {
+			row[x] = int64(100003*(x+1)) / int64(y-x+1)
+		}
+		h = append(h, row)
+	}
+	cr := &core.CommonAnalysisResult{
+		BeginTime: 1390499270,
+		EndTime:   1390499270 + 12*30*24*3600 - 1000,
+	}
+	// combining 20 identical shards must give the same as analysing them in a single pass
+	const shards = 20
+	drift := func(highPrecision bool) int64 {
+		bd := BurndownAnalysis{TickSize: 24 * time.Hour, HighPrecision: highPrecision}
+		merged := h
+		for i := 1; i < shards; i++ {
+			merged = bd.mergeMatrices(merged, h, 30, 30, 30, 30, bd.TickSize, cr, cr)
+		}
+		var sum int64
+		for y, row := range h {
+			for x, val := range row {
+				delta := merged[y][x] - val*shards
+				if delta < 0 {
+					delta = -delta
+				}
+				sum += delta
+			}
+		}
+		return sum
+	}
+	lowDrift := drift(false)
+	highDrift := drift(true)
+	assert.True(t, highDrift < lowDrift, "%d >= %d", highDrift, lowDrift)
+	assert.Equal(t, int64(0), highDrift)
+}
+
 func TestBurndownMergePeopleHistories(t *testing.T) {
 	h1 := [][]int64{
 		{50, 0, 0},
@@ -1621,6 +2344,160 @@ func TestBurndownHandleRenameCycle(t *testing.T) {
 	})
 }
 
+func TestBurndownIgnoreWhitespace(t *testing.T) {
+	before := "func main() {\nfoo()\nbar()\n}\n  "
+	// reindented, one line changed and the trailing whitespace-only line is kept
+	after := "func main() {\n\tfoo()\n\tbaz()\n}\n\t"
+	hashBefore := plumbing.NewHash("1111111111111111111111111111111111111111")
+	hashAfter := plumbing.NewHash("2222222222222222222222222222222222222222")
+	cache := map[plumbing.Hash]*items.CachedBlob{
+		hashBefore: {Blob: object.Blob{Hash: hashBefore}, Data: []byte(before)},
+		hashAfter:  {Blob: object.Blob{Hash: hashAfter}, Data: []byte(after)},
+	}
+	change := &object.Change{
+		From: object.ChangeEntry{Name: "main.go", TreeEntry: object.TreeEntry{
+			Name: "main.go", Hash: hashBefore}},
+		To: object.ChangeEntry{Name: "main.go", TreeEntry: object.TreeEntry{
+			Name: "main.go", Hash: hashAfter}},
+	}
+	for _, ignore := range []bool{false, true} {
+		facts := map[string]interface{}{ConfigBurndownIgnoreWhitespace: ignore}
+		bd := BurndownAnalysis{Granularity: 10, Sampling: 10}
+		assert.NoError(t, bd.ConfigureDependencies(facts))
+		assert.Equal(t, ignore, facts[items.ConfigFileWhitespaceIgnore] == true)
+		fd := &items.FileDiff{}
+		assert.NoError(t, fd.Configure(facts))
+		assert.NoError(t, fd.Initialize(test.Repository))
+		res, err := fd.Consume(map[string]interface{}{
+			items.DependencyBlobCache:   cache,
+			items.DependencyTreeChanges: object.Changes{change},
+		})
+		assert.NoError(t, err)
+		diffs := res[items.DependencyFileDiff].(map[string]items.FileDiffData)
+		assert.NoError(t, bd.Initialize(test.Repository))
+		assert.NoError(t, bd.handleInsertion(&object.Change{To: change.From}, 0, cache))
+		bd.tick = 1
+		assert.NoError(t, bd.handleModification(change, 0, cache, diffs))
+		assert.Equal(t, 5, bd.files["main.go"].Len())
+		if ignore {
+			assert.Equal(t, map[int]int64{0: -1, 1: 1}, bd.globalHistory[1])
+		} else {
+			assert.Equal(t, map[int]int64{0: -3, 1: 3}, bd.globalHistory[1])
+		}
+	}
+}
+
+func TestBurndownOldVsNew(t *testing.T) {
+	bd := BurndownAnalysis{}
+	assert.Error(t, bd.Configure(map[string]interface{}{ConfigBurndownOldVsNewThreshold: -1}))
+	assert.NoError(t, bd.Configure(map[string]interface{}{ConfigBurndownOldVsNewThreshold: 5}))
+	assert.Equal(t, 5, bd.OldVsNewThreshold)
+	bd.Granularity = 10
+	bd.Sampling = 10
+	assert.NoError(t, bd.Initialize(test.Repository))
+
+	blobs := []string{"a\nb\nc\n", "a\nb\nc\nd\n", "A\nb\nc\n"}
+	cache := map[plumbing.Hash]*items.CachedBlob{}
+	entries := make([]object.ChangeEntry, len(blobs))
+	for i, blob := range blobs {
+		hash := plumbing.ComputeHash(plumbing.BlobObject, []byte(blob))
+		cache[hash] = &items.CachedBlob{Blob: object.Blob{Hash: hash}, Data: []byte(blob)}
+		entries[i] = object.ChangeEntry{Name: "file", TreeEntry: object.TreeEntry{Name: "file", Hash: hash}}
+	}
+	modify := func(tick int, from, to object.ChangeEntry) {
+		change := &object.Change{From: from, To: to}
+		fd := &items.FileDiff{}
+		assert.NoError(t, fd.Initialize(test.Repository))
+		res, err := fd.Consume(map[string]interface{}{
+			items.DependencyBlobCache:   cache,
+			items.DependencyTreeChanges: object.Changes{change},
+		})
+		assert.NoError(t, err)
+		bd.tick = tick
+		assert.NoError(t, bd.handleModification(
+			change, 0, cache, res[items.DependencyFileDiff].(map[string]items.FileDiffData)))
+	}
+	assert.NoError(t, bd.handleInsertion(&object.Change{To: entries[0]}, 0, cache))
+	// a pure insertion of a new line
+	modify(8, entries[0], entries[1])
+	// an old line is replaced and a new line is removed
+	modify(10, entries[1], entries[2])
+	bd.tick = 12
+	assert.NoError(t, bd.handleDeletion(&object.Change{From: entries[2]}, 0, cache))
+	// merges are ignored
+	bd.tick = burndown.TreeMergeMark
+	assert.NoError(t, bd.handleInsertion(&object.Change{To: entries[0]}, 0, cache))
+	assert.Equal(t, map[int][]int64{
+		0:  {0, 0, 3, 0},
+		8:  {0, 0, 1, 0},
+		10: {1, 1, 0, 1},
+		12: {0, 2, 0, 1},
+	}, bd.oldVsNew)
+
+	bd.tick = 12
+	result := bd.Finalize().(BurndownResult)
+	assert.Len(t, result.OldVsNew, 13)
+	assert.Equal(t, []int64{1, 1, 0, 1}, result.OldVsNew[10])
+	assert.Equal(t, []int64{0, 0, 0, 0}, result.OldVsNew[11])
+	assert.Equal(t, 5, result.oldVsNewThreshold)
+	bd.OldVsNewThreshold = 0
+	result = bd.Finalize().(BurndownResult)
+	assert.Nil(t, result.OldVsNew)
+}
+
+func TestBurndownSerializeOldVsNew(t *testing.T) {
+	bd := &BurndownAnalysis{}
+	result := BurndownResult{
+		GlobalHistory:     DenseHistory{{15, 0}, {13, 3}},
+		FileHistories:     map[string]DenseHistory{},
+		FileOwnership:     map[string]map[int]int{},
+		OldVsNew:          DenseHistory{{0, 0, 15, 0}, {0, 0, 0, 0}, {1, 2, 3, 0}},
+		oldVsNewThreshold: 7,
+		tickSize:          24 * time.Hour,
+		sampling:          10,
+		granularity:       10,
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, bd.Serialize(result, false, buffer))
+	assert.Equal(t, `  granularity: 10
+  sampling: 10
+  tick_size: 86400
+  "project": |-
+    15  0
+    13  3
+  old_vs_new_threshold: 7
+  "old_vs_new": |-
+    0   0 15  0
+     0  0  0  0
+     1  2  3  0
+`, buffer.String())
+	buffer = &bytes.Buffer{}
+	assert.NoError(t, bd.Serialize(result, true, buffer))
+	msg := pb.BurndownAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, "old_vs_new", msg.OldVsNew.Name)
+	deserialized, err := bd.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result.OldVsNew, deserialized.(BurndownResult).OldVsNew)
+	assert.Equal(t, 7, deserialized.(BurndownResult).oldVsNewThreshold)
+}
+
+func TestBurndownMergeOldVsNew(t *testing.T) {
+	c1 := &core.CommonAnalysisResult{
+		BeginTime: 600566400, // 1989 Jan 12
+		EndTime:   600739200, // 1989 Jan 14
+	}
+	c2 := &core.CommonAnalysisResult{
+		BeginTime: 600652800, // 1989 Jan 13
+		EndTime:   600825600, // 1989 Jan 15
+	}
+	merged := mergeOldVsNew(
+		DenseHistory{{1, 0, 0, 0}, {0, 1, 0, 0}},
+		DenseHistory{{0, 0, 1, 0}, {0, 0, 0, 1}},
+		24*time.Hour, c1, c2)
+	assert.Equal(t, DenseHistory{{1, 0, 0, 0}, {0, 1, 1, 0}, {0, 0, 0, 1}}, merged)
+}
+
 func TestBurndownResultGetters(t *testing.T) {
 	br := BurndownResult{tickSize: time.Hour, reversedPeopleDict: []string{"one", "two"}}
 	assert.Equal(t, br.tickSize, br.GetTickSize())
@@ -0,0 +1,169 @@
+package leaves
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+)
+
+const (
+	// MeasureUnitLines makes BurndownAnalysis count the lines, which is the default.
+	MeasureUnitLines = "lines"
+	// MeasureUnitBytes makes BurndownAnalysis count the bytes, so that the verbose languages
+	// do not weigh more than the terse ones.
+	MeasureUnitBytes = "bytes"
+)
+
+// BlobMeasurer measures the files in the units which BurndownAnalysis tracks. burndown.File
+// does not care what the units are as long as the insertions, the deletions and
+// the modifications report them consistently.
+type BlobMeasurer interface {
+	// Measure returns the size of the blob in units or (0, ErrorBinary) if it is binary.
+	Measure(blob *items.CachedBlob) (int, error)
+	// Diff converts the line diff between two versions of the file to the units.
+	Diff(from, to *items.CachedBlob, diff items.FileDiffData) BlobDiff
+}
+
+// BlobDiff is the difference between two versions of a file measured by a BlobMeasurer.
+type BlobDiff struct {
+	// OldSize is the size of the previous version in units.
+	OldSize int
+	// NewSize is the size of the next version in units.
+	NewSize int
+	// Edits are the consecutive diff operations. A deletion may only be followed by
+	// an insertion or an equality, and an insertion may only be followed by an equality.
+	Edits []BlobEdit
+}
+
+// BlobEdit is a single diff operation which spans Length units.
+type BlobEdit struct {
+	Type   diffmatchpatch.Operation
+	Length int
+}
+
+// NewBlobMeasurer returns the BlobMeasurer which corresponds to the unit name:
+// MeasureUnitLines or MeasureUnitBytes.
+func NewBlobMeasurer(unit string) (BlobMeasurer, error) {
+	switch unit {
+	case "", MeasureUnitLines:
+		return lineMeasurer{}, nil
+	case MeasureUnitBytes:
+		return byteMeasurer{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported measure unit: %s", unit)
+	}
+}
+
+// lineMeasurer counts the lines. It is the BlobMeasurer for MeasureUnitLines.
+type lineMeasurer struct{}
+
+func (lineMeasurer) Measure(blob *items.CachedBlob) (int, error) {
+	return blob.CountLines()
+}
+
+func (lineMeasurer) Diff(from, to *items.CachedBlob, diff items.FileDiffData) BlobDiff {
+	result := BlobDiff{OldSize: diff.OldLinesOfCode, NewSize: diff.NewLinesOfCode}
+	result.Edits = make([]BlobEdit, len(diff.Diffs))
+	for i, edit := range diff.Diffs {
+		// we do not call RunesToDiffLines so the number of lines equals to the rune count
+		result.Edits[i] = BlobEdit{Type: edit.Type, Length: utf8.RuneCountInString(edit.Text)}
+	}
+	return result
+}
+
+// byteMeasurer counts the bytes. It is the BlobMeasurer for MeasureUnitBytes.
+type byteMeasurer struct{}
+
+func (byteMeasurer) Measure(blob *items.CachedBlob) (int, error) {
+	if _, err := blob.CountLines(); err != nil {
+		return 0, err
+	}
+	return len(blob.Data), nil
+}
+
+// Diff expands each line of the line diff to its length in bytes. The equal lines may still
+// differ if the diff ignored the whitespace, and then the common prefix length stays equal
+// while the rest is replaced.
+func (byteMeasurer) Diff(from, to *items.CachedBlob, diff items.FileDiffData) BlobDiff {
+	result := BlobDiff{OldSize: len(from.Data), NewSize: len(to.Data)}
+	oldLines, newLines := splitLineLengths(from.Data), splitLineLengths(to.Data)
+	oldLine, newLine := 0, 0
+	sum := func(lengths []int) int {
+		total := 0
+		for _, length := range lengths {
+			total += length
+		}
+		return total
+	}
+	for _, edit := range diff.Diffs {
+		count := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			for i := 0; i < count; i++ {
+				oldLength, newLength := oldLines[oldLine+i], newLines[newLine+i]
+				common := oldLength
+				if newLength < common {
+					common = newLength
+				}
+				result.Edits = appendBlobEdit(result.Edits, diffmatchpatch.DiffEqual, common)
+				result.Edits = appendBlobEdit(
+					result.Edits, diffmatchpatch.DiffDelete, oldLength-common)
+				result.Edits = appendBlobEdit(
+					result.Edits, diffmatchpatch.DiffInsert, newLength-common)
+			}
+			oldLine += count
+			newLine += count
+		case diffmatchpatch.DiffDelete:
+			result.Edits = appendBlobEdit(
+				result.Edits, edit.Type, sum(oldLines[oldLine:oldLine+count]))
+			oldLine += count
+		case diffmatchpatch.DiffInsert:
+			result.Edits = appendBlobEdit(
+				result.Edits, edit.Type, sum(newLines[newLine:newLine+count]))
+			newLine += count
+		}
+	}
+	return result
+}
+
+// splitLineLengths returns the length in bytes of each line including the line break.
+// The lines are the same as in diffmatchpatch.DiffLinesToRunes() and CachedBlob.CountLines().
+func splitLineLengths(data []byte) []int {
+	var lengths []int
+	for len(data) > 0 {
+		end := bytes.IndexByte(data, '\n') + 1
+		if end == 0 {
+			end = len(data)
+		}
+		lengths = append(lengths, end)
+		data = data[end:]
+	}
+	return lengths
+}
+
+// appendBlobEdit adds the edit to the sequence while keeping the invariants of BlobDiff.Edits:
+// the empty edits are dropped, the edits of the same type are joined and a deletion which
+// follows an insertion is moved before it.
+func appendBlobEdit(edits []BlobEdit, op diffmatchpatch.Operation, length int) []BlobEdit {
+	if length == 0 {
+		return edits
+	}
+	n := len(edits)
+	if n > 0 && edits[n-1].Type == op {
+		edits[n-1].Length += length
+		return edits
+	}
+	if op == diffmatchpatch.DiffDelete && n > 0 && edits[n-1].Type == diffmatchpatch.DiffInsert {
+		if n > 1 && edits[n-2].Type == diffmatchpatch.DiffDelete {
+			edits[n-2].Length += length
+			return edits
+		}
+		edits = append(edits, edits[n-1])
+		edits[n-1] = BlobEdit{Type: op, Length: length}
+		return edits
+	}
+	return append(edits, BlobEdit{Type: op, Length: length})
+}
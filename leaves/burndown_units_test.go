@@ -0,0 +1,110 @@
+package leaves
+
+import (
+	"testing"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+)
+
+func TestNewBlobMeasurer(t *testing.T) {
+	measurer, err := NewBlobMeasurer("")
+	assert.NoError(t, err)
+	assert.Equal(t, lineMeasurer{}, measurer)
+	measurer, err = NewBlobMeasurer(MeasureUnitLines)
+	assert.NoError(t, err)
+	assert.Equal(t, lineMeasurer{}, measurer)
+	measurer, err = NewBlobMeasurer(MeasureUnitBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, byteMeasurer{}, measurer)
+	measurer, err = NewBlobMeasurer("tokens")
+	assert.EqualError(t, err, "unsupported measure unit: tokens")
+	assert.Nil(t, measurer)
+}
+
+func TestBlobMeasurerMeasure(t *testing.T) {
+	text := &items.CachedBlob{Data: []byte("one\ntwo\nthree")}
+	binary := &items.CachedBlob{Data: []byte("one\x00two\n")}
+	lines, err := lineMeasurer{}.Measure(text)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, lines)
+	size, err := byteMeasurer{}.Measure(text)
+	assert.NoError(t, err)
+	assert.Equal(t, 13, size)
+	_, err = lineMeasurer{}.Measure(binary)
+	assert.Equal(t, items.ErrorBinary, err)
+	_, err = byteMeasurer{}.Measure(binary)
+	assert.Equal(t, items.ErrorBinary, err)
+}
+
+func TestSplitLineLengths(t *testing.T) {
+	assert.Nil(t, splitLineLengths(nil))
+	assert.Equal(t, []int{4, 1, 5}, splitLineLengths([]byte("one\n\nthree")))
+	assert.Equal(t, []int{4, 4}, splitLineLengths([]byte("one\ntwo\n")))
+}
+
+func TestAppendBlobEdit(t *testing.T) {
+	var edits []BlobEdit
+	edits = appendBlobEdit(edits, diffmatchpatch.DiffEqual, 0)
+	assert.Len(t, edits, 0)
+	edits = appendBlobEdit(edits, diffmatchpatch.DiffEqual, 2)
+	edits = appendBlobEdit(edits, diffmatchpatch.DiffEqual, 3)
+	edits = appendBlobEdit(edits, diffmatchpatch.DiffInsert, 1)
+	edits = appendBlobEdit(edits, diffmatchpatch.DiffDelete, 4)
+	edits = appendBlobEdit(edits, diffmatchpatch.DiffInsert, 2)
+	edits = appendBlobEdit(edits, diffmatchpatch.DiffDelete, 1)
+	edits = appendBlobEdit(edits, diffmatchpatch.DiffEqual, 1)
+	assert.Equal(t, []BlobEdit{
+		{diffmatchpatch.DiffEqual, 5},
+		{diffmatchpatch.DiffDelete, 5},
+		{diffmatchpatch.DiffInsert, 3},
+		{diffmatchpatch.DiffEqual, 1},
+	}, edits)
+}
+
+func TestBlobMeasurerDiff(t *testing.T) {
+	from := &items.CachedBlob{Data: []byte("a\nbb\nccc\n")}
+	to := &items.CachedBlob{Data: []byte("a\nxxxx\nccc\nd")}
+	// every rune is a line
+	diff := items.FileDiffData{
+		OldLinesOfCode: 3,
+		NewLinesOfCode: 4,
+		Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "1"},
+			{Type: diffmatchpatch.DiffDelete, Text: "2"},
+			{Type: diffmatchpatch.DiffInsert, Text: "4"},
+			{Type: diffmatchpatch.DiffEqual, Text: "3"},
+			{Type: diffmatchpatch.DiffInsert, Text: "5"},
+		},
+	}
+	assert.Equal(t, BlobDiff{OldSize: 3, NewSize: 4, Edits: []BlobEdit{
+		{diffmatchpatch.DiffEqual, 1},
+		{diffmatchpatch.DiffDelete, 1},
+		{diffmatchpatch.DiffInsert, 1},
+		{diffmatchpatch.DiffEqual, 1},
+		{diffmatchpatch.DiffInsert, 1},
+	}}, lineMeasurer{}.Diff(from, to, diff))
+	assert.Equal(t, BlobDiff{OldSize: 9, NewSize: 12, Edits: []BlobEdit{
+		{diffmatchpatch.DiffEqual, 2},
+		{diffmatchpatch.DiffDelete, 3},
+		{diffmatchpatch.DiffInsert, 5},
+		{diffmatchpatch.DiffEqual, 4},
+		{diffmatchpatch.DiffInsert, 1},
+	}}, byteMeasurer{}.Diff(from, to, diff))
+
+	// the equal lines differ in whitespace
+	from = &items.CachedBlob{Data: []byte("a b\nc\n")}
+	to = &items.CachedBlob{Data: []byte("ab\n  c\n")}
+	diff = items.FileDiffData{
+		OldLinesOfCode: 2,
+		NewLinesOfCode: 2,
+		Diffs:          []diffmatchpatch.Diff{{Type: diffmatchpatch.DiffEqual, Text: "12"}},
+	}
+	assert.Equal(t, BlobDiff{OldSize: 6, NewSize: 7, Edits: []BlobEdit{
+		{diffmatchpatch.DiffEqual, 3},
+		{diffmatchpatch.DiffDelete, 1},
+		{diffmatchpatch.DiffEqual, 2},
+		{diffmatchpatch.DiffInsert, 2},
+	}}, byteMeasurer{}.Diff(from, to, diff))
+}
@@ -0,0 +1,293 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// BusFactorAnalysis calculates the bus factor of each file and each directory: the minimum
+// number of developers who together own more than half of the surviving lines.
+// It tracks the line ownership with the embedded lineTracker. It is a LeafPipelineItem.
+type BusFactorAnalysis struct {
+	lineTracker
+
+	l core.Logger
+}
+
+// BusFactor is the bus factor of a single file or directory.
+type BusFactor struct {
+	// Path is the file name or the directory prefix ending with "/".
+	Path string
+	// Lines is the number of surviving lines which belong to identified developers.
+	Lines int
+	// BusFactor is the minimum number of developers who together own more than Lines / 2.
+	BusFactor int
+	// Owners are the indexes of those BusFactor developers sorted by the owned lines
+	// in descending order.
+	Owners []int
+}
+
+// BusFactorResult is returned by BusFactorAnalysis.Finalize() and carries the bus factors
+// of the files and the directories.
+type BusFactorResult struct {
+	// Files are sorted by Path.
+	Files []BusFactor
+	// Directories are sorted by Path.
+	Directories []BusFactor
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *BusFactorAnalysis) Name() string {
+	return "BusFactor"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *BusFactorAnalysis) Provides() []string {
+	return []string{}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *BusFactorAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *BusFactorAnalysis) Flag() string {
+	return "bus-factor"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *BusFactorAnalysis) Description() string {
+	return "Calculates the minimum number of developers who together own more than half " +
+		"of the surviving lines in each file and in each directory."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *BusFactorAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		analyser.l = l
+	}
+	return analyser.configure(facts, true)
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *BusFactorAnalysis) Initialize(repository *git.Repository) error {
+	if analyser.l == nil {
+		analyser.l = core.NewLogger()
+	}
+	return analyser.initialize(repository)
+}
+
+// Fork clones this item. The files are copied by value.
+func (analyser *BusFactorAnalysis) Fork(n int) []core.PipelineItem {
+	result := make([]core.PipelineItem, n)
+	for i, tracker := range analyser.fork(n) {
+		clone := *analyser
+		clone.lineTracker = tracker
+		result[i] = &clone
+	}
+	return result
+}
+
+// Merge combines several items together. The file merging logic belongs to BurndownAnalysis.
+func (analyser *BusFactorAnalysis) Merge(branches []core.PipelineItem) {
+	trackers := make([]lineTracker, len(branches))
+	for i, branch := range branches {
+		trackers[i] = branch.(*BusFactorAnalysis).lineTracker
+	}
+	analyser.merge(trackers)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *BusFactorAnalysis) Finalize() interface{} {
+	fileOwnership := map[string]map[int]int{}
+	for path, file := range analyser.burndown.files {
+		fileOwnership[path] = analyser.burndown.fileOwnership(file)
+	}
+	return groupBusFactors(fileOwnership, analyser.burndown.reversedPeopleDict)
+}
+
+// groupBusFactors calculates the bus factors of the files and aggregates the ownership
+// in every directory.
+func groupBusFactors(fileOwnership map[string]map[int]int, reversedPeopleDict []string) BusFactorResult {
+	result := BusFactorResult{
+		Files:              []BusFactor{},
+		Directories:        []BusFactor{},
+		reversedPeopleDict: reversedPeopleDict,
+	}
+	dirOwnership := map[string]map[int]int{}
+	for path, ownership := range fileOwnership {
+		if factor := calculateBusFactor(path, ownership); factor.Lines > 0 {
+			result.Files = append(result.Files, factor)
+		}
+		for i, c := range path {
+			if c != '/' {
+				continue
+			}
+			dir := path[:i+1]
+			owned := dirOwnership[dir]
+			if owned == nil {
+				owned = map[int]int{}
+				dirOwnership[dir] = owned
+			}
+			for author, lines := range ownership {
+				owned[author] += lines
+			}
+		}
+	}
+	for dir, ownership := range dirOwnership {
+		if factor := calculateBusFactor(dir, ownership); factor.Lines > 0 {
+			result.Directories = append(result.Directories, factor)
+		}
+	}
+	sort.Slice(result.Files, func(i, j int) bool {
+		return result.Files[i].Path < result.Files[j].Path
+	})
+	sort.Slice(result.Directories, func(i, j int) bool {
+		return result.Directories[i].Path < result.Directories[j].Path
+	})
+	return result
+}
+
+// calculateBusFactor picks the biggest owners until they own more than half of the lines.
+// The lines of the unidentified developers are ignored.
+func calculateBusFactor(path string, ownership map[int]int) BusFactor {
+	factor := BusFactor{Path: path, Owners: []int{}}
+	authors := make([]int, 0, len(ownership))
+	for author, lines := range ownership {
+		// BurndownResult.FileOwnership maps identity.AuthorMissing to -1
+		if author < 0 || lines <= 0 {
+			continue
+		}
+		authors = append(authors, author)
+		factor.Lines += lines
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		li, lj := ownership[authors[i]], ownership[authors[j]]
+		if li != lj {
+			return li > lj
+		}
+		return authors[i] < authors[j]
+	})
+	owned := 0
+	for _, author := range authors {
+		if owned*2 > factor.Lines {
+			break
+		}
+		owned += ownership[author]
+		factor.Owners = append(factor.Owners, author)
+	}
+	factor.BusFactor = len(factor.Owners)
+	return factor
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *BusFactorAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	busFactorResult := result.(BusFactorResult)
+	if binary {
+		return analyser.serializeBinary(&busFactorResult, writer)
+	}
+	analyser.serializeText(&busFactorResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to BusFactorResult.
+func (analyser *BusFactorAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.BusFactorAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(factors []*pb.BusFactor) []BusFactor {
+		result := make([]BusFactor, len(factors))
+		for i, factor := range factors {
+			owners := make([]int, len(factor.Owners))
+			for j, owner := range factor.Owners {
+				owners[j] = int(owner)
+			}
+			result[i] = BusFactor{
+				Path:      factor.Path,
+				Lines:     int(factor.Lines),
+				BusFactor: int(factor.BusFactor),
+				Owners:    owners,
+			}
+		}
+		return result
+	}
+	return BusFactorResult{
+		Files:              convert(message.Files),
+		Directories:        convert(message.Directories),
+		reversedPeopleDict: message.DevIndex,
+	}, nil
+}
+
+func (analyser *BusFactorAnalysis) serializeText(result *BusFactorResult, writer io.Writer) {
+	writeFactors := func(name string, factors []BusFactor) {
+		fmt.Fprintf(writer, "  %s:\n", name)
+		for _, factor := range factors {
+			owners := make([]string, len(factor.Owners))
+			for i, owner := range factor.Owners {
+				owners[i] = fmt.Sprint(owner)
+			}
+			fmt.Fprintf(writer, "  - path: %s\n", yaml.SafeString(factor.Path))
+			fmt.Fprintf(writer, "    lines: %d\n", factor.Lines)
+			fmt.Fprintf(writer, "    bus_factor: %d\n", factor.BusFactor)
+			fmt.Fprintf(writer, "    owners: [%s]\n", strings.Join(owners, ", "))
+		}
+	}
+	writeFactors("files", result.Files)
+	writeFactors("directories", result.Directories)
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (analyser *BusFactorAnalysis) serializeBinary(result *BusFactorResult, writer io.Writer) error {
+	convert := func(factors []BusFactor) []*pb.BusFactor {
+		result := make([]*pb.BusFactor, len(factors))
+		for i, factor := range factors {
+			owners := make([]int32, len(factor.Owners))
+			for j, owner := range factor.Owners {
+				owners[j] = int32(owner)
+			}
+			result[i] = &pb.BusFactor{
+				Path:      factor.Path,
+				Lines:     int32(factor.Lines),
+				BusFactor: int32(factor.BusFactor),
+				Owners:    owners,
+			}
+		}
+		return result
+	}
+	message := pb.BusFactorAnalysisResults{
+		Files:       convert(result.Files),
+		Directories: convert(result.Directories),
+		DevIndex:    result.reversedPeopleDict,
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&BusFactorAnalysis{})
+}
@@ -0,0 +1,148 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureBusFactor() *BusFactorAnalysis {
+	bf := BusFactorAnalysis{}
+	bf.Initialize(test.Repository)
+	return &bf
+}
+
+func TestBusFactorMeta(t *testing.T) {
+	bf := fixtureBusFactor()
+	assert.Equal(t, bf.Name(), "BusFactor")
+	assert.Len(t, bf.Provides(), 0)
+	assert.Equal(t, (&BurndownAnalysis{}).Requires(), bf.Requires())
+	assert.Len(t, bf.ListConfigurationOptions(), 0)
+	assert.Equal(t, bf.Flag(), "bus-factor")
+	assert.NotEmpty(t, bf.Description())
+	logger := core.NewLogger()
+	people := []string{"one@srcd", "two@srcd"}
+	assert.NoError(t, bf.Configure(map[string]interface{}{
+		core.ConfigLogger:                               logger,
+		ConfigBurndownTrackFiles:                        true,
+		ConfigBurndownTrackPeople:                       false,
+		ConfigBurndownAuthorActivity:                    true,
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: people,
+	}))
+	assert.Equal(t, logger, bf.l)
+	assert.False(t, bf.burndown.TrackFiles)
+	assert.False(t, bf.burndown.AuthorActivity)
+	assert.Equal(t, 2, bf.burndown.PeopleNumber)
+	assert.Equal(t, people, bf.burndown.reversedPeopleDict)
+}
+
+func TestBusFactorRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&BusFactorAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "BusFactor")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&BusFactorAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestBusFactorFork(t *testing.T) {
+	bf1 := fixtureBusFactor()
+	clones := bf1.Fork(1)
+	assert.Len(t, clones, 1)
+	bf2 := clones[0].(*BusFactorAnalysis)
+	assert.True(t, bf1 != bf2)
+	assert.True(t, bf1.burndown != bf2.burndown)
+	bf1.Merge([]core.PipelineItem{bf2})
+	assert.NoError(t, bf1.Hibernate())
+	assert.NoError(t, bf1.Boot())
+}
+
+func TestBusFactorCalculate(t *testing.T) {
+	factor := calculateBusFactor("a.go", map[int]int{0: 10, 1: 30, 2: 20, -1: 100})
+	assert.Equal(t, BusFactor{Path: "a.go", Lines: 60, BusFactor: 2, Owners: []int{1, 2}}, factor)
+	factor = calculateBusFactor("b.go", map[int]int{3: 10, 1: 10})
+	assert.Equal(t, BusFactor{Path: "b.go", Lines: 20, BusFactor: 2, Owners: []int{1, 3}}, factor)
+	factor = calculateBusFactor("c.go", map[int]int{0: 11, 1: 10})
+	assert.Equal(t, BusFactor{Path: "c.go", Lines: 21, BusFactor: 1, Owners: []int{0}}, factor)
+	factor = calculateBusFactor("d.go", map[int]int{-1: 10})
+	assert.Equal(t, BusFactor{Path: "d.go", Owners: []int{}}, factor)
+}
+
+func fixtureBusFactorResult() BusFactorResult {
+	return groupBusFactors(map[string]map[int]int{
+		"README.md":          {0: 10},
+		"leaves/burndown.go": {0: 10, 1: 30},
+		"leaves/devs.go":     {1: 10, 2: 40},
+		"internal/core/x.go": {-1: 10},
+	}, []string{"one", "two", "three"})
+}
+
+func TestBusFactorGroup(t *testing.T) {
+	result := fixtureBusFactorResult()
+	assert.Equal(t, []BusFactor{
+		{Path: "README.md", Lines: 10, BusFactor: 1, Owners: []int{0}},
+		{Path: "leaves/burndown.go", Lines: 40, BusFactor: 1, Owners: []int{1}},
+		{Path: "leaves/devs.go", Lines: 50, BusFactor: 1, Owners: []int{2}},
+	}, result.Files)
+	assert.Equal(t, []BusFactor{
+		{Path: "leaves/", Lines: 90, BusFactor: 2, Owners: []int{1, 2}},
+	}, result.Directories)
+	assert.Equal(t, []string{"one", "two", "three"}, result.reversedPeopleDict)
+}
+
+func TestBusFactorSerialize(t *testing.T) {
+	bf := fixtureBusFactor()
+	result := fixtureBusFactorResult()
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, bf.Serialize(result, false, buffer))
+	assert.Equal(t, `  files:
+  - path: "README.md"
+    lines: 10
+    bus_factor: 1
+    owners: [0]
+  - path: "leaves/burndown.go"
+    lines: 40
+    bus_factor: 1
+    owners: [1]
+  - path: "leaves/devs.go"
+    lines: 50
+    bus_factor: 1
+    owners: [2]
+  directories:
+  - path: "leaves/"
+    lines: 90
+    bus_factor: 2
+    owners: [1, 2]
+  people:
+  - "one"
+  - "two"
+  - "three"
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, bf.Serialize(result, true, buffer))
+	msg := pb.BusFactorAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Files, 3)
+	assert.Len(t, msg.Directories, 1)
+	assert.Equal(t, "leaves/", msg.Directories[0].Path)
+	assert.Equal(t, int32(2), msg.Directories[0].BusFactor)
+	assert.Equal(t, []int32{1, 2}, msg.Directories[0].Owners)
+	assert.Equal(t, []string{"one", "two", "three"}, msg.DevIndex)
+	deserialized, err := bf.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
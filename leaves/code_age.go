@@ -0,0 +1,248 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v10/internal/burndown"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// CodeAgeAnalysis calculates the age distribution of the lines which are alive at HEAD.
+// It reads the final state of the embedded lineTracker, which keeps only the tick of each line
+// and neither the histories nor the people, so it is much cheaper than the burndown matrices.
+// It is a LeafPipelineItem.
+type CodeAgeAnalysis struct {
+	lineTracker
+
+	l core.Logger
+}
+
+// CodeAgeResult is returned by CodeAgeAnalysis.Finalize().
+type CodeAgeResult struct {
+	// Ages maps the line ages in ticks to the number of lines alive at HEAD.
+	// The age is the tick of the last commit minus the tick when the line was written.
+	Ages map[int]int64
+	// FileMedians maps the paths of the files alive at HEAD to the median line age in ticks.
+	FileMedians map[string]float64
+
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *CodeAgeAnalysis) Name() string {
+	return "CodeAge"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *CodeAgeAnalysis) Provides() []string {
+	return []string{}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *CodeAgeAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *CodeAgeAnalysis) Flag() string {
+	return "code-age"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *CodeAgeAnalysis) Description() string {
+	return "Calculates the age distribution of the lines alive at HEAD and the median line age " +
+		"of each file."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *CodeAgeAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		analyser.l = l
+	}
+	return analyser.configure(facts, false)
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *CodeAgeAnalysis) Initialize(repository *git.Repository) error {
+	if analyser.l == nil {
+		analyser.l = core.NewLogger()
+	}
+	return analyser.initialize(repository)
+}
+
+// Fork clones this item. The files are copied by value.
+func (analyser *CodeAgeAnalysis) Fork(n int) []core.PipelineItem {
+	result := make([]core.PipelineItem, n)
+	for i, tracker := range analyser.fork(n) {
+		clone := *analyser
+		clone.lineTracker = tracker
+		result[i] = &clone
+	}
+	return result
+}
+
+// Merge combines several items together. The file merging logic belongs to BurndownAnalysis.
+func (analyser *CodeAgeAnalysis) Merge(branches []core.PipelineItem) {
+	trackers := make([]lineTracker, len(branches))
+	for i, branch := range branches {
+		trackers[i] = branch.(*CodeAgeAnalysis).lineTracker
+	}
+	analyser.merge(trackers)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *CodeAgeAnalysis) Finalize() interface{} {
+	ages := map[int]int64{}
+	medians := map[string]float64{}
+	for path, file := range analyser.burndown.files {
+		fileAges := analyser.fileAges(file)
+		if len(fileAges) == 0 {
+			continue
+		}
+		for age, lines := range fileAges {
+			ages[age] += int64(lines)
+		}
+		medians[path] = histogramMedian(fileAges)
+	}
+	return CodeAgeResult{
+		Ages:        ages,
+		FileMedians: medians,
+		tickSize:    analyser.burndown.TickSize,
+	}
+}
+
+// fileAges returns the number of lines in `file` by their age in ticks relative to the tick
+// of the last consumed commit.
+func (analyser *CodeAgeAnalysis) fileAges(file *burndown.File) map[int]int {
+	previousLine := 0
+	previousTick := 0
+	ages := map[int]int{}
+	file.ForEach(func(line, value int) {
+		if length := line - previousLine; length > 0 {
+			ages[analyser.burndown.tick-previousTick] += length
+		}
+		previousLine = line
+		_, previousTick = analyser.burndown.unpackPersonWithTick(value)
+	})
+	return ages
+}
+
+// histogramMedian returns the median of the values which are counted in `histogram`.
+// The middle values are averaged if the total count is even.
+func histogramMedian(histogram map[int]int) float64 {
+	keys := make([]int, 0, len(histogram))
+	total := 0
+	for key, count := range histogram {
+		keys = append(keys, key)
+		total += count
+	}
+	sort.Ints(keys)
+	low, high := (total-1)/2, total/2
+	var lowValue, highValue int
+	seen := 0
+	for _, key := range keys {
+		if seen <= low {
+			lowValue = key
+		}
+		seen += histogram[key]
+		if seen > high {
+			highValue = key
+			break
+		}
+	}
+	return float64(lowValue+highValue) / 2
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *CodeAgeAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	codeAgeResult := result.(CodeAgeResult)
+	if binary {
+		return analyser.serializeBinary(&codeAgeResult, writer)
+	}
+	analyser.serializeText(&codeAgeResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to CodeAgeResult.
+func (analyser *CodeAgeAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.CodeAgeAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	ages := map[int]int64{}
+	for age, lines := range message.Ages {
+		ages[int(age)] = lines
+	}
+	medians := map[string]float64{}
+	for path, median := range message.FileMedians {
+		medians[path] = median
+	}
+	return CodeAgeResult{
+		Ages:        ages,
+		FileMedians: medians,
+		tickSize:    time.Duration(message.TickSize),
+	}, nil
+}
+
+func (analyser *CodeAgeAnalysis) serializeText(result *CodeAgeResult, writer io.Writer) {
+	ages := make([]int, 0, len(result.Ages))
+	for age := range result.Ages {
+		ages = append(ages, age)
+	}
+	sort.Ints(ages)
+	pairs := make([]string, len(ages))
+	for i, age := range ages {
+		pairs[i] = fmt.Sprintf("%d: %d", age, result.Ages[age])
+	}
+	fmt.Fprintf(writer, "  ages: {%s}\n", strings.Join(pairs, ", "))
+	fmt.Fprintln(writer, "  file_medians:")
+	paths := make([]string, 0, len(result.FileMedians))
+	for path := range result.FileMedians {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(writer, "    %s: %.1f\n", yaml.SafeString(path), result.FileMedians[path])
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (analyser *CodeAgeAnalysis) serializeBinary(result *CodeAgeResult, writer io.Writer) error {
+	message := pb.CodeAgeAnalysisResults{
+		Ages:        map[int32]int64{},
+		FileMedians: result.FileMedians,
+		TickSize:    int64(result.tickSize),
+	}
+	for age, lines := range result.Ages {
+		message.Ages[int32(age)] = lines
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this code age result.
+func (car CodeAgeResult) GetTickSize() time.Duration {
+	return car.tickSize
+}
+
+func init() {
+	core.Registry.Register(&CodeAgeAnalysis{})
+}
@@ -0,0 +1,132 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureCodeAge() *CodeAgeAnalysis {
+	ca := CodeAgeAnalysis{}
+	ca.Initialize(test.Repository)
+	return &ca
+}
+
+func TestCodeAgeMeta(t *testing.T) {
+	ca := fixtureCodeAge()
+	assert.Equal(t, ca.Name(), "CodeAge")
+	assert.Len(t, ca.Provides(), 0)
+	assert.Equal(t, (&BurndownAnalysis{}).Requires(), ca.Requires())
+	assert.Len(t, ca.ListConfigurationOptions(), 0)
+	assert.Equal(t, ca.Flag(), "code-age")
+	assert.NotEmpty(t, ca.Description())
+	logger := core.NewLogger()
+	assert.NoError(t, ca.Configure(map[string]interface{}{
+		core.ConfigLogger:         logger,
+		ConfigBurndownTrackFiles:  true,
+		ConfigBurndownTrackPeople: true,
+	}))
+	assert.Equal(t, logger, ca.l)
+	assert.False(t, ca.burndown.TrackFiles)
+	assert.Equal(t, 0, ca.burndown.PeopleNumber)
+}
+
+func TestCodeAgeRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CodeAgeAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CodeAge")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CodeAgeAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCodeAgeFork(t *testing.T) {
+	ca1 := fixtureCodeAge()
+	clones := ca1.Fork(1)
+	assert.Len(t, clones, 1)
+	ca2 := clones[0].(*CodeAgeAnalysis)
+	assert.True(t, ca1 != ca2)
+	assert.True(t, ca1.burndown != ca2.burndown)
+	ca1.Merge([]core.PipelineItem{ca2})
+	assert.NoError(t, ca1.Hibernate())
+	assert.NoError(t, ca1.Boot())
+}
+
+func TestCodeAgeHistogramMedian(t *testing.T) {
+	assert.Equal(t, 2.0, histogramMedian(map[int]int{2: 3}))
+	assert.Equal(t, 3.0, histogramMedian(map[int]int{1: 1, 5: 1}))
+	assert.Equal(t, 1.0, histogramMedian(map[int]int{0: 2, 1: 1, 7: 2}))
+	assert.Equal(t, 0.5, histogramMedian(map[int]int{0: 2, 1: 1, 7: 1}))
+}
+
+func TestCodeAgeConsumeFinalize(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "zoe", When: when, Parents: []int{}, Files: map[string]string{
+			"a/x.go": "1\n2\n3\n", "README": "r\n"}},
+		{Author: "adam", When: when.Add(time.Hour), Files: map[string]string{
+			"a/b/y.go": "1\n2\n"}},
+		{Author: "adam", When: when.Add(48 * time.Hour), Files: map[string]string{
+			"a/x.go": "1\n2\nnew\n"}},
+		{Author: "zoe", When: when.Add(49 * time.Hour), Files: map[string]string{
+			"a/b/y.go": "1\nnew\n"}},
+	})
+	assert.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	ca := pipeline.DeployItem(&CodeAgeAnalysis{}).(*CodeAgeAnalysis)
+	commits, err := pipeline.Commits(false)
+	assert.NoError(t, err)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigBurndownTrackFiles: true,
+	}))
+	results, err := pipeline.Run(commits)
+	assert.NoError(t, err)
+	// only the ticks of the lines are tracked
+	assert.Len(t, ca.burndown.globalHistory, 0)
+	assert.Len(t, ca.burndown.fileHistories, 0)
+	result := results[ca].(CodeAgeResult)
+	assert.Equal(t, map[int]int64{0: 2, 2: 4}, result.Ages)
+	assert.Equal(t, map[string]float64{"README": 2, "a/x.go": 2, "a/b/y.go": 1}, result.FileMedians)
+	assert.Equal(t, 24*time.Hour, result.GetTickSize())
+}
+
+func TestCodeAgeSerialize(t *testing.T) {
+	ca := fixtureCodeAge()
+	result := CodeAgeResult{
+		Ages:        map[int]int64{0: 2, 2: 4},
+		FileMedians: map[string]float64{"README": 2, "a/b/y.go": 1.5},
+		tickSize:    24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, ca.Serialize(result, false, buffer))
+	assert.Equal(t, `  ages: {0: 2, 2: 4}
+  file_medians:
+    "README": 2.0
+    "a/b/y.go": 1.5
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, ca.Serialize(result, true, buffer))
+	msg := pb.CodeAgeAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, map[int32]int64{0: 2, 2: 4}, msg.Ages)
+	assert.Equal(t, 1.5, msg.FileMedians["a/b/y.go"])
+	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	deserialized, err := ca.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
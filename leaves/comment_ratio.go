@@ -0,0 +1,600 @@
+package leaves
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// CommentRatioAnalysis calculates the numbers of alive comment, code and blank lines in each
+// language at the end of each tick. The lines are classified with simple per-language comment
+// syntax rules which are chosen by the file extension. It is a LeafPipelineItem.
+type CommentRatioAnalysis struct {
+	// Rules are the comment syntax rules in addition to the built-in ones. They take precedence
+	// over the built-in rules for the same extensions.
+	Rules []CommentRule
+
+	// rules maps the lower case file extensions to the effective rules.
+	rules map[string]*CommentRule
+	// files are the classified lines of the alive files. Each branch has its own copy.
+	files map[string]commentRatioFile
+	// totals are the sums of files by language.
+	totals map[string]CommentLineCounts
+	// tick is the tick of the last consumed commit.
+	tick int
+	// series accumulates the line counts in each tick. It is shared between the forks since
+	// every regular commit is consumed by a single branch.
+	series *commentRatioSeries
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// CommentRule is the comment syntax of a language. The lines which start with LineComment
+// and the lines inside BlockStart and BlockEnd are the comments. The string literals are
+// not recognized.
+type CommentRule struct {
+	// Language is the name of the language which the lines are attributed to.
+	Language string
+	// Extensions are the file extensions including the leading dot, e.g. ".go".
+	Extensions []string
+	// LineComment starts a comment which lasts until the end of the line. It may be empty.
+	LineComment string
+	// BlockStart starts a multi-line comment. It may be empty.
+	BlockStart string
+	// BlockEnd finishes a multi-line comment. It is empty if BlockStart is empty.
+	BlockEnd string
+}
+
+// CommentLineCounts are the numbers of comment, code and blank lines.
+type CommentLineCounts struct {
+	Comment int64
+	Code    int64
+	Blank   int64
+}
+
+// commentRatioFile is the language and the classified lines of a single file.
+type commentRatioFile struct {
+	language string
+	counts   CommentLineCounts
+}
+
+// commentRatioSeries are the line counts of each language in each tick.
+type commentRatioSeries struct {
+	// deltas are the numbers of added minus the numbers of removed lines of each language
+	// in each tick.
+	deltas map[string][]CommentLineCounts
+	// totals are the sums of deltas by language.
+	totals map[string]CommentLineCounts
+	// ticks is the number of ticks seen so far.
+	ticks int
+}
+
+// CommentRatioResult is returned by CommentRatioAnalysis.Finalize() and carries the numbers
+// of alive comment, code and blank lines of each language in each tick.
+type CommentRatioResult struct {
+	// Languages maps the language names to the line counts at the end of each tick.
+	Languages map[string][]CommentLineCounts
+
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+const (
+	// ConfigCommentRatioRules is the name of the option to set CommentRatioAnalysis.Rules.
+	ConfigCommentRatioRules = "CommentRatio.Rules"
+)
+
+// defaultCommentRules are the built-in rules of CommentRatioAnalysis, see parseCommentRule().
+var defaultCommentRules = []string{
+	"C:.c .h:// /* */",
+	"C++:.cc .cpp .cxx .hh .hpp .hxx:// /* */",
+	"C#:.cs:// /* */",
+	"CSS:.css:/* */",
+	"Go:.go:// /* */",
+	"HTML:.html .htm:<!-- -->",
+	"Haskell:.hs:-- {- -}",
+	"Java:.java:// /* */",
+	"JavaScript:.js .jsx .mjs:// /* */",
+	"Kotlin:.kt .kts:// /* */",
+	"Lua:.lua:--",
+	"PHP:.php:// /* */",
+	"Perl:.pl .pm:#",
+	"Python:.py:#",
+	"R:.r:#",
+	"Ruby:.rb:#",
+	"Rust:.rs:// /* */",
+	"SQL:.sql:-- /* */",
+	"Scala:.scala:// /* */",
+	"Shell:.sh .bash .zsh:#",
+	"Swift:.swift:// /* */",
+	"TypeScript:.ts .tsx:// /* */",
+	"XML:.xml:<!-- -->",
+	"YAML:.yml .yaml:#",
+}
+
+// parseCommentRule converts "<language>:<extensions>:<tokens>" to CommentRule. The extensions
+// and the tokens are separated with spaces. The tokens are either the line comment start,
+// or the block comment start and end, or all three, e.g. "Go:.go:// /* */".
+func parseCommentRule(text string) (CommentRule, error) {
+	parts := strings.SplitN(text, ":", 3)
+	if len(parts) != 3 {
+		return CommentRule{}, fmt.Errorf("invalid comment rule %q: expected "+
+			"<language>:<extensions>:<tokens>", text)
+	}
+	rule := CommentRule{
+		Language:   strings.TrimSpace(parts[0]),
+		Extensions: strings.Fields(parts[1]),
+	}
+	if rule.Language == "" || len(rule.Extensions) == 0 {
+		return CommentRule{}, fmt.Errorf("invalid comment rule %q: the language and the "+
+			"extensions must not be empty", text)
+	}
+	for i, ext := range rule.Extensions {
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		rule.Extensions[i] = strings.ToLower(ext)
+	}
+	tokens := strings.Fields(parts[2])
+	switch len(tokens) {
+	case 1:
+		rule.LineComment = tokens[0]
+	case 2:
+		rule.BlockStart, rule.BlockEnd = tokens[0], tokens[1]
+	case 3:
+		rule.LineComment, rule.BlockStart, rule.BlockEnd = tokens[0], tokens[1], tokens[2]
+	default:
+		return CommentRule{}, fmt.Errorf("invalid comment rule %q: expected 1, 2 or 3 "+
+			"comment tokens", text)
+	}
+	return rule, nil
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ratio *CommentRatioAnalysis) Name() string {
+	return "CommentRatio"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (ratio *CommentRatioAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (ratio *CommentRatioAnalysis) Requires() []string {
+	return []string{items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ratio *CommentRatioAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{{
+		Name: ConfigCommentRatioRules,
+		Description: "Additional comment syntax rules in the format " +
+			"\"<language>:<extensions>:<tokens>\", e.g. \"Go:.go:// /* */\". The tokens are " +
+			"the line comment start, or the block comment start and end, or all three. " +
+			"They override the built-in rules for the same extensions. Separated with commas \",\".",
+		Flag:    "comment-ratio-rules",
+		Type:    core.StringsConfigurationOption,
+		Default: []string{}},
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ratio *CommentRatioAnalysis) Flag() string {
+	return "comment-ratio"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (ratio *CommentRatioAnalysis) Description() string {
+	return "Calculates the numbers of alive comment, code and blank lines in each language " +
+		"at the end of each tick. The languages are recognized by the file extensions."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ratio *CommentRatioAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		ratio.l = l
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		ratio.tickSize = val
+	}
+	if val, exists := facts[ConfigCommentRatioRules].([]string); exists {
+		ratio.Rules = nil
+		for _, text := range val {
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			rule, err := parseCommentRule(text)
+			if err != nil {
+				return err
+			}
+			ratio.Rules = append(ratio.Rules, rule)
+		}
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ratio *CommentRatioAnalysis) Initialize(repository *git.Repository) error {
+	if ratio.l == nil {
+		ratio.l = core.NewLogger()
+	}
+	ratio.files = map[string]commentRatioFile{}
+	ratio.totals = map[string]CommentLineCounts{}
+	ratio.tick = 0
+	ratio.series = &commentRatioSeries{
+		deltas: map[string][]CommentLineCounts{},
+		totals: map[string]CommentLineCounts{},
+	}
+	ratio.rules = map[string]*CommentRule{}
+	for _, text := range defaultCommentRules {
+		rule, err := parseCommentRule(text)
+		if err != nil {
+			panic(err)
+		}
+		ratio.addRule(rule)
+	}
+	for _, rule := range ratio.Rules {
+		ratio.addRule(rule)
+	}
+	if ratio.tickSize == 0 {
+		ratio.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	return nil
+}
+
+func (ratio *CommentRatioAnalysis) addRule(rule CommentRule) {
+	for _, ext := range rule.Extensions {
+		ratio.rules[strings.ToLower(ext)] = &rule
+	}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ratio *CommentRatioAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	tick := deps[items.DependencyTick].(int)
+	ratio.tick = tick
+	// the series last until the last commit even if it did not change anything
+	if ratio.series.ticks <= tick {
+		ratio.series.ticks = tick + 1
+	}
+	// the diff of a merge commit repeats the lines which were written in the merged branch,
+	// so it only updates the files, and Merge() records the lines changed by the merge itself
+	record := !deps[core.DependencyIsMerge].(bool)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*items.CachedBlob)
+	for _, change := range treeDiff {
+		if change.From.Name != "" {
+			ratio.remove(change.From.Name, record)
+		}
+		if change.To.Name != "" {
+			ratio.add(change.To, cache, record)
+		}
+	}
+	return nil, nil
+}
+
+// add classifies the lines of the file and adds them to the totals and, if `record` is true,
+// to the series in the current tick. The files without a rule and the binary files are ignored.
+func (ratio *CommentRatioAnalysis) add(
+	entry object.ChangeEntry, cache map[plumbing.Hash]*items.CachedBlob, record bool) {
+	rule := ratio.rules[strings.ToLower(path.Ext(entry.Name))]
+	if rule == nil {
+		return
+	}
+	blob := cache[entry.TreeEntry.Hash]
+	if blob == nil {
+		return
+	}
+	if _, err := blob.CountLines(); err != nil {
+		return
+	}
+	file := commentRatioFile{language: rule.Language, counts: rule.Classify(blob.Data)}
+	ratio.files[entry.Name] = file
+	ratio.totals[file.language] = ratio.totals[file.language].plus(file.counts, 1)
+	if record {
+		ratio.series.record(file.language, ratio.tick, file.counts)
+	}
+}
+
+// remove subtracts the lines of the file from the totals and, if `record` is true, from
+// the series in the current tick.
+func (ratio *CommentRatioAnalysis) remove(name string, record bool) {
+	file, exists := ratio.files[name]
+	if !exists {
+		return
+	}
+	delete(ratio.files, name)
+	ratio.totals[file.language] = ratio.totals[file.language].plus(file.counts, -1)
+	if record {
+		ratio.series.record(file.language, ratio.tick, CommentLineCounts{}.plus(file.counts, -1))
+	}
+}
+
+// record adds `delta` to the line counts of the language in the tick.
+func (series *commentRatioSeries) record(language string, tick int, delta CommentLineCounts) {
+	if delta == (CommentLineCounts{}) {
+		return
+	}
+	deltas := series.deltas[language]
+	for len(deltas) <= tick {
+		deltas = append(deltas, CommentLineCounts{})
+	}
+	deltas[tick] = deltas[tick].plus(delta, 1)
+	series.deltas[language] = deltas
+	series.totals[language] = series.totals[language].plus(delta, 1)
+}
+
+// plus returns the sum of the counts and the other counts multiplied by `sign`.
+func (counts CommentLineCounts) plus(other CommentLineCounts, sign int64) CommentLineCounts {
+	return CommentLineCounts{
+		Comment: counts.Comment + sign*other.Comment,
+		Code:    counts.Code + sign*other.Code,
+		Blank:   counts.Blank + sign*other.Blank,
+	}
+}
+
+// Classify counts the comment, code and blank lines in the text. The lines which contain
+// both code and comments are code.
+func (rule *CommentRule) Classify(data []byte) CommentLineCounts {
+	var counts CommentLineCounts
+	if len(data) == 0 {
+		return counts
+	}
+	lines := bytes.Split(data, []byte{'\n'})
+	if len(lines[len(lines)-1]) == 0 {
+		// the trailing newline does not start a new line
+		lines = lines[:len(lines)-1]
+	}
+	inBlock := false
+	for _, line := range lines {
+		text := strings.TrimSpace(string(line))
+		if text == "" {
+			if inBlock {
+				counts.Comment++
+			} else {
+				counts.Blank++
+			}
+			continue
+		}
+		var code bool
+		code, inBlock = rule.scan(text, inBlock)
+		if code {
+			counts.Code++
+		} else {
+			counts.Comment++
+		}
+	}
+	return counts
+}
+
+// scan returns whether the trimmed non-empty line contains code and whether it ends inside
+// a block comment.
+func (rule *CommentRule) scan(text string, inBlock bool) (code bool, stillInBlock bool) {
+	for text != "" {
+		if inBlock {
+			end := strings.Index(text, rule.BlockEnd)
+			if end < 0 {
+				return code, true
+			}
+			text = strings.TrimSpace(text[end+len(rule.BlockEnd):])
+			inBlock = false
+			continue
+		}
+		if rule.LineComment != "" && strings.HasPrefix(text, rule.LineComment) {
+			return code, false
+		}
+		if rule.BlockStart != "" && strings.HasPrefix(text, rule.BlockStart) {
+			text = text[len(rule.BlockStart):]
+			inBlock = true
+			continue
+		}
+		code = true
+		// find the next comment after the code
+		next := -1
+		if rule.LineComment != "" {
+			next = strings.Index(text, rule.LineComment)
+		}
+		if rule.BlockStart != "" {
+			if start := strings.Index(text, rule.BlockStart); start >= 0 && (next < 0 || start < next) {
+				next = start
+			}
+		}
+		if next < 0 {
+			return code, false
+		}
+		text = text[next:]
+	}
+	return code, inBlock
+}
+
+// Ratio returns the number of comment lines divided by the number of code lines,
+// 0 if there is no code.
+func (counts CommentLineCounts) Ratio() float64 {
+	if counts.Code <= 0 {
+		return 0
+	}
+	return float64(counts.Comment) / float64(counts.Code)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ratio *CommentRatioAnalysis) Finalize() interface{} {
+	result := CommentRatioResult{
+		Languages: map[string][]CommentLineCounts{},
+		tickSize:  ratio.tickSize,
+	}
+	for lang, deltas := range ratio.series.deltas {
+		series := make([]CommentLineCounts, ratio.series.ticks)
+		var sum CommentLineCounts
+		for tick := range series {
+			if tick < len(deltas) {
+				sum = sum.plus(deltas[tick], 1)
+			}
+			series[tick] = sum
+		}
+		result.Languages[lang] = series
+	}
+	return result
+}
+
+// Fork clones this PipelineItem. The files are copied by value, the series are shared.
+func (ratio *CommentRatioAnalysis) Fork(n int) []core.PipelineItem {
+	result := make([]core.PipelineItem, n)
+	for i := range result {
+		clone := *ratio
+		clone.files = make(map[string]commentRatioFile, len(ratio.files))
+		for name, file := range ratio.files {
+			clone.files[name] = file
+		}
+		clone.totals = make(map[string]CommentLineCounts, len(ratio.totals))
+		for lang, counts := range ratio.totals {
+			clone.totals[lang] = counts
+		}
+		result[i] = &clone
+	}
+	return result
+}
+
+// Merge records the lines which were changed by the merge commit itself, e.g. the conflict
+// resolutions. Every branch has consumed the merge commit, so they have the same files,
+// and the difference between their totals and the series is exactly those lines.
+func (ratio *CommentRatioAnalysis) Merge(branches []core.PipelineItem) {
+	for lang, counts := range ratio.totals {
+		ratio.series.record(lang, ratio.tick, counts.plus(ratio.series.totals[lang], -1))
+	}
+	for lang, counts := range ratio.series.totals {
+		if _, exists := ratio.totals[lang]; !exists {
+			ratio.series.record(lang, ratio.tick, CommentLineCounts{}.plus(counts, -1))
+		}
+	}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ratio *CommentRatioAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	ratioResult := result.(CommentRatioResult)
+	if binary {
+		return ratio.serializeBinary(&ratioResult, writer)
+	}
+	ratio.serializeText(&ratioResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to CommentRatioResult.
+func (ratio *CommentRatioAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.CommentRatioAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := CommentRatioResult{
+		Languages: map[string][]CommentLineCounts{},
+		tickSize:  time.Duration(message.TickSize),
+	}
+	for lang, series := range message.Languages {
+		if len(series.Code) != len(series.Comment) || len(series.Blank) != len(series.Comment) {
+			return nil, fmt.Errorf("%s: the lengths of the series do not match: %d, %d, %d",
+				lang, len(series.Comment), len(series.Code), len(series.Blank))
+		}
+		counts := make([]CommentLineCounts, len(series.Comment))
+		for i := range counts {
+			counts[i] = CommentLineCounts{
+				Comment: series.Comment[i],
+				Code:    series.Code[i],
+				Blank:   series.Blank[i],
+			}
+		}
+		result.Languages[lang] = counts
+	}
+	return result, nil
+}
+
+func (ratio *CommentRatioAnalysis) serializeText(result *CommentRatioResult, writer io.Writer) {
+	writeSeries := func(key string, series []CommentLineCounts, value func(CommentLineCounts) string) {
+		fmt.Fprintf(writer, "      %s: [", key)
+		for i, counts := range series {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprint(writer, value(counts))
+		}
+		fmt.Fprintln(writer, "]")
+	}
+	langs := make([]string, 0, len(result.Languages))
+	for lang := range result.Languages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	fmt.Fprintln(writer, "  languages:")
+	for _, lang := range langs {
+		series := result.Languages[lang]
+		fmt.Fprintf(writer, "    %s:\n", yaml.SafeString(lang))
+		writeSeries("comment", series, func(c CommentLineCounts) string { return fmt.Sprint(c.Comment) })
+		writeSeries("code", series, func(c CommentLineCounts) string { return fmt.Sprint(c.Code) })
+		writeSeries("blank", series, func(c CommentLineCounts) string { return fmt.Sprint(c.Blank) })
+		writeSeries("ratios", series, func(c CommentLineCounts) string {
+			return fmt.Sprintf("%.3f", c.Ratio())
+		})
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (ratio *CommentRatioAnalysis) serializeBinary(result *CommentRatioResult, writer io.Writer) error {
+	message := pb.CommentRatioAnalysisResults{
+		Languages: map[string]*pb.CommentRatioSeries{},
+		TickSize:  int64(result.tickSize),
+	}
+	for lang, counts := range result.Languages {
+		series := &pb.CommentRatioSeries{
+			Comment: make([]int64, len(counts)),
+			Code:    make([]int64, len(counts)),
+			Blank:   make([]int64, len(counts)),
+			Ratios:  make([]float64, len(counts)),
+		}
+		for i, c := range counts {
+			series.Comment[i] = c.Comment
+			series.Code[i] = c.Code
+			series.Blank[i] = c.Blank
+			series.Ratios[i] = c.Ratio()
+		}
+		message.Languages[lang] = series
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this comment ratio result.
+func (crr CommentRatioResult) GetTickSize() time.Duration {
+	return crr.tickSize
+}
+
+func init() {
+	core.Registry.Register(&CommentRatioAnalysis{})
+}
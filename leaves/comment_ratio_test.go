@@ -0,0 +1,238 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureCommentRatio() *CommentRatioAnalysis {
+	ratio := CommentRatioAnalysis{}
+	ratio.Initialize(test.Repository)
+	return &ratio
+}
+
+func TestCommentRatioMeta(t *testing.T) {
+	ratio := fixtureCommentRatio()
+	assert.Equal(t, ratio.Name(), "CommentRatio")
+	assert.Len(t, ratio.Provides(), 0)
+	assert.Equal(t, []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyTick},
+		ratio.Requires())
+	opts := ratio.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigCommentRatioRules)
+	assert.Equal(t, ratio.Flag(), "comment-ratio")
+	assert.NotEmpty(t, ratio.Description())
+	assert.Equal(t, 24*time.Hour, ratio.tickSize)
+	assert.Equal(t, "Go", ratio.rules[".go"].Language)
+	logger := core.NewLogger()
+	assert.NoError(t, ratio.Configure(map[string]interface{}{
+		core.ConfigLogger:       logger,
+		items.FactTickSize:      time.Hour,
+		ConfigCommentRatioRules: []string{"Nim:.nim:# #[ ]#", " "},
+	}))
+	assert.Equal(t, logger, ratio.l)
+	assert.Equal(t, time.Hour, ratio.tickSize)
+	assert.Equal(t, []CommentRule{{
+		Language: "Nim", Extensions: []string{".nim"},
+		LineComment: "#", BlockStart: "#[", BlockEnd: "]#"}}, ratio.Rules)
+	assert.EqualError(t, ratio.Configure(map[string]interface{}{
+		ConfigCommentRatioRules: []string{"Nim:.nim"},
+	}), `invalid comment rule "Nim:.nim": expected <language>:<extensions>:<tokens>`)
+}
+
+func TestCommentRatioRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CommentRatioAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CommentRatio")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CommentRatioAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCommentRatioFork(t *testing.T) {
+	ratio1 := fixtureCommentRatio()
+	clones := ratio1.Fork(1)
+	assert.Len(t, clones, 1)
+	ratio2 := clones[0].(*CommentRatioAnalysis)
+	assert.True(t, ratio1 != ratio2)
+	assert.True(t, ratio1.series == ratio2.series)
+	ratio2.files["a.go"] = commentRatioFile{language: "Go", counts: CommentLineCounts{Code: 1}}
+	assert.Len(t, ratio1.files, 0)
+	ratio1.Merge([]core.PipelineItem{ratio2})
+}
+
+func TestParseCommentRule(t *testing.T) {
+	rule, err := parseCommentRule("Python:py .PYI:#")
+	assert.NoError(t, err)
+	assert.Equal(t, CommentRule{
+		Language: "Python", Extensions: []string{".py", ".pyi"}, LineComment: "#"}, rule)
+	rule, err = parseCommentRule("HTML:.html:<!-- -->")
+	assert.NoError(t, err)
+	assert.Equal(t, CommentRule{
+		Language: "HTML", Extensions: []string{".html"}, BlockStart: "<!--", BlockEnd: "-->"}, rule)
+	_, err = parseCommentRule(":.go://")
+	assert.Error(t, err)
+	_, err = parseCommentRule("Go::://")
+	assert.Error(t, err)
+	_, err = parseCommentRule("Go:.go:")
+	assert.Error(t, err)
+	_, err = parseCommentRule("Go:.go:a b c d")
+	assert.Error(t, err)
+	for _, text := range defaultCommentRules {
+		_, err = parseCommentRule(text)
+		assert.NoError(t, err, text)
+	}
+}
+
+func TestCommentRuleClassify(t *testing.T) {
+	rule := CommentRule{LineComment: "//", BlockStart: "/*", BlockEnd: "*/"}
+	assert.Equal(t, CommentLineCounts{}, rule.Classify(nil))
+	assert.Equal(t, CommentLineCounts{Comment: 6, Code: 6, Blank: 1}, rule.Classify([]byte(
+		`// Package main
+package main
+
+/* one
+   two
+
+*/
+func main() { // call
+	x := 1 /* start
+	end */ y := 2
+	/* a */ /* b */
+	/* a */ z := 3
+}`)))
+	rule = CommentRule{LineComment: "#"}
+	assert.Equal(t, CommentLineCounts{Comment: 1, Code: 1, Blank: 1}, rule.Classify(
+		[]byte("  # comment\r\n\r\nx = 1\n")))
+}
+
+func bakeCommentRatio(t *testing.T) (*CommentRatioAnalysis, interface{}) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"main.go": "// main\npackage main\n\nfunc main() {}\n", "app.py": "# app\nx = 1\n",
+			"README": "r\n"}},
+		{Author: "one", When: when.Add(24 * time.Hour), Files: map[string]string{
+			"main.go": "package main\n\nfunc main() {}\n"}},
+		{Author: "two", When: when.Add(72 * time.Hour), Deleted: []string{"app.py"},
+			Files: map[string]string{"util.go": "/* util\n*/\npackage main\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	ratio := pipeline.DeployItem(&CommentRatioAnalysis{}).(*CommentRatioAnalysis)
+	commits, err := pipeline.Commits(false)
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	results, err := pipeline.Run(commits)
+	require.NoError(t, err)
+	return ratio, results[ratio]
+}
+
+func TestCommentRatioConsumeFinalize(t *testing.T) {
+	_, result := bakeCommentRatio(t)
+	ratioResult := result.(CommentRatioResult)
+	assert.Equal(t, map[string][]CommentLineCounts{
+		"Go": {
+			{Comment: 1, Code: 2, Blank: 1},
+			{Comment: 0, Code: 2, Blank: 1},
+			{Comment: 0, Code: 2, Blank: 1},
+			{Comment: 2, Code: 3, Blank: 1},
+		},
+		"Python": {
+			{Comment: 1, Code: 1},
+			{Comment: 1, Code: 1},
+			{Comment: 1, Code: 1},
+			{},
+		},
+	}, ratioResult.Languages)
+	assert.Equal(t, 24*time.Hour, ratioResult.GetTickSize())
+	assert.Equal(t, 0.5, ratioResult.Languages["Go"][0].Ratio())
+	assert.Equal(t, 0.0, ratioResult.Languages["Python"][3].Ratio())
+	empty := fixtureCommentRatio().Finalize().(CommentRatioResult)
+	assert.Len(t, empty.Languages, 0)
+}
+
+func TestCommentRatioConsumeMerge(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"a.go": "// a\nvar a = 1\n"}},
+		{Author: "one", When: when.Add(day), Files: map[string]string{
+			"a.go": "// a\nvar a = 2\n", "b.go": "var b = 1\n"}},
+		{Author: "two", When: when.Add(2 * day), Parents: []int{0}, Files: map[string]string{
+			"a.go": "// a\nvar a = 3\n", "c.go": "// c\n"}},
+		// the conflict resolution adds a comment and a blank line
+		{Author: "one", When: when.Add(4 * day), Parents: []int{1, 2}, Files: map[string]string{
+			"a.go": "// a\n// resolved\nvar a = 4\n\n", "b.go": "var b = 1\n", "c.go": "// c\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	ratio := pipeline.DeployItem(&CommentRatioAnalysis{}).(*CommentRatioAnalysis)
+	commits, err := pipeline.Commits(false)
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	results, err := pipeline.Run(commits)
+	require.NoError(t, err)
+	assert.Equal(t, []CommentLineCounts{
+		{Comment: 1, Code: 1},
+		{Comment: 1, Code: 2},
+		{Comment: 2, Code: 2},
+		{Comment: 2, Code: 2},
+		// a.go, b.go and c.go as of the merge commit
+		{Comment: 3, Code: 2, Blank: 1},
+	}, results[ratio].(CommentRatioResult).Languages["Go"])
+}
+
+func TestCommentRatioSerialize(t *testing.T) {
+	ratio, result := bakeCommentRatio(t)
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, ratio.Serialize(result, false, buffer))
+	assert.Equal(t, `  languages:
+    "Go":
+      comment: [1, 0, 0, 2]
+      code: [2, 2, 2, 3]
+      blank: [1, 1, 1, 1]
+      ratios: [0.500, 0.000, 0.000, 0.667]
+    "Python":
+      comment: [1, 1, 1, 0]
+      code: [1, 1, 1, 0]
+      blank: [0, 0, 0, 0]
+      ratios: [1.000, 1.000, 1.000, 0.000]
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, ratio.Serialize(result, true, buffer))
+	msg := pb.CommentRatioAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []int64{1, 0, 0, 2}, msg.Languages["Go"].Comment)
+	assert.Equal(t, []float64{1, 1, 1, 0}, msg.Languages["Python"].Ratios)
+	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	deserialized, err := ratio.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+
+	msg.Languages["Go"].Blank = msg.Languages["Go"].Blank[:2]
+	serialized, err := proto.Marshal(&msg)
+	assert.NoError(t, err)
+	_, err = ratio.Deserialize(serialized)
+	assert.Error(t, err)
+}
@@ -0,0 +1,315 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+)
+
+// CommitSizeAnalysis calculates the distribution of the commit sizes through time. The size
+// of a commit is the number of inserted plus the number of deleted lines. It is a LeafPipelineItem.
+type CommitSizeAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+
+	// ticks maps ticks to the number of commits in each size class.
+	ticks map[int][]int64
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// CommitSizeResult is returned by CommitSizeAnalysis.Finalize() and carries the number of commits
+// in each size class per tick.
+type CommitSizeResult struct {
+	// Bounds are the inclusive upper limits of the size classes except the last one,
+	// which is unbounded. See CommitSizeClassBounds.
+	Bounds []int
+	// Ticks maps ticks to the number of commits in each size class. The length of each
+	// slice is len(Bounds) + 1.
+	Ticks map[int][]int64
+
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+// CommitSizeClassBounds are the inclusive upper limits of the commit size classes:
+// 0-10, 11-100, 101-1000 and >1000 changed lines.
+var CommitSizeClassBounds = []int{10, 100, 1000}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (sizes *CommitSizeAnalysis) Name() string {
+	return "CommitSizes"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (sizes *CommitSizeAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (sizes *CommitSizeAnalysis) Requires() []string {
+	return []string{
+		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (sizes *CommitSizeAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (sizes *CommitSizeAnalysis) Flag() string {
+	return "commit-sizes"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (sizes *CommitSizeAnalysis) Description() string {
+	return "Counts the commits in each size class (0-10, 11-100, 101-1000 and >1000 " +
+		"inserted plus deleted lines) through time. Merge commits are counted once."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (sizes *CommitSizeAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		sizes.l = l
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		sizes.tickSize = val
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (sizes *CommitSizeAnalysis) Initialize(repository *git.Repository) error {
+	if sizes.l == nil {
+		sizes.l = core.NewLogger()
+	}
+	sizes.ticks = map[int][]int64{}
+	sizes.OneShotMergeProcessor.Initialize()
+	if sizes.tickSize == 0 {
+		sizes.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (sizes *CommitSizeAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !sizes.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*items.CachedBlob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	tick := deps[items.DependencyTick].(int)
+	size := 0
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			if lines, err := cache[change.To.TreeEntry.Hash].CountLines(); err == nil {
+				size += lines
+			}
+		case merkletrie.Delete:
+			if lines, err := cache[change.From.TreeEntry.Hash].CountLines(); err == nil {
+				size += lines
+			}
+		case merkletrie.Modify:
+			// binary files are not in fileDiffs
+			for _, edit := range fileDiffs[change.To.Name].Diffs {
+				if edit.Type != diffmatchpatch.DiffEqual {
+					// each rune is a line
+					size += utf8.RuneCountInString(edit.Text)
+				}
+			}
+		}
+	}
+	counts := sizes.ticks[tick]
+	if counts == nil {
+		counts = make([]int64, len(CommitSizeClassBounds)+1)
+		sizes.ticks[tick] = counts
+	}
+	counts[commitSizeClass(size, CommitSizeClassBounds)]++
+	return nil, nil
+}
+
+// commitSizeClass returns the index of the size class which `size` belongs to.
+func commitSizeClass(size int, bounds []int) int {
+	return sort.SearchInts(bounds, size)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (sizes *CommitSizeAnalysis) Finalize() interface{} {
+	return CommitSizeResult{
+		Bounds:   append([]int{}, CommitSizeClassBounds...),
+		Ticks:    sizes.ticks,
+		tickSize: sizes.tickSize,
+	}
+}
+
+// Fork clones this PipelineItem.
+func (sizes *CommitSizeAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(sizes, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (sizes *CommitSizeAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	sizesResult := result.(CommitSizeResult)
+	if binary {
+		return sizes.serializeBinary(&sizesResult, writer)
+	}
+	sizes.serializeText(&sizesResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to CommitSizeResult.
+func (sizes *CommitSizeAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.CommitSizeAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := CommitSizeResult{
+		Bounds:   make([]int, len(message.Bounds)),
+		Ticks:    map[int][]int64{},
+		tickSize: time.Duration(message.TickSize),
+	}
+	for i, bound := range message.Bounds {
+		result.Bounds[i] = int(bound)
+	}
+	for tick, counts := range message.Ticks {
+		if len(counts.Counts) != len(result.Bounds)+1 {
+			return nil, fmt.Errorf("tick %d: %d size classes while %d are expected",
+				tick, len(counts.Counts), len(result.Bounds)+1)
+		}
+		result.Ticks[int(tick)] = counts.Counts
+	}
+	return result, nil
+}
+
+// MergeResults combines two CommitSizeResult-s together.
+func (sizes *CommitSizeAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	cr1 := r1.(CommitSizeResult)
+	cr2 := r2.(CommitSizeResult)
+	if cr1.tickSize != cr2.tickSize {
+		return fmt.Errorf("mismatching tick sizes (r1: %v, r2: %v) received",
+			cr1.tickSize, cr2.tickSize)
+	}
+	if fmt.Sprint(cr1.Bounds) != fmt.Sprint(cr2.Bounds) {
+		return fmt.Errorf("mismatching size classes (r1: %v, r2: %v) received",
+			cr1.Bounds, cr2.Bounds)
+	}
+	t01 := items.FloorTime(c1.BeginTimeAsTime(), cr1.tickSize)
+	t02 := items.FloorTime(c2.BeginTimeAsTime(), cr2.tickSize)
+	t0 := t01
+	if t02.Before(t0) {
+		t0 = t02
+	}
+	offset1 := int(t01.Sub(t0) / cr1.tickSize)
+	offset2 := int(t02.Sub(t0) / cr2.tickSize)
+
+	merged := CommitSizeResult{
+		Bounds:   cr1.Bounds,
+		Ticks:    map[int][]int64{},
+		tickSize: cr1.tickSize,
+	}
+	for _, pair := range []struct {
+		ticks  map[int][]int64
+		offset int
+	}{{cr1.Ticks, offset1}, {cr2.Ticks, offset2}} {
+		for tick, counts := range pair.ticks {
+			tick += pair.offset
+			newCounts := merged.Ticks[tick]
+			if newCounts == nil {
+				newCounts = make([]int64, len(merged.Bounds)+1)
+				merged.Ticks[tick] = newCounts
+			}
+			for i, count := range counts {
+				newCounts[i] += count
+			}
+		}
+	}
+	return merged
+}
+
+func (sizes *CommitSizeAnalysis) serializeText(result *CommitSizeResult, writer io.Writer) {
+	classes := make([]string, len(result.Bounds)+1)
+	lower := 0
+	for i, bound := range result.Bounds {
+		classes[i] = fmt.Sprintf("\"%d-%d\"", lower, bound)
+		lower = bound + 1
+	}
+	classes[len(result.Bounds)] = fmt.Sprintf("\">%d\"", lower-1)
+	fmt.Fprintf(writer, "  classes: [%s]\n", strings.Join(classes, ", "))
+	fmt.Fprintln(writer, "  ticks:")
+	ticks := make([]int, 0, len(result.Ticks))
+	for tick := range result.Ticks {
+		ticks = append(ticks, tick)
+	}
+	sort.Ints(ticks)
+	for _, tick := range ticks {
+		counts := make([]string, len(result.Ticks[tick]))
+		for i, count := range result.Ticks[tick] {
+			counts[i] = fmt.Sprint(count)
+		}
+		fmt.Fprintf(writer, "    %d: [%s]\n", tick, strings.Join(counts, ", "))
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (sizes *CommitSizeAnalysis) serializeBinary(result *CommitSizeResult, writer io.Writer) error {
+	message := pb.CommitSizeAnalysisResults{
+		Bounds:   make([]int32, len(result.Bounds)),
+		Ticks:    map[int32]*pb.CommitSizeTick{},
+		TickSize: int64(result.tickSize),
+	}
+	for i, bound := range result.Bounds {
+		message.Bounds[i] = int32(bound)
+	}
+	for tick, counts := range result.Ticks {
+		message.Ticks[int32(tick)] = &pb.CommitSizeTick{Counts: counts}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this commit sizes result.
+func (csr CommitSizeResult) GetTickSize() time.Duration {
+	return csr.tickSize
+}
+
+func init() {
+	core.Registry.Register(&CommitSizeAnalysis{})
+}
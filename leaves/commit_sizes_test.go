@@ -0,0 +1,191 @@
+package leaves
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureCommitSizes() *CommitSizeAnalysis {
+	sizes := CommitSizeAnalysis{}
+	sizes.Initialize(test.Repository)
+	return &sizes
+}
+
+func TestCommitSizesMeta(t *testing.T) {
+	sizes := fixtureCommitSizes()
+	assert.Equal(t, sizes.Name(), "CommitSizes")
+	assert.Len(t, sizes.Provides(), 0)
+	assert.Equal(t, []string{
+		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyTick}, sizes.Requires())
+	assert.Len(t, sizes.ListConfigurationOptions(), 0)
+	assert.Equal(t, sizes.Flag(), "commit-sizes")
+	assert.Equal(t, 24*time.Hour, sizes.tickSize)
+	logger := core.NewLogger()
+	assert.NoError(t, sizes.Configure(map[string]interface{}{
+		core.ConfigLogger:  logger,
+		items.FactTickSize: time.Hour,
+	}))
+	assert.Equal(t, logger, sizes.l)
+	assert.Equal(t, time.Hour, sizes.tickSize)
+}
+
+func TestCommitSizesRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CommitSizeAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CommitSizes")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CommitSizeAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCommitSizesFork(t *testing.T) {
+	sizes1 := fixtureCommitSizes()
+	clones := sizes1.Fork(1)
+	assert.Len(t, clones, 1)
+	sizes2 := clones[0].(*CommitSizeAnalysis)
+	assert.True(t, sizes1 == sizes2)
+	sizes1.Merge([]core.PipelineItem{sizes2})
+}
+
+func TestCommitSizeClass(t *testing.T) {
+	for size, class := range map[int]int{0: 0, 10: 0, 11: 1, 100: 1, 101: 2, 1000: 2, 1001: 3} {
+		assert.Equal(t, class, commitSizeClass(size, CommitSizeClassBounds), size)
+	}
+}
+
+func bakeCommitSizes(t *testing.T) *CommitSizeAnalysis {
+	sizes := fixtureCommitSizes()
+	smallHash := plumbing.NewHash("1111111111111111111111111111111111111111")
+	bigHash := plumbing.NewHash("2222222222222222222222222222222222222222")
+	binaryHash := plumbing.NewHash("3333333333333333333333333333333333333333")
+	cache := map[plumbing.Hash]*items.CachedBlob{
+		smallHash:  {Data: []byte("text\n")},
+		bigHash:    {Data: []byte(strings.Repeat("text\n", 200))},
+		binaryHash: {Data: []byte("\x00\x01\x02")},
+	}
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: hash}}
+	}
+	consume := func(tick int, merge bool, fileDiffs map[string]items.FileDiffData,
+		changes ...*object.Change) {
+		if fileDiffs == nil {
+			fileDiffs = map[string]items.FileDiffData{}
+		}
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("4444444444444444444444444444444444444444")
+			commit.ParentHashes = []plumbing.Hash{smallHash, bigHash}
+		}
+		res, err := sizes.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			items.DependencyTreeChanges: object.Changes(changes),
+			items.DependencyBlobCache:   cache,
+			items.DependencyFileDiff:    fileDiffs,
+			items.DependencyTick:        tick,
+		})
+		assert.Nil(t, res)
+		assert.NoError(t, err)
+	}
+	// 1 + 200 lines, the binary file is ignored
+	consume(0, false, nil,
+		&object.Change{To: entry("README.md", smallHash)},
+		&object.Change{To: entry("main.go", bigHash)},
+		&object.Change{To: entry("logo.png", binaryHash)})
+	// 3 inserted + 2 deleted lines
+	consume(0, false, map[string]items.FileDiffData{"main.go": {Diffs: []diffmatchpatch.Diff{
+		{Type: diffmatchpatch.DiffEqual, Text: "abc"},
+		{Type: diffmatchpatch.DiffDelete, Text: "de"},
+		{Type: diffmatchpatch.DiffInsert, Text: "fgh"},
+	}}}, &object.Change{From: entry("main.go", bigHash), To: entry("main.go", smallHash)})
+	consume(2, false, nil, &object.Change{From: entry("README.md", smallHash)})
+	// the merge commit is consumed once per merged branch but counted once
+	consume(2, true, nil, &object.Change{To: entry("big.go", bigHash)})
+	consume(2, true, nil, &object.Change{To: entry("big.go", bigHash)})
+	// empty commit
+	consume(3, false, nil)
+	return sizes
+}
+
+func TestCommitSizesConsumeFinalize(t *testing.T) {
+	sizes := bakeCommitSizes(t)
+	result := sizes.Finalize().(CommitSizeResult)
+	assert.Equal(t, []int{10, 100, 1000}, result.Bounds)
+	assert.Equal(t, map[int][]int64{
+		0: {1, 0, 1, 0},
+		2: {1, 0, 1, 0},
+		3: {1, 0, 0, 0},
+	}, result.Ticks)
+	assert.Equal(t, 24*time.Hour, result.GetTickSize())
+}
+
+func TestCommitSizesSerialize(t *testing.T) {
+	sizes := bakeCommitSizes(t)
+	result := sizes.Finalize()
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, sizes.Serialize(result, false, buffer))
+	assert.Equal(t, `  classes: ["0-10", "11-100", "101-1000", ">1000"]
+  ticks:
+    0: [1, 0, 1, 0]
+    2: [1, 0, 1, 0]
+    3: [1, 0, 0, 0]
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, sizes.Serialize(result, true, buffer))
+	msg := pb.CommitSizeAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []int32{10, 100, 1000}, msg.Bounds)
+	assert.Len(t, msg.Ticks, 3)
+	assert.Equal(t, []int64{1, 0, 1, 0}, msg.Ticks[0].Counts)
+	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	deserialized, err := sizes.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
+
+func TestCommitSizesMergeResults(t *testing.T) {
+	sizes := bakeCommitSizes(t)
+	r1 := sizes.Finalize().(CommitSizeResult)
+	r2 := CommitSizeResult{
+		Bounds:   []int{10, 100, 1000},
+		Ticks:    map[int][]int64{0: {0, 0, 0, 1}, 1: {2, 0, 0, 0}},
+		tickSize: 24 * time.Hour,
+	}
+	c1 := &core.CommonAnalysisResult{BeginTime: 0}
+	c2 := &core.CommonAnalysisResult{BeginTime: 24 * 3600}
+	merged := sizes.MergeResults(r1, r2, c1, c2).(CommitSizeResult)
+	assert.Equal(t, map[int][]int64{
+		0: {1, 0, 1, 0},
+		1: {0, 0, 0, 1},
+		2: {3, 0, 1, 0},
+		3: {1, 0, 0, 0},
+	}, merged.Ticks)
+	assert.Equal(t, r1.Bounds, merged.Bounds)
+	r2.tickSize = time.Hour
+	assert.IsType(t, assert.AnError, sizes.MergeResults(r1, r2, c1, c2))
+	r2.tickSize = 24 * time.Hour
+	r2.Bounds = []int{10}
+	assert.IsType(t, assert.AnError, sizes.MergeResults(r1, r2, c1, c2))
+}
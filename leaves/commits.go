@@ -100,7 +100,9 @@ func (ca *CommitsAnalysis) Description() string {
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (ca *CommitsAnalysis) Initialize(repository *git.Repository) error {
-	ca.l = core.NewLogger()
+	if ca.l == nil {
+		ca.l = core.NewLogger()
+	}
 	return nil
 }
 
@@ -3,7 +3,10 @@ package leaves
 import (
 	"fmt"
 	"io"
+	"math"
+	"math/rand"
 	"sort"
+	"strings"
 
 	"github.com/gogo/protobuf/proto"
 	"gopkg.in/src-d/go-git.v4"
@@ -24,19 +27,45 @@ type CouplesAnalysis struct {
 	core.OneShotMergeProcessor
 	// PeopleNumber is the number of developers for which to build the matrix. 0 disables this analysis.
 	PeopleNumber int
+	// Jaccard enables the calculation of the Jaccard similarity index between files
+	// in addition to the raw co-occurrence counts.
+	Jaccard bool
+	// Embeddings is the number of dimensions of the file embeddings which are calculated
+	// from the co-occurrence matrix. 0 disables the embeddings.
+	Embeddings int
+	// Only limits the YAML output to either the files (CouplesOnlyFiles) or the developers
+	// (CouplesOnlyPeople). Empty means both. The Protocol Buffers output always contains both.
+	Only string
+	// EdgeList makes the YAML output the tab-separated weighted edge lists of the files and of
+	// the developers instead of the matrices. The Protocol Buffers output is not affected.
+	EdgeList bool
+	// MinCooccurrence is the minimum weight of the edge to be written to the edge lists.
+	MinCooccurrence int
 
-	// people store how many times every developer committed to every file.
-	people []map[string]int
+	// people store how many times every developer committed to every file identifier.
+	people []map[int]int
 	// peopleCommits is the number of commits each author made.
 	peopleCommits []int
-	// files store every file occurred in the same commit with every other file.
-	files map[string]map[string]int
-	// renames point from new file name to old file name.
-	renames *[]rename
+	// files store how many times every file identifier occurred in the same commit
+	// with every other.
+	files map[int]map[int]int
+	// fileIDs maps every path which a file has ever had to its identifier, which survives
+	// the renames, so that the coupling history of a renamed file continues under the new path.
+	// The old paths stay because a parallel branch may still change the file under them.
+	// It is shared among the forks.
+	fileIDs map[string]int
+	// fileNames maps the identifiers to the latest file paths. It is shared among the forks
+	// and its length is the next identifier.
+	fileNames *[]string
+	// deletedFiles are the identifiers of the deleted files. A file which is added again
+	// under the same path gets a new identifier. It is shared among the forks.
+	deletedFiles map[int]bool
 	// lastCommit is the last commit which was consumed.
 	lastCommit *object.Commit
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
+	// normalizeIdentities references IdentityDetector.Normalize, see MergeResults().
+	normalizeIdentities bool
 
 	l core.Logger
 }
@@ -57,22 +86,40 @@ type CouplesResult struct {
 	FilesLines []int
 	// Files is the names of the files. The order matches PeopleFiles' indexes and FilesMatrix.
 	Files []string
+	// FilesJaccard is the Jaccard similarity index of file pairs: the number of commits which
+	// changed both files divided by the number of commits which changed either of them.
+	// It is nil unless CouplesAnalysis.Jaccard is enabled.
+	FilesJaccard []map[int]float32
+	// FilesEmbeddings are the vectors of the files which are close for the files changed together.
+	// The order matches Files. It is nil unless CouplesAnalysis.Embeddings is positive.
+	FilesEmbeddings [][]float32
 
-	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	// reversedPeopleDict is IdentityDetector.ReversedPeopleDict sorted by name, the indexes
+	// in PeopleMatrix and PeopleFiles follow this order.
 	reversedPeopleDict []string
 }
 
 const (
+	// ConfigCouplesJaccard is the name of the option to set CouplesAnalysis.Jaccard.
+	ConfigCouplesJaccard = "Couples.Jaccard"
+	// ConfigCouplesEmbeddings is the name of the option to set CouplesAnalysis.Embeddings.
+	ConfigCouplesEmbeddings = "Couples.Embeddings"
+	// ConfigCouplesOnly is the name of the option to set CouplesAnalysis.Only.
+	ConfigCouplesOnly = "Couples.Only"
+	// ConfigCouplesEdgeList is the name of the option to set CouplesAnalysis.EdgeList.
+	ConfigCouplesEdgeList = "Couples.EdgeList"
+	// ConfigCouplesMinCooccurrence is the name of the option to set CouplesAnalysis.MinCooccurrence.
+	ConfigCouplesMinCooccurrence = "Couples.MinCooccurrence"
+	// CouplesOnlyFiles is the value of ConfigCouplesOnly to write only the file-file couples.
+	CouplesOnlyFiles = "files"
+	// CouplesOnlyPeople is the value of ConfigCouplesOnly to write only the developer-developer
+	// couples and the files changed by each developer.
+	CouplesOnlyPeople = "people"
 	// CouplesMaximumMeaningfulContextSize is the threshold on the number of files in a commit to
 	// consider them as grouped together.
 	CouplesMaximumMeaningfulContextSize = 1000
 )
 
-type rename struct {
-	FromName string
-	ToName   string
-}
-
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
 func (couples *CouplesAnalysis) Name() string {
 	return "Couples"
@@ -94,7 +141,40 @@ func (couples *CouplesAnalysis) Requires() []string {
 
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (couples *CouplesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
-	return []core.ConfigurationOption{}
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigCouplesJaccard,
+		Description: "Additionally calculate the Jaccard similarity index of each pair of files.",
+		Flag:        "couples-jaccard",
+		Type:        core.BoolConfigurationOption,
+		Default:     false}, {
+		Name: ConfigCouplesOnly,
+		Description: "Write only the file-file couples (\"" + CouplesOnlyFiles +
+			"\") or the developer-developer couples (\"" + CouplesOnlyPeople +
+			"\") in YAML. Empty means both.",
+		Flag:    "couples-only",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
+		Name: ConfigCouplesEmbeddings,
+		Description: "Calculate the file embeddings of the specified dimensionality from the " +
+			"co-occurrence matrix, e.g. 2 or 3 to plot the files. 0 disables the embeddings.",
+		Flag:    "couples-embeddings",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name: ConfigCouplesEdgeList,
+		Description: "Write the tab-separated weighted edge lists \"fileA fileB count\" and " +
+			"\"developerA developerB count\" in YAML instead of the matrices, e.g. to import " +
+			"them into Gephi or NetworkX.",
+		Flag:    "couples-edgelist",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name: ConfigCouplesMinCooccurrence,
+		Description: "Skip the edges with smaller weights in the edge lists, " +
+			"see --couples-edgelist.",
+		Flag:    "couples-min-cooccurrence",
+		Type:    core.IntConfigurationOption,
+		Default: 0},
+	}
+	return options[:]
 }
 
 // Configure sets the properties previously published by ListConfigurationOptions().
@@ -102,10 +182,38 @@ func (couples *CouplesAnalysis) Configure(facts map[string]interface{}) error {
 	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
 		couples.l = l
 	}
+	if val, exists := facts[identity.ConfigIdentityDetectorNormalize].(bool); exists {
+		couples.normalizeIdentities = val
+	}
 	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
 		couples.PeopleNumber = val
 		couples.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
 	}
+	if val, exists := facts[ConfigCouplesJaccard].(bool); exists {
+		couples.Jaccard = val
+	}
+	if val, exists := facts[ConfigCouplesEmbeddings].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("%s may not be negative: %d", ConfigCouplesEmbeddings, val)
+		}
+		couples.Embeddings = val
+	}
+	if val, exists := facts[ConfigCouplesOnly].(string); exists {
+		if val != "" && val != CouplesOnlyFiles && val != CouplesOnlyPeople {
+			return fmt.Errorf("%s must be either \"%s\" or \"%s\": %s",
+				ConfigCouplesOnly, CouplesOnlyFiles, CouplesOnlyPeople, val)
+		}
+		couples.Only = val
+	}
+	if val, exists := facts[ConfigCouplesEdgeList].(bool); exists {
+		couples.EdgeList = val
+	}
+	if val, exists := facts[ConfigCouplesMinCooccurrence].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("%s may not be negative: %d", ConfigCouplesMinCooccurrence, val)
+		}
+		couples.MinCooccurrence = val
+	}
 	return nil
 }
 
@@ -114,6 +222,12 @@ func (couples *CouplesAnalysis) Flag() string {
 	return "couples"
 }
 
+// ConsumesMerges returns true so that the deletions and the renames in the merges keep
+// the file identifiers up to date.
+func (couples *CouplesAnalysis) ConsumesMerges() bool {
+	return true
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (couples *CouplesAnalysis) Description() string {
 	return "The result is a square matrix, the value in each cell corresponds to the number " +
@@ -124,14 +238,18 @@ func (couples *CouplesAnalysis) Description() string {
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (couples *CouplesAnalysis) Initialize(repository *git.Repository) error {
-	couples.l = core.NewLogger()
-	couples.people = make([]map[string]int, couples.PeopleNumber+1)
+	if couples.l == nil {
+		couples.l = core.NewLogger()
+	}
+	couples.people = make([]map[int]int, couples.PeopleNumber+1)
 	for i := range couples.people {
-		couples.people[i] = map[string]int{}
+		couples.people[i] = map[int]int{}
 	}
 	couples.peopleCommits = make([]int, couples.PeopleNumber+1)
-	couples.files = map[string]map[string]int{}
-	couples.renames = &[]rename{}
+	couples.files = map[int]map[int]int{}
+	couples.fileIDs = map[string]int{}
+	couples.fileNames = &[]string{}
+	couples.deletedFiles = map[int]bool{}
 	couples.OneShotMergeProcessor.Initialize()
 	return nil
 }
@@ -153,7 +271,7 @@ func (couples *CouplesAnalysis) Consume(deps map[string]interface{}) (map[string
 		couples.peopleCommits[author]++
 	}
 	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
-	context := make([]string, 0, len(treeDiff))
+	context := make([]int, 0, len(treeDiff))
 	for _, change := range treeDiff {
 		action, err := change.Action()
 		if err != nil {
@@ -163,24 +281,45 @@ func (couples *CouplesAnalysis) Consume(deps map[string]interface{}) (map[string
 		fromName := change.From.Name
 		switch action {
 		case merkletrie.Insert:
-			if !mergeMode || couples.files[toName] == nil {
-				context = append(context, toName)
-				couples.people[author][toName]++
+			id, exists := couples.fileIDs[toName]
+			if exists && !mergeMode && (couples.deletedFiles[id] || (*couples.fileNames)[id] != toName) {
+				// a new file under the path of a deleted or a renamed one
+				exists = false
+			}
+			if !exists {
+				id = couples.newFile(toName)
+			} else if mergeMode {
+				// the merged branch has already counted it
+				continue
 			}
+			context = append(context, id)
+			couples.people[author][id]++
 		case merkletrie.Delete:
+			id, exists := couples.fileIDs[fromName]
+			if !exists {
+				id = couples.newFile(fromName)
+			}
 			if !mergeMode {
-				couples.people[author][fromName]++
+				couples.people[author][id]++
 			}
+			couples.deletedFiles[id] = true
 		case merkletrie.Modify:
+			id, exists := couples.fileIDs[toName]
 			if fromName != toName {
-				// renamed
-				*couples.renames = append(
-					*couples.renames, rename{ToName: toName, FromName: fromName})
+				// renamed: the file keeps its identifier and thus the coupling history
+				if fromID, fromExists := couples.fileIDs[fromName]; fromExists {
+					id, exists = fromID, true
+					couples.fileIDs[toName] = id
+					(*couples.fileNames)[id] = toName
+				}
 			}
-			if !mergeMode || couples.files[toName] == nil {
-				context = append(context, toName)
-				couples.people[author][toName]++
+			if !exists {
+				id = couples.newFile(toName)
+			} else if mergeMode {
+				continue
 			}
+			context = append(context, id)
+			couples.people[author][id]++
 		}
 	}
 	if len(context) <= CouplesMaximumMeaningfulContextSize {
@@ -188,7 +327,7 @@ func (couples *CouplesAnalysis) Consume(deps map[string]interface{}) (map[string
 			for _, otherFile := range context {
 				lane, exists := couples.files[file]
 				if !exists {
-					lane = map[string]int{}
+					lane = map[int]int{}
 					couples.files[file] = lane
 				}
 				lane[otherFile]++
@@ -198,19 +337,31 @@ func (couples *CouplesAnalysis) Consume(deps map[string]interface{}) (map[string
 	return nil, nil
 }
 
+// newFile assigns a new identifier to the file.
+func (couples *CouplesAnalysis) newFile(name string) int {
+	id := len(*couples.fileNames)
+	*couples.fileNames = append(*couples.fileNames, name)
+	couples.fileIDs[name] = id
+	return id
+}
+
 // Finalize returns the result of the analysis. Further Consume() calls are not expected.
 func (couples *CouplesAnalysis) Finalize() interface{} {
-	files, people := couples.propagateRenames(couples.currentFiles())
-	filesSequence := make([]string, len(files))
-	i := 0
-	for file := range files {
-		filesSequence[i] = file
-		i++
+	current := couples.currentFiles()
+	filesSequence := make([]string, 0, len(current))
+	for id, name := range current {
+		for other := range couples.files[id] {
+			if _, exists := current[other]; exists {
+				filesSequence = append(filesSequence, name)
+				break
+			}
+		}
 	}
 	sort.Strings(filesSequence)
-	filesIndex := map[string]int{}
-	for i, file := range filesSequence {
-		filesIndex[file] = i
+	// filesIndex maps the file identifiers to the indexes in filesSequence
+	filesIndex := map[int]int{}
+	for i, name := range filesSequence {
+		filesIndex[couples.fileIDs[name]] = i
 	}
 	filesLines := make([]int, len(filesSequence))
 	for i, name := range filesSequence {
@@ -232,6 +383,7 @@ func (couples *CouplesAnalysis) Finalize() interface{} {
 		filesLines[i], _ = blob.CountLines()
 	}
 
+	people := couples.people
 	peopleMatrix := make([]map[int]int64, couples.PeopleNumber+1)
 	peopleFiles := make([][]int, couples.PeopleNumber+1)
 	for i := range peopleMatrix {
@@ -253,21 +405,211 @@ func (couples *CouplesAnalysis) Finalize() interface{} {
 		}
 		sort.Ints(peopleFiles[i])
 	}
+	reversedPeopleDict, peopleMatrix, peopleFiles := sortCouplesPeople(
+		couples.reversedPeopleDict, peopleMatrix, peopleFiles)
 
 	filesMatrix := make([]map[int]int64, len(filesIndex))
 	for i := range filesMatrix {
 		filesMatrix[i] = map[int]int64{}
-		for otherFile, cooccs := range files[filesSequence[i]] {
-			filesMatrix[i][filesIndex[otherFile]] = int64(cooccs)
+		for otherFile, cooccs := range couples.files[couples.fileIDs[filesSequence[i]]] {
+			if j, exists := filesIndex[otherFile]; exists {
+				filesMatrix[i][j] = int64(cooccs)
+			}
 		}
 	}
+	var filesJaccard []map[int]float32
+	if couples.Jaccard {
+		filesJaccard = computeFilesJaccard(filesMatrix)
+	}
+	var filesEmbeddings [][]float32
+	if couples.Embeddings > 0 {
+		filesEmbeddings = computeFilesEmbeddings(filesMatrix, couples.Embeddings)
+	}
 	return CouplesResult{
 		PeopleMatrix:       peopleMatrix,
 		PeopleFiles:        peopleFiles,
 		Files:              filesSequence,
 		FilesLines:         filesLines,
 		FilesMatrix:        filesMatrix,
-		reversedPeopleDict: couples.reversedPeopleDict,
+		FilesJaccard:       filesJaccard,
+		FilesEmbeddings:    filesEmbeddings,
+		reversedPeopleDict: reversedPeopleDict,
+	}
+}
+
+// sortCouplesPeople orders the developers by name so that the output does not depend on
+// the identity detection order, and remaps the people matrix and the touched files accordingly.
+// The rows without names, that is, the missing authors, stay at the end.
+func sortCouplesPeople(dict []string, matrix []map[int]int64, files [][]int) (
+	[]string, []map[int]int64, [][]int) {
+	if len(dict) > len(matrix) {
+		return dict, matrix, files
+	}
+	order := make([]int, len(dict))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return dict[order[i]] < dict[order[j]]
+	})
+	newIndex := make([]int, len(matrix))
+	for i := range newIndex {
+		newIndex[i] = i
+	}
+	sortedDict := make([]string, len(dict))
+	for i, old := range order {
+		newIndex[old] = i
+		sortedDict[i] = dict[old]
+	}
+	sortedMatrix := make([]map[int]int64, len(matrix))
+	sortedFiles := make([][]int, len(files))
+	for i, row := range matrix {
+		sortedRow := map[int]int64{}
+		for j, val := range row {
+			sortedRow[newIndex[j]] = val
+		}
+		sortedMatrix[newIndex[i]] = sortedRow
+		if i < len(files) {
+			sortedFiles[newIndex[i]] = files[i]
+		}
+	}
+	return sortedDict, sortedMatrix, sortedFiles
+}
+
+// computeFilesJaccard derives the Jaccard similarity index of each pair of files from
+// the co-occurrence matrix. The diagonal contains the number of commits which changed each file,
+// so |A ∩ B| / |A ∪ B| = M[a][b] / (M[a][a] + M[b][b] - M[a][b]).
+func computeFilesJaccard(filesMatrix []map[int]int64) []map[int]float32 {
+	jaccard := make([]map[int]float32, len(filesMatrix))
+	for i, row := range filesMatrix {
+		jaccard[i] = map[int]float32{}
+		for j, cooccs := range row {
+			union := filesMatrix[i][i] + filesMatrix[j][j] - cooccs
+			if union <= 0 {
+				continue
+			}
+			jaccard[i][j] = float32(cooccs) / float32(union)
+		}
+	}
+	return jaccard
+}
+
+// couplesEmbeddingsIterations is the number of subspace iterations in computeFilesEmbeddings().
+const couplesEmbeddingsIterations = 100
+
+// computeFilesEmbeddings factorizes the positive pointwise mutual information (PPMI) of
+// the co-occurrence matrix, which is the linear algebra counterpart of Swivel. PPMI is symmetric,
+// so the vectors are the eigenvectors with the largest absolute eigenvalues found by the subspace
+// iteration, scaled by the square roots of those eigenvalues.
+func computeFilesEmbeddings(filesMatrix []map[int]int64, dims int) [][]float32 {
+	size := len(filesMatrix)
+	if dims > size {
+		dims = size
+	}
+	sums := make([]float64, size)
+	total := 0.0
+	for i, row := range filesMatrix {
+		for _, cooccs := range row {
+			sums[i] += float64(cooccs)
+		}
+		total += sums[i]
+	}
+	ppmi := make([]map[int]float64, size)
+	for i, row := range filesMatrix {
+		ppmi[i] = map[int]float64{}
+		for j, cooccs := range row {
+			if pmi := math.Log(float64(cooccs) * total / (sums[i] * sums[j])); pmi > 0 {
+				ppmi[i][j] = pmi
+			}
+		}
+	}
+	// the seed is fixed to make the results reproducible
+	random := rand.New(rand.NewSource(1))
+	basis := make([][]float64, dims)
+	for d := range basis {
+		basis[d] = make([]float64, size)
+		for i := range basis[d] {
+			basis[d][i] = random.Float64() - 0.5
+		}
+	}
+	orthonormalize(basis)
+	// the floating point sums depend on the order of the terms, so the columns are sorted
+	// to make the results reproducible
+	columns := make([][]int, size)
+	for i, row := range ppmi {
+		for j := range row {
+			columns[i] = append(columns[i], j)
+		}
+		sort.Ints(columns[i])
+	}
+	multiply := func(vector []float64) []float64 {
+		result := make([]float64, size)
+		for i, row := range ppmi {
+			for _, j := range columns[i] {
+				result[i] += row[j] * vector[j]
+			}
+		}
+		return result
+	}
+	for iteration := 0; iteration < couplesEmbeddingsIterations; iteration++ {
+		for d, vector := range basis {
+			basis[d] = multiply(vector)
+		}
+		orthonormalize(basis)
+	}
+	embeddings := make([][]float32, size)
+	for i := range embeddings {
+		embeddings[i] = make([]float32, dims)
+	}
+	for d, vector := range basis {
+		// Rayleigh quotient
+		eigenvalue := 0.0
+		for i, val := range multiply(vector) {
+			eigenvalue += val * vector[i]
+		}
+		scale := math.Sqrt(math.Abs(eigenvalue))
+		// the sign of an eigenvector is arbitrary; make the largest component positive
+		largest := 0.0
+		for _, val := range vector {
+			if math.Abs(val) > math.Abs(largest) {
+				largest = val
+			}
+		}
+		if largest < 0 {
+			scale = -scale
+		}
+		for i, val := range vector {
+			embeddings[i][d] = float32(val * scale)
+		}
+	}
+	return embeddings
+}
+
+// orthonormalize applies the modified Gram-Schmidt process to the vectors in place.
+// The vectors which turn out to be linearly dependent are zeroed.
+func orthonormalize(vectors [][]float64) {
+	for i, vector := range vectors {
+		for _, prev := range vectors[:i] {
+			dot := 0.0
+			for k, val := range vector {
+				dot += val * prev[k]
+			}
+			for k := range vector {
+				vector[k] -= dot * prev[k]
+			}
+		}
+		norm := 0.0
+		for _, val := range vector {
+			norm += val * val
+		}
+		norm = math.Sqrt(norm)
+		for k := range vector {
+			if norm > 1e-12 {
+				vector[k] /= norm
+			} else {
+				vector[k] = 0
+			}
+		}
 	}
 }
 
@@ -330,6 +672,24 @@ func (couples *CouplesAnalysis) Deserialize(pbmessage []byte) (interface{}, erro
 	}
 	convertCSR(result.FilesMatrix, message.FileCouples.Matrix)
 	convertCSR(result.PeopleMatrix, message.PeopleCouples.Matrix)
+	if src := message.FilesJaccard; src != nil {
+		result.FilesJaccard = make([]map[int]float32, src.NumberOfRows)
+		for indptr := range src.Indptr {
+			if indptr == 0 {
+				continue
+			}
+			result.FilesJaccard[indptr-1] = map[int]float32{}
+			for j := src.Indptr[indptr-1]; j < src.Indptr[indptr]; j++ {
+				result.FilesJaccard[indptr-1][int(src.Indices[j])] = src.Data[j]
+			}
+		}
+	}
+	if len(message.FilesEmbeddings) > 0 {
+		result.FilesEmbeddings = make([][]float32, len(message.FilesEmbeddings))
+		for i, embedding := range message.FilesEmbeddings {
+			result.FilesEmbeddings[i] = embedding.Vector
+		}
+	}
 	return result, nil
 }
 
@@ -340,7 +700,7 @@ func (couples *CouplesAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.Co
 	merged := CouplesResult{}
 	var people, files map[string]identity.MergedIndex
 	people, merged.reversedPeopleDict = identity.MergeReversedDictsIdentities(
-		cr1.reversedPeopleDict, cr2.reversedPeopleDict)
+		cr1.reversedPeopleDict, cr2.reversedPeopleDict, couples.normalizeIdentities)
 	files, merged.Files = identity.MergeReversedDictsLiteral(cr1.Files, cr2.Files)
 	merged.FilesLines = make([]int, len(merged.Files))
 	for i, name := range merged.Files {
@@ -422,10 +782,37 @@ func (couples *CouplesAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.Co
 	}
 	addFiles(cr1.FilesMatrix, cr1.Files)
 	addFiles(cr2.FilesMatrix, cr2.Files)
+	if cr1.FilesJaccard != nil || cr2.FilesJaccard != nil {
+		merged.FilesJaccard = computeFilesJaccard(merged.FilesMatrix)
+	}
+	dims := 0
+	for _, embeddings := range [][][]float32{cr1.FilesEmbeddings, cr2.FilesEmbeddings} {
+		if len(embeddings) > 0 && len(embeddings[0]) > dims {
+			dims = len(embeddings[0])
+		}
+	}
+	if dims > 0 {
+		merged.FilesEmbeddings = computeFilesEmbeddings(merged.FilesMatrix, dims)
+	}
 	return merged
 }
 
+// serializeText writes the file-file couples, the developer-developer couples and the files
+// changed by each developer under separate top-level keys.
 func (couples *CouplesAnalysis) serializeText(result *CouplesResult, writer io.Writer) {
+	if couples.EdgeList {
+		couples.serializeEdgeLists(result, writer)
+		return
+	}
+	if couples.Only != CouplesOnlyPeople {
+		serializeFilesCouplesText(result, writer)
+	}
+	if couples.Only != CouplesOnlyFiles {
+		serializePeopleCouplesText(result, writer)
+	}
+}
+
+func serializeFilesCouplesText(result *CouplesResult, writer io.Writer) {
 	fmt.Fprintln(writer, "  files_coocc:")
 	fmt.Fprintln(writer, "    index:")
 	for _, file := range result.Files {
@@ -453,6 +840,38 @@ func (couples *CouplesAnalysis) serializeText(result *CouplesResult, writer io.W
 		fmt.Fprintln(writer, "}")
 	}
 
+	if result.FilesJaccard != nil {
+		fmt.Fprintln(writer, "    jaccard:")
+		for _, files := range result.FilesJaccard {
+			fmt.Fprint(writer, "      - {")
+			var indices []int
+			for file := range files {
+				indices = append(indices, file)
+			}
+			sort.Ints(indices)
+			for i, file := range indices {
+				fmt.Fprintf(writer, "%d: %.4f", file, files[file])
+				if i < len(indices)-1 {
+					fmt.Fprint(writer, ", ")
+				}
+			}
+			fmt.Fprintln(writer, "}")
+		}
+	}
+
+	if result.FilesEmbeddings != nil {
+		fmt.Fprintln(writer, "    embeddings:")
+		for _, vector := range result.FilesEmbeddings {
+			values := make([]string, len(vector))
+			for i, val := range vector {
+				values[i] = fmt.Sprintf("%.4f", val)
+			}
+			fmt.Fprintf(writer, "      - [%s]\n", strings.Join(values, ", "))
+		}
+	}
+}
+
+func serializePeopleCouplesText(result *CouplesResult, writer io.Writer) {
 	fmt.Fprintln(writer, "  people_coocc:")
 	fmt.Fprintln(writer, "    index:")
 	for _, person := range result.reversedPeopleDict {
@@ -476,13 +895,46 @@ func (couples *CouplesAnalysis) serializeText(result *CouplesResult, writer io.W
 		fmt.Fprintln(writer, "}")
 	}
 
-	fmt.Fprintln(writer, "    author_files:") // sorted by number of files each author changed
+	fmt.Fprintln(writer, "  author_files:") // sorted by number of files each author changed
 	peopleFiles := sortByNumberOfFiles(result.PeopleFiles, result.reversedPeopleDict, result.Files)
 	for _, authorFiles := range peopleFiles {
-		fmt.Fprintf(writer, "      - %s:\n", yaml.SafeString(authorFiles.Author))
+		fmt.Fprintf(writer, "    - %s:\n", yaml.SafeString(authorFiles.Author))
 		sort.Strings(authorFiles.Files)
 		for _, file := range authorFiles.Files {
-			fmt.Fprintf(writer, "        - %s\n", yaml.SafeString(file)) // sorted by path
+			fmt.Fprintf(writer, "      - %s\n", yaml.SafeString(file)) // sorted by path
+		}
+	}
+}
+
+// serializeEdgeLists writes the file-file and the developer-developer couples as the YAML
+// literal blocks of tab-separated "nameA nameB count" lines, each undirected edge once.
+// The self-loops and the edges lighter than MinCooccurrence are skipped.
+func (couples *CouplesAnalysis) serializeEdgeLists(result *CouplesResult, writer io.Writer) {
+	if couples.Only != CouplesOnlyPeople {
+		fmt.Fprintln(writer, "  files_edgelist: |-")
+		writeEdgeList(writer, result.FilesMatrix, result.Files, couples.MinCooccurrence)
+	}
+	if couples.Only != CouplesOnlyFiles {
+		fmt.Fprintln(writer, "  people_edgelist: |-")
+		writeEdgeList(writer, result.PeopleMatrix, result.reversedPeopleDict, couples.MinCooccurrence)
+	}
+}
+
+func writeEdgeList(writer io.Writer, matrix []map[int]int64, names []string, minWeight int) {
+	for i, row := range matrix {
+		if i >= len(names) {
+			// the missing author
+			break
+		}
+		var indices []int
+		for j := range row {
+			if j > i && j < len(names) && row[j] >= int64(minWeight) {
+				indices = append(indices, j)
+			}
+		}
+		sort.Ints(indices)
+		for _, j := range indices {
+			fmt.Fprintf(writer, "    %s\t%s\t%d\n", names[i], names[j], row[j])
 		}
 	}
 }
@@ -546,6 +998,15 @@ func (couples *CouplesAnalysis) serializeBinary(result *CouplesResult, writer io
 	for i, l := range result.FilesLines {
 		message.FilesLines[i] = int32(l)
 	}
+	if result.FilesJaccard != nil {
+		message.FilesJaccard = pb.MapToCompressedSparseRowFloatMatrix(result.FilesJaccard)
+	}
+	if result.FilesEmbeddings != nil {
+		message.FilesEmbeddings = make([]*pb.FileEmbedding, len(result.FilesEmbeddings))
+		for i, vector := range result.FilesEmbeddings {
+			message.FilesEmbeddings[i] = &pb.FileEmbedding{Vector: vector}
+		}
+	}
 
 	serialized, err := proto.Marshal(&message)
 	if err != nil {
@@ -555,112 +1016,27 @@ func (couples *CouplesAnalysis) serializeBinary(result *CouplesResult, writer io
 	return err
 }
 
-// currentFiles return the list of files in the last consumed commit.
-func (couples *CouplesAnalysis) currentFiles() map[string]bool {
-	files := map[string]bool{}
+// currentFiles maps the identifiers of the files which exist in the last consumed commit
+// to their paths. If several paths share the identifier, e.g. the old path was edited on
+// a parallel branch and the merge kept both, the latest path wins.
+func (couples *CouplesAnalysis) currentFiles() map[int]string {
+	files := map[int]string{}
 	if couples.lastCommit == nil {
-		for key := range couples.files {
-			files[key] = true
-		}
-	}
-	tree, _ := couples.lastCommit.Tree()
-	fileIter := tree.Files()
-	fileIter.ForEach(func(fobj *object.File) error {
-		files[fobj.Name] = true
-		return nil
-	})
-	return files
-}
-
-// propagateRenames applies `renames` over the files from `lastCommit`.
-func (couples *CouplesAnalysis) propagateRenames(files map[string]bool) (
-	map[string]map[string]int, []map[string]int) {
-
-	renames := *couples.renames
-	reducedFiles := map[string]map[string]int{}
-	for file := range files {
-		fmap := map[string]int{}
-		refmap := couples.files[file]
-		for other := range files {
-			refval := refmap[other]
-			if refval > 0 {
-				fmap[other] = refval
-			}
-		}
-		if len(fmap) > 0 {
-			reducedFiles[file] = fmap
-		}
-	}
-	// propagate renames
-	aliases := map[string]map[string]bool{}
-	pointers := map[string]string{}
-	for i := range renames {
-		rename := renames[len(renames)-i-1]
-		toName := rename.ToName
-		if newTo, exists := pointers[toName]; exists {
-			toName = newTo
-		}
-		if _, exists := reducedFiles[toName]; exists {
-			if rename.FromName != toName {
-				var set map[string]bool
-				if set, exists = aliases[toName]; !exists {
-					set = map[string]bool{}
-					aliases[toName] = set
-				}
-				set[rename.FromName] = true
-				pointers[rename.FromName] = toName
-			}
-			continue
-		}
+		return files
 	}
-	adjustments := map[string]map[string]int{}
-	for final, set := range aliases {
-		adjustment := map[string]int{}
-		for alias := range set {
-			for k, v := range couples.files[alias] {
-				adjustment[k] += v
-			}
-		}
-		adjustments[final] = adjustment
-	}
-	for _, adjustment := range adjustments {
-		for final, set := range aliases {
-			for alias := range set {
-				adjustment[final] += adjustment[alias]
-				delete(adjustment, alias)
-			}
-		}
+	tree, err := couples.lastCommit.Tree()
+	if err != nil {
+		return files
 	}
-	for final, adjustment := range adjustments {
-		for key, val := range adjustment {
-			if coocc, exists := reducedFiles[final][key]; exists {
-				reducedFiles[final][key] = coocc + val
-				reducedFiles[key][final] = coocc + val
+	tree.Files().ForEach(func(fobj *object.File) error {
+		if id, exists := couples.fileIDs[fobj.Name]; exists {
+			if _, duplicate := files[id]; !duplicate || (*couples.fileNames)[id] == fobj.Name {
+				files[id] = fobj.Name
 			}
 		}
-	}
-	people := make([]map[string]int, len(couples.people))
-	for i, counts := range couples.people {
-		reducedCounts := map[string]int{}
-		people[i] = reducedCounts
-		for file := range files {
-			count := counts[file]
-			for alias := range aliases[file] {
-				count += counts[alias]
-			}
-			if count > 0 {
-				reducedCounts[file] = count
-			}
-		}
-		for key, val := range counts {
-			if _, exists := files[key]; !exists {
-				if _, exists = pointers[key]; !exists {
-					reducedCounts[key] = val
-				}
-			}
-		}
-	}
-	return reducedFiles, people
+		return nil
+	})
+	return files
 }
 
 func init() {
@@ -2,10 +2,13 @@ package leaves
 
 import (
 	"bytes"
+	"fmt"
 	"io/ioutil"
+	"math"
 	"path"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
@@ -32,12 +35,39 @@ func TestCouplesMeta(t *testing.T) {
 	assert.Equal(t, c.Requires()[0], identity.DependencyAuthor)
 	assert.Equal(t, c.Requires()[1], plumbing.DependencyTreeChanges)
 	assert.Equal(t, c.Flag(), "couples")
-	assert.Len(t, c.ListConfigurationOptions(), 0)
+	assert.Len(t, c.ListConfigurationOptions(), 5)
+	assert.Equal(t, c.ListConfigurationOptions()[0].Name, ConfigCouplesJaccard)
+	assert.Equal(t, c.ListConfigurationOptions()[1].Name, ConfigCouplesOnly)
+	assert.Equal(t, c.ListConfigurationOptions()[2].Name, ConfigCouplesEmbeddings)
+	assert.Equal(t, c.ListConfigurationOptions()[3].Name, ConfigCouplesEdgeList)
+	assert.Equal(t, c.ListConfigurationOptions()[4].Name, ConfigCouplesMinCooccurrence)
 	logger := core.NewLogger()
 	assert.NoError(t, c.Configure(map[string]interface{}{
-		core.ConfigLogger: logger,
+		core.ConfigLogger:            logger,
+		ConfigCouplesJaccard:         true,
+		ConfigCouplesOnly:            CouplesOnlyPeople,
+		ConfigCouplesEmbeddings:      3,
+		ConfigCouplesEdgeList:        true,
+		ConfigCouplesMinCooccurrence: 2,
 	}))
 	assert.Equal(t, logger, c.l)
+	assert.True(t, c.Jaccard)
+	assert.Equal(t, CouplesOnlyPeople, c.Only)
+	assert.Equal(t, 3, c.Embeddings)
+	assert.True(t, c.EdgeList)
+	assert.Equal(t, 2, c.MinCooccurrence)
+	assert.Error(t, c.Configure(map[string]interface{}{
+		ConfigCouplesMinCooccurrence: -1,
+	}))
+	assert.Equal(t, 2, c.MinCooccurrence)
+	assert.Error(t, c.Configure(map[string]interface{}{
+		ConfigCouplesOnly: "nobody",
+	}))
+	assert.Equal(t, CouplesOnlyPeople, c.Only)
+	assert.Error(t, c.Configure(map[string]interface{}{
+		ConfigCouplesEmbeddings: -1,
+	}))
+	assert.Equal(t, 3, c.Embeddings)
 }
 
 func TestCouplesRegistration(t *testing.T) {
@@ -108,47 +138,45 @@ func TestCouplesConsumeFinalize(t *testing.T) {
 	deps[identity.DependencyAuthor] = 2
 	deps[plumbing.DependencyTreeChanges] = generateChanges("=file_test.go")
 	c.Consume(deps)
-	assert.Equal(t, len(c.people[0]), 6)
-	assert.Equal(t, c.people[0]["README.md"], 1)
-	assert.Equal(t, c.people[0]["LICENSE2"], 2)
-	assert.Equal(t, c.people[0]["analyser.go"], 1)
-	assert.Equal(t, c.people[0]["file2.go"], 1)
-	assert.Equal(t, c.people[0]["file_test.go"], 1)
-	assert.Equal(t, c.people[0]["rbtree2.go"], 1)
-	assert.Equal(t, len(c.people[1]), 3)
-	assert.Equal(t, c.people[1]["README.md"], 1)
-	assert.Equal(t, c.people[1]["analyser.go"], 1)
-	assert.Equal(t, c.people[1]["rbtree2.go"], 1)
-	assert.Equal(t, len(c.people[2]), 1)
-	assert.Equal(t, c.people[2]["file_test.go"], 1)
-	assert.Equal(t, len(c.files["README.md"]), 3)
-	assert.Equal(t, c.files["README.md"], map[string]int{
+	// file2.go was renamed to file_test.go
+	assert.Equal(t, couplesByName(c, c.people[0]), map[string]int{
+		"README.md":    1,
+		"LICENSE2":     2,
+		"analyser.go":  1,
+		"file_test.go": 2,
+		"rbtree2.go":   1,
+	})
+	assert.Equal(t, couplesByName(c, c.people[1]), map[string]int{
+		"README.md":   1,
+		"analyser.go": 1,
+		"rbtree2.go":  1,
+	})
+	assert.Equal(t, couplesByName(c, c.people[2]), map[string]int{"file_test.go": 1})
+	assert.Equal(t, couplesFileRow(c, "README.md"), map[string]int{
 		"README.md":    2,
 		"analyser.go":  2,
 		"file_test.go": 1,
 	})
-	assert.Equal(t, c.files["LICENSE2"], map[string]int{
-		"LICENSE2":   1,
-		"file2.go":   1,
-		"rbtree2.go": 1,
-	})
-	assert.Equal(t, c.files["file2.go"], map[string]int{
-		"LICENSE2":   1,
-		"file2.go":   1,
-		"rbtree2.go": 1,
+	assert.Equal(t, couplesFileRow(c, "LICENSE2"), map[string]int{
+		"LICENSE2":     1,
+		"file_test.go": 1,
+		"rbtree2.go":   1,
 	})
-	assert.Equal(t, c.files["rbtree2.go"], map[string]int{
-		"LICENSE2":   1,
-		"file2.go":   1,
-		"rbtree2.go": 1,
+	assert.Nil(t, couplesFileRow(c, "file2.go"))
+	assert.Equal(t, couplesFileRow(c, "rbtree2.go"), map[string]int{
+		"LICENSE2":     1,
+		"file_test.go": 1,
+		"rbtree2.go":   1,
 	})
-	assert.Equal(t, c.files["analyser.go"], map[string]int{
+	assert.Equal(t, couplesFileRow(c, "analyser.go"), map[string]int{
 		"analyser.go":  2,
 		"README.md":    2,
 		"file_test.go": 1,
 	})
-	assert.Equal(t, c.files["file_test.go"], map[string]int{
-		"file_test.go": 2,
+	assert.Equal(t, couplesFileRow(c, "file_test.go"), map[string]int{
+		"LICENSE2":     1,
+		"rbtree2.go":   1,
+		"file_test.go": 3,
 		"README.md":    1,
 		"analyser.go":  1,
 	})
@@ -209,23 +237,21 @@ func TestCouplesConsumeFinalizeMerge(t *testing.T) {
 	c.Consume(deps)
 	deps[plumbing.DependencyTreeChanges] = generateChanges("+file2.go", "-LICENSE2", ">file2.go>file_test.go")
 	c.Consume(deps)
-	assert.Equal(t, len(c.people[0]), 3)
-	assert.Equal(t, c.people[0]["LICENSE2"], 1)
-	assert.Equal(t, c.people[0]["file2.go"], 1)
-	assert.Equal(t, c.people[0]["file_test.go"], 1)
+	// the second commit only repeats what the merged branch has already counted
+	assert.Equal(t, couplesByName(c, c.people[0]), map[string]int{
+		"LICENSE2":     1,
+		"file_test.go": 1,
+	})
 	for i := 1; i < 3; i++ {
 		assert.Equal(t, len(c.people[i]), 0)
 	}
-	assert.Equal(t, c.files["LICENSE2"], map[string]int{
-		"LICENSE2": 1,
-		"file2.go": 1,
-	})
-	assert.Equal(t, c.files["file2.go"], map[string]int{
-		"file2.go": 1,
-		"LICENSE2": 1,
+	assert.Equal(t, couplesFileRow(c, "LICENSE2"), map[string]int{
+		"LICENSE2":     1,
+		"file_test.go": 1,
 	})
-	assert.Equal(t, c.files["file_test.go"], map[string]int{
+	assert.Equal(t, couplesFileRow(c, "file_test.go"), map[string]int{
 		"file_test.go": 1,
+		"LICENSE2":     1,
 	})
 }
 
@@ -246,46 +272,45 @@ func TestCouplesConsumeFinalizeAuthorMissing(t *testing.T) {
 	deps[identity.DependencyAuthor] = identity.AuthorMissing
 	deps[plumbing.DependencyTreeChanges] = generateChanges("=file_test.go")
 	c.Consume(deps)
-	assert.Equal(t, len(c.people[0]), 6)
-	assert.Equal(t, c.people[0]["README.md"], 1)
-	assert.Equal(t, c.people[0]["LICENSE2"], 2)
-	assert.Equal(t, c.people[0]["analyser.go"], 1)
-	assert.Equal(t, c.people[0]["file2.go"], 1)
-	assert.Equal(t, c.people[0]["file_test.go"], 1)
-	assert.Equal(t, c.people[0]["rbtree2.go"], 1)
-	assert.Equal(t, len(c.people[1]), 3)
-	assert.Equal(t, c.people[1]["README.md"], 1)
-	assert.Equal(t, c.people[1]["analyser.go"], 1)
-	assert.Equal(t, c.people[1]["rbtree2.go"], 1)
+	// file2.go was renamed to file_test.go
+	assert.Equal(t, couplesByName(c, c.people[0]), map[string]int{
+		"README.md":    1,
+		"LICENSE2":     2,
+		"analyser.go":  1,
+		"file_test.go": 2,
+		"rbtree2.go":   1,
+	})
+	assert.Equal(t, couplesByName(c, c.people[1]), map[string]int{
+		"README.md":   1,
+		"analyser.go": 1,
+		"rbtree2.go":  1,
+	})
 	assert.Equal(t, len(c.people[2]), 0)
-	assert.Equal(t, len(c.files["README.md"]), 3)
-	assert.Equal(t, c.files["README.md"], map[string]int{
+	assert.Equal(t, couplesFileRow(c, "README.md"), map[string]int{
 		"README.md":    2,
 		"analyser.go":  2,
 		"file_test.go": 1,
 	})
-	assert.Equal(t, c.files["LICENSE2"], map[string]int{
-		"LICENSE2":   1,
-		"file2.go":   1,
-		"rbtree2.go": 1,
-	})
-	assert.Equal(t, c.files["file2.go"], map[string]int{
-		"LICENSE2":   1,
-		"file2.go":   1,
-		"rbtree2.go": 1,
+	assert.Equal(t, couplesFileRow(c, "LICENSE2"), map[string]int{
+		"LICENSE2":     1,
+		"file_test.go": 1,
+		"rbtree2.go":   1,
 	})
-	assert.Equal(t, c.files["rbtree2.go"], map[string]int{
-		"LICENSE2":   1,
-		"file2.go":   1,
-		"rbtree2.go": 1,
+	assert.Nil(t, couplesFileRow(c, "file2.go"))
+	assert.Equal(t, couplesFileRow(c, "rbtree2.go"), map[string]int{
+		"LICENSE2":     1,
+		"file_test.go": 1,
+		"rbtree2.go":   1,
 	})
-	assert.Equal(t, c.files["analyser.go"], map[string]int{
+	assert.Equal(t, couplesFileRow(c, "analyser.go"), map[string]int{
 		"analyser.go":  2,
 		"README.md":    2,
 		"file_test.go": 1,
 	})
-	assert.Equal(t, c.files["file_test.go"], map[string]int{
-		"file_test.go": 2,
+	assert.Equal(t, couplesFileRow(c, "file_test.go"), map[string]int{
+		"LICENSE2":     1,
+		"rbtree2.go":   1,
+		"file_test.go": 3,
 		"README.md":    1,
 		"analyser.go":  1,
 	})
@@ -343,7 +368,7 @@ func TestCouplesConsumeManyFiles(t *testing.T) {
 	for i := 0; i < len(changes); i++ {
 		changes[i] = &object.Change{
 			From: object.ChangeEntry{},
-			To:   object.ChangeEntry{Name: string(i)},
+			To:   object.ChangeEntry{Name: string(rune(i))},
 		}
 	}
 	deps[plumbing.DependencyTreeChanges] = changes
@@ -404,16 +429,16 @@ func TestCouplesSerialize(t *testing.T) {
       - {0: 3, 1: 3}
       - {0: 1, 2: 1}
       - {}
-    author_files:
-      - "p3":
-        - "five"
-      - "p2":
-        - "one"
-        - "three"
-      - "p1":
-        - "five"
-        - "one"
-        - "three"
+  author_files:
+    - "p3":
+      - "five"
+    - "p2":
+      - "one"
+      - "three"
+    - "p1":
+      - "five"
+      - "one"
+      - "three"
 `)
 	buffer = &bytes.Buffer{}
 	assert.Nil(t, c.Serialize(result, true, buffer))
@@ -448,6 +473,140 @@ func TestCouplesSerialize(t *testing.T) {
 	assert.Equal(t, msg.FileCouples.Matrix.Indptr, indptr2[:])
 }
 
+func TestCouplesSortPeople(t *testing.T) {
+	dict, matrix, files := sortCouplesPeople(
+		[]string{"zoe", "adam", "mary"},
+		[]map[int]int64{{0: 5, 1: 1, 3: 2}, {0: 1, 1: 3}, {2: 4}, {0: 2, 3: 2}},
+		[][]int{{0, 1}, {1}, {2}, {0}})
+	assert.Equal(t, []string{"adam", "mary", "zoe"}, dict)
+	assert.Equal(t, []map[int]int64{{0: 3, 2: 1}, {1: 4}, {0: 1, 2: 5, 3: 2}, {2: 2, 3: 2}}, matrix)
+	assert.Equal(t, [][]int{{1}, {2}, {0, 1}, {0}}, files)
+	dict, _, _ = sortCouplesPeople([]string{"b", "a"}, []map[int]int64{{}}, [][]int{{}})
+	assert.Equal(t, []string{"b", "a"}, dict)
+}
+
+func TestCouplesReproducible(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	// many files are changed together so that the floating point sums have many terms
+	var fakeCommits []test.FakeCommit
+	authors := []string{"zoe", "adam", "mary"}
+	for i := 0; i < 30; i++ {
+		files := map[string]string{}
+		for j := 0; j < 40; j++ {
+			if (i*7+j*j)%5 < 2 {
+				files[fmt.Sprintf("dir%d/file%d.go", j%4, j)] = fmt.Sprintf("%d\n", i)
+			}
+		}
+		fakeCommits = append(fakeCommits, test.FakeCommit{
+			Author: authors[i%len(authors)], When: when.Add(time.Duration(i) * time.Hour),
+			Files: files})
+	}
+	repository, _, err := test.NewMemoryRepository(fakeCommits)
+	assert.NoError(t, err)
+	run := func() []byte {
+		pipeline := core.NewPipeline(repository)
+		pipeline.PrintActions = false
+		couples := pipeline.DeployItem(&CouplesAnalysis{}).(*CouplesAnalysis)
+		commits, err := pipeline.Commits(false)
+		assert.NoError(t, err)
+		assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+			ConfigCouplesJaccard:    true,
+			ConfigCouplesEmbeddings: 2,
+		}))
+		results, err := pipeline.Run(commits)
+		assert.NoError(t, err)
+		result := results[couples].(CouplesResult)
+		assert.Equal(t, []string{"adam|adam@srcd", "mary|mary@srcd", "zoe|zoe@srcd"},
+			result.reversedPeopleDict)
+		buffer := &bytes.Buffer{}
+		assert.NoError(t, couples.Serialize(result, true, buffer))
+		return buffer.Bytes()
+	}
+	first := run()
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, first, run())
+	}
+}
+
+func TestCouplesSerializeOnly(t *testing.T) {
+	c := fixtureCouples()
+	result := CouplesResult{
+		PeopleMatrix:       []map[int]int64{{0: 1}, {}},
+		PeopleFiles:        [][]int{{0, 1}, {}},
+		FilesMatrix:        []map[int]int64{{0: 4, 1: 2}, {0: 2, 1: 3}},
+		Files:              []string{"one", "two"},
+		FilesLines:         []int{9, 8},
+		reversedPeopleDict: []string{"p1"},
+	}
+	c.Only = CouplesOnlyFiles
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, false, buffer))
+	assert.Equal(t, `  files_coocc:
+    index:
+      - "one"
+      - "two"
+    lines:
+      - 9
+      - 8
+    matrix:
+      - {0: 4, 1: 2}
+      - {0: 2, 1: 3}
+`, buffer.String())
+	c.Only = CouplesOnlyPeople
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, false, buffer))
+	assert.Equal(t, `  people_coocc:
+    index:
+      - "p1"
+    matrix:
+      - {0: 1}
+      - {}
+  author_files:
+    - "p1":
+      - "one"
+      - "two"
+`, buffer.String())
+	// the binary output always carries both matrices
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	msg := pb.CouplesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []string{"one", "two"}, msg.FileCouples.Index)
+	assert.Equal(t, []string{"p1"}, msg.PeopleCouples.Index)
+}
+
+func TestCouplesSerializeEdgeList(t *testing.T) {
+	c := fixtureCouples()
+	c.EdgeList = true
+	result := CouplesResult{
+		PeopleMatrix: []map[int]int64{
+			{0: 7, 1: 3, 2: 1}, {0: 3, 1: 3}, {0: 1, 2: 1}, {0: 5},
+		},
+		PeopleFiles: [][]int{{0, 1, 2}, {1, 2}, {0}, {}},
+		FilesMatrix: []map[int]int64{
+			{1: 1, 2: 1, 0: 3}, {1: 2, 2: 2, 0: 1}, {2: 2, 0: 1, 1: 2},
+		},
+		Files:              []string{"five", "one", "three"},
+		FilesLines:         []int{9, 8, 7},
+		reversedPeopleDict: []string{"p1", "p2", "p3"},
+	}
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, c.Serialize(result, false, buffer))
+	assert.Equal(t, "  files_edgelist: |-\n"+
+		"    five\tone\t1\n"+
+		"    five\tthree\t1\n"+
+		"    one\tthree\t2\n"+
+		"  people_edgelist: |-\n"+
+		"    p1\tp2\t3\n"+
+		"    p1\tp3\t1\n", buffer.String())
+
+	c.MinCooccurrence = 2
+	c.Only = CouplesOnlyFiles
+	buffer.Reset()
+	assert.NoError(t, c.Serialize(result, false, buffer))
+	assert.Equal(t, "  files_edgelist: |-\n    one\tthree\t2\n", buffer.String())
+}
+
 func TestCouplesDeserialize(t *testing.T) {
 	message, err := ioutil.ReadFile(path.Join("..", "internal", "test_data", "couples.pb"))
 	assert.Nil(t, err)
@@ -535,53 +694,231 @@ func TestCouplesMerge(t *testing.T) {
 	assert.Equal(t, merged.FilesMatrix[2], getCouplesMap(1, 200))
 }
 
+func TestCouplesJaccard(t *testing.T) {
+	jaccard := computeFilesJaccard([]map[int]int64{
+		{0: 4, 1: 2, 2: 1}, {0: 2, 1: 3}, {0: 1, 2: 1},
+	})
+	assert.Len(t, jaccard, 3)
+	assert.Equal(t, jaccard[0], map[int]float32{0: 1, 1: 0.4, 2: 0.25})
+	assert.Equal(t, jaccard[1], map[int]float32{0: 0.4, 1: 1})
+	assert.Equal(t, jaccard[2], map[int]float32{0: 0.25, 2: 1})
+}
+
+func TestCouplesSerializeJaccard(t *testing.T) {
+	c := fixtureCouples()
+	result := CouplesResult{
+		PeopleMatrix: []map[int]int64{{0: 1}, {}},
+		PeopleFiles:  [][]int{{0, 1}, {}},
+		FilesMatrix: []map[int]int64{
+			{0: 4, 1: 2}, {0: 2, 1: 3},
+		},
+		FilesJaccard: []map[int]float32{
+			{0: 1, 1: 0.4}, {0: 0.4, 1: 1},
+		},
+		Files:              []string{"one", "two"},
+		FilesLines:         []int{9, 8},
+		reversedPeopleDict: []string{"p1"},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), `    jaccard:
+      - {0: 1.0000, 1: 0.4000}
+      - {0: 0.4000, 1: 1.0000}
+  people_coocc:
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	msg := pb.CouplesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.FilesJaccard.NumberOfRows, int32(2))
+	assert.Equal(t, msg.FilesJaccard.Data, []float32{1, 0.4, 0.4, 1})
+	assert.Equal(t, msg.FilesJaccard.Indices, []int32{0, 1, 0, 1})
+	assert.Equal(t, msg.FilesJaccard.Indptr, []int64{0, 2, 4})
+	iresult, err := c.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, iresult.(CouplesResult).FilesJaccard, result.FilesJaccard)
+	result.FilesJaccard = nil
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	msg = pb.CouplesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Nil(t, msg.FilesJaccard)
+}
+
+func TestCouplesEmbeddings(t *testing.T) {
+	// two groups of files which are changed together: {0, 1, 2} and {3, 4}
+	filesMatrix := []map[int]int64{
+		{0: 5, 1: 4, 2: 3}, {0: 4, 1: 6, 2: 4}, {0: 3, 1: 4, 2: 5},
+		{3: 7, 4: 6}, {3: 6, 4: 7},
+	}
+	embeddings := computeFilesEmbeddings(filesMatrix, 2)
+	assert.Len(t, embeddings, 5)
+	distance := func(i, j int) float64 {
+		sum := 0.0
+		for d := range embeddings[i] {
+			delta := float64(embeddings[i][d] - embeddings[j][d])
+			sum += delta * delta
+		}
+		return math.Sqrt(sum)
+	}
+	for _, pair := range [][2]int{{0, 1}, {1, 2}, {0, 2}, {3, 4}} {
+		for _, other := range [][2]int{{0, 3}, {1, 4}, {2, 3}} {
+			assert.True(t, distance(pair[0], pair[1]) < distance(other[0], other[1]))
+		}
+	}
+	// the results are reproducible
+	assert.Equal(t, embeddings, computeFilesEmbeddings(filesMatrix, 2))
+	// the dimensionality may not exceed the number of files
+	embeddings = computeFilesEmbeddings([]map[int]int64{{0: 7, 1: 6}, {0: 6, 1: 7}}, 3)
+	assert.Len(t, embeddings, 2)
+	assert.Len(t, embeddings[0], 2)
+	assert.Len(t, computeFilesEmbeddings(nil, 2), 0)
+}
+
+func TestCouplesSerializeEmbeddings(t *testing.T) {
+	c := fixtureCouples()
+	result := CouplesResult{
+		PeopleMatrix:       []map[int]int64{{0: 1}, {}},
+		PeopleFiles:        [][]int{{0, 1}},
+		FilesMatrix:        []map[int]int64{{0: 4, 1: 2}, {0: 2, 1: 3}},
+		FilesEmbeddings:    [][]float32{{0.5, -1}, {0.25, 2}},
+		Files:              []string{"one", "two"},
+		FilesLines:         []int{9, 8},
+		reversedPeopleDict: []string{"p1"},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), `    embeddings:
+      - [0.5000, -1.0000]
+      - [0.2500, 2.0000]
+  people_coocc:
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	msg := pb.CouplesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.FilesEmbeddings, 2)
+	assert.Equal(t, []float32{0.25, 2}, msg.FilesEmbeddings[1].Vector)
+	iresult, err := c.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, result.FilesEmbeddings, iresult.(CouplesResult).FilesEmbeddings)
+	merged := c.MergeResults(result, result, nil, nil).(CouplesResult)
+	assert.Len(t, merged.FilesEmbeddings, 2)
+	assert.Len(t, merged.FilesEmbeddings[0], 2)
+	result.FilesEmbeddings = nil
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, false, buffer))
+	assert.NotContains(t, buffer.String(), "embeddings")
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	iresult, err = c.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Nil(t, iresult.(CouplesResult).FilesEmbeddings)
+}
+
 func TestCouplesCurrentFiles(t *testing.T) {
 	c := fixtureCouples()
 	c.lastCommit, _ = test.Repository.CommitObject(gitplumbing.NewHash(
 		"cce947b98a050c6d356bc6ba95030254914027b1"))
+	c.fileIDs = map[string]int{"LICENSE": 1, "README.md": 2}
 	files := c.currentFiles()
-	assert.Equal(t, files, map[string]bool{".gitignore": true, "LICENSE": true})
+	assert.Equal(t, files, map[int]string{1: "LICENSE"})
+	c.lastCommit = nil
+	assert.Len(t, c.currentFiles(), 0)
 }
 
-func TestCouplesPropagateRenames(t *testing.T) {
-	c := fixtureCouples()
-	c.files["one"] = map[string]int{
-		"one":   1,
-		"two":   2,
-		"three": 3,
-	}
-	c.files["two"] = map[string]int{
-		"one":   2,
-		"two":   10,
-		"three": 1,
-		"four":  7,
-	}
-	c.files["three"] = map[string]int{
-		"one":   3,
-		"two":   1,
-		"three": 3,
-		"four":  2,
+func TestCouplesRenames(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"a.go": "a\n", "b.go": "b\n", "c.go": "c\n"}},
+		{Author: "one", When: when.Add(time.Hour), Files: map[string]string{
+			"a.go": "a\na\n", "b.go": "b\nb\n"}},
+		// rename b.go to d.go together with a modification of a.go
+		{Author: "two", When: when.Add(2 * time.Hour), Deleted: []string{"b.go"},
+			Files: map[string]string{"a.go": "a\na\na\n", "d.go": "b\nb\n"}},
+		{Author: "two", When: when.Add(3 * time.Hour), Files: map[string]string{
+			"a.go": "a\na\na\na\n", "d.go": "b\nb\nb\n"}},
+		// a re-added file does not inherit the history
+		{Author: "one", When: when.Add(4 * time.Hour), Files: map[string]string{
+			"a.go": "a\n", "b.go": "x\n"}},
+	})
+	assert.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	couples := pipeline.DeployItem(&CouplesAnalysis{}).(*CouplesAnalysis)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	results, err := pipeline.Run(nil)
+	assert.NoError(t, err)
+	result := results[couples].(CouplesResult)
+	assert.Equal(t, []string{"a.go", "b.go", "c.go", "d.go"}, result.Files)
+	assert.Equal(t, []map[int]int64{
+		{0: 5, 1: 1, 2: 1, 3: 4},
+		{0: 1, 1: 1},
+		{0: 1, 2: 1, 3: 1},
+		{0: 4, 2: 1, 3: 4},
+	}, result.FilesMatrix)
+	assert.Equal(t, []string{"one|one@srcd", "two|two@srcd"}, result.reversedPeopleDict)
+	assert.Equal(t, [][]int{{0, 1, 2, 3}, {0, 3}, nil}, result.PeopleFiles)
+	// the renamed file keeps its identifier, the re-added one gets a new
+	assert.Len(t, couples.fileIDs, 4)
+	assert.Len(t, *couples.fileNames, 4)
+	assert.NotEqual(t, couples.fileIDs["b.go"], couples.fileIDs["d.go"])
+	assert.Equal(t, "d.go", (*couples.fileNames)[couples.fileIDs["d.go"]])
+}
+
+func TestCouplesRenameParallelBranches(t *testing.T) {
+	when := time.Unix(1500000000, 0)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"a.go": "1\n2\n3\n4\n5\n", "b.go": "b\n", "c.go": "c\n"}},
+		// rename a.go to e.go on one branch
+		{Author: "one", When: when.Add(time.Hour), Deleted: []string{"a.go"},
+			Files: map[string]string{"e.go": "1\n2\n3\n4\n5\n", "b.go": "b\nb\n"}},
+		// edit a.go on the other
+		{Author: "two", When: when.Add(2 * time.Hour), Parents: []int{0},
+			Files: map[string]string{"a.go": "1\n2\n3\n4\n5\n6\n", "c.go": "c\nc\n"}},
+		{Author: "one", When: when.Add(3 * time.Hour), Parents: []int{1, 2},
+			Files: map[string]string{"e.go": "1\n2\n3\n4\n5\n6\n", "c.go": "c\nc\n"}},
+		{Author: "two", When: when.Add(4 * time.Hour), Files: map[string]string{
+			"e.go": "1\n2\n3\n4\n5\n6\n7\n", "c.go": "c\nc\nc\n"}},
+	})
+	assert.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	couples := pipeline.DeployItem(&CouplesAnalysis{}).(*CouplesAnalysis)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	results, err := pipeline.Run(nil)
+	assert.NoError(t, err)
+	result := results[couples].(CouplesResult)
+	assert.Equal(t, []string{"b.go", "c.go", "e.go"}, result.Files)
+	// the edit of a.go on the parallel branch belongs to e.go
+	assert.Equal(t, []map[int]int64{
+		{0: 2, 1: 1, 2: 2},
+		{0: 1, 1: 3, 2: 3},
+		{0: 2, 1: 3, 2: 4},
+	}, result.FilesMatrix)
+	assert.Len(t, *couples.fileNames, 3)
+	assert.Equal(t, couples.fileIDs["a.go"], couples.fileIDs["e.go"])
+}
+
+// couplesByName converts the file identifiers in `counts` to the latest file names.
+func couplesByName(c *CouplesAnalysis, counts map[int]int) map[string]int {
+	result := map[string]int{}
+	for id, val := range counts {
+		result[(*c.fileNames)[id]] = val
 	}
-	c.files["four"] = map[string]int{
-		"two":   7,
-		"three": 3,
-		"four":  1,
+	return result
+}
+
+// couplesFileRow returns the co-occurrences of the file with the specified latest name.
+func couplesFileRow(c *CouplesAnalysis, name string) map[string]int {
+	for id, other := range *c.fileNames {
+		if other == name {
+			return couplesByName(c, c.files[id])
+		}
 	}
-	c.PeopleNumber = 1
-	c.people = make([]map[string]int, 1)
-	c.people[0] = map[string]int{}
-	c.people[0]["one"] = 1
-	c.people[0]["two"] = 2
-	c.people[0]["three"] = 3
-	c.people[0]["four"] = 4
-	*c.renames = []rename{{ToName: "four", FromName: "one"}}
-	files, people := c.propagateRenames(map[string]bool{"two": true, "three": true, "four": true})
-	assert.Len(t, files, 3)
-	assert.Len(t, people, 1)
-	assert.Equal(t, files["two"], map[string]int{"two": 10, "three": 1, "four": 9})
-	assert.Equal(t, files["three"], map[string]int{"two": 1, "three": 3, "four": 6})
-	assert.Equal(t, files["four"], map[string]int{"two": 9, "three": 6, "four": 2})
-	assert.Equal(t, people[0], map[string]int{"two": 2, "three": 3, "four": 5})
+	return nil
 }
 
 func getSlice(vals ...int) []int {
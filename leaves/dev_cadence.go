@@ -0,0 +1,405 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// DevCadenceAnalysis measures how regularly each developer commits: the time between
+// the consecutive commits and the streaks of active and inactive ticks. It is a LeafPipelineItem.
+type DevCadenceAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+
+	// commits maps developers to the timestamps of their commits.
+	commits map[int][]time.Time
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// normalizeIdentities references IdentityDetector.Normalize, see MergeResults().
+	normalizeIdentities bool
+	// timeSource references Pipeline.TimeSource, see core.CommitTime().
+	timeSource string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// DevCadence is the commit cadence of a single developer.
+type DevCadence struct {
+	// Commits are the sorted timestamps of the developer's commits.
+	Commits []time.Time
+	// MeanGap is the mean time between the consecutive commits in hours.
+	MeanGap float64
+	// MedianGap is the median time between the consecutive commits in hours.
+	MedianGap float64
+	// GapHistogram is the number of gaps in each class. Its length is len(GapBounds) + 1.
+	GapHistogram []int64
+	// LongestActiveStreak is the longest run of consecutive ticks with commits.
+	LongestActiveStreak int
+	// LongestInactiveStreak is the longest run of consecutive ticks without commits
+	// between the first and the last commit.
+	LongestInactiveStreak int
+}
+
+// DevCadenceResult is returned by DevCadenceAnalysis.Finalize() and carries the commit cadence
+// of each developer.
+type DevCadenceResult struct {
+	// Developers maps the developer indexes to their cadence.
+	Developers map[int]*DevCadence
+	// GapBounds are the inclusive upper limits of the gap classes in hours except the last one,
+	// which is unbounded. See DevCadenceGapBounds.
+	GapBounds []int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+// DevCadenceGapBounds are the inclusive upper limits of the gap classes in hours:
+// up to an hour, a day, a week, 30 days and longer.
+var DevCadenceGapBounds = []int{1, 24, 24 * 7, 24 * 30}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (cadence *DevCadenceAnalysis) Name() string {
+	return "DevCadence"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (cadence *DevCadenceAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (cadence *DevCadenceAnalysis) Requires() []string {
+	return []string{identity.DependencyAuthor}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (cadence *DevCadenceAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (cadence *DevCadenceAnalysis) Flag() string {
+	return "dev-cadence"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (cadence *DevCadenceAnalysis) Description() string {
+	return "Calculates the mean and the median time between the consecutive commits of each " +
+		"developer, the histogram of those gaps and the longest streaks of active and " +
+		"inactive ticks."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (cadence *DevCadenceAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		cadence.l = l
+	}
+	if val, exists := facts[identity.ConfigIdentityDetectorNormalize].(bool); exists {
+		cadence.normalizeIdentities = val
+	}
+	if val, exists := facts[core.ConfigTimeSource].(string); exists {
+		cadence.timeSource = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		cadence.reversedPeopleDict = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		cadence.tickSize = val
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (cadence *DevCadenceAnalysis) Initialize(repository *git.Repository) error {
+	if cadence.l == nil {
+		cadence.l = core.NewLogger()
+	}
+	cadence.commits = map[int][]time.Time{}
+	if cadence.tickSize == 0 {
+		cadence.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	cadence.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (cadence *DevCadenceAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !cadence.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	cadence.commits[author] = append(cadence.commits[author],
+		core.CommitTime(commit, cadence.timeSource))
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (cadence *DevCadenceAnalysis) Finalize() interface{} {
+	result := DevCadenceResult{
+		Developers:         map[int]*DevCadence{},
+		GapBounds:          append([]int{}, DevCadenceGapBounds...),
+		reversedPeopleDict: cadence.reversedPeopleDict,
+		tickSize:           cadence.tickSize,
+	}
+	for dev, commits := range cadence.commits {
+		result.Developers[dev] = calculateDevCadence(
+			append([]time.Time{}, commits...), result.GapBounds, result.tickSize)
+	}
+	return result
+}
+
+// calculateDevCadence sorts the commit timestamps and derives the statistics from them.
+func calculateDevCadence(commits []time.Time, bounds []int, tickSize time.Duration) *DevCadence {
+	sort.Slice(commits, func(i, j int) bool {
+		return commits[i].Before(commits[j])
+	})
+	result := &DevCadence{
+		Commits:      commits,
+		GapHistogram: make([]int64, len(bounds)+1),
+	}
+	if len(commits) == 0 {
+		return result
+	}
+	gaps := make([]float64, len(commits)-1)
+	var sum float64
+	for i := range gaps {
+		gap := commits[i+1].Sub(commits[i]).Hours()
+		gaps[i] = gap
+		sum += gap
+		result.GapHistogram[sort.Search(len(bounds), func(j int) bool {
+			return gap <= float64(bounds[j])
+		})]++
+	}
+	if len(gaps) > 0 {
+		result.MeanGap = sum / float64(len(gaps))
+		sort.Float64s(gaps)
+		if len(gaps)%2 == 1 {
+			result.MedianGap = gaps[len(gaps)/2]
+		} else {
+			result.MedianGap = (gaps[len(gaps)/2-1] + gaps[len(gaps)/2]) / 2
+		}
+	}
+	// the ticks are counted from the Unix epoch so that the merged timelines stay aligned
+	tickSeconds := int64(tickSize.Seconds())
+	prevTick := commits[0].Unix() / tickSeconds
+	streak := 1
+	result.LongestActiveStreak = 1
+	for _, commit := range commits[1:] {
+		tick := commit.Unix() / tickSeconds
+		switch delta := int(tick - prevTick); {
+		case delta == 0:
+			continue
+		case delta == 1:
+			streak++
+		default:
+			streak = 1
+			if delta-1 > result.LongestInactiveStreak {
+				result.LongestInactiveStreak = delta - 1
+			}
+		}
+		if streak > result.LongestActiveStreak {
+			result.LongestActiveStreak = streak
+		}
+		prevTick = tick
+	}
+	return result
+}
+
+// Fork clones this PipelineItem.
+func (cadence *DevCadenceAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(cadence, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (cadence *DevCadenceAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	cadenceResult := result.(DevCadenceResult)
+	if binary {
+		return cadence.serializeBinary(&cadenceResult, writer)
+	}
+	cadence.serializeText(&cadenceResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to DevCadenceResult.
+func (cadence *DevCadenceAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.DevCadenceAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := DevCadenceResult{
+		Developers:         map[int]*DevCadence{},
+		GapBounds:          make([]int, len(message.GapBounds)),
+		reversedPeopleDict: message.DevIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}
+	for i, bound := range message.GapBounds {
+		result.GapBounds[i] = int(bound)
+	}
+	for dev, devCadence := range message.Developers {
+		if len(devCadence.GapHistogram) != len(result.GapBounds)+1 {
+			return nil, fmt.Errorf("developer %d: %d gap classes while %d are expected",
+				dev, len(devCadence.GapHistogram), len(result.GapBounds)+1)
+		}
+		commits := make([]time.Time, len(devCadence.Commits))
+		for i, commit := range devCadence.Commits {
+			commits[i] = time.Unix(commit, 0)
+		}
+		result.Developers[int(dev)] = &DevCadence{
+			Commits:               commits,
+			MeanGap:               devCadence.MeanGap,
+			MedianGap:             devCadence.MedianGap,
+			GapHistogram:          devCadence.GapHistogram,
+			LongestActiveStreak:   int(devCadence.LongestActiveStreak),
+			LongestInactiveStreak: int(devCadence.LongestInactiveStreak),
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two DevCadenceResult-s together. The commit timelines of the same
+// developers are joined and the statistics are calculated again.
+func (cadence *DevCadenceAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	cr1 := r1.(DevCadenceResult)
+	cr2 := r2.(DevCadenceResult)
+	if cr1.tickSize != cr2.tickSize {
+		return fmt.Errorf("mismatching tick sizes (r1: %v, r2: %v) received",
+			cr1.tickSize, cr2.tickSize)
+	}
+	if fmt.Sprint(cr1.GapBounds) != fmt.Sprint(cr2.GapBounds) {
+		return fmt.Errorf("mismatching gap classes (r1: %v, r2: %v) received",
+			cr1.GapBounds, cr2.GapBounds)
+	}
+	merged := DevCadenceResult{
+		Developers: map[int]*DevCadence{},
+		GapBounds:  cr1.GapBounds,
+		tickSize:   cr1.tickSize,
+	}
+	var mergedIndex map[string]identity.MergedIndex
+	mergedIndex, merged.reversedPeopleDict = identity.MergeReversedDictsIdentities(
+		cr1.reversedPeopleDict, cr2.reversedPeopleDict, cadence.normalizeIdentities)
+	commits := map[int][]time.Time{}
+	for _, result := range []DevCadenceResult{cr1, cr2} {
+		for dev, devCadence := range result.Developers {
+			newdev := mergedIndex[result.reversedPeopleDict[dev]].Final
+			commits[newdev] = append(commits[newdev], devCadence.Commits...)
+		}
+	}
+	for dev, devCommits := range commits {
+		merged.Developers[dev] = calculateDevCadence(devCommits, merged.GapBounds, merged.tickSize)
+	}
+	return merged
+}
+
+func (cadence *DevCadenceAnalysis) serializeText(result *DevCadenceResult, writer io.Writer) {
+	classes := make([]string, len(result.GapBounds)+1)
+	lower := 0
+	for i, bound := range result.GapBounds {
+		classes[i] = fmt.Sprintf("\"%d-%d\"", lower, bound)
+		lower = bound
+	}
+	classes[len(result.GapBounds)] = fmt.Sprintf("\">%d\"", lower)
+	fmt.Fprintf(writer, "  gap_classes: [%s]\n", strings.Join(classes, ", "))
+	fmt.Fprintln(writer, "  developers:")
+	devs := make([]int, 0, len(result.Developers))
+	for dev := range result.Developers {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	for _, dev := range devs {
+		devCadence := result.Developers[dev]
+		histogram := make([]string, len(devCadence.GapHistogram))
+		for i, count := range devCadence.GapHistogram {
+			histogram[i] = fmt.Sprint(count)
+		}
+		fmt.Fprintf(writer, "    %d:\n", dev)
+		fmt.Fprintf(writer, "      commits: %d\n", len(devCadence.Commits))
+		fmt.Fprintf(writer, "      mean_gap: %.2f\n", devCadence.MeanGap)
+		fmt.Fprintf(writer, "      median_gap: %.2f\n", devCadence.MedianGap)
+		fmt.Fprintf(writer, "      gap_histogram: [%s]\n", strings.Join(histogram, ", "))
+		fmt.Fprintf(writer, "      longest_active_streak: %d\n", devCadence.LongestActiveStreak)
+		fmt.Fprintf(writer, "      longest_inactive_streak: %d\n", devCadence.LongestInactiveStreak)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (cadence *DevCadenceAnalysis) serializeBinary(result *DevCadenceResult, writer io.Writer) error {
+	message := pb.DevCadenceAnalysisResults{
+		Developers: map[int32]*pb.DevCadence{},
+		DevIndex:   result.reversedPeopleDict,
+		GapBounds:  make([]int32, len(result.GapBounds)),
+		TickSize:   int64(result.tickSize),
+	}
+	for i, bound := range result.GapBounds {
+		message.GapBounds[i] = int32(bound)
+	}
+	for dev, devCadence := range result.Developers {
+		commits := make([]int64, len(devCadence.Commits))
+		for i, commit := range devCadence.Commits {
+			commits[i] = commit.Unix()
+		}
+		message.Developers[int32(dev)] = &pb.DevCadence{
+			Commits:               commits,
+			MeanGap:               devCadence.MeanGap,
+			MedianGap:             devCadence.MedianGap,
+			GapHistogram:          devCadence.GapHistogram,
+			LongestActiveStreak:   int32(devCadence.LongestActiveStreak),
+			LongestInactiveStreak: int32(devCadence.LongestInactiveStreak),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this commit cadence result.
+func (dcr DevCadenceResult) GetTickSize() time.Duration {
+	return dcr.tickSize
+}
+
+// GetIdentities returns the list of developer identities used to generate this commit cadence
+// result. The format is |-joined keys, see internals/plumbing/identity for details.
+func (dcr DevCadenceResult) GetIdentities() []string {
+	return dcr.reversedPeopleDict
+}
+
+func init() {
+	core.Registry.Register(&DevCadenceAnalysis{})
+}
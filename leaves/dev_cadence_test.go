@@ -0,0 +1,205 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureDevCadence() *DevCadenceAnalysis {
+	cadence := DevCadenceAnalysis{}
+	cadence.Initialize(test.Repository)
+	cadence.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	})
+	return &cadence
+}
+
+func TestDevCadenceMeta(t *testing.T) {
+	cadence := fixtureDevCadence()
+	assert.Equal(t, cadence.Name(), "DevCadence")
+	assert.Len(t, cadence.Provides(), 0)
+	assert.Equal(t, []string{identity.DependencyAuthor}, cadence.Requires())
+	assert.Len(t, cadence.ListConfigurationOptions(), 0)
+	assert.Equal(t, cadence.Flag(), "dev-cadence")
+	assert.Equal(t, 24*time.Hour, cadence.tickSize)
+	logger := core.NewLogger()
+	assert.NoError(t, cadence.Configure(map[string]interface{}{
+		core.ConfigLogger:  logger,
+		items.FactTickSize: time.Hour,
+	}))
+	assert.Equal(t, logger, cadence.l)
+	assert.Equal(t, time.Hour, cadence.tickSize)
+	assert.Equal(t, []string{"one", "two"}, cadence.reversedPeopleDict)
+}
+
+func TestDevCadenceRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&DevCadenceAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "DevCadence")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&DevCadenceAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestDevCadenceFork(t *testing.T) {
+	cadence1 := fixtureDevCadence()
+	clones := cadence1.Fork(1)
+	assert.Len(t, clones, 1)
+	cadence2 := clones[0].(*DevCadenceAnalysis)
+	assert.True(t, cadence1 == cadence2)
+	cadence1.Merge([]core.PipelineItem{cadence2})
+}
+
+// devCadenceBase is the beginning of the 100th day since the Unix epoch.
+var devCadenceBase = time.Unix(100*24*3600, 0)
+
+func bakeDevCadence(t *testing.T) *DevCadenceAnalysis {
+	cadence := fixtureDevCadence()
+	for _, c := range []struct {
+		author int
+		offset time.Duration
+	}{
+		{0, 0},
+		{0, 240 * time.Hour},
+		{0, 30 * time.Minute},
+		{1, 2 * time.Hour},
+		{identity.AuthorMissing, time.Hour},
+		{0, 26 * time.Hour},
+		{0, 27 * time.Hour},
+	} {
+		result, err := cadence.Consume(map[string]interface{}{
+			identity.DependencyAuthor: c.author,
+			core.DependencyCommit: &object.Commit{
+				Committer: object.Signature{When: devCadenceBase.Add(c.offset)}},
+			core.DependencyIsMerge: false,
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	}
+	return cadence
+}
+
+func TestDevCadenceConsumeFinalize(t *testing.T) {
+	result := bakeDevCadence(t).Finalize().(DevCadenceResult)
+	assert.Equal(t, DevCadenceGapBounds, result.GapBounds)
+	assert.Len(t, result.Developers, 2)
+	dev := result.Developers[0]
+	assert.Equal(t, []time.Time{
+		devCadenceBase, devCadenceBase.Add(30 * time.Minute), devCadenceBase.Add(26 * time.Hour),
+		devCadenceBase.Add(27 * time.Hour), devCadenceBase.Add(240 * time.Hour),
+	}, dev.Commits)
+	// the gaps are 0.5, 25.5, 1 and 213 hours
+	assert.Equal(t, 60.0, dev.MeanGap)
+	assert.Equal(t, 13.25, dev.MedianGap)
+	assert.Equal(t, []int64{2, 0, 1, 1, 0}, dev.GapHistogram)
+	assert.Equal(t, 2, dev.LongestActiveStreak)
+	assert.Equal(t, 8, dev.LongestInactiveStreak)
+	assert.Equal(t, &DevCadence{
+		Commits:             []time.Time{devCadenceBase.Add(2 * time.Hour)},
+		GapHistogram:        []int64{0, 0, 0, 0, 0},
+		LongestActiveStreak: 1,
+	}, result.Developers[1])
+}
+
+func TestDevCadenceTimeSource(t *testing.T) {
+	cadence := fixtureDevCadence()
+	assert.NoError(t, cadence.Configure(map[string]interface{}{
+		core.ConfigTimeSource: core.TimeSourceAuthor,
+	}))
+	result, err := cadence.Consume(map[string]interface{}{
+		identity.DependencyAuthor: 0,
+		core.DependencyCommit: &object.Commit{
+			Author:    object.Signature{When: devCadenceBase},
+			Committer: object.Signature{When: devCadenceBase.Add(time.Hour)}},
+		core.DependencyIsMerge: false,
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, []time.Time{devCadenceBase}, cadence.commits[0])
+}
+
+func TestDevCadenceSerialize(t *testing.T) {
+	cadence := bakeDevCadence(t)
+	result := cadence.Finalize()
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, cadence.Serialize(result, false, buffer))
+	assert.Equal(t, `  gap_classes: ["0-1", "1-24", "24-168", "168-720", ">720"]
+  developers:
+    0:
+      commits: 5
+      mean_gap: 60.00
+      median_gap: 13.25
+      gap_histogram: [2, 0, 1, 1, 0]
+      longest_active_streak: 2
+      longest_inactive_streak: 8
+    1:
+      commits: 1
+      mean_gap: 0.00
+      median_gap: 0.00
+      gap_histogram: [0, 0, 0, 0, 0]
+      longest_active_streak: 1
+      longest_inactive_streak: 0
+  people:
+  - "one"
+  - "two"
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, cadence.Serialize(result, true, buffer))
+	msg := pb.DevCadenceAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []int32{1, 24, 168, 720}, msg.GapBounds)
+	assert.Equal(t, []string{"one", "two"}, msg.DevIndex)
+	assert.Len(t, msg.Developers, 2)
+	assert.Equal(t, []int64{2, 0, 1, 1, 0}, msg.Developers[0].GapHistogram)
+	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	deserialized, err := cadence.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
+
+func TestDevCadenceMergeResults(t *testing.T) {
+	cadence := bakeDevCadence(t)
+	r1 := cadence.Finalize().(DevCadenceResult)
+	r2 := DevCadenceResult{
+		Developers: map[int]*DevCadence{
+			0: {Commits: []time.Time{devCadenceBase.Add(3 * time.Hour)}},
+			1: {Commits: []time.Time{devCadenceBase.Add(48 * time.Hour)}},
+		},
+		GapBounds:          []int{1, 24, 24 * 7, 24 * 30},
+		reversedPeopleDict: []string{"two", "three"},
+		tickSize:           24 * time.Hour,
+	}
+	merged := cadence.MergeResults(r1, r2, nil, nil).(DevCadenceResult)
+	assert.Equal(t, []string{"one", "two", "three"}, merged.reversedPeopleDict)
+	assert.Len(t, merged.Developers, 3)
+	assert.Equal(t, r1.Developers[0], merged.Developers[0])
+	assert.Equal(t, []time.Time{devCadenceBase.Add(2 * time.Hour),
+		devCadenceBase.Add(3 * time.Hour)}, merged.Developers[1].Commits)
+	assert.Equal(t, 1.0, merged.Developers[1].MeanGap)
+	assert.Equal(t, []int64{1, 0, 0, 0, 0}, merged.Developers[1].GapHistogram)
+	assert.Equal(t, []time.Time{devCadenceBase.Add(48 * time.Hour)},
+		merged.Developers[2].Commits)
+	r2.tickSize = time.Hour
+	assert.IsType(t, assert.AnError, cadence.MergeResults(r1, r2, nil, nil))
+	r2.tickSize = 24 * time.Hour
+	r2.GapBounds = []int{1}
+	assert.IsType(t, assert.AnError, cadence.MergeResults(r1, r2, nil, nil))
+}
@@ -0,0 +1,410 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// DevFocusAnalysis measures how narrow the set of files edited by each developer is:
+// the specialists edit few files and the generalists spread over many. It follows the renames.
+// It is a LeafPipelineItem.
+type DevFocusAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+
+	// edits maps ticks to developers to file identifiers to the number of edits.
+	edits map[int]map[int]map[int]int64
+	// files maps the current file paths to their identifiers, which survive the renames.
+	files map[string]int
+	// nextFileID is the identifier of the next new file.
+	nextFileID int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// DevFocus is the distribution of a developer's edits across the files.
+type DevFocus struct {
+	// Files is the number of distinct edited files.
+	Files int
+	// Edits is the number of file edits. Each commit edits a file at most once.
+	Edits int64
+	// Entropy is the Shannon entropy of the edits distribution across the files in bits.
+	Entropy float64
+	// Gini is the Gini coefficient of the edits across the edited files: 0 means that
+	// all the files were edited equally often.
+	Gini float64
+	// Focus is 2^-Entropy, the inverse of the effective number of files: 1 means a single file
+	// and 1/N means N equally edited files.
+	Focus float64
+}
+
+// DevFocusTimeline is the focus of a single developer over the analysed range and in each tick.
+type DevFocusTimeline struct {
+	// Total is calculated over the whole analysed range.
+	Total DevFocus
+	// Ticks maps the ticks with the developer's edits to the focus within them.
+	Ticks map[int]DevFocus
+}
+
+// DevFocusResult is returned by DevFocusAnalysis.Finalize() and carries the focus of each
+// developer.
+type DevFocusResult struct {
+	// Developers maps the developer indexes to their focus.
+	Developers map[int]*DevFocusTimeline
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (focus *DevFocusAnalysis) Name() string {
+	return "DevFocus"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (focus *DevFocusAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (focus *DevFocusAnalysis) Requires() []string {
+	return []string{identity.DependencyAuthor, items.DependencyTreeChanges, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (focus *DevFocusAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (focus *DevFocusAnalysis) Flag() string {
+	return "dev-focus"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (focus *DevFocusAnalysis) Description() string {
+	return "Calculates the entropy and the Gini coefficient of each developer's edits across " +
+		"the files over the whole history and in each tick to tell the specialists from " +
+		"the generalists."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (focus *DevFocusAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		focus.l = l
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		focus.reversedPeopleDict = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		focus.tickSize = val
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (focus *DevFocusAnalysis) Initialize(repository *git.Repository) error {
+	if focus.l == nil {
+		focus.l = core.NewLogger()
+	}
+	focus.edits = map[int]map[int]map[int]int64{}
+	focus.files = map[string]int{}
+	focus.nextFileID = 0
+	focus.OneShotMergeProcessor.Initialize()
+	if focus.tickSize == 0 {
+		focus.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (focus *DevFocusAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !focus.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	tick := deps[items.DependencyTick].(int)
+	edit := func(name string) {
+		if author == identity.AuthorMissing {
+			return
+		}
+		id, exists := focus.files[name]
+		if !exists {
+			id = focus.newFile(name)
+		}
+		devs := focus.edits[tick]
+		if devs == nil {
+			devs = map[int]map[int]int64{}
+			focus.edits[tick] = devs
+		}
+		files := devs[author]
+		if files == nil {
+			files = map[int]int64{}
+			devs[author] = files
+		}
+		files[id]++
+	}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			// a re-added file is a new file
+			focus.newFile(change.To.Name)
+			edit(change.To.Name)
+		case merkletrie.Delete:
+			edit(change.From.Name)
+			delete(focus.files, change.From.Name)
+		case merkletrie.Modify:
+			if change.From.Name != change.To.Name {
+				if id, exists := focus.files[change.From.Name]; exists {
+					delete(focus.files, change.From.Name)
+					focus.files[change.To.Name] = id
+				}
+				if change.From.TreeEntry.Hash == change.To.TreeEntry.Hash {
+					// pure rename
+					break
+				}
+			}
+			edit(change.To.Name)
+		}
+	}
+	return nil, nil
+}
+
+// newFile assigns a new identifier to the file.
+func (focus *DevFocusAnalysis) newFile(name string) int {
+	id := focus.nextFileID
+	focus.nextFileID++
+	focus.files[name] = id
+	return id
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (focus *DevFocusAnalysis) Finalize() interface{} {
+	result := DevFocusResult{
+		Developers:         map[int]*DevFocusTimeline{},
+		reversedPeopleDict: focus.reversedPeopleDict,
+		tickSize:           focus.tickSize,
+	}
+	totals := map[int]map[int]int64{}
+	for tick, devs := range focus.edits {
+		for dev, files := range devs {
+			timeline := result.Developers[dev]
+			if timeline == nil {
+				timeline = &DevFocusTimeline{Ticks: map[int]DevFocus{}}
+				result.Developers[dev] = timeline
+				totals[dev] = map[int]int64{}
+			}
+			counts := make([]int64, 0, len(files))
+			for id, edits := range files {
+				counts = append(counts, edits)
+				totals[dev][id] += edits
+			}
+			timeline.Ticks[tick] = calculateDevFocus(counts)
+		}
+	}
+	for dev, files := range totals {
+		counts := make([]int64, 0, len(files))
+		for _, edits := range files {
+			counts = append(counts, edits)
+		}
+		result.Developers[dev].Total = calculateDevFocus(counts)
+	}
+	return result
+}
+
+// calculateDevFocus derives the focus statistics from the numbers of edits of each file.
+func calculateDevFocus(counts []int64) DevFocus {
+	result := DevFocus{Files: len(counts)}
+	if len(counts) == 0 {
+		return result
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i] < counts[j]
+	})
+	var weighted int64
+	for i, count := range counts {
+		result.Edits += count
+		weighted += int64(i+1) * count
+	}
+	total := float64(result.Edits)
+	for _, count := range counts {
+		p := float64(count) / total
+		result.Entropy -= p * math.Log2(p)
+	}
+	n := float64(len(counts))
+	result.Gini = 2*float64(weighted)/(n*total) - (n+1)/n
+	if result.Gini < 1e-12 {
+		// rounding errors of the equal counts
+		result.Gini = 0
+	}
+	result.Focus = math.Exp2(-result.Entropy)
+	return result
+}
+
+// Fork clones this PipelineItem.
+func (focus *DevFocusAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(focus, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (focus *DevFocusAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	focusResult := result.(DevFocusResult)
+	if binary {
+		return focus.serializeBinary(&focusResult, writer)
+	}
+	focus.serializeText(&focusResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to DevFocusResult.
+func (focus *DevFocusAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.DevFocusAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := DevFocusResult{
+		Developers:         map[int]*DevFocusTimeline{},
+		reversedPeopleDict: message.DevIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}
+	for dev, devTimeline := range message.Developers {
+		if devTimeline.Total == nil {
+			return nil, fmt.Errorf("developer %d: the total focus is missing", dev)
+		}
+		timeline := &DevFocusTimeline{
+			Total: devFocusFromProto(devTimeline.Total),
+			Ticks: map[int]DevFocus{},
+		}
+		for tick, devFocus := range devTimeline.Ticks {
+			timeline.Ticks[int(tick)] = devFocusFromProto(devFocus)
+		}
+		result.Developers[int(dev)] = timeline
+	}
+	return result, nil
+}
+
+func devFocusFromProto(message *pb.DevFocus) DevFocus {
+	return DevFocus{
+		Files:   int(message.Files),
+		Edits:   message.Edits,
+		Entropy: message.Entropy,
+		Gini:    message.Gini,
+		Focus:   message.Focus,
+	}
+}
+
+func devFocusToProto(devFocus DevFocus) *pb.DevFocus {
+	return &pb.DevFocus{
+		Files:   int32(devFocus.Files),
+		Edits:   devFocus.Edits,
+		Entropy: devFocus.Entropy,
+		Gini:    devFocus.Gini,
+		Focus:   devFocus.Focus,
+	}
+}
+
+func (focus *DevFocusAnalysis) serializeText(result *DevFocusResult, writer io.Writer) {
+	format := func(devFocus DevFocus) string {
+		return fmt.Sprintf("{files: %d, edits: %d, entropy: %.4f, gini: %.4f, focus: %.4f}",
+			devFocus.Files, devFocus.Edits, devFocus.Entropy, devFocus.Gini, devFocus.Focus)
+	}
+	fmt.Fprintln(writer, "  developers:")
+	devs := make([]int, 0, len(result.Developers))
+	for dev := range result.Developers {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	for _, dev := range devs {
+		timeline := result.Developers[dev]
+		fmt.Fprintf(writer, "    %d:\n", dev)
+		fmt.Fprintf(writer, "      total: %s\n", format(timeline.Total))
+		fmt.Fprintln(writer, "      ticks:")
+		ticks := make([]int, 0, len(timeline.Ticks))
+		for tick := range timeline.Ticks {
+			ticks = append(ticks, tick)
+		}
+		sort.Ints(ticks)
+		for _, tick := range ticks {
+			fmt.Fprintf(writer, "        %d: %s\n", tick, format(timeline.Ticks[tick]))
+		}
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (focus *DevFocusAnalysis) serializeBinary(result *DevFocusResult, writer io.Writer) error {
+	message := pb.DevFocusAnalysisResults{
+		Developers: map[int32]*pb.DevFocusTimeline{},
+		DevIndex:   result.reversedPeopleDict,
+		TickSize:   int64(result.tickSize),
+	}
+	for dev, timeline := range result.Developers {
+		devTimeline := &pb.DevFocusTimeline{
+			Total: devFocusToProto(timeline.Total),
+			Ticks: map[int32]*pb.DevFocus{},
+		}
+		for tick, devFocus := range timeline.Ticks {
+			devTimeline.Ticks[int32(tick)] = devFocusToProto(devFocus)
+		}
+		message.Developers[int32(dev)] = devTimeline
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this focus result.
+func (dfr DevFocusResult) GetTickSize() time.Duration {
+	return dfr.tickSize
+}
+
+// GetIdentities returns the list of developer identities used to generate this focus result.
+// The format is |-joined keys, see internals/plumbing/identity for details.
+func (dfr DevFocusResult) GetIdentities() []string {
+	return dfr.reversedPeopleDict
+}
+
+func init() {
+	core.Registry.Register(&DevFocusAnalysis{})
+}
@@ -0,0 +1,193 @@
+package leaves
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureDevFocus() *DevFocusAnalysis {
+	focus := DevFocusAnalysis{}
+	focus.Initialize(test.Repository)
+	focus.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	})
+	return &focus
+}
+
+func TestDevFocusMeta(t *testing.T) {
+	focus := fixtureDevFocus()
+	assert.Equal(t, focus.Name(), "DevFocus")
+	assert.Len(t, focus.Provides(), 0)
+	assert.Equal(t, []string{identity.DependencyAuthor, items.DependencyTreeChanges,
+		items.DependencyTick}, focus.Requires())
+	assert.Len(t, focus.ListConfigurationOptions(), 0)
+	assert.Equal(t, focus.Flag(), "dev-focus")
+	assert.NotEmpty(t, focus.Description())
+	assert.Equal(t, 24*time.Hour, focus.tickSize)
+	logger := core.NewLogger()
+	assert.NoError(t, focus.Configure(map[string]interface{}{
+		core.ConfigLogger:  logger,
+		items.FactTickSize: time.Hour,
+	}))
+	assert.Equal(t, logger, focus.l)
+	assert.Equal(t, time.Hour, focus.tickSize)
+	assert.Equal(t, []string{"one", "two"}, focus.reversedPeopleDict)
+}
+
+func TestDevFocusRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&DevFocusAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "DevFocus")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&DevFocusAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestDevFocusFork(t *testing.T) {
+	focus1 := fixtureDevFocus()
+	clones := focus1.Fork(1)
+	assert.Len(t, clones, 1)
+	focus2 := clones[0].(*DevFocusAnalysis)
+	assert.True(t, focus1 == focus2)
+	focus1.Merge([]core.PipelineItem{focus2})
+}
+
+func TestCalculateDevFocus(t *testing.T) {
+	assert.Equal(t, DevFocus{}, calculateDevFocus(nil))
+	assert.Equal(t, DevFocus{Files: 1, Edits: 5, Focus: 1}, calculateDevFocus([]int64{5}))
+	even := calculateDevFocus([]int64{2, 2, 2, 2})
+	assert.Equal(t, 4, even.Files)
+	assert.Equal(t, int64(8), even.Edits)
+	assert.InDelta(t, 2, even.Entropy, 1e-9)
+	assert.Equal(t, 0.0, even.Gini)
+	assert.InDelta(t, 0.25, even.Focus, 1e-9)
+	skewed := calculateDevFocus([]int64{3, 1})
+	assert.InDelta(t, 0.811278, skewed.Entropy, 1e-6)
+	assert.InDelta(t, 0.25, skewed.Gini, 1e-9)
+	assert.InDelta(t, math.Exp2(-0.811278), skewed.Focus, 1e-6)
+}
+
+func bakeDevFocus(t *testing.T) (DevFocusResult, *DevFocusAnalysis) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"a.go": "a\n", "b.go": "b\n"}},
+		{Author: "one", When: when.Add(day), Files: map[string]string{"a.go": "a\na\n"}},
+		{Author: "two", When: when.Add(2 * day), Files: map[string]string{
+			"a.go": "a\n", "b.go": "b\nb\n", "c.go": "c\n"}},
+		{Author: "one", When: when.Add(2*day + time.Hour), Files: map[string]string{"a.go": "aa\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	focus := pipeline.DeployItem(&DevFocusAnalysis{}).(*DevFocusAnalysis)
+	commits, err := pipeline.Commits(false)
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	results, err := pipeline.Run(commits)
+	require.NoError(t, err)
+	return results[focus].(DevFocusResult), focus
+}
+
+func TestDevFocusConsumeFinalize(t *testing.T) {
+	result, _ := bakeDevFocus(t)
+	people := result.GetIdentities()
+	require.Len(t, people, 2)
+	one, two := 0, 1
+	if people[0] != "one|one@srcd" {
+		one, two = two, one
+	}
+	assert.Equal(t, 24*time.Hour, result.GetTickSize())
+	require.Len(t, result.Developers, 2)
+	timeline := result.Developers[one]
+	assert.Equal(t, 2, timeline.Total.Files)
+	assert.Equal(t, int64(4), timeline.Total.Edits)
+	assert.InDelta(t, 0.811278, timeline.Total.Entropy, 1e-6)
+	assert.InDelta(t, 0.25, timeline.Total.Gini, 1e-9)
+	assert.Equal(t, map[int]DevFocus{
+		0: {Files: 2, Edits: 2, Entropy: 1, Focus: 0.5},
+		1: {Files: 1, Edits: 1, Focus: 1},
+		2: {Files: 1, Edits: 1, Focus: 1},
+	}, timeline.Ticks)
+	timeline = result.Developers[two]
+	assert.Equal(t, 3, timeline.Total.Files)
+	assert.InDelta(t, math.Log2(3), timeline.Total.Entropy, 1e-9)
+	assert.Equal(t, 0.0, timeline.Total.Gini)
+	assert.InDelta(t, 1.0/3, timeline.Total.Focus, 1e-9)
+	assert.Len(t, timeline.Ticks, 1)
+	assert.Equal(t, timeline.Total, timeline.Ticks[2])
+}
+
+func TestDevFocusSerialize(t *testing.T) {
+	focus := fixtureDevFocus()
+	result := DevFocusResult{
+		Developers: map[int]*DevFocusTimeline{
+			1: {
+				Total: DevFocus{Files: 2, Edits: 4, Entropy: 0.811278, Gini: 0.25, Focus: 0.569877},
+				Ticks: map[int]DevFocus{
+					3: {Files: 1, Edits: 1, Focus: 1},
+					0: {Files: 2, Edits: 3, Entropy: 0.918296, Gini: 0.166667, Focus: 0.529134},
+				},
+			},
+			0: {
+				Total: DevFocus{Files: 1, Edits: 1, Focus: 1},
+				Ticks: map[int]DevFocus{2: {Files: 1, Edits: 1, Focus: 1}},
+			},
+		},
+		reversedPeopleDict: []string{"one", "two"},
+		tickSize:           24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, focus.Serialize(result, false, buffer))
+	assert.Equal(t, `  developers:
+    0:
+      total: {files: 1, edits: 1, entropy: 0.0000, gini: 0.0000, focus: 1.0000}
+      ticks:
+        2: {files: 1, edits: 1, entropy: 0.0000, gini: 0.0000, focus: 1.0000}
+    1:
+      total: {files: 2, edits: 4, entropy: 0.8113, gini: 0.2500, focus: 0.5699}
+      ticks:
+        0: {files: 2, edits: 3, entropy: 0.9183, gini: 0.1667, focus: 0.5291}
+        3: {files: 1, edits: 1, entropy: 0.0000, gini: 0.0000, focus: 1.0000}
+  people:
+  - "one"
+  - "two"
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, focus.Serialize(result, true, buffer))
+	msg := pb.DevFocusAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []string{"one", "two"}, msg.DevIndex)
+	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	assert.Len(t, msg.Developers, 2)
+	assert.Equal(t, int64(4), msg.Developers[1].Total.Edits)
+	assert.Equal(t, 0.166667, msg.Developers[1].Ticks[0].Gini)
+	deserialized, err := focus.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+
+	msg.Developers[0].Total = nil
+	serialized, err := proto.Marshal(&msg)
+	assert.NoError(t, err)
+	_, err = focus.Deserialize(serialized)
+	assert.Error(t, err)
+}
@@ -0,0 +1,267 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// DevMergeRatioAnalysis counts the merge and the regular commits of each developer in each tick
+// to tell the integrators from the feature authors. It is a LeafPipelineItem.
+type DevMergeRatioAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+
+	// commits maps developers to ticks to the commit counts.
+	commits map[int]map[int]*DevMergeCounts
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// DevMergeCounts is the number of the merge and the regular commits.
+type DevMergeCounts struct {
+	// Merges is the number of the merge commits.
+	Merges int
+	// Regular is the number of the commits with a single parent.
+	Regular int
+}
+
+// DevMergeRatioResult is returned by DevMergeRatioAnalysis.Finalize() and carries the merge
+// and the regular commit counts of each developer in each tick.
+type DevMergeRatioResult struct {
+	// Developers maps the developer indexes to the ticks with their commits to the counts.
+	Developers map[int]map[int]DevMergeCounts
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ratio *DevMergeRatioAnalysis) Name() string {
+	return "DevMergeRatio"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (ratio *DevMergeRatioAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (ratio *DevMergeRatioAnalysis) Requires() []string {
+	return []string{identity.DependencyAuthor, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ratio *DevMergeRatioAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ratio *DevMergeRatioAnalysis) Flag() string {
+	return "dev-merge-ratio"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (ratio *DevMergeRatioAnalysis) Description() string {
+	return "Counts the merge and the regular commits of each developer in each tick " +
+		"to tell the integrators from the feature authors."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ratio *DevMergeRatioAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		ratio.l = l
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		ratio.reversedPeopleDict = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		ratio.tickSize = val
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ratio *DevMergeRatioAnalysis) Initialize(repository *git.Repository) error {
+	if ratio.l == nil {
+		ratio.l = core.NewLogger()
+	}
+	ratio.commits = map[int]map[int]*DevMergeCounts{}
+	if ratio.tickSize == 0 {
+		ratio.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	ratio.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ratio *DevMergeRatioAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !ratio.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		return nil, nil
+	}
+	tick := deps[items.DependencyTick].(int)
+	ticks := ratio.commits[author]
+	if ticks == nil {
+		ticks = map[int]*DevMergeCounts{}
+		ratio.commits[author] = ticks
+	}
+	counts := ticks[tick]
+	if counts == nil {
+		counts = &DevMergeCounts{}
+		ticks[tick] = counts
+	}
+	if deps[core.DependencyIsMerge].(bool) {
+		counts.Merges++
+	} else {
+		counts.Regular++
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ratio *DevMergeRatioAnalysis) Finalize() interface{} {
+	result := DevMergeRatioResult{
+		Developers:         map[int]map[int]DevMergeCounts{},
+		reversedPeopleDict: ratio.reversedPeopleDict,
+		tickSize:           ratio.tickSize,
+	}
+	for dev, ticks := range ratio.commits {
+		devTicks := map[int]DevMergeCounts{}
+		for tick, counts := range ticks {
+			devTicks[tick] = *counts
+		}
+		result.Developers[dev] = devTicks
+	}
+	return result
+}
+
+// Fork clones this PipelineItem.
+func (ratio *DevMergeRatioAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(ratio, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ratio *DevMergeRatioAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	ratioResult := result.(DevMergeRatioResult)
+	if binary {
+		return ratio.serializeBinary(&ratioResult, writer)
+	}
+	ratio.serializeText(&ratioResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to DevMergeRatioResult.
+func (ratio *DevMergeRatioAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.DevMergeRatioAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := DevMergeRatioResult{
+		Developers:         map[int]map[int]DevMergeCounts{},
+		reversedPeopleDict: message.DevIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}
+	for dev, devTicks := range message.Developers {
+		ticks := map[int]DevMergeCounts{}
+		for tick, counts := range devTicks.Ticks {
+			ticks[int(tick)] = DevMergeCounts{Merges: int(counts.Merges), Regular: int(counts.Regular)}
+		}
+		result.Developers[int(dev)] = ticks
+	}
+	return result, nil
+}
+
+func (ratio *DevMergeRatioAnalysis) serializeText(result *DevMergeRatioResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  developers:")
+	devs := make([]int, 0, len(result.Developers))
+	for dev := range result.Developers {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	for _, dev := range devs {
+		devTicks := result.Developers[dev]
+		fmt.Fprintf(writer, "    %d:\n", dev)
+		ticks := make([]int, 0, len(devTicks))
+		for tick := range devTicks {
+			ticks = append(ticks, tick)
+		}
+		sort.Ints(ticks)
+		for _, tick := range ticks {
+			counts := devTicks[tick]
+			fmt.Fprintf(writer, "      %d: {merges: %d, regular: %d}\n",
+				tick, counts.Merges, counts.Regular)
+		}
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (ratio *DevMergeRatioAnalysis) serializeBinary(result *DevMergeRatioResult, writer io.Writer) error {
+	message := pb.DevMergeRatioAnalysisResults{
+		Developers: map[int32]*pb.DevMergeRatioTicks{},
+		DevIndex:   result.reversedPeopleDict,
+		TickSize:   int64(result.tickSize),
+	}
+	for dev, devTicks := range result.Developers {
+		ticks := map[int32]*pb.DevMergeCounts{}
+		for tick, counts := range devTicks {
+			ticks[int32(tick)] = &pb.DevMergeCounts{
+				Merges: int32(counts.Merges), Regular: int32(counts.Regular)}
+		}
+		message.Developers[int32(dev)] = &pb.DevMergeRatioTicks{Ticks: ticks}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this merge ratio result.
+func (dmr DevMergeRatioResult) GetTickSize() time.Duration {
+	return dmr.tickSize
+}
+
+// GetIdentities returns the list of developer identities used to generate this merge ratio
+// result. The format is |-joined keys, see internals/plumbing/identity for details.
+func (dmr DevMergeRatioResult) GetIdentities() []string {
+	return dmr.reversedPeopleDict
+}
+
+func init() {
+	core.Registry.Register(&DevMergeRatioAnalysis{})
+}
@@ -0,0 +1,175 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureDevMergeRatio() *DevMergeRatioAnalysis {
+	ratio := DevMergeRatioAnalysis{}
+	ratio.Initialize(test.Repository)
+	ratio.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	})
+	return &ratio
+}
+
+func TestDevMergeRatioMeta(t *testing.T) {
+	ratio := fixtureDevMergeRatio()
+	assert.Equal(t, ratio.Name(), "DevMergeRatio")
+	assert.Len(t, ratio.Provides(), 0)
+	assert.Equal(t, []string{identity.DependencyAuthor, items.DependencyTick}, ratio.Requires())
+	assert.Len(t, ratio.ListConfigurationOptions(), 0)
+	assert.Equal(t, ratio.Flag(), "dev-merge-ratio")
+	assert.NotEmpty(t, ratio.Description())
+	assert.Equal(t, 24*time.Hour, ratio.tickSize)
+	logger := core.NewLogger()
+	assert.NoError(t, ratio.Configure(map[string]interface{}{
+		core.ConfigLogger:  logger,
+		items.FactTickSize: time.Hour,
+	}))
+	assert.Equal(t, logger, ratio.l)
+	assert.Equal(t, time.Hour, ratio.tickSize)
+	assert.Equal(t, []string{"one", "two"}, ratio.reversedPeopleDict)
+}
+
+func TestDevMergeRatioRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&DevMergeRatioAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "DevMergeRatio")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&DevMergeRatioAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestDevMergeRatioFork(t *testing.T) {
+	ratio1 := fixtureDevMergeRatio()
+	clones := ratio1.Fork(1)
+	assert.Len(t, clones, 1)
+	ratio2 := clones[0].(*DevMergeRatioAnalysis)
+	assert.True(t, ratio1 == ratio2)
+	ratio1.Merge([]core.PipelineItem{ratio2})
+}
+
+func TestDevMergeRatioConsumeFinalize(t *testing.T) {
+	ratio := fixtureDevMergeRatio()
+	merge := &object.Commit{Hash: plumbing.NewHash("cce947b98a050c6d356bc6ba95030254914027b1"),
+		ParentHashes: []plumbing.Hash{plumbing.ZeroHash, plumbing.ZeroHash}}
+	for _, c := range []struct {
+		author int
+		tick   int
+		commit *object.Commit
+	}{
+		{0, 0, &object.Commit{}},
+		{0, 0, &object.Commit{}},
+		{1, 1, &object.Commit{}},
+		{identity.AuthorMissing, 1, &object.Commit{}},
+		{1, 2, merge},
+		// the same merge commit in the other branch
+		{1, 2, merge},
+		{0, 2, &object.Commit{}},
+	} {
+		result, err := ratio.Consume(map[string]interface{}{
+			identity.DependencyAuthor: c.author,
+			items.DependencyTick:      c.tick,
+			core.DependencyCommit:     c.commit,
+			core.DependencyIsMerge:    c.commit.NumParents() > 1,
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	}
+	result := ratio.Finalize().(DevMergeRatioResult)
+	assert.Equal(t, map[int]map[int]DevMergeCounts{
+		0: {0: {Regular: 2}, 2: {Regular: 1}},
+		1: {1: {Regular: 1}, 2: {Merges: 1}},
+	}, result.Developers)
+	assert.Equal(t, []string{"one", "two"}, result.GetIdentities())
+	assert.Equal(t, 24*time.Hour, result.GetTickSize())
+}
+
+func TestDevMergeRatioPipeline(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"a.go": "a\n"}},
+		{Author: "two", When: when.Add(time.Hour), Files: map[string]string{"b.go": "b\n"}},
+		{Author: "two", When: when.Add(2 * time.Hour), Parents: []int{0},
+			Files: map[string]string{"c.go": "c\n"}},
+		{Author: "one", When: when.Add(24 * time.Hour), Parents: []int{1, 2},
+			Files: map[string]string{"c.go": "c\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	ratio := pipeline.DeployItem(&DevMergeRatioAnalysis{}).(*DevMergeRatioAnalysis)
+	commits, err := pipeline.Commits(false)
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	results, err := pipeline.Run(commits)
+	require.NoError(t, err)
+	result := results[ratio].(DevMergeRatioResult)
+	people := result.GetIdentities()
+	require.Len(t, people, 2)
+	one, two := 0, 1
+	if people[0] != "one|one@srcd" {
+		one, two = two, one
+	}
+	assert.Equal(t, map[int]map[int]DevMergeCounts{
+		one: {0: {Regular: 1}, 1: {Merges: 1}},
+		two: {0: {Regular: 2}},
+	}, result.Developers)
+}
+
+func TestDevMergeRatioSerialize(t *testing.T) {
+	ratio := fixtureDevMergeRatio()
+	result := DevMergeRatioResult{
+		Developers: map[int]map[int]DevMergeCounts{
+			1: {3: {Merges: 2, Regular: 1}, 0: {Regular: 4}},
+			0: {2: {Merges: 1}},
+		},
+		reversedPeopleDict: []string{"one", "two"},
+		tickSize:           24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, ratio.Serialize(result, false, buffer))
+	assert.Equal(t, `  developers:
+    0:
+      2: {merges: 1, regular: 0}
+    1:
+      0: {merges: 0, regular: 4}
+      3: {merges: 2, regular: 1}
+  people:
+  - "one"
+  - "two"
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, ratio.Serialize(result, true, buffer))
+	msg := pb.DevMergeRatioAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []string{"one", "two"}, msg.DevIndex)
+	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	assert.Len(t, msg.Developers, 2)
+	assert.Equal(t, int32(2), msg.Developers[1].Ticks[3].Merges)
+	deserialized, err := ratio.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
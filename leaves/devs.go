@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -33,6 +34,8 @@ type DevsAnalysis struct {
 	ticks map[int]map[int]*DevTick
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
+	// normalizeIdentities references IdentityDetector.Normalize, see MergeResults().
+	normalizeIdentities bool
 	// TickSize references TicksSinceStart.TickSize
 	tickSize time.Duration
 
@@ -44,6 +47,10 @@ type DevsAnalysis struct {
 type DevsResult struct {
 	// Ticks is <tick index> -> <developer index> -> daily stats
 	Ticks map[int]map[int]*DevTick
+	// ActiveDevsPerTick is <tick index> -> the number of distinct developers who committed
+	// in that tick. The unmatched authors (identity.AuthorMissing) are not counted because
+	// they cannot be told apart.
+	ActiveDevsPerTick []int
 
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
@@ -102,6 +109,9 @@ func (devs *DevsAnalysis) Configure(facts map[string]interface{}) error {
 	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
 		devs.l = l
 	}
+	if val, exists := facts[identity.ConfigIdentityDetectorNormalize].(bool); exists {
+		devs.normalizeIdentities = val
+	}
 	if val, exists := facts[ConfigDevsConsiderEmptyCommits].(bool); exists {
 		devs.ConsiderEmptyCommits = val
 	}
@@ -130,7 +140,9 @@ func (devs *DevsAnalysis) Initialize(repository *git.Repository) error {
 	if devs.tickSize == 0 {
 		return errors.New("tick size must be specified")
 	}
-	devs.l = core.NewLogger()
+	if devs.l == nil {
+		devs.l = core.NewLogger()
+	}
 	devs.ticks = map[int]map[int]*DevTick{}
 	devs.OneShotMergeProcessor.Initialize()
 	return nil
@@ -188,6 +200,7 @@ func (devs *DevsAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 func (devs *DevsAnalysis) Finalize() interface{} {
 	return DevsResult{
 		Ticks:              devs.ticks,
+		ActiveDevsPerTick:  activeDevsPerTick(devs.ticks),
 		reversedPeopleDict: devs.reversedPeopleDict,
 		tickSize:           devs.tickSize,
 	}
@@ -245,6 +258,7 @@ func (devs *DevsAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
 	}
 	result := DevsResult{
 		Ticks:              ticks,
+		ActiveDevsPerTick:  activeDevsPerTick(ticks),
 		reversedPeopleDict: message.DevIndex,
 		tickSize:           time.Duration(message.TickSize),
 	}
@@ -256,7 +270,7 @@ func (devs *DevsAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAn
 	cr1 := r1.(DevsResult)
 	cr2 := r2.(DevsResult)
 	if cr1.tickSize != cr2.tickSize {
-		return fmt.Errorf("mismatching tick sizes (r1: %d, r2: %d) received",
+		return fmt.Errorf("mismatching tick sizes (r1: %v, r2: %v) received",
 			cr1.tickSize, cr2.tickSize)
 	}
 	t01 := items.FloorTime(c1.BeginTimeAsTime(), cr1.tickSize)
@@ -271,7 +285,7 @@ func (devs *DevsAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAn
 	merged := DevsResult{tickSize: cr1.tickSize}
 	var mergedIndex map[string]identity.MergedIndex
 	mergedIndex, merged.reversedPeopleDict = identity.MergeReversedDictsIdentities(
-		cr1.reversedPeopleDict, cr2.reversedPeopleDict)
+		cr1.reversedPeopleDict, cr2.reversedPeopleDict, devs.normalizeIdentities)
 	newticks := map[int]map[int]*DevTick{}
 	merged.Ticks = newticks
 	for tick, dd := range cr1.Ticks {
@@ -336,9 +350,33 @@ func (devs *DevsAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAn
 			}
 		}
 	}
+	// the developers are united by the merged identities, so they are not counted twice
+	merged.ActiveDevsPerTick = activeDevsPerTick(newticks)
 	return merged
 }
 
+// activeDevsPerTick counts the distinct developers in each tick, see DevsResult.ActiveDevsPerTick.
+func activeDevsPerTick(ticks map[int]map[int]*DevTick) []int {
+	maxTick := -1
+	for tick := range ticks {
+		if tick > maxTick {
+			maxTick = tick
+		}
+	}
+	if maxTick < 0 {
+		return nil
+	}
+	result := make([]int, maxTick+1)
+	for tick, dd := range ticks {
+		for dev := range dd {
+			if dev != identity.AuthorMissing {
+				result[tick]++
+			}
+		}
+	}
+	return result
+}
+
 func (devs *DevsAnalysis) serializeText(result *DevsResult, writer io.Writer) {
 	fmt.Fprintln(writer, "  ticks:")
 	ticks := make([]int, len(result.Ticks))
@@ -381,6 +419,11 @@ func (devs *DevsAnalysis) serializeText(result *DevsResult, writer io.Writer) {
 				strings.Join(langs, ", "))
 		}
 	}
+	active := make([]string, len(result.ActiveDevsPerTick))
+	for i, count := range result.ActiveDevsPerTick {
+		active[i] = strconv.Itoa(count)
+	}
+	fmt.Fprintf(writer, "  active_devs: [%s]\n", strings.Join(active, ", "))
 	fmt.Fprintln(writer, "  people:")
 	for _, person := range result.reversedPeopleDict {
 		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
@@ -392,6 +435,10 @@ func (devs *DevsAnalysis) serializeBinary(result *DevsResult, writer io.Writer)
 	message := pb.DevsAnalysisResults{}
 	message.DevIndex = result.reversedPeopleDict
 	message.TickSize = int64(result.tickSize)
+	message.ActiveDevs = make([]int32, len(result.ActiveDevsPerTick))
+	for i, count := range result.ActiveDevsPerTick {
+		message.ActiveDevs[i] = int32(count)
+	}
 	message.Ticks = map[int32]*pb.TickDevs{}
 	for tick, devs := range result.Ticks {
 		dd := &pb.TickDevs{}
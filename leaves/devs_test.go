@@ -284,6 +284,7 @@ func TestDevsFinalize(t *testing.T) {
 	devs.ticks[1][1] = &DevTick{10, ls(20, 30, 40), nil}
 	x := devs.Finalize().(DevsResult)
 	assert.Equal(t, x.Ticks, devs.ticks)
+	assert.Equal(t, []int{0, 1}, x.ActiveDevsPerTick)
 	assert.Equal(t, x.reversedPeopleDict, devs.reversedPeopleDict)
 	assert.Equal(t, 24*time.Hour, devs.tickSize)
 }
@@ -314,6 +315,7 @@ func TestDevsSerialize(t *testing.T) {
     10:
       0: [11, 21, 31, 41, {none: [12, 13, 14]}]
       -1: [100, 200, 300, 400, {Go: [32, 33, 34]}]
+  active_devs: [0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 1]
   people:
   - "one@srcd"
   - "two@srcd"
@@ -327,6 +329,7 @@ func TestDevsSerialize(t *testing.T) {
 	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
 	assert.Equal(t, msg.DevIndex, devs.reversedPeopleDict)
 	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	assert.Equal(t, []int32{0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 1}, msg.ActiveDevs)
 	assert.Len(t, msg.Ticks, 2)
 	assert.Len(t, msg.Ticks[1].Devs, 2)
 	assert.Equal(t, msg.Ticks[1].Devs[0], &pb.DevTick{
@@ -405,6 +408,8 @@ func TestDevsMergeResults(t *testing.T) {
 	peoplerm := [...]string{"1@srcd", "2@srcd", "3@srcd"}
 	assert.Equal(t, rm.reversedPeopleDict, peoplerm[:])
 	assert.Len(t, rm.Ticks, 4)
+	// 1@srcd is active in tick 1 of both repositories and is counted once
+	assert.Equal(t, []int{0, 3, 1, 0, 0, 0, 0, 0, 0, 0, 2, 1}, rm.ActiveDevsPerTick)
 	assert.Equal(t, rm.Ticks[11], map[int]*DevTick{
 		1: {10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(42, 43, 44)}}})
 	assert.Equal(t, rm.Ticks[2], map[int]*DevTick{
@@ -451,6 +456,7 @@ func TestDevsMergeResults(t *testing.T) {
 
 func TestDevsResultGetters(t *testing.T) {
 	dr := DevsResult{tickSize: time.Hour, reversedPeopleDict: []string{"one", "two"}}
+	assert.Nil(t, activeDevsPerTick(map[int]map[int]*DevTick{}))
 	assert.Equal(t, dr.tickSize, dr.GetTickSize())
 	assert.Equal(t, dr.GetIdentities(), dr.reversedPeopleDict)
 }
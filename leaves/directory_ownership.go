@@ -0,0 +1,326 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// DirectoryOwnershipAnalysis calculates how many surviving lines each developer owns
+// in each directory at the end of each tick. It tracks the line ownership with the embedded
+// lineTracker, like BusFactorAnalysis. It is a LeafPipelineItem.
+type DirectoryOwnershipAnalysis struct {
+	lineTracker
+	// Depth is the number of leading path components which make the directory prefix.
+	Depth int
+
+	// ticks is the ownership snapshot at the end of each tick. It is shared between the forks,
+	// so the branch which finishes the tick last wins.
+	ticks map[int]map[string]map[int]int64
+	// tick is the tick of the last consumed commit.
+	tick int
+	// dirty indicates whether the last consumed commit is not in `ticks` yet.
+	dirty bool
+
+	l core.Logger
+}
+
+// DirectoryOwnershipResult is returned by DirectoryOwnershipAnalysis.Finalize().
+type DirectoryOwnershipResult struct {
+	// Ticks maps ticks to directory prefixes to developer indexes to the number of owned lines.
+	// The ticks without commits are omitted, the ownership is the same as in the previous tick.
+	// The directory prefixes end with "/", the files in the root belong to "".
+	// -1 stands for the unidentified developers.
+	Ticks map[int]map[string]map[int]int64
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// depth is copied from DirectoryOwnershipAnalysis.Depth.
+	depth int
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+const (
+	// ConfigDirectoryOwnershipDepth is the name of the option to set
+	// DirectoryOwnershipAnalysis.Depth.
+	ConfigDirectoryOwnershipDepth = "DirectoryOwnership.Depth"
+	// DefaultDirectoryOwnershipDepth is the default value of DirectoryOwnershipAnalysis.Depth:
+	// the top-level directories.
+	DefaultDirectoryOwnershipDepth = 1
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *DirectoryOwnershipAnalysis) Name() string {
+	return "DirectoryOwnership"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *DirectoryOwnershipAnalysis) Provides() []string {
+	return []string{}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *DirectoryOwnershipAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{{
+		Name:        ConfigDirectoryOwnershipDepth,
+		Description: "How many leading path components make the directory prefix.",
+		Flag:        "directory-ownership-depth",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultDirectoryOwnershipDepth},
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *DirectoryOwnershipAnalysis) Flag() string {
+	return "directory-ownership"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *DirectoryOwnershipAnalysis) Description() string {
+	return "Calculates how many surviving lines each developer owns in each directory " +
+		"at the end of each tick."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *DirectoryOwnershipAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		analyser.l = l
+	}
+	if val, exists := facts[ConfigDirectoryOwnershipDepth].(int); exists {
+		if val <= 0 {
+			return fmt.Errorf("%s must be positive: %d", ConfigDirectoryOwnershipDepth, val)
+		}
+		analyser.Depth = val
+	}
+	return analyser.configure(facts, true)
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *DirectoryOwnershipAnalysis) Initialize(repository *git.Repository) error {
+	if analyser.l == nil {
+		analyser.l = core.NewLogger()
+	}
+	if analyser.Depth <= 0 {
+		analyser.Depth = DefaultDirectoryOwnershipDepth
+	}
+	analyser.ticks = map[int]map[string]map[int]int64{}
+	analyser.tick = 0
+	analyser.dirty = false
+	return analyser.initialize(repository)
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *DirectoryOwnershipAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	tick := deps[items.DependencyTick].(int)
+	if analyser.dirty && tick != analyser.tick {
+		analyser.snapshot()
+	}
+	result, err := analyser.lineTracker.Consume(deps)
+	if err != nil {
+		return nil, err
+	}
+	analyser.tick = tick
+	analyser.dirty = true
+	return result, nil
+}
+
+// Fork clones this item. The files are copied by value.
+func (analyser *DirectoryOwnershipAnalysis) Fork(n int) []core.PipelineItem {
+	result := make([]core.PipelineItem, n)
+	for i, tracker := range analyser.fork(n) {
+		clone := *analyser
+		clone.lineTracker = tracker
+		result[i] = &clone
+	}
+	return result
+}
+
+// Merge combines several items together. The file merging logic belongs to BurndownAnalysis.
+func (analyser *DirectoryOwnershipAnalysis) Merge(branches []core.PipelineItem) {
+	trackers := make([]lineTracker, len(branches))
+	for i, branch := range branches {
+		trackers[i] = branch.(*DirectoryOwnershipAnalysis).lineTracker
+	}
+	analyser.merge(trackers)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *DirectoryOwnershipAnalysis) Finalize() interface{} {
+	if analyser.dirty {
+		analyser.snapshot()
+	}
+	return DirectoryOwnershipResult{
+		Ticks:              analyser.ticks,
+		reversedPeopleDict: analyser.burndown.reversedPeopleDict,
+		depth:              analyser.Depth,
+		tickSize:           analyser.burndown.TickSize,
+	}
+}
+
+// snapshot aggregates the current file ownership by directory and records it for the tick
+// of the last consumed commit.
+func (analyser *DirectoryOwnershipAnalysis) snapshot() {
+	dirs := map[string]map[int]int64{}
+	for path, file := range analyser.burndown.files {
+		dir := directoryPrefix(path, analyser.Depth)
+		owned := dirs[dir]
+		if owned == nil {
+			owned = map[int]int64{}
+			dirs[dir] = owned
+		}
+		for author, lines := range analyser.burndown.fileOwnership(file) {
+			if lines > 0 {
+				owned[author] += int64(lines)
+			}
+		}
+		if len(owned) == 0 {
+			delete(dirs, dir)
+		}
+	}
+	analyser.ticks[analyser.tick] = dirs
+	analyser.dirty = false
+}
+
+// directoryPrefix returns the first `depth` directories of `path` ending with "/".
+// The files in the root directory yield "".
+func directoryPrefix(path string, depth int) string {
+	end := 0
+	for i := 0; i < depth; i++ {
+		slash := strings.IndexByte(path[end:], '/')
+		if slash < 0 {
+			break
+		}
+		end += slash + 1
+	}
+	return path[:end]
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *DirectoryOwnershipAnalysis) Serialize(
+	result interface{}, binary bool, writer io.Writer) error {
+	ownershipResult := result.(DirectoryOwnershipResult)
+	if binary {
+		return analyser.serializeBinary(&ownershipResult, writer)
+	}
+	analyser.serializeText(&ownershipResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to DirectoryOwnershipResult.
+func (analyser *DirectoryOwnershipAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.DirectoryOwnershipAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	ticks := map[int]map[string]map[int]int64{}
+	for tick, tickDirs := range message.Ticks {
+		dirs := map[string]map[int]int64{}
+		ticks[int(tick)] = dirs
+		for dir, ownership := range tickDirs.Directories {
+			owned := map[int]int64{}
+			dirs[dir] = owned
+			for author, lines := range ownership.Lines {
+				owned[int(author)] = lines
+			}
+		}
+	}
+	return DirectoryOwnershipResult{
+		Ticks:              ticks,
+		reversedPeopleDict: message.DevIndex,
+		depth:              int(message.Depth),
+		tickSize:           time.Duration(message.TickSize),
+	}, nil
+}
+
+func (analyser *DirectoryOwnershipAnalysis) serializeText(
+	result *DirectoryOwnershipResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  ticks:")
+	ticks := make([]int, 0, len(result.Ticks))
+	for tick := range result.Ticks {
+		ticks = append(ticks, tick)
+	}
+	sort.Ints(ticks)
+	for _, tick := range ticks {
+		fmt.Fprintf(writer, "    %d:\n", tick)
+		dirs := result.Ticks[tick]
+		paths := make([]string, 0, len(dirs))
+		for dir := range dirs {
+			paths = append(paths, dir)
+		}
+		sort.Strings(paths)
+		for _, dir := range paths {
+			owned := dirs[dir]
+			authors := make([]int, 0, len(owned))
+			for author := range owned {
+				authors = append(authors, author)
+			}
+			sort.Ints(authors)
+			pairs := make([]string, len(authors))
+			for i, author := range authors {
+				pairs[i] = fmt.Sprintf("%d: %d", author, owned[author])
+			}
+			fmt.Fprintf(writer, "      %s: {%s}\n", yaml.SafeString(dir), strings.Join(pairs, ", "))
+		}
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "  depth:", result.depth)
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (analyser *DirectoryOwnershipAnalysis) serializeBinary(
+	result *DirectoryOwnershipResult, writer io.Writer) error {
+	message := pb.DirectoryOwnershipAnalysisResults{
+		Ticks:    map[int32]*pb.TickDirectoryOwnership{},
+		DevIndex: result.reversedPeopleDict,
+		Depth:    int32(result.depth),
+		TickSize: int64(result.tickSize),
+	}
+	for tick, dirs := range result.Ticks {
+		tickDirs := &pb.TickDirectoryOwnership{Directories: map[string]*pb.DirectoryOwnership{}}
+		message.Ticks[int32(tick)] = tickDirs
+		for dir, owned := range dirs {
+			ownership := &pb.DirectoryOwnership{Lines: map[int32]int64{}}
+			tickDirs.Directories[dir] = ownership
+			for author, lines := range owned {
+				ownership.Lines[int32(author)] = lines
+			}
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this directory ownership result.
+func (dor DirectoryOwnershipResult) GetTickSize() time.Duration {
+	return dor.tickSize
+}
+
+func init() {
+	core.Registry.Register(&DirectoryOwnershipAnalysis{})
+}
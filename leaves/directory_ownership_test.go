@@ -0,0 +1,161 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureDirectoryOwnership() *DirectoryOwnershipAnalysis {
+	do := DirectoryOwnershipAnalysis{}
+	do.Initialize(test.Repository)
+	return &do
+}
+
+func TestDirectoryOwnershipMeta(t *testing.T) {
+	do := fixtureDirectoryOwnership()
+	assert.Equal(t, do.Name(), "DirectoryOwnership")
+	assert.Len(t, do.Provides(), 0)
+	assert.Equal(t, (&BurndownAnalysis{}).Requires(), do.Requires())
+	opts := do.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, ConfigDirectoryOwnershipDepth, opts[0].Name)
+	assert.Equal(t, do.Flag(), "directory-ownership")
+	assert.NotEmpty(t, do.Description())
+	assert.Equal(t, DefaultDirectoryOwnershipDepth, do.Depth)
+	logger := core.NewLogger()
+	people := []string{"one@srcd", "two@srcd"}
+	assert.NoError(t, do.Configure(map[string]interface{}{
+		core.ConfigLogger:                               logger,
+		ConfigDirectoryOwnershipDepth:                   2,
+		ConfigBurndownTrackPeople:                       false,
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: people,
+	}))
+	assert.Equal(t, logger, do.l)
+	assert.Equal(t, 2, do.Depth)
+	assert.Equal(t, 2, do.burndown.PeopleNumber)
+	assert.Equal(t, people, do.burndown.reversedPeopleDict)
+	assert.Error(t, do.Configure(map[string]interface{}{ConfigDirectoryOwnershipDepth: 0}))
+}
+
+func TestDirectoryOwnershipRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&DirectoryOwnershipAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "DirectoryOwnership")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&DirectoryOwnershipAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestDirectoryOwnershipFork(t *testing.T) {
+	do1 := fixtureDirectoryOwnership()
+	clones := do1.Fork(1)
+	assert.Len(t, clones, 1)
+	do2 := clones[0].(*DirectoryOwnershipAnalysis)
+	assert.True(t, do1 != do2)
+	assert.True(t, do1.burndown != do2.burndown)
+	do2.ticks[1] = map[string]map[int]int64{}
+	assert.Len(t, do1.ticks, 1)
+	do1.Merge([]core.PipelineItem{do2})
+	assert.NoError(t, do1.Hibernate())
+	assert.NoError(t, do1.Boot())
+}
+
+func TestDirectoryOwnershipPrefix(t *testing.T) {
+	assert.Equal(t, "", directoryPrefix("README.md", 1))
+	assert.Equal(t, "leaves/", directoryPrefix("leaves/burndown.go", 1))
+	assert.Equal(t, "internal/", directoryPrefix("internal/core/pipeline.go", 1))
+	assert.Equal(t, "internal/core/", directoryPrefix("internal/core/pipeline.go", 2))
+	assert.Equal(t, "internal/core/", directoryPrefix("internal/core/pipeline.go", 3))
+}
+
+func TestDirectoryOwnershipConsumeFinalize(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "zoe", When: when, Parents: []int{}, Files: map[string]string{
+			"a/x.go": "1\n2\n3\n", "README": "r\n"}},
+		{Author: "adam", When: when.Add(time.Hour), Files: map[string]string{
+			"a/b/y.go": "1\n2\n"}},
+		{Author: "adam", When: when.Add(48 * time.Hour), Files: map[string]string{
+			"a/x.go": "1\n2\nnew\n"}},
+	})
+	assert.NoError(t, err)
+	run := func(depth int) DirectoryOwnershipResult {
+		pipeline := core.NewPipeline(repository)
+		pipeline.PrintActions = false
+		do := pipeline.DeployItem(&DirectoryOwnershipAnalysis{}).(*DirectoryOwnershipAnalysis)
+		commits, err := pipeline.Commits(false)
+		assert.NoError(t, err)
+		assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+			ConfigDirectoryOwnershipDepth: depth,
+		}))
+		results, err := pipeline.Run(commits)
+		assert.NoError(t, err)
+		return results[do].(DirectoryOwnershipResult)
+	}
+	result := run(1)
+	assert.Equal(t, []string{"adam|adam@srcd", "zoe|zoe@srcd"}, result.reversedPeopleDict)
+	assert.Equal(t, 1, result.depth)
+	assert.Equal(t, 24*time.Hour, result.GetTickSize())
+	assert.Equal(t, map[int]map[string]map[int]int64{
+		0: {"": {1: 1}, "a/": {0: 2, 1: 3}},
+		2: {"": {1: 1}, "a/": {0: 3, 1: 2}},
+	}, result.Ticks)
+	result = run(2)
+	assert.Equal(t, map[int]map[string]map[int]int64{
+		0: {"": {1: 1}, "a/": {1: 3}, "a/b/": {0: 2}},
+		2: {"": {1: 1}, "a/": {0: 1, 1: 2}, "a/b/": {0: 2}},
+	}, result.Ticks)
+}
+
+func TestDirectoryOwnershipSerialize(t *testing.T) {
+	do := fixtureDirectoryOwnership()
+	result := DirectoryOwnershipResult{
+		Ticks: map[int]map[string]map[int]int64{
+			0: {"": {-1: 1}, "a/": {1: 3, 0: 2}},
+			2: {"a/": {0: 3}},
+		},
+		reversedPeopleDict: []string{"one", "two"},
+		depth:              1,
+		tickSize:           24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, do.Serialize(result, false, buffer))
+	assert.Equal(t, `  ticks:
+    0:
+      "": {-1: 1}
+      "a/": {0: 2, 1: 3}
+    2:
+      "a/": {0: 3}
+  people:
+  - "one"
+  - "two"
+  depth: 1
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, do.Serialize(result, true, buffer))
+	msg := pb.DirectoryOwnershipAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Ticks, 2)
+	assert.Equal(t, map[int32]int64{0: 2, 1: 3}, msg.Ticks[0].Directories["a/"].Lines)
+	assert.Equal(t, int32(1), msg.Depth)
+	deserialized, err := do.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
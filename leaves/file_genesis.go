@@ -0,0 +1,283 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// FileGenesisAnalysis records the tick and the author of the first insertion of each file,
+// following the renames. It is a LeafPipelineItem.
+type FileGenesisAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	files      map[string]*FileGenesis
+	lastCommit *object.Commit
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// FileGenesis describes the origin of a file.
+type FileGenesis struct {
+	// Tick is the tick of the commit which inserted the file.
+	Tick int
+	// Author is the index of the developer who inserted the file.
+	Author int
+}
+
+// FileGenesisResult is returned by Finalize() and represents the analysis result.
+type FileGenesisResult struct {
+	// Files maps the paths which exist in the last commit to their origins.
+	Files map[string]FileGenesis
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (genesis *FileGenesisAnalysis) Name() string {
+	return "FileGenesisAnalysis"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (genesis *FileGenesisAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (genesis *FileGenesisAnalysis) Requires() []string {
+	return []string{items.DependencyTreeChanges, identity.DependencyAuthor, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (genesis *FileGenesisAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (genesis *FileGenesisAnalysis) Flag() string {
+	return "file-genesis"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (genesis *FileGenesisAnalysis) Description() string {
+	return "Each file path which exists in the last commit is mapped to the tick and the author " +
+		"of the commit which created that file. Renames are followed."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (genesis *FileGenesisAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		genesis.l = l
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		genesis.reversedPeopleDict = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		genesis.tickSize = val
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (genesis *FileGenesisAnalysis) Initialize(repository *git.Repository) error {
+	if genesis.l == nil {
+		genesis.l = core.NewLogger()
+	}
+	genesis.files = map[string]*FileGenesis{}
+	genesis.lastCommit = nil
+	if genesis.tickSize == 0 {
+		genesis.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	genesis.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (genesis *FileGenesisAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !genesis.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	genesis.lastCommit = deps[core.DependencyCommit].(*object.Commit)
+	author := deps[identity.DependencyAuthor].(int)
+	tick := deps[items.DependencyTick].(int)
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			// the file may have been already inserted in a parallel branch
+			if genesis.files[change.To.Name] == nil {
+				genesis.files[change.To.Name] = &FileGenesis{Tick: tick, Author: author}
+			}
+		case merkletrie.Delete:
+			delete(genesis.files, change.From.Name)
+		case merkletrie.Modify:
+			if change.From.Name == change.To.Name {
+				break
+			}
+			origin := genesis.files[change.From.Name]
+			if origin == nil {
+				origin = &FileGenesis{Tick: tick, Author: author}
+			}
+			delete(genesis.files, change.From.Name)
+			genesis.files[change.To.Name] = origin
+		}
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (genesis *FileGenesisAnalysis) Finalize() interface{} {
+	files := map[string]FileGenesis{}
+	result := FileGenesisResult{
+		Files:              files,
+		reversedPeopleDict: genesis.reversedPeopleDict,
+		tickSize:           genesis.tickSize,
+	}
+	if genesis.lastCommit == nil {
+		return result
+	}
+	fileIter, err := genesis.lastCommit.Files()
+	if err != nil {
+		genesis.l.Errorf("Failed to iterate files of %s", genesis.lastCommit.Hash.String())
+		return err
+	}
+	err = fileIter.ForEach(func(file *object.File) error {
+		if origin := genesis.files[file.Name]; origin != nil {
+			files[file.Name] = *origin
+		}
+		return nil
+	})
+	if err != nil {
+		genesis.l.Errorf("Failed to iterate files of %s", genesis.lastCommit.Hash.String())
+		return err
+	}
+	return result
+}
+
+// Fork clones this PipelineItem.
+func (genesis *FileGenesisAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(genesis, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (genesis *FileGenesisAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	genesisResult := result.(FileGenesisResult)
+	if binary {
+		return genesis.serializeBinary(&genesisResult, writer)
+	}
+	genesis.serializeText(&genesisResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to FileGenesisResult.
+func (genesis *FileGenesisAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.FileGenesisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]FileGenesis{}
+	for name, origin := range message.Files {
+		author := int(origin.Author)
+		if author == -1 {
+			author = identity.AuthorMissing
+		}
+		files[name] = FileGenesis{Tick: int(origin.Tick), Author: author}
+	}
+	return FileGenesisResult{
+		Files:              files,
+		reversedPeopleDict: message.AuthorIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}, nil
+}
+
+func (genesis *FileGenesisAnalysis) serializeText(result *FileGenesisResult, writer io.Writer) {
+	keys := make([]string, 0, len(result.Files))
+	for key := range result.Files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Fprintln(writer, "  files:")
+	for _, key := range keys {
+		origin := result.Files[key]
+		author := origin.Author
+		if author == identity.AuthorMissing {
+			author = -1
+		}
+		fmt.Fprintf(writer, "    %s: {tick: %d, author: %d}\n",
+			yaml.SafeString(key), origin.Tick, author)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (genesis *FileGenesisAnalysis) serializeBinary(result *FileGenesisResult, writer io.Writer) error {
+	message := pb.FileGenesisResults{
+		Files:       map[string]*pb.FileGenesis{},
+		AuthorIndex: result.reversedPeopleDict,
+		TickSize:    int64(result.tickSize),
+	}
+	for key, origin := range result.Files {
+		author := origin.Author
+		if author == identity.AuthorMissing {
+			author = -1
+		}
+		message.Files[key] = &pb.FileGenesis{Tick: int32(origin.Tick), Author: int32(author)}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this file genesis result.
+func (fgr FileGenesisResult) GetTickSize() time.Duration {
+	return fgr.tickSize
+}
+
+// GetIdentities returns the list of developer identities used to generate this file genesis result.
+// The format is |-joined keys, see internals/plumbing/identity for details.
+func (fgr FileGenesisResult) GetIdentities() []string {
+	return fgr.reversedPeopleDict
+}
+
+func init() {
+	core.Registry.Register(&FileGenesisAnalysis{})
+}
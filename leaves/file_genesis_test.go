@@ -0,0 +1,162 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureFileGenesis() *FileGenesisAnalysis {
+	fg := FileGenesisAnalysis{}
+	fg.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one@srcd", "two@srcd"},
+	})
+	fg.Initialize(test.Repository)
+	return &fg
+}
+
+func TestFileGenesisMeta(t *testing.T) {
+	fg := fixtureFileGenesis()
+	assert.Equal(t, fg.Name(), "FileGenesisAnalysis")
+	assert.Len(t, fg.Provides(), 0)
+	assert.Len(t, fg.Requires(), 3)
+	assert.Equal(t, fg.Requires()[0], items.DependencyTreeChanges)
+	assert.Equal(t, fg.Requires()[1], identity.DependencyAuthor)
+	assert.Equal(t, fg.Requires()[2], items.DependencyTick)
+	assert.Len(t, fg.ListConfigurationOptions(), 0)
+	assert.Equal(t, fg.Flag(), "file-genesis")
+	assert.Equal(t, fg.tickSize, 24*time.Hour)
+	logger := core.NewLogger()
+	assert.NoError(t, fg.Configure(map[string]interface{}{
+		core.ConfigLogger:  logger,
+		items.FactTickSize: time.Hour,
+	}))
+	assert.Equal(t, logger, fg.l)
+	assert.Equal(t, time.Hour, fg.tickSize)
+	assert.Equal(t, []string{"one@srcd", "two@srcd"}, fg.reversedPeopleDict)
+}
+
+func TestFileGenesisRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&FileGenesisAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "FileGenesisAnalysis")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&FileGenesisAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestFileGenesisFork(t *testing.T) {
+	fg1 := fixtureFileGenesis()
+	clones := fg1.Fork(1)
+	assert.Len(t, clones, 1)
+	fg2 := clones[0].(*FileGenesisAnalysis)
+	assert.True(t, fg1 == fg2)
+	fg1.Merge([]core.PipelineItem{fg2})
+}
+
+func bakeFileGenesis(t *testing.T) *FileGenesisAnalysis {
+	fg := fixtureFileGenesis()
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: plumbing.NewHash(
+				"291286b4ac41952cbd1389fda66420ec03c1a9fe")}}
+	}
+	consume := func(tick, author int, changes ...*object.Change) {
+		deps := map[string]interface{}{
+			core.DependencyCommit:       &object.Commit{},
+			items.DependencyTreeChanges: object.Changes(changes),
+			identity.DependencyAuthor:   author,
+			items.DependencyTick:        tick,
+		}
+		res, err := fg.Consume(deps)
+		assert.Nil(t, res)
+		assert.NoError(t, err)
+	}
+	consume(0, 0,
+		&object.Change{To: entry("analyser.go")},
+		&object.Change{To: entry("README.md")})
+	consume(1, identity.AuthorMissing,
+		&object.Change{From: entry("analyser.go"), To: entry(".travis.yml")},
+		&object.Change{To: entry("cmd/hercules/main.go")},
+		&object.Change{To: entry("labours.py")})
+	consume(2, 1,
+		&object.Change{From: entry("README.md")},
+		&object.Change{To: entry(".travis.yml")},
+		&object.Change{From: entry("labours.py"), To: entry("labours/labours.py")})
+	return fg
+}
+
+func TestFileGenesisConsume(t *testing.T) {
+	fg := bakeFileGenesis(t)
+	assert.Equal(t, map[string]*FileGenesis{
+		".travis.yml":          {Tick: 0, Author: 0},
+		"cmd/hercules/main.go": {Tick: 1, Author: identity.AuthorMissing},
+		"labours/labours.py":   {Tick: 1, Author: identity.AuthorMissing},
+	}, fg.files)
+}
+
+func finalizeFileGenesis(t *testing.T, fg *FileGenesisAnalysis) FileGenesisResult {
+	fg.lastCommit, _ = test.Repository.CommitObject(plumbing.NewHash(
+		"2b1ed978194a94edeabbca6de7ff3b5771d4d665"))
+	return fg.Finalize().(FileGenesisResult)
+}
+
+func TestFileGenesisFinalize(t *testing.T) {
+	fg := bakeFileGenesis(t)
+	res := finalizeFileGenesis(t, fg)
+	assert.Equal(t, map[string]FileGenesis{
+		".travis.yml":          {Tick: 0, Author: 0},
+		"cmd/hercules/main.go": {Tick: 1, Author: identity.AuthorMissing},
+	}, res.Files)
+	assert.Equal(t, []string{"one@srcd", "two@srcd"}, res.GetIdentities())
+	assert.Equal(t, 24*time.Hour, res.GetTickSize())
+}
+
+func TestFileGenesisSerializeText(t *testing.T) {
+	fg := bakeFileGenesis(t)
+	res := finalizeFileGenesis(t, fg)
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, fg.Serialize(res, false, buffer))
+	assert.Equal(t, `  files:
+    ".travis.yml": {tick: 0, author: 0}
+    "cmd/hercules/main.go": {tick: 1, author: -1}
+  people:
+  - "one@srcd"
+  - "two@srcd"
+  tick_size: 86400
+`, buffer.String())
+}
+
+func TestFileGenesisSerializeBinary(t *testing.T) {
+	fg := bakeFileGenesis(t)
+	res := finalizeFileGenesis(t, fg)
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, fg.Serialize(res, true, buffer))
+	msg := pb.FileGenesisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, map[string]*pb.FileGenesis{
+		".travis.yml":          {Tick: 0, Author: 0},
+		"cmd/hercules/main.go": {Tick: 1, Author: -1},
+	}, msg.Files)
+	assert.Equal(t, []string{"one@srcd", "two@srcd"}, msg.AuthorIndex)
+	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	dres, err := fg.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, res, dres)
+}
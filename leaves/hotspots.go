@@ -0,0 +1,279 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// HotspotsAnalysis ranks the files by the number of times they were edited, following
+// the renames. It is a LeafPipelineItem.
+type HotspotsAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Top is the number of the most frequently edited files to report. 0 means all the files.
+	Top int
+
+	// files maps the current file paths to the number of edits in each tick.
+	files map[string]map[int]int64
+	// lastTick is the biggest tick seen so far.
+	lastTick int
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// Hotspot is the edit statistics of a single file.
+type Hotspot struct {
+	// Path is the file name.
+	Path string
+	// Edits is the total number of edits.
+	Edits int64
+	// Series is the number of edits in each tick.
+	Series []int64
+}
+
+// HotspotsResult is returned by HotspotsAnalysis.Finalize() and carries the most frequently
+// edited files.
+type HotspotsResult struct {
+	// Files are sorted by Edits in descending order.
+	Files []Hotspot
+
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+const (
+	// ConfigHotspotsTop is the name of the option to set HotspotsAnalysis.Top.
+	ConfigHotspotsTop = "Hotspots.Top"
+	// DefaultHotspotsTop is the default value of HotspotsAnalysis.Top.
+	DefaultHotspotsTop = 20
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (hotspots *HotspotsAnalysis) Name() string {
+	return "Hotspots"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (hotspots *HotspotsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (hotspots *HotspotsAnalysis) Requires() []string {
+	return []string{items.DependencyTreeChanges, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (hotspots *HotspotsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{{
+		Name:        ConfigHotspotsTop,
+		Description: "The number of the most frequently edited files to report; 0 means all.",
+		Flag:        "hotspots-top",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultHotspotsTop},
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (hotspots *HotspotsAnalysis) Flag() string {
+	return "hotspots"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (hotspots *HotspotsAnalysis) Description() string {
+	return "Ranks the files by the number of commits which edited them and records " +
+		"the number of edits in each tick. Renames are followed."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (hotspots *HotspotsAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		hotspots.l = l
+	}
+	if val, exists := facts[ConfigHotspotsTop].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("%s may not be negative: %d", ConfigHotspotsTop, val)
+		}
+		hotspots.Top = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		hotspots.tickSize = val
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (hotspots *HotspotsAnalysis) Initialize(repository *git.Repository) error {
+	if hotspots.l == nil {
+		hotspots.l = core.NewLogger()
+	}
+	hotspots.files = map[string]map[int]int64{}
+	hotspots.lastTick = 0
+	hotspots.OneShotMergeProcessor.Initialize()
+	if hotspots.tickSize == 0 {
+		hotspots.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (hotspots *HotspotsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !hotspots.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	tick := deps[items.DependencyTick].(int)
+	if tick > hotspots.lastTick {
+		hotspots.lastTick = tick
+	}
+	edit := func(name string) {
+		series := hotspots.files[name]
+		if series == nil {
+			series = map[int]int64{}
+			hotspots.files[name] = series
+		}
+		series[tick]++
+	}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			edit(change.To.Name)
+		case merkletrie.Delete:
+			delete(hotspots.files, change.From.Name)
+		case merkletrie.Modify:
+			if change.From.Name != change.To.Name {
+				if series, exists := hotspots.files[change.From.Name]; exists {
+					delete(hotspots.files, change.From.Name)
+					hotspots.files[change.To.Name] = series
+				}
+				if change.From.TreeEntry.Hash == change.To.TreeEntry.Hash {
+					// pure rename
+					break
+				}
+			}
+			edit(change.To.Name)
+		}
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (hotspots *HotspotsAnalysis) Finalize() interface{} {
+	files := make([]Hotspot, 0, len(hotspots.files))
+	for name, ticks := range hotspots.files {
+		hotspot := Hotspot{Path: name, Series: make([]int64, hotspots.lastTick+1)}
+		for tick, edits := range ticks {
+			hotspot.Series[tick] = edits
+			hotspot.Edits += edits
+		}
+		files = append(files, hotspot)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Edits != files[j].Edits {
+			return files[i].Edits > files[j].Edits
+		}
+		return files[i].Path < files[j].Path
+	})
+	if hotspots.Top > 0 && len(files) > hotspots.Top {
+		files = files[:hotspots.Top]
+	}
+	return HotspotsResult{Files: files, tickSize: hotspots.tickSize}
+}
+
+// Fork clones this PipelineItem.
+func (hotspots *HotspotsAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(hotspots, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (hotspots *HotspotsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	hotspotsResult := result.(HotspotsResult)
+	if binary {
+		return hotspots.serializeBinary(&hotspotsResult, writer)
+	}
+	hotspots.serializeText(&hotspotsResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to HotspotsResult.
+func (hotspots *HotspotsAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.HotspotsAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]Hotspot, len(message.Files))
+	for i, file := range message.Files {
+		files[i] = Hotspot{Path: file.Path, Edits: file.Edits, Series: file.Series}
+	}
+	return HotspotsResult{Files: files, tickSize: time.Duration(message.TickSize)}, nil
+}
+
+func (hotspots *HotspotsAnalysis) serializeText(result *HotspotsResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  files:")
+	for _, file := range result.Files {
+		fmt.Fprintf(writer, "  - path: %s\n", yaml.SafeString(file.Path))
+		fmt.Fprintf(writer, "    edits: %d\n", file.Edits)
+		fmt.Fprint(writer, "    series: [")
+		for i, val := range file.Series {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprint(writer, val)
+		}
+		fmt.Fprintln(writer, "]")
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (hotspots *HotspotsAnalysis) serializeBinary(result *HotspotsResult, writer io.Writer) error {
+	message := pb.HotspotsAnalysisResults{
+		Files:    make([]*pb.Hotspot, len(result.Files)),
+		TickSize: int64(result.tickSize),
+	}
+	for i, file := range result.Files {
+		message.Files[i] = &pb.Hotspot{Path: file.Path, Edits: file.Edits, Series: file.Series}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this hotspots result.
+func (hr HotspotsResult) GetTickSize() time.Duration {
+	return hr.tickSize
+}
+
+func init() {
+	core.Registry.Register(&HotspotsAnalysis{})
+}
@@ -0,0 +1,156 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureHotspots() *HotspotsAnalysis {
+	hotspots := HotspotsAnalysis{}
+	hotspots.Initialize(test.Repository)
+	return &hotspots
+}
+
+func TestHotspotsMeta(t *testing.T) {
+	hotspots := fixtureHotspots()
+	assert.Equal(t, hotspots.Name(), "Hotspots")
+	assert.Len(t, hotspots.Provides(), 0)
+	assert.Equal(t, []string{items.DependencyTreeChanges, items.DependencyTick}, hotspots.Requires())
+	opts := hotspots.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigHotspotsTop)
+	assert.Equal(t, opts[0].Flag, "hotspots-top")
+	assert.Equal(t, hotspots.Flag(), "hotspots")
+	assert.Equal(t, 24*time.Hour, hotspots.tickSize)
+	logger := core.NewLogger()
+	assert.NoError(t, hotspots.Configure(map[string]interface{}{
+		core.ConfigLogger:  logger,
+		ConfigHotspotsTop:  5,
+		items.FactTickSize: time.Hour,
+	}))
+	assert.Equal(t, logger, hotspots.l)
+	assert.Equal(t, 5, hotspots.Top)
+	assert.Equal(t, time.Hour, hotspots.tickSize)
+	assert.Error(t, hotspots.Configure(map[string]interface{}{ConfigHotspotsTop: -1}))
+}
+
+func TestHotspotsRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&HotspotsAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Hotspots")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&HotspotsAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestHotspotsFork(t *testing.T) {
+	hotspots1 := fixtureHotspots()
+	clones := hotspots1.Fork(1)
+	assert.Len(t, clones, 1)
+	hotspots2 := clones[0].(*HotspotsAnalysis)
+	assert.True(t, hotspots1 == hotspots2)
+	hotspots1.Merge([]core.PipelineItem{hotspots2})
+}
+
+func bakeHotspots(t *testing.T) *HotspotsAnalysis {
+	hotspots := fixtureHotspots()
+	hash1 := plumbing.NewHash("1111111111111111111111111111111111111111")
+	hash2 := plumbing.NewHash("2222222222222222222222222222222222222222")
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: hash}}
+	}
+	consume := func(tick int, merge bool, changes ...*object.Change) {
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("3333333333333333333333333333333333333333")
+			commit.ParentHashes = []plumbing.Hash{hash1, hash2}
+		}
+		res, err := hotspots.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			items.DependencyTreeChanges: object.Changes(changes),
+			items.DependencyTick:        tick,
+		})
+		assert.Nil(t, res)
+		assert.NoError(t, err)
+	}
+	consume(0, false,
+		&object.Change{To: entry("analyser.go", hash1)},
+		&object.Change{To: entry("README.md", hash1)},
+		&object.Change{To: entry("labours.py", hash1)})
+	consume(1, false,
+		&object.Change{From: entry("analyser.go", hash1), To: entry("analyser.go", hash2)})
+	consume(1, false,
+		&object.Change{From: entry("analyser.go", hash2), To: entry("burndown.go", hash2)},
+		&object.Change{From: entry("README.md", hash1), To: entry("README.md", hash2)})
+	// the merge commit is consumed once per merged branch but counted once
+	consume(1, true,
+		&object.Change{From: entry("README.md", hash2), To: entry("README.md", hash1)})
+	consume(1, true,
+		&object.Change{From: entry("README.md", hash2), To: entry("README.md", hash1)})
+	consume(3, false,
+		&object.Change{From: entry("burndown.go", hash2), To: entry("leaves/burndown.go", hash1)},
+		&object.Change{From: entry("labours.py", hash1)})
+	return hotspots
+}
+
+func TestHotspotsConsumeFinalize(t *testing.T) {
+	hotspots := bakeHotspots(t)
+	result := hotspots.Finalize().(HotspotsResult)
+	assert.Equal(t, []Hotspot{
+		{Path: "README.md", Edits: 3, Series: []int64{1, 2, 0, 0}},
+		{Path: "leaves/burndown.go", Edits: 3, Series: []int64{1, 1, 0, 1}},
+	}, result.Files)
+	assert.Equal(t, 24*time.Hour, result.GetTickSize())
+	hotspots.Top = 1
+	result = hotspots.Finalize().(HotspotsResult)
+	assert.Len(t, result.Files, 1)
+	assert.Equal(t, "README.md", result.Files[0].Path)
+	assert.Len(t, fixtureHotspots().Finalize().(HotspotsResult).Files, 0)
+}
+
+func TestHotspotsSerialize(t *testing.T) {
+	hotspots := bakeHotspots(t)
+	result := hotspots.Finalize()
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, hotspots.Serialize(result, false, buffer))
+	assert.Equal(t, `  files:
+  - path: "README.md"
+    edits: 3
+    series: [1, 2, 0, 0]
+  - path: "leaves/burndown.go"
+    edits: 3
+    series: [1, 1, 0, 1]
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, hotspots.Serialize(result, true, buffer))
+	msg := pb.HotspotsAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Files, 2)
+	assert.Equal(t, "README.md", msg.Files[0].Path)
+	assert.Equal(t, int64(3), msg.Files[0].Edits)
+	assert.Equal(t, []int64{1, 2, 0, 0}, msg.Files[0].Series)
+	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	deserialized, err := hotspots.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
@@ -0,0 +1,307 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// ImportCouplingAnalysis parses the import directives of the files in the last commit and
+// builds the file-to-file dependency graph. The languages are supported by the registered
+// ImportParser-s. It is a LeafPipelineItem.
+type ImportCouplingAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// files is the set of the analysed files which exist at the current commit.
+	files      map[string]bool
+	lastCommit *object.Commit
+
+	l core.Logger
+}
+
+// ImportCouplingResult is returned by Finalize() and represents the analysis result.
+type ImportCouplingResult struct {
+	// Files are the sorted paths of the files in the last commit which were parsed or imported.
+	Files []string
+	// Imports are the sorted indexes in Files of the files which each file imports.
+	// The order matches Files.
+	Imports [][]int
+}
+
+// importCouplingMaxFileSize is the size of the files starting from which they are not parsed.
+const importCouplingMaxFileSize = 1 << 20
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (coupling *ImportCouplingAnalysis) Name() string {
+	return "ImportCoupling"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (coupling *ImportCouplingAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (coupling *ImportCouplingAnalysis) Requires() []string {
+	return []string{items.DependencyTreeChanges}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (coupling *ImportCouplingAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (coupling *ImportCouplingAnalysis) Flag() string {
+	return "import-coupling"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (coupling *ImportCouplingAnalysis) Description() string {
+	languages := map[string]bool{}
+	for _, parser := range importParsers {
+		languages[parser.Language()] = true
+	}
+	names := make([]string, 0, len(languages))
+	for name := range languages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "Parses the imports of the files in the last commit and reports which files each " +
+		"file depends on. The supported languages are " + strings.Join(names, ", ") +
+		"; the rest of the files are skipped."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (coupling *ImportCouplingAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		coupling.l = l
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (coupling *ImportCouplingAnalysis) Initialize(repository *git.Repository) error {
+	if coupling.l == nil {
+		coupling.l = core.NewLogger()
+	}
+	coupling.files = map[string]bool{}
+	coupling.lastCommit = nil
+	coupling.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (coupling *ImportCouplingAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !coupling.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	coupling.lastCommit = deps[core.DependencyCommit].(*object.Commit)
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			coupling.files[change.To.Name] = true
+		case merkletrie.Delete:
+			delete(coupling.files, change.From.Name)
+		case merkletrie.Modify:
+			delete(coupling.files, change.From.Name)
+			coupling.files[change.To.Name] = true
+		}
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (coupling *ImportCouplingAnalysis) Finalize() interface{} {
+	result := ImportCouplingResult{Files: []string{}, Imports: [][]int{}}
+	if coupling.lastCommit == nil {
+		return result
+	}
+	// the files which were filtered upstream, e.g. by --exclude, are not tracked
+	var files []*object.File
+	var names []string
+	fileIter, err := coupling.lastCommit.Files()
+	if err != nil {
+		coupling.l.Errorf("Failed to iterate files of %s", coupling.lastCommit.Hash.String())
+		return err
+	}
+	err = fileIter.ForEach(func(file *object.File) error {
+		if coupling.files[file.Name] {
+			names = append(names, file.Name)
+			if findImportParser(file.Name) != nil {
+				files = append(files, file)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		coupling.l.Errorf("Failed to iterate files of %s", coupling.lastCommit.Hash.String())
+		return err
+	}
+	snapshot := NewImportSnapshot(names)
+	snapshot.read = func(name string) ([]byte, error) {
+		file, err := coupling.lastCommit.File(name)
+		if err != nil {
+			return nil, err
+		}
+		if file.Size > importCouplingMaxFileSize {
+			return nil, fmt.Errorf("%s is too big", name)
+		}
+		contents, err := file.Contents()
+		return []byte(contents), err
+	}
+	imports := map[string]map[string]bool{}
+	for _, file := range files {
+		targets := map[string]bool{}
+		imports[file.Name] = targets
+		if file.Size > importCouplingMaxFileSize {
+			continue
+		}
+		contents, err := file.Contents()
+		if err != nil {
+			coupling.l.Warnf("Failed to read %s: %v", file.Name, err)
+			continue
+		}
+		parser := findImportParser(file.Name)
+		for _, spec := range parser.Parse([]byte(contents)) {
+			for _, target := range parser.Resolve(file.Name, spec, snapshot) {
+				targets[target] = true
+			}
+		}
+	}
+	// the custom parsers may resolve to the files which they do not parse
+	all := map[string]bool{}
+	for name, targets := range imports {
+		all[name] = true
+		for target := range targets {
+			all[target] = true
+		}
+	}
+	for name := range all {
+		result.Files = append(result.Files, name)
+	}
+	sort.Strings(result.Files)
+	index := map[string]int{}
+	for i, name := range result.Files {
+		index[name] = i
+	}
+	result.Imports = make([][]int, len(result.Files))
+	for i, name := range result.Files {
+		result.Imports[i] = []int{}
+		for target := range imports[name] {
+			result.Imports[i] = append(result.Imports[i], index[target])
+		}
+		sort.Ints(result.Imports[i])
+	}
+	return result
+}
+
+// Fork clones this PipelineItem.
+func (coupling *ImportCouplingAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(coupling, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (coupling *ImportCouplingAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	couplingResult := result.(ImportCouplingResult)
+	if binary {
+		return coupling.serializeBinary(&couplingResult, writer)
+	}
+	coupling.serializeText(&couplingResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to ImportCouplingResult.
+func (coupling *ImportCouplingAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ImportCouplingAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	if len(message.Imports) != len(message.Files) {
+		return nil, fmt.Errorf("%d import lists while %d are expected",
+			len(message.Imports), len(message.Files))
+	}
+	result := ImportCouplingResult{
+		Files:   message.Files,
+		Imports: make([][]int, len(message.Imports)),
+	}
+	if result.Files == nil {
+		result.Files = []string{}
+	}
+	for i, imports := range message.Imports {
+		result.Imports[i] = make([]int, len(imports.Files))
+		for j, val := range imports.Files {
+			if int(val) >= len(result.Files) || val < 0 {
+				return nil, fmt.Errorf("%s imports the file with invalid index %d",
+					result.Files[i], val)
+			}
+			result.Imports[i][j] = int(val)
+		}
+	}
+	return result, nil
+}
+
+func (coupling *ImportCouplingAnalysis) serializeText(result *ImportCouplingResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  files:")
+	for _, file := range result.Files {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(file))
+	}
+	fmt.Fprintln(writer, "  imports:")
+	for _, imports := range result.Imports {
+		indexes := make([]string, len(imports))
+		for i, index := range imports {
+			indexes[i] = fmt.Sprint(index)
+		}
+		fmt.Fprintf(writer, "    - [%s]\n", strings.Join(indexes, ", "))
+	}
+}
+
+func (coupling *ImportCouplingAnalysis) serializeBinary(result *ImportCouplingResult, writer io.Writer) error {
+	message := pb.ImportCouplingAnalysisResults{
+		Files:   result.Files,
+		Imports: make([]*pb.TouchedFiles, len(result.Imports)),
+	}
+	for i, imports := range result.Imports {
+		files := make([]int32, len(imports))
+		for j, index := range imports {
+			files[j] = int32(index)
+		}
+		message.Imports[i] = &pb.TouchedFiles{Files: files}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&ImportCouplingAnalysis{})
+}
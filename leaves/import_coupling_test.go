@@ -0,0 +1,169 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureImportCoupling() *ImportCouplingAnalysis {
+	coupling := ImportCouplingAnalysis{}
+	coupling.Initialize(test.Repository)
+	return &coupling
+}
+
+func TestImportCouplingMeta(t *testing.T) {
+	coupling := fixtureImportCoupling()
+	assert.Equal(t, coupling.Name(), "ImportCoupling")
+	assert.Len(t, coupling.Provides(), 0)
+	assert.Equal(t, []string{items.DependencyTreeChanges}, coupling.Requires())
+	assert.Len(t, coupling.ListConfigurationOptions(), 0)
+	assert.Equal(t, coupling.Flag(), "import-coupling")
+	assert.Contains(t, coupling.Description(), "Go, JavaScript, Python;")
+	logger := core.NewLogger()
+	assert.NoError(t, coupling.Configure(map[string]interface{}{
+		core.ConfigLogger: logger,
+	}))
+	assert.Equal(t, logger, coupling.l)
+}
+
+func TestImportCouplingRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&ImportCouplingAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "ImportCoupling")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&ImportCouplingAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestImportCouplingFork(t *testing.T) {
+	coupling1 := fixtureImportCoupling()
+	clones := coupling1.Fork(1)
+	assert.Len(t, clones, 1)
+	coupling2 := clones[0].(*ImportCouplingAnalysis)
+	assert.True(t, coupling1 == coupling2)
+	coupling1.Merge([]core.PipelineItem{coupling2})
+}
+
+type fakeImportParser struct{}
+
+func (fakeImportParser) Language() string {
+	return "Fake"
+}
+
+func (fakeImportParser) Extensions() []string {
+	return []string{".fake"}
+}
+
+func (fakeImportParser) Parse(contents []byte) []string {
+	return []string{string(bytes.TrimSpace(contents))}
+}
+
+func (fakeImportParser) Resolve(file, spec string, snapshot *ImportSnapshot) []string {
+	if snapshot.Exists(spec) {
+		return []string{spec}
+	}
+	return nil
+}
+
+func TestImportCouplingConsumeFinalize(t *testing.T) {
+	RegisterImportParser(fakeImportParser{})
+	defer delete(importParsers, ".fake")
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"go.mod": "module github.com/x/app\n",
+			"main.go": "package main\n\nimport (\n\t\"log\"\n\t\"github.com/x/app/pkg\"\n" +
+				"\t\"github.com/x/app/util\"\n\t\"github.com/y/log\"\n)\n",
+			"log/log.go":    "package log\n",
+			"pkg/p.go":      "package pkg\n",
+			"util/util.go":  "package util\n",
+			"app.py":        "from lib import db\n",
+			"lib/db.py":     "import sqlite3\n",
+			"web/index.js":  "import {f} from './lib';\nimport './style.css';\n",
+			"web/lib.js":    "export const f = 1;\n",
+			"web/style.css": "body {}\n",
+			"README.md":     "import util\n",
+		}},
+		{Author: "one", When: when.Add(24 * time.Hour), Deleted: []string{"web/lib.js"},
+			Files: map[string]string{
+				"web/lib/index.js": "export const f = 1;\n",
+				"x.fake":           "README.md\n",
+			}},
+		// the renamed file is still imported
+		{Author: "two", When: when.Add(48 * time.Hour), Deleted: []string{"lib/db.py"},
+			Files: map[string]string{"lib/db/__init__.py": "import sqlite3\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	coupling := pipeline.DeployItem(&ImportCouplingAnalysis{}).(*ImportCouplingAnalysis)
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{
+		items.ConfigTreeDiffExcludeGlobs: []string{"util/"},
+	}))
+	results, err := pipeline.Run(nil)
+	require.NoError(t, err)
+	result := results[coupling].(ImportCouplingResult)
+	assert.Equal(t, []string{
+		"README.md", "app.py", "lib/db/__init__.py", "log/log.go", "main.go", "pkg/p.go",
+		"web/index.js", "web/lib/index.js", "x.fake"}, result.Files)
+	// neither the standard "log" nor "github.com/y/log" is log/log.go
+	assert.Equal(t, [][]int{{}, {2}, {}, {}, {5}, {}, {7}, {}, {0}}, result.Imports)
+	empty := fixtureImportCoupling().Finalize().(ImportCouplingResult)
+	assert.Len(t, empty.Files, 0)
+	assert.Len(t, empty.Imports, 0)
+}
+
+func TestImportCouplingSerialize(t *testing.T) {
+	coupling := fixtureImportCoupling()
+	result := ImportCouplingResult{
+		Files:   []string{"a.go", "b/c.go", "d.py"},
+		Imports: [][]int{{1}, {}, {0, 1}},
+	}
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, coupling.Serialize(result, false, buffer))
+	assert.Equal(t, `  files:
+    - "a.go"
+    - "b/c.go"
+    - "d.py"
+  imports:
+    - [1]
+    - []
+    - [0, 1]
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, coupling.Serialize(result, true, buffer))
+	msg := pb.ImportCouplingAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, result.Files, msg.Files)
+	assert.Equal(t, []int32{0, 1}, msg.Imports[2].Files)
+	deserialized, err := coupling.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+
+	msg.Imports[1].Files = []int32{3}
+	serialized, err := proto.Marshal(&msg)
+	assert.NoError(t, err)
+	_, err = coupling.Deserialize(serialized)
+	assert.EqualError(t, err, "b/c.go imports the file with invalid index 3")
+	msg.Imports = msg.Imports[:2]
+	serialized, err = proto.Marshal(&msg)
+	assert.NoError(t, err)
+	_, err = coupling.Deserialize(serialized)
+	assert.EqualError(t, err, "2 import lists while 3 are expected")
+}
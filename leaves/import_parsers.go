@@ -0,0 +1,318 @@
+package leaves
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportParser extracts the import directives of a programming language and resolves them
+// to the files in the repository. ImportCouplingAnalysis uses the parsers which are registered
+// with RegisterImportParser().
+type ImportParser interface {
+	// Language returns the name of the supported language, e.g. "Go".
+	Language() string
+	// Extensions returns the lower case file extensions with the leading dot, e.g. ".go".
+	Extensions() []string
+	// Parse returns the imported module specifications in the file's contents.
+	Parse(contents []byte) []string
+	// Resolve returns the paths of the files which the module specification `spec` imported
+	// from `file` refers to. It returns nil if the module is external to the repository.
+	Resolve(file, spec string, snapshot *ImportSnapshot) []string
+}
+
+// ImportSnapshot is the set of the files in the analysed revision which the imports are
+// resolved against.
+type ImportSnapshot struct {
+	files map[string]bool
+	dirs  map[string][]string
+	// read loads the contents of the file, see Contents().
+	read func(file string) ([]byte, error)
+	// contents caches the results of read, nil if the file could not be read.
+	contents map[string][]byte
+}
+
+// importParsers maps the file extensions to the registered ImportParser-s.
+var importParsers = map[string]ImportParser{}
+
+// RegisterImportParser makes ImportCouplingAnalysis parse the files with the extensions of
+// `parser`. It overrides the parsers which were previously registered for the same extensions.
+// It should be called from init(), e.g. by a plugin.
+func RegisterImportParser(parser ImportParser) {
+	for _, ext := range parser.Extensions() {
+		importParsers[ext] = parser
+	}
+}
+
+// findImportParser returns the ImportParser which supports the file or nil.
+func findImportParser(name string) ImportParser {
+	return importParsers[strings.ToLower(path.Ext(name))]
+}
+
+// NewImportSnapshot indexes the specified file paths.
+func NewImportSnapshot(files []string) *ImportSnapshot {
+	snapshot := &ImportSnapshot{
+		files: map[string]bool{}, dirs: map[string][]string{}, contents: map[string][]byte{}}
+	for _, file := range files {
+		snapshot.files[file] = true
+		dir := path.Dir(file)
+		snapshot.dirs[dir] = append(snapshot.dirs[dir], file)
+	}
+	for _, dirFiles := range snapshot.dirs {
+		sort.Strings(dirFiles)
+	}
+	return snapshot
+}
+
+// Exists returns true if the file exists in the snapshot.
+func (snapshot *ImportSnapshot) Exists(file string) bool {
+	return snapshot.files[file]
+}
+
+// Contents returns the contents of the file in the snapshot, e.g. to read go.mod. The second
+// value is false if the file does not exist or cannot be read.
+func (snapshot *ImportSnapshot) Contents(file string) ([]byte, bool) {
+	if !snapshot.files[file] || snapshot.read == nil {
+		return nil, false
+	}
+	contents, exists := snapshot.contents[file]
+	if !exists {
+		var err error
+		if contents, err = snapshot.read(file); err != nil {
+			contents = nil
+		}
+		snapshot.contents[file] = contents
+	}
+	return contents, contents != nil
+}
+
+// Dir returns the sorted paths of the files which are directly in the directory `dir`.
+// The root directory is ".".
+func (snapshot *ImportSnapshot) Dir(dir string) []string {
+	return snapshot.dirs[path.Clean(dir)]
+}
+
+// goModuleRE extracts the module path from go.mod.
+var goModuleRE = regexp.MustCompile(`(?m)^\s*module\s+"?([^"\s]+)"?`)
+
+// goImportParser resolves the import paths to the packages by the module path in the nearest
+// go.mod. The other import paths resolve by the longest suffix of at least two components
+// which names a directory with Go files, e.g. in GOPATH or in another module.
+// The standard library is skipped and the tests are never imported.
+type goImportParser struct{}
+
+func (goImportParser) Language() string {
+	return "Go"
+}
+
+func (goImportParser) Extensions() []string {
+	return []string{".go"}
+}
+
+func (goImportParser) Parse(contents []byte) []string {
+	file, err := goparser.ParseFile(token.NewFileSet(), "", contents, goparser.ImportsOnly)
+	if err != nil {
+		return nil
+	}
+	specs := make([]string, 0, len(file.Imports))
+	for _, imp := range file.Imports {
+		if spec, err := strconv.Unquote(imp.Path.Value); err == nil {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+func (parser goImportParser) Resolve(file, spec string, snapshot *ImportSnapshot) []string {
+	parts := strings.Split(spec, "/")
+	if !strings.Contains(parts[0], ".") {
+		// the standard library, e.g. "errors" or "net/http"
+		return nil
+	}
+	if dir, module := parser.module(file, snapshot); module != "" &&
+		(spec == module || strings.HasPrefix(spec, module+"/")) {
+		return parser.packageFiles(file, path.Join(dir, strings.TrimPrefix(spec, module)), snapshot)
+	}
+	// a single component would match any directory with the same name
+	for i := 0; i < len(parts)-1; i++ {
+		if targets := parser.packageFiles(file, path.Join(parts[i:]...), snapshot); len(targets) > 0 {
+			return targets
+		}
+	}
+	return nil
+}
+
+// module returns the directory and the module path of the nearest go.mod above `file`.
+func (goImportParser) module(file string, snapshot *ImportSnapshot) (string, string) {
+	for dir := path.Dir(file); ; dir = path.Dir(dir) {
+		name := path.Join(dir, "go.mod")
+		if snapshot.Exists(name) {
+			contents, _ := snapshot.Contents(name)
+			if match := goModuleRE.FindSubmatch(contents); match != nil {
+				return dir, string(match[1])
+			}
+			return dir, ""
+		}
+		if dir == "." {
+			return "", ""
+		}
+	}
+}
+
+// packageFiles returns the non-test Go files in `dir` except `file`.
+func (goImportParser) packageFiles(file, dir string, snapshot *ImportSnapshot) []string {
+	var targets []string
+	for _, target := range snapshot.Dir(dir) {
+		if target != file && strings.HasSuffix(target, ".go") &&
+			!strings.HasSuffix(target, "_test.go") {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+var (
+	pythonImportRE = regexp.MustCompile(`^\s*import\s+(.+)$`)
+	pythonFromRE   = regexp.MustCompile(`^\s*from\s+(\.*[\w.]*)\s+import\s+(.+)$`)
+)
+
+// pythonImportParser resolves the absolute imports relative to the importing file's directory
+// and then to each parent directory up to the root, to support the "src" layouts.
+type pythonImportParser struct{}
+
+func (pythonImportParser) Language() string {
+	return "Python"
+}
+
+func (pythonImportParser) Extensions() []string {
+	return []string{".py"}
+}
+
+func (pythonImportParser) Parse(contents []byte) []string {
+	var specs []string
+	for _, line := range strings.Split(string(contents), "\n") {
+		if pos := strings.Index(line, "#"); pos >= 0 {
+			line = line[:pos]
+		}
+		if match := pythonFromRE.FindStringSubmatch(line); match != nil {
+			module := match[1]
+			specs = append(specs, module)
+			prefix := module
+			if strings.Trim(module, ".") != "" {
+				prefix += "."
+			}
+			// the imported names can be the submodules
+			for _, name := range pythonImportedNames(strings.Trim(match[2], "() \t\r")) {
+				if name != "*" {
+					specs = append(specs, prefix+name)
+				}
+			}
+		} else if match := pythonImportRE.FindStringSubmatch(line); match != nil {
+			specs = append(specs, pythonImportedNames(match[1])...)
+		}
+	}
+	return specs
+}
+
+// pythonImportedNames splits "a as b, c" to ["a", "c"].
+func pythonImportedNames(text string) []string {
+	var names []string
+	for _, item := range strings.Split(text, ",") {
+		if fields := strings.Fields(item); len(fields) > 0 {
+			names = append(names, fields[0])
+		}
+	}
+	return names
+}
+
+func (pythonImportParser) Resolve(file, spec string, snapshot *ImportSnapshot) []string {
+	name := strings.TrimLeft(spec, ".")
+	dots := len(spec) - len(name)
+	rel := strings.Replace(name, ".", "/", -1)
+	find := func(module string) []string {
+		for _, candidate := range []string{module + ".py", path.Join(module, "__init__.py")} {
+			if candidate != file && snapshot.Exists(candidate) {
+				return []string{candidate}
+			}
+		}
+		return nil
+	}
+	dir := path.Dir(file)
+	if dots > 0 {
+		for i := 1; i < dots; i++ {
+			dir = path.Dir(dir)
+		}
+		if rel == "" {
+			return find(dir)
+		}
+		return find(path.Join(dir, rel))
+	}
+	for {
+		if targets := find(path.Join(dir, rel)); targets != nil {
+			return targets
+		}
+		if dir == "." {
+			return nil
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+var jsImportRE = regexp.MustCompile(`(?:\bfrom|\bimport|\brequire\s*\()\s*\(?\s*["']([^"'\n]+)["']`)
+
+var jsExtensions = []string{".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx"}
+
+// jsImportParser resolves only the relative imports because the packages are external.
+type jsImportParser struct{}
+
+func (jsImportParser) Language() string {
+	return "JavaScript"
+}
+
+func (jsImportParser) Extensions() []string {
+	return jsExtensions
+}
+
+func (jsImportParser) Parse(contents []byte) []string {
+	var specs []string
+	for _, match := range jsImportRE.FindAllSubmatch(contents, -1) {
+		specs = append(specs, string(match[1]))
+	}
+	return specs
+}
+
+func (jsImportParser) Resolve(file, spec string, snapshot *ImportSnapshot) []string {
+	if !strings.HasPrefix(spec, "./") && !strings.HasPrefix(spec, "../") {
+		return nil
+	}
+	base := path.Join(path.Dir(file), spec)
+	var candidates []string
+	for _, ext := range jsExtensions {
+		if strings.HasSuffix(base, ext) {
+			candidates = append(candidates, base)
+			break
+		}
+	}
+	for _, ext := range jsExtensions {
+		candidates = append(candidates, base+ext)
+	}
+	for _, ext := range jsExtensions {
+		candidates = append(candidates, path.Join(base, "index"+ext))
+	}
+	for _, candidate := range candidates {
+		if candidate != file && snapshot.Exists(candidate) {
+			return []string{candidate}
+		}
+	}
+	return nil
+}
+
+func init() {
+	RegisterImportParser(goImportParser{})
+	RegisterImportParser(pythonImportParser{})
+	RegisterImportParser(jsImportParser{})
+}
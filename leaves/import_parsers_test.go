@@ -0,0 +1,145 @@
+package leaves
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportSnapshot(t *testing.T) {
+	snapshot := NewImportSnapshot([]string{"b.go", "a.go", "pkg/c.go", "pkg/sub/d.go"})
+	assert.True(t, snapshot.Exists("pkg/c.go"))
+	assert.False(t, snapshot.Exists("pkg"))
+	assert.Equal(t, []string{"a.go", "b.go"}, snapshot.Dir("."))
+	assert.Equal(t, []string{"pkg/c.go"}, snapshot.Dir("pkg/"))
+	assert.Nil(t, snapshot.Dir("sub"))
+}
+
+func TestFindImportParser(t *testing.T) {
+	assert.Equal(t, "Go", findImportParser("a/b.go").Language())
+	assert.Equal(t, "Python", findImportParser("a/B.PY").Language())
+	assert.Equal(t, "JavaScript", findImportParser("c.tsx").Language())
+	assert.Nil(t, findImportParser("Makefile"))
+	assert.Nil(t, findImportParser("a.c"))
+}
+
+func TestGoImportParser(t *testing.T) {
+	parser := goImportParser{}
+	assert.Equal(t, []string{"fmt", "github.com/x/app/pkg", "github.com/x/app/pkg/sub"},
+		parser.Parse([]byte(`package main
+
+import (
+	"fmt"
+
+	p "github.com/x/app/pkg"
+	_ "github.com/x/app/pkg/sub"
+)
+
+func main() {
+`)))
+	assert.Nil(t, parser.Parse([]byte("not go")))
+	// without go.mod
+	snapshot := NewImportSnapshot([]string{
+		"main.go", "pkg/a.go", "pkg/b.go", "pkg/a_test.go", "pkg/README.md", "pkg/sub/c.go",
+		"errors/e.go"})
+	assert.Nil(t, parser.Resolve("main.go", "github.com/x/app/pkg", snapshot))
+	assert.Equal(t, []string{"pkg/sub/c.go"}, parser.Resolve("main.go", "x.org/app/pkg/sub", snapshot))
+	assert.Nil(t, parser.Resolve("main.go", "fmt", snapshot))
+	assert.Nil(t, parser.Resolve("main.go", "errors", snapshot))
+	assert.Nil(t, parser.Resolve("main.go", "github.com/z/errors", snapshot))
+
+	snapshot = NewImportSnapshot([]string{
+		"go.mod", "main.go", "pkg/a.go", "pkg/b.go", "pkg/a_test.go", "log/log.go",
+		"tools/go.mod", "tools/gen/gen.go", "tools/main.go", "broken/go.mod", "broken/x.go"})
+	snapshot.read = func(file string) ([]byte, error) {
+		switch file {
+		case "go.mod":
+			return []byte("// the app\nmodule github.com/x/app\n\ngo 1.18\n"), nil
+		case "tools/go.mod":
+			return []byte("module \"github.com/x/app/tools\"\n"), nil
+		}
+		return nil, errors.New("failed")
+	}
+	assert.Equal(t, []string{"pkg/a.go", "pkg/b.go"},
+		parser.Resolve("main.go", "github.com/x/app/pkg", snapshot))
+	assert.Equal(t, []string{"pkg/b.go"}, parser.Resolve("pkg/a.go", "github.com/x/app/pkg", snapshot))
+	assert.Nil(t, parser.Resolve("main.go", "github.com/y/log", snapshot))
+	assert.Nil(t, parser.Resolve("main.go", "github.com/x/app/missing", snapshot))
+	assert.Equal(t, []string{"tools/gen/gen.go"},
+		parser.Resolve("tools/main.go", "github.com/x/app/tools/gen", snapshot))
+	assert.Nil(t, parser.Resolve("broken/x.go", "github.com/x/app/log", snapshot))
+}
+
+func TestImportSnapshotContents(t *testing.T) {
+	snapshot := NewImportSnapshot([]string{"a.go", "b.go"})
+	_, exists := snapshot.Contents("a.go")
+	assert.False(t, exists)
+	reads := 0
+	snapshot.read = func(file string) ([]byte, error) {
+		reads++
+		if file == "b.go" {
+			return []byte("b"), errors.New("failed")
+		}
+		return []byte(file), nil
+	}
+	for i := 0; i < 2; i++ {
+		contents, exists := snapshot.Contents("a.go")
+		assert.True(t, exists)
+		assert.Equal(t, "a.go", string(contents))
+		_, exists = snapshot.Contents("b.go")
+		assert.False(t, exists)
+		_, exists = snapshot.Contents("c.go")
+		assert.False(t, exists)
+	}
+	assert.Equal(t, 2, reads)
+}
+
+func TestPythonImportParser(t *testing.T) {
+	parser := pythonImportParser{}
+	assert.Equal(t, []string{
+		"os", "app.util", "app", "app.models", "app.views", ".", ".helpers", "..core", "..core.x",
+		"pkg",
+	}, parser.Parse([]byte(`import os, app.util as u
+from app import models, views as v  # the views
+from . import helpers
+from ..core import x
+from pkg import *
+# import commented
+def f():
+    return "import nothing"
+`)))
+	snapshot := NewImportSnapshot([]string{
+		"app/__init__.py", "app/util.py", "app/models/__init__.py", "app/sub/helpers.py",
+		"app/sub/__init__.py", "app/sub/view.py", "src/lib/x.py", "src/main.py"})
+	assert.Equal(t, []string{"app/util.py"}, parser.Resolve("app/sub/view.py", "app.util", snapshot))
+	assert.Equal(t, []string{"app/models/__init__.py"},
+		parser.Resolve("app/util.py", "app.models", snapshot))
+	assert.Equal(t, []string{"app/sub/helpers.py"},
+		parser.Resolve("app/sub/view.py", ".helpers", snapshot))
+	assert.Equal(t, []string{"app/sub/__init__.py"}, parser.Resolve("app/sub/view.py", ".", snapshot))
+	assert.Equal(t, []string{"app/util.py"}, parser.Resolve("app/sub/view.py", "..util", snapshot))
+	assert.Equal(t, []string{"src/lib/x.py"}, parser.Resolve("src/main.py", "lib.x", snapshot))
+	assert.Nil(t, parser.Resolve("app/util.py", "os", snapshot))
+	assert.Nil(t, parser.Resolve("app/util.py", "app.util", snapshot))
+}
+
+func TestJSImportParser(t *testing.T) {
+	parser := jsImportParser{}
+	assert.Equal(t, []string{"react", "./a", "../b/c.js", "./style.css", "./d", "./e"},
+		parser.Parse([]byte(`import React from "react";
+import {x} from './a'
+export * from "../b/c.js";
+import './style.css';
+const d = require( './d' );
+const e = await import("./e");
+`)))
+	snapshot := NewImportSnapshot([]string{
+		"src/a.ts", "b/c.js", "src/style.css", "src/d/index.jsx", "src/main.js"})
+	assert.Equal(t, []string{"src/a.ts"}, parser.Resolve("src/main.js", "./a", snapshot))
+	assert.Equal(t, []string{"b/c.js"}, parser.Resolve("src/main.js", "../b/c.js", snapshot))
+	assert.Equal(t, []string{"src/d/index.jsx"}, parser.Resolve("src/main.js", "./d", snapshot))
+	assert.Nil(t, parser.Resolve("src/main.js", "./style.css", snapshot))
+	assert.Nil(t, parser.Resolve("src/main.js", "react", snapshot))
+	assert.Nil(t, parser.Resolve("src/main.js", "./main", snapshot))
+}
@@ -0,0 +1,305 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+)
+
+// LeadTimeAnalysis approximates the commit-to-deploy lead time with the tags as the release
+// markers. Each commit is released by the earliest tag which contains it, and the delay is
+// the difference between the time of that tag and the commit time. It is a LeafPipelineItem.
+type LeadTimeAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+
+	// releases maps the commit hashes to the time of the earliest tag which contains them.
+	releases map[plumbing.Hash]time.Time
+	// histograms are the lead time distributions of the commits in each tick.
+	histograms []LeadTimeHistogram
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+	// timeSource references Pipeline.TimeSource, see core.CommitTime().
+	timeSource string
+
+	l core.Logger
+}
+
+// LeadTimeHistogram is the distribution of the lead times of the commits in a single tick.
+type LeadTimeHistogram struct {
+	// Delays maps the delays in ticks between the commits and their releases to the number
+	// of commits.
+	Delays map[int]int64
+	// Untagged is the number of commits which are not contained in any tag yet.
+	Untagged int64
+}
+
+// LeadTimeResult is returned by LeadTimeAnalysis.Finalize() and carries the lead time
+// histograms of the commits in each tick.
+type LeadTimeResult struct {
+	// Ticks are the histograms of the commits in each tick.
+	Ticks []LeadTimeHistogram
+
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+// releaseTag is a tag resolved to the commit which it points to.
+type releaseTag struct {
+	name   string
+	commit *object.Commit
+	when   time.Time
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (lead *LeadTimeAnalysis) Name() string {
+	return "LeadTime"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (lead *LeadTimeAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (lead *LeadTimeAnalysis) Requires() []string {
+	return []string{items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (lead *LeadTimeAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (lead *LeadTimeAnalysis) Flag() string {
+	return "lead-time"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (lead *LeadTimeAnalysis) Description() string {
+	return "Calculates the histogram of the delays between the commits and the earliest tags " +
+		"which contain them in each tick. Merge commits are counted once."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (lead *LeadTimeAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		lead.l = l
+	}
+	if val, exists := facts[core.ConfigTimeSource].(string); exists {
+		lead.timeSource = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		lead.tickSize = val
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+// The tags are read here and every commit is assigned the earliest tag which contains it.
+func (lead *LeadTimeAnalysis) Initialize(repository *git.Repository) error {
+	if lead.l == nil {
+		lead.l = core.NewLogger()
+	}
+	lead.histograms = nil
+	lead.OneShotMergeProcessor.Initialize()
+	if lead.tickSize == 0 {
+		lead.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	tags, err := lead.readTags(repository)
+	if err != nil {
+		return err
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if !tags[i].when.Equal(tags[j].when) {
+			return tags[i].when.Before(tags[j].when)
+		}
+		return tags[i].name < tags[j].name
+	})
+	lead.releases = map[plumbing.Hash]time.Time{}
+	for _, tag := range tags {
+		// the ancestors of a released commit were released by the same or an earlier tag,
+		// so we stop there and visit each commit once
+		stack := []*object.Commit{tag.commit}
+		for len(stack) > 0 {
+			commit := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if _, exists := lead.releases[commit.Hash]; exists {
+				continue
+			}
+			lead.releases[commit.Hash] = tag.when
+			err = commit.Parents().ForEach(func(parent *object.Commit) error {
+				stack = append(stack, parent)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list the parents of %s: %v", commit.Hash, err)
+			}
+		}
+	}
+	return nil
+}
+
+// readTags resolves the tags to the commits. The annotated tags are timed by the tagger and
+// the lightweight tags by the committer. The tags of other objects are ignored.
+func (lead *LeadTimeAnalysis) readTags(repository *git.Repository) ([]releaseTag, error) {
+	refs, err := repository.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the tags: %v", err)
+	}
+	var tags []releaseTag
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if tag, err := repository.TagObject(ref.Hash()); err == nil {
+			commit, err := tag.Commit()
+			if err != nil {
+				lead.l.Warnf("tag %s does not point to a commit, skipped", name)
+				return nil
+			}
+			tags = append(tags, releaseTag{name: name, commit: commit, when: tag.Tagger.When})
+			return nil
+		}
+		commit, err := repository.CommitObject(ref.Hash())
+		if err != nil {
+			lead.l.Warnf("tag %s does not point to a commit, skipped", name)
+			return nil
+		}
+		tags = append(tags, releaseTag{
+			name: name, commit: commit, when: core.CommitTime(commit, lead.timeSource)})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the tags: %v", err)
+	}
+	return tags, nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (lead *LeadTimeAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	tick := deps[items.DependencyTick].(int)
+	for len(lead.histograms) <= tick {
+		lead.histograms = append(lead.histograms, LeadTimeHistogram{Delays: map[int]int64{}})
+	}
+	if !lead.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	histogram := &lead.histograms[tick]
+	release, exists := lead.releases[commit.Hash]
+	if !exists {
+		histogram.Untagged++
+		return nil, nil
+	}
+	delay := release.Sub(core.CommitTime(commit, lead.timeSource))
+	if delay < 0 {
+		// the clocks were skewed
+		delay = 0
+	}
+	histogram.Delays[int(delay/lead.tickSize)]++
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (lead *LeadTimeAnalysis) Finalize() interface{} {
+	return LeadTimeResult{Ticks: lead.histograms, tickSize: lead.tickSize}
+}
+
+// Fork clones this PipelineItem.
+func (lead *LeadTimeAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(lead, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (lead *LeadTimeAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	leadResult := result.(LeadTimeResult)
+	if binary {
+		return lead.serializeBinary(&leadResult, writer)
+	}
+	lead.serializeText(&leadResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to LeadTimeResult.
+func (lead *LeadTimeAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.LeadTimeAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	ticks := make([]LeadTimeHistogram, len(message.Ticks))
+	for i, tick := range message.Ticks {
+		ticks[i] = LeadTimeHistogram{Delays: map[int]int64{}, Untagged: tick.Untagged}
+		for delay, commits := range tick.Delays {
+			ticks[i].Delays[int(delay)] = commits
+		}
+	}
+	return LeadTimeResult{Ticks: ticks, tickSize: time.Duration(message.TickSize)}, nil
+}
+
+func (lead *LeadTimeAnalysis) serializeText(result *LeadTimeResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks {
+		delays := make([]int, 0, len(tick.Delays))
+		for delay := range tick.Delays {
+			delays = append(delays, delay)
+		}
+		sort.Ints(delays)
+		pairs := make([]string, len(delays))
+		for i, delay := range delays {
+			pairs[i] = fmt.Sprintf("%d: %d", delay, tick.Delays[delay])
+		}
+		fmt.Fprintf(writer, "    - {delays: {%s}, untagged: %d}\n",
+			strings.Join(pairs, ", "), tick.Untagged)
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (lead *LeadTimeAnalysis) serializeBinary(result *LeadTimeResult, writer io.Writer) error {
+	message := pb.LeadTimeAnalysisResults{
+		Ticks:    make([]*pb.LeadTimeHistogram, len(result.Ticks)),
+		TickSize: int64(result.tickSize),
+	}
+	for i, tick := range result.Ticks {
+		histogram := &pb.LeadTimeHistogram{Delays: map[int32]int64{}, Untagged: tick.Untagged}
+		for delay, commits := range tick.Delays {
+			histogram.Delays[int32(delay)] = commits
+		}
+		message.Ticks[i] = histogram
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this lead time result.
+func (ltr LeadTimeResult) GetTickSize() time.Duration {
+	return ltr.tickSize
+}
+
+func init() {
+	core.Registry.Register(&LeadTimeAnalysis{})
+}
@@ -0,0 +1,214 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureLeadTime() *LeadTimeAnalysis {
+	lead := LeadTimeAnalysis{}
+	lead.Initialize(test.Repository)
+	return &lead
+}
+
+func TestLeadTimeMeta(t *testing.T) {
+	lead := fixtureLeadTime()
+	assert.Equal(t, lead.Name(), "LeadTime")
+	assert.Len(t, lead.Provides(), 0)
+	assert.Equal(t, []string{items.DependencyTick}, lead.Requires())
+	assert.Len(t, lead.ListConfigurationOptions(), 0)
+	assert.Equal(t, lead.Flag(), "lead-time")
+	assert.NotEmpty(t, lead.Description())
+	assert.Equal(t, 24*time.Hour, lead.tickSize)
+	logger := core.NewLogger()
+	assert.NoError(t, lead.Configure(map[string]interface{}{
+		core.ConfigLogger:  logger,
+		items.FactTickSize: time.Hour,
+	}))
+	assert.Equal(t, logger, lead.l)
+	assert.Equal(t, time.Hour, lead.tickSize)
+}
+
+func TestLeadTimeRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&LeadTimeAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "LeadTime")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&LeadTimeAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestLeadTimeFork(t *testing.T) {
+	lead1 := fixtureLeadTime()
+	clones := lead1.Fork(1)
+	assert.Len(t, clones, 1)
+	lead2 := clones[0].(*LeadTimeAnalysis)
+	assert.True(t, lead1 == lead2)
+	lead1.Merge([]core.PipelineItem{lead2})
+}
+
+func bakeLeadTime(t *testing.T) (*LeadTimeAnalysis, interface{}) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"main.go": "1\n"}},
+		{Author: "one", When: when.Add(day), Files: map[string]string{"main.go": "2\n"}},
+		{Author: "two", When: when.Add(2 * day), Files: map[string]string{"main.go": "3\n"}},
+		{Author: "two", When: when.Add(5 * day), Files: map[string]string{"main.go": "4\n"}},
+		{Author: "one", When: when.Add(7 * day), Files: map[string]string{"main.go": "5\n"}},
+	})
+	assert.NoError(t, err)
+	// lightweight
+	_, err = repository.CreateTag("v1", hashes[1], nil)
+	assert.NoError(t, err)
+	// annotated, released a day after the commit
+	_, err = repository.CreateTag("v2", hashes[3], &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "one", Email: "one@srcd", When: when.Add(6 * day)},
+		Message: "v2",
+	})
+	assert.NoError(t, err)
+	// not a commit
+	commit, err := repository.CommitObject(hashes[4])
+	assert.NoError(t, err)
+	_, err = repository.CreateTag("tree", commit.TreeHash, nil)
+	assert.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	lead := pipeline.DeployItem(&LeadTimeAnalysis{}).(*LeadTimeAnalysis)
+	commits, err := pipeline.Commits(false)
+	assert.NoError(t, err)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	results, err := pipeline.Run(commits)
+	assert.NoError(t, err)
+	return lead, results[lead]
+}
+
+func TestLeadTimeConsumeFinalize(t *testing.T) {
+	_, result := bakeLeadTime(t)
+	leadResult := result.(LeadTimeResult)
+	assert.Equal(t, []LeadTimeHistogram{
+		{Delays: map[int]int64{1: 1}},
+		{Delays: map[int]int64{0: 1}},
+		{Delays: map[int]int64{4: 1}},
+		{Delays: map[int]int64{}},
+		{Delays: map[int]int64{}},
+		{Delays: map[int]int64{1: 1}},
+		{Delays: map[int]int64{}},
+		{Delays: map[int]int64{}, Untagged: 1},
+	}, leadResult.Ticks)
+	assert.Equal(t, 24*time.Hour, leadResult.GetTickSize())
+}
+
+func TestLeadTimeMergeCountedOnce(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"main.go": "1\n"}},
+		{Author: "one", When: when.Add(day), Files: map[string]string{"main.go": "2\n"}},
+		{Author: "two", When: when.Add(day), Parents: []int{0},
+			Files: map[string]string{"util.go": "1\n"}},
+		{Author: "one", When: when.Add(2 * day), Parents: []int{1, 2},
+			Files: map[string]string{"util.go": "1\n"}},
+	})
+	assert.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	lead := pipeline.DeployItem(&LeadTimeAnalysis{}).(*LeadTimeAnalysis)
+	commits, err := pipeline.Commits(false)
+	assert.NoError(t, err)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	results, err := pipeline.Run(commits)
+	assert.NoError(t, err)
+	assert.Equal(t, []LeadTimeHistogram{
+		{Delays: map[int]int64{}, Untagged: 1},
+		{Delays: map[int]int64{}, Untagged: 2},
+		{Delays: map[int]int64{}, Untagged: 1},
+	}, results[lead].(LeadTimeResult).Ticks)
+}
+
+func TestLeadTimeTimeSource(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"main.go": "1\n"}},
+		// rebased three days after it was written
+		{Author: "one", When: when.Add(day), CommitterWhen: when.Add(4 * day),
+			Files: map[string]string{"main.go": "2\n"}},
+	})
+	assert.NoError(t, err)
+	_, err = repository.CreateTag("v1", hashes[1], &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "one", Email: "one@srcd", When: when.Add(5 * day)},
+		Message: "v1",
+	})
+	assert.NoError(t, err)
+	for source, delays := range map[string][]LeadTimeHistogram{
+		core.TimeSourceCommitter: {
+			{Delays: map[int]int64{5: 1}},
+			{Delays: map[int]int64{}},
+			{Delays: map[int]int64{}},
+			{Delays: map[int]int64{}},
+			{Delays: map[int]int64{1: 1}},
+		},
+		core.TimeSourceAuthor: {
+			{Delays: map[int]int64{5: 1}},
+			{Delays: map[int]int64{4: 1}},
+		},
+	} {
+		pipeline := core.NewPipeline(repository)
+		pipeline.PrintActions = false
+		lead := pipeline.DeployItem(&LeadTimeAnalysis{}).(*LeadTimeAnalysis)
+		commits, err := pipeline.Commits(false)
+		assert.NoError(t, err)
+		assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+			core.ConfigTimeSource: source,
+		}))
+		results, err := pipeline.Run(commits)
+		assert.NoError(t, err)
+		assert.Equal(t, delays, results[lead].(LeadTimeResult).Ticks, source)
+	}
+}
+
+func TestLeadTimeSerialize(t *testing.T) {
+	lead, result := bakeLeadTime(t)
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, lead.Serialize(result, false, buffer))
+	assert.Equal(t, `  ticks:
+    - {delays: {1: 1}, untagged: 0}
+    - {delays: {0: 1}, untagged: 0}
+    - {delays: {4: 1}, untagged: 0}
+    - {delays: {}, untagged: 0}
+    - {delays: {}, untagged: 0}
+    - {delays: {1: 1}, untagged: 0}
+    - {delays: {}, untagged: 0}
+    - {delays: {}, untagged: 1}
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, lead.Serialize(result, true, buffer))
+	msg := pb.LeadTimeAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Ticks, 8)
+	assert.Equal(t, map[int32]int64{4: 1}, msg.Ticks[2].Delays)
+	assert.Equal(t, int64(1), msg.Ticks[7].Untagged)
+	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	deserialized, err := lead.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
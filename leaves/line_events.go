@@ -0,0 +1,417 @@
+package leaves
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// LineEventsAnalysis records the raw line attribution events: how many lines each commit
+// inserted and removed in each file. It tracks the files with the embedded lineTracker,
+// like BusFactorAnalysis, so the numbers match the burndown matrices, and lets the downstream
+// tools calculate any aggregation. It is a LeafPipelineItem.
+type LineEventsAnalysis struct {
+	lineTracker
+	// TempFile makes the events be written to a temporary file instead of being held in memory.
+	TempFile bool
+
+	// sink receives the events from the tracker. It is shared between the forks since every
+	// regular commit is consumed by a single branch.
+	sink *lineEventsSink
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+
+	l core.Logger
+}
+
+// LineEvent is the change of a single file in a single commit.
+type LineEvent struct {
+	// Tick is the tick of the commit.
+	Tick int
+	// Author is the index of the developer who made the commit.
+	Author int
+	// File is the path of the changed file.
+	File string
+	// Added is the number of inserted lines.
+	Added int
+	// Removed is the number of removed lines.
+	Removed int
+}
+
+// LineEventsResult is returned by LineEventsAnalysis.Finalize().
+type LineEventsResult struct {
+	// Events are in the order of the analysed commits. It is nil if LineEventsAnalysis.TempFile
+	// is enabled, ForEach() reads the events from the temporary file then, and Close() must be
+	// called to remove that file.
+	Events []LineEvent
+
+	// eventsFile is the path to the temporary file with the length-delimited pb.LineEvent-s.
+	eventsFile string
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+// lineEventsSink collects the events reported by BurndownAnalysis.lineEvents.
+type lineEventsSink struct {
+	// tick and author belong to the currently consumed commit.
+	tick   int
+	author int
+	// events are held in memory unless file is set.
+	events []LineEvent
+	file   *os.File
+	writer *bufio.Writer
+	// err is the first error which happened while writing to file.
+	err error
+}
+
+const (
+	// ConfigLineEventsTempFile is the name of the option to set LineEventsAnalysis.TempFile.
+	ConfigLineEventsTempFile = "LineEvents.TempFile"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *LineEventsAnalysis) Name() string {
+	return "LineEvents"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *LineEventsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *LineEventsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{{
+		Name:        ConfigLineEventsTempFile,
+		Description: "Write the line events to a temporary file instead of holding them in memory.",
+		Flag:        "line-events-temp-file",
+		Type:        core.BoolConfigurationOption,
+		Default:     false},
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *LineEventsAnalysis) Flag() string {
+	return "line-events"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *LineEventsAnalysis) Description() string {
+	return "Records how many lines each commit inserted and removed in each file, " +
+		"without any aggregation."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *LineEventsAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		analyser.l = l
+	}
+	if val, exists := facts[ConfigLineEventsTempFile].(bool); exists {
+		analyser.TempFile = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		analyser.reversedPeopleDict = val
+	}
+	return analyser.configure(facts, false)
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *LineEventsAnalysis) Initialize(repository *git.Repository) error {
+	if analyser.l == nil {
+		analyser.l = core.NewLogger()
+	}
+	analyser.sink = &lineEventsSink{}
+	if analyser.TempFile {
+		file, err := ioutil.TempFile("", "*-hercules-line-events.bin")
+		if err != nil {
+			return err
+		}
+		analyser.sink.file = file
+		analyser.sink.writer = bufio.NewWriter(file)
+	}
+	err := analyser.initialize(repository)
+	analyser.burndown.lineEvents = analyser.sink.record
+	return err
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *LineEventsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	analyser.sink.tick = deps[items.DependencyTick].(int)
+	analyser.sink.author = deps[identity.DependencyAuthor].(int)
+	result, err := analyser.lineTracker.Consume(deps)
+	if err != nil {
+		return nil, err
+	}
+	if analyser.sink.err != nil {
+		return nil, analyser.sink.err
+	}
+	return result, nil
+}
+
+// Fork clones this item. The files are copied by value.
+func (analyser *LineEventsAnalysis) Fork(n int) []core.PipelineItem {
+	result := make([]core.PipelineItem, n)
+	for i, tracker := range analyser.fork(n) {
+		clone := *analyser
+		clone.lineTracker = tracker
+		result[i] = &clone
+	}
+	return result
+}
+
+// Merge combines several items together. The file merging logic belongs to BurndownAnalysis.
+func (analyser *LineEventsAnalysis) Merge(branches []core.PipelineItem) {
+	trackers := make([]lineTracker, len(branches))
+	for i, branch := range branches {
+		trackers[i] = branch.(*LineEventsAnalysis).lineTracker
+	}
+	analyser.merge(trackers)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *LineEventsAnalysis) Finalize() interface{} {
+	result := LineEventsResult{
+		Events:             analyser.sink.events,
+		reversedPeopleDict: analyser.reversedPeopleDict,
+		tickSize:           analyser.burndown.TickSize,
+	}
+	if sink := analyser.sink; sink.file != nil {
+		if err := sink.writer.Flush(); err != nil {
+			analyser.l.Errorf("failed to write the line events to %s: %v", sink.file.Name(), err)
+		}
+		checkClose(sink.file)
+		result.eventsFile = sink.file.Name()
+	}
+	return result
+}
+
+// record is bound to BurndownAnalysis.lineEvents.
+func (sink *lineEventsSink) record(path string, inserted, removed int) {
+	event := LineEvent{
+		Tick: sink.tick, Author: sink.author, File: path, Added: inserted, Removed: removed,
+	}
+	if sink.writer == nil {
+		sink.events = append(sink.events, event)
+		return
+	}
+	if sink.err == nil {
+		sink.err = writeLineEvent(sink.writer, event)
+	}
+}
+
+// ForEach calls `callback` for each event in the order of the analysed commits, either from
+// Events or from the temporary file.
+func (ler LineEventsResult) ForEach(callback func(event LineEvent) error) error {
+	if ler.eventsFile == "" {
+		for _, event := range ler.Events {
+			if err := callback(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	file, err := os.Open(ler.eventsFile)
+	if err != nil {
+		return err
+	}
+	defer checkClose(file)
+	return readLineEvents(bufio.NewReader(file), callback)
+}
+
+// Close removes the temporary file with the events, if any. The result may not be serialized
+// afterwards. It is safe to call Close several times.
+func (ler LineEventsResult) Close() error {
+	if ler.eventsFile == "" {
+		return nil
+	}
+	if err := os.Remove(ler.eventsFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is the length-delimited Protocol Buffers
+// stream, see pb.LineEventsAnalysisResults. The result may be serialized several times;
+// LineEventsResult.Close() removes the temporary file with the events in the end.
+func (analyser *LineEventsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	lineEventsResult := result.(LineEventsResult)
+	if binary {
+		return analyser.serializeBinary(&lineEventsResult, writer)
+	}
+	return analyser.serializeText(&lineEventsResult, writer)
+}
+
+// Deserialize converts the specified protobuf bytes to LineEventsResult.
+// The events are always loaded in memory.
+func (analyser *LineEventsAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	reader := bufio.NewReader(bytes.NewReader(pbmessage))
+	message := pb.LineEventsAnalysisResults{}
+	if err := readDelimited(reader, &message); err != nil {
+		return nil, err
+	}
+	events := []LineEvent{}
+	err := readLineEvents(reader, func(event LineEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return LineEventsResult{
+		Events:             events,
+		reversedPeopleDict: message.AuthorIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}, nil
+}
+
+func (analyser *LineEventsAnalysis) serializeText(result *LineEventsResult, writer io.Writer) error {
+	fmt.Fprintln(writer, "  events:")
+	err := result.ForEach(func(event LineEvent) error {
+		_, err := fmt.Fprintf(writer, "    - {tick: %d, author: %d, file: %s, added: %d, removed: %d}\n",
+			event.Tick, packLineEventAuthor(event.Author), yaml.SafeString(event.File),
+			event.Added, event.Removed)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+	return nil
+}
+
+func (analyser *LineEventsAnalysis) serializeBinary(result *LineEventsResult, writer io.Writer) error {
+	err := writeDelimited(writer, &pb.LineEventsAnalysisResults{
+		AuthorIndex: result.reversedPeopleDict,
+		TickSize:    int64(result.tickSize),
+	})
+	if err != nil {
+		return err
+	}
+	if result.eventsFile != "" {
+		// the events are already serialized
+		file, err := os.Open(result.eventsFile)
+		if err != nil {
+			return err
+		}
+		defer checkClose(file)
+		_, err = io.Copy(writer, file)
+		return err
+	}
+	for _, event := range result.Events {
+		if err := writeLineEvent(writer, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// packLineEventAuthor converts identity.AuthorMissing to -1 in the serialized results.
+func packLineEventAuthor(author int) int {
+	if author == identity.AuthorMissing {
+		return -1
+	}
+	return author
+}
+
+// writeLineEvent writes the length-delimited pb.LineEvent.
+func writeLineEvent(writer io.Writer, event LineEvent) error {
+	return writeDelimited(writer, &pb.LineEvent{
+		Tick:    int32(event.Tick),
+		Author:  int32(packLineEventAuthor(event.Author)),
+		File:    event.File,
+		Added:   int32(event.Added),
+		Removed: int32(event.Removed),
+	})
+}
+
+// readLineEvents reads the length-delimited pb.LineEvent-s until EOF.
+func readLineEvents(reader *bufio.Reader, callback func(event LineEvent) error) error {
+	for {
+		if _, err := reader.Peek(1); err == io.EOF {
+			return nil
+		}
+		message := pb.LineEvent{}
+		if err := readDelimited(reader, &message); err != nil {
+			return err
+		}
+		author := int(message.Author)
+		if author == -1 {
+			author = identity.AuthorMissing
+		}
+		err := callback(LineEvent{
+			Tick:    int(message.Tick),
+			Author:  author,
+			File:    message.File,
+			Added:   int(message.Added),
+			Removed: int(message.Removed),
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// writeDelimited writes the message prefixed with its size as varint.
+func writeDelimited(writer io.Writer, message proto.Message) error {
+	serialized, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(append(proto.EncodeVarint(uint64(len(serialized))), serialized...))
+	return err
+}
+
+// readDelimited reads the message written by writeDelimited().
+func readDelimited(reader *bufio.Reader, message proto.Message) error {
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return err
+	}
+	serialized := make([]byte, size)
+	if _, err = io.ReadFull(reader, serialized); err != nil {
+		return err
+	}
+	return proto.Unmarshal(serialized, message)
+}
+
+// GetTickSize returns the tick size used to generate this line events result.
+func (ler LineEventsResult) GetTickSize() time.Duration {
+	return ler.tickSize
+}
+
+// GetIdentities returns the list of developer identities used to generate this line events result.
+// The format is |-joined keys, see internals/plumbing/identity for details.
+func (ler LineEventsResult) GetIdentities() []string {
+	return ler.reversedPeopleDict
+}
+
+func init() {
+	core.Registry.Register(&LineEventsAnalysis{})
+}
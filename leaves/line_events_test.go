@@ -0,0 +1,172 @@
+package leaves
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureLineEvents() *LineEventsAnalysis {
+	le := LineEventsAnalysis{}
+	le.Initialize(test.Repository)
+	return &le
+}
+
+func TestLineEventsMeta(t *testing.T) {
+	le := fixtureLineEvents()
+	assert.Equal(t, le.Name(), "LineEvents")
+	assert.Len(t, le.Provides(), 0)
+	assert.Equal(t, (&BurndownAnalysis{}).Requires(), le.Requires())
+	opts := le.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigLineEventsTempFile)
+	assert.Equal(t, le.Flag(), "line-events")
+	assert.NotEmpty(t, le.Description())
+	logger := core.NewLogger()
+	assert.NoError(t, le.Configure(map[string]interface{}{
+		core.ConfigLogger:                               logger,
+		ConfigBurndownTrackPeople:                       true,
+		ConfigLineEventsTempFile:                        true,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	}))
+	assert.Equal(t, logger, le.l)
+	assert.True(t, le.TempFile)
+	assert.Equal(t, 0, le.burndown.PeopleNumber)
+	assert.Equal(t, []string{"one", "two"}, le.reversedPeopleDict)
+}
+
+func TestLineEventsRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&LineEventsAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "LineEvents")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&LineEventsAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestLineEventsFork(t *testing.T) {
+	le1 := fixtureLineEvents()
+	clones := le1.Fork(1)
+	assert.Len(t, clones, 1)
+	le2 := clones[0].(*LineEventsAnalysis)
+	assert.True(t, le1 != le2)
+	assert.True(t, le1.burndown != le2.burndown)
+	assert.True(t, le1.sink == le2.sink)
+	le1.Merge([]core.PipelineItem{le2})
+	assert.NoError(t, le1.Hibernate())
+	assert.NoError(t, le1.Boot())
+}
+
+func TestLineEventsConsumeFinalize(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "zoe", When: when, Parents: []int{}, Files: map[string]string{
+			"a/x.go": "1\n2\n3\n", "README": "r\n"}},
+		{Author: "adam", When: when.Add(48 * time.Hour), Files: map[string]string{
+			"a/x.go": "1\nnew\n3\n4\n"}},
+		{Author: "zoe", When: when.Add(72 * time.Hour), Deleted: []string{"README"}},
+	})
+	assert.NoError(t, err)
+	for _, tempFile := range []bool{false, true} {
+		pipeline := core.NewPipeline(repository)
+		pipeline.PrintActions = false
+		le := pipeline.DeployItem(&LineEventsAnalysis{}).(*LineEventsAnalysis)
+		commits, err := pipeline.Commits(false)
+		assert.NoError(t, err)
+		assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+			ConfigLineEventsTempFile: tempFile,
+		}))
+		results, err := pipeline.Run(commits)
+		assert.NoError(t, err)
+		result := results[le].(LineEventsResult)
+		zoe, adam := 1, 0
+		if result.GetIdentities()[0] != "adam" {
+			zoe, adam = adam, zoe
+		}
+		var events []LineEvent
+		assert.NoError(t, result.ForEach(func(event LineEvent) error {
+			events = append(events, event)
+			return nil
+		}))
+		expected := []LineEvent{
+			{Tick: 0, Author: zoe, File: "a/x.go", Added: 3},
+			{Tick: 0, Author: zoe, File: "README", Added: 1},
+			{Tick: 2, Author: adam, File: "a/x.go", Added: 2, Removed: 1},
+			{Tick: 3, Author: zoe, File: "README", Removed: 1},
+		}
+		assert.Equal(t, expected, events, "temp file: %v", tempFile)
+		if tempFile {
+			assert.Nil(t, result.Events)
+			assert.NotEmpty(t, result.eventsFile)
+			// several sinks may serialize the same result
+			first, second := &bytes.Buffer{}, &bytes.Buffer{}
+			assert.NoError(t, le.Serialize(result, true, first))
+			assert.NoError(t, le.Serialize(result, true, second))
+			assert.Equal(t, first.Bytes(), second.Bytes())
+			assert.NoError(t, le.Serialize(result, false, &bytes.Buffer{}))
+			_, err = os.Stat(result.eventsFile)
+			assert.NoError(t, err)
+			assert.NoError(t, result.Close())
+			_, err = os.Stat(result.eventsFile)
+			assert.True(t, os.IsNotExist(err))
+			assert.NoError(t, result.Close())
+		} else {
+			assert.Equal(t, expected, result.Events)
+		}
+		assert.Equal(t, 24*time.Hour, result.GetTickSize())
+	}
+}
+
+func TestLineEventsSerialize(t *testing.T) {
+	le := fixtureLineEvents()
+	result := LineEventsResult{
+		Events: []LineEvent{
+			{Tick: 0, Author: 0, File: "README", Added: 2},
+			{Tick: 3, Author: identity.AuthorMissing, File: "a/x.go", Added: 1, Removed: 4},
+		},
+		reversedPeopleDict: []string{"one"},
+		tickSize:           24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, le.Serialize(result, false, buffer))
+	assert.Equal(t, `  events:
+    - {tick: 0, author: 0, file: "README", added: 2, removed: 0}
+    - {tick: 3, author: -1, file: "a/x.go", added: 1, removed: 4}
+  people:
+  - "one"
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, le.Serialize(result, true, buffer))
+	reader := bufio.NewReader(bytes.NewReader(buffer.Bytes()))
+	header := pb.LineEventsAnalysisResults{}
+	assert.NoError(t, readDelimited(reader, &header))
+	assert.Equal(t, []string{"one"}, header.AuthorIndex)
+	assert.Equal(t, int64(24*time.Hour), header.TickSize)
+	event := pb.LineEvent{}
+	assert.NoError(t, readDelimited(reader, &event))
+	assert.Equal(t, "README", event.File)
+	assert.NoError(t, readDelimited(reader, &event))
+	assert.Equal(t, int32(-1), event.Author)
+	assert.Equal(t, int32(4), event.Removed)
+	deserialized, err := le.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+	_, err = le.Deserialize(buffer.Bytes()[:buffer.Len()-1])
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package leaves
+
+import (
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+)
+
+// lineTracker attributes the lines of each file to the ticks and optionally to the developers
+// exactly like BurndownAnalysis, but does not record any history. The leaves which derive
+// their results from the line state embed it: it implements Requires(), ConsumesMerges(),
+// Consume(), Hibernate() and Boot(), while the embedding leaf calls configure(), initialize(),
+// fork() and merge() from the corresponding methods.
+type lineTracker struct {
+	// burndown maintains the files, its histories are disabled with linesOnly.
+	burndown *BurndownAnalysis
+}
+
+// lineTrackerFacts are the facts which are passed to the embedded BurndownAnalysis.
+// The rest of the burndown options only change the matrices, so they are ignored.
+var lineTrackerFacts = []string{
+	core.ConfigLogger,
+	items.FactTickSize,
+	identity.FactIdentityDetectorPeopleCount,
+	identity.FactIdentityDetectorReversedPeopleDict,
+	FactBurndownIntegrityViolations,
+	core.ConfigPipelineContinueOnError,
+	ConfigBurndownHibernationThreshold,
+	ConfigBurndownHibernationToDisk,
+	ConfigBurndownHibernationDirectory,
+	ConfigBurndownDebug,
+}
+
+// Requires returns the same dependencies as BurndownAnalysis.
+func (tracker *lineTracker) Requires() []string {
+	return (&BurndownAnalysis{}).Requires()
+}
+
+// ConsumesMerges returns true: the conflicts are resolved in the merge commits.
+func (tracker *lineTracker) ConsumesMerges() bool {
+	return true
+}
+
+// configure passes lineTrackerFacts to the embedded BurndownAnalysis, so that the --burndown-*
+// flags do not affect the tracker. The lines are attributed to the developers only if
+// `trackPeople` is true.
+func (tracker *lineTracker) configure(facts map[string]interface{}, trackPeople bool) error {
+	burndownFacts := map[string]interface{}{ConfigBurndownTrackPeople: trackPeople}
+	for _, key := range lineTrackerFacts {
+		if val, exists := facts[key]; exists {
+			burndownFacts[key] = val
+		}
+	}
+	if tracker.burndown == nil {
+		tracker.burndown = &BurndownAnalysis{}
+	}
+	return tracker.burndown.Configure(burndownFacts)
+}
+
+// initialize prepares the embedded BurndownAnalysis for a series of Consume() calls.
+func (tracker *lineTracker) initialize(repository *git.Repository) error {
+	if tracker.burndown == nil {
+		tracker.burndown = &BurndownAnalysis{}
+	}
+	tracker.burndown.linesOnly = true
+	// the bands are not used, set them to avoid the warnings
+	tracker.burndown.Granularity = DefaultBurndownGranularity
+	tracker.burndown.Sampling = DefaultBurndownGranularity
+	return tracker.burndown.Initialize(repository)
+}
+
+// Consume updates the lines with the next commit data.
+func (tracker *lineTracker) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	return tracker.burndown.Consume(deps)
+}
+
+// fork clones the files `n` times.
+func (tracker *lineTracker) fork(n int) []lineTracker {
+	result := make([]lineTracker, n)
+	for i, burndown := range tracker.burndown.Fork(n) {
+		result[i].burndown = burndown.(*BurndownAnalysis)
+	}
+	return result
+}
+
+// merge combines the files of several branches, see BurndownAnalysis.Merge().
+func (tracker *lineTracker) merge(branches []lineTracker) {
+	burndowns := make([]core.PipelineItem, len(branches))
+	for i, branch := range branches {
+		burndowns[i] = branch.burndown
+	}
+	tracker.burndown.Merge(burndowns)
+}
+
+// Hibernate compresses the bound RBTree memory with the files.
+func (tracker *lineTracker) Hibernate() error {
+	return tracker.burndown.Hibernate()
+}
+
+// Boot decompresses the bound RBTree memory with the files.
+func (tracker *lineTracker) Boot() error {
+	return tracker.burndown.Boot()
+}
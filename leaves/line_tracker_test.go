@@ -0,0 +1,102 @@
+package leaves
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func TestLineTrackerConfigure(t *testing.T) {
+	tracker := lineTracker{}
+	logger := core.NewLogger()
+	people := []string{"one@srcd", "two@srcd"}
+	assert.NoError(t, tracker.configure(map[string]interface{}{
+		core.ConfigLogger:                               logger,
+		items.FactTickSize:                              time.Hour,
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: people,
+		ConfigBurndownHibernationThreshold:              100,
+		ConfigBurndownGranularity:                       7,
+		ConfigBurndownSampling:                          7,
+		ConfigBurndownTrackFiles:                        true,
+		ConfigBurndownFilesGlob:                         "*.go",
+		ConfigBurndownTrackPeople:                       false,
+		ConfigBurndownMaxPeople:                         1,
+		ConfigBurndownAuthorActivity:                    true,
+		ConfigBurndownCommitCounts:                      true,
+		ConfigBurndownSampleCommits:                     true,
+		ConfigBurndownTotalLines:                        true,
+		ConfigBurndownMeasureUnit:                       MeasureUnitBytes,
+		ConfigBurndownOldVsNewThreshold:                 10,
+	}, true))
+	burndown := tracker.burndown
+	assert.Equal(t, logger, burndown.l)
+	assert.Equal(t, time.Hour, burndown.TickSize)
+	assert.Equal(t, 2, burndown.PeopleNumber)
+	assert.Equal(t, people, burndown.reversedPeopleDict)
+	assert.Equal(t, 100, burndown.HibernationThreshold)
+	assert.Equal(t, 0, burndown.Granularity)
+	assert.False(t, burndown.TrackFiles)
+	assert.Equal(t, "", burndown.FilesGlob)
+	assert.Equal(t, 0, burndown.MaxPeople)
+	assert.False(t, burndown.AuthorActivity)
+	assert.False(t, burndown.CommitCounts)
+	assert.False(t, burndown.SampleCommits)
+	assert.False(t, burndown.TotalLines)
+	assert.Equal(t, "", burndown.MeasureUnit)
+	assert.Equal(t, 0, burndown.OldVsNewThreshold)
+	assert.NoError(t, tracker.configure(map[string]interface{}{
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: people,
+	}, false))
+	assert.Equal(t, 0, tracker.burndown.PeopleNumber)
+	assert.NoError(t, tracker.initialize(test.Repository))
+	assert.True(t, tracker.burndown.linesOnly)
+	assert.Equal(t, DefaultBurndownGranularity, tracker.burndown.Granularity)
+	assert.Equal(t, DefaultBurndownGranularity, tracker.burndown.Sampling)
+}
+
+func TestLineTrackerNoHistories(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"a.go": "1\n2\n3\n"}},
+		{Author: "two", When: when.Add(day), Files: map[string]string{"a.go": "1\n2\n3\n4\n"}},
+		{Author: "two", When: when.Add(2 * day), Parents: []int{0},
+			Files: map[string]string{"b.go": "x\ny\n"}},
+		{Author: "one", When: when.Add(4 * day), Parents: []int{1, 2},
+			Files: map[string]string{"a.go": "1\n2\n3\n4\n5\n", "b.go": "x\ny\n"}},
+	})
+	assert.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	bf := pipeline.DeployItem(&BusFactorAnalysis{}).(*BusFactorAnalysis)
+	commits, err := pipeline.Commits(false)
+	assert.NoError(t, err)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigBurndownTrackFiles:        true,
+		ConfigBurndownCommitCounts:      true,
+		ConfigBurndownSampleCommits:     true,
+		ConfigBurndownOldVsNewThreshold: 1,
+	}))
+	_, err = pipeline.Run(commits)
+	assert.NoError(t, err)
+	burndown := bf.burndown
+	assert.Len(t, burndown.files, 2)
+	assert.Equal(t, 5, burndown.files["a.go"].Len())
+	assert.Equal(t, 2, burndown.files["b.go"].Len())
+	assert.Len(t, burndown.globalHistory, 0)
+	assert.Len(t, burndown.globalCommits, 0)
+	assert.Len(t, *burndown.sampleCommits, 0)
+	assert.Len(t, burndown.oldVsNew, 0)
+	assert.Len(t, burndown.fileHistories, 0)
+	for i := range burndown.peopleHistories {
+		assert.Nil(t, burndown.peopleHistories[i])
+		assert.Nil(t, burndown.matrix[i])
+	}
+}
@@ -0,0 +1,363 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// OwnershipTransferAnalysis reports the knowledge handoffs: the moments when the developer
+// who owns the most surviving lines of a file changes. It tracks the line ownership with
+// the embedded lineTracker, like BusFactorAnalysis. It is a LeafPipelineItem.
+type OwnershipTransferAnalysis struct {
+	lineTracker
+	// owners maps the file paths to the indexes of their current majority owners.
+	// Each branch has its own copy.
+	owners map[string]int
+	// transfers are the detected events. They are shared between the forks since every regular
+	// commit is consumed by a single branch.
+	transfers *[]OwnershipTransfer
+	// tick is the tick of the last consumed commit.
+	tick int
+
+	l core.Logger
+}
+
+// OwnershipTransfer is a single change of the majority owner of a file.
+type OwnershipTransfer struct {
+	// Path is the file name.
+	Path string
+	// Tick is the tick of the commit which changed the owner.
+	Tick int
+	// From is the index of the previous owner.
+	From int
+	// To is the index of the new owner.
+	To int
+}
+
+// OwnershipTransferResult is returned by OwnershipTransferAnalysis.Finalize().
+type OwnershipTransferResult struct {
+	// Transfers are sorted by Tick and then by Path.
+	Transfers []OwnershipTransfer
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *OwnershipTransferAnalysis) Name() string {
+	return "OwnershipTransfer"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *OwnershipTransferAnalysis) Provides() []string {
+	return []string{}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *OwnershipTransferAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *OwnershipTransferAnalysis) Flag() string {
+	return "ownership-transfers"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *OwnershipTransferAnalysis) Description() string {
+	return "Reports the ticks when the developer who owns the most surviving lines " +
+		"of a file changes, together with the previous and the new owners."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *OwnershipTransferAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		analyser.l = l
+	}
+	return analyser.configure(facts, true)
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *OwnershipTransferAnalysis) Initialize(repository *git.Repository) error {
+	if analyser.l == nil {
+		analyser.l = core.NewLogger()
+	}
+	analyser.owners = map[string]int{}
+	analyser.transfers = &[]OwnershipTransfer{}
+	analyser.tick = 0
+	return analyser.initialize(repository)
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *OwnershipTransferAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	result, err := analyser.lineTracker.Consume(deps)
+	if err != nil {
+		return nil, err
+	}
+	analyser.tick = deps[items.DependencyTick].(int)
+	if deps[core.DependencyIsMerge].(bool) {
+		// the files are merged and checked in Merge()
+		return result, nil
+	}
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Delete:
+			delete(analyser.owners, change.From.Name)
+			continue
+		case merkletrie.Modify:
+			if change.From.Name != change.To.Name {
+				// the renamed file keeps its owner
+				if owner, exists := analyser.owners[change.From.Name]; exists {
+					analyser.owners[change.To.Name] = owner
+				} else {
+					delete(analyser.owners, change.To.Name)
+				}
+				delete(analyser.owners, change.From.Name)
+			}
+		}
+		owner, exists := analyser.majorityOwner(change.To.Name)
+		previous, existed := analyser.owners[change.To.Name]
+		if !exists {
+			delete(analyser.owners, change.To.Name)
+			continue
+		}
+		analyser.owners[change.To.Name] = owner
+		if existed && previous != owner {
+			*analyser.transfers = append(*analyser.transfers, OwnershipTransfer{
+				Path: change.To.Name, Tick: analyser.tick, From: previous, To: owner,
+			})
+		}
+	}
+	return result, nil
+}
+
+// majorityOwner returns the identified developer who owns the most lines of the file.
+// The ties are resolved in favor of the current owner and then of the smaller index.
+func (analyser *OwnershipTransferAnalysis) majorityOwner(path string) (int, bool) {
+	file := analyser.burndown.files[path]
+	if file == nil {
+		// binary or deleted
+		return 0, false
+	}
+	ownership := analyser.burndown.fileOwnership(file)
+	maxLines := 0
+	for author, lines := range ownership {
+		if author >= 0 && lines > maxLines {
+			maxLines = lines
+		}
+	}
+	if maxLines == 0 {
+		return 0, false
+	}
+	if current, exists := analyser.owners[path]; exists && ownership[current] == maxLines {
+		return current, true
+	}
+	owner := -1
+	for author, lines := range ownership {
+		if author >= 0 && lines == maxLines && (owner < 0 || author < owner) {
+			owner = author
+		}
+	}
+	return owner, true
+}
+
+// Fork clones this item. The files and the owners are copied by value.
+func (analyser *OwnershipTransferAnalysis) Fork(n int) []core.PipelineItem {
+	result := make([]core.PipelineItem, n)
+	for i, tracker := range analyser.fork(n) {
+		clone := *analyser
+		clone.lineTracker = tracker
+		clone.owners = make(map[string]int, len(analyser.owners))
+		for key, val := range analyser.owners {
+			clone.owners[key] = val
+		}
+		result[i] = &clone
+	}
+	return result
+}
+
+// Merge combines several items together. The file merging logic belongs to BurndownAnalysis.
+// The owners of the merged files are checked again, and the change is reported only if none
+// of the branches has already reported it.
+func (analyser *OwnershipTransferAnalysis) Merge(branches []core.PipelineItem) {
+	all := make([]*OwnershipTransferAnalysis, len(branches)+1)
+	all[0] = analyser
+	trackers := make([]lineTracker, len(branches))
+	for i, branch := range branches {
+		all[i+1] = branch.(*OwnershipTransferAnalysis)
+		trackers[i] = all[i+1].lineTracker
+	}
+	merged := map[string]bool{}
+	for _, other := range all {
+		for path := range other.burndown.mergedFiles {
+			merged[path] = true
+		}
+	}
+	analyser.merge(trackers)
+	paths := make([]string, 0, len(merged))
+	for path := range merged {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		owner, exists := analyser.majorityOwner(path)
+		reported := false
+		previous, existed := 0, false
+		for _, other := range all {
+			if current, ok := other.owners[path]; ok {
+				if current == owner {
+					reported = true
+				}
+				if !existed {
+					previous, existed = current, true
+				}
+			}
+		}
+		if exists && existed && !reported {
+			*analyser.transfers = append(*analyser.transfers, OwnershipTransfer{
+				Path: path, Tick: analyser.tick, From: previous, To: owner,
+			})
+		}
+		// Merge() must update all the branches, not only self
+		for _, other := range all {
+			if exists {
+				other.owners[path] = owner
+			} else {
+				delete(other.owners, path)
+			}
+		}
+	}
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *OwnershipTransferAnalysis) Finalize() interface{} {
+	transfers := append([]OwnershipTransfer{}, *analyser.transfers...)
+	sortOwnershipTransfers(transfers)
+	return OwnershipTransferResult{
+		Transfers:          transfers,
+		reversedPeopleDict: analyser.burndown.reversedPeopleDict,
+		tickSize:           analyser.burndown.TickSize,
+	}
+}
+
+// sortOwnershipTransfers orders the events by Tick and then by Path. The events of the same
+// file in the same tick keep their order.
+func sortOwnershipTransfers(transfers []OwnershipTransfer) {
+	sort.SliceStable(transfers, func(i, j int) bool {
+		if transfers[i].Tick != transfers[j].Tick {
+			return transfers[i].Tick < transfers[j].Tick
+		}
+		return transfers[i].Path < transfers[j].Path
+	})
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *OwnershipTransferAnalysis) Serialize(
+	result interface{}, binary bool, writer io.Writer) error {
+	transferResult := result.(OwnershipTransferResult)
+	if binary {
+		return analyser.serializeBinary(&transferResult, writer)
+	}
+	analyser.serializeText(&transferResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to OwnershipTransferResult.
+func (analyser *OwnershipTransferAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.OwnershipTransferAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	transfers := make([]OwnershipTransfer, len(message.Transfers))
+	for i, event := range message.Transfers {
+		transfers[i] = OwnershipTransfer{
+			Path: event.Path,
+			Tick: int(event.Tick),
+			From: int(event.FromAuthor),
+			To:   int(event.ToAuthor),
+		}
+	}
+	return OwnershipTransferResult{
+		Transfers:          transfers,
+		reversedPeopleDict: message.AuthorIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}, nil
+}
+
+func (analyser *OwnershipTransferAnalysis) serializeText(
+	result *OwnershipTransferResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  transfers:")
+	for _, event := range result.Transfers {
+		fmt.Fprintf(writer, "    - {path: %s, tick: %d, from: %d, to: %d}\n",
+			yaml.SafeString(event.Path), event.Tick, event.From, event.To)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (analyser *OwnershipTransferAnalysis) serializeBinary(
+	result *OwnershipTransferResult, writer io.Writer) error {
+	message := pb.OwnershipTransferAnalysisResults{
+		Transfers:   make([]*pb.OwnershipTransfer, len(result.Transfers)),
+		AuthorIndex: result.reversedPeopleDict,
+		TickSize:    int64(result.tickSize),
+	}
+	for i, event := range result.Transfers {
+		message.Transfers[i] = &pb.OwnershipTransfer{
+			Path:       event.Path,
+			Tick:       int32(event.Tick),
+			FromAuthor: int32(event.From),
+			ToAuthor:   int32(event.To),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this ownership transfer result.
+func (otr OwnershipTransferResult) GetTickSize() time.Duration {
+	return otr.tickSize
+}
+
+// GetIdentities returns the list of developer identities used to generate this ownership
+// transfer result. The format is |-joined keys, see internals/plumbing/identity for details.
+func (otr OwnershipTransferResult) GetIdentities() []string {
+	return otr.reversedPeopleDict
+}
+
+func init() {
+	core.Registry.Register(&OwnershipTransferAnalysis{})
+}
@@ -0,0 +1,162 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureOwnershipTransfer() *OwnershipTransferAnalysis {
+	ot := OwnershipTransferAnalysis{}
+	ot.Initialize(test.Repository)
+	return &ot
+}
+
+func TestOwnershipTransferMeta(t *testing.T) {
+	ot := fixtureOwnershipTransfer()
+	assert.Equal(t, ot.Name(), "OwnershipTransfer")
+	assert.Len(t, ot.Provides(), 0)
+	assert.Equal(t, (&BurndownAnalysis{}).Requires(), ot.Requires())
+	assert.Len(t, ot.ListConfigurationOptions(), 0)
+	assert.Equal(t, ot.Flag(), "ownership-transfers")
+	assert.NotEmpty(t, ot.Description())
+	logger := core.NewLogger()
+	people := []string{"one@srcd", "two@srcd"}
+	assert.NoError(t, ot.Configure(map[string]interface{}{
+		core.ConfigLogger:                               logger,
+		ConfigBurndownTrackPeople:                       false,
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: people,
+	}))
+	assert.Equal(t, logger, ot.l)
+	assert.Equal(t, 2, ot.burndown.PeopleNumber)
+	assert.Equal(t, people, ot.burndown.reversedPeopleDict)
+}
+
+func TestOwnershipTransferRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&OwnershipTransferAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "OwnershipTransfer")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&OwnershipTransferAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestOwnershipTransferFork(t *testing.T) {
+	ot1 := fixtureOwnershipTransfer()
+	ot1.owners["a.go"] = 1
+	clones := ot1.Fork(1)
+	assert.Len(t, clones, 1)
+	ot2 := clones[0].(*OwnershipTransferAnalysis)
+	assert.True(t, ot1 != ot2)
+	assert.True(t, ot1.burndown != ot2.burndown)
+	assert.True(t, ot1.transfers == ot2.transfers)
+	ot2.owners["b.go"] = 0
+	assert.Len(t, ot1.owners, 1)
+	ot1.Merge([]core.PipelineItem{ot2})
+	assert.NoError(t, ot1.Hibernate())
+	assert.NoError(t, ot1.Boot())
+}
+
+func TestOwnershipTransferConsumeFinalize(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "zoe", When: when, Parents: []int{}, Files: map[string]string{
+			"a.go": "1\n2\n3\n", "b.go": "x\n"}},
+		{Author: "adam", When: when.Add(24 * time.Hour), Parents: []int{0}, Files: map[string]string{
+			"a.go": "1\nA\nB\nC\n"}},
+		{Author: "zoe", When: when.Add(25 * time.Hour), Parents: []int{0}, Files: map[string]string{
+			"b.go": "x\ny\n"}},
+		{Author: "adam", When: when.Add(48 * time.Hour), Parents: []int{1, 2},
+			Files: map[string]string{"b.go": "x\ny\n"}},
+		{Author: "zoe", When: when.Add(72 * time.Hour), Files: map[string]string{
+			"a.go": "1\nZ\nZ\nZ\nZ\n"}},
+		{Author: "adam", When: when.Add(96 * time.Hour), Deleted: []string{"b.go"},
+			Files: map[string]string{"c.go": "x\ny\n", "a.go": "1\nZ\nZ\nZ\nZ\nA\nA\nA\nA\nA\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	ot := pipeline.DeployItem(&OwnershipTransferAnalysis{}).(*OwnershipTransferAnalysis)
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	results, err := pipeline.Run(nil)
+	require.NoError(t, err)
+	result := results[ot].(OwnershipTransferResult)
+	assert.Equal(t, []string{"adam|adam@srcd", "zoe|zoe@srcd"}, result.GetIdentities())
+	assert.Equal(t, 24*time.Hour, result.GetTickSize())
+	assert.Equal(t, []OwnershipTransfer{
+		{Path: "a.go", Tick: 1, From: 1, To: 0},
+		{Path: "a.go", Tick: 3, From: 0, To: 1},
+	}, result.Transfers)
+}
+
+func TestOwnershipTransferMajorityOwner(t *testing.T) {
+	ot := fixtureOwnershipTransfer()
+	assert.NoError(t, ot.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorPeopleCount:        3,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two", "three"},
+	}))
+	assert.NoError(t, ot.Initialize(test.Repository))
+	_, exists := ot.majorityOwner("a.go")
+	assert.False(t, exists)
+	file, err := ot.burndown.newFile(plumbing.ZeroHash, "a.go", 2, 0, 2)
+	require.NoError(t, err)
+	ot.burndown.files["a.go"] = file
+	file.Update(ot.burndown.packPersonWithTick(1, 0), 2, 2, 0)
+	// two has 2 lines, three has 2 lines
+	owner, exists := ot.majorityOwner("a.go")
+	assert.True(t, exists)
+	assert.Equal(t, 1, owner)
+	ot.owners["a.go"] = 2
+	owner, _ = ot.majorityOwner("a.go")
+	assert.Equal(t, 2, owner)
+}
+
+func TestOwnershipTransferSerialize(t *testing.T) {
+	ot := fixtureOwnershipTransfer()
+	result := OwnershipTransferResult{
+		Transfers: []OwnershipTransfer{
+			{Path: "a.go", Tick: 1, From: 1, To: 0},
+			{Path: "b/c.go", Tick: 3, From: 0, To: 1},
+		},
+		reversedPeopleDict: []string{"one", "two"},
+		tickSize:           24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, ot.Serialize(result, false, buffer))
+	assert.Equal(t, `  transfers:
+    - {path: "a.go", tick: 1, from: 1, to: 0}
+    - {path: "b/c.go", tick: 3, from: 0, to: 1}
+  people:
+  - "one"
+  - "two"
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, ot.Serialize(result, true, buffer))
+	msg := pb.OwnershipTransferAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []string{"one", "two"}, msg.AuthorIndex)
+	assert.Len(t, msg.Transfers, 2)
+	assert.Equal(t, "b/c.go", msg.Transfers[1].Path)
+	assert.Equal(t, int32(1), msg.Transfers[1].ToAuthor)
+	deserialized, err := ot.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
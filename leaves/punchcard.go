@@ -0,0 +1,384 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// PunchcardAnalysis counts the commits by the day of the week and the hour of the day
+// of their timestamps, which are chosen by core.ConfigTimeSource. It is a LeafPipelineItem.
+type PunchcardAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+
+	// Timezone is the IANA name of the time zone to convert the commit timestamps to,
+	// e.g. "UTC" or "Europe/Madrid". The empty string keeps the committers' own time zones.
+	Timezone string
+	// PerDeveloper enables the separate punchcard of each developer.
+	PerDeveloper bool
+
+	// location is the loaded Timezone, nil keeps the committers' own time zones.
+	location *time.Location
+	// total is the punchcard of all the commits.
+	total Punchcard
+	// developers maps the developer indexes to their punchcards.
+	developers map[int]*Punchcard
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// normalizeIdentities references IdentityDetector.Normalize, see MergeResults().
+	normalizeIdentities bool
+	// timeSource references Pipeline.TimeSource, see core.CommitTime().
+	timeSource string
+
+	l core.Logger
+}
+
+// Punchcard is the number of commits in each hour of each day of the week.
+// The days are indexed by time.Weekday, that is, Sunday goes first.
+type Punchcard [7][24]int64
+
+// PunchcardResult is returned by PunchcardAnalysis.Finalize() and carries the commit punchcards.
+type PunchcardResult struct {
+	// Total is the punchcard of all the commits.
+	Total Punchcard
+	// Developers maps the developer indexes to their punchcards. It is nil unless
+	// PunchcardAnalysis.PerDeveloper is enabled.
+	Developers map[int]Punchcard
+	// Timezone is copied from PunchcardAnalysis.Timezone.
+	Timezone string
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigPunchcardTimezone is the name of the option to set PunchcardAnalysis.Timezone.
+	ConfigPunchcardTimezone = "Punchcard.Timezone"
+	// ConfigPunchcardPerDeveloper is the name of the option to set PunchcardAnalysis.PerDeveloper.
+	ConfigPunchcardPerDeveloper = "Punchcard.PerDeveloper"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (punchcard *PunchcardAnalysis) Name() string {
+	return "Punchcard"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (punchcard *PunchcardAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (punchcard *PunchcardAnalysis) Requires() []string {
+	if punchcard.PerDeveloper {
+		return []string{identity.DependencyAuthor}
+	}
+	return []string{}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (punchcard *PunchcardAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigPunchcardTimezone,
+		Description: "IANA time zone to convert the commit timestamps to, e.g. \"UTC\". " +
+			"By default, the committers' own time zones are kept.",
+		Flag:    "punchcard-timezone",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
+		Name:        ConfigPunchcardPerDeveloper,
+		Description: "Record the punchcard of each developer.",
+		Flag:        "punchcard-people",
+		Type:        core.BoolConfigurationOption,
+		Default:     false},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (punchcard *PunchcardAnalysis) Flag() string {
+	return "punchcard"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (punchcard *PunchcardAnalysis) Description() string {
+	return "Counts the commits by the day of the week and the hour of the day, " +
+		"optionally for each developer."
+}
+
+// ConfigureDependencies sets PerDeveloper which Requires() depends on.
+func (punchcard *PunchcardAnalysis) ConfigureDependencies(facts map[string]interface{}) error {
+	if val, exists := facts[ConfigPunchcardPerDeveloper].(bool); exists {
+		punchcard.PerDeveloper = val
+	}
+	return nil
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (punchcard *PunchcardAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		punchcard.l = l
+	}
+	if val, exists := facts[identity.ConfigIdentityDetectorNormalize].(bool); exists {
+		punchcard.normalizeIdentities = val
+	}
+	if val, exists := facts[core.ConfigTimeSource].(string); exists {
+		punchcard.timeSource = val
+	}
+	if err := punchcard.ConfigureDependencies(facts); err != nil {
+		return err
+	}
+	if val, exists := facts[ConfigPunchcardTimezone].(string); exists {
+		if _, err := time.LoadLocation(val); err != nil {
+			return fmt.Errorf("invalid %s: %v", ConfigPunchcardTimezone, err)
+		}
+		punchcard.Timezone = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		punchcard.reversedPeopleDict = val
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (punchcard *PunchcardAnalysis) Initialize(repository *git.Repository) error {
+	if punchcard.l == nil {
+		punchcard.l = core.NewLogger()
+	}
+	punchcard.location = nil
+	if punchcard.Timezone != "" {
+		location, err := time.LoadLocation(punchcard.Timezone)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", ConfigPunchcardTimezone, err)
+		}
+		punchcard.location = location
+	}
+	punchcard.total = Punchcard{}
+	punchcard.developers = map[int]*Punchcard{}
+	punchcard.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (punchcard *PunchcardAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !punchcard.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	when := core.CommitTime(deps[core.DependencyCommit].(*object.Commit), punchcard.timeSource)
+	if punchcard.location != nil {
+		when = when.In(punchcard.location)
+	}
+	day, hour := when.Weekday(), when.Hour()
+	punchcard.total[day][hour]++
+	if !punchcard.PerDeveloper {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		return nil, nil
+	}
+	devPunchcard := punchcard.developers[author]
+	if devPunchcard == nil {
+		devPunchcard = &Punchcard{}
+		punchcard.developers[author] = devPunchcard
+	}
+	devPunchcard[day][hour]++
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (punchcard *PunchcardAnalysis) Finalize() interface{} {
+	result := PunchcardResult{
+		Total:              punchcard.total,
+		Timezone:           punchcard.Timezone,
+		reversedPeopleDict: punchcard.reversedPeopleDict,
+	}
+	if punchcard.PerDeveloper {
+		result.Developers = map[int]Punchcard{}
+		for dev, devPunchcard := range punchcard.developers {
+			result.Developers[dev] = *devPunchcard
+		}
+	}
+	return result
+}
+
+// Fork clones this PipelineItem.
+func (punchcard *PunchcardAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(punchcard, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (punchcard *PunchcardAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	punchcardResult := result.(PunchcardResult)
+	if binary {
+		return punchcard.serializeBinary(&punchcardResult, writer)
+	}
+	punchcard.serializeText(&punchcardResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to PunchcardResult.
+func (punchcard *PunchcardAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.PunchcardAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := PunchcardResult{
+		Timezone:           message.Timezone,
+		reversedPeopleDict: message.DevIndex,
+	}
+	if result.Total, err = punchcardFromProtobuf(message.Total); err != nil {
+		return nil, fmt.Errorf("total: %v", err)
+	}
+	if len(message.Developers) > 0 || len(message.DevIndex) > 0 {
+		result.Developers = map[int]Punchcard{}
+	}
+	for dev, devPunchcard := range message.Developers {
+		if result.Developers[int(dev)], err = punchcardFromProtobuf(devPunchcard); err != nil {
+			return nil, fmt.Errorf("developer %d: %v", dev, err)
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two PunchcardResult-s together by summing the punchcards.
+func (punchcard *PunchcardAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	pr1 := r1.(PunchcardResult)
+	pr2 := r2.(PunchcardResult)
+	if pr1.Timezone != pr2.Timezone {
+		return fmt.Errorf("mismatching time zones (r1: %q, r2: %q) received",
+			pr1.Timezone, pr2.Timezone)
+	}
+	merged := PunchcardResult{Timezone: pr1.Timezone}
+	merged.Total.add(&pr1.Total)
+	merged.Total.add(&pr2.Total)
+	if pr1.Developers == nil && pr2.Developers == nil {
+		return merged
+	}
+	var mergedIndex map[string]identity.MergedIndex
+	mergedIndex, merged.reversedPeopleDict = identity.MergeReversedDictsIdentities(
+		pr1.reversedPeopleDict, pr2.reversedPeopleDict, punchcard.normalizeIdentities)
+	merged.Developers = map[int]Punchcard{}
+	for _, result := range []PunchcardResult{pr1, pr2} {
+		for dev, devPunchcard := range result.Developers {
+			newdev := mergedIndex[result.reversedPeopleDict[dev]].Final
+			sum := merged.Developers[newdev]
+			sum.add(&devPunchcard)
+			merged.Developers[newdev] = sum
+		}
+	}
+	return merged
+}
+
+// add sums the other punchcard into this one.
+func (p *Punchcard) add(other *Punchcard) {
+	for day := range p {
+		for hour := range p[day] {
+			p[day][hour] += other[day][hour]
+		}
+	}
+}
+
+// rows returns the punchcard as a matrix of the days by the hours.
+func (p *Punchcard) rows() [][]int64 {
+	rows := make([][]int64, len(p))
+	for day := range p {
+		rows[day] = p[day][:]
+	}
+	return rows
+}
+
+func punchcardToProtobuf(p *Punchcard) *pb.Punchcard {
+	counts := make([]int64, 0, 7*24)
+	for _, day := range p {
+		counts = append(counts, day[:]...)
+	}
+	return &pb.Punchcard{Counts: counts}
+}
+
+func punchcardFromProtobuf(message *pb.Punchcard) (Punchcard, error) {
+	var p Punchcard
+	if message == nil {
+		return p, fmt.Errorf("the punchcard is missing")
+	}
+	if len(message.Counts) != 7*24 {
+		return p, fmt.Errorf("%d counts while %d are expected", len(message.Counts), 7*24)
+	}
+	for day := range p {
+		copy(p[day][:], message.Counts[day*24:(day+1)*24])
+	}
+	return p, nil
+}
+
+func (punchcard *PunchcardAnalysis) serializeText(result *PunchcardResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  timezone:", yaml.SafeString(result.Timezone))
+	yaml.PrintMatrix(writer, result.Total.rows(), 2, "total", false)
+	if result.Developers == nil {
+		return
+	}
+	fmt.Fprintln(writer, "  developers:")
+	devs := make([]int, 0, len(result.Developers))
+	for dev := range result.Developers {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	for _, dev := range devs {
+		devPunchcard := result.Developers[dev]
+		yaml.PrintMatrix(writer, devPunchcard.rows(), 4, strconv.Itoa(dev), false)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (punchcard *PunchcardAnalysis) serializeBinary(result *PunchcardResult, writer io.Writer) error {
+	message := pb.PunchcardAnalysisResults{
+		Total:    punchcardToProtobuf(&result.Total),
+		Timezone: result.Timezone,
+	}
+	if result.Developers != nil {
+		message.DevIndex = result.reversedPeopleDict
+		message.Developers = map[int32]*pb.Punchcard{}
+		for dev, devPunchcard := range result.Developers {
+			message.Developers[int32(dev)] = punchcardToProtobuf(&devPunchcard)
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetIdentities returns the list of developer identities used to generate this punchcard
+// result. The format is |-joined keys, see internals/plumbing/identity for details.
+func (pr PunchcardResult) GetIdentities() []string {
+	return pr.reversedPeopleDict
+}
+
+func init() {
+	core.Registry.Register(&PunchcardAnalysis{})
+}
@@ -0,0 +1,242 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixturePunchcard() *PunchcardAnalysis {
+	punchcard := PunchcardAnalysis{}
+	punchcard.Initialize(test.Repository)
+	punchcard.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	})
+	return &punchcard
+}
+
+func TestPunchcardMeta(t *testing.T) {
+	punchcard := fixturePunchcard()
+	assert.Equal(t, punchcard.Name(), "Punchcard")
+	assert.Len(t, punchcard.Provides(), 0)
+	assert.Len(t, punchcard.Requires(), 0)
+	opts := punchcard.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, ConfigPunchcardTimezone, opts[0].Name)
+	assert.Equal(t, ConfigPunchcardPerDeveloper, opts[1].Name)
+	assert.Equal(t, punchcard.Flag(), "punchcard")
+	assert.NotEmpty(t, punchcard.Description())
+	logger := core.NewLogger()
+	assert.NoError(t, punchcard.Configure(map[string]interface{}{
+		core.ConfigLogger:           logger,
+		ConfigPunchcardTimezone:     "Europe/Madrid",
+		ConfigPunchcardPerDeveloper: true,
+	}))
+	assert.Equal(t, logger, punchcard.l)
+	assert.Equal(t, "Europe/Madrid", punchcard.Timezone)
+	assert.True(t, punchcard.PerDeveloper)
+	assert.Equal(t, []string{identity.DependencyAuthor}, punchcard.Requires())
+	assert.Equal(t, []string{"one", "two"}, punchcard.reversedPeopleDict)
+	assert.Error(t, punchcard.Configure(map[string]interface{}{
+		ConfigPunchcardTimezone: "Mars/Olympus",
+	}))
+	assert.Equal(t, "Europe/Madrid", punchcard.Timezone)
+}
+
+func TestPunchcardRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&PunchcardAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Punchcard")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&PunchcardAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestPunchcardFork(t *testing.T) {
+	punchcard1 := fixturePunchcard()
+	clones := punchcard1.Fork(1)
+	assert.Len(t, clones, 1)
+	punchcard2 := clones[0].(*PunchcardAnalysis)
+	assert.True(t, punchcard1 == punchcard2)
+	punchcard1.Merge([]core.PipelineItem{punchcard2})
+}
+
+func bakePunchcard(t *testing.T, facts map[string]interface{}) (PunchcardResult, *PunchcardAnalysis) {
+	madrid := time.FixedZone("CEST", 2*3600)
+	// Wednesday
+	when := time.Date(2020, 7, 1, 23, 30, 0, 0, madrid)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{"a.go": "a\n"}},
+		{Author: "two", When: when.Add(time.Hour), Parents: []int{0},
+			Files: map[string]string{"b.go": "b\n"}},
+		{Author: "two", When: when.Add(time.Hour + time.Minute), Parents: []int{0},
+			Files: map[string]string{"c.go": "c\n"}},
+		{Author: "one", When: when.Add(3 * 24 * time.Hour), Parents: []int{1, 2},
+			Files: map[string]string{"c.go": "c\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	punchcard := &PunchcardAnalysis{}
+	require.NoError(t, punchcard.ConfigureDependencies(facts))
+	punchcard = pipeline.DeployItem(punchcard).(*PunchcardAnalysis)
+	commits, err := pipeline.Commits(false)
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Initialize(facts))
+	results, err := pipeline.Run(commits)
+	require.NoError(t, err)
+	return results[punchcard].(PunchcardResult), punchcard
+}
+
+func TestPunchcardConsumeFinalize(t *testing.T) {
+	result, _ := bakePunchcard(t, map[string]interface{}{})
+	var expected Punchcard
+	expected[time.Wednesday][23] = 1
+	expected[time.Thursday][0] = 2
+	expected[time.Saturday][23] = 1
+	assert.Equal(t, expected, result.Total)
+	assert.Nil(t, result.Developers)
+	assert.Equal(t, "", result.Timezone)
+
+	result, _ = bakePunchcard(t, map[string]interface{}{
+		ConfigPunchcardTimezone:     "UTC",
+		ConfigPunchcardPerDeveloper: true,
+	})
+	expected = Punchcard{}
+	expected[time.Wednesday][21] = 1
+	expected[time.Wednesday][22] = 2
+	expected[time.Saturday][21] = 1
+	assert.Equal(t, expected, result.Total)
+	assert.Equal(t, "UTC", result.Timezone)
+	people := result.GetIdentities()
+	require.Len(t, people, 2)
+	one, two := 0, 1
+	if people[0] != "one|one@srcd" {
+		one, two = two, one
+	}
+	require.Len(t, result.Developers, 2)
+	var expectedOne, expectedTwo Punchcard
+	expectedOne[time.Wednesday][21] = 1
+	expectedOne[time.Saturday][21] = 1
+	expectedTwo[time.Wednesday][22] = 2
+	assert.Equal(t, expectedOne, result.Developers[one])
+	assert.Equal(t, expectedTwo, result.Developers[two])
+}
+
+func TestPunchcardTimeSource(t *testing.T) {
+	// Wednesday
+	when := time.Date(2020, 7, 1, 23, 30, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, CommitterWhen: when.Add(35 * time.Hour),
+			Files: map[string]string{"a.go": "a\n"}},
+	})
+	require.NoError(t, err)
+	for _, source := range []string{core.TimeSourceCommitter, core.TimeSourceAuthor} {
+		pipeline := core.NewPipeline(repository)
+		pipeline.PrintActions = false
+		punchcard := pipeline.DeployItem(&PunchcardAnalysis{}).(*PunchcardAnalysis)
+		commits, err := pipeline.Commits(false)
+		require.NoError(t, err)
+		require.NoError(t, pipeline.Initialize(map[string]interface{}{
+			core.ConfigTimeSource: source,
+		}))
+		results, err := pipeline.Run(commits)
+		require.NoError(t, err)
+		var expected Punchcard
+		if source == core.TimeSourceAuthor {
+			expected[time.Wednesday][23] = 1
+		} else {
+			expected[time.Friday][10] = 1
+		}
+		assert.Equal(t, expected, results[punchcard].(PunchcardResult).Total, source)
+	}
+}
+
+func TestPunchcardSerialize(t *testing.T) {
+	punchcard := fixturePunchcard()
+	result := PunchcardResult{
+		Developers:         map[int]Punchcard{},
+		Timezone:           "UTC",
+		reversedPeopleDict: []string{"one", "two"},
+	}
+	result.Total[time.Monday][9] = 3
+	result.Total[time.Friday][17] = 12
+	var dev Punchcard
+	dev[time.Monday][9] = 2
+	result.Developers[1] = dev
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, punchcard.Serialize(result, false, buffer))
+	text := buffer.String()
+	assert.Contains(t, text, "  timezone: \"UTC\"\n")
+	assert.Contains(t, text, "  \"total\": |-\n")
+	assert.Contains(t, text, "  developers:\n    \"1\": |-\n")
+	assert.Contains(t, text, "  people:\n  - \"one\"\n  - \"two\"\n")
+
+	buffer.Reset()
+	assert.NoError(t, punchcard.Serialize(result, true, buffer))
+	msg := pb.PunchcardAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []string{"one", "two"}, msg.DevIndex)
+	assert.Equal(t, "UTC", msg.Timezone)
+	assert.Len(t, msg.Total.Counts, 7*24)
+	assert.Equal(t, int64(3), msg.Total.Counts[int(time.Monday)*24+9])
+	assert.Equal(t, int64(2), msg.Developers[1].Counts[int(time.Monday)*24+9])
+	deserialized, err := punchcard.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+
+	msg.Total.Counts = msg.Total.Counts[:24]
+	serialized, err := proto.Marshal(&msg)
+	assert.NoError(t, err)
+	_, err = punchcard.Deserialize(serialized)
+	assert.Error(t, err)
+}
+
+func TestPunchcardMergeResults(t *testing.T) {
+	punchcard := fixturePunchcard()
+	r1 := PunchcardResult{
+		Developers:         map[int]Punchcard{},
+		reversedPeopleDict: []string{"one", "two"},
+	}
+	r1.Total[time.Monday][9] = 3
+	var dev Punchcard
+	dev[time.Monday][9] = 1
+	r1.Developers[1] = dev
+	r2 := PunchcardResult{
+		Developers:         map[int]Punchcard{},
+		reversedPeopleDict: []string{"two", "three"},
+	}
+	r2.Total[time.Monday][9] = 1
+	r2.Total[time.Sunday][0] = 5
+	dev = Punchcard{}
+	dev[time.Monday][9] = 1
+	dev[time.Sunday][0] = 2
+	r2.Developers[0] = dev
+	c := &core.CommonAnalysisResult{}
+	merged := punchcard.MergeResults(r1, r2, c, c).(PunchcardResult)
+	var expected Punchcard
+	expected[time.Monday][9] = 4
+	expected[time.Sunday][0] = 5
+	assert.Equal(t, expected, merged.Total)
+	assert.Equal(t, []string{"one", "two", "three"}, merged.reversedPeopleDict)
+	expected = Punchcard{}
+	expected[time.Monday][9] = 2
+	expected[time.Sunday][0] = 2
+	assert.Equal(t, map[int]Punchcard{1: expected}, merged.Developers)
+	r2.Timezone = "UTC"
+	assert.IsType(t, assert.AnError, punchcard.MergeResults(r1, r2, c, c))
+}
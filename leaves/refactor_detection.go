@@ -0,0 +1,330 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// RefactorDetectionAnalysis flags the commits which rename or move many files at once.
+// It is a LeafPipelineItem.
+type RefactorDetectionAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// MinRenames is the number of renamed files starting from which a commit is a refactor.
+	MinRenames int
+	// MinRenameFraction is the ratio of the renamed files to all the changed files starting
+	// from which a commit is a refactor. 0 disables this criterion.
+	MinRenameFraction float32
+
+	events []RefactorEvent
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// RefactorEvent describes a commit which renamed or moved many files.
+type RefactorEvent struct {
+	// Commit is the hash of the refactoring commit.
+	Commit plumbing.Hash
+	// Tick is the tick of the commit.
+	Tick int
+	// Renames is the number of renamed or moved files.
+	Renames int
+	// Changes is the number of changed files including the renamed ones.
+	Changes int
+	// Author is the index of the commit's author.
+	Author int
+}
+
+// RefactorDetectionResult is returned by Finalize() and represents the analysis result.
+type RefactorDetectionResult struct {
+	// Events are the detected refactors in the order of the analysed commits.
+	Events []RefactorEvent
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+const (
+	// ConfigRefactorDetectionMinRenames is the name of the option to set
+	// RefactorDetectionAnalysis.MinRenames.
+	ConfigRefactorDetectionMinRenames = "RefactorDetection.MinRenames"
+	// ConfigRefactorDetectionMinRenameFraction is the name of the option to set
+	// RefactorDetectionAnalysis.MinRenameFraction.
+	ConfigRefactorDetectionMinRenameFraction = "RefactorDetection.MinRenameFraction"
+
+	// DefaultRefactorDetectionMinRenames is the default value of
+	// RefactorDetectionAnalysis.MinRenames.
+	DefaultRefactorDetectionMinRenames = 10
+	// DefaultRefactorDetectionMinRenameFraction is the default value of
+	// RefactorDetectionAnalysis.MinRenameFraction.
+	DefaultRefactorDetectionMinRenameFraction = float32(0.8)
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (refactors *RefactorDetectionAnalysis) Name() string {
+	return "RefactorDetection"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (refactors *RefactorDetectionAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (refactors *RefactorDetectionAnalysis) Requires() []string {
+	return []string{items.DependencyTreeChanges, identity.DependencyAuthor, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (refactors *RefactorDetectionAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigRefactorDetectionMinRenames,
+		Description: "Minimum number of renamed files in a commit to consider it a refactor.",
+		Flag:        "refactor-min-renames",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultRefactorDetectionMinRenames}, {
+		Name: ConfigRefactorDetectionMinRenameFraction,
+		Description: "Minimum ratio of the renamed files to all the changed files in a commit " +
+			"to consider it a refactor. Must be >= 0 and <= 1, 0 disables this criterion.",
+		Flag:    "refactor-min-rename-fraction",
+		Type:    core.FloatConfigurationOption,
+		Default: DefaultRefactorDetectionMinRenameFraction},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (refactors *RefactorDetectionAnalysis) Flag() string {
+	return "refactors"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (refactors *RefactorDetectionAnalysis) Description() string {
+	return "Finds the commits which rename or move many files at once: either the number " +
+		"of renames or their share among the changed files exceeds the threshold."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (refactors *RefactorDetectionAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		refactors.l = l
+	}
+	if val, exists := facts[ConfigRefactorDetectionMinRenames].(int); exists {
+		refactors.MinRenames = val
+	}
+	if val, exists := facts[ConfigRefactorDetectionMinRenameFraction].(float32); exists {
+		refactors.MinRenameFraction = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		refactors.reversedPeopleDict = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		refactors.tickSize = val
+	}
+	return nil
+}
+
+func (refactors *RefactorDetectionAnalysis) validate() {
+	if refactors.MinRenames <= 0 {
+		refactors.l.Warnf("Minimum number of renames is not positive: %d => reset to the default %d",
+			refactors.MinRenames, DefaultRefactorDetectionMinRenames)
+		refactors.MinRenames = DefaultRefactorDetectionMinRenames
+	}
+	if refactors.MinRenameFraction < 0 || refactors.MinRenameFraction > 1 {
+		refactors.l.Warnf("Minimum rename fraction is out of range: %f => reset to the default %f",
+			refactors.MinRenameFraction, DefaultRefactorDetectionMinRenameFraction)
+		refactors.MinRenameFraction = DefaultRefactorDetectionMinRenameFraction
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (refactors *RefactorDetectionAnalysis) Initialize(repository *git.Repository) error {
+	if refactors.l == nil {
+		refactors.l = core.NewLogger()
+	}
+	refactors.events = []RefactorEvent{}
+	refactors.OneShotMergeProcessor.Initialize()
+	if refactors.tickSize == 0 {
+		refactors.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	refactors.validate()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (refactors *RefactorDetectionAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !refactors.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	renames := 0
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		if action == merkletrie.Modify && change.From.Name != change.To.Name {
+			renames++
+		}
+	}
+	if !refactors.isRefactor(renames, len(changes)) {
+		return nil, nil
+	}
+	refactors.events = append(refactors.events, RefactorEvent{
+		Commit:  deps[core.DependencyCommit].(*object.Commit).Hash,
+		Tick:    deps[items.DependencyTick].(int),
+		Renames: renames,
+		Changes: len(changes),
+		Author:  deps[identity.DependencyAuthor].(int),
+	})
+	return nil, nil
+}
+
+// isRefactor decides whether a commit with `renames` renamed files out of `changes`
+// changed files is a refactor. A single renamed file is never a refactor.
+func (refactors *RefactorDetectionAnalysis) isRefactor(renames, changes int) bool {
+	if renames >= refactors.MinRenames {
+		return true
+	}
+	return renames > 1 && refactors.MinRenameFraction > 0 &&
+		float32(renames) >= refactors.MinRenameFraction*float32(changes)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (refactors *RefactorDetectionAnalysis) Finalize() interface{} {
+	return RefactorDetectionResult{
+		Events:             refactors.events,
+		reversedPeopleDict: refactors.reversedPeopleDict,
+		tickSize:           refactors.tickSize,
+	}
+}
+
+// Fork clones this PipelineItem.
+func (refactors *RefactorDetectionAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(refactors, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (refactors *RefactorDetectionAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	refactorsResult := result.(RefactorDetectionResult)
+	if binary {
+		return refactors.serializeBinary(&refactorsResult, writer)
+	}
+	refactors.serializeText(&refactorsResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to RefactorDetectionResult.
+func (refactors *RefactorDetectionAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.RefactorDetectionAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]RefactorEvent, len(message.Events))
+	for i, event := range message.Events {
+		author := int(event.Author)
+		if author == -1 {
+			author = identity.AuthorMissing
+		}
+		events[i] = RefactorEvent{
+			Commit:  plumbing.NewHash(event.Commit),
+			Tick:    int(event.Tick),
+			Renames: int(event.Renames),
+			Changes: int(event.Changes),
+			Author:  author,
+		}
+	}
+	return RefactorDetectionResult{
+		Events:             events,
+		reversedPeopleDict: message.AuthorIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}, nil
+}
+
+func (refactors *RefactorDetectionAnalysis) serializeText(result *RefactorDetectionResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  events:")
+	for _, event := range result.Events {
+		author := event.Author
+		if author == identity.AuthorMissing {
+			author = -1
+		}
+		fmt.Fprintf(writer, "    - {commit: %s, tick: %d, renames: %d, changes: %d, author: %d}\n",
+			event.Commit.String(), event.Tick, event.Renames, event.Changes, author)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (refactors *RefactorDetectionAnalysis) serializeBinary(result *RefactorDetectionResult, writer io.Writer) error {
+	message := pb.RefactorDetectionAnalysisResults{
+		Events:      make([]*pb.RefactorEvent, len(result.Events)),
+		AuthorIndex: result.reversedPeopleDict,
+		TickSize:    int64(result.tickSize),
+	}
+	for i, event := range result.Events {
+		author := event.Author
+		if author == identity.AuthorMissing {
+			author = -1
+		}
+		message.Events[i] = &pb.RefactorEvent{
+			Commit:  event.Commit.String(),
+			Tick:    int32(event.Tick),
+			Renames: int32(event.Renames),
+			Changes: int32(event.Changes),
+			Author:  int32(author),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this refactor detection result.
+func (rdr RefactorDetectionResult) GetTickSize() time.Duration {
+	return rdr.tickSize
+}
+
+// GetIdentities returns the list of developer identities used to generate this refactor
+// detection result. The format is |-joined keys, see internals/plumbing/identity for details.
+func (rdr RefactorDetectionResult) GetIdentities() []string {
+	return rdr.reversedPeopleDict
+}
+
+func init() {
+	core.Registry.Register(&RefactorDetectionAnalysis{})
+}
@@ -0,0 +1,167 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureRefactorDetection() *RefactorDetectionAnalysis {
+	refactors := RefactorDetectionAnalysis{}
+	refactors.Initialize(test.Repository)
+	return &refactors
+}
+
+func TestRefactorDetectionMeta(t *testing.T) {
+	refactors := fixtureRefactorDetection()
+	assert.Equal(t, refactors.Name(), "RefactorDetection")
+	assert.Len(t, refactors.Provides(), 0)
+	assert.Equal(t, []string{
+		items.DependencyTreeChanges, identity.DependencyAuthor, items.DependencyTick},
+		refactors.Requires())
+	opts := refactors.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Name, ConfigRefactorDetectionMinRenames)
+	assert.Equal(t, opts[1].Name, ConfigRefactorDetectionMinRenameFraction)
+	assert.Equal(t, refactors.Flag(), "refactors")
+	assert.NotEmpty(t, refactors.Description())
+	assert.Equal(t, DefaultRefactorDetectionMinRenames, refactors.MinRenames)
+	assert.Equal(t, float32(0), refactors.MinRenameFraction)
+	logger := core.NewLogger()
+	people := []string{"one@srcd", "two@srcd"}
+	assert.NoError(t, refactors.Configure(map[string]interface{}{
+		core.ConfigLogger:                               logger,
+		ConfigRefactorDetectionMinRenames:               3,
+		ConfigRefactorDetectionMinRenameFraction:        float32(0.25),
+		identity.FactIdentityDetectorReversedPeopleDict: people,
+		items.FactTickSize:                              time.Hour,
+	}))
+	assert.Equal(t, logger, refactors.l)
+	assert.Equal(t, 3, refactors.MinRenames)
+	assert.Equal(t, float32(0.25), refactors.MinRenameFraction)
+	assert.Equal(t, people, refactors.reversedPeopleDict)
+	assert.Equal(t, time.Hour, refactors.tickSize)
+	refactors.MinRenames = -1
+	refactors.MinRenameFraction = 2
+	assert.NoError(t, refactors.Initialize(test.Repository))
+	assert.Equal(t, DefaultRefactorDetectionMinRenames, refactors.MinRenames)
+	assert.Equal(t, DefaultRefactorDetectionMinRenameFraction, refactors.MinRenameFraction)
+}
+
+func TestRefactorDetectionRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&RefactorDetectionAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "RefactorDetection")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&RefactorDetectionAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestRefactorDetectionFork(t *testing.T) {
+	refactors1 := fixtureRefactorDetection()
+	clones := refactors1.Fork(1)
+	assert.Len(t, clones, 1)
+	refactors2 := clones[0].(*RefactorDetectionAnalysis)
+	assert.True(t, refactors1 == refactors2)
+	refactors1.Merge([]core.PipelineItem{refactors2})
+}
+
+func TestRefactorDetectionIsRefactor(t *testing.T) {
+	refactors := fixtureRefactorDetection()
+	refactors.MinRenames = 3
+	refactors.MinRenameFraction = 0.5
+	assert.False(t, refactors.isRefactor(0, 0))
+	assert.False(t, refactors.isRefactor(1, 1))
+	assert.True(t, refactors.isRefactor(2, 4))
+	assert.False(t, refactors.isRefactor(2, 5))
+	assert.True(t, refactors.isRefactor(3, 100))
+	refactors.MinRenameFraction = 0
+	assert.False(t, refactors.isRefactor(2, 2))
+}
+
+func TestRefactorDetectionConsumeFinalize(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, hashes, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"a.go": "a\na\na\n", "b.go": "b\nb\nb\n", "c.go": "c\nc\nc\n", "d.go": "d\nd\nd\n"}},
+		// a single rename is not a refactor
+		{Author: "one", When: when.Add(24 * time.Hour), Deleted: []string{"a.go"},
+			Files: map[string]string{"x/a.go": "a\na\na\n"}},
+		// 2 renames out of 3 changes
+		{Author: "two", When: when.Add(48 * time.Hour), Deleted: []string{"b.go", "c.go"},
+			Files: map[string]string{
+				"x/b.go": "b\nb\nb\n", "x/c.go": "c\nc\nc\n", "d.go": "d\nd\nd\nd\n"}},
+		// 1 rename out of 3 changes
+		{Author: "two", When: when.Add(72 * time.Hour), Deleted: []string{"d.go"},
+			Files: map[string]string{
+				"x/d.go": "d\nd\nd\nd\n", "e.go": "e\n", "f.go": "f\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	refactors := pipeline.DeployItem(&RefactorDetectionAnalysis{}).(*RefactorDetectionAnalysis)
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigRefactorDetectionMinRenames:        3,
+		ConfigRefactorDetectionMinRenameFraction: float32(0.5),
+	}))
+	results, err := pipeline.Run(nil)
+	require.NoError(t, err)
+	result := results[refactors].(RefactorDetectionResult)
+	assert.Equal(t, []string{"two|two@srcd", "one|one@srcd"}, result.GetIdentities())
+	assert.Equal(t, 24*time.Hour, result.GetTickSize())
+	assert.Equal(t, []RefactorEvent{
+		{Commit: hashes[2], Tick: 2, Renames: 2, Changes: 3, Author: 0},
+	}, result.Events)
+}
+
+func TestRefactorDetectionSerialize(t *testing.T) {
+	refactors := fixtureRefactorDetection()
+	result := RefactorDetectionResult{
+		Events: []RefactorEvent{
+			{Commit: plumbing.NewHash("cce947b98a050c6d356bc6ba95030254914027b1"),
+				Tick: 1, Renames: 12, Changes: 20, Author: 0},
+			{Commit: plumbing.NewHash("a3ee37f91f0d705ec9c41ae88426f0ae44b2fbc3"),
+				Tick: 5, Renames: 3, Changes: 3, Author: identity.AuthorMissing},
+		},
+		reversedPeopleDict: []string{"one", "two"},
+		tickSize:           24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, refactors.Serialize(result, false, buffer))
+	assert.Equal(t, `  events:
+    - {commit: cce947b98a050c6d356bc6ba95030254914027b1, tick: 1, renames: 12, changes: 20, author: 0}
+    - {commit: a3ee37f91f0d705ec9c41ae88426f0ae44b2fbc3, tick: 5, renames: 3, changes: 3, author: -1}
+  people:
+  - "one"
+  - "two"
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, refactors.Serialize(result, true, buffer))
+	msg := pb.RefactorDetectionAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []string{"one", "two"}, msg.AuthorIndex)
+	assert.Len(t, msg.Events, 2)
+	assert.Equal(t, int32(12), msg.Events[0].Renames)
+	assert.Equal(t, int32(-1), msg.Events[1].Author)
+	deserialized, err := refactors.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
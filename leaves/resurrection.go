@@ -0,0 +1,401 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/yaml"
+)
+
+// ResurrectionAnalysis reports the files which were deleted and later appeared again under
+// the same path, since their history confuses the ownership. A file comes back either because
+// it was added again or because it was renamed away and then renamed back.
+// It is a LeafPipelineItem.
+type ResurrectionAnalysis struct {
+	// gone maps the deleted and the renamed away paths to their disappearance. Each branch
+	// has its own copy.
+	gone map[string]resurrectionGone
+	// origins maps the renamed files to the paths which they were originally renamed from.
+	// Each branch has its own copy.
+	origins map[string]string
+	// resurrections are the detected events by commit. It is shared between the forks since
+	// every regular commit is consumed by a single branch.
+	resurrections map[plumbing.Hash][]Resurrection
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// resurrectionGone is the disappearance of a path.
+type resurrectionGone struct {
+	tick   int
+	author int
+}
+
+// Resurrection is a single event when a path appeared again after it had disappeared.
+type Resurrection struct {
+	// Path is the file path which came back.
+	Path string
+	// DeletionTick is the tick of the commit which deleted or renamed away the file.
+	DeletionTick int
+	// DeletionAuthor is the index of the developer who deleted or renamed away the file.
+	DeletionAuthor int
+	// ResurrectionTick is the tick of the commit which brought the file back.
+	ResurrectionTick int
+	// ResurrectionAuthor is the index of the developer who brought the file back.
+	ResurrectionAuthor int
+	// RenameBack is true if the file was renamed away from Path and later renamed back,
+	// and false if it was added again.
+	RenameBack bool
+}
+
+// ResurrectionResult is returned by ResurrectionAnalysis.Finalize().
+type ResurrectionResult struct {
+	// Resurrections are sorted by ResurrectionTick and then by Path.
+	Resurrections []Resurrection
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *ResurrectionAnalysis) Name() string {
+	return "Resurrection"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *ResurrectionAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (analyser *ResurrectionAnalysis) Requires() []string {
+	return []string{items.DependencyTreeChanges, identity.DependencyAuthor, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *ResurrectionAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *ResurrectionAnalysis) Flag() string {
+	return "resurrections"
+}
+
+// ConsumesMerges returns true: the deleted and restored paths of the branches are
+// reconciled on the merges.
+func (analyser *ResurrectionAnalysis) ConsumesMerges() bool {
+	return true
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *ResurrectionAnalysis) Description() string {
+	return "Reports the files which were deleted and later added again under the same path, " +
+		"or renamed away and later renamed back, with the ticks and the authors of both events."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *ResurrectionAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		analyser.l = l
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		analyser.reversedPeopleDict = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		analyser.tickSize = val
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *ResurrectionAnalysis) Initialize(repository *git.Repository) error {
+	if analyser.l == nil {
+		analyser.l = core.NewLogger()
+	}
+	analyser.gone = map[string]resurrectionGone{}
+	analyser.origins = map[string]string{}
+	analyser.resurrections = map[plumbing.Hash][]Resurrection{}
+	if analyser.tickSize == 0 {
+		analyser.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *ResurrectionAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	author := deps[identity.DependencyAuthor].(int)
+	tick := deps[items.DependencyTick].(int)
+	// each branch diffs the merge commit against its own parent, so the files which were
+	// deleted in one branch appear to be added again in the other
+	isMerge := deps[core.DependencyIsMerge].(bool)
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	var resurrections []Resurrection
+	comeBack := func(path string, renameBack bool) {
+		gone, exists := analyser.gone[path]
+		if !exists {
+			return
+		}
+		delete(analyser.gone, path)
+		if isMerge {
+			return
+		}
+		resurrections = append(resurrections, Resurrection{
+			Path:               path,
+			DeletionTick:       gone.tick,
+			DeletionAuthor:     gone.author,
+			ResurrectionTick:   tick,
+			ResurrectionAuthor: author,
+			RenameBack:         renameBack,
+		})
+	}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			delete(analyser.origins, change.To.Name)
+			comeBack(change.To.Name, false)
+		case merkletrie.Delete:
+			delete(analyser.origins, change.From.Name)
+			analyser.gone[change.From.Name] = resurrectionGone{tick: tick, author: author}
+		case merkletrie.Modify:
+			if change.From.Name == change.To.Name {
+				break
+			}
+			origin, exists := analyser.origins[change.From.Name]
+			if !exists {
+				origin = change.From.Name
+			}
+			delete(analyser.origins, change.From.Name)
+			analyser.gone[change.From.Name] = resurrectionGone{tick: tick, author: author}
+			if origin == change.To.Name {
+				comeBack(change.To.Name, true)
+			} else {
+				// another file takes the path of the gone one, that is not a resurrection
+				delete(analyser.gone, change.To.Name)
+				analyser.origins[change.To.Name] = origin
+			}
+		}
+	}
+	if len(resurrections) > 0 {
+		analyser.resurrections[commit.Hash] = resurrections
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *ResurrectionAnalysis) Finalize() interface{} {
+	var resurrections []Resurrection
+	for _, events := range analyser.resurrections {
+		resurrections = append(resurrections, events...)
+	}
+	sortResurrections(resurrections)
+	return ResurrectionResult{
+		Resurrections:      resurrections,
+		reversedPeopleDict: analyser.reversedPeopleDict,
+		tickSize:           analyser.tickSize,
+	}
+}
+
+// sortResurrections orders the events by ResurrectionTick, Path and DeletionTick.
+func sortResurrections(resurrections []Resurrection) {
+	sort.Slice(resurrections, func(i, j int) bool {
+		ri, rj := resurrections[i], resurrections[j]
+		if ri.ResurrectionTick != rj.ResurrectionTick {
+			return ri.ResurrectionTick < rj.ResurrectionTick
+		}
+		if ri.Path != rj.Path {
+			return ri.Path < rj.Path
+		}
+		return ri.DeletionTick < rj.DeletionTick
+	})
+}
+
+// Fork clones this item. The gone paths and the rename origins are copied by value,
+// the detected events are shared.
+func (analyser *ResurrectionAnalysis) Fork(n int) []core.PipelineItem {
+	result := make([]core.PipelineItem, n)
+	for i := range result {
+		clone := *analyser
+		clone.gone = make(map[string]resurrectionGone, len(analyser.gone))
+		for key, val := range analyser.gone {
+			clone.gone[key] = val
+		}
+		clone.origins = make(map[string]string, len(analyser.origins))
+		for key, val := range analyser.origins {
+			clone.origins[key] = val
+		}
+		result[i] = &clone
+	}
+	return result
+}
+
+// Merge combines several branches together. All the branches have consumed the merge commit,
+// so they agree on which paths are gone, but a branch which did not delete the file itself
+// attributes the deletion to the merge commit. Hence the earliest deletion wins.
+func (analyser *ResurrectionAnalysis) Merge(branches []core.PipelineItem) {
+	all := append([]*ResurrectionAnalysis{analyser}, make([]*ResurrectionAnalysis, len(branches))...)
+	for i, branch := range branches {
+		all[i+1] = branch.(*ResurrectionAnalysis)
+	}
+	gone := map[string]resurrectionGone{}
+	origins := map[string]string{}
+	for _, other := range all {
+		for path, g := range other.gone {
+			if mine, exists := gone[path]; !exists || g.tick < mine.tick {
+				gone[path] = g
+			}
+		}
+		for path, origin := range other.origins {
+			if _, exists := origins[path]; !exists {
+				origins[path] = origin
+			}
+		}
+	}
+	// Merge() must update all the branches, not only self
+	for _, other := range all {
+		other.gone = make(map[string]resurrectionGone, len(gone))
+		for path, g := range gone {
+			other.gone[path] = g
+		}
+		other.origins = make(map[string]string, len(origins))
+		for path, origin := range origins {
+			other.origins[path] = origin
+		}
+	}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *ResurrectionAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	resurrectionResult := result.(ResurrectionResult)
+	if binary {
+		return analyser.serializeBinary(&resurrectionResult, writer)
+	}
+	analyser.serializeText(&resurrectionResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to ResurrectionResult.
+func (analyser *ResurrectionAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ResurrectionAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	unpackAuthor := func(author int32) int {
+		if author == -1 {
+			return identity.AuthorMissing
+		}
+		return int(author)
+	}
+	var resurrections []Resurrection
+	for _, event := range message.Resurrections {
+		resurrections = append(resurrections, Resurrection{
+			Path:               event.Path,
+			DeletionTick:       int(event.DeletionTick),
+			DeletionAuthor:     unpackAuthor(event.DeletionAuthor),
+			ResurrectionTick:   int(event.ResurrectionTick),
+			ResurrectionAuthor: unpackAuthor(event.ResurrectionAuthor),
+			RenameBack:         event.RenameBack,
+		})
+	}
+	return ResurrectionResult{
+		Resurrections:      resurrections,
+		reversedPeopleDict: message.AuthorIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}, nil
+}
+
+// packResurrectionAuthor converts identity.AuthorMissing to -1 in the serialized results.
+func packResurrectionAuthor(author int) int {
+	if author == identity.AuthorMissing {
+		return -1
+	}
+	return author
+}
+
+func (analyser *ResurrectionAnalysis) serializeText(result *ResurrectionResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  resurrections:")
+	for _, event := range result.Resurrections {
+		fmt.Fprintf(writer, "    - {path: %s, deletion_tick: %d, deletion_author: %d, "+
+			"resurrection_tick: %d, resurrection_author: %d, rename_back: %t}\n",
+			yaml.SafeString(event.Path), event.DeletionTick,
+			packResurrectionAuthor(event.DeletionAuthor), event.ResurrectionTick,
+			packResurrectionAuthor(event.ResurrectionAuthor), event.RenameBack)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (analyser *ResurrectionAnalysis) serializeBinary(result *ResurrectionResult, writer io.Writer) error {
+	message := pb.ResurrectionAnalysisResults{
+		Resurrections: make([]*pb.Resurrection, len(result.Resurrections)),
+		AuthorIndex:   result.reversedPeopleDict,
+		TickSize:      int64(result.tickSize),
+	}
+	for i, event := range result.Resurrections {
+		message.Resurrections[i] = &pb.Resurrection{
+			Path:               event.Path,
+			DeletionTick:       int32(event.DeletionTick),
+			DeletionAuthor:     int32(packResurrectionAuthor(event.DeletionAuthor)),
+			ResurrectionTick:   int32(event.ResurrectionTick),
+			ResurrectionAuthor: int32(packResurrectionAuthor(event.ResurrectionAuthor)),
+			RenameBack:         event.RenameBack,
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this resurrection result.
+func (rr ResurrectionResult) GetTickSize() time.Duration {
+	return rr.tickSize
+}
+
+// GetIdentities returns the list of developer identities used to generate this resurrection result.
+// The format is |-joined keys, see internals/plumbing/identity for details.
+func (rr ResurrectionResult) GetIdentities() []string {
+	return rr.reversedPeopleDict
+}
+
+func init() {
+	core.Registry.Register(&ResurrectionAnalysis{})
+}
@@ -0,0 +1,254 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureResurrection() *ResurrectionAnalysis {
+	ra := ResurrectionAnalysis{}
+	ra.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one@srcd", "two@srcd"},
+	})
+	ra.Initialize(test.Repository)
+	return &ra
+}
+
+func TestResurrectionMeta(t *testing.T) {
+	ra := fixtureResurrection()
+	assert.Equal(t, ra.Name(), "Resurrection")
+	assert.Len(t, ra.Provides(), 0)
+	assert.Equal(t, []string{
+		items.DependencyTreeChanges, identity.DependencyAuthor, items.DependencyTick},
+		ra.Requires())
+	assert.Len(t, ra.ListConfigurationOptions(), 0)
+	assert.Equal(t, ra.Flag(), "resurrections")
+	assert.NotEmpty(t, ra.Description())
+	assert.Equal(t, 24*time.Hour, ra.tickSize)
+	logger := core.NewLogger()
+	assert.NoError(t, ra.Configure(map[string]interface{}{
+		core.ConfigLogger:  logger,
+		items.FactTickSize: time.Hour,
+	}))
+	assert.Equal(t, logger, ra.l)
+	assert.Equal(t, time.Hour, ra.tickSize)
+	assert.Equal(t, []string{"one@srcd", "two@srcd"}, ra.reversedPeopleDict)
+}
+
+func TestResurrectionRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&ResurrectionAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Resurrection")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&ResurrectionAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func consumeResurrection(t *testing.T, ra *ResurrectionAnalysis, hash string, merge bool,
+	tick, author int, changes ...*object.Change) {
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: plumbing.NewHash(
+				"291286b4ac41952cbd1389fda66420ec03c1a9fe")}}
+	}
+	for _, change := range changes {
+		if change.From.Name != "" {
+			change.From = entry(change.From.Name)
+		}
+		if change.To.Name != "" {
+			change.To = entry(change.To.Name)
+		}
+	}
+	res, err := ra.Consume(map[string]interface{}{
+		core.DependencyCommit:       &object.Commit{Hash: plumbing.NewHash(hash)},
+		core.DependencyIsMerge:      merge,
+		items.DependencyTreeChanges: object.Changes(changes),
+		identity.DependencyAuthor:   author,
+		items.DependencyTick:        tick,
+	})
+	assert.Nil(t, res)
+	assert.NoError(t, err)
+}
+
+func resurrectionChange(from, to string) *object.Change {
+	return &object.Change{
+		From: object.ChangeEntry{Name: from}, To: object.ChangeEntry{Name: to}}
+}
+
+func bakeResurrection(t *testing.T) *ResurrectionAnalysis {
+	ra := fixtureResurrection()
+	consumeResurrection(t, ra, "01", false, 0, 0,
+		resurrectionChange("", "a.go"), resurrectionChange("", "b.go"),
+		resurrectionChange("", "c.go"), resurrectionChange("", "d.go"))
+	consumeResurrection(t, ra, "02", false, 1, 0,
+		resurrectionChange("a.go", ""), resurrectionChange("b.go", "e.go"),
+		resurrectionChange("c.go", "f.go"))
+	consumeResurrection(t, ra, "03", false, 2, identity.AuthorMissing,
+		resurrectionChange("e.go", "g.go"))
+	consumeResurrection(t, ra, "04", false, 3, 1,
+		// true re-add
+		resurrectionChange("", "a.go"),
+		// rename back through e.go
+		resurrectionChange("g.go", "b.go"),
+		// another file takes the path
+		resurrectionChange("d.go", "c.go"),
+		// a new file, not a resurrection
+		resurrectionChange("", "h.go"))
+	return ra
+}
+
+func TestResurrectionConsumeFinalize(t *testing.T) {
+	ra := bakeResurrection(t)
+	result := ra.Finalize().(ResurrectionResult)
+	assert.Equal(t, []Resurrection{
+		{Path: "a.go", DeletionTick: 1, DeletionAuthor: 0, ResurrectionTick: 3,
+			ResurrectionAuthor: 1},
+		{Path: "b.go", DeletionTick: 1, DeletionAuthor: 0, ResurrectionTick: 3,
+			ResurrectionAuthor: 1, RenameBack: true},
+	}, result.Resurrections)
+	assert.Equal(t, map[string]resurrectionGone{
+		"d.go": {tick: 3, author: 1},
+		"e.go": {tick: 2, author: identity.AuthorMissing},
+		"g.go": {tick: 3, author: 1},
+	}, ra.gone)
+	assert.Equal(t, map[string]string{"c.go": "d.go", "f.go": "c.go"}, ra.origins)
+	assert.Equal(t, []string{"one@srcd", "two@srcd"}, result.GetIdentities())
+	assert.Equal(t, 24*time.Hour, result.GetTickSize())
+	assert.Len(t, fixtureResurrection().Finalize().(ResurrectionResult).Resurrections, 0)
+}
+
+func TestResurrectionForkMerge(t *testing.T) {
+	ra1 := fixtureResurrection()
+	consumeResurrection(t, ra1, "01", false, 0, 0,
+		resurrectionChange("", "a.go"), resurrectionChange("", "b.go"))
+	clones := ra1.Fork(2)
+	assert.Len(t, clones, 2)
+	ra2, ra3 := clones[0].(*ResurrectionAnalysis), clones[1].(*ResurrectionAnalysis)
+	assert.True(t, ra1 != ra2)
+	// ra2 deletes a.go, ra3 renames b.go
+	consumeResurrection(t, ra2, "02", false, 1, 1, resurrectionChange("a.go", ""))
+	consumeResurrection(t, ra3, "03", false, 2, 0, resurrectionChange("b.go", "c.go"))
+	assert.Len(t, ra3.gone, 1)
+	assert.Len(t, ra2.origins, 0)
+	// the merge commit brings the changes of the other branch
+	consumeResurrection(t, ra2, "04", true, 3, 0, resurrectionChange("b.go", "c.go"))
+	consumeResurrection(t, ra3, "04", true, 3, 0, resurrectionChange("a.go", ""))
+	ra2.Merge([]core.PipelineItem{ra3})
+	assert.Equal(t, map[string]resurrectionGone{
+		"a.go": {tick: 1, author: 1},
+		"b.go": {tick: 2, author: 0},
+	}, ra2.gone)
+	assert.Equal(t, map[string]string{"c.go": "b.go"}, ra2.origins)
+	consumeResurrection(t, ra2, "05", false, 4, 0,
+		resurrectionChange("", "a.go"), resurrectionChange("c.go", "b.go"))
+	assert.Equal(t, []Resurrection{
+		{Path: "a.go", DeletionTick: 1, DeletionAuthor: 1, ResurrectionTick: 4},
+		{Path: "b.go", DeletionTick: 2, DeletionAuthor: 0, ResurrectionTick: 4,
+			RenameBack: true},
+	}, ra1.Finalize().(ResurrectionResult).Resurrections)
+}
+
+func TestResurrectionMergeCommit(t *testing.T) {
+	ra := fixtureResurrection()
+	consumeResurrection(t, ra, "01", false, 0, 0, resurrectionChange("", "a.go"))
+	consumeResurrection(t, ra, "02", false, 1, 0, resurrectionChange("a.go", ""))
+	// the other branch still has a.go
+	consumeResurrection(t, ra, "03", true, 2, 0, resurrectionChange("", "a.go"))
+	assert.Len(t, ra.Finalize().(ResurrectionResult).Resurrections, 0)
+	assert.Len(t, ra.gone, 0)
+}
+
+func TestResurrectionPipeline(t *testing.T) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"a.go": "package a\n", "b.go": "package b\n\nfunc B() {}\n", "c.go": "package c\n"}},
+		{Author: "one", When: when.Add(day), Deleted: []string{"a.go"}},
+		{Author: "two", When: when.Add(2 * day), Files: map[string]string{"a.go": "package aa\n"}},
+		{Author: "one", When: when.Add(3 * day), Deleted: []string{"b.go"},
+			Files: map[string]string{"d.go": "package b\n\nfunc B() {}\n"}},
+		{Author: "two", When: when.Add(4 * day), Deleted: []string{"d.go"},
+			Files: map[string]string{"b.go": "package b\n\nfunc B() {}\n"}},
+		// 5 and 6 are parallel branches
+		{Author: "two", When: when.Add(5 * day), Deleted: []string{"c.go"}},
+		{Author: "one", When: when.Add(6 * day), Parents: []int{4},
+			Files: map[string]string{"e.go": "package e\n"}},
+		{Author: "one", When: when.Add(7 * day), Parents: []int{5, 6},
+			Files: map[string]string{"e.go": "package e\n"}},
+		{Author: "one", When: when.Add(8 * day), Files: map[string]string{"c.go": "package cc\n"}},
+	})
+	require.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	ra := pipeline.DeployItem(&ResurrectionAnalysis{}).(*ResurrectionAnalysis)
+	commits, err := pipeline.Commits(false)
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	results, err := pipeline.Run(commits)
+	require.NoError(t, err)
+	result := results[ra].(ResurrectionResult)
+	people := result.GetIdentities()
+	require.Len(t, people, 2)
+	one, two := 0, 1
+	if people[0] != "one|one@srcd" {
+		one, two = 1, 0
+	}
+	assert.Equal(t, []Resurrection{
+		{Path: "a.go", DeletionTick: 1, DeletionAuthor: one, ResurrectionTick: 2,
+			ResurrectionAuthor: two},
+		{Path: "b.go", DeletionTick: 3, DeletionAuthor: one, ResurrectionTick: 4,
+			ResurrectionAuthor: two, RenameBack: true},
+		{Path: "c.go", DeletionTick: 5, DeletionAuthor: two, ResurrectionTick: 8,
+			ResurrectionAuthor: one},
+	}, result.Resurrections)
+}
+
+func TestResurrectionSerialize(t *testing.T) {
+	ra := bakeResurrection(t)
+	consumeResurrection(t, ra, "06", false, 5, identity.AuthorMissing,
+		resurrectionChange("", "e.go"))
+	result := ra.Finalize()
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, ra.Serialize(result, false, buffer))
+	assert.Equal(t, `  resurrections:
+    - {path: "a.go", deletion_tick: 1, deletion_author: 0, resurrection_tick: 3, resurrection_author: 1, rename_back: false}
+    - {path: "b.go", deletion_tick: 1, deletion_author: 0, resurrection_tick: 3, resurrection_author: 1, rename_back: true}
+    - {path: "e.go", deletion_tick: 2, deletion_author: -1, resurrection_tick: 5, resurrection_author: -1, rename_back: false}
+  people:
+  - "one@srcd"
+  - "two@srcd"
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, ra.Serialize(result, true, buffer))
+	msg := pb.ResurrectionAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Resurrections, 3)
+	assert.Equal(t, &pb.Resurrection{Path: "e.go", DeletionTick: 2, DeletionAuthor: -1,
+		ResurrectionTick: 5, ResurrectionAuthor: -1}, msg.Resurrections[2])
+	assert.Equal(t, []string{"one@srcd", "two@srcd"}, msg.AuthorIndex)
+	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	deserialized, err := ra.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
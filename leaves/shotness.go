@@ -1,8 +1,15 @@
 package leaves
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
 	"sort"
 	"unicode/utf8"
 
@@ -13,7 +20,9 @@ import (
 	uast_nodes "gopkg.in/bblfsh/sdk.v2/uast/nodes"
 	"gopkg.in/bblfsh/sdk.v2/uast/query"
 	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
 	"gopkg.in/src-d/hercules.v10/internal/core"
 	"gopkg.in/src-d/hercules.v10/internal/pb"
 	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
@@ -25,16 +34,25 @@ import (
 type ShotnessAnalysis struct {
 	core.NoopMerger
 	core.OneShotMergeProcessor
+	// Backend extracts the analysed nodes: either ShotnessBackendUAST or ShotnessBackendCtags.
+	Backend     string
 	XpathStruct string
 	XpathName   string
+	// CtagsKinds are the ctags tag kinds to analyse with ShotnessBackendCtags.
+	CtagsKinds []string
 
 	nodes map[string]*nodeShotness
 	files map[string]map[string]*nodeShotness
+	// ctagsPath is the resolved path to the ctags executable.
+	ctagsPath string
 
 	l core.Logger
 }
 
 const (
+	// ConfigShotnessBackend is the name of the configuration option (ShotnessAnalysis.Configure())
+	// which chooses how to extract the analysed nodes: ShotnessBackendUAST or ShotnessBackendCtags.
+	ConfigShotnessBackend = "Shotness.Backend"
 	// ConfigShotnessXpathStruct is the name of the configuration option (ShotnessAnalysis.Configure())
 	// which sets the UAST XPath to choose the analysed nodes.
 	// The format is Semantic UASTv2, see https://docs.sourced.tech/babelfish/using-babelfish/uast-querying
@@ -43,6 +61,16 @@ const (
 	// which sets the UAST XPath to find the name of the nodes chosen by ConfigShotnessXpathStruct.
 	// The format is Semantic UASTv2, see https://docs.sourced.tech/babelfish/using-babelfish/uast-querying
 	ConfigShotnessXpathName = "Shotness.XpathName"
+	// ConfigShotnessCtagsKinds is the name of the configuration option (ShotnessAnalysis.Configure())
+	// which sets the ctags tag kinds to analyse with ShotnessBackendCtags.
+	ConfigShotnessCtagsKinds = "Shotness.CtagsKinds"
+
+	// ShotnessBackendUAST extracts the nodes from the UASTs returned by Babelfish.
+	ShotnessBackendUAST = "uast"
+	// ShotnessBackendCtags extracts the nodes with universal-ctags, which must be on PATH.
+	ShotnessBackendCtags = "ctags"
+	// DefaultShotnessBackend is the default value of ConfigShotnessBackend.
+	DefaultShotnessBackend = ShotnessBackendUAST
 
 	// DefaultShotnessXpathStruct is the default UAST XPath to choose the analysed nodes.
 	// It extracts functions.
@@ -50,8 +78,32 @@ const (
 	// DefaultShotnessXpathName is the default UAST XPath to choose the names of the analysed nodes.
 	// It looks at the current tree level and at the immediate children.
 	DefaultShotnessXpathName = "/Nodes/uast:Alias/Name"
+
+	// ctagsExecutable is the name of the universal-ctags executable which is looked up in PATH.
+	ctagsExecutable = "ctags"
 )
 
+// DefaultShotnessCtagsKinds are the default ctags tag kinds to analyse: functions and methods.
+var DefaultShotnessCtagsKinds = []string{"function", "method"}
+
+// shotnessNode is a node extracted by either backend.
+type shotnessNode struct {
+	Type string
+	// StartLine and EndLine are 1-based and inclusive. StartLine is 0 if the position is unknown.
+	StartLine int
+	EndLine   int
+}
+
+// shotnessChange is a changed file together with its nodes before and after the change.
+type shotnessChange struct {
+	// From is empty if the file was added, To is empty if the file was deleted.
+	From string
+	To   string
+	// Before and After are nil if the nodes could not be extracted.
+	Before map[string]shotnessNode
+	After  map[string]shotnessNode
+}
+
 type nodeShotness struct {
 	Count   int
 	Summary NodeSummary
@@ -92,12 +144,22 @@ func (shotness *ShotnessAnalysis) Provides() []string {
 // Each requested entity will be inserted into `deps` of Consume(). In turn, those
 // entities are Provides() upstream.
 func (shotness *ShotnessAnalysis) Requires() []string {
+	if shotness.Backend == ShotnessBackendCtags {
+		return []string{
+			items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache}
+	}
 	return []string{items.DependencyFileDiff, uast_items.DependencyUastChanges}
 }
 
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (shotness *ShotnessAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
 	opts := [...]core.ConfigurationOption{{
+		Name: ConfigShotnessBackend,
+		Description: "How to extract the nodes: \"" + ShotnessBackendUAST + "\" (Babelfish) or \"" +
+			ShotnessBackendCtags + "\" (universal-ctags on PATH).",
+		Flag:    "shotness-backend",
+		Type:    core.StringConfigurationOption,
+		Default: DefaultShotnessBackend}, {
 		Name: ConfigShotnessXpathStruct,
 		Description: "Semantic UAST XPath query to use for filtering the nodes. " +
 			"Refer to https://docs.sourced.tech/babelfish/using-babelfish/uast-querying",
@@ -109,7 +171,12 @@ func (shotness *ShotnessAnalysis) ListConfigurationOptions() []core.Configuratio
 			"Refer to https://docs.sourced.tech/babelfish/using-babelfish/uast-querying",
 		Flag:    "shotness-xpath-name",
 		Type:    core.StringConfigurationOption,
-		Default: DefaultShotnessXpathName},
+		Default: DefaultShotnessXpathName}, {
+		Name:        ConfigShotnessCtagsKinds,
+		Description: "ctags tag kinds to analyse with --shotness-backend=" + ShotnessBackendCtags + ".",
+		Flag:        "shotness-ctags-kinds",
+		Type:        core.StringsConfigurationOption,
+		Default:     DefaultShotnessCtagsKinds},
 	}
 	return opts[:]
 }
@@ -121,6 +188,9 @@ func (shotness *ShotnessAnalysis) Flag() string {
 
 // Features returns the Hercules features required to deploy this leaf.
 func (shotness *ShotnessAnalysis) Features() []string {
+	if shotness.Backend == ShotnessBackendCtags {
+		return []string{}
+	}
 	return []string{uast_items.FeatureUast}
 }
 
@@ -129,7 +199,22 @@ func (shotness *ShotnessAnalysis) Description() string {
 	return "Structural hotness - a fine-grained alternative to --couples. " +
 		"Given an XPath over UASTs - selecting functions by default - we build the square " +
 		"co-occurrence matrix. The value in each cell equals to the number of times the pair " +
-		"of selected UAST units appeared in the same commit."
+		"of selected UAST units appeared in the same commit. universal-ctags can replace " +
+		"Babelfish with --shotness-backend=" + ShotnessBackendCtags + "."
+}
+
+// ConfigureDependencies sets the backend which Requires() and Features() depend on.
+func (shotness *ShotnessAnalysis) ConfigureDependencies(facts map[string]interface{}) error {
+	backend := DefaultShotnessBackend
+	if val, exists := facts[ConfigShotnessBackend].(string); exists {
+		backend = val
+	}
+	if backend != ShotnessBackendUAST && backend != ShotnessBackendCtags {
+		return fmt.Errorf("%s must be either \"%s\" or \"%s\": \"%s\"",
+			ConfigShotnessBackend, ShotnessBackendUAST, ShotnessBackendCtags, backend)
+	}
+	shotness.Backend = backend
+	return nil
 }
 
 // Configure sets the properties previously published by ListConfigurationOptions().
@@ -137,6 +222,9 @@ func (shotness *ShotnessAnalysis) Configure(facts map[string]interface{}) error
 	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
 		shotness.l = l
 	}
+	if err := shotness.ConfigureDependencies(facts); err != nil {
+		return err
+	}
 	if val, exists := facts[ConfigShotnessXpathStruct]; exists {
 		shotness.XpathStruct = val.(string)
 	} else {
@@ -147,16 +235,34 @@ func (shotness *ShotnessAnalysis) Configure(facts map[string]interface{}) error
 	} else {
 		shotness.XpathName = DefaultShotnessXpathName
 	}
+	if val, exists := facts[ConfigShotnessCtagsKinds].([]string); exists {
+		shotness.CtagsKinds = val
+	} else {
+		shotness.CtagsKinds = DefaultShotnessCtagsKinds
+	}
 	return nil
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (shotness *ShotnessAnalysis) Initialize(repository *git.Repository) error {
-	shotness.l = core.NewLogger()
+	if shotness.l == nil {
+		shotness.l = core.NewLogger()
+	}
 	shotness.nodes = map[string]*nodeShotness{}
 	shotness.files = map[string]map[string]*nodeShotness{}
 	shotness.OneShotMergeProcessor.Initialize()
+	if shotness.Backend == ShotnessBackendCtags {
+		ctagsPath, err := exec.LookPath(ctagsExecutable)
+		if err != nil {
+			return fmt.Errorf("--shotness-backend=%s requires universal-ctags, but \"%s\" "+
+				"was not found in PATH: %v", ShotnessBackendCtags, ctagsExecutable, err)
+		}
+		shotness.ctagsPath = ctagsPath
+		if len(shotness.CtagsKinds) == 0 {
+			shotness.CtagsKinds = DefaultShotnessCtagsKinds
+		}
+	}
 	return nil
 }
 
@@ -169,14 +275,22 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 	if !shotness.ShouldConsumeCommit(deps) {
 		return nil, nil
 	}
-	commit := deps[core.DependencyCommit].(*object.Commit)
-	changesList := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
 	diffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	var changes []shotnessChange
+	if shotness.Backend == ShotnessBackendCtags {
+		var err error
+		changes, err = shotness.extractCtagsChanges(deps)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		changes = shotness.extractUastChanges(deps)
+	}
 	allNodes := map[string]bool{}
 
-	addNode := func(name string, node uast_nodes.Node, fileName string) {
+	addNode := func(name string, nodeType string, fileName string) {
 		nodeSummary := NodeSummary{
-			Type: uast.TypeOf(node),
+			Type: nodeType,
 			Name: name,
 			File: fileName,
 		}
@@ -201,36 +315,30 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 		}
 	}
 
-	for _, change := range changesList {
-		if change.After == nil {
-			for key, summary := range shotness.files[change.Change.From.Name] {
+	for _, change := range changes {
+		if change.To == "" {
+			for key, summary := range shotness.files[change.From] {
 				for subkey := range summary.Couples {
 					delete(shotness.nodes[subkey].Couples, key)
 				}
 			}
-			for key := range shotness.files[change.Change.From.Name] {
+			for key := range shotness.files[change.From] {
 				delete(shotness.nodes, key)
 			}
-			delete(shotness.files, change.Change.From.Name)
+			delete(shotness.files, change.From)
 			continue
 		}
-		toName := change.Change.To.Name
-		if change.Before == nil {
-			nodes, err := shotness.extractNodes(change.After)
-			if err != nil {
-				shotness.l.Warnf("Shotness: commit %s file %s failed to filter UAST: %s\n",
-					commit.Hash.String(), toName, err.Error())
-				continue
-			}
-			for name, node := range nodes {
-				addNode(name, node, toName)
+		toName := change.To
+		if change.From == "" {
+			for name, node := range change.After {
+				addNode(name, node.Type, toName)
 			}
 			continue
 		}
 		// Before -> After
-		if change.Change.From.Name != toName {
+		if change.From != toName {
 			// renamed
-			oldFile := shotness.files[change.Change.From.Name]
+			oldFile := shotness.files[change.From]
 			newFile := map[string]*nodeShotness{}
 			shotness.files[toName] = newFile
 			for oldKey, ns := range oldFile {
@@ -248,64 +356,26 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 			for key := range oldFile {
 				delete(shotness.nodes, key)
 			}
-			delete(shotness.files, change.Change.From.Name)
+			delete(shotness.files, change.From)
 		}
-		// pass through old UAST
-		// pass through new UAST
-		nodesBefore, err := shotness.extractNodes(change.Before)
-		if err != nil {
-			shotness.l.Warnf("Shotness: commit ^%s file %s failed to filter UAST: %s\n",
-				commit.Hash.String(), change.Change.From.Name, err.Error())
-			continue
-		}
-		reversedNodesBefore := reverseNodeMap(nodesBefore)
-		nodesAfter, err := shotness.extractNodes(change.After)
-		if err != nil {
-			shotness.l.Warnf("Shotness: commit %s file %s failed to filter UAST: %s\n",
-				commit.Hash.String(), toName, err.Error())
+		if change.Before == nil || change.After == nil {
 			continue
 		}
-		reversedNodesAfter := reverseNodeMap(nodesAfter)
-		genLine2Node := func(nodes map[string]uast_nodes.Node, linesNum int) [][]uast_nodes.Node {
-			res := make([][]uast_nodes.Node, linesNum)
-			for _, node := range nodes {
-				pos := uast.PositionsOf(node.(uast_nodes.Object))
-				if pos.Start() == nil {
+		genLine2Node := func(nodes map[string]shotnessNode, linesNum int) [][]string {
+			res := make([][]string, linesNum)
+			for name, node := range nodes {
+				if node.StartLine == 0 {
 					continue
 				}
-				startLine := pos.Start().Line
-				endLine := pos.Start().Line
-				if pos.End() != nil && pos.End().Line > pos.Start().Line {
-					endLine = pos.End().Line
-				} else {
-					// we need to determine pos.End().Line
-					uast_items.VisitEachNode(node, func(child uast_nodes.Node) {
-						childPos := uast.PositionsOf(child.(uast_nodes.Object))
-						if childPos.Start() != nil {
-							candidate := childPos.Start().Line
-							if childPos.End() != nil {
-								candidate = childPos.End().Line
-							}
-							if candidate > endLine {
-								endLine = candidate
-							}
-						}
-					})
-				}
-				for l := startLine; l <= endLine; l++ {
-					lineNodes := res[l-1]
-					if lineNodes == nil {
-						lineNodes = []uast_nodes.Node{}
-					}
-					lineNodes = append(lineNodes, node)
-					res[l-1] = lineNodes
+				for l := node.StartLine; l <= node.EndLine && l <= linesNum; l++ {
+					res[l-1] = append(res[l-1], name)
 				}
 			}
 			return res
 		}
 		diff := diffs[toName]
-		line2nodeBefore := genLine2Node(nodesBefore, diff.OldLinesOfCode)
-		line2nodeAfter := genLine2Node(nodesAfter, diff.NewLinesOfCode)
+		line2nodeBefore := genLine2Node(change.Before, diff.OldLinesOfCode)
+		line2nodeAfter := genLine2Node(change.After, diff.NewLinesOfCode)
 		// Scan through all the edits. Given the line numbers, get the list of active nodes
 		// and add them.
 		var lineNumBefore, lineNumAfter int
@@ -314,18 +384,16 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 			switch edit.Type {
 			case diffmatchpatch.DiffDelete:
 				for l := lineNumBefore; l < lineNumBefore+size; l++ {
-					nodes := line2nodeBefore[l]
-					for _, node := range nodes {
+					for _, name := range line2nodeBefore[l] {
 						// toName because we handled a possible rename before
-						addNode(reversedNodesBefore[uast_nodes.UniqueKey(node)], node, toName)
+						addNode(name, change.Before[name].Type, toName)
 					}
 				}
 				lineNumBefore += size
 			case diffmatchpatch.DiffInsert:
 				for l := lineNumAfter; l < lineNumAfter+size; l++ {
-					nodes := line2nodeAfter[l]
-					for _, node := range nodes {
-						addNode(reversedNodesAfter[uast_nodes.UniqueKey(node)], node, toName)
+					for _, name := range line2nodeAfter[l] {
+						addNode(name, change.After[name].Type, toName)
 					}
 				}
 				lineNumAfter += size
@@ -440,6 +508,204 @@ func (shotness *ShotnessAnalysis) serializeBinary(result *ShotnessResult, writer
 	return err
 }
 
+// extractUastChanges converts the UAST changes to shotnessChange-s.
+func (shotness *ShotnessAnalysis) extractUastChanges(deps map[string]interface{}) []shotnessChange {
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	changesList := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	changes := make([]shotnessChange, 0, len(changesList))
+	for _, change := range changesList {
+		if change.After == nil {
+			changes = append(changes, shotnessChange{From: change.Change.From.Name})
+			continue
+		}
+		toName := change.Change.To.Name
+		if change.Before == nil {
+			nodes, err := shotness.extractNodes(change.After)
+			if err != nil {
+				shotness.l.Warnf("Shotness: commit %s file %s failed to filter UAST: %s\n",
+					commit.Hash.String(), toName, err.Error())
+				continue
+			}
+			changes = append(changes, shotnessChange{To: toName, After: convertUastNodes(nodes)})
+			continue
+		}
+		sc := shotnessChange{From: change.Change.From.Name, To: toName}
+		nodesBefore, err := shotness.extractNodes(change.Before)
+		if err != nil {
+			shotness.l.Warnf("Shotness: commit ^%s file %s failed to filter UAST: %s\n",
+				commit.Hash.String(), sc.From, err.Error())
+		} else if nodesAfter, err := shotness.extractNodes(change.After); err != nil {
+			shotness.l.Warnf("Shotness: commit %s file %s failed to filter UAST: %s\n",
+				commit.Hash.String(), toName, err.Error())
+		} else {
+			sc.Before = convertUastNodes(nodesBefore)
+			sc.After = convertUastNodes(nodesAfter)
+		}
+		changes = append(changes, sc)
+	}
+	return changes
+}
+
+// convertUastNodes determines the types and the line ranges of the UAST nodes.
+func convertUastNodes(nodes map[string]uast_nodes.Node) map[string]shotnessNode {
+	res := make(map[string]shotnessNode, len(nodes))
+	for name, node := range nodes {
+		sn := shotnessNode{Type: uast.TypeOf(node)}
+		pos := uast.PositionsOf(node.(uast_nodes.Object))
+		if pos.Start() != nil {
+			sn.StartLine = int(pos.Start().Line)
+			sn.EndLine = sn.StartLine
+			if pos.End() != nil && int(pos.End().Line) > sn.StartLine {
+				sn.EndLine = int(pos.End().Line)
+			} else {
+				// we need to determine pos.End().Line
+				uast_items.VisitEachNode(node, func(child uast_nodes.Node) {
+					childPos := uast.PositionsOf(child.(uast_nodes.Object))
+					if childPos.Start() != nil {
+						candidate := int(childPos.Start().Line)
+						if childPos.End() != nil {
+							candidate = int(childPos.End().Line)
+						}
+						if candidate > sn.EndLine {
+							sn.EndLine = candidate
+						}
+					}
+				})
+			}
+		}
+		res[name] = sn
+	}
+	return res
+}
+
+// extractCtagsChanges runs ctags over the changed blobs and returns the shotnessChange-s.
+func (shotness *ShotnessAnalysis) extractCtagsChanges(deps map[string]interface{}) (
+	[]shotnessChange, error) {
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	treeChanges := deps[items.DependencyTreeChanges].(object.Changes)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*items.CachedBlob)
+	extract := func(entry object.ChangeEntry) (map[string]shotnessNode, error) {
+		blob := cache[entry.TreeEntry.Hash]
+		if blob == nil {
+			return nil, fmt.Errorf("blob %s is missing", entry.TreeEntry.Hash.String())
+		}
+		return shotness.runCtags(entry.Name, blob.Data)
+	}
+	changes := make([]shotnessChange, 0, len(treeChanges))
+	for _, change := range treeChanges {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Delete:
+			changes = append(changes, shotnessChange{From: change.From.Name})
+		case merkletrie.Insert:
+			nodes, err := extract(change.To)
+			if err != nil {
+				shotness.l.Warnf("Shotness: commit %s file %s failed to run ctags: %s\n",
+					commit.Hash.String(), change.To.Name, err.Error())
+				continue
+			}
+			changes = append(changes, shotnessChange{To: change.To.Name, After: nodes})
+		case merkletrie.Modify:
+			sc := shotnessChange{From: change.From.Name, To: change.To.Name}
+			nodesBefore, err := extract(change.From)
+			if err != nil {
+				shotness.l.Warnf("Shotness: commit ^%s file %s failed to run ctags: %s\n",
+					commit.Hash.String(), sc.From, err.Error())
+			} else if nodesAfter, err := extract(change.To); err != nil {
+				shotness.l.Warnf("Shotness: commit %s file %s failed to run ctags: %s\n",
+					commit.Hash.String(), sc.To, err.Error())
+			} else {
+				sc.Before = nodesBefore
+				sc.After = nodesAfter
+			}
+			changes = append(changes, sc)
+		}
+	}
+	return changes, nil
+}
+
+// runCtags writes the file contents to a temporary directory - ctags detects the language
+// by the file name - and extracts the tags of the configured kinds.
+func (shotness *ShotnessAnalysis) runCtags(fileName string, data []byte) (map[string]shotnessNode, error) {
+	dir, err := ioutil.TempDir("", "hercules-shotness-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, path.Base(fileName))
+	err = ioutil.WriteFile(filePath, data, 0600)
+	if err != nil {
+		return nil, err
+	}
+	output, err := exec.Command(shotness.ctagsPath, "--output-format=json", "--fields=+ne",
+		"--sort=no", "-f", "-", filePath).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			err = fmt.Errorf("%v: %s", err, bytes.TrimSpace(exitErr.Stderr))
+		}
+		return nil, err
+	}
+	return parseCtagsOutput(output, shotness.CtagsKinds)
+}
+
+// ctagsTag is a single record in the JSON output of universal-ctags.
+type ctagsTag struct {
+	Type  string `json:"_type"`
+	Name  string `json:"name"`
+	Kind  string `json:"kind"`
+	Scope string `json:"scope"`
+	Line  int    `json:"line"`
+	End   int    `json:"end"`
+}
+
+// parseCtagsOutput reads the JSON lines produced by universal-ctags and picks the outermost
+// tags of the specified kinds. The scope is prepended to the tag names, e.g. "Class.method".
+func parseCtagsOutput(output []byte, kinds []string) (map[string]shotnessNode, error) {
+	acceptedKinds := map[string]bool{}
+	for _, kind := range kinds {
+		acceptedKinds[kind] = true
+	}
+	var tags []ctagsTag
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for decoder.More() {
+		tag := ctagsTag{}
+		if err := decoder.Decode(&tag); err != nil {
+			return nil, fmt.Errorf("failed to parse the ctags output: %v", err)
+		}
+		if tag.Type != "tag" || !acceptedKinds[tag.Kind] || tag.Line <= 0 {
+			continue
+		}
+		if tag.End < tag.Line {
+			tag.End = tag.Line
+		}
+		tags = append(tags, tag)
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		if tags[i].Line != tags[j].Line {
+			return tags[i].Line < tags[j].Line
+		}
+		return tags[i].End > tags[j].End
+	})
+	res := map[string]shotnessNode{}
+	outerEnd := 0
+	for _, tag := range tags {
+		// some tags may be inside other tags; we pick the outermost
+		if tag.End <= outerEnd {
+			continue
+		}
+		outerEnd = tag.End
+		name := tag.Name
+		if tag.Scope != "" {
+			name = tag.Scope + "." + name
+		}
+		res[name] = shotnessNode{Type: tag.Kind, StartLine: tag.Line, EndLine: tag.End}
+	}
+	return res, nil
+}
+
 func (shotness *ShotnessAnalysis) extractNodes(root uast_nodes.Node) (map[string]uast_nodes.Node, error) {
 	it, err := tools.Filter(root, shotness.XpathStruct)
 	if err != nil {
@@ -480,14 +746,6 @@ func (shotness *ShotnessAnalysis) extractNodes(root uast_nodes.Node) (map[string
 	return res, nil
 }
 
-func reverseNodeMap(nodes map[string]uast_nodes.Node) map[uast_nodes.Comparable]string {
-	res := map[uast_nodes.Comparable]string{}
-	for key, node := range nodes {
-		res[uast_nodes.UniqueKey(node)] = key
-	}
-	return res
-}
-
 func init() {
 	core.Registry.Register(&ShotnessAnalysis{})
 }
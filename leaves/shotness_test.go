@@ -5,6 +5,8 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +16,8 @@ import (
 	"gopkg.in/bblfsh/sdk.v2/uast"
 	"gopkg.in/bblfsh/sdk.v2/uast/nodes"
 	"gopkg.in/bblfsh/sdk.v2/uast/nodes/nodesproto"
+	gitplumbing "gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/hercules.v10/internal/core"
 	"gopkg.in/src-d/hercules.v10/internal/pb"
@@ -39,12 +43,16 @@ func TestShotnessMeta(t *testing.T) {
 	assert.Equal(t, len(sh.Requires()), 2)
 	assert.Equal(t, sh.Requires()[0], items.DependencyFileDiff)
 	assert.Equal(t, sh.Requires()[1], uast_items.DependencyUastChanges)
-	assert.Len(t, sh.ListConfigurationOptions(), 2)
-	assert.Equal(t, sh.ListConfigurationOptions()[0].Name, ConfigShotnessXpathStruct)
-	assert.Equal(t, sh.ListConfigurationOptions()[1].Name, ConfigShotnessXpathName)
+	assert.Len(t, sh.ListConfigurationOptions(), 4)
+	assert.Equal(t, sh.ListConfigurationOptions()[0].Name, ConfigShotnessBackend)
+	assert.Equal(t, sh.ListConfigurationOptions()[1].Name, ConfigShotnessXpathStruct)
+	assert.Equal(t, sh.ListConfigurationOptions()[2].Name, ConfigShotnessXpathName)
+	assert.Equal(t, sh.ListConfigurationOptions()[3].Name, ConfigShotnessCtagsKinds)
 	assert.Nil(t, sh.Configure(nil))
+	assert.Equal(t, sh.Backend, ShotnessBackendUAST)
 	assert.Equal(t, sh.XpathStruct, DefaultShotnessXpathStruct)
 	assert.Equal(t, sh.XpathName, DefaultShotnessXpathName)
+	assert.Equal(t, sh.CtagsKinds, DefaultShotnessCtagsKinds)
 	assert.NoError(t, sh.Configure(map[string]interface{}{
 		ConfigShotnessXpathStruct: "xpath!",
 		ConfigShotnessXpathName:   "another!",
@@ -60,6 +68,125 @@ func TestShotnessMeta(t *testing.T) {
 	assert.Equal(t, []string{uast_items.FeatureUast}, sh.Features())
 }
 
+func TestShotnessCtagsMeta(t *testing.T) {
+	sh := &ShotnessAnalysis{}
+	assert.NoError(t, sh.ConfigureDependencies(map[string]interface{}{
+		ConfigShotnessBackend: ShotnessBackendCtags,
+	}))
+	assert.Equal(t, ShotnessBackendCtags, sh.Backend)
+	assert.Equal(t, []string{
+		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache},
+		sh.Requires())
+	assert.Len(t, sh.Features(), 0)
+	assert.NoError(t, sh.Configure(map[string]interface{}{
+		ConfigShotnessBackend:    ShotnessBackendCtags,
+		ConfigShotnessCtagsKinds: []string{"class"},
+	}))
+	assert.Equal(t, []string{"class"}, sh.CtagsKinds)
+	assert.Error(t, sh.ConfigureDependencies(map[string]interface{}{
+		ConfigShotnessBackend: "",
+	}))
+	assert.Error(t, sh.Configure(map[string]interface{}{
+		ConfigShotnessBackend: "babelfish",
+	}))
+	oldPath := os.Getenv("PATH")
+	defer os.Setenv("PATH", oldPath)
+	os.Setenv("PATH", "")
+	err := sh.Initialize(test.Repository)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "universal-ctags")
+}
+
+func TestShotnessParseCtagsOutput(t *testing.T) {
+	nodes, err := parseCtagsOutput([]byte(`{"_type": "ptag", "name": "JSON_OUTPUT_VERSION"}
+{"_type": "tag", "name": "main", "path": "a.c", "kind": "function", "line": 3, "end": 9}
+{"_type": "tag", "name": "inner", "path": "a.c", "kind": "function", "line": 4, "end": 5}
+{"_type": "tag", "name": "x", "path": "a.c", "kind": "variable", "line": 11}
+{"_type": "tag", "name": "run", "path": "a.c", "kind": "method", "scope": "A", "line": 12}
+{"_type": "tag", "name": "stop", "path": "a.c", "kind": "method", "scope": "A", "line": 14, "end": 20}
+`), DefaultShotnessCtagsKinds)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]shotnessNode{
+		"main":   {Type: "function", StartLine: 3, EndLine: 9},
+		"A.run":  {Type: "method", StartLine: 12, EndLine: 12},
+		"A.stop": {Type: "method", StartLine: 14, EndLine: 20},
+	}, nodes)
+	nodes, err = parseCtagsOutput([]byte{}, DefaultShotnessCtagsKinds)
+	assert.NoError(t, err)
+	assert.NotNil(t, nodes)
+	assert.Len(t, nodes, 0)
+	_, err = parseCtagsOutput([]byte("{"), DefaultShotnessCtagsKinds)
+	assert.Error(t, err)
+}
+
+func TestShotnessConsumeCtags(t *testing.T) {
+	// the fake ctags prints the analysed file, so the file contents are the tags themselves
+	dir, err := ioutil.TempDir("", "hercules-test-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "ctags"),
+		[]byte("#!/bin/sh\nfor last; do :; done\ncat \"$last\"\n"), 0700))
+	oldPath := os.Getenv("PATH")
+	defer os.Setenv("PATH", oldPath)
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+oldPath)
+
+	sh := &ShotnessAnalysis{}
+	assert.NoError(t, sh.Configure(map[string]interface{}{
+		ConfigShotnessBackend: ShotnessBackendCtags,
+	}))
+	assert.NoError(t, sh.Initialize(test.Repository))
+	contents1 := `{"_type": "tag", "name": "foo", "kind": "function", "line": 1, "end": 2}
+{"_type": "tag", "name": "x", "kind": "variable", "line": 2}
+{"_type": "tag", "name": "bar", "kind": "method", "scope": "A", "line": 3, "end": 4}
+{"_type": "ptag", "name": "before"}
+`
+	contents2 := strings.Replace(contents1, "before", "after", 1)
+	hash1 := gitplumbing.NewHash("1111111111111111111111111111111111111111")
+	hash2 := gitplumbing.NewHash("2222222222222222222222222222222222222222")
+	const fileName = "test.c"
+	entry1 := object.ChangeEntry{Name: fileName, TreeEntry: object.TreeEntry{
+		Name: fileName, Mode: filemode.Regular, Hash: hash1}}
+	entry2 := object.ChangeEntry{Name: fileName, TreeEntry: object.TreeEntry{
+		Name: fileName, Mode: filemode.Regular, Hash: hash2}}
+	dmp := diffmatchpatch.New()
+	src, dst, _ := dmp.DiffLinesToRunes(contents1, contents2)
+	state := map[string]interface{}{
+		core.DependencyCommit: &object.Commit{},
+		items.DependencyBlobCache: map[gitplumbing.Hash]*items.CachedBlob{
+			hash1: {Data: []byte(contents1)},
+			hash2: {Data: []byte(contents2)},
+		},
+		items.DependencyTreeChanges: object.Changes{{To: entry1}},
+		items.DependencyFileDiff:    map[string]items.FileDiffData{},
+	}
+	iresult, err := sh.Consume(state)
+	assert.NoError(t, err)
+	assert.Nil(t, iresult)
+	state[items.DependencyTreeChanges] = object.Changes{{From: entry1, To: entry2}}
+	state[items.DependencyFileDiff] = map[string]items.FileDiffData{
+		fileName: {
+			OldLinesOfCode: len(src),
+			NewLinesOfCode: len(dst),
+			Diffs:          dmp.DiffMainRunes(src, dst, false),
+		},
+	}
+	iresult, err = sh.Consume(state)
+	assert.NoError(t, err)
+	assert.Nil(t, iresult)
+	result := sh.Finalize().(ShotnessResult)
+	assert.Equal(t, []NodeSummary{
+		{Type: "function", Name: "foo", File: fileName},
+		{Type: "method", Name: "A.bar", File: fileName},
+	}, result.Nodes)
+	assert.Equal(t, []map[int]int{{0: 1, 1: 1}, {0: 1, 1: 2}}, result.Counters)
+	state[items.DependencyTreeChanges] = object.Changes{{From: entry2}}
+	iresult, err = sh.Consume(state)
+	assert.NoError(t, err)
+	assert.Nil(t, iresult)
+	assert.Len(t, sh.nodes, 0)
+	assert.Len(t, sh.files, 0)
+}
+
 func TestShotnessRegistration(t *testing.T) {
 	summoned := core.Registry.Summon((&ShotnessAnalysis{}).Name())
 	assert.Len(t, summoned, 1)
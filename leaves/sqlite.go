@@ -0,0 +1,146 @@
+// +build sqlite
+
+package leaves
+
+import (
+	"database/sql"
+	"fmt"
+
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+)
+
+// SerializeSQLite writes BurndownResult to the burndown_global, burndown_files and
+// burndown_total_lines tables.
+// Both "tick" and "band" are measured in ticks: "band" is the first tick of the band.
+// The zero values are not written.
+func (analyser *BurndownAnalysis) SerializeSQLite(result interface{}, tx *sql.Tx) error {
+	burndownResult, ok := result.(BurndownResult)
+	if !ok {
+		return fmt.Errorf("result is not a burndown result: '%v'", result)
+	}
+	for _, kv := range [][2]interface{}{
+		{"burndown.granularity", burndownResult.granularity},
+		{"burndown.sampling", burndownResult.sampling},
+		{"burndown.tick_size", int(burndownResult.tickSize.Seconds())},
+	} {
+		if _, err := tx.Exec("INSERT INTO meta VALUES (?, ?)", kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	_, err := tx.Exec("CREATE TABLE burndown_global (tick INTEGER, band INTEGER, lines INTEGER)")
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		"CREATE TABLE burndown_files (path TEXT, tick INTEGER, band INTEGER, lines INTEGER)")
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("CREATE TABLE burndown_total_lines (tick INTEGER, lines INTEGER)")
+	if err != nil {
+		return err
+	}
+	err = insertDenseHistory(tx, "INSERT INTO burndown_global VALUES (?, ?, ?)",
+		burndownResult.GlobalHistory, burndownResult.sampling, burndownResult.granularity)
+	if err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(burndownResult.FileHistories) {
+		err = insertDenseHistory(tx, "INSERT INTO burndown_files VALUES (?, ?, ?, ?)",
+			burndownResult.FileHistories[key], burndownResult.sampling, burndownResult.granularity,
+			key)
+		if err != nil {
+			return err
+		}
+	}
+	stmt, err := tx.Prepare("INSERT INTO burndown_total_lines VALUES (?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for tick, lines := range burndownResult.TotalLines {
+		if _, err = stmt.Exec(tick, lines); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertDenseHistory executes `query` with `prefix` followed by tick, band and value
+// for each non-zero element of `matrix`.
+func insertDenseHistory(tx *sql.Tx, query string, matrix DenseHistory, sampling, granularity int,
+	prefix ...interface{}) error {
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for y, row := range matrix {
+		for x, value := range row {
+			if value == 0 {
+				continue
+			}
+			args := append(append([]interface{}{}, prefix...), y*sampling, x*granularity, value)
+			if _, err = stmt.Exec(args...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SerializeSQLite writes the file-file couples to the couples table. The matrix is symmetric
+// and the diagonal holds the number of commits which changed each file.
+func (couples *CouplesAnalysis) SerializeSQLite(result interface{}, tx *sql.Tx) error {
+	couplesResult := result.(CouplesResult)
+	_, err := tx.Exec("CREATE TABLE couples (a TEXT, b TEXT, count INTEGER)")
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("INSERT INTO couples VALUES (?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for i, row := range couplesResult.FilesMatrix {
+		for j, count := range row {
+			if _, err = stmt.Exec(
+				couplesResult.Files[i], couplesResult.Files[j], count); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SerializeSQLite writes DevsResult to the devs table. "dev" is the developer's name.
+func (devs *DevsAnalysis) SerializeSQLite(result interface{}, tx *sql.Tx) error {
+	devsResult := result.(DevsResult)
+	_, err := tx.Exec("INSERT INTO meta VALUES (?, ?)",
+		"devs.tick_size", int(devsResult.tickSize.Seconds()))
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("CREATE TABLE devs " +
+		"(tick INTEGER, dev TEXT, commits INTEGER, added INTEGER, removed INTEGER)")
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("INSERT INTO devs VALUES (?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for tick, tickDevs := range devsResult.Ticks {
+		for dev, stats := range tickDevs {
+			name := identity.AuthorMissingName
+			if dev != identity.AuthorMissing && dev < len(devsResult.reversedPeopleDict) {
+				name = devsResult.reversedPeopleDict[dev]
+			}
+			if _, err = stmt.Exec(tick, name, stats.Commits, stats.Added, stats.Removed); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// +build sqlite
+
+package leaves
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/plumbing/identity"
+	_ "modernc.org/sqlite"
+)
+
+// newTestSQLite opens an in-memory SQLite database with the "meta" table as hercules --sqlite does.
+func newTestSQLite(t *testing.T) (*sql.DB, *sql.Tx) {
+	db, err := sql.Open("sqlite", ":memory:")
+	assert.NoError(t, err)
+	// every connection has its own in-memory database
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec("CREATE TABLE meta (key TEXT PRIMARY KEY, value TEXT)")
+	assert.NoError(t, err)
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	return db, tx
+}
+
+// queryTestSQLite returns all the rows selected by `query` with the values separated by spaces.
+func queryTestSQLite(t *testing.T, db *sql.DB, query string) []string {
+	rows, err := db.Query(query)
+	assert.NoError(t, err)
+	defer rows.Close()
+	columns, err := rows.Columns()
+	assert.NoError(t, err)
+	var result []string
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		assert.NoError(t, rows.Scan(pointers...))
+		result = append(result, strings.TrimSuffix(fmt.Sprintln(values...), "\n"))
+	}
+	return result
+}
+
+func TestBurndownSerializeSQLite(t *testing.T) {
+	bd := &BurndownAnalysis{}
+	result := BurndownResult{
+		GlobalHistory: DenseHistory{{15, 0}, {13, 3}},
+		FileHistories: map[string]DenseHistory{"a": {{0, 1}}, "b": {{2, 0}}},
+		TotalLines:    []int64{15, 15, 16},
+		tickSize:      24 * time.Hour,
+		sampling:      10,
+		granularity:   20,
+	}
+	db, tx := newTestSQLite(t)
+	defer db.Close()
+	assert.NoError(t, bd.SerializeSQLite(result, tx))
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, []string{
+		"burndown.granularity 20", "burndown.sampling 10", "burndown.tick_size 86400"},
+		queryTestSQLite(t, db, "SELECT * FROM meta ORDER BY key"))
+	assert.Equal(t, []string{"0 0 15", "10 0 13", "10 20 3"},
+		queryTestSQLite(t, db, "SELECT * FROM burndown_global ORDER BY tick, band"))
+	assert.Equal(t, []string{"a 0 20 1", "b 0 0 2"},
+		queryTestSQLite(t, db, "SELECT * FROM burndown_files ORDER BY path"))
+	assert.Equal(t, []string{"0 15", "1 15", "2 16"},
+		queryTestSQLite(t, db, "SELECT * FROM burndown_total_lines ORDER BY tick"))
+	assert.Error(t, bd.SerializeSQLite(nil, nil))
+}
+
+func TestCouplesSerializeSQLite(t *testing.T) {
+	c := fixtureCouples()
+	result := CouplesResult{
+		Files:       []string{"a", "b"},
+		FilesMatrix: []map[int]int64{{0: 3, 1: 1}, {0: 1, 1: 2}},
+	}
+	db, tx := newTestSQLite(t)
+	defer db.Close()
+	assert.NoError(t, c.SerializeSQLite(result, tx))
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, []string{"a a 3", "a b 1", "b a 1", "b b 2"},
+		queryTestSQLite(t, db, "SELECT * FROM couples ORDER BY a, b"))
+}
+
+func TestDevsSerializeSQLite(t *testing.T) {
+	devs := fixtureDevs()
+	devs.ticks[1] = map[int]*DevTick{}
+	devs.ticks[1][0] = &DevTick{10, ls(20, 30, 40), map[string]items.LineStats{}}
+	devs.ticks[10] = map[int]*DevTick{}
+	devs.ticks[10][1] = &DevTick{1, ls(2, 3, 4), map[string]items.LineStats{}}
+	devs.ticks[10][identity.AuthorMissing] = &DevTick{
+		100, ls(200, 300, 400), map[string]items.LineStats{}}
+	db, tx := newTestSQLite(t)
+	defer db.Close()
+	assert.NoError(t, devs.SerializeSQLite(devs.Finalize(), tx))
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, []string{"devs.tick_size 86400"}, queryTestSQLite(t, db, "SELECT * FROM meta"))
+	assert.Equal(t, []string{
+		"1 one@srcd 10 20 30", "10 <unmatched> 100 200 300", "10 two@srcd 1 2 3"},
+		queryTestSQLite(t, db, "SELECT * FROM devs ORDER BY tick, dev"))
+}
@@ -0,0 +1,307 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+)
+
+// TestRatioAnalysis calculates the number of alive lines in the test files and in the rest
+// ("production") files at the end of each tick, similar to BurndownResult.TotalLines.
+// The test files are recognized by TestPatterns. It is a LeafPipelineItem.
+type TestRatioAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// TestPatterns are the glob patterns of the test files, see plumbing.MatchGlob().
+	TestPatterns []string
+
+	// testDeltas is the number of added minus the number of removed test lines in each tick.
+	testDeltas []int64
+	// productionDeltas is the same as testDeltas for the rest of the files.
+	productionDeltas []int64
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// TestRatioResult is returned by TestRatioAnalysis.Finalize() and carries the numbers
+// of alive test and production lines in each tick.
+type TestRatioResult struct {
+	// TestLines is the number of alive lines in the test files at the end of each tick.
+	TestLines []int64
+	// ProductionLines is the number of alive lines in the rest of the files at the end of each tick.
+	ProductionLines []int64
+	// Ratios is TestLines divided by ProductionLines in each tick, 0 if there are no
+	// production lines.
+	Ratios []float64
+
+	// tickSize references TicksSinceStart.TickSize
+	tickSize time.Duration
+}
+
+const (
+	// ConfigTestRatioPatterns is the name of the option to set TestRatioAnalysis.TestPatterns.
+	ConfigTestRatioPatterns = "TestRatio.Patterns"
+)
+
+// defaultTestRatioPatterns is the default value of TestRatioAnalysis.TestPatterns.
+var defaultTestRatioPatterns = []string{"*_test.go", "test/", "spec/"}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ratio *TestRatioAnalysis) Name() string {
+	return "TestRatio"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (ratio *TestRatioAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (ratio *TestRatioAnalysis) Requires() []string {
+	return []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyLineStats,
+		items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ratio *TestRatioAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{{
+		Name: ConfigTestRatioPatterns,
+		Description: "Glob patterns of the test files. \"**\" matches any number of directories, " +
+			"a trailing \"/\" matches everything inside the directory and the patterns without " +
+			"\"/\" are applied to every path component. Separated with commas \",\".",
+		Flag:    "test-ratio-patterns",
+		Type:    core.StringsConfigurationOption,
+		Default: defaultTestRatioPatterns},
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ratio *TestRatioAnalysis) Flag() string {
+	return "test-ratio"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (ratio *TestRatioAnalysis) Description() string {
+	return "Calculates the numbers of alive lines in the test and the production files " +
+		"at the end of each tick and their ratio. Merge commits are ignored."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ratio *TestRatioAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		ratio.l = l
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		ratio.tickSize = val
+	}
+	if val, exists := facts[ConfigTestRatioPatterns].([]string); exists {
+		ratio.TestPatterns = nil
+		for _, glob := range val {
+			glob = strings.TrimSpace(glob)
+			if glob == "" {
+				continue
+			}
+			if _, err := path.Match(glob, ""); err != nil {
+				return fmt.Errorf("invalid test pattern %q: %v", glob, err)
+			}
+			ratio.TestPatterns = append(ratio.TestPatterns, glob)
+		}
+	}
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ratio *TestRatioAnalysis) Initialize(repository *git.Repository) error {
+	if ratio.l == nil {
+		ratio.l = core.NewLogger()
+	}
+	ratio.testDeltas = nil
+	ratio.productionDeltas = nil
+	if ratio.TestPatterns == nil {
+		ratio.TestPatterns = defaultTestRatioPatterns
+	}
+	ratio.OneShotMergeProcessor.Initialize()
+	if ratio.tickSize == 0 {
+		ratio.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ratio *TestRatioAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	tick := deps[items.DependencyTick].(int)
+	// the series last until the last commit even if it did not change anything
+	for len(ratio.testDeltas) <= tick {
+		ratio.testDeltas = append(ratio.testDeltas, 0)
+		ratio.productionDeltas = append(ratio.productionDeltas, 0)
+	}
+	if !ratio.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*items.CachedBlob)
+	lineStats := deps[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats)
+	for _, change := range treeDiff {
+		name := change.To.Name
+		stats, exists := lineStats[change.To]
+		if !exists {
+			name = change.From.Name
+			stats, exists = lineStats[change.From]
+		}
+		if !exists {
+			// binary
+			continue
+		}
+		delta := int64(stats.Added - stats.Removed)
+		if change.From.Name != "" && change.To.Name != "" &&
+			ratio.isTest(change.From.Name) != ratio.isTest(change.To.Name) {
+			// the renamed file takes its previous lines to the other series
+			if lines, err := cache[change.From.TreeEntry.Hash].CountLines(); err == nil {
+				ratio.deltas(change.From.Name)[tick] -= int64(lines)
+				delta += int64(lines)
+			}
+		}
+		ratio.deltas(name)[tick] += delta
+	}
+	return nil, nil
+}
+
+// isTest returns true if the file matches any of TestPatterns.
+func (ratio *TestRatioAnalysis) isTest(name string) bool {
+	for _, glob := range ratio.TestPatterns {
+		if items.MatchGlob(glob, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// deltas returns the series which the file belongs to.
+func (ratio *TestRatioAnalysis) deltas(name string) []int64 {
+	if ratio.isTest(name) {
+		return ratio.testDeltas
+	}
+	return ratio.productionDeltas
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ratio *TestRatioAnalysis) Finalize() interface{} {
+	result := TestRatioResult{
+		TestLines:       make([]int64, len(ratio.testDeltas)),
+		ProductionLines: make([]int64, len(ratio.productionDeltas)),
+		Ratios:          make([]float64, len(ratio.testDeltas)),
+		tickSize:        ratio.tickSize,
+	}
+	var testSum, productionSum int64
+	for tick := range ratio.testDeltas {
+		testSum += ratio.testDeltas[tick]
+		productionSum += ratio.productionDeltas[tick]
+		result.TestLines[tick] = testSum
+		result.ProductionLines[tick] = productionSum
+		if productionSum > 0 {
+			result.Ratios[tick] = float64(testSum) / float64(productionSum)
+		}
+	}
+	return result
+}
+
+// Fork clones this PipelineItem.
+func (ratio *TestRatioAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(ratio, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ratio *TestRatioAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	ratioResult := result.(TestRatioResult)
+	if binary {
+		return ratio.serializeBinary(&ratioResult, writer)
+	}
+	ratio.serializeText(&ratioResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to TestRatioResult.
+func (ratio *TestRatioAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.TestRatioAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	return TestRatioResult{
+		TestLines:       message.TestLines,
+		ProductionLines: message.ProductionLines,
+		Ratios:          message.Ratios,
+		tickSize:        time.Duration(message.TickSize),
+	}, nil
+}
+
+func (ratio *TestRatioAnalysis) serializeText(result *TestRatioResult, writer io.Writer) {
+	writeInts := func(key string, values []int64) {
+		fmt.Fprintf(writer, "  %s: [", key)
+		for i, val := range values {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprint(writer, val)
+		}
+		fmt.Fprintln(writer, "]")
+	}
+	writeInts("test_lines", result.TestLines)
+	writeInts("production_lines", result.ProductionLines)
+	fmt.Fprint(writer, "  ratios: [")
+	for i, val := range result.Ratios {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprintf(writer, "%.3f", val)
+	}
+	fmt.Fprintln(writer, "]")
+	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (ratio *TestRatioAnalysis) serializeBinary(result *TestRatioResult, writer io.Writer) error {
+	message := pb.TestRatioAnalysisResults{
+		TestLines:       result.TestLines,
+		ProductionLines: result.ProductionLines,
+		Ratios:          result.Ratios,
+		TickSize:        int64(result.tickSize),
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// GetTickSize returns the tick size used to generate this test ratio result.
+func (trr TestRatioResult) GetTickSize() time.Duration {
+	return trr.tickSize
+}
+
+func init() {
+	core.Registry.Register(&TestRatioAnalysis{})
+}
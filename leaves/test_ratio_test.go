@@ -0,0 +1,139 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v10/internal/core"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+	items "gopkg.in/src-d/hercules.v10/internal/plumbing"
+	"gopkg.in/src-d/hercules.v10/internal/test"
+)
+
+func fixtureTestRatio() *TestRatioAnalysis {
+	ratio := TestRatioAnalysis{}
+	ratio.Initialize(test.Repository)
+	return &ratio
+}
+
+func TestTestRatioMeta(t *testing.T) {
+	ratio := fixtureTestRatio()
+	assert.Equal(t, ratio.Name(), "TestRatio")
+	assert.Len(t, ratio.Provides(), 0)
+	assert.Equal(t, []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyLineStats,
+		items.DependencyTick}, ratio.Requires())
+	opts := ratio.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigTestRatioPatterns)
+	assert.Equal(t, ratio.Flag(), "test-ratio")
+	assert.NotEmpty(t, ratio.Description())
+	assert.Equal(t, []string{"*_test.go", "test/", "spec/"}, ratio.TestPatterns)
+	assert.Equal(t, 24*time.Hour, ratio.tickSize)
+	logger := core.NewLogger()
+	assert.NoError(t, ratio.Configure(map[string]interface{}{
+		core.ConfigLogger:       logger,
+		items.FactTickSize:      time.Hour,
+		ConfigTestRatioPatterns: []string{" tests/", "", "*.spec.js"},
+	}))
+	assert.Equal(t, logger, ratio.l)
+	assert.Equal(t, time.Hour, ratio.tickSize)
+	assert.Equal(t, []string{"tests/", "*.spec.js"}, ratio.TestPatterns)
+	assert.EqualError(t, ratio.Configure(map[string]interface{}{
+		ConfigTestRatioPatterns: []string{"[x"},
+	}), `invalid test pattern "[x": syntax error in pattern`)
+}
+
+func TestTestRatioRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&TestRatioAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "TestRatio")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&TestRatioAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestTestRatioFork(t *testing.T) {
+	ratio1 := fixtureTestRatio()
+	clones := ratio1.Fork(1)
+	assert.Len(t, clones, 1)
+	ratio2 := clones[0].(*TestRatioAnalysis)
+	assert.True(t, ratio1 == ratio2)
+	ratio1.Merge([]core.PipelineItem{ratio2})
+}
+
+func TestTestRatioIsTest(t *testing.T) {
+	ratio := fixtureTestRatio()
+	assert.True(t, ratio.isTest("main_test.go"))
+	assert.True(t, ratio.isTest("internal/core/pipeline_test.go"))
+	assert.True(t, ratio.isTest("test/util.go"))
+	assert.True(t, ratio.isTest("web/spec/app.js"))
+	assert.False(t, ratio.isTest("main.go"))
+	assert.False(t, ratio.isTest("testdata.go"))
+	assert.False(t, ratio.isTest("internal/test_data/x.go"))
+}
+
+func bakeTestRatio(t *testing.T) (*TestRatioAnalysis, interface{}) {
+	when := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repository, _, err := test.NewMemoryRepository([]test.FakeCommit{
+		{Author: "one", When: when, Files: map[string]string{
+			"main.go": "1\n2\n3\n", "main_test.go": "1\n2\n", "README": "r\n"}},
+		{Author: "one", When: when.Add(24 * time.Hour), Files: map[string]string{
+			"test/util.go": "a\nb\nc\nd\n", "main.go": "1\n2\n3\n4\n"}},
+		{Author: "two", When: when.Add(72 * time.Hour), Deleted: []string{"test/util.go"},
+			Files: map[string]string{"lib/util.go": "a\nb\nc\nd\n"}},
+	})
+	assert.NoError(t, err)
+	pipeline := core.NewPipeline(repository)
+	pipeline.PrintActions = false
+	ratio := pipeline.DeployItem(&TestRatioAnalysis{}).(*TestRatioAnalysis)
+	commits, err := pipeline.Commits(false)
+	assert.NoError(t, err)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	results, err := pipeline.Run(commits)
+	assert.NoError(t, err)
+	return ratio, results[ratio]
+}
+
+func TestTestRatioConsumeFinalize(t *testing.T) {
+	_, result := bakeTestRatio(t)
+	ratioResult := result.(TestRatioResult)
+	assert.Equal(t, []int64{2, 6, 6, 2}, ratioResult.TestLines)
+	assert.Equal(t, []int64{4, 5, 5, 9}, ratioResult.ProductionLines)
+	assert.Equal(t, []float64{0.5, 1.2, 1.2, 2.0 / 9}, ratioResult.Ratios)
+	assert.Equal(t, 24*time.Hour, ratioResult.GetTickSize())
+	empty := fixtureTestRatio().Finalize().(TestRatioResult)
+	assert.Len(t, empty.TestLines, 0)
+	assert.Len(t, empty.Ratios, 0)
+}
+
+func TestTestRatioSerialize(t *testing.T) {
+	ratio, result := bakeTestRatio(t)
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, ratio.Serialize(result, false, buffer))
+	assert.Equal(t, `  test_lines: [2, 6, 6, 2]
+  production_lines: [4, 5, 5, 9]
+  ratios: [0.500, 1.200, 1.200, 0.222]
+  tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, ratio.Serialize(result, true, buffer))
+	msg := pb.TestRatioAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, []int64{2, 6, 6, 2}, msg.TestLines)
+	assert.Equal(t, []int64{4, 5, 5, 9}, msg.ProductionLines)
+	assert.Equal(t, int64(24*time.Hour), msg.TickSize)
+	deserialized, err := ratio.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
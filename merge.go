@@ -0,0 +1,149 @@
+package hercules
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+)
+
+// MergeResultFiles reads several Protocol Buffers analysis results produced with --pb,
+// optionally compressed with --gzip,
+// merges the analyses which exist in every file and returns the merged results serialized
+// to Protocol Buffers. The keys are the analysis names, like in pb.AnalysisResults.Contents.
+// The analyses which are missing in some of the files are skipped. The files of the empty
+// repositories do not change the merged metadata, and the headers which have commits but not
+// the time range are rejected.
+func MergeResultFiles(paths []string) (map[string][]byte, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no result files to merge")
+	}
+	messages := make([]*pb.AnalysisResults, len(paths))
+	for i, path := range paths {
+		message, err := readResultFile(path)
+		if err != nil {
+			return nil, err
+		}
+		messages[i] = message
+	}
+	if len(messages) == 1 {
+		return messages[0].Contents, nil
+	}
+	var mergedResults map[string]interface{}
+	var mergedCommons *CommonAnalysisResult
+	for i, message := range messages {
+		commons := MetadataToCommonAnalysisResult(message.Header)
+		if i == 0 {
+			mergedResults = map[string]interface{}{}
+			for key, val := range message.Contents {
+				if !existsInAll(key, messages[1:]) {
+					continue
+				}
+				result, err := deserializeResult(key, val)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", paths[i], err)
+				}
+				mergedResults[key] = result
+			}
+			mergedCommons = commons
+			continue
+		}
+		for key, mergedResult := range mergedResults {
+			result, err := deserializeResult(key, message.Contents[key])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", paths[i], err)
+			}
+			item := Registry.Summon(key)[0].(ResultMergeablePipelineItem)
+			mergedResult = item.MergeResults(mergedResult, result, mergedCommons, commons)
+			if err, isErr := mergedResult.(error); isErr {
+				return nil, fmt.Errorf("%s: could not merge %s: %v", paths[i], key, err)
+			}
+			mergedResults[key] = mergedResult
+		}
+		if mergedCommons.CommitsNumber == 0 {
+			*mergedCommons = *commons
+		} else if commons.CommitsNumber > 0 {
+			mergedCommons.Merge(commons)
+		}
+	}
+	contents := map[string][]byte{}
+	for key, val := range mergedResults {
+		buffer := &bytes.Buffer{}
+		err := Registry.Summon(key)[0].(LeafPipelineItem).Serialize(val, true, buffer)
+		if err != nil {
+			return nil, fmt.Errorf("could not serialize %s: %v", key, err)
+		}
+		contents[key] = buffer.Bytes()
+	}
+	return contents, nil
+}
+
+// existsInAll checks whether each of `messages` contains the analysis `key`.
+func existsInAll(key string, messages []*pb.AnalysisResults) bool {
+	for _, message := range messages {
+		if _, exists := message.Contents[key]; !exists {
+			return false
+		}
+	}
+	return true
+}
+
+// readResultFile loads the Protocol Buffers analysis results envelope.
+func readResultFile(path string) (*pb.AnalysisResults, error) {
+	buffer, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if buffer, err = DecompressResults(buffer); err != nil {
+		return nil, fmt.Errorf("cannot decompress %s: %v", path, err)
+	}
+	message := &pb.AnalysisResults{}
+	if err = proto.Unmarshal(buffer, message); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %v", path, err)
+	}
+	if err = CheckCompatibility(message.Header); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %v", path, err)
+	}
+	if header := message.Header; header.Commits > 0 &&
+		(header.BeginUnixTime == 0 || header.EndUnixTime == 0) {
+		return nil, fmt.Errorf("cannot parse %s: corrupted header: %d commits without the time range",
+			path, header.Commits)
+	}
+	return message, nil
+}
+
+// DecompressResults detects the gzip magic bytes in the beginning of `buffer` and decompresses it.
+// The analysis results written with --gzip are conventionally named *.pb.gz.
+// Uncompressed buffers are returned as is.
+func DecompressResults(buffer []byte) ([]byte, error) {
+	if len(buffer) < 2 || buffer[0] != 0x1f || buffer[1] != 0x8b {
+		return buffer, nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(buffer))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// deserializeResult summons the registered analysis by name and loads its result.
+func deserializeResult(key string, val []byte) (interface{}, error) {
+	summoned := Registry.Summon(key)
+	if len(summoned) == 0 {
+		return nil, fmt.Errorf("item not found: %s", key)
+	}
+	item, ok := summoned[0].(ResultMergeablePipelineItem)
+	if !ok {
+		return nil, fmt.Errorf("%s: ResultMergeablePipelineItem is not implemented", key)
+	}
+	result, err := item.Deserialize(val)
+	if err != nil {
+		return nil, fmt.Errorf("deserialization failed: %s: %v", key, err)
+	}
+	return result, nil
+}
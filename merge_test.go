@@ -0,0 +1,215 @@
+package hercules
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/hercules.v10/internal/pb"
+)
+
+// resultFileEpoch is the midnight which writeResultFile() counts `beginTime` from.
+const resultFileEpoch = 1499990400
+
+func writeResultFile(t *testing.T, dir, name string, beginTime int64,
+	contents map[string]proto.Message) string {
+	beginTime += resultFileEpoch
+	message := pb.AnalysisResults{
+		Header: &pb.Metadata{
+			Version:       SchemaVersion,
+			Repository:    name,
+			BeginUnixTime: beginTime,
+			EndUnixTime:   beginTime + 24*3600,
+			Commits:       10,
+		},
+		Contents: map[string][]byte{},
+	}
+	for key, val := range contents {
+		serialized, err := proto.Marshal(val)
+		require.NoError(t, err)
+		message.Contents[key] = serialized
+	}
+	serialized, err := proto.Marshal(&message)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, serialized, 0666))
+	return path
+}
+
+func devsResultMessage(dev string, commits int32, tickSize time.Duration) *pb.DevsAnalysisResults {
+	return &pb.DevsAnalysisResults{
+		Ticks: map[int32]*pb.TickDevs{0: {Devs: map[int32]*pb.DevTick{0: {
+			Commits: commits,
+			Stats:   &pb.LineStats{Added: 10},
+		}}}},
+		DevIndex: []string{dev},
+		TickSize: int64(tickSize),
+	}
+}
+
+// updateResultFileHeader changes the header of the results file with `update`.
+func updateResultFileHeader(t *testing.T, path string, update func(header *pb.Metadata)) {
+	buffer, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	message := pb.AnalysisResults{}
+	require.NoError(t, proto.Unmarshal(buffer, &message))
+	update(message.Header)
+	buffer, err = proto.Marshal(&message)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, buffer, 0666))
+}
+
+func TestMergeResultFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-merge-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path1 := writeResultFile(t, dir, "one", 0, map[string]proto.Message{
+		"Devs":        devsResultMessage("one|one@srcd", 1, 24*time.Hour),
+		"BinaryChurn": &pb.BinaryChurnAnalysisResults{Added: []int64{1}},
+	})
+	path2 := writeResultFile(t, dir, "two", 24*3600, map[string]proto.Message{
+		"Devs": devsResultMessage("one|one@srcd", 2, 24*time.Hour),
+	})
+	contents, err := MergeResultFiles([]string{path1, path2})
+	assert.NoError(t, err)
+	assert.Len(t, contents, 1)
+	merged := pb.DevsAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(contents["Devs"], &merged))
+	assert.Equal(t, []string{"one|one@srcd"}, merged.DevIndex)
+	assert.Len(t, merged.Ticks, 2)
+	assert.Equal(t, int32(1), merged.Ticks[0].Devs[0].Commits)
+	assert.Equal(t, int32(2), merged.Ticks[1].Devs[0].Commits)
+	assert.Equal(t, int64(24*time.Hour), merged.TickSize)
+
+	contents, err = MergeResultFiles([]string{path1})
+	assert.NoError(t, err)
+	assert.Len(t, contents, 2)
+
+	path3 := writeResultFile(t, dir, "three", 0, map[string]proto.Message{
+		"Devs": devsResultMessage("two|two@srcd", 1, time.Hour),
+	})
+	contents, err = MergeResultFiles([]string{path1, path3})
+	assert.Nil(t, contents)
+	assert.Error(t, err)
+
+	path4 := writeResultFile(t, dir, "four", 0, map[string]proto.Message{
+		"XXX":         devsResultMessage("two|two@srcd", 1, time.Hour),
+		"BinaryChurn": &pb.BinaryChurnAnalysisResults{Added: []int64{1}},
+	})
+	contents, err = MergeResultFiles([]string{path4, path1})
+	assert.Nil(t, contents)
+	assert.Error(t, err)
+	path5 := writeResultFile(t, dir, "five", 0, map[string]proto.Message{
+		"XXX": devsResultMessage("two|two@srcd", 1, time.Hour),
+	})
+	contents, err = MergeResultFiles([]string{path4, path5})
+	assert.Nil(t, contents)
+	assert.Error(t, err)
+
+	// "hercules combine" of the older binaries wrote the binary version
+	legacy := writeResultFile(t, dir, "legacy", 24*3600, map[string]proto.Message{
+		"Devs": devsResultMessage("one|one@srcd", 2, 24*time.Hour),
+	})
+	updateResultFileHeader(t, legacy, func(header *pb.Metadata) { header.Version = 10 })
+	contents, err = MergeResultFiles([]string{path1, legacy})
+	assert.NoError(t, err)
+	assert.Len(t, contents, 1)
+
+	future := writeResultFile(t, dir, "future", 0, map[string]proto.Message{
+		"Devs": devsResultMessage("one|one@srcd", 1, 24*time.Hour),
+	})
+	updateResultFileHeader(t, future, func(header *pb.Metadata) { header.Version = SchemaVersion + 1 })
+	contents, err = MergeResultFiles([]string{path1, future})
+	assert.Nil(t, contents)
+	assert.Error(t, err)
+
+	// e.g. written by a third-party tool
+	untimed := writeResultFile(t, dir, "untimed", 24*3600, map[string]proto.Message{
+		"Devs": devsResultMessage("one|one@srcd", 2, 24*time.Hour),
+	})
+	updateResultFileHeader(t, untimed, func(header *pb.Metadata) { header.BeginUnixTime = 0 })
+	contents, err = MergeResultFiles([]string{path1, untimed})
+	assert.Nil(t, contents)
+	assert.Error(t, err)
+	updateResultFileHeader(t, untimed, func(header *pb.Metadata) {
+		header.BeginUnixTime = resultFileEpoch
+		header.EndUnixTime = 0
+	})
+	contents, err = MergeResultFiles([]string{untimed, path1})
+	assert.Nil(t, contents)
+	assert.Error(t, err)
+	// the empty repository does not have the time range either
+	updateResultFileHeader(t, untimed, func(header *pb.Metadata) {
+		header.BeginUnixTime = 0
+		header.Commits = 0
+	})
+	for _, paths := range [][]string{{path2, untimed}, {untimed, path2}} {
+		contents, err = MergeResultFiles(paths)
+		assert.NoError(t, err)
+		assert.Len(t, contents, 1)
+	}
+
+	garbage := filepath.Join(dir, "garbage")
+	require.NoError(t, ioutil.WriteFile(garbage, []byte("garbage"), 0666))
+	for _, paths := range [][]string{{}, {garbage}, {filepath.Join(dir, "missing")}} {
+		contents, err = MergeResultFiles(paths)
+		assert.Nil(t, contents)
+		assert.Error(t, err)
+	}
+}
+
+func TestMergeResultFilesGzip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-merge-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path1 := writeResultFile(t, dir, "one", 0, map[string]proto.Message{
+		"Devs": devsResultMessage("one|one@srcd", 1, 24*time.Hour),
+	})
+	path2 := writeResultFile(t, dir, "two.pb.gz", 24*3600, map[string]proto.Message{
+		"Devs": devsResultMessage("one|one@srcd", 2, 24*time.Hour),
+	})
+	buffer, err := ioutil.ReadFile(path2)
+	require.NoError(t, err)
+	compressed := &bytes.Buffer{}
+	writer := gzip.NewWriter(compressed)
+	_, err = writer.Write(buffer)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	require.NoError(t, ioutil.WriteFile(path2, compressed.Bytes(), 0666))
+	contents, err := MergeResultFiles([]string{path1, path2})
+	assert.NoError(t, err)
+	merged := pb.DevsAnalysisResults{}
+	assert.NoError(t, proto.Unmarshal(contents["Devs"], &merged))
+	assert.Len(t, merged.Ticks, 2)
+
+	// truncated
+	require.NoError(t, ioutil.WriteFile(path2, compressed.Bytes()[:20], 0666))
+	contents, err = MergeResultFiles([]string{path1, path2})
+	assert.Nil(t, contents)
+	assert.Error(t, err)
+}
+
+func TestDecompressResults(t *testing.T) {
+	for _, plain := range [][]byte{nil, {0x1f}, []byte("hercules")} {
+		decompressed, err := DecompressResults(plain)
+		assert.NoError(t, err)
+		assert.Equal(t, plain, decompressed)
+	}
+	compressed := &bytes.Buffer{}
+	writer := gzip.NewWriter(compressed)
+	_, err := writer.Write([]byte("hercules"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	decompressed, err := DecompressResults(compressed.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hercules"), decompressed)
+	_, err = DecompressResults([]byte{0x1f, 0x8b, 0})
+	assert.Error(t, err)
+}
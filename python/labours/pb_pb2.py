@@ -19,7 +19,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   package='',
   syntax='proto3',
   serialized_options=None,
-  serialized_pb=_b('\n\x08pb.proto\"\x81\x02\n\x08Metadata\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x0c\n\x04hash\x18\x02 \x01(\t\x12\x12\n\nrepository\x18\x03 \x01(\t\x12\x17\n\x0f\x62\x65gin_unix_time\x18\x04 \x01(\x03\x12\x15\n\rend_unix_time\x18\x05 \x01(\x03\x12\x0f\n\x07\x63ommits\x18\x06 \x01(\x05\x12\x10\n\x08run_time\x18\x07 \x01(\x03\x12\x38\n\x11run_time_per_item\x18\x08 \x03(\x0b\x32\x1d.Metadata.RunTimePerItemEntry\x1a\x35\n\x13RunTimePerItemEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\"*\n\x17\x42urndownSparseMatrixRow\x12\x0f\n\x07\x63olumns\x18\x01 \x03(\r\"\x7f\n\x14\x42urndownSparseMatrix\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x16\n\x0enumber_of_rows\x18\x02 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x03 \x01(\x05\x12&\n\x04rows\x18\x04 \x03(\x0b\x32\x18.BurndownSparseMatrixRow\"i\n\x0e\x46ilesOwnership\x12)\n\x05value\x18\x01 \x03(\x0b\x32\x1a.FilesOwnership.ValueEntry\x1a,\n\nValueEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xaa\x02\n\x17\x42urndownAnalysisResults\x12\x13\n\x0bgranularity\x18\x01 \x01(\x05\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12&\n\x07project\x18\x03 \x01(\x0b\x32\x15.BurndownSparseMatrix\x12$\n\x05\x66iles\x18\x04 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12%\n\x06people\x18\x05 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12\x36\n\x12people_interaction\x18\x06 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\x12(\n\x0f\x66iles_ownership\x18\x07 \x03(\x0b\x32\x0f.FilesOwnership\x12\x11\n\ttick_size\x18\x08 \x01(\x03\"}\n\x19\x43ompressedSparseRowMatrix\x12\x16\n\x0enumber_of_rows\x18\x01 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x02 \x01(\x05\x12\x0c\n\x04\x64\x61ta\x18\x03 \x03(\x03\x12\x0f\n\x07indices\x18\x04 \x03(\x05\x12\x0e\n\x06indptr\x18\x05 \x03(\x03\"D\n\x07\x43ouples\x12\r\n\x05index\x18\x01 \x03(\t\x12*\n\x06matrix\x18\x02 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\"\x1d\n\x0cTouchedFiles\x12\r\n\x05\x66iles\x18\x01 \x03(\x05\"\x94\x01\n\x16\x43ouplesAnalysisResults\x12\x1e\n\x0c\x66ile_couples\x18\x06 \x01(\x0b\x32\x08.Couples\x12 \n\x0epeople_couples\x18\x07 \x01(\x0b\x32\x08.Couples\x12#\n\x0cpeople_files\x18\x08 \x03(\x0b\x32\r.TouchedFiles\x12\x13\n\x0b\x66iles_lines\x18\t \x03(\x05\"o\n\nUASTChange\x12\x11\n\tfile_name\x18\x01 \x01(\t\x12\x12\n\nsrc_before\x18\x02 \x01(\t\x12\x11\n\tsrc_after\x18\x03 \x01(\t\x12\x13\n\x0buast_before\x18\x04 \x01(\t\x12\x12\n\nuast_after\x18\x05 \x01(\t\"7\n\x17UASTChangesSaverResults\x12\x1c\n\x07\x63hanges\x18\x01 \x03(\x0b\x32\x0b.UASTChange\"\x9c\x01\n\x0eShotnessRecord\x12\x0c\n\x04type\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0c\n\x04\x66ile\x18\x03 \x01(\t\x12/\n\x08\x63ounters\x18\x04 \x03(\x0b\x32\x1d.ShotnessRecord.CountersEntry\x1a/\n\rCountersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\";\n\x17ShotnessAnalysisResults\x12 \n\x07records\x18\x01 \x03(\x0b\x32\x0f.ShotnessRecord\"\xa9\x01\n\x0b\x46ileHistory\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\t\x12\x42\n\x14\x63hanges_by_developer\x18\x02 \x03(\x0b\x32$.FileHistory.ChangesByDeveloperEntry\x1a\x45\n\x17\x43hangesByDeveloperEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.LineStats:\x02\x38\x01\"\x8b\x01\n\x18\x46ileHistoryResultMessage\x12\x33\n\x05\x66iles\x18\x01 \x03(\x0b\x32$.FileHistoryResultMessage.FilesEntry\x1a:\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.FileHistory:\x02\x38\x01\"<\n\tLineStats\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x01 \x01(\x05\x12\x0f\n\x07removed\x18\x02 \x01(\x05\x12\x0f\n\x07\x63hanged\x18\x03 \x01(\x05\"\x9f\x01\n\x07\x44\x65vTick\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x19\n\x05stats\x18\x02 \x01(\x0b\x32\n.LineStats\x12*\n\tlanguages\x18\x03 \x03(\x0b\x32\x17.DevTick.LanguagesEntry\x1a<\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.LineStats:\x02\x38\x01\"d\n\x08TickDevs\x12!\n\x04\x64\x65vs\x18\x01 \x03(\x0b\x32\x13.TickDevs.DevsEntry\x1a\x35\n\tDevsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x17\n\x05value\x18\x02 \x01(\x0b\x32\x08.DevTick:\x02\x38\x01\"\xa4\x01\n\x13\x44\x65vsAnalysisResults\x12.\n\x05ticks\x18\x01 \x03(\x0b\x32\x1f.DevsAnalysisResults.TicksEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x08 \x01(\x03\x1a\x37\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x18\n\x05value\x18\x02 \x01(\x0b\x32\t.TickDevs:\x02\x38\x01\"=\n\tSentiment\x12\r\n\x05value\x18\x01 \x01(\x02\x12\x10\n\x08\x63omments\x18\x02 \x03(\t\x12\x0f\n\x07\x63ommits\x18\x03 \x03(\t\"\xa7\x01\n\x17\x43ommentSentimentResults\x12H\n\x11sentiment_by_tick\x18\x01 \x03(\x0b\x32-.CommentSentimentResults.SentimentByTickEntry\x1a\x42\n\x14SentimentByTickEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.Sentiment:\x02\x38\x01\"G\n\nCommitFile\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x10\n\x08language\x18\x03 \x01(\t\x12\x19\n\x05stats\x18\x04 \x01(\x0b\x32\n.LineStats\"Z\n\x06\x43ommit\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x16\n\x0ewhen_unix_time\x18\x02 \x01(\x03\x12\x0e\n\x06\x61uthor\x18\x03 \x01(\x05\x12\x1a\n\x05\x66iles\x18\x04 \x03(\x0b\x32\x0b.CommitFile\"H\n\x16\x43ommitsAnalysisResults\x12\x18\n\x07\x63ommits\x18\x01 \x03(\x0b\x32\x07.Commit\x12\x14\n\x0c\x61uthor_index\x18\x02 \x03(\t\"R\n\x04Typo\x12\r\n\x05wrong\x18\x01 \x01(\t\x12\x0f\n\x07\x63orrect\x18\x02 \x01(\t\x12\x0e\n\x06\x63ommit\x18\x03 \x01(\t\x12\x0c\n\x04\x66ile\x18\x04 \x01(\t\x12\x0c\n\x04line\x18\x05 \x01(\x05\"$\n\x0cTyposDataset\x12\x14\n\x05typos\x18\x01 \x03(\x0b\x32\x05.Typo\"l\n\x0eImportsPerTick\x12+\n\x06\x63ounts\x18\x01 \x03(\x0b\x32\x1b.ImportsPerTick.CountsEntry\x1a-\n\x0b\x43ountsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"\x82\x01\n\x12ImportsPerLanguage\x12-\n\x05ticks\x18\x01 \x03(\x0b\x32\x1e.ImportsPerLanguage.TicksEntry\x1a=\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.ImportsPerTick:\x02\x38\x01\"\x94\x01\n\x13ImportsPerDeveloper\x12\x36\n\tlanguages\x18\x01 \x03(\x0b\x32#.ImportsPerDeveloper.LanguagesEntry\x1a\x45\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.ImportsPerLanguage:\x02\x38\x01\"l\n\x1aImportsPerDeveloperResults\x12%\n\x07imports\x18\x01 \x03(\x0b\x32\x14.ImportsPerDeveloper\x12\x14\n\x0c\x61uthor_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x03 \x01(\x03\"\x8f\x01\n\x0f\x41nalysisResults\x12\x19\n\x06header\x18\x01 \x01(\x0b\x32\t.Metadata\x12\x30\n\x08\x63ontents\x18\x02 \x03(\x0b\x32\x1e.AnalysisResults.ContentsEntry\x1a/\n\rContentsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\x62\x06proto3')
+  serialized_pb=_b('\n\x08pb.proto\"\x87\x03\n\x08Metadata\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x0c\n\x04hash\x18\x02 \x01(\t\x12\x12\n\nrepository\x18\x03 \x01(\t\x12\x17\n\x0f\x62\x65gin_unix_time\x18\x04 \x01(\x03\x12\x15\n\rend_unix_time\x18\x05 \x01(\x03\x12\x0f\n\x07\x63ommits\x18\x06 \x01(\x05\x12\x10\n\x08run_time\x18\x07 \x01(\x03\x12\x38\n\x11run_time_per_item\x18\x08 \x03(\x0b\x32\x1d.Metadata.RunTimePerItemEntry\x12\x16\n\x0eskewed_commits\x18\t \x01(\x05\x12\x16\n\x0e\x66\x61iled_commits\x18\n \x01(\x05\x12%\n\x06\x63onfig\x18\x0b \x03(\x0b\x32\x15.Metadata.ConfigEntry\x1a\x35\n\x13RunTimePerItemEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\x1a-\n\x0b\x43onfigEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"*\n\x17\x42urndownSparseMatrixRow\x12\x0f\n\x07\x63olumns\x18\x01 \x03(\r\"\x7f\n\x14\x42urndownSparseMatrix\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x16\n\x0enumber_of_rows\x18\x02 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x03 \x01(\x05\x12&\n\x04rows\x18\x04 \x03(\x0b\x32\x18.BurndownSparseMatrixRow\"i\n\x0e\x46ilesOwnership\x12)\n\x05value\x18\x01 \x03(\x0b\x32\x1a.FilesOwnership.ValueEntry\x1a,\n\nValueEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\x9c\x04\n\x17\x42urndownAnalysisResults\x12\x13\n\x0bgranularity\x18\x01 \x01(\x05\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12&\n\x07project\x18\x03 \x01(\x0b\x32\x15.BurndownSparseMatrix\x12$\n\x05\x66iles\x18\x04 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12%\n\x06people\x18\x05 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12\x36\n\x12people_interaction\x18\x06 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\x12(\n\x0f\x66iles_ownership\x18\x07 \x03(\x0b\x32\x0f.FilesOwnership\x12\x11\n\ttick_size\x18\x08 \x01(\x03\x12-\n\x0e\x61\x63tive_authors\x18\t \x01(\x0b\x32\x15.BurndownSparseMatrix\x12\x1d\n\x15\x61\x63tive_authors_window\x18\n \x01(\x05\x12,\n\rcommit_counts\x18\x0b \x01(\x0b\x32\x15.BurndownSparseMatrix\x12)\n\nold_vs_new\x18\x0c \x01(\x0b\x32\x15.BurndownSparseMatrix\x12\x1c\n\x14old_vs_new_threshold\x18\r \x01(\x05\x12\x16\n\x0esample_commits\x18\x0e \x03(\t\x12\x13\n\x0btotal_lines\x18\x0f \x03(\x03\"}\n\x19\x43ompressedSparseRowMatrix\x12\x16\n\x0enumber_of_rows\x18\x01 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x02 \x01(\x05\x12\x0c\n\x04\x64\x61ta\x18\x03 \x03(\x03\x12\x0f\n\x07indices\x18\x04 \x03(\x05\x12\x0e\n\x06indptr\x18\x05 \x03(\x03\"\x82\x01\n\x1e\x43ompressedSparseRowFloatMatrix\x12\x16\n\x0enumber_of_rows\x18\x01 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x02 \x01(\x05\x12\x0c\n\x04\x64\x61ta\x18\x03 \x03(\x02\x12\x0f\n\x07indices\x18\x04 \x03(\x05\x12\x0e\n\x06indptr\x18\x05 \x03(\x03\"D\n\x07\x43ouples\x12\r\n\x05index\x18\x01 \x03(\t\x12*\n\x06matrix\x18\x02 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\"\x1d\n\x0cTouchedFiles\x12\r\n\x05\x66iles\x18\x01 \x03(\x05\"\xf6\x01\n\x16\x43ouplesAnalysisResults\x12\x1e\n\x0c\x66ile_couples\x18\x06 \x01(\x0b\x32\x08.Couples\x12 \n\x0epeople_couples\x18\x07 \x01(\x0b\x32\x08.Couples\x12#\n\x0cpeople_files\x18\x08 \x03(\x0b\x32\r.TouchedFiles\x12\x13\n\x0b\x66iles_lines\x18\t \x03(\x05\x12\x36\n\rfiles_jaccard\x18\n \x01(\x0b\x32\x1f.CompressedSparseRowFloatMatrix\x12(\n\x10\x66iles_embeddings\x18\x0b \x03(\x0b\x32\x0e.FileEmbedding\"\x1f\n\rFileEmbedding\x12\x0e\n\x06vector\x18\x01 \x03(\x02\"o\n\nUASTChange\x12\x11\n\tfile_name\x18\x01 \x01(\t\x12\x12\n\nsrc_before\x18\x02 \x01(\t\x12\x11\n\tsrc_after\x18\x03 \x01(\t\x12\x13\n\x0buast_before\x18\x04 \x01(\t\x12\x12\n\nuast_after\x18\x05 \x01(\t\"7\n\x17UASTChangesSaverResults\x12\x1c\n\x07\x63hanges\x18\x01 \x03(\x0b\x32\x0b.UASTChange\"\x9c\x01\n\x0eShotnessRecord\x12\x0c\n\x04type\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0c\n\x04\x66ile\x18\x03 \x01(\t\x12/\n\x08\x63ounters\x18\x04 \x03(\x0b\x32\x1d.ShotnessRecord.CountersEntry\x1a/\n\rCountersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\";\n\x17ShotnessAnalysisResults\x12 \n\x07records\x18\x01 \x03(\x0b\x32\x0f.ShotnessRecord\"\xa9\x01\n\x0b\x46ileHistory\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\t\x12\x42\n\x14\x63hanges_by_developer\x18\x02 \x03(\x0b\x32$.FileHistory.ChangesByDeveloperEntry\x1a\x45\n\x17\x43hangesByDeveloperEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.LineStats:\x02\x38\x01\"\x8b\x01\n\x18\x46ileHistoryResultMessage\x12\x33\n\x05\x66iles\x18\x01 \x03(\x0b\x32$.FileHistoryResultMessage.FilesEntry\x1a:\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.FileHistory:\x02\x38\x01\"_\n\x1a\x42inaryChurnAnalysisResults\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x01 \x03(\x03\x12\x0f\n\x07removed\x18\x02 \x03(\x03\x12\x0e\n\x06\x64\x65ltas\x18\x03 \x03(\x03\x12\x11\n\ttick_size\x18\x04 \x01(\x03\"+\n\x0b\x46ileGenesis\x12\x0c\n\x04tick\x18\x01 \x01(\x05\x12\x0e\n\x06\x61uthor\x18\x02 \x01(\x05\"\xa8\x01\n\x12\x46ileGenesisResults\x12-\n\x05\x66iles\x18\x01 \x03(\x0b\x32\x1e.FileGenesisResults.FilesEntry\x12\x14\n\x0c\x61uthor_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x03 \x01(\x03\x1a:\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.FileGenesis:\x02\x38\x01\"6\n\x07Hotspot\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\r\n\x05\x65\x64its\x18\x02 \x01(\x03\x12\x0e\n\x06series\x18\x03 \x03(\x03\"E\n\x17HotspotsAnalysisResults\x12\x17\n\x05\x66iles\x18\x01 \x03(\x0b\x32\x08.Hotspot\x12\x11\n\ttick_size\x18\x02 \x01(\x03\"L\n\tBusFactor\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\r\n\x05lines\x18\x02 \x01(\x05\x12\x12\n\nbus_factor\x18\x03 \x01(\x05\x12\x0e\n\x06owners\x18\x04 \x03(\x05\"i\n\x18\x42usFactorAnalysisResults\x12\x19\n\x05\x66iles\x18\x01 \x03(\x0b\x32\n.BusFactor\x12\x1f\n\x0b\x64irectories\x18\x02 \x03(\x0b\x32\n.BusFactor\x12\x11\n\tdev_index\x18\x03 \x03(\t\" \n\x0e\x43ommitSizeTick\x12\x0e\n\x06\x63ounts\x18\x01 \x03(\x03\"\xb3\x01\n\x19\x43ommitSizeAnalysisResults\x12\x0e\n\x06\x62ounds\x18\x01 \x03(\x05\x12\x34\n\x05ticks\x18\x02 \x03(\x0b\x32%.CommitSizeAnalysisResults.TicksEntry\x12\x11\n\ttick_size\x18\x03 \x01(\x03\x1a=\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.CommitSizeTick:\x02\x38\x01\"<\n\tLineStats\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x01 \x01(\x05\x12\x0f\n\x07removed\x18\x02 \x01(\x05\x12\x0f\n\x07\x63hanged\x18\x03 \x01(\x05\"\x9f\x01\n\x07\x44\x65vTick\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x19\n\x05stats\x18\x02 \x01(\x0b\x32\n.LineStats\x12*\n\tlanguages\x18\x03 \x03(\x0b\x32\x17.DevTick.LanguagesEntry\x1a<\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.LineStats:\x02\x38\x01\"d\n\x08TickDevs\x12!\n\x04\x64\x65vs\x18\x01 \x03(\x0b\x32\x13.TickDevs.DevsEntry\x1a\x35\n\tDevsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x17\n\x05value\x18\x02 \x01(\x0b\x32\x08.DevTick:\x02\x38\x01\"\xb9\x01\n\x13\x44\x65vsAnalysisResults\x12.\n\x05ticks\x18\x01 \x03(\x0b\x32\x1f.DevsAnalysisResults.TicksEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x12\x13\n\x0b\x61\x63tive_devs\x18\x03 \x03(\x05\x12\x11\n\ttick_size\x18\x08 \x01(\x03\x1a\x37\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x18\n\x05value\x18\x02 \x01(\x0b\x32\t.TickDevs:\x02\x38\x01\"=\n\tSentiment\x12\r\n\x05value\x18\x01 \x01(\x02\x12\x10\n\x08\x63omments\x18\x02 \x03(\t\x12\x0f\n\x07\x63ommits\x18\x03 \x03(\t\"\xa7\x01\n\x17\x43ommentSentimentResults\x12H\n\x11sentiment_by_tick\x18\x01 \x03(\x0b\x32-.CommentSentimentResults.SentimentByTickEntry\x1a\x42\n\x14SentimentByTickEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.Sentiment:\x02\x38\x01\"G\n\nCommitFile\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x10\n\x08language\x18\x03 \x01(\t\x12\x19\n\x05stats\x18\x04 \x01(\x0b\x32\n.LineStats\"Z\n\x06\x43ommit\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x16\n\x0ewhen_unix_time\x18\x02 \x01(\x03\x12\x0e\n\x06\x61uthor\x18\x03 \x01(\x05\x12\x1a\n\x05\x66iles\x18\x04 \x03(\x0b\x32\x0b.CommitFile\"H\n\x16\x43ommitsAnalysisResults\x12\x18\n\x07\x63ommits\x18\x01 \x03(\x0b\x32\x07.Commit\x12\x14\n\x0c\x61uthor_index\x18\x02 \x03(\t\"R\n\x04Typo\x12\r\n\x05wrong\x18\x01 \x01(\t\x12\x0f\n\x07\x63orrect\x18\x02 \x01(\t\x12\x0e\n\x06\x63ommit\x18\x03 \x01(\t\x12\x0c\n\x04\x66ile\x18\x04 \x01(\t\x12\x0c\n\x04line\x18\x05 \x01(\x05\"$\n\x0cTyposDataset\x12\x14\n\x05typos\x18\x01 \x03(\x0b\x32\x05.Typo\"l\n\x0eImportsPerTick\x12+\n\x06\x63ounts\x18\x01 \x03(\x0b\x32\x1b.ImportsPerTick.CountsEntry\x1a-\n\x0b\x43ountsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"\x82\x01\n\x12ImportsPerLanguage\x12-\n\x05ticks\x18\x01 \x03(\x0b\x32\x1e.ImportsPerLanguage.TicksEntry\x1a=\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.ImportsPerTick:\x02\x38\x01\"\x94\x01\n\x13ImportsPerDeveloper\x12\x36\n\tlanguages\x18\x01 \x03(\x0b\x32#.ImportsPerDeveloper.LanguagesEntry\x1a\x45\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.ImportsPerLanguage:\x02\x38\x01\"l\n\x1aImportsPerDeveloperResults\x12%\n\x07imports\x18\x01 \x03(\x0b\x32\x14.ImportsPerDeveloper\x12\x14\n\x0c\x61uthor_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x03 \x01(\x03\"\x9a\x01\n\nDevCadence\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\x03\x12\x10\n\x08mean_gap\x18\x02 \x01(\x01\x12\x12\n\nmedian_gap\x18\x03 \x01(\x01\x12\x15\n\rgap_histogram\x18\x04 \x03(\x03\x12\x1d\n\x15longest_active_streak\x18\x05 \x01(\x05\x12\x1f\n\x17longest_inactive_streak\x18\x06 \x01(\x05\"\xd5\x01\n\x19\x44\x65vCadenceAnalysisResults\x12>\n\ndevelopers\x18\x01 \x03(\x0b\x32*.DevCadenceAnalysisResults.DevelopersEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x12\x12\n\ngap_bounds\x18\x03 \x03(\x05\x12\x11\n\ttick_size\x18\x04 \x01(\x03\x1a>\n\x0f\x44\x65velopersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1a\n\x05value\x18\x02 \x01(\x0b\x32\x0b.DevCadence:\x02\x38\x01\"V\n\x08\x44\x65vFocus\x12\r\n\x05\x66iles\x18\x01 \x01(\x05\x12\r\n\x05\x65\x64its\x18\x02 \x01(\x03\x12\x0f\n\x07\x65ntropy\x18\x03 \x01(\x01\x12\x0c\n\x04gini\x18\x04 \x01(\x01\x12\r\n\x05\x66ocus\x18\x05 \x01(\x01\"\x92\x01\n\x10\x44\x65vFocusTimeline\x12\x18\n\x05total\x18\x01 \x01(\x0b\x32\t.DevFocus\x12+\n\x05ticks\x18\x02 \x03(\x0b\x32\x1c.DevFocusTimeline.TicksEntry\x1a\x37\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x18\n\x05value\x18\x02 \x01(\x0b\x32\t.DevFocus:\x02\x38\x01\"\xc3\x01\n\x17\x44\x65vFocusAnalysisResults\x12<\n\ndevelopers\x18\x01 \x03(\x0b\x32(.DevFocusAnalysisResults.DevelopersEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x03 \x01(\x03\x1a\x44\n\x0f\x44\x65velopersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.DevFocusTimeline:\x02\x38\x01\"1\n\x0e\x44\x65vMergeCounts\x12\x0e\n\x06merges\x18\x01 \x01(\x05\x12\x0f\n\x07regular\x18\x02 \x01(\x05\"\x82\x01\n\x12\x44\x65vMergeRatioTicks\x12-\n\x05ticks\x18\x01 \x03(\x0b\x32\x1e.DevMergeRatioTicks.TicksEntry\x1a=\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.DevMergeCounts:\x02\x38\x01\"\xcf\x01\n\x1c\x44\x65vMergeRatioAnalysisResults\x12\x41\n\ndevelopers\x18\x01 \x03(\x0b\x32-.DevMergeRatioAnalysisResults.DevelopersEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x03 \x01(\x03\x1a\x46\n\x0f\x44\x65velopersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.DevMergeRatioTicks:\x02\x38\x01\"\x1b\n\tPunchcard\x12\x0e\n\x06\x63ounts\x18\x01 \x03(\x03\"\xd8\x01\n\x18PunchcardAnalysisResults\x12\x19\n\x05total\x18\x01 \x01(\x0b\x32\n.Punchcard\x12=\n\ndevelopers\x18\x02 \x03(\x0b\x32).PunchcardAnalysisResults.DevelopersEntry\x12\x11\n\tdev_index\x18\x03 \x03(\t\x12\x10\n\x08timezone\x18\x04 \x01(\t\x1a=\n\x0f\x44\x65velopersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.Punchcard:\x02\x38\x01\"q\n\x12\x44irectoryOwnership\x12-\n\x05lines\x18\x01 \x03(\x0b\x32\x1e.DirectoryOwnership.LinesEntry\x1a,\n\nLinesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"\xa0\x01\n\x16TickDirectoryOwnership\x12=\n\x0b\x64irectories\x18\x01 \x03(\x0b\x32(.TickDirectoryOwnership.DirectoriesEntry\x1aG\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.DirectoryOwnership:\x02\x38\x01\"\xdd\x01\n!DirectoryOwnershipAnalysisResults\x12<\n\x05ticks\x18\x01 \x03(\x0b\x32-.DirectoryOwnershipAnalysisResults.TicksEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x12\r\n\x05\x64\x65pth\x18\x03 \x01(\x05\x12\x11\n\ttick_size\x18\x04 \x01(\x03\x1a\x45\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12&\n\x05value\x18\x02 \x01(\x0b\x32\x17.TickDirectoryOwnership:\x02\x38\x01\"\xfd\x01\n\x16\x43odeAgeAnalysisResults\x12/\n\x04\x61ges\x18\x01 \x03(\x0b\x32!.CodeAgeAnalysisResults.AgesEntry\x12>\n\x0c\x66ile_medians\x18\x02 \x03(\x0b\x32(.CodeAgeAnalysisResults.FileMediansEntry\x12\x11\n\ttick_size\x18\x03 \x01(\x03\x1a+\n\tAgesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\x1a\x32\n\x10\x46ileMediansEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\"k\n\x18TestRatioAnalysisResults\x12\x12\n\ntest_lines\x18\x01 \x03(\x03\x12\x18\n\x10production_lines\x18\x02 \x03(\x03\x12\x0e\n\x06ratios\x18\x03 \x03(\x01\x12\x11\n\ttick_size\x18\x04 \x01(\x03\"R\n\x12\x43ommentRatioSeries\x12\x0f\n\x07\x63omment\x18\x01 \x03(\x03\x12\x0c\n\x04\x63ode\x18\x02 \x03(\x03\x12\r\n\x05\x62lank\x18\x03 \x03(\x03\x12\x0e\n\x06ratios\x18\x04 \x03(\x01\"\xb7\x01\n\x1b\x43ommentRatioAnalysisResults\x12>\n\tlanguages\x18\x01 \x03(\x0b\x32+.CommentRatioAnalysisResults.LanguagesEntry\x12\x11\n\ttick_size\x18\x02 \x01(\x03\x1a\x45\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.CommentRatioSeries:\x02\x38\x01\"\x84\x01\n\x11LeadTimeHistogram\x12.\n\x06\x64\x65lays\x18\x01 \x03(\x0b\x32\x1e.LeadTimeHistogram.DelaysEntry\x12\x10\n\x08untagged\x18\x02 \x01(\x03\x1a-\n\x0b\x44\x65laysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"O\n\x17LeadTimeAnalysisResults\x12!\n\x05ticks\x18\x01 \x03(\x0b\x32\x12.LeadTimeHistogram\x12\x11\n\ttick_size\x18\x02 \x01(\x03\"\x99\x01\n\x0cResurrection\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x15\n\rdeletion_tick\x18\x02 \x01(\x05\x12\x17\n\x0f\x64\x65letion_author\x18\x03 \x01(\x05\x12\x19\n\x11resurrection_tick\x18\x04 \x01(\x05\x12\x1b\n\x13resurrection_author\x18\x05 \x01(\x05\x12\x13\n\x0brename_back\x18\x06 \x01(\x08\"l\n\x1bResurrectionAnalysisResults\x12$\n\rresurrections\x18\x01 \x03(\x0b\x32\r.Resurrection\x12\x14\n\x0c\x61uthor_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x03 \x01(\x03\"W\n\x11OwnershipTransfer\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x0c\n\x04tick\x18\x02 \x01(\x05\x12\x13\n\x0b\x66rom_author\x18\x03 \x01(\x05\x12\x11\n\tto_author\x18\x04 \x01(\x05\"r\n OwnershipTransferAnalysisResults\x12%\n\ttransfers\x18\x01 \x03(\x0b\x32\x12.OwnershipTransfer\x12\x14\n\x0c\x61uthor_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x03 \x01(\x03\"_\n\rRefactorEvent\x12\x0e\n\x06\x63ommit\x18\x01 \x01(\t\x12\x0c\n\x04tick\x18\x02 \x01(\x05\x12\x0f\n\x07renames\x18\x03 \x01(\x05\x12\x0f\n\x07\x63hanges\x18\x04 \x01(\x05\x12\x0e\n\x06\x61uthor\x18\x05 \x01(\x05\"k\n RefactorDetectionAnalysisResults\x12\x1e\n\x06\x65vents\x18\x01 \x03(\x0b\x32\x0e.RefactorEvent\x12\x14\n\x0c\x61uthor_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x03 \x01(\x03\"N\n\x1dImportCouplingAnalysisResults\x12\r\n\x05\x66iles\x18\x01 \x03(\t\x12\x1e\n\x07imports\x18\x02 \x03(\x0b\x32\r.TouchedFiles\"W\n\tLineEvent\x12\x0c\n\x04tick\x18\x01 \x01(\x05\x12\x0e\n\x06\x61uthor\x18\x02 \x01(\x05\x12\x0c\n\x04\x66ile\x18\x03 \x01(\t\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x04 \x01(\x05\x12\x0f\n\x07removed\x18\x05 \x01(\x05\"D\n\x19LineEventsAnalysisResults\x12\x14\n\x0c\x61uthor_index\x18\x01 \x03(\t\x12\x11\n\ttick_size\x18\x02 \x01(\x03\"\x8f\x01\n\x0f\x41nalysisResults\x12\x19\n\x06header\x18\x01 \x01(\x0b\x32\t.Metadata\x12\x30\n\x08\x63ontents\x18\x02 \x03(\x0b\x32\x1e.AnalysisResults.ContentsEntry\x1a/\n\rContentsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\x62\x06proto3')
 )
 
 
@@ -58,8 +58,45 @@ _METADATA_RUNTIMEPERITEMENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=217,
-  serialized_end=270,
+  serialized_start=304,
+  serialized_end=357,
+)
+
+_METADATA_CONFIGENTRY = _descriptor.Descriptor(
+  name='ConfigEntry',
+  full_name='Metadata.ConfigEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='Metadata.ConfigEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='Metadata.ConfigEntry.value', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=359,
+  serialized_end=404,
 )
 
 _METADATA = _descriptor.Descriptor(
@@ -125,10 +162,31 @@ _METADATA = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='skewed_commits', full_name='Metadata.skewed_commits', index=8,
+      number=9, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='failed_commits', full_name='Metadata.failed_commits', index=9,
+      number=10, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='config', full_name='Metadata.config', index=10,
+      number=11, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
-  nested_types=[_METADATA_RUNTIMEPERITEMENTRY, ],
+  nested_types=[_METADATA_RUNTIMEPERITEMENTRY, _METADATA_CONFIGENTRY, ],
   enum_types=[
   ],
   serialized_options=None,
@@ -138,7 +196,7 @@ _METADATA = _descriptor.Descriptor(
   oneofs=[
   ],
   serialized_start=13,
-  serialized_end=270,
+  serialized_end=404,
 )
 
 
@@ -168,8 +226,8 @@ _BURNDOWNSPARSEMATRIXROW = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=272,
-  serialized_end=314,
+  serialized_start=406,
+  serialized_end=448,
 )
 
 
@@ -220,8 +278,8 @@ _BURNDOWNSPARSEMATRIX = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=316,
-  serialized_end=443,
+  serialized_start=450,
+  serialized_end=577,
 )
 
 
@@ -258,8 +316,8 @@ _FILESOWNERSHIP_VALUEENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=506,
-  serialized_end=550,
+  serialized_start=640,
+  serialized_end=684,
 )
 
 _FILESOWNERSHIP = _descriptor.Descriptor(
@@ -288,8 +346,8 @@ _FILESOWNERSHIP = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=445,
-  serialized_end=550,
+  serialized_start=579,
+  serialized_end=684,
 )
 
 
@@ -356,6 +414,55 @@ _BURNDOWNANALYSISRESULTS = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='active_authors', full_name='BurndownAnalysisResults.active_authors', index=8,
+      number=9, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='active_authors_window', full_name='BurndownAnalysisResults.active_authors_window', index=9,
+      number=10, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='commit_counts', full_name='BurndownAnalysisResults.commit_counts', index=10,
+      number=11, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='old_vs_new', full_name='BurndownAnalysisResults.old_vs_new', index=11,
+      number=12, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='old_vs_new_threshold', full_name='BurndownAnalysisResults.old_vs_new_threshold', index=12,
+      number=13, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='sample_commits', full_name='BurndownAnalysisResults.sample_commits', index=13,
+      number=14, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='total_lines', full_name='BurndownAnalysisResults.total_lines', index=14,
+      number=15, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
@@ -368,8 +475,8 @@ _BURNDOWNANALYSISRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=553,
-  serialized_end=851,
+  serialized_start=687,
+  serialized_end=1227,
 )
 
 
@@ -427,8 +534,67 @@ _COMPRESSEDSPARSEROWMATRIX = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=853,
-  serialized_end=978,
+  serialized_start=1229,
+  serialized_end=1354,
+)
+
+
+_COMPRESSEDSPARSEROWFLOATMATRIX = _descriptor.Descriptor(
+  name='CompressedSparseRowFloatMatrix',
+  full_name='CompressedSparseRowFloatMatrix',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='number_of_rows', full_name='CompressedSparseRowFloatMatrix.number_of_rows', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='number_of_columns', full_name='CompressedSparseRowFloatMatrix.number_of_columns', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='data', full_name='CompressedSparseRowFloatMatrix.data', index=2,
+      number=3, type=2, cpp_type=6, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='indices', full_name='CompressedSparseRowFloatMatrix.indices', index=3,
+      number=4, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='indptr', full_name='CompressedSparseRowFloatMatrix.indptr', index=4,
+      number=5, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1357,
+  serialized_end=1487,
 )
 
 
@@ -465,8 +631,8 @@ _COUPLES = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=980,
-  serialized_end=1048,
+  serialized_start=1489,
+  serialized_end=1557,
 )
 
 
@@ -496,8 +662,8 @@ _TOUCHEDFILES = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1050,
-  serialized_end=1079,
+  serialized_start=1559,
+  serialized_end=1588,
 )
 
 
@@ -536,6 +702,51 @@ _COUPLESANALYSISRESULTS = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='files_jaccard', full_name='CouplesAnalysisResults.files_jaccard', index=4,
+      number=10, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='files_embeddings', full_name='CouplesAnalysisResults.files_embeddings', index=5,
+      number=11, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1591,
+  serialized_end=1837,
+)
+
+
+_FILEEMBEDDING = _descriptor.Descriptor(
+  name='FileEmbedding',
+  full_name='FileEmbedding',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='vector', full_name='FileEmbedding.vector', index=0,
+      number=1, type=2, cpp_type=6, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
@@ -548,8 +759,8 @@ _COUPLESANALYSISRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1082,
-  serialized_end=1230,
+  serialized_start=1839,
+  serialized_end=1870,
 )
 
 
@@ -607,8 +818,8 @@ _UASTCHANGE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1232,
-  serialized_end=1343,
+  serialized_start=1872,
+  serialized_end=1983,
 )
 
 
@@ -638,8 +849,8 @@ _UASTCHANGESSAVERRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1345,
-  serialized_end=1400,
+  serialized_start=1985,
+  serialized_end=2040,
 )
 
 
@@ -676,8 +887,8 @@ _SHOTNESSRECORD_COUNTERSENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1512,
-  serialized_end=1559,
+  serialized_start=2152,
+  serialized_end=2199,
 )
 
 _SHOTNESSRECORD = _descriptor.Descriptor(
@@ -727,8 +938,8 @@ _SHOTNESSRECORD = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1403,
-  serialized_end=1559,
+  serialized_start=2043,
+  serialized_end=2199,
 )
 
 
@@ -758,8 +969,8 @@ _SHOTNESSANALYSISRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1561,
-  serialized_end=1620,
+  serialized_start=2201,
+  serialized_end=2260,
 )
 
 
@@ -796,8 +1007,8 @@ _FILEHISTORY_CHANGESBYDEVELOPERENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1723,
-  serialized_end=1792,
+  serialized_start=2363,
+  serialized_end=2432,
 )
 
 _FILEHISTORY = _descriptor.Descriptor(
@@ -833,8 +1044,8 @@ _FILEHISTORY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1623,
-  serialized_end=1792,
+  serialized_start=2263,
+  serialized_end=2432,
 )
 
 
@@ -871,8 +1082,8 @@ _FILEHISTORYRESULTMESSAGE_FILESENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1876,
-  serialized_end=1934,
+  serialized_start=2516,
+  serialized_end=2574,
 )
 
 _FILEHISTORYRESULTMESSAGE = _descriptor.Descriptor(
@@ -901,35 +1112,80 @@ _FILEHISTORYRESULTMESSAGE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1795,
-  serialized_end=1934,
+  serialized_start=2435,
+  serialized_end=2574,
 )
 
 
-_LINESTATS = _descriptor.Descriptor(
-  name='LineStats',
-  full_name='LineStats',
+_BINARYCHURNANALYSISRESULTS = _descriptor.Descriptor(
+  name='BinaryChurnAnalysisResults',
+  full_name='BinaryChurnAnalysisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='added', full_name='LineStats.added', index=0,
-      number=1, type=5, cpp_type=1, label=1,
+      name='added', full_name='BinaryChurnAnalysisResults.added', index=0,
+      number=1, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='BinaryChurnAnalysisResults.removed', index=1,
+      number=2, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='deltas', full_name='BinaryChurnAnalysisResults.deltas', index=2,
+      number=3, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='BinaryChurnAnalysisResults.tick_size', index=3,
+      number=4, type=3, cpp_type=2, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2576,
+  serialized_end=2671,
+)
+
+
+_FILEGENESIS = _descriptor.Descriptor(
+  name='FileGenesis',
+  full_name='FileGenesis',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
     _descriptor.FieldDescriptor(
-      name='removed', full_name='LineStats.removed', index=1,
-      number=2, type=5, cpp_type=1, label=1,
+      name='tick', full_name='FileGenesis.tick', index=0,
+      number=1, type=5, cpp_type=1, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='changed', full_name='LineStats.changed', index=2,
-      number=3, type=5, cpp_type=1, label=1,
+      name='author', full_name='FileGenesis.author', index=1,
+      number=2, type=5, cpp_type=1, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -946,27 +1202,27 @@ _LINESTATS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1936,
-  serialized_end=1996,
+  serialized_start=2673,
+  serialized_end=2716,
 )
 
 
-_DEVTICK_LANGUAGESENTRY = _descriptor.Descriptor(
-  name='LanguagesEntry',
-  full_name='DevTick.LanguagesEntry',
+_FILEGENESISRESULTS_FILESENTRY = _descriptor.Descriptor(
+  name='FilesEntry',
+  full_name='FileGenesisResults.FilesEntry',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='key', full_name='DevTick.LanguagesEntry.key', index=0,
+      name='key', full_name='FileGenesisResults.FilesEntry.key', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=_b("").decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='value', full_name='DevTick.LanguagesEntry.value', index=1,
+      name='value', full_name='FileGenesisResults.FilesEntry.value', index=1,
       number=2, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
@@ -984,42 +1240,42 @@ _DEVTICK_LANGUAGESENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2098,
-  serialized_end=2158,
+  serialized_start=2829,
+  serialized_end=2887,
 )
 
-_DEVTICK = _descriptor.Descriptor(
-  name='DevTick',
-  full_name='DevTick',
+_FILEGENESISRESULTS = _descriptor.Descriptor(
+  name='FileGenesisResults',
+  full_name='FileGenesisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='commits', full_name='DevTick.commits', index=0,
-      number=1, type=5, cpp_type=1, label=1,
-      has_default_value=False, default_value=0,
+      name='files', full_name='FileGenesisResults.files', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='stats', full_name='DevTick.stats', index=1,
-      number=2, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='author_index', full_name='FileGenesisResults.author_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='languages', full_name='DevTick.languages', index=2,
-      number=3, type=11, cpp_type=10, label=3,
-      has_default_value=False, default_value=[],
+      name='tick_size', full_name='FileGenesisResults.tick_size', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
-  nested_types=[_DEVTICK_LANGUAGESENTRY, ],
+  nested_types=[_FILEGENESISRESULTS_FILESENTRY, ],
   enum_types=[
   ],
   serialized_options=None,
@@ -1028,29 +1284,36 @@ _DEVTICK = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1999,
-  serialized_end=2158,
+  serialized_start=2719,
+  serialized_end=2887,
 )
 
 
-_TICKDEVS_DEVSENTRY = _descriptor.Descriptor(
-  name='DevsEntry',
-  full_name='TickDevs.DevsEntry',
+_HOTSPOT = _descriptor.Descriptor(
+  name='Hotspot',
+  full_name='Hotspot',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='key', full_name='TickDevs.DevsEntry.key', index=0,
-      number=1, type=5, cpp_type=1, label=1,
+      name='path', full_name='Hotspot.path', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='edits', full_name='Hotspot.edits', index=1,
+      number=2, type=3, cpp_type=2, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='value', full_name='TickDevs.DevsEntry.value', index=1,
-      number=2, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='series', full_name='Hotspot.series', index=2,
+      number=3, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
@@ -1060,14 +1323,427 @@ _TICKDEVS_DEVSENTRY = _descriptor.Descriptor(
   nested_types=[],
   enum_types=[
   ],
-  serialized_options=_b('8\001'),
+  serialized_options=None,
   is_extendable=False,
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2207,
-  serialized_end=2260,
+  serialized_start=2889,
+  serialized_end=2943,
+)
+
+
+_HOTSPOTSANALYSISRESULTS = _descriptor.Descriptor(
+  name='HotspotsAnalysisResults',
+  full_name='HotspotsAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='files', full_name='HotspotsAnalysisResults.files', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='HotspotsAnalysisResults.tick_size', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2945,
+  serialized_end=3014,
+)
+
+
+_BUSFACTOR = _descriptor.Descriptor(
+  name='BusFactor',
+  full_name='BusFactor',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='path', full_name='BusFactor.path', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='BusFactor.lines', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='bus_factor', full_name='BusFactor.bus_factor', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='owners', full_name='BusFactor.owners', index=3,
+      number=4, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3016,
+  serialized_end=3092,
+)
+
+
+_BUSFACTORANALYSISRESULTS = _descriptor.Descriptor(
+  name='BusFactorAnalysisResults',
+  full_name='BusFactorAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='files', full_name='BusFactorAnalysisResults.files', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='BusFactorAnalysisResults.directories', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='BusFactorAnalysisResults.dev_index', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3094,
+  serialized_end=3199,
+)
+
+
+_COMMITSIZETICK = _descriptor.Descriptor(
+  name='CommitSizeTick',
+  full_name='CommitSizeTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='counts', full_name='CommitSizeTick.counts', index=0,
+      number=1, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3201,
+  serialized_end=3233,
+)
+
+
+_COMMITSIZEANALYSISRESULTS_TICKSENTRY = _descriptor.Descriptor(
+  name='TicksEntry',
+  full_name='CommitSizeAnalysisResults.TicksEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CommitSizeAnalysisResults.TicksEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CommitSizeAnalysisResults.TicksEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3354,
+  serialized_end=3415,
+)
+
+_COMMITSIZEANALYSISRESULTS = _descriptor.Descriptor(
+  name='CommitSizeAnalysisResults',
+  full_name='CommitSizeAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='bounds', full_name='CommitSizeAnalysisResults.bounds', index=0,
+      number=1, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='CommitSizeAnalysisResults.ticks', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='CommitSizeAnalysisResults.tick_size', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMMITSIZEANALYSISRESULTS_TICKSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3236,
+  serialized_end=3415,
+)
+
+
+_LINESTATS = _descriptor.Descriptor(
+  name='LineStats',
+  full_name='LineStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='added', full_name='LineStats.added', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='LineStats.removed', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='changed', full_name='LineStats.changed', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3417,
+  serialized_end=3477,
+)
+
+
+_DEVTICK_LANGUAGESENTRY = _descriptor.Descriptor(
+  name='LanguagesEntry',
+  full_name='DevTick.LanguagesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DevTick.LanguagesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DevTick.LanguagesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3579,
+  serialized_end=3639,
+)
+
+_DEVTICK = _descriptor.Descriptor(
+  name='DevTick',
+  full_name='DevTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='DevTick.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='stats', full_name='DevTick.stats', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='languages', full_name='DevTick.languages', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DEVTICK_LANGUAGESENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3480,
+  serialized_end=3639,
+)
+
+
+_TICKDEVS_DEVSENTRY = _descriptor.Descriptor(
+  name='DevsEntry',
+  full_name='TickDevs.DevsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='TickDevs.DevsEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='TickDevs.DevsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3688,
+  serialized_end=3741,
 )
 
 _TICKDEVS = _descriptor.Descriptor(
@@ -1078,7 +1754,1430 @@ _TICKDEVS = _descriptor.Descriptor(
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='devs', full_name='TickDevs.devs', index=0,
+      name='devs', full_name='TickDevs.devs', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_TICKDEVS_DEVSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3641,
+  serialized_end=3741,
+)
+
+
+_DEVSANALYSISRESULTS_TICKSENTRY = _descriptor.Descriptor(
+  name='TicksEntry',
+  full_name='DevsAnalysisResults.TicksEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DevsAnalysisResults.TicksEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DevsAnalysisResults.TicksEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3874,
+  serialized_end=3929,
+)
+
+_DEVSANALYSISRESULTS = _descriptor.Descriptor(
+  name='DevsAnalysisResults',
+  full_name='DevsAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='DevsAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='DevsAnalysisResults.dev_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='active_devs', full_name='DevsAnalysisResults.active_devs', index=2,
+      number=3, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='DevsAnalysisResults.tick_size', index=3,
+      number=8, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DEVSANALYSISRESULTS_TICKSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3744,
+  serialized_end=3929,
+)
+
+
+_SENTIMENT = _descriptor.Descriptor(
+  name='Sentiment',
+  full_name='Sentiment',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='value', full_name='Sentiment.value', index=0,
+      number=1, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='comments', full_name='Sentiment.comments', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='Sentiment.commits', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3931,
+  serialized_end=3992,
+)
+
+
+_COMMENTSENTIMENTRESULTS_SENTIMENTBYTICKENTRY = _descriptor.Descriptor(
+  name='SentimentByTickEntry',
+  full_name='CommentSentimentResults.SentimentByTickEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CommentSentimentResults.SentimentByTickEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CommentSentimentResults.SentimentByTickEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4096,
+  serialized_end=4162,
+)
+
+_COMMENTSENTIMENTRESULTS = _descriptor.Descriptor(
+  name='CommentSentimentResults',
+  full_name='CommentSentimentResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sentiment_by_tick', full_name='CommentSentimentResults.sentiment_by_tick', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMMENTSENTIMENTRESULTS_SENTIMENTBYTICKENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3995,
+  serialized_end=4162,
+)
+
+
+_COMMITFILE = _descriptor.Descriptor(
+  name='CommitFile',
+  full_name='CommitFile',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='name', full_name='CommitFile.name', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='language', full_name='CommitFile.language', index=1,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='stats', full_name='CommitFile.stats', index=2,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4164,
+  serialized_end=4235,
+)
+
+
+_COMMIT = _descriptor.Descriptor(
+  name='Commit',
+  full_name='Commit',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='hash', full_name='Commit.hash', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='when_unix_time', full_name='Commit.when_unix_time', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='author', full_name='Commit.author', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='Commit.files', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4237,
+  serialized_end=4327,
+)
+
+
+_COMMITSANALYSISRESULTS = _descriptor.Descriptor(
+  name='CommitsAnalysisResults',
+  full_name='CommitsAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='CommitsAnalysisResults.commits', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='author_index', full_name='CommitsAnalysisResults.author_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4329,
+  serialized_end=4401,
+)
+
+
+_TYPO = _descriptor.Descriptor(
+  name='Typo',
+  full_name='Typo',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='wrong', full_name='Typo.wrong', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='correct', full_name='Typo.correct', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='commit', full_name='Typo.commit', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='Typo.file', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='line', full_name='Typo.line', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4403,
+  serialized_end=4485,
+)
+
+
+_TYPOSDATASET = _descriptor.Descriptor(
+  name='TyposDataset',
+  full_name='TyposDataset',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='typos', full_name='TyposDataset.typos', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4487,
+  serialized_end=4523,
+)
+
+
+_IMPORTSPERTICK_COUNTSENTRY = _descriptor.Descriptor(
+  name='CountsEntry',
+  full_name='ImportsPerTick.CountsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ImportsPerTick.CountsEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ImportsPerTick.CountsEntry.value', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4588,
+  serialized_end=4633,
+)
+
+_IMPORTSPERTICK = _descriptor.Descriptor(
+  name='ImportsPerTick',
+  full_name='ImportsPerTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='counts', full_name='ImportsPerTick.counts', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_IMPORTSPERTICK_COUNTSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4525,
+  serialized_end=4633,
+)
+
+
+_IMPORTSPERLANGUAGE_TICKSENTRY = _descriptor.Descriptor(
+  name='TicksEntry',
+  full_name='ImportsPerLanguage.TicksEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ImportsPerLanguage.TicksEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ImportsPerLanguage.TicksEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4705,
+  serialized_end=4766,
+)
+
+_IMPORTSPERLANGUAGE = _descriptor.Descriptor(
+  name='ImportsPerLanguage',
+  full_name='ImportsPerLanguage',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='ImportsPerLanguage.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_IMPORTSPERLANGUAGE_TICKSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4636,
+  serialized_end=4766,
+)
+
+
+_IMPORTSPERDEVELOPER_LANGUAGESENTRY = _descriptor.Descriptor(
+  name='LanguagesEntry',
+  full_name='ImportsPerDeveloper.LanguagesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ImportsPerDeveloper.LanguagesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ImportsPerDeveloper.LanguagesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4848,
+  serialized_end=4917,
+)
+
+_IMPORTSPERDEVELOPER = _descriptor.Descriptor(
+  name='ImportsPerDeveloper',
+  full_name='ImportsPerDeveloper',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='languages', full_name='ImportsPerDeveloper.languages', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_IMPORTSPERDEVELOPER_LANGUAGESENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4769,
+  serialized_end=4917,
+)
+
+
+_IMPORTSPERDEVELOPERRESULTS = _descriptor.Descriptor(
+  name='ImportsPerDeveloperResults',
+  full_name='ImportsPerDeveloperResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='imports', full_name='ImportsPerDeveloperResults.imports', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='author_index', full_name='ImportsPerDeveloperResults.author_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='ImportsPerDeveloperResults.tick_size', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4919,
+  serialized_end=5027,
+)
+
+
+_DEVCADENCE = _descriptor.Descriptor(
+  name='DevCadence',
+  full_name='DevCadence',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='DevCadence.commits', index=0,
+      number=1, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='mean_gap', full_name='DevCadence.mean_gap', index=1,
+      number=2, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='median_gap', full_name='DevCadence.median_gap', index=2,
+      number=3, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='gap_histogram', full_name='DevCadence.gap_histogram', index=3,
+      number=4, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='longest_active_streak', full_name='DevCadence.longest_active_streak', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='longest_inactive_streak', full_name='DevCadence.longest_inactive_streak', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5030,
+  serialized_end=5184,
+)
+
+
+_DEVCADENCEANALYSISRESULTS_DEVELOPERSENTRY = _descriptor.Descriptor(
+  name='DevelopersEntry',
+  full_name='DevCadenceAnalysisResults.DevelopersEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DevCadenceAnalysisResults.DevelopersEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DevCadenceAnalysisResults.DevelopersEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5338,
+  serialized_end=5400,
+)
+
+_DEVCADENCEANALYSISRESULTS = _descriptor.Descriptor(
+  name='DevCadenceAnalysisResults',
+  full_name='DevCadenceAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='developers', full_name='DevCadenceAnalysisResults.developers', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='DevCadenceAnalysisResults.dev_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='gap_bounds', full_name='DevCadenceAnalysisResults.gap_bounds', index=2,
+      number=3, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='DevCadenceAnalysisResults.tick_size', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DEVCADENCEANALYSISRESULTS_DEVELOPERSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5187,
+  serialized_end=5400,
+)
+
+
+_DEVFOCUS = _descriptor.Descriptor(
+  name='DevFocus',
+  full_name='DevFocus',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='files', full_name='DevFocus.files', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='edits', full_name='DevFocus.edits', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='entropy', full_name='DevFocus.entropy', index=2,
+      number=3, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='gini', full_name='DevFocus.gini', index=3,
+      number=4, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='focus', full_name='DevFocus.focus', index=4,
+      number=5, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5402,
+  serialized_end=5488,
+)
+
+
+_DEVFOCUSTIMELINE_TICKSENTRY = _descriptor.Descriptor(
+  name='TicksEntry',
+  full_name='DevFocusTimeline.TicksEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DevFocusTimeline.TicksEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DevFocusTimeline.TicksEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5582,
+  serialized_end=5637,
+)
+
+_DEVFOCUSTIMELINE = _descriptor.Descriptor(
+  name='DevFocusTimeline',
+  full_name='DevFocusTimeline',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='total', full_name='DevFocusTimeline.total', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='DevFocusTimeline.ticks', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DEVFOCUSTIMELINE_TICKSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5491,
+  serialized_end=5637,
+)
+
+
+_DEVFOCUSANALYSISRESULTS_DEVELOPERSENTRY = _descriptor.Descriptor(
+  name='DevelopersEntry',
+  full_name='DevFocusAnalysisResults.DevelopersEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DevFocusAnalysisResults.DevelopersEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DevFocusAnalysisResults.DevelopersEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5767,
+  serialized_end=5835,
+)
+
+_DEVFOCUSANALYSISRESULTS = _descriptor.Descriptor(
+  name='DevFocusAnalysisResults',
+  full_name='DevFocusAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='developers', full_name='DevFocusAnalysisResults.developers', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='DevFocusAnalysisResults.dev_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='DevFocusAnalysisResults.tick_size', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DEVFOCUSANALYSISRESULTS_DEVELOPERSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5640,
+  serialized_end=5835,
+)
+
+
+_DEVMERGECOUNTS = _descriptor.Descriptor(
+  name='DevMergeCounts',
+  full_name='DevMergeCounts',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='merges', full_name='DevMergeCounts.merges', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='regular', full_name='DevMergeCounts.regular', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5837,
+  serialized_end=5886,
+)
+
+
+_DEVMERGERATIOTICKS_TICKSENTRY = _descriptor.Descriptor(
+  name='TicksEntry',
+  full_name='DevMergeRatioTicks.TicksEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DevMergeRatioTicks.TicksEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DevMergeRatioTicks.TicksEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5958,
+  serialized_end=6019,
+)
+
+_DEVMERGERATIOTICKS = _descriptor.Descriptor(
+  name='DevMergeRatioTicks',
+  full_name='DevMergeRatioTicks',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='DevMergeRatioTicks.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DEVMERGERATIOTICKS_TICKSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5889,
+  serialized_end=6019,
+)
+
+
+_DEVMERGERATIOANALYSISRESULTS_DEVELOPERSENTRY = _descriptor.Descriptor(
+  name='DevelopersEntry',
+  full_name='DevMergeRatioAnalysisResults.DevelopersEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DevMergeRatioAnalysisResults.DevelopersEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DevMergeRatioAnalysisResults.DevelopersEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6159,
+  serialized_end=6229,
+)
+
+_DEVMERGERATIOANALYSISRESULTS = _descriptor.Descriptor(
+  name='DevMergeRatioAnalysisResults',
+  full_name='DevMergeRatioAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='developers', full_name='DevMergeRatioAnalysisResults.developers', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='DevMergeRatioAnalysisResults.dev_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='DevMergeRatioAnalysisResults.tick_size', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DEVMERGERATIOANALYSISRESULTS_DEVELOPERSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6022,
+  serialized_end=6229,
+)
+
+
+_PUNCHCARD = _descriptor.Descriptor(
+  name='Punchcard',
+  full_name='Punchcard',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='counts', full_name='Punchcard.counts', index=0,
+      number=1, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6231,
+  serialized_end=6258,
+)
+
+
+_PUNCHCARDANALYSISRESULTS_DEVELOPERSENTRY = _descriptor.Descriptor(
+  name='DevelopersEntry',
+  full_name='PunchcardAnalysisResults.DevelopersEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='PunchcardAnalysisResults.DevelopersEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='PunchcardAnalysisResults.DevelopersEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6416,
+  serialized_end=6477,
+)
+
+_PUNCHCARDANALYSISRESULTS = _descriptor.Descriptor(
+  name='PunchcardAnalysisResults',
+  full_name='PunchcardAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='total', full_name='PunchcardAnalysisResults.total', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='developers', full_name='PunchcardAnalysisResults.developers', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='PunchcardAnalysisResults.dev_index', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='timezone', full_name='PunchcardAnalysisResults.timezone', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_PUNCHCARDANALYSISRESULTS_DEVELOPERSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6261,
+  serialized_end=6477,
+)
+
+
+_DIRECTORYOWNERSHIP_LINESENTRY = _descriptor.Descriptor(
+  name='LinesEntry',
+  full_name='DirectoryOwnership.LinesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DirectoryOwnership.LinesEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DirectoryOwnership.LinesEntry.value', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6548,
+  serialized_end=6592,
+)
+
+_DIRECTORYOWNERSHIP = _descriptor.Descriptor(
+  name='DirectoryOwnership',
+  full_name='DirectoryOwnership',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='DirectoryOwnership.lines', index=0,
       number=1, type=11, cpp_type=10, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
@@ -1087,7 +3186,7 @@ _TICKDEVS = _descriptor.Descriptor(
   ],
   extensions=[
   ],
-  nested_types=[_TICKDEVS_DEVSENTRY, ],
+  nested_types=[_DIRECTORYOWNERSHIP_LINESENTRY, ],
   enum_types=[
   ],
   serialized_options=None,
@@ -1096,72 +3195,318 @@ _TICKDEVS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2160,
-  serialized_end=2260,
+  serialized_start=6479,
+  serialized_end=6592,
 )
 
 
-_DEVSANALYSISRESULTS_TICKSENTRY = _descriptor.Descriptor(
+_TICKDIRECTORYOWNERSHIP_DIRECTORIESENTRY = _descriptor.Descriptor(
+  name='DirectoriesEntry',
+  full_name='TickDirectoryOwnership.DirectoriesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='TickDirectoryOwnership.DirectoriesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='TickDirectoryOwnership.DirectoriesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6684,
+  serialized_end=6755,
+)
+
+_TICKDIRECTORYOWNERSHIP = _descriptor.Descriptor(
+  name='TickDirectoryOwnership',
+  full_name='TickDirectoryOwnership',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='TickDirectoryOwnership.directories', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_TICKDIRECTORYOWNERSHIP_DIRECTORIESENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6595,
+  serialized_end=6755,
+)
+
+
+_DIRECTORYOWNERSHIPANALYSISRESULTS_TICKSENTRY = _descriptor.Descriptor(
   name='TicksEntry',
-  full_name='DevsAnalysisResults.TicksEntry',
+  full_name='DirectoryOwnershipAnalysisResults.TicksEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DirectoryOwnershipAnalysisResults.TicksEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DirectoryOwnershipAnalysisResults.TicksEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6910,
+  serialized_end=6979,
+)
+
+_DIRECTORYOWNERSHIPANALYSISRESULTS = _descriptor.Descriptor(
+  name='DirectoryOwnershipAnalysisResults',
+  full_name='DirectoryOwnershipAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='DirectoryOwnershipAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='DirectoryOwnershipAnalysisResults.dev_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='depth', full_name='DirectoryOwnershipAnalysisResults.depth', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='DirectoryOwnershipAnalysisResults.tick_size', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DIRECTORYOWNERSHIPANALYSISRESULTS_TICKSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6758,
+  serialized_end=6979,
+)
+
+
+_CODEAGEANALYSISRESULTS_AGESENTRY = _descriptor.Descriptor(
+  name='AgesEntry',
+  full_name='CodeAgeAnalysisResults.AgesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CodeAgeAnalysisResults.AgesEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CodeAgeAnalysisResults.AgesEntry.value', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7140,
+  serialized_end=7183,
+)
+
+_CODEAGEANALYSISRESULTS_FILEMEDIANSENTRY = _descriptor.Descriptor(
+  name='FileMediansEntry',
+  full_name='CodeAgeAnalysisResults.FileMediansEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CodeAgeAnalysisResults.FileMediansEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CodeAgeAnalysisResults.FileMediansEntry.value', index=1,
+      number=2, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=_b('8\001'),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7185,
+  serialized_end=7235,
+)
+
+_CODEAGEANALYSISRESULTS = _descriptor.Descriptor(
+  name='CodeAgeAnalysisResults',
+  full_name='CodeAgeAnalysisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='key', full_name='DevsAnalysisResults.TicksEntry.key', index=0,
-      number=1, type=5, cpp_type=1, label=1,
-      has_default_value=False, default_value=0,
+      name='ages', full_name='CodeAgeAnalysisResults.ages', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='file_medians', full_name='CodeAgeAnalysisResults.file_medians', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='value', full_name='DevsAnalysisResults.TicksEntry.value', index=1,
-      number=2, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='tick_size', full_name='CodeAgeAnalysisResults.tick_size', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
-  nested_types=[],
+  nested_types=[_CODEAGEANALYSISRESULTS_AGESENTRY, _CODEAGEANALYSISRESULTS_FILEMEDIANSENTRY, ],
   enum_types=[
   ],
-  serialized_options=_b('8\001'),
+  serialized_options=None,
   is_extendable=False,
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2372,
-  serialized_end=2427,
+  serialized_start=6982,
+  serialized_end=7235,
 )
 
-_DEVSANALYSISRESULTS = _descriptor.Descriptor(
-  name='DevsAnalysisResults',
-  full_name='DevsAnalysisResults',
+
+_TESTRATIOANALYSISRESULTS = _descriptor.Descriptor(
+  name='TestRatioAnalysisResults',
+  full_name='TestRatioAnalysisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='ticks', full_name='DevsAnalysisResults.ticks', index=0,
-      number=1, type=11, cpp_type=10, label=3,
+      name='test_lines', full_name='TestRatioAnalysisResults.test_lines', index=0,
+      number=1, type=3, cpp_type=2, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='dev_index', full_name='DevsAnalysisResults.dev_index', index=1,
-      number=2, type=9, cpp_type=9, label=3,
+      name='production_lines', full_name='TestRatioAnalysisResults.production_lines', index=1,
+      number=2, type=3, cpp_type=2, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='tick_size', full_name='DevsAnalysisResults.tick_size', index=2,
-      number=8, type=3, cpp_type=2, label=1,
+      name='ratios', full_name='TestRatioAnalysisResults.ratios', index=2,
+      number=3, type=1, cpp_type=5, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='TestRatioAnalysisResults.tick_size', index=3,
+      number=4, type=3, cpp_type=2, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -1169,7 +3514,7 @@ _DEVSANALYSISRESULTS = _descriptor.Descriptor(
   ],
   extensions=[
   ],
-  nested_types=[_DEVSANALYSISRESULTS_TICKSENTRY, ],
+  nested_types=[],
   enum_types=[
   ],
   serialized_options=None,
@@ -1178,35 +3523,42 @@ _DEVSANALYSISRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2263,
-  serialized_end=2427,
+  serialized_start=7237,
+  serialized_end=7344,
 )
 
 
-_SENTIMENT = _descriptor.Descriptor(
-  name='Sentiment',
-  full_name='Sentiment',
+_COMMENTRATIOSERIES = _descriptor.Descriptor(
+  name='CommentRatioSeries',
+  full_name='CommentRatioSeries',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='value', full_name='Sentiment.value', index=0,
-      number=1, type=2, cpp_type=6, label=1,
-      has_default_value=False, default_value=float(0),
+      name='comment', full_name='CommentRatioSeries.comment', index=0,
+      number=1, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='comments', full_name='Sentiment.comments', index=1,
-      number=2, type=9, cpp_type=9, label=3,
+      name='code', full_name='CommentRatioSeries.code', index=1,
+      number=2, type=3, cpp_type=2, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='commits', full_name='Sentiment.commits', index=2,
-      number=3, type=9, cpp_type=9, label=3,
+      name='blank', full_name='CommentRatioSeries.blank', index=2,
+      number=3, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='ratios', full_name='CommentRatioSeries.ratios', index=3,
+      number=4, type=1, cpp_type=5, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -1223,27 +3575,27 @@ _SENTIMENT = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2429,
-  serialized_end=2490,
+  serialized_start=7346,
+  serialized_end=7428,
 )
 
 
-_COMMENTSENTIMENTRESULTS_SENTIMENTBYTICKENTRY = _descriptor.Descriptor(
-  name='SentimentByTickEntry',
-  full_name='CommentSentimentResults.SentimentByTickEntry',
+_COMMENTRATIOANALYSISRESULTS_LANGUAGESENTRY = _descriptor.Descriptor(
+  name='LanguagesEntry',
+  full_name='CommentRatioAnalysisResults.LanguagesEntry',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='key', full_name='CommentSentimentResults.SentimentByTickEntry.key', index=0,
-      number=1, type=5, cpp_type=1, label=1,
-      has_default_value=False, default_value=0,
+      name='key', full_name='CommentRatioAnalysisResults.LanguagesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='value', full_name='CommentSentimentResults.SentimentByTickEntry.value', index=1,
+      name='value', full_name='CommentRatioAnalysisResults.LanguagesEntry.value', index=1,
       number=2, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
@@ -1261,28 +3613,35 @@ _COMMENTSENTIMENTRESULTS_SENTIMENTBYTICKENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2594,
-  serialized_end=2660,
+  serialized_start=7545,
+  serialized_end=7614,
 )
 
-_COMMENTSENTIMENTRESULTS = _descriptor.Descriptor(
-  name='CommentSentimentResults',
-  full_name='CommentSentimentResults',
+_COMMENTRATIOANALYSISRESULTS = _descriptor.Descriptor(
+  name='CommentRatioAnalysisResults',
+  full_name='CommentRatioAnalysisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='sentiment_by_tick', full_name='CommentSentimentResults.sentiment_by_tick', index=0,
+      name='languages', full_name='CommentRatioAnalysisResults.languages', index=0,
       number=1, type=11, cpp_type=10, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='CommentRatioAnalysisResults.tick_size', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
-  nested_types=[_COMMENTSENTIMENTRESULTS_SENTIMENTBYTICKENTRY, ],
+  nested_types=[_COMMENTRATIOANALYSISRESULTS_LANGUAGESENTRY, ],
   enum_types=[
   ],
   serialized_options=None,
@@ -1291,36 +3650,29 @@ _COMMENTSENTIMENTRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2493,
-  serialized_end=2660,
+  serialized_start=7431,
+  serialized_end=7614,
 )
 
 
-_COMMITFILE = _descriptor.Descriptor(
-  name='CommitFile',
-  full_name='CommitFile',
+_LEADTIMEHISTOGRAM_DELAYSENTRY = _descriptor.Descriptor(
+  name='DelaysEntry',
+  full_name='LeadTimeHistogram.DelaysEntry',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='name', full_name='CommitFile.name', index=0,
-      number=1, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      serialized_options=None, file=DESCRIPTOR),
-    _descriptor.FieldDescriptor(
-      name='language', full_name='CommitFile.language', index=1,
-      number=3, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
+      name='key', full_name='LeadTimeHistogram.DelaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='stats', full_name='CommitFile.stats', index=2,
-      number=4, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='value', full_name='LeadTimeHistogram.DelaysEntry.value', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
@@ -1330,56 +3682,41 @@ _COMMITFILE = _descriptor.Descriptor(
   nested_types=[],
   enum_types=[
   ],
-  serialized_options=None,
+  serialized_options=_b('8\001'),
   is_extendable=False,
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2662,
-  serialized_end=2733,
+  serialized_start=7704,
+  serialized_end=7749,
 )
 
-
-_COMMIT = _descriptor.Descriptor(
-  name='Commit',
-  full_name='Commit',
+_LEADTIMEHISTOGRAM = _descriptor.Descriptor(
+  name='LeadTimeHistogram',
+  full_name='LeadTimeHistogram',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='hash', full_name='Commit.hash', index=0,
-      number=1, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
+      name='delays', full_name='LeadTimeHistogram.delays', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='when_unix_time', full_name='Commit.when_unix_time', index=1,
+      name='untagged', full_name='LeadTimeHistogram.untagged', index=1,
       number=2, type=3, cpp_type=2, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
-    _descriptor.FieldDescriptor(
-      name='author', full_name='Commit.author', index=2,
-      number=3, type=5, cpp_type=1, label=1,
-      has_default_value=False, default_value=0,
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      serialized_options=None, file=DESCRIPTOR),
-    _descriptor.FieldDescriptor(
-      name='files', full_name='Commit.files', index=3,
-      number=4, type=11, cpp_type=10, label=3,
-      has_default_value=False, default_value=[],
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
-  nested_types=[],
+  nested_types=[_LEADTIMEHISTOGRAM_DELAYSENTRY, ],
   enum_types=[
   ],
   serialized_options=None,
@@ -1388,29 +3725,29 @@ _COMMIT = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2735,
-  serialized_end=2825,
+  serialized_start=7617,
+  serialized_end=7749,
 )
 
 
-_COMMITSANALYSISRESULTS = _descriptor.Descriptor(
-  name='CommitsAnalysisResults',
-  full_name='CommitsAnalysisResults',
+_LEADTIMEANALYSISRESULTS = _descriptor.Descriptor(
+  name='LeadTimeAnalysisResults',
+  full_name='LeadTimeAnalysisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='commits', full_name='CommitsAnalysisResults.commits', index=0,
+      name='ticks', full_name='LeadTimeAnalysisResults.ticks', index=0,
       number=1, type=11, cpp_type=10, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='author_index', full_name='CommitsAnalysisResults.author_index', index=1,
-      number=2, type=9, cpp_type=9, label=3,
-      has_default_value=False, default_value=[],
+      name='tick_size', full_name='LeadTimeAnalysisResults.tick_size', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
@@ -1426,53 +3763,60 @@ _COMMITSANALYSISRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2827,
-  serialized_end=2899,
+  serialized_start=7751,
+  serialized_end=7830,
 )
 
 
-_TYPO = _descriptor.Descriptor(
-  name='Typo',
-  full_name='Typo',
+_RESURRECTION = _descriptor.Descriptor(
+  name='Resurrection',
+  full_name='Resurrection',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='wrong', full_name='Typo.wrong', index=0,
+      name='path', full_name='Resurrection.path', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=_b("").decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='correct', full_name='Typo.correct', index=1,
-      number=2, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
+      name='deletion_tick', full_name='Resurrection.deletion_tick', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='commit', full_name='Typo.commit', index=2,
-      number=3, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
+      name='deletion_author', full_name='Resurrection.deletion_author', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='file', full_name='Typo.file', index=3,
-      number=4, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
+      name='resurrection_tick', full_name='Resurrection.resurrection_tick', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='line', full_name='Typo.line', index=4,
+      name='resurrection_author', full_name='Resurrection.resurrection_author', index=4,
       number=5, type=5, cpp_type=1, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='rename_back', full_name='Resurrection.rename_back', index=5,
+      number=6, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
@@ -1485,25 +3829,39 @@ _TYPO = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2901,
-  serialized_end=2983,
+  serialized_start=7833,
+  serialized_end=7986,
 )
 
 
-_TYPOSDATASET = _descriptor.Descriptor(
-  name='TyposDataset',
-  full_name='TyposDataset',
+_RESURRECTIONANALYSISRESULTS = _descriptor.Descriptor(
+  name='ResurrectionAnalysisResults',
+  full_name='ResurrectionAnalysisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='typos', full_name='TyposDataset.typos', index=0,
+      name='resurrections', full_name='ResurrectionAnalysisResults.resurrections', index=0,
       number=1, type=11, cpp_type=10, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='author_index', full_name='ResurrectionAnalysisResults.author_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='ResurrectionAnalysisResults.tick_size', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
@@ -1516,28 +3874,42 @@ _TYPOSDATASET = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2985,
-  serialized_end=3021,
+  serialized_start=7988,
+  serialized_end=8096,
 )
 
 
-_IMPORTSPERTICK_COUNTSENTRY = _descriptor.Descriptor(
-  name='CountsEntry',
-  full_name='ImportsPerTick.CountsEntry',
+_OWNERSHIPTRANSFER = _descriptor.Descriptor(
+  name='OwnershipTransfer',
+  full_name='OwnershipTransfer',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='key', full_name='ImportsPerTick.CountsEntry.key', index=0,
-      number=1, type=5, cpp_type=1, label=1,
+      name='path', full_name='OwnershipTransfer.path', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick', full_name='OwnershipTransfer.tick', index=1,
+      number=2, type=5, cpp_type=1, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='value', full_name='ImportsPerTick.CountsEntry.value', index=1,
-      number=2, type=3, cpp_type=2, label=1,
+      name='from_author', full_name='OwnershipTransfer.from_author', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='to_author', full_name='OwnershipTransfer.to_author', index=3,
+      number=4, type=5, cpp_type=1, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -1548,34 +3920,49 @@ _IMPORTSPERTICK_COUNTSENTRY = _descriptor.Descriptor(
   nested_types=[],
   enum_types=[
   ],
-  serialized_options=_b('8\001'),
+  serialized_options=None,
   is_extendable=False,
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3086,
-  serialized_end=3131,
+  serialized_start=8098,
+  serialized_end=8185,
 )
 
-_IMPORTSPERTICK = _descriptor.Descriptor(
-  name='ImportsPerTick',
-  full_name='ImportsPerTick',
+
+_OWNERSHIPTRANSFERANALYSISRESULTS = _descriptor.Descriptor(
+  name='OwnershipTransferAnalysisResults',
+  full_name='OwnershipTransferAnalysisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='counts', full_name='ImportsPerTick.counts', index=0,
+      name='transfers', full_name='OwnershipTransferAnalysisResults.transfers', index=0,
       number=1, type=11, cpp_type=10, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='author_index', full_name='OwnershipTransferAnalysisResults.author_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='OwnershipTransferAnalysisResults.tick_size', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
-  nested_types=[_IMPORTSPERTICK_COUNTSENTRY, ],
+  nested_types=[],
   enum_types=[
   ],
   serialized_options=None,
@@ -1584,29 +3971,50 @@ _IMPORTSPERTICK = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3023,
-  serialized_end=3131,
+  serialized_start=8187,
+  serialized_end=8301,
 )
 
 
-_IMPORTSPERLANGUAGE_TICKSENTRY = _descriptor.Descriptor(
-  name='TicksEntry',
-  full_name='ImportsPerLanguage.TicksEntry',
+_REFACTOREVENT = _descriptor.Descriptor(
+  name='RefactorEvent',
+  full_name='RefactorEvent',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='key', full_name='ImportsPerLanguage.TicksEntry.key', index=0,
+      name='commit', full_name='RefactorEvent.commit', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=_b("").decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='value', full_name='ImportsPerLanguage.TicksEntry.value', index=1,
-      number=2, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='tick', full_name='RefactorEvent.tick', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='renames', full_name='RefactorEvent.renames', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='changes', full_name='RefactorEvent.changes', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='author', full_name='RefactorEvent.author', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
@@ -1616,34 +4024,49 @@ _IMPORTSPERLANGUAGE_TICKSENTRY = _descriptor.Descriptor(
   nested_types=[],
   enum_types=[
   ],
-  serialized_options=_b('8\001'),
+  serialized_options=None,
   is_extendable=False,
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3203,
-  serialized_end=3264,
+  serialized_start=8303,
+  serialized_end=8398,
 )
 
-_IMPORTSPERLANGUAGE = _descriptor.Descriptor(
-  name='ImportsPerLanguage',
-  full_name='ImportsPerLanguage',
+
+_REFACTORDETECTIONANALYSISRESULTS = _descriptor.Descriptor(
+  name='RefactorDetectionAnalysisResults',
+  full_name='RefactorDetectionAnalysisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='ticks', full_name='ImportsPerLanguage.ticks', index=0,
+      name='events', full_name='RefactorDetectionAnalysisResults.events', index=0,
       number=1, type=11, cpp_type=10, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='author_index', full_name='RefactorDetectionAnalysisResults.author_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tick_size', full_name='RefactorDetectionAnalysisResults.tick_size', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
-  nested_types=[_IMPORTSPERLANGUAGE_TICKSENTRY, ],
+  nested_types=[],
   enum_types=[
   ],
   serialized_options=None,
@@ -1652,29 +4075,29 @@ _IMPORTSPERLANGUAGE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3134,
-  serialized_end=3264,
+  serialized_start=8400,
+  serialized_end=8507,
 )
 
 
-_IMPORTSPERDEVELOPER_LANGUAGESENTRY = _descriptor.Descriptor(
-  name='LanguagesEntry',
-  full_name='ImportsPerDeveloper.LanguagesEntry',
+_IMPORTCOUPLINGANALYSISRESULTS = _descriptor.Descriptor(
+  name='ImportCouplingAnalysisResults',
+  full_name='ImportCouplingAnalysisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='key', full_name='ImportsPerDeveloper.LanguagesEntry.key', index=0,
-      number=1, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
+      name='files', full_name='ImportCouplingAnalysisResults.files', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='value', full_name='ImportsPerDeveloper.LanguagesEntry.value', index=1,
-      number=2, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='imports', full_name='ImportCouplingAnalysisResults.imports', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
@@ -1684,34 +4107,63 @@ _IMPORTSPERDEVELOPER_LANGUAGESENTRY = _descriptor.Descriptor(
   nested_types=[],
   enum_types=[
   ],
-  serialized_options=_b('8\001'),
+  serialized_options=None,
   is_extendable=False,
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3346,
-  serialized_end=3415,
+  serialized_start=8509,
+  serialized_end=8587,
 )
 
-_IMPORTSPERDEVELOPER = _descriptor.Descriptor(
-  name='ImportsPerDeveloper',
-  full_name='ImportsPerDeveloper',
+
+_LINEEVENT = _descriptor.Descriptor(
+  name='LineEvent',
+  full_name='LineEvent',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='languages', full_name='ImportsPerDeveloper.languages', index=0,
-      number=1, type=11, cpp_type=10, label=3,
-      has_default_value=False, default_value=[],
+      name='tick', full_name='LineEvent.tick', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='author', full_name='LineEvent.author', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='LineEvent.file', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='added', full_name='LineEvent.added', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='LineEvent.removed', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
-  nested_types=[_IMPORTSPERDEVELOPER_LANGUAGESENTRY, ],
+  nested_types=[],
   enum_types=[
   ],
   serialized_options=None,
@@ -1720,35 +4172,28 @@ _IMPORTSPERDEVELOPER = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3267,
-  serialized_end=3415,
+  serialized_start=8589,
+  serialized_end=8676,
 )
 
 
-_IMPORTSPERDEVELOPERRESULTS = _descriptor.Descriptor(
-  name='ImportsPerDeveloperResults',
-  full_name='ImportsPerDeveloperResults',
+_LINEEVENTSANALYSISRESULTS = _descriptor.Descriptor(
+  name='LineEventsAnalysisResults',
+  full_name='LineEventsAnalysisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='imports', full_name='ImportsPerDeveloperResults.imports', index=0,
-      number=1, type=11, cpp_type=10, label=3,
-      has_default_value=False, default_value=[],
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      serialized_options=None, file=DESCRIPTOR),
-    _descriptor.FieldDescriptor(
-      name='author_index', full_name='ImportsPerDeveloperResults.author_index', index=1,
-      number=2, type=9, cpp_type=9, label=3,
+      name='author_index', full_name='LineEventsAnalysisResults.author_index', index=0,
+      number=1, type=9, cpp_type=9, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
     _descriptor.FieldDescriptor(
-      name='tick_size', full_name='ImportsPerDeveloperResults.tick_size', index=2,
-      number=3, type=3, cpp_type=2, label=1,
+      name='tick_size', full_name='LineEventsAnalysisResults.tick_size', index=1,
+      number=2, type=3, cpp_type=2, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -1765,8 +4210,8 @@ _IMPORTSPERDEVELOPERRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3417,
-  serialized_end=3525,
+  serialized_start=8678,
+  serialized_end=8746,
 )
 
 
@@ -1803,8 +4248,8 @@ _ANALYSISRESULTS_CONTENTSENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3624,
-  serialized_end=3671,
+  serialized_start=8845,
+  serialized_end=8892,
 )
 
 _ANALYSISRESULTS = _descriptor.Descriptor(
@@ -1840,12 +4285,14 @@ _ANALYSISRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3528,
-  serialized_end=3671,
+  serialized_start=8749,
+  serialized_end=8892,
 )
 
 _METADATA_RUNTIMEPERITEMENTRY.containing_type = _METADATA
+_METADATA_CONFIGENTRY.containing_type = _METADATA
 _METADATA.fields_by_name['run_time_per_item'].message_type = _METADATA_RUNTIMEPERITEMENTRY
+_METADATA.fields_by_name['config'].message_type = _METADATA_CONFIGENTRY
 _BURNDOWNSPARSEMATRIX.fields_by_name['rows'].message_type = _BURNDOWNSPARSEMATRIXROW
 _FILESOWNERSHIP_VALUEENTRY.containing_type = _FILESOWNERSHIP
 _FILESOWNERSHIP.fields_by_name['value'].message_type = _FILESOWNERSHIP_VALUEENTRY
@@ -1854,10 +4301,15 @@ _BURNDOWNANALYSISRESULTS.fields_by_name['files'].message_type = _BURNDOWNSPARSEM
 _BURNDOWNANALYSISRESULTS.fields_by_name['people'].message_type = _BURNDOWNSPARSEMATRIX
 _BURNDOWNANALYSISRESULTS.fields_by_name['people_interaction'].message_type = _COMPRESSEDSPARSEROWMATRIX
 _BURNDOWNANALYSISRESULTS.fields_by_name['files_ownership'].message_type = _FILESOWNERSHIP
+_BURNDOWNANALYSISRESULTS.fields_by_name['active_authors'].message_type = _BURNDOWNSPARSEMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['commit_counts'].message_type = _BURNDOWNSPARSEMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['old_vs_new'].message_type = _BURNDOWNSPARSEMATRIX
 _COUPLES.fields_by_name['matrix'].message_type = _COMPRESSEDSPARSEROWMATRIX
 _COUPLESANALYSISRESULTS.fields_by_name['file_couples'].message_type = _COUPLES
 _COUPLESANALYSISRESULTS.fields_by_name['people_couples'].message_type = _COUPLES
 _COUPLESANALYSISRESULTS.fields_by_name['people_files'].message_type = _TOUCHEDFILES
+_COUPLESANALYSISRESULTS.fields_by_name['files_jaccard'].message_type = _COMPRESSEDSPARSEROWFLOATMATRIX
+_COUPLESANALYSISRESULTS.fields_by_name['files_embeddings'].message_type = _FILEEMBEDDING
 _UASTCHANGESSAVERRESULTS.fields_by_name['changes'].message_type = _UASTCHANGE
 _SHOTNESSRECORD_COUNTERSENTRY.containing_type = _SHOTNESSRECORD
 _SHOTNESSRECORD.fields_by_name['counters'].message_type = _SHOTNESSRECORD_COUNTERSENTRY
@@ -1868,6 +4320,15 @@ _FILEHISTORY.fields_by_name['changes_by_developer'].message_type = _FILEHISTORY_
 _FILEHISTORYRESULTMESSAGE_FILESENTRY.fields_by_name['value'].message_type = _FILEHISTORY
 _FILEHISTORYRESULTMESSAGE_FILESENTRY.containing_type = _FILEHISTORYRESULTMESSAGE
 _FILEHISTORYRESULTMESSAGE.fields_by_name['files'].message_type = _FILEHISTORYRESULTMESSAGE_FILESENTRY
+_FILEGENESISRESULTS_FILESENTRY.fields_by_name['value'].message_type = _FILEGENESIS
+_FILEGENESISRESULTS_FILESENTRY.containing_type = _FILEGENESISRESULTS
+_FILEGENESISRESULTS.fields_by_name['files'].message_type = _FILEGENESISRESULTS_FILESENTRY
+_HOTSPOTSANALYSISRESULTS.fields_by_name['files'].message_type = _HOTSPOT
+_BUSFACTORANALYSISRESULTS.fields_by_name['files'].message_type = _BUSFACTOR
+_BUSFACTORANALYSISRESULTS.fields_by_name['directories'].message_type = _BUSFACTOR
+_COMMITSIZEANALYSISRESULTS_TICKSENTRY.fields_by_name['value'].message_type = _COMMITSIZETICK
+_COMMITSIZEANALYSISRESULTS_TICKSENTRY.containing_type = _COMMITSIZEANALYSISRESULTS
+_COMMITSIZEANALYSISRESULTS.fields_by_name['ticks'].message_type = _COMMITSIZEANALYSISRESULTS_TICKSENTRY
 _DEVTICK_LANGUAGESENTRY.fields_by_name['value'].message_type = _LINESTATS
 _DEVTICK_LANGUAGESENTRY.containing_type = _DEVTICK
 _DEVTICK.fields_by_name['stats'].message_type = _LINESTATS
@@ -1894,6 +4355,48 @@ _IMPORTSPERDEVELOPER_LANGUAGESENTRY.fields_by_name['value'].message_type = _IMPO
 _IMPORTSPERDEVELOPER_LANGUAGESENTRY.containing_type = _IMPORTSPERDEVELOPER
 _IMPORTSPERDEVELOPER.fields_by_name['languages'].message_type = _IMPORTSPERDEVELOPER_LANGUAGESENTRY
 _IMPORTSPERDEVELOPERRESULTS.fields_by_name['imports'].message_type = _IMPORTSPERDEVELOPER
+_DEVCADENCEANALYSISRESULTS_DEVELOPERSENTRY.fields_by_name['value'].message_type = _DEVCADENCE
+_DEVCADENCEANALYSISRESULTS_DEVELOPERSENTRY.containing_type = _DEVCADENCEANALYSISRESULTS
+_DEVCADENCEANALYSISRESULTS.fields_by_name['developers'].message_type = _DEVCADENCEANALYSISRESULTS_DEVELOPERSENTRY
+_DEVFOCUSTIMELINE_TICKSENTRY.fields_by_name['value'].message_type = _DEVFOCUS
+_DEVFOCUSTIMELINE_TICKSENTRY.containing_type = _DEVFOCUSTIMELINE
+_DEVFOCUSTIMELINE.fields_by_name['total'].message_type = _DEVFOCUS
+_DEVFOCUSTIMELINE.fields_by_name['ticks'].message_type = _DEVFOCUSTIMELINE_TICKSENTRY
+_DEVFOCUSANALYSISRESULTS_DEVELOPERSENTRY.fields_by_name['value'].message_type = _DEVFOCUSTIMELINE
+_DEVFOCUSANALYSISRESULTS_DEVELOPERSENTRY.containing_type = _DEVFOCUSANALYSISRESULTS
+_DEVFOCUSANALYSISRESULTS.fields_by_name['developers'].message_type = _DEVFOCUSANALYSISRESULTS_DEVELOPERSENTRY
+_DEVMERGERATIOTICKS_TICKSENTRY.fields_by_name['value'].message_type = _DEVMERGECOUNTS
+_DEVMERGERATIOTICKS_TICKSENTRY.containing_type = _DEVMERGERATIOTICKS
+_DEVMERGERATIOTICKS.fields_by_name['ticks'].message_type = _DEVMERGERATIOTICKS_TICKSENTRY
+_DEVMERGERATIOANALYSISRESULTS_DEVELOPERSENTRY.fields_by_name['value'].message_type = _DEVMERGERATIOTICKS
+_DEVMERGERATIOANALYSISRESULTS_DEVELOPERSENTRY.containing_type = _DEVMERGERATIOANALYSISRESULTS
+_DEVMERGERATIOANALYSISRESULTS.fields_by_name['developers'].message_type = _DEVMERGERATIOANALYSISRESULTS_DEVELOPERSENTRY
+_PUNCHCARDANALYSISRESULTS_DEVELOPERSENTRY.fields_by_name['value'].message_type = _PUNCHCARD
+_PUNCHCARDANALYSISRESULTS_DEVELOPERSENTRY.containing_type = _PUNCHCARDANALYSISRESULTS
+_PUNCHCARDANALYSISRESULTS.fields_by_name['total'].message_type = _PUNCHCARD
+_PUNCHCARDANALYSISRESULTS.fields_by_name['developers'].message_type = _PUNCHCARDANALYSISRESULTS_DEVELOPERSENTRY
+_DIRECTORYOWNERSHIP_LINESENTRY.containing_type = _DIRECTORYOWNERSHIP
+_DIRECTORYOWNERSHIP.fields_by_name['lines'].message_type = _DIRECTORYOWNERSHIP_LINESENTRY
+_TICKDIRECTORYOWNERSHIP_DIRECTORIESENTRY.fields_by_name['value'].message_type = _DIRECTORYOWNERSHIP
+_TICKDIRECTORYOWNERSHIP_DIRECTORIESENTRY.containing_type = _TICKDIRECTORYOWNERSHIP
+_TICKDIRECTORYOWNERSHIP.fields_by_name['directories'].message_type = _TICKDIRECTORYOWNERSHIP_DIRECTORIESENTRY
+_DIRECTORYOWNERSHIPANALYSISRESULTS_TICKSENTRY.fields_by_name['value'].message_type = _TICKDIRECTORYOWNERSHIP
+_DIRECTORYOWNERSHIPANALYSISRESULTS_TICKSENTRY.containing_type = _DIRECTORYOWNERSHIPANALYSISRESULTS
+_DIRECTORYOWNERSHIPANALYSISRESULTS.fields_by_name['ticks'].message_type = _DIRECTORYOWNERSHIPANALYSISRESULTS_TICKSENTRY
+_CODEAGEANALYSISRESULTS_AGESENTRY.containing_type = _CODEAGEANALYSISRESULTS
+_CODEAGEANALYSISRESULTS_FILEMEDIANSENTRY.containing_type = _CODEAGEANALYSISRESULTS
+_CODEAGEANALYSISRESULTS.fields_by_name['ages'].message_type = _CODEAGEANALYSISRESULTS_AGESENTRY
+_CODEAGEANALYSISRESULTS.fields_by_name['file_medians'].message_type = _CODEAGEANALYSISRESULTS_FILEMEDIANSENTRY
+_COMMENTRATIOANALYSISRESULTS_LANGUAGESENTRY.fields_by_name['value'].message_type = _COMMENTRATIOSERIES
+_COMMENTRATIOANALYSISRESULTS_LANGUAGESENTRY.containing_type = _COMMENTRATIOANALYSISRESULTS
+_COMMENTRATIOANALYSISRESULTS.fields_by_name['languages'].message_type = _COMMENTRATIOANALYSISRESULTS_LANGUAGESENTRY
+_LEADTIMEHISTOGRAM_DELAYSENTRY.containing_type = _LEADTIMEHISTOGRAM
+_LEADTIMEHISTOGRAM.fields_by_name['delays'].message_type = _LEADTIMEHISTOGRAM_DELAYSENTRY
+_LEADTIMEANALYSISRESULTS.fields_by_name['ticks'].message_type = _LEADTIMEHISTOGRAM
+_RESURRECTIONANALYSISRESULTS.fields_by_name['resurrections'].message_type = _RESURRECTION
+_OWNERSHIPTRANSFERANALYSISRESULTS.fields_by_name['transfers'].message_type = _OWNERSHIPTRANSFER
+_REFACTORDETECTIONANALYSISRESULTS.fields_by_name['events'].message_type = _REFACTOREVENT
+_IMPORTCOUPLINGANALYSISRESULTS.fields_by_name['imports'].message_type = _TOUCHEDFILES
 _ANALYSISRESULTS_CONTENTSENTRY.containing_type = _ANALYSISRESULTS
 _ANALYSISRESULTS.fields_by_name['header'].message_type = _METADATA
 _ANALYSISRESULTS.fields_by_name['contents'].message_type = _ANALYSISRESULTS_CONTENTSENTRY
@@ -1903,15 +4406,26 @@ DESCRIPTOR.message_types_by_name['BurndownSparseMatrix'] = _BURNDOWNSPARSEMATRIX
 DESCRIPTOR.message_types_by_name['FilesOwnership'] = _FILESOWNERSHIP
 DESCRIPTOR.message_types_by_name['BurndownAnalysisResults'] = _BURNDOWNANALYSISRESULTS
 DESCRIPTOR.message_types_by_name['CompressedSparseRowMatrix'] = _COMPRESSEDSPARSEROWMATRIX
+DESCRIPTOR.message_types_by_name['CompressedSparseRowFloatMatrix'] = _COMPRESSEDSPARSEROWFLOATMATRIX
 DESCRIPTOR.message_types_by_name['Couples'] = _COUPLES
 DESCRIPTOR.message_types_by_name['TouchedFiles'] = _TOUCHEDFILES
 DESCRIPTOR.message_types_by_name['CouplesAnalysisResults'] = _COUPLESANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['FileEmbedding'] = _FILEEMBEDDING
 DESCRIPTOR.message_types_by_name['UASTChange'] = _UASTCHANGE
 DESCRIPTOR.message_types_by_name['UASTChangesSaverResults'] = _UASTCHANGESSAVERRESULTS
 DESCRIPTOR.message_types_by_name['ShotnessRecord'] = _SHOTNESSRECORD
 DESCRIPTOR.message_types_by_name['ShotnessAnalysisResults'] = _SHOTNESSANALYSISRESULTS
 DESCRIPTOR.message_types_by_name['FileHistory'] = _FILEHISTORY
 DESCRIPTOR.message_types_by_name['FileHistoryResultMessage'] = _FILEHISTORYRESULTMESSAGE
+DESCRIPTOR.message_types_by_name['BinaryChurnAnalysisResults'] = _BINARYCHURNANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['FileGenesis'] = _FILEGENESIS
+DESCRIPTOR.message_types_by_name['FileGenesisResults'] = _FILEGENESISRESULTS
+DESCRIPTOR.message_types_by_name['Hotspot'] = _HOTSPOT
+DESCRIPTOR.message_types_by_name['HotspotsAnalysisResults'] = _HOTSPOTSANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['BusFactor'] = _BUSFACTOR
+DESCRIPTOR.message_types_by_name['BusFactorAnalysisResults'] = _BUSFACTORANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['CommitSizeTick'] = _COMMITSIZETICK
+DESCRIPTOR.message_types_by_name['CommitSizeAnalysisResults'] = _COMMITSIZEANALYSISRESULTS
 DESCRIPTOR.message_types_by_name['LineStats'] = _LINESTATS
 DESCRIPTOR.message_types_by_name['DevTick'] = _DEVTICK
 DESCRIPTOR.message_types_by_name['TickDevs'] = _TICKDEVS
@@ -1927,6 +4441,34 @@ DESCRIPTOR.message_types_by_name['ImportsPerTick'] = _IMPORTSPERTICK
 DESCRIPTOR.message_types_by_name['ImportsPerLanguage'] = _IMPORTSPERLANGUAGE
 DESCRIPTOR.message_types_by_name['ImportsPerDeveloper'] = _IMPORTSPERDEVELOPER
 DESCRIPTOR.message_types_by_name['ImportsPerDeveloperResults'] = _IMPORTSPERDEVELOPERRESULTS
+DESCRIPTOR.message_types_by_name['DevCadence'] = _DEVCADENCE
+DESCRIPTOR.message_types_by_name['DevCadenceAnalysisResults'] = _DEVCADENCEANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['DevFocus'] = _DEVFOCUS
+DESCRIPTOR.message_types_by_name['DevFocusTimeline'] = _DEVFOCUSTIMELINE
+DESCRIPTOR.message_types_by_name['DevFocusAnalysisResults'] = _DEVFOCUSANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['DevMergeCounts'] = _DEVMERGECOUNTS
+DESCRIPTOR.message_types_by_name['DevMergeRatioTicks'] = _DEVMERGERATIOTICKS
+DESCRIPTOR.message_types_by_name['DevMergeRatioAnalysisResults'] = _DEVMERGERATIOANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['Punchcard'] = _PUNCHCARD
+DESCRIPTOR.message_types_by_name['PunchcardAnalysisResults'] = _PUNCHCARDANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['DirectoryOwnership'] = _DIRECTORYOWNERSHIP
+DESCRIPTOR.message_types_by_name['TickDirectoryOwnership'] = _TICKDIRECTORYOWNERSHIP
+DESCRIPTOR.message_types_by_name['DirectoryOwnershipAnalysisResults'] = _DIRECTORYOWNERSHIPANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['CodeAgeAnalysisResults'] = _CODEAGEANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['TestRatioAnalysisResults'] = _TESTRATIOANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['CommentRatioSeries'] = _COMMENTRATIOSERIES
+DESCRIPTOR.message_types_by_name['CommentRatioAnalysisResults'] = _COMMENTRATIOANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['LeadTimeHistogram'] = _LEADTIMEHISTOGRAM
+DESCRIPTOR.message_types_by_name['LeadTimeAnalysisResults'] = _LEADTIMEANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['Resurrection'] = _RESURRECTION
+DESCRIPTOR.message_types_by_name['ResurrectionAnalysisResults'] = _RESURRECTIONANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['OwnershipTransfer'] = _OWNERSHIPTRANSFER
+DESCRIPTOR.message_types_by_name['OwnershipTransferAnalysisResults'] = _OWNERSHIPTRANSFERANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['RefactorEvent'] = _REFACTOREVENT
+DESCRIPTOR.message_types_by_name['RefactorDetectionAnalysisResults'] = _REFACTORDETECTIONANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['ImportCouplingAnalysisResults'] = _IMPORTCOUPLINGANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['LineEvent'] = _LINEEVENT
+DESCRIPTOR.message_types_by_name['LineEventsAnalysisResults'] = _LINEEVENTSANALYSISRESULTS
 DESCRIPTOR.message_types_by_name['AnalysisResults'] = _ANALYSISRESULTS
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
 
@@ -1938,12 +4480,20 @@ Metadata = _reflection.GeneratedProtocolMessageType('Metadata', (_message.Messag
     # @@protoc_insertion_point(class_scope:Metadata.RunTimePerItemEntry)
     ))
   ,
+
+  ConfigEntry = _reflection.GeneratedProtocolMessageType('ConfigEntry', (_message.Message,), dict(
+    DESCRIPTOR = _METADATA_CONFIGENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:Metadata.ConfigEntry)
+    ))
+  ,
   DESCRIPTOR = _METADATA,
   __module__ = 'pb_pb2'
   # @@protoc_insertion_point(class_scope:Metadata)
   ))
 _sym_db.RegisterMessage(Metadata)
 _sym_db.RegisterMessage(Metadata.RunTimePerItemEntry)
+_sym_db.RegisterMessage(Metadata.ConfigEntry)
 
 BurndownSparseMatrixRow = _reflection.GeneratedProtocolMessageType('BurndownSparseMatrixRow', (_message.Message,), dict(
   DESCRIPTOR = _BURNDOWNSPARSEMATRIXROW,
@@ -1988,6 +4538,13 @@ CompressedSparseRowMatrix = _reflection.GeneratedProtocolMessageType('Compressed
   ))
 _sym_db.RegisterMessage(CompressedSparseRowMatrix)
 
+CompressedSparseRowFloatMatrix = _reflection.GeneratedProtocolMessageType('CompressedSparseRowFloatMatrix', (_message.Message,), dict(
+  DESCRIPTOR = _COMPRESSEDSPARSEROWFLOATMATRIX,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CompressedSparseRowFloatMatrix)
+  ))
+_sym_db.RegisterMessage(CompressedSparseRowFloatMatrix)
+
 Couples = _reflection.GeneratedProtocolMessageType('Couples', (_message.Message,), dict(
   DESCRIPTOR = _COUPLES,
   __module__ = 'pb_pb2'
@@ -2009,6 +4566,13 @@ CouplesAnalysisResults = _reflection.GeneratedProtocolMessageType('CouplesAnalys
   ))
 _sym_db.RegisterMessage(CouplesAnalysisResults)
 
+FileEmbedding = _reflection.GeneratedProtocolMessageType('FileEmbedding', (_message.Message,), dict(
+  DESCRIPTOR = _FILEEMBEDDING,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FileEmbedding)
+  ))
+_sym_db.RegisterMessage(FileEmbedding)
+
 UASTChange = _reflection.GeneratedProtocolMessageType('UASTChange', (_message.Message,), dict(
   DESCRIPTOR = _UASTCHANGE,
   __module__ = 'pb_pb2'
@@ -2075,6 +4639,85 @@ FileHistoryResultMessage = _reflection.GeneratedProtocolMessageType('FileHistory
 _sym_db.RegisterMessage(FileHistoryResultMessage)
 _sym_db.RegisterMessage(FileHistoryResultMessage.FilesEntry)
 
+BinaryChurnAnalysisResults = _reflection.GeneratedProtocolMessageType('BinaryChurnAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _BINARYCHURNANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BinaryChurnAnalysisResults)
+  ))
+_sym_db.RegisterMessage(BinaryChurnAnalysisResults)
+
+FileGenesis = _reflection.GeneratedProtocolMessageType('FileGenesis', (_message.Message,), dict(
+  DESCRIPTOR = _FILEGENESIS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FileGenesis)
+  ))
+_sym_db.RegisterMessage(FileGenesis)
+
+FileGenesisResults = _reflection.GeneratedProtocolMessageType('FileGenesisResults', (_message.Message,), dict(
+
+  FilesEntry = _reflection.GeneratedProtocolMessageType('FilesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _FILEGENESISRESULTS_FILESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:FileGenesisResults.FilesEntry)
+    ))
+  ,
+  DESCRIPTOR = _FILEGENESISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FileGenesisResults)
+  ))
+_sym_db.RegisterMessage(FileGenesisResults)
+_sym_db.RegisterMessage(FileGenesisResults.FilesEntry)
+
+Hotspot = _reflection.GeneratedProtocolMessageType('Hotspot', (_message.Message,), dict(
+  DESCRIPTOR = _HOTSPOT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Hotspot)
+  ))
+_sym_db.RegisterMessage(Hotspot)
+
+HotspotsAnalysisResults = _reflection.GeneratedProtocolMessageType('HotspotsAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _HOTSPOTSANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:HotspotsAnalysisResults)
+  ))
+_sym_db.RegisterMessage(HotspotsAnalysisResults)
+
+BusFactor = _reflection.GeneratedProtocolMessageType('BusFactor', (_message.Message,), dict(
+  DESCRIPTOR = _BUSFACTOR,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BusFactor)
+  ))
+_sym_db.RegisterMessage(BusFactor)
+
+BusFactorAnalysisResults = _reflection.GeneratedProtocolMessageType('BusFactorAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _BUSFACTORANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BusFactorAnalysisResults)
+  ))
+_sym_db.RegisterMessage(BusFactorAnalysisResults)
+
+CommitSizeTick = _reflection.GeneratedProtocolMessageType('CommitSizeTick', (_message.Message,), dict(
+  DESCRIPTOR = _COMMITSIZETICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommitSizeTick)
+  ))
+_sym_db.RegisterMessage(CommitSizeTick)
+
+CommitSizeAnalysisResults = _reflection.GeneratedProtocolMessageType('CommitSizeAnalysisResults', (_message.Message,), dict(
+
+  TicksEntry = _reflection.GeneratedProtocolMessageType('TicksEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMMITSIZEANALYSISRESULTS_TICKSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CommitSizeAnalysisResults.TicksEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMMITSIZEANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommitSizeAnalysisResults)
+  ))
+_sym_db.RegisterMessage(CommitSizeAnalysisResults)
+_sym_db.RegisterMessage(CommitSizeAnalysisResults.TicksEntry)
+
 LineStats = _reflection.GeneratedProtocolMessageType('LineStats', (_message.Message,), dict(
   DESCRIPTOR = _LINESTATS,
   __module__ = 'pb_pb2'
@@ -2236,6 +4879,306 @@ ImportsPerDeveloperResults = _reflection.GeneratedProtocolMessageType('ImportsPe
   ))
 _sym_db.RegisterMessage(ImportsPerDeveloperResults)
 
+DevCadence = _reflection.GeneratedProtocolMessageType('DevCadence', (_message.Message,), dict(
+  DESCRIPTOR = _DEVCADENCE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DevCadence)
+  ))
+_sym_db.RegisterMessage(DevCadence)
+
+DevCadenceAnalysisResults = _reflection.GeneratedProtocolMessageType('DevCadenceAnalysisResults', (_message.Message,), dict(
+
+  DevelopersEntry = _reflection.GeneratedProtocolMessageType('DevelopersEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DEVCADENCEANALYSISRESULTS_DEVELOPERSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DevCadenceAnalysisResults.DevelopersEntry)
+    ))
+  ,
+  DESCRIPTOR = _DEVCADENCEANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DevCadenceAnalysisResults)
+  ))
+_sym_db.RegisterMessage(DevCadenceAnalysisResults)
+_sym_db.RegisterMessage(DevCadenceAnalysisResults.DevelopersEntry)
+
+DevFocus = _reflection.GeneratedProtocolMessageType('DevFocus', (_message.Message,), dict(
+  DESCRIPTOR = _DEVFOCUS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DevFocus)
+  ))
+_sym_db.RegisterMessage(DevFocus)
+
+DevFocusTimeline = _reflection.GeneratedProtocolMessageType('DevFocusTimeline', (_message.Message,), dict(
+
+  TicksEntry = _reflection.GeneratedProtocolMessageType('TicksEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DEVFOCUSTIMELINE_TICKSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DevFocusTimeline.TicksEntry)
+    ))
+  ,
+  DESCRIPTOR = _DEVFOCUSTIMELINE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DevFocusTimeline)
+  ))
+_sym_db.RegisterMessage(DevFocusTimeline)
+_sym_db.RegisterMessage(DevFocusTimeline.TicksEntry)
+
+DevFocusAnalysisResults = _reflection.GeneratedProtocolMessageType('DevFocusAnalysisResults', (_message.Message,), dict(
+
+  DevelopersEntry = _reflection.GeneratedProtocolMessageType('DevelopersEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DEVFOCUSANALYSISRESULTS_DEVELOPERSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DevFocusAnalysisResults.DevelopersEntry)
+    ))
+  ,
+  DESCRIPTOR = _DEVFOCUSANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DevFocusAnalysisResults)
+  ))
+_sym_db.RegisterMessage(DevFocusAnalysisResults)
+_sym_db.RegisterMessage(DevFocusAnalysisResults.DevelopersEntry)
+
+DevMergeCounts = _reflection.GeneratedProtocolMessageType('DevMergeCounts', (_message.Message,), dict(
+  DESCRIPTOR = _DEVMERGECOUNTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DevMergeCounts)
+  ))
+_sym_db.RegisterMessage(DevMergeCounts)
+
+DevMergeRatioTicks = _reflection.GeneratedProtocolMessageType('DevMergeRatioTicks', (_message.Message,), dict(
+
+  TicksEntry = _reflection.GeneratedProtocolMessageType('TicksEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DEVMERGERATIOTICKS_TICKSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DevMergeRatioTicks.TicksEntry)
+    ))
+  ,
+  DESCRIPTOR = _DEVMERGERATIOTICKS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DevMergeRatioTicks)
+  ))
+_sym_db.RegisterMessage(DevMergeRatioTicks)
+_sym_db.RegisterMessage(DevMergeRatioTicks.TicksEntry)
+
+DevMergeRatioAnalysisResults = _reflection.GeneratedProtocolMessageType('DevMergeRatioAnalysisResults', (_message.Message,), dict(
+
+  DevelopersEntry = _reflection.GeneratedProtocolMessageType('DevelopersEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DEVMERGERATIOANALYSISRESULTS_DEVELOPERSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DevMergeRatioAnalysisResults.DevelopersEntry)
+    ))
+  ,
+  DESCRIPTOR = _DEVMERGERATIOANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DevMergeRatioAnalysisResults)
+  ))
+_sym_db.RegisterMessage(DevMergeRatioAnalysisResults)
+_sym_db.RegisterMessage(DevMergeRatioAnalysisResults.DevelopersEntry)
+
+Punchcard = _reflection.GeneratedProtocolMessageType('Punchcard', (_message.Message,), dict(
+  DESCRIPTOR = _PUNCHCARD,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Punchcard)
+  ))
+_sym_db.RegisterMessage(Punchcard)
+
+PunchcardAnalysisResults = _reflection.GeneratedProtocolMessageType('PunchcardAnalysisResults', (_message.Message,), dict(
+
+  DevelopersEntry = _reflection.GeneratedProtocolMessageType('DevelopersEntry', (_message.Message,), dict(
+    DESCRIPTOR = _PUNCHCARDANALYSISRESULTS_DEVELOPERSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:PunchcardAnalysisResults.DevelopersEntry)
+    ))
+  ,
+  DESCRIPTOR = _PUNCHCARDANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:PunchcardAnalysisResults)
+  ))
+_sym_db.RegisterMessage(PunchcardAnalysisResults)
+_sym_db.RegisterMessage(PunchcardAnalysisResults.DevelopersEntry)
+
+DirectoryOwnership = _reflection.GeneratedProtocolMessageType('DirectoryOwnership', (_message.Message,), dict(
+
+  LinesEntry = _reflection.GeneratedProtocolMessageType('LinesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DIRECTORYOWNERSHIP_LINESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DirectoryOwnership.LinesEntry)
+    ))
+  ,
+  DESCRIPTOR = _DIRECTORYOWNERSHIP,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DirectoryOwnership)
+  ))
+_sym_db.RegisterMessage(DirectoryOwnership)
+_sym_db.RegisterMessage(DirectoryOwnership.LinesEntry)
+
+TickDirectoryOwnership = _reflection.GeneratedProtocolMessageType('TickDirectoryOwnership', (_message.Message,), dict(
+
+  DirectoriesEntry = _reflection.GeneratedProtocolMessageType('DirectoriesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _TICKDIRECTORYOWNERSHIP_DIRECTORIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:TickDirectoryOwnership.DirectoriesEntry)
+    ))
+  ,
+  DESCRIPTOR = _TICKDIRECTORYOWNERSHIP,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TickDirectoryOwnership)
+  ))
+_sym_db.RegisterMessage(TickDirectoryOwnership)
+_sym_db.RegisterMessage(TickDirectoryOwnership.DirectoriesEntry)
+
+DirectoryOwnershipAnalysisResults = _reflection.GeneratedProtocolMessageType('DirectoryOwnershipAnalysisResults', (_message.Message,), dict(
+
+  TicksEntry = _reflection.GeneratedProtocolMessageType('TicksEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DIRECTORYOWNERSHIPANALYSISRESULTS_TICKSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DirectoryOwnershipAnalysisResults.TicksEntry)
+    ))
+  ,
+  DESCRIPTOR = _DIRECTORYOWNERSHIPANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DirectoryOwnershipAnalysisResults)
+  ))
+_sym_db.RegisterMessage(DirectoryOwnershipAnalysisResults)
+_sym_db.RegisterMessage(DirectoryOwnershipAnalysisResults.TicksEntry)
+
+CodeAgeAnalysisResults = _reflection.GeneratedProtocolMessageType('CodeAgeAnalysisResults', (_message.Message,), dict(
+
+  AgesEntry = _reflection.GeneratedProtocolMessageType('AgesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _CODEAGEANALYSISRESULTS_AGESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CodeAgeAnalysisResults.AgesEntry)
+    ))
+  ,
+
+  FileMediansEntry = _reflection.GeneratedProtocolMessageType('FileMediansEntry', (_message.Message,), dict(
+    DESCRIPTOR = _CODEAGEANALYSISRESULTS_FILEMEDIANSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CodeAgeAnalysisResults.FileMediansEntry)
+    ))
+  ,
+  DESCRIPTOR = _CODEAGEANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CodeAgeAnalysisResults)
+  ))
+_sym_db.RegisterMessage(CodeAgeAnalysisResults)
+_sym_db.RegisterMessage(CodeAgeAnalysisResults.AgesEntry)
+_sym_db.RegisterMessage(CodeAgeAnalysisResults.FileMediansEntry)
+
+TestRatioAnalysisResults = _reflection.GeneratedProtocolMessageType('TestRatioAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _TESTRATIOANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TestRatioAnalysisResults)
+  ))
+_sym_db.RegisterMessage(TestRatioAnalysisResults)
+
+CommentRatioSeries = _reflection.GeneratedProtocolMessageType('CommentRatioSeries', (_message.Message,), dict(
+  DESCRIPTOR = _COMMENTRATIOSERIES,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommentRatioSeries)
+  ))
+_sym_db.RegisterMessage(CommentRatioSeries)
+
+CommentRatioAnalysisResults = _reflection.GeneratedProtocolMessageType('CommentRatioAnalysisResults', (_message.Message,), dict(
+
+  LanguagesEntry = _reflection.GeneratedProtocolMessageType('LanguagesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMMENTRATIOANALYSISRESULTS_LANGUAGESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CommentRatioAnalysisResults.LanguagesEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMMENTRATIOANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommentRatioAnalysisResults)
+  ))
+_sym_db.RegisterMessage(CommentRatioAnalysisResults)
+_sym_db.RegisterMessage(CommentRatioAnalysisResults.LanguagesEntry)
+
+LeadTimeHistogram = _reflection.GeneratedProtocolMessageType('LeadTimeHistogram', (_message.Message,), dict(
+
+  DelaysEntry = _reflection.GeneratedProtocolMessageType('DelaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _LEADTIMEHISTOGRAM_DELAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:LeadTimeHistogram.DelaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _LEADTIMEHISTOGRAM,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LeadTimeHistogram)
+  ))
+_sym_db.RegisterMessage(LeadTimeHistogram)
+_sym_db.RegisterMessage(LeadTimeHistogram.DelaysEntry)
+
+LeadTimeAnalysisResults = _reflection.GeneratedProtocolMessageType('LeadTimeAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _LEADTIMEANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LeadTimeAnalysisResults)
+  ))
+_sym_db.RegisterMessage(LeadTimeAnalysisResults)
+
+Resurrection = _reflection.GeneratedProtocolMessageType('Resurrection', (_message.Message,), dict(
+  DESCRIPTOR = _RESURRECTION,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Resurrection)
+  ))
+_sym_db.RegisterMessage(Resurrection)
+
+ResurrectionAnalysisResults = _reflection.GeneratedProtocolMessageType('ResurrectionAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _RESURRECTIONANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ResurrectionAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ResurrectionAnalysisResults)
+
+OwnershipTransfer = _reflection.GeneratedProtocolMessageType('OwnershipTransfer', (_message.Message,), dict(
+  DESCRIPTOR = _OWNERSHIPTRANSFER,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:OwnershipTransfer)
+  ))
+_sym_db.RegisterMessage(OwnershipTransfer)
+
+OwnershipTransferAnalysisResults = _reflection.GeneratedProtocolMessageType('OwnershipTransferAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _OWNERSHIPTRANSFERANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:OwnershipTransferAnalysisResults)
+  ))
+_sym_db.RegisterMessage(OwnershipTransferAnalysisResults)
+
+RefactorEvent = _reflection.GeneratedProtocolMessageType('RefactorEvent', (_message.Message,), dict(
+  DESCRIPTOR = _REFACTOREVENT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RefactorEvent)
+  ))
+_sym_db.RegisterMessage(RefactorEvent)
+
+RefactorDetectionAnalysisResults = _reflection.GeneratedProtocolMessageType('RefactorDetectionAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _REFACTORDETECTIONANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RefactorDetectionAnalysisResults)
+  ))
+_sym_db.RegisterMessage(RefactorDetectionAnalysisResults)
+
+ImportCouplingAnalysisResults = _reflection.GeneratedProtocolMessageType('ImportCouplingAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _IMPORTCOUPLINGANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ImportCouplingAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ImportCouplingAnalysisResults)
+
+LineEvent = _reflection.GeneratedProtocolMessageType('LineEvent', (_message.Message,), dict(
+  DESCRIPTOR = _LINEEVENT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LineEvent)
+  ))
+_sym_db.RegisterMessage(LineEvent)
+
+LineEventsAnalysisResults = _reflection.GeneratedProtocolMessageType('LineEventsAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _LINEEVENTSANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LineEventsAnalysisResults)
+  ))
+_sym_db.RegisterMessage(LineEventsAnalysisResults)
+
 AnalysisResults = _reflection.GeneratedProtocolMessageType('AnalysisResults', (_message.Message,), dict(
 
   ContentsEntry = _reflection.GeneratedProtocolMessageType('ContentsEntry', (_message.Message,), dict(
@@ -2253,10 +5196,13 @@ _sym_db.RegisterMessage(AnalysisResults.ContentsEntry)
 
 
 _METADATA_RUNTIMEPERITEMENTRY._options = None
+_METADATA_CONFIGENTRY._options = None
 _FILESOWNERSHIP_VALUEENTRY._options = None
 _SHOTNESSRECORD_COUNTERSENTRY._options = None
 _FILEHISTORY_CHANGESBYDEVELOPERENTRY._options = None
 _FILEHISTORYRESULTMESSAGE_FILESENTRY._options = None
+_FILEGENESISRESULTS_FILESENTRY._options = None
+_COMMITSIZEANALYSISRESULTS_TICKSENTRY._options = None
 _DEVTICK_LANGUAGESENTRY._options = None
 _TICKDEVS_DEVSENTRY._options = None
 _DEVSANALYSISRESULTS_TICKSENTRY._options = None
@@ -2264,5 +5210,18 @@ _COMMENTSENTIMENTRESULTS_SENTIMENTBYTICKENTRY._options = None
 _IMPORTSPERTICK_COUNTSENTRY._options = None
 _IMPORTSPERLANGUAGE_TICKSENTRY._options = None
 _IMPORTSPERDEVELOPER_LANGUAGESENTRY._options = None
+_DEVCADENCEANALYSISRESULTS_DEVELOPERSENTRY._options = None
+_DEVFOCUSTIMELINE_TICKSENTRY._options = None
+_DEVFOCUSANALYSISRESULTS_DEVELOPERSENTRY._options = None
+_DEVMERGERATIOTICKS_TICKSENTRY._options = None
+_DEVMERGERATIOANALYSISRESULTS_DEVELOPERSENTRY._options = None
+_PUNCHCARDANALYSISRESULTS_DEVELOPERSENTRY._options = None
+_DIRECTORYOWNERSHIP_LINESENTRY._options = None
+_TICKDIRECTORYOWNERSHIP_DIRECTORIESENTRY._options = None
+_DIRECTORYOWNERSHIPANALYSISRESULTS_TICKSENTRY._options = None
+_CODEAGEANALYSISRESULTS_AGESENTRY._options = None
+_CODEAGEANALYSISRESULTS_FILEMEDIANSENTRY._options = None
+_COMMENTRATIOANALYSISRESULTS_LANGUAGESENTRY._options = None
+_LEADTIMEHISTOGRAM_DELAYSENTRY._options = None
 _ANALYSISRESULTS_CONTENTSENTRY._options = None
 # @@protoc_insertion_point(module_scope)
@@ -1,4 +1,5 @@
 from argparse import Namespace
+import gzip
 from importlib import import_module
 import io
 import re
@@ -228,6 +229,9 @@ class ProtobufReader(Reader):
         all_bytes = fileobj.read()
         if not all_bytes:
             raise ValueError("empty input")
+        if all_bytes[:2] == b"\x1f\x8b":
+            # hercules --pb --gzip
+            all_bytes = gzip.decompress(all_bytes)
         self.data.ParseFromString(all_bytes)
         self.contents = {}
         for key, val in self.data.contents.items():